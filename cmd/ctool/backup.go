@@ -23,6 +23,7 @@ import (
 var (
 	expireTime           string
 	jsonFormatBackupList bool
+	systemToken          string
 )
 
 // nolint
@@ -101,6 +102,8 @@ func newBackupCmd() *cobra.Command {
 		RunE: backupNow,
 	}
 
+	backupWorkspaceCmd := newWorkspaceBackupCmd("workspace <app> <wsid> <archive name>", "Logical backup of the application workspace to the archive", backupWorkspace)
+
 	backupCmd := &cobra.Command{
 		Use:   "backup",
 		Short: "Backup the database",
@@ -109,12 +112,67 @@ func newBackupCmd() *cobra.Command {
 	if c.Edition != clusterEditionN1 && !addSshKeyFlag(backupCmd) {
 		return nil
 	}
-	backupCmd.AddCommand(backupNodeCmd, backupCronCmd, backupListCmd, backupNowCmd)
+	backupCmd.AddCommand(backupNodeCmd, backupCronCmd, backupListCmd, backupNowCmd, backupWorkspaceCmd)
 
 	return backupCmd
 
 }
 
+func newWorkspaceBackupCmd(use string, short string, runE func(cmd *cobra.Command, args []string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return ErrInvalidNumberOfArguments
+			}
+			if wsid, err := strconv.ParseInt(args[1], 10, 64); err != nil || wsid <= 0 {
+				return ErrInvalidWSID
+			}
+			if !filepath.IsLocal(args[2]) {
+				return ErrInvalidArchiveName
+			}
+			return nil
+		},
+		RunE: runE,
+	}
+	cmd.PersistentFlags().StringVarP(&systemToken, "token", "t", "", "System token to call the admin endpoint")
+	_ = cmd.MarkPersistentFlagRequired("token")
+	return cmd
+}
+
+func backupWorkspace(cmd *cobra.Command, args []string) error {
+	currentCmd = cmd
+	cluster := newCluster()
+	if cluster.Draft {
+		return ErrClusterConfNotFound
+	}
+
+	if err := mkCommandDirAndLogFile(cmd, cluster); err != nil {
+		return err
+	}
+
+	if err := runWorkspaceBackupScript(cluster, "BackupWorkspace", args); err != nil {
+		return err
+	}
+	loggerInfoGreen("Workspace", args[1], "of", args[0], "backed up successfully")
+	return nil
+}
+
+// The archive is written and read by the voedger service, so the archive path is relative to the workspace backup path of the app node
+func runWorkspaceBackupScript(cluster *clusterType, command string, args []string) error {
+	archivePath := args[2]
+
+	scriptArgs := []string{command, args[0], args[1], archivePath, systemToken}
+	if cluster.Edition != clusterEditionN1 {
+		seConf := newSeConfigType(cluster)
+		scriptArgs = append(scriptArgs, seConf.AppNode1, seConf.AppNode2)
+	}
+
+	loggerInfo(command, args[0], args[1], archivePath)
+	return newScriptExecuter(cluster.sshKey, "").run("ws-backup.sh", scriptArgs...)
+}
+
 type expireType struct {
 	value int
 	unit  string
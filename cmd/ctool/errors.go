@@ -78,6 +78,10 @@ var ErrInvalidExpireTime = errors.New("invalid expire time")
 
 var ErrBackupNotExist = errors.New("backup does not exist")
 
+var ErrInvalidWSID = errors.New("invalid WSID")
+
+var ErrInvalidArchiveName = errors.New("archive name must be relative to the workspace backup path")
+
 const errBackupNotExistOnHost = "backup %s does not exist on host %s: %w"
 
 var ErrMonPasswordIsTooShort = fmt.Errorf("password must be at least %d characters long", minMonPasswordLength)
@@ -28,6 +28,9 @@ func newRestoreCmd() *cobra.Command {
 		RunE: restore,
 	}
 
+	restoreWorkspaceCmd := newWorkspaceBackupCmd("workspace <app> <wsid> <archive name>", "Restore the application workspace in place from its own archive or import the archive into the empty workspace", restoreWorkspace)
+	restoreCmd.AddCommand(restoreWorkspaceCmd)
+
 	if newCluster().Edition != clusterEditionN1 && !addSshKeyFlag(restoreCmd) {
 		return nil
 	}
@@ -119,3 +122,21 @@ func backupExists(cluster *clusterType, backupPath string) error {
 
 	return err
 }
+
+func restoreWorkspace(cmd *cobra.Command, args []string) error {
+	currentCmd = cmd
+	cluster := newCluster()
+	if cluster.Draft {
+		return ErrClusterConfNotFound
+	}
+
+	if err := mkCommandDirAndLogFile(cmd, cluster); err != nil {
+		return err
+	}
+
+	if err := runWorkspaceBackupScript(cluster, "RestoreWorkspace", args); err != nil {
+		return err
+	}
+	loggerInfoGreen("Workspace", args[1], "of", args[0], "restored successfully")
+	return nil
+}
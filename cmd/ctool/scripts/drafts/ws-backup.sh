@@ -0,0 +1,61 @@
+#!/usr/bin/env bash
+#
+# Copyright (c) 2025-present unTill Software Development Group B.V.
+#
+# Logical backup or restore of the application workspace using the admin endpoint of the voedger node
+# Usage: ./ws-backup.sh <BackupWorkspace | RestoreWorkspace> <app> <wsid> <archive path> <system token> [<app node>...]
+# The command is executed on the local host if app nodes are not provided
+
+set -euo pipefail
+
+if [[ $# -lt 5 ]]; then
+  echo "Usage: $0 <BackupWorkspace | RestoreWorkspace> <app> <wsid> <archive path> <system token> [<app node>...]"
+  exit 1
+fi
+
+source ./utils.sh
+
+readonly command=$1
+readonly app=$2
+readonly wsid=$3
+readonly archivePath=$4
+readonly token=$5
+shift 5
+
+# see ClusterAppPseudoWSID in pkg/vvm/builtin/clusterapp
+readonly clusterAppPseudoWSID=140737488355328
+readonly url="http://127.0.0.1:55555/api/sys/cluster/${clusterAppPseudoWSID}/c.cluster.${command}"
+readonly body="{\"args\":{\"AppQName\":\"${app}\",\"WSID\":${wsid},\"FilePath\":\"${archivePath}\"}}"
+readonly request="curl -s -w '\n%{http_code}' -X POST -H 'Authorization: Bearer ${token}' -d '${body}' ${url}"
+
+run_request() {
+  local resp
+  if [[ $# -eq 0 ]]; then
+    resp=$(eval "${request}")
+  else
+    resp=$(utils_ssh "$LOGNAME@$1" "${request}")
+  fi
+  local code
+  code=$(echo "$resp" | tail -n 1)
+  echo "$resp" | sed '$d'
+  if [[ "$code" != "200" ]]; then
+    echo "${command} failed with HTTP status ${code}"
+    exit 1
+  fi
+}
+
+if [[ $# -eq 0 ]]; then
+  run_request
+  exit 0
+fi
+
+# voedger service is run on one of the app nodes
+for node in "$@"; do
+  if [[ -n $(utils_ssh "$LOGNAME@$node" "docker ps -q --filter name=voedger") ]]; then
+    run_request "$node"
+    exit 0
+  fi
+done
+
+echo "voedger service is not found on app nodes: $*"
+exit 1
@@ -5,11 +5,21 @@
 
 package main
 
+import "github.com/voedger/voedger/pkg/appdef"
+
 const (
 	SPTypeCommand = "command"
 	SPTypeDocker  = "docker"
 )
 
+const (
+	storageTypePebble = "pebble"
+	storageTypeBbolt  = "bbolt"
+)
+
+// SPs are kept in the storage of this app
+var edgerAppQName = appdef.NewAppQName(appdef.SysOwner, "edger")
+
 const (
 	NumCommandControllerRoutines = 5
 	NumDockerControllerRoutines  = 5
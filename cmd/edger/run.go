@@ -27,10 +27,17 @@ type InputControlMessage struct {
 var inputStreamReadingInterval time.Duration = 0
 
 func newRunEdgerCmd() *cobra.Command {
+	storageParams := StorageParams{}
 	cmd := cobra.Command{
 		Use:   "run",
 		Short: "Runs edger and processes SP values from stdin",
-		Run: func(c *cobra.Command, args []string) {
+		RunE: func(c *cobra.Command, args []string) error {
+			store, cleanup, err := newSPStore(storageParams)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
 			commandInCh := make(chan ctrlloop.ControlMessage[string, CommandSP])
 			commandCtrlloopWaitFunc := ctrlloop.New(CommandController, CommandReporter, NumCommandControllerRoutines, commandInCh, time.Now)
 			defer commandCtrlloopWaitFunc()
@@ -41,14 +48,28 @@ func newRunEdgerCmd() *cobra.Command {
 			defer dockerCtrlloopWaitFunc()
 			defer close(dockerInCh)
 
-			runEdger(c.Context(), os.Stdin, commandInCh, dockerInCh)
+			return runEdger(c.Context(), os.Stdin, store, commandInCh, dockerInCh)
 		},
 	}
+	cmd.Flags().StringVar(&storageParams.Storage, "storage", storageTypePebble, "storage to keep the received SPs in: pebble, bbolt")
+	cmd.Flags().StringVar(&storageParams.DBDir, "db-dir", "", "database directory, SPs are not kept if empty")
 
 	return &cmd
 }
 
-func runEdger(ctx context.Context, r io.Reader, commandInCh chan ctrlloop.ControlMessage[string, CommandSP], dockerInCh chan ctrlloop.ControlMessage[string, DockerSP]) {
+// SPs kept in the store are sent to the controllers first
+// store could be nil
+func runEdger(ctx context.Context, r io.Reader, store *spStore, commandInCh chan ctrlloop.ControlMessage[string, CommandSP], dockerInCh chan ctrlloop.ControlMessage[string, DockerSP]) error {
+	if store != nil {
+		for _, spType := range []string{SPTypeCommand, SPTypeDocker} {
+			if err := store.read(ctx, spType, func(input InputControlMessage) {
+				sendControlMessage(input, commandInCh, dockerInCh)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
 	decoder := json.NewDecoder(r)
 
 	var decodingErr error
@@ -62,22 +83,35 @@ func runEdger(ctx context.Context, r io.Reader, commandInCh chan ctrlloop.Contro
 			continue
 		}
 
-		switch input.Type {
-		case SPTypeCommand:
-			m := getControlMessage[string, CommandSP](input)
-			if m != nil {
-				commandInCh <- *m
-			}
-		case SPTypeDocker:
-			m := getControlMessage[string, DockerSP](input)
-			if m != nil {
-				dockerInCh <- *m
+		if key, ok := sendControlMessage(input, commandInCh, dockerInCh); ok && store != nil {
+			if err := store.put(input, key); err != nil {
+				return err
 			}
-		default:
-			logger.Verbose("unknown sp type: " + input.Type)
 		}
 		time.Sleep(inputStreamReadingInterval)
 	}
+	return nil
+}
+
+// returns the key of the sent message, false if the message is not sent
+func sendControlMessage(input InputControlMessage, commandInCh chan ctrlloop.ControlMessage[string, CommandSP], dockerInCh chan ctrlloop.ControlMessage[string, DockerSP]) (key string, ok bool) {
+	switch input.Type {
+	case SPTypeCommand:
+		m := getControlMessage[string, CommandSP](input)
+		if m != nil {
+			commandInCh <- *m
+			return m.Key, true
+		}
+	case SPTypeDocker:
+		m := getControlMessage[string, DockerSP](input)
+		if m != nil {
+			dockerInCh <- *m
+			return m.Key, true
+		}
+	default:
+		logger.Verbose("unknown sp type: " + input.Type)
+	}
+	return "", false
 }
 
 func decodeControlMessage[Key comparable, SP any](input InputControlMessage) *ctrlloop.ControlMessage[Key, SP] {
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
+	"github.com/voedger/voedger/pkg/istorage/pebble"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+)
+
+// spStore keeps the last SP received for each controller key, SPs are replayed to the controllers on the next start
+type spStore struct {
+	storage istorage.IAppStorage
+}
+
+// returns nil store if params.DBDir is empty
+func newSPStore(params StorageParams) (store *spStore, cleanup func(), err error) {
+	if len(params.DBDir) == 0 {
+		return nil, func() {}, nil
+	}
+	var factory istorage.IAppStorageFactory
+	switch params.Storage {
+	case storageTypePebble:
+		factory = pebble.Provide(pebble.ParamsType{DBDir: params.DBDir}, timeu.NewITime())
+	case storageTypeBbolt:
+		factory = bbolt.Provide(bbolt.ParamsType{DBDir: params.DBDir}, timeu.NewITime())
+	default:
+		return nil, nil, fmt.Errorf("unknown storage %q, must be %s or %s", params.Storage, storageTypePebble, storageTypeBbolt)
+	}
+	if err := os.MkdirAll(params.DBDir, coreutils.FileMode_rwxrwxrwx); err != nil {
+		return nil, nil, err
+	}
+	storageProvider := provider.Provide(factory)
+	storage, err := storageProvider.AppStorage(edgerAppQName)
+	if err != nil {
+		storageProvider.Stop()
+		return nil, nil, err
+	}
+	return &spStore{storage: storage}, storageProvider.Stop, nil
+}
+
+func (s *spStore) put(input InputControlMessage, key string) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		// notest
+		return err
+	}
+	return s.storage.Put([]byte(input.Type), []byte(key), data)
+}
+
+func (s *spStore) read(ctx context.Context, spType string, cb func(input InputControlMessage)) error {
+	return s.storage.Read(ctx, []byte(spType), nil, nil, func(_ []byte, data []byte) error {
+		var input InputControlMessage
+		if err := json.Unmarshal(data, &input); err != nil {
+			return err
+		}
+		cb(input)
+		return nil
+	})
+}
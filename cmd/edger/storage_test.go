@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/ctrlloop"
+)
+
+func TestSPStore(t *testing.T) {
+	for _, storageType := range []string{storageTypePebble, storageTypeBbolt} {
+		t.Run(storageType, func(t *testing.T) {
+			require := require.New(t)
+			params := StorageParams{Storage: storageType, DBDir: t.TempDir()}
+
+			input := InputControlMessage{
+				Type:  SPTypeCommand,
+				Value: json.RawMessage(`{"Key":"cmd1","SP":{"Cmd":"echo","Args":["hello"]}}`),
+			}
+			inputJSON, err := json.Marshal(input)
+			require.NoError(err)
+
+			store, cleanup, err := newSPStore(params)
+			require.NoError(err)
+			defer cleanup()
+
+			// the received SP is kept
+			commandInCh := make(chan ctrlloop.ControlMessage[string, CommandSP], 1)
+			dockerInCh := make(chan ctrlloop.ControlMessage[string, DockerSP], 1)
+			require.NoError(runEdger(context.Background(), strings.NewReader(string(inputJSON)), store, commandInCh, dockerInCh))
+			require.Equal("cmd1", (<-commandInCh).Key)
+
+			// the kept SP is replayed on the next run
+			require.NoError(runEdger(context.Background(), strings.NewReader(""), store, commandInCh, dockerInCh))
+			m := <-commandInCh
+			require.Equal("cmd1", m.Key)
+			require.Equal("echo", m.SP.Cmd)
+			require.Equal([]string{"hello"}, m.SP.Args)
+			require.Empty(dockerInCh)
+		})
+	}
+
+	t.Run("unknown storage", func(t *testing.T) {
+		_, _, err := newSPStore(StorageParams{Storage: "unknown", DBDir: t.TempDir()})
+		require.Error(t, err)
+	})
+
+	t.Run("no db dir -> nil store", func(t *testing.T) {
+		store, cleanup, err := newSPStore(StorageParams{Storage: storageTypePebble})
+		require.NoError(t, err)
+		require.Nil(t, store)
+		cleanup()
+	})
+}
@@ -7,6 +7,11 @@ package main
 
 import "time"
 
+type StorageParams struct {
+	Storage string
+	DBDir   string // empty -> SPs are not kept
+}
+
 // Command related types
 type (
 	CommandSP struct {
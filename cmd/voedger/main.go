@@ -29,6 +29,7 @@ func execRootCmd(args []string, ver string) error {
 		args,
 		ver,
 		newServerCmd(),
+		newMigrateCmd(),
 	)
 
 	return cobrau.ExecCommandAndCatchInterrupt(rootCmd)
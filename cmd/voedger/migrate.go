@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	voedger "github.com/voedger/voedger/cmd/voedger/voedgerimpl"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/istorage/migration"
+)
+
+func newMigrateCmd() *cobra.Command {
+	var from, to voedger.CLIParams
+	var params migration.Params
+	migrateCmd := &cobra.Command{
+		Use:   "migrate <app>",
+		Short: "Copy the application storage from one storage to another, e.g. voedger migrate untill/airs-bp --from-storage bbolt --from-db-dir ./data --to-storage cas3",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			appQName, err := appdef.ParseAppQName(args[0])
+			if err != nil {
+				return err
+			}
+			params.OnPartition = func(_ []byte, res migration.Result) {
+				if res.Partitions%migration.DefaultCheckpointEvery == 0 {
+					logger.Info(fmt.Sprintf("%s: %d partitions, %d records copied", appQName, res.Partitions, res.Records))
+				}
+			}
+			res, err := voedger.MigrateApp(cmd.Context(), appQName, from, to, params)
+			if err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("%s: done, %d partitions, %d records (%d with TTL), %d partitions caught up, checksum %s", appQName, res.Partitions, res.Records, res.TTLRecords, res.CaughtUp, res.Checksum))
+			return nil
+		},
+	}
+	migrateCmd.Flags().StringVar(&from.Storage, "from-storage", "", "source storage: cas1, cas3, pg, bbolt, pebble")
+	migrateCmd.Flags().StringVar(&from.PgConnString, "from-pg-conn", "", "PostgreSQL connection string for the source pg storage, ISTORAGEPG_CONNSTRING env var is used if empty")
+	migrateCmd.Flags().StringVar(&from.DBDir, "from-db-dir", "", "database directory for the source bbolt and pebble storages")
+	migrateCmd.Flags().BoolVar(&from.EncryptStorage, "from-encrypt-storage", false, "the source storage is encrypted, see --encrypt-storage of the server command")
+	migrateCmd.Flags().BoolVar(&from.EncryptCCols, "from-encrypt-ccols", false, "clustering columns of the source storage are encrypted, see --encrypt-ccols of the server command")
+	migrateCmd.Flags().StringVar(&to.Storage, "to-storage", "", "target storage: cas1, cas3, pg, bbolt, pebble")
+	migrateCmd.Flags().StringVar(&to.PgConnString, "to-pg-conn", "", "PostgreSQL connection string for the target pg storage, ISTORAGEPG_CONNSTRING env var is used if empty")
+	migrateCmd.Flags().StringVar(&to.DBDir, "to-db-dir", "", "database directory for the target bbolt and pebble storages")
+	migrateCmd.Flags().BoolVar(&to.EncryptStorage, "to-encrypt-storage", false, "the target storage is encrypted, see --encrypt-storage of the server command")
+	migrateCmd.Flags().BoolVar(&to.EncryptCCols, "to-encrypt-ccols", false, "clustering columns of the target storage are encrypted, see --encrypt-ccols of the server command")
+	migrateCmd.Flags().StringVar(&params.CheckpointFile, "checkpoint", "", "checkpoint file, the migration is continued from the checkpoint if the file exists")
+	migrateCmd.Flags().IntVar(&params.CatchUpPasses, "catch-up-passes", migration.DefaultCatchUpPasses, "max passes over the source storage to copy partitions changed during the migration, negative to disable")
+	migrateCmd.Flags().BoolVar(&params.Verify, "verify", true, "verify checksum of each copied partition and that all changes of the source storage are caught up")
+	migrateCmd.Flags().IntVar(&params.BatchSize, "batch-size", migration.DefaultBatchSize, "max records read from the source storage at once and written to the target storage by one batch")
+	return migrateCmd
+}
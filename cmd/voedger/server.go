@@ -40,7 +40,11 @@ func newServerCmd() *cobra.Command {
 		},
 	}
 	serverCmd.PersistentFlags().IntVar(&httpCLIParams.Port, "ihttp.Port", Default_ihttp_Port, "")
-	serverCmd.Flags().StringVar(&appsCLIParams.Storage, "storage", "", "")
+	serverCmd.Flags().StringVar(&appsCLIParams.Storage, "storage", "", "storage: cas1, cas3 (default), mem, pg, bbolt, pebble")
+	serverCmd.Flags().StringVar(&appsCLIParams.PgConnString, "pg-conn", "", "PostgreSQL connection string for the pg storage, ISTORAGEPG_CONNSTRING env var is used if empty")
+	serverCmd.Flags().StringVar(&appsCLIParams.DBDir, "db-dir", "", "database directory for the bbolt and pebble storages")
+	serverCmd.Flags().BoolVar(&appsCLIParams.EncryptStorage, "encrypt-storage", false, "encrypt values of the application storages by AES-GCM, the key ring of each application is read from the storage-key-<app> secret in /run/secrets or $SECRET_ROOT")
+	serverCmd.Flags().BoolVar(&appsCLIParams.EncryptCCols, "encrypt-ccols", false, "encrypt clustering columns as well, used with --encrypt-storage, the key 0 of the key ring is used. Each read then loads the whole partition into memory")
 	serverCmd.Flags().StringArrayVar((*[]string)(&httpCLIParams.AcmeDomains), "acme-domain", []string{}, "")
 	return serverCmd
 }
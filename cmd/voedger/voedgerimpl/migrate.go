@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package voedger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istorage/migration"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+)
+
+// MigrateApp copies the app storage from one storage to another
+// SafeAppName of the app in the target storage is obtained from the target sysmeta, so it could differ from the source one
+func MigrateApp(ctx context.Context, appQName appdef.AppQName, from, to CLIParams, params migration.Params) (res migration.Result, err error) {
+	if from == to {
+		return res, errors.New("source and target storages are the same")
+	}
+	srcFactory, err := NewAppStorageFactory(from)
+	if err != nil {
+		return res, err
+	}
+	dstFactory, err := NewAppStorageFactory(to)
+	if err != nil {
+		return res, err
+	}
+
+	srcProvider := provider.Provide(srcFactory)
+	defer srcProvider.Stop()
+	dstProvider := provider.Provide(dstFactory)
+	defer dstProvider.Stop()
+
+	src, err := srcProvider.AppStorage(appQName)
+	if err != nil {
+		return res, err
+	}
+	dst, err := dstProvider.AppStorage(appQName)
+	if err != nil {
+		return res, err
+	}
+	return migration.Migrate(ctx, src, dst, params)
+}
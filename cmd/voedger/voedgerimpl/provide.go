@@ -11,15 +11,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 
 	sysmonitor "github.com/voedger/voedger/cmd/voedger/sys.monitor"
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/ihttpctl"
+	"github.com/voedger/voedger/pkg/isecretsimpl"
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
 	"github.com/voedger/voedger/pkg/istorage/cas"
+	"github.com/voedger/voedger/pkg/istorage/encryption"
 	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/pebble"
+	"github.com/voedger/voedger/pkg/istorage/pg"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -41,6 +48,14 @@ func NewDefaultRedirectionRoute() ihttpctl.DefaultRedirectRoute {
 }
 
 func NewAppStorageFactory(params CLIParams) (istorage.IAppStorageFactory, error) {
+	factory, err := newDriverStorageFactory(params)
+	if err != nil || !params.EncryptStorage {
+		return factory, err
+	}
+	return encryption.Provide(factory, isecretsimpl.ProvideSecretReader(), encryption.Params{EncryptCCols: params.EncryptCCols}), nil
+}
+
+func newDriverStorageFactory(params CLIParams) (istorage.IAppStorageFactory, error) {
 	if len(params.Storage) == 0 {
 		params.Storage = storageTypeCas3
 	}
@@ -54,6 +69,23 @@ func NewAppStorageFactory(params CLIParams) (istorage.IAppStorageFactory, error)
 		casParams.KeyspaceWithReplication = cas3ReplicationStrategy
 	case storageTypeMem:
 		return mem.Provide(testingu.MockTime), nil
+	case storageTypePg:
+		pgParams := defaultPgParams
+		if connString, ok := os.LookupEnv(pg.ConnStringEnv); ok {
+			pgParams.ConnString = connString
+		}
+		if len(params.PgConnString) > 0 {
+			pgParams.ConnString = params.PgConnString
+		}
+		return pg.Provide(pgParams, timeu.NewITime())
+	case storageTypeBbolt, storageTypePebble:
+		if len(params.DBDir) == 0 {
+			return nil, fmt.Errorf("db dir must be specified for %s storage", params.Storage)
+		}
+		if params.Storage == storageTypeBbolt {
+			return bbolt.Provide(bbolt.ParamsType{DBDir: params.DBDir}, timeu.NewITime()), nil
+		}
+		return pebble.Provide(pebble.ParamsType{DBDir: params.DBDir}, timeu.NewITime()), nil
 	default:
 		return nil, errors.New("unable to define replication strategy")
 	}
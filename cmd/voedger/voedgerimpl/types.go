@@ -7,4 +7,17 @@ package voedger
 
 type CLIParams struct {
 	Storage string
+
+	// used if Storage is "pg", pg.ConnStringEnv env var or defaultPgParams.ConnString is used if empty
+	PgConnString string
+
+	// used if Storage is "bbolt" or "pebble"
+	DBDir string
+
+	// storage values are encrypted by the application keys read from secrets, see pkg/istorage/encryption
+	EncryptStorage bool
+
+	// clustering columns are encrypted as well, used if EncryptStorage
+	// each read then loads the whole partition into memory, see encryption.Params.EncryptCCols
+	EncryptCCols bool
 }
@@ -106,6 +106,36 @@ func MaxExcl(v float64, c ...string) appdef.IConstraint {
 	return datas.NewDataConstraint(appdef.ConstraintKind_MaxExcl, v, c...)
 }
 
+// Return new precision constraint for decimal data types.
+//
+// Precision is the total number of significant digits.
+//
+// # Panics:
+//   - if value is zero,
+//   - if value is greater than MaxDecimalPrecision (18)
+func Precision(v uint8, c ...string) appdef.IConstraint {
+	if v == 0 {
+		panic(appdef.ErrOutOfBounds("decimal precision value is zero"))
+	}
+	if v > appdef.MaxDecimalPrecision {
+		panic(appdef.ErrOutOfBounds("decimal precision value %d, maximum is %d", v, appdef.MaxDecimalPrecision))
+	}
+	return datas.NewDataConstraint(appdef.ConstraintKind_Precision, v, c...)
+}
+
+// Return new scale constraint for decimal data types.
+//
+// Scale is the number of digits to the right of the decimal point.
+//
+// # Panics:
+//   - if value is greater than MaxDecimalPrecision (18)
+func Scale(v uint8, c ...string) appdef.IConstraint {
+	if v > appdef.MaxDecimalPrecision {
+		panic(appdef.ErrOutOfBounds("decimal scale value %d, maximum is %d", v, appdef.MaxDecimalPrecision))
+	}
+	return datas.NewDataConstraint(appdef.ConstraintKind_Scale, v, c...)
+}
+
 // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
 type enumerable interface {
 	string | int8 | int16 | int32 | int64 | float32 | float64
@@ -163,6 +193,10 @@ func NewConstraint(kind appdef.ConstraintKind, value any, c ...string) appdef.IC
 		return MaxIncl(value.(float64), c...)
 	case appdef.ConstraintKind_MaxExcl:
 		return MaxExcl(value.(float64), c...)
+	case appdef.ConstraintKind_Precision:
+		return Precision(value.(uint8), c...)
+	case appdef.ConstraintKind_Scale:
+		return Scale(value.(uint8), c...)
 	case appdef.ConstraintKind_Enum:
 		var enum appdef.IConstraint
 		switch v := value.(type) {
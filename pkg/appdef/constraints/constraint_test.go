@@ -74,6 +74,14 @@ func TestNewConstraint(t *testing.T) {
 			args{appdef.ConstraintKind_Enum, []float64{3, 1, 2, 2, 3}, []string{"test float64 enum"}},
 			[]float64{1, 2, 3},
 		},
+		{"Precision",
+			args{appdef.ConstraintKind_Precision, uint8(10), []string{"test precision"}},
+			10,
+		},
+		{"Scale",
+			args{appdef.ConstraintKind_Scale, uint8(2), []string{"test scale"}},
+			2,
+		},
 	}
 	require := require.New(t)
 	for _, tt := range tests {
@@ -136,6 +144,15 @@ func TestNewConstraintPanics(t *testing.T) {
 		{"Enum([][]byte)",
 			args{appdef.ConstraintKind_Enum, [][]byte{{1, 2, 3}, {4, 5, 6}}}, appdef.ErrUnsupportedError,
 		},
+		{"Precision(0)",
+			args{appdef.ConstraintKind_Precision, uint8(0)}, appdef.ErrOutOfBoundsError,
+		},
+		{"Precision(19)",
+			args{appdef.ConstraintKind_Precision, uint8(19)}, appdef.ErrOutOfBoundsError,
+		},
+		{"Scale(19)",
+			args{appdef.ConstraintKind_Scale, uint8(19)}, appdef.ErrOutOfBoundsError,
+		},
 		{"???(0)",
 			args{appdef.ConstraintKind_count, 0}, appdef.ErrUnsupportedError,
 		},
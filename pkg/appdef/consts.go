@@ -63,17 +63,23 @@ const (
 
 // System data type names
 var (
-	SysData_int8     QName = SysDataName(DataKind_int8)  // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
-	SysData_int16    QName = SysDataName(DataKind_int16) // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
-	SysData_int32    QName = SysDataName(DataKind_int32)
-	SysData_int64    QName = SysDataName(DataKind_int64)
-	SysData_float32  QName = SysDataName(DataKind_float32)
-	SysData_float64  QName = SysDataName(DataKind_float64)
-	SysData_bytes    QName = SysDataName(DataKind_bytes)
-	SysData_String   QName = SysDataName(DataKind_string)
-	SysData_QName    QName = SysDataName(DataKind_QName)
-	SysData_bool     QName = SysDataName(DataKind_bool)
-	SysData_RecordID QName = SysDataName(DataKind_RecordID)
+	SysData_int8      QName = SysDataName(DataKind_int8)  // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
+	SysData_int16     QName = SysDataName(DataKind_int16) // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
+	SysData_int32     QName = SysDataName(DataKind_int32)
+	SysData_int64     QName = SysDataName(DataKind_int64)
+	SysData_float32   QName = SysDataName(DataKind_float32)
+	SysData_float64   QName = SysDataName(DataKind_float64)
+	SysData_bytes     QName = SysDataName(DataKind_bytes)
+	SysData_String    QName = SysDataName(DataKind_string)
+	SysData_QName     QName = SysDataName(DataKind_QName)
+	SysData_bool      QName = SysDataName(DataKind_bool)
+	SysData_RecordID  QName = SysDataName(DataKind_RecordID)
+	SysData_decimal   QName = SysDataName(DataKind_decimal)
+	SysData_timestamp QName = SysDataName(DataKind_timestamp)
+	SysData_date      QName = SysDataName(DataKind_date)
+	SysData_interval  QName = SysDataName(DataKind_interval)
+	SysData_uuid      QName = SysDataName(DataKind_uuid)
+	SysData_json      QName = SysDataName(DataKind_json)
 )
 
 // Maximum containers per one structured type
@@ -85,6 +91,23 @@ const MaxTypeUniqueFieldsCount = 256
 // Maximum uniques
 const MaxTypeUniqueCount = 100
 
+// Maximum fields per one index
+const MaxTypeIndexFieldsCount = 16
+
+// Maximum indexes.
+//
+// Each index produces up to three view intents per record change, so the event with maximum CUDs fits the sync projector intents limit
+const MaxTypeIndexCount = 3
+
+// Maximum decimal precision, total number of digits which can be stored in int64
+const MaxDecimalPrecision = uint8(18)
+
+// Default decimal precision and scale, used if decimal data has no constraints
+const (
+	DefaultDecimalPrecision = MaxDecimalPrecision
+	DefaultDecimalScale     = uint8(0)
+)
+
 // Maximum string and bytes data length
 const MaxFieldLength = uint16(math.MaxUint16)
 
@@ -34,6 +34,32 @@ const (
 	DataKind_Record
 	DataKind_Event
 
+	// Fixed-point decimal number.
+	//
+	// Stored as int64 scaled by 10^scale, precision and scale are
+	// specified by ConstraintKind_Precision and ConstraintKind_Scale constraints.
+	DataKind_decimal
+
+	// Date and time, stored as int64 Unix milliseconds (UTC).
+	// JSON representation is RFC 3339 date-time string, e.g. "2025-01-31T10:20:30.123Z"
+	DataKind_timestamp
+
+	// Date without time, stored as int64 Unix milliseconds of UTC midnight.
+	// JSON representation is RFC 3339 full-date string, e.g. "2025-01-31"
+	DataKind_date
+
+	// Time interval, stored as int64 milliseconds.
+	// JSON representation is ISO 8601 duration string, e.g. "P1DT2H30M"
+	DataKind_interval
+
+	// Universally unique identifier, stored as 16 bytes.
+	// JSON representation is canonical lowercase string, e.g. "123e4567-e89b-12d3-a456-426614174000"
+	DataKind_uuid
+
+	// JSON document, stored as compact JSON text.
+	// JSON representation is embedded JSON value, not a string
+	DataKind_json
+
 	DataKind_FakeLast
 )
 
@@ -106,6 +132,9 @@ const (
 
 	ConstraintKind_Enum
 
+	ConstraintKind_Precision
+	ConstraintKind_Scale
+
 	ConstraintKind_count
 )
 
@@ -124,6 +153,7 @@ type IConstraint interface {
 	//	- uint16 value for min/max length constraints,
 	// 	- *regexp.Regexp value for pattern constraint,
 	// 	- float64 value for min/max inclusive/exclusive constraints.
-	//	- sorted slice with values for enumeration constraint.
+	//	- sorted slice with values for enumeration constraint,
+	//	- uint8 value for decimal precision and scale constraints.
 	Value() any
 }
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef
+
+// Records with indexes.
+//
+// Indexes are maintained by the engine for TypeKind_CDoc, TypeKind_CRecord, TypeKind_WDoc and TypeKind_WRecord
+type IWithIndexes interface {
+	// Return index by qualified name.
+	//
+	// Returns nil if index not found
+	Index(QName) IIndex
+
+	// Return indexes count
+	IndexCount() int
+
+	// All indexes, sorted by index name.
+	Indexes() []IIndex
+}
+
+type IIndexesBuilder interface {
+	// Adds new index with specified name and fields.
+	//
+	// # Panics:
+	//   - if index name is empty,
+	//   - if index name is invalid,
+	//   - if name is already exists,
+	//   - if fields list is empty or too long,
+	//   - if fields has duplicates,
+	//   - if some field not found,
+	//   - if some field has json data kind.
+	AddIndex(name QName, fields []FieldName, comment ...string) IIndexesBuilder
+}
+
+// Describe single index for record.
+type IIndex interface {
+	IWithComments
+
+	// Returns qualified name of index.
+	Name() QName
+
+	// Returns index fields list in declaration order
+	Fields() []IField
+}
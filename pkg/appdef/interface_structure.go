@@ -30,6 +30,7 @@ type IStructureBuilder interface {
 // Record has ID field.
 type IRecord interface {
 	IStructure
+	IWithIndexes
 
 	// Returns definition for «sys.ID» field
 	SystemField_ID() IField
@@ -37,6 +38,7 @@ type IRecord interface {
 
 type IRecordBuilder interface {
 	IStructureBuilder
+	IIndexesBuilder
 }
 
 // Document is a record.
@@ -124,6 +124,11 @@ func (d *Data) addConstraints(cc ...appdef.IConstraint) {
 		}
 		d.constraints[ck] = c
 	}
+	if dk == appdef.DataKind_decimal {
+		if p, s := appdef.DecimalPrecisionScale(d.Constraints(true)); s > p {
+			panic(appdef.ErrIncompatible("decimal scale %d with precision %d for data type «%v»", s, p, d))
+		}
+	}
 }
 
 // # Supports:
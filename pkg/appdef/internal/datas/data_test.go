@@ -346,6 +346,15 @@ func Test_DataBuilder_AddConstraint(t *testing.T) {
 			args{appdef.DataKind_string, appdef.ConstraintKind_Enum, []string{"a", "b", "c"}}, false, nil},
 		{"string: enum constraint should fail if incompatible enum type",
 			args{appdef.DataKind_float64, appdef.ConstraintKind_Enum, []int32{1, 2, 3}}, true, appdef.ErrIncompatibleError},
+		//- Decimal
+		{"decimal: precision constraint should be ok",
+			args{appdef.DataKind_decimal, appdef.ConstraintKind_Precision, uint8(10)}, false, nil},
+		{"decimal: scale constraint should be ok",
+			args{appdef.DataKind_decimal, appdef.ConstraintKind_Scale, uint8(2)}, false, nil},
+		{"decimal: enum constraint should fail",
+			args{appdef.DataKind_decimal, appdef.ConstraintKind_Enum, []int64{1, 2, 3}}, true, appdef.ErrIncompatibleError},
+		{"int64: precision constraint should fail",
+			args{appdef.DataKind_int64, appdef.ConstraintKind_Precision, uint8(10)}, true, appdef.ErrIncompatibleError},
 	}
 	require := require.New(t)
 	for _, tt := range tests {
@@ -368,6 +377,49 @@ func Test_DataBuilder_AddConstraint(t *testing.T) {
 	}
 }
 
+func Test_DecimalData(t *testing.T) {
+	require := require.New(t)
+
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+
+	t.Run("should be ok to add decimal data with precision and scale", func(t *testing.T) {
+		money := wsb.AddData(appdef.NewQName("test", "money"), appdef.DataKind_decimal, appdef.NullQName,
+			constraints.Precision(12), constraints.Scale(2))
+		require.NotNil(money)
+
+		p, s := appdef.DecimalPrecisionScale(appdef.Data(wsb.Workspace().Type, appdef.NewQName("test", "money")).Constraints(true))
+		require.EqualValues(12, p)
+		require.EqualValues(2, s)
+	})
+
+	t.Run("should be ok to inherit precision and scale", func(t *testing.T) {
+		_ = wsb.AddData(appdef.NewQName("test", "price"), appdef.DataKind_decimal, appdef.NewQName("test", "money"),
+			constraints.MinIncl(0))
+		p, s := appdef.DecimalPrecisionScale(appdef.Data(wsb.Workspace().Type, appdef.NewQName("test", "price")).Constraints(true))
+		require.EqualValues(12, p)
+		require.EqualValues(2, s)
+	})
+
+	t.Run("should be default precision and scale for system decimal", func(t *testing.T) {
+		p, s := appdef.DecimalPrecisionScale(appdef.SysData(wsb.Workspace().Type, appdef.DataKind_decimal).Constraints(true))
+		require.Equal(appdef.DefaultDecimalPrecision, p)
+		require.Equal(appdef.DefaultDecimalScale, s)
+	})
+
+	t.Run("should be panic if scale is greater than precision", func(t *testing.T) {
+		require.Panics(func() {
+			wsb.AddData(appdef.NewQName("test", "wrong"), appdef.DataKind_decimal, appdef.NullQName,
+				constraints.Precision(2), constraints.Scale(3))
+		}, require.Is(appdef.ErrIncompatibleError))
+		require.Panics(func() {
+			wsb.AddData(appdef.NewQName("test", "wrong1"), appdef.DataKind_decimal, appdef.NewQName("test", "money"),
+				constraints.Precision(1))
+		}, require.Is(appdef.ErrIncompatibleError))
+	})
+}
+
 func Test_DataConstraint_String(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/internal/comments"
+	"github.com/voedger/voedger/pkg/appdef/internal/slicex"
+)
+
+// # Supports:
+//   - appdef.IIndex
+type Index struct {
+	comments.WithComments
+	name   appdef.QName
+	fields []appdef.IField
+}
+
+func NewIndex(name appdef.QName, fieldNames []appdef.FieldName, fields appdef.IWithFields) *Index {
+	idx := &Index{
+		name:   name,
+		fields: make([]appdef.IField, 0, len(fieldNames)),
+	}
+	for _, f := range fieldNames {
+		fld := fields.Field(f)
+		if fld == nil {
+			panic(appdef.ErrFieldNotFound(f))
+		}
+		if fld.DataKind() == appdef.DataKind_json {
+			panic(appdef.ErrUnsupported("%s-field «%s» in index «%v»", fld.DataKind().TrimString(), f, name))
+		}
+		idx.fields = append(idx.fields, fld)
+	}
+	return idx
+}
+
+func (idx Index) Name() appdef.QName {
+	return idx.name
+}
+
+func (idx Index) Fields() []appdef.IField {
+	return idx.fields
+}
+
+func (idx Index) String() string {
+	return fmt.Sprintf("index «%v»", idx.name)
+}
+
+// # Supports:
+//   - appdef.IWithIndexes
+type WithIndexes struct {
+	find    appdef.FindType
+	fields  appdef.IWithFields
+	indexes map[appdef.QName]appdef.IIndex
+}
+
+func MakeWithIndexes(find appdef.FindType, fields appdef.IWithFields) WithIndexes {
+	ii := WithIndexes{
+		find:    find,
+		fields:  fields,
+		indexes: make(map[appdef.QName]appdef.IIndex),
+	}
+	return ii
+}
+
+func (ii WithIndexes) Index(name appdef.QName) appdef.IIndex {
+	if idx, ok := ii.indexes[name]; ok {
+		return idx
+	}
+	return nil
+}
+
+func (ii WithIndexes) IndexCount() int {
+	return len(ii.indexes)
+}
+
+func (ii WithIndexes) Indexes() []appdef.IIndex {
+	ss := make([]appdef.IIndex, 0, len(ii.indexes))
+	for _, idx := range ii.indexes {
+		ss = append(ss, idx)
+	}
+	slices.SortFunc(ss, func(a, b appdef.IIndex) int {
+		return appdef.CompareQName(a.Name(), b.Name())
+	})
+	return ss
+}
+
+func (ii *WithIndexes) addIndex(name appdef.QName, fields []appdef.FieldName, comment ...string) {
+	if name == appdef.NullQName {
+		panic(appdef.ErrMissed("index name"))
+	}
+	if ok, err := appdef.ValidQName(name); !ok {
+		panic(fmt.Errorf("index name «%v» is invalid: %w", name, err))
+	}
+	if ii.Index(name) != nil {
+		panic(appdef.ErrAlreadyExists("index «%v»", name))
+	}
+
+	if t := ii.find(name); t.Kind() != appdef.TypeKind_null {
+		panic(appdef.ErrAlreadyExists("name «%v» already used for %v", name, t))
+	}
+
+	if len(fields) == 0 {
+		panic(appdef.ErrMissed("index «%v» fields", name))
+	}
+	if i, j := slicex.FindDuplicates(fields); i >= 0 {
+		panic(appdef.ErrAlreadyExists("fields in index «%v» has duplicates (fields[%d] == fields[%d] == %q)", name, i, j, fields[i]))
+	}
+
+	if len(fields) > appdef.MaxTypeIndexFieldsCount {
+		panic(appdef.ErrTooMany("fields in index «%v», maximum is %d", name, appdef.MaxTypeIndexFieldsCount))
+	}
+
+	if len(ii.indexes) >= appdef.MaxTypeIndexCount {
+		panic(appdef.ErrTooMany("indexes, maximum is %d", appdef.MaxTypeIndexCount))
+	}
+
+	idx := NewIndex(name, fields, ii.fields)
+
+	comments.SetComment(&idx.WithComments, comment...)
+
+	ii.indexes[name] = idx
+}
+
+// # Supports:
+//   - appdef.IIndexesBuilder
+type IndexesBuilder struct {
+	*WithIndexes
+}
+
+func MakeIndexesBuilder(indexes *WithIndexes) IndexesBuilder {
+	return IndexesBuilder{WithIndexes: indexes}
+}
+
+func (ib *IndexesBuilder) AddIndex(name appdef.QName, fields []appdef.FieldName, comment ...string) appdef.IIndexesBuilder {
+	ib.addIndex(name, fields, comment...)
+	return ib
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/goutils/testingu/require"
+)
+
+func Test_Indexes(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "doc")
+	recName := appdef.NewQName("test", "rec")
+	idx1 := appdef.IndexQName(docName, "ByName")
+	idx2 := appdef.IndexQName(docName, "ByEMail")
+	idx3 := appdef.IndexQName(recName, "ByAmount")
+
+	var app appdef.IAppDef
+
+	t.Run("should be ok to add indexes", func(t *testing.T) {
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+		wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+
+		doc := wsb.AddCDoc(docName)
+		doc.
+			AddField("name", appdef.DataKind_string, true).
+			AddField("surname", appdef.DataKind_string, false).
+			AddField("eMail", appdef.DataKind_string, false)
+		doc.
+			AddIndex(idx1, []appdef.FieldName{"surname", "name"}, "index by full name").
+			AddIndex(idx2, []appdef.FieldName{"eMail"})
+
+		rec := wsb.AddWRecord(recName)
+		rec.AddField("amount", appdef.DataKind_int64, false)
+		rec.AddIndex(idx3, []appdef.FieldName{"amount"})
+
+		a, err := adb.Build()
+		require.NoError(err)
+
+		app = a
+	})
+
+	t.Run("should be ok to read indexes", func(t *testing.T) {
+		doc := appdef.CDoc(app.Type, docName)
+
+		require.Equal(2, doc.IndexCount())
+
+		idx := doc.Index(idx1)
+		require.NotNil(idx)
+		require.Equal(idx1, idx.Name())
+		require.Equal("index by full name", idx.Comment())
+		require.Contains(fmt.Sprint(idx), idx1.String())
+		ff := idx.Fields()
+		require.Len(ff, 2)
+		require.Equal("surname", ff[0].Name(), "fields should be in declaration order")
+		require.Equal("name", ff[1].Name())
+
+		ii := doc.Indexes()
+		require.Len(ii, 2)
+		require.Equal(idx2, ii[0].Name(), "indexes should be sorted by name")
+		require.Equal(idx1, ii[1].Name())
+
+		require.Nil(doc.Index(idx3), "should be nil if unknown index")
+
+		rec := appdef.WRecord(app.Type, recName)
+		require.Equal(1, rec.IndexCount())
+		require.Equal(appdef.DataKind_int64, rec.Index(idx3).Fields()[0].DataKind())
+	})
+}
+
+func Test_IndexesPanics(t *testing.T) {
+	require := require.New(t)
+
+	docName := appdef.NewQName("test", "doc")
+	idx1 := appdef.IndexQName(docName, "ByName")
+
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+
+	doc := wsb.AddCDoc(docName)
+	doc.
+		AddField("name", appdef.DataKind_string, true).
+		AddField("birthday", appdef.DataKind_date, false).
+		AddField("extra", appdef.DataKind_json, false)
+	doc.AddIndex(idx1, []appdef.FieldName{"name"})
+
+	require.Panics(func() {
+		doc.AddIndex(appdef.NullQName, []appdef.FieldName{"birthday"})
+	}, require.Is(appdef.ErrMissedError),
+		"if missed index name")
+
+	require.Panics(func() {
+		doc.AddIndex(appdef.NewQName("naked", "🔫"), []appdef.FieldName{"birthday"})
+	}, require.Is(appdef.ErrInvalidError), require.Has("naked.🔫"),
+		"if invalid index name")
+
+	require.Panics(func() {
+		doc.AddIndex(idx1, []appdef.FieldName{"birthday"})
+	}, require.Is(appdef.ErrAlreadyExistsError), require.Has(idx1),
+		"if index name already used")
+
+	require.Panics(func() {
+		doc.AddIndex(docName, []appdef.FieldName{"birthday"})
+	}, require.Is(appdef.ErrAlreadyExistsError), require.Has(docName),
+		"if index name used by other entity")
+
+	require.Panics(func() {
+		doc.AddIndex(appdef.NewQName("test", "doc$iii"), []appdef.FieldName{})
+	}, require.Is(appdef.ErrMissedError),
+		"if fields missed")
+
+	require.Panics(func() {
+		doc.AddIndex(appdef.NewQName("test", "doc$iii"), []appdef.FieldName{"birthday", "birthday"})
+	}, require.Is(appdef.ErrAlreadyExistsError), require.Has("birthday"),
+		"if fields with duplicates")
+
+	require.Panics(func() {
+		doc.AddIndex(appdef.NewQName("test", "doc$iii"), []appdef.FieldName{"unknown"})
+	}, require.Is(appdef.ErrNotFoundError), require.Has("unknown"),
+		"if unknown field")
+
+	require.Panics(func() {
+		doc.AddIndex(appdef.NewQName("test", "doc$iii"), []appdef.FieldName{"extra"})
+	}, require.Is(appdef.ErrUnsupportedError), require.Has("extra"),
+		"if json field")
+
+	t.Run("if too many fields", func(t *testing.T) {
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+		wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+		rec := wsb.AddCRecord(appdef.NewQName("test", "rec"))
+		fldNames := []appdef.FieldName{}
+		for i := 0; i <= appdef.MaxTypeIndexFieldsCount; i++ {
+			n := fmt.Sprintf("f_%#x", i)
+			rec.AddField(n, appdef.DataKind_int32, false)
+			fldNames = append(fldNames, n)
+		}
+		require.Panics(func() { rec.AddIndex(appdef.NewQName("test", "rec$iii"), fldNames) },
+			require.Is(appdef.ErrTooManyError))
+	})
+
+	t.Run("if too many indexes", func(t *testing.T) {
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+		wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+		rec := wsb.AddCRecord(appdef.NewQName("test", "rec"))
+		rec.AddField("f", appdef.DataKind_int32, false)
+		for i := 0; i < appdef.MaxTypeIndexCount; i++ {
+			rec.AddIndex(appdef.NewQName("test", fmt.Sprintf("rec$indexes$i%d", i)), []appdef.FieldName{"f"})
+		}
+		require.Panics(func() { rec.AddIndex(appdef.NewQName("test", "rec$indexes$lastStraw"), []appdef.FieldName{"f"}) },
+			require.Is(appdef.ErrTooManyError))
+	})
+}
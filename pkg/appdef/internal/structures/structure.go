@@ -12,6 +12,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef/internal/abstracts"
 	"github.com/voedger/voedger/pkg/appdef/internal/containers"
 	"github.com/voedger/voedger/pkg/appdef/internal/fields"
+	"github.com/voedger/voedger/pkg/appdef/internal/indexes"
 	"github.com/voedger/voedger/pkg/appdef/internal/types"
 	"github.com/voedger/voedger/pkg/appdef/internal/uniques"
 )
@@ -76,6 +77,7 @@ func MakeStructureBuilder(structure *Structure) StructureBuilder {
 //   - appdef.IRecord
 type Record struct {
 	Structure
+	indexes.WithIndexes
 }
 
 func (r Record) SystemField_ID() appdef.IField {
@@ -87,6 +89,7 @@ func MakeRecord(ws appdef.IWorkspace, name appdef.QName, kind appdef.TypeKind) R
 	r := Record{
 		Structure: MakeStructure(ws, name, kind),
 	}
+	r.WithIndexes = indexes.MakeWithIndexes(ws.App().Type, &r.WithFields)
 	return r
 }
 
@@ -94,12 +97,14 @@ func MakeRecord(ws appdef.IWorkspace, name appdef.QName, kind appdef.TypeKind) R
 //   - appdef.IRecordBuilder
 type RecordBuilder struct {
 	StructureBuilder
+	indexes.IndexesBuilder
 	*Record
 }
 
 func MakeRecordBuilder(record *Record) RecordBuilder {
 	return RecordBuilder{
 		StructureBuilder: MakeStructureBuilder(&record.Structure),
+		IndexesBuilder:   indexes.MakeIndexesBuilder(&record.WithIndexes),
 		Record:           record,
 	}
 }
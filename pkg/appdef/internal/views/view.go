@@ -250,8 +250,11 @@ func (cc *ViewClustCols) addRefField(name appdef.FieldName, ref ...appdef.QName)
 	fields.AddRefField(&cc.WithFields, name, false, ref...)
 }
 
-// Panics if variable length field already exists
+// Panics if variable length field already exists or if json field is added
 func (cc *ViewClustCols) panicIfVarFieldDuplication(name appdef.FieldName, kind appdef.DataKind) {
+	if kind == appdef.DataKind_json {
+		panic(appdef.ErrUnsupported("%s-field «%s» with clustering columns of %v", kind.TrimString(), name, cc.view))
+	}
 	if len(cc.varField) > 0 {
 		panic(appdef.ErrUnsupported("%v clustering column already has a various length field «%s», it should be last field and no more fields can be added", cc.view, cc.varField))
 	}
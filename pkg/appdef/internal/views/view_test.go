@@ -82,6 +82,12 @@ func Test_Views(t *testing.T) {
 				}, require.Is(appdef.ErrAlreadyExistsError), require.Has("ccF1"))
 			})
 
+			t.Run("panic if json field added to cc", func(t *testing.T) {
+				require.Panics(func() {
+					vb.Key().ClustCols().AddField("ccF3", appdef.DataKind_json)
+				}, require.Is(appdef.ErrUnsupportedError), require.Has("ccF3"))
+			})
+
 			t.Run("panic if unknown data type field added to cc", func(t *testing.T) {
 				require.Panics(func() {
 					vb.Key().ClustCols().AddDataField("ccF3", appdef.NewQName("test", "unknown"))
@@ -22,12 +22,18 @@ func _() {
 	_ = x[DataKind_RecordID-11]
 	_ = x[DataKind_Record-12]
 	_ = x[DataKind_Event-13]
-	_ = x[DataKind_FakeLast-14]
+	_ = x[DataKind_decimal-14]
+	_ = x[DataKind_timestamp-15]
+	_ = x[DataKind_date-16]
+	_ = x[DataKind_interval-17]
+	_ = x[DataKind_uuid-18]
+	_ = x[DataKind_json-19]
+	_ = x[DataKind_FakeLast-20]
 }
 
-const _DataKind_name = "DataKind_nullDataKind_int8DataKind_int16DataKind_int32DataKind_int64DataKind_float32DataKind_float64DataKind_bytesDataKind_stringDataKind_QNameDataKind_boolDataKind_RecordIDDataKind_RecordDataKind_EventDataKind_FakeLast"
+const _DataKind_name = "DataKind_nullDataKind_int8DataKind_int16DataKind_int32DataKind_int64DataKind_float32DataKind_float64DataKind_bytesDataKind_stringDataKind_QNameDataKind_boolDataKind_RecordIDDataKind_RecordDataKind_EventDataKind_decimalDataKind_timestampDataKind_dateDataKind_intervalDataKind_uuidDataKind_jsonDataKind_FakeLast"
 
-var _DataKind_index = [...]uint8{0, 13, 26, 40, 54, 68, 84, 100, 114, 129, 143, 156, 173, 188, 202, 219}
+var _DataKind_index = [...]uint16{0, 13, 26, 40, 54, 68, 84, 100, 114, 129, 143, 156, 173, 188, 202, 218, 236, 249, 266, 279, 292, 309}
 
 func (i DataKind) String() string {
 	if i >= DataKind(len(_DataKind_index)-1) {
@@ -34,6 +34,25 @@ var ProjectionOffsetsView = ProjectionOffsetView{
 	},
 }
 
+// Change data capture consumers offsets view
+type CDCOffsetsViewFields struct {
+	Partition string
+	Consumer  string
+	Offset    string
+}
+
+var CDCOffsetsView = struct {
+	Name   appdef.QName
+	Fields CDCOffsetsViewFields
+}{
+	Name: appdef.NewQName(appdef.SysPackage, "CDCOffsets"),
+	Fields: CDCOffsetsViewFields{
+		Partition: "partition",
+		Consumer:  "consumer",
+		Offset:    "offset",
+	},
+}
+
 // Child workspaces IDs view
 type NextBaseWSIDViewFields struct {
 	PartKeyDummy  string
@@ -35,6 +35,12 @@ func makeSysWorkspace(adb appdef.IAppDefBuilder) {
 	viewProjectionOffsets.Key().ClustCols().AddField(ProjectionOffsetsView.Fields.Projector, appdef.DataKind_QName)
 	viewProjectionOffsets.Value().AddField(ProjectionOffsetsView.Fields.Offset, appdef.DataKind_int64, true)
 
+	// for change data capture consumers: sys.CDCOffsets
+	viewCDCOffsets := wsb.AddView(CDCOffsetsView.Name)
+	viewCDCOffsets.Key().PartKey().AddField(CDCOffsetsView.Fields.Partition, appdef.DataKind_int32)
+	viewCDCOffsets.Key().ClustCols().AddField(CDCOffsetsView.Fields.Consumer, appdef.DataKind_string)
+	viewCDCOffsets.Value().AddField(CDCOffsetsView.Fields.Offset, appdef.DataKind_int64, true)
+
 	// for child workspaces: sys.NextBaseWSID
 	viewNextBaseWSID := wsb.AddView(NextBaseWSIDView.Name)
 	viewNextBaseWSID.Key().PartKey().AddField(NextBaseWSIDView.Fields.PartKeyDummy, appdef.DataKind_int32)
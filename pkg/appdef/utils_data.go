@@ -33,7 +33,23 @@ func (k DataKind) IsFixed() bool {
 		DataKind_float64,
 		DataKind_QName,
 		DataKind_bool,
-		DataKind_RecordID:
+		DataKind_RecordID,
+		DataKind_decimal,
+		DataKind_timestamp,
+		DataKind_date,
+		DataKind_interval,
+		DataKind_uuid:
+		return true
+	}
+	return false
+}
+
+// Returns is data kind is date and time kind: timestamp, date or interval.
+//
+// Temporal data is stored as int64 milliseconds and represented in JSON as string.
+func (k DataKind) IsTemporal() bool {
+	switch k {
+	case DataKind_timestamp, DataKind_date, DataKind_interval:
 		return true
 	}
 	return false
@@ -58,6 +74,18 @@ func (k DataKind) IsFixed() bool {
 //   - ConstraintKind_MaxIncl
 //   - ConstraintKind_MaxExcl
 //   - ConstraintKind_Enum
+//
+// # Decimal data supports:
+//   - ConstraintKind_Precision
+//   - ConstraintKind_Scale
+//   - ConstraintKind_MinIncl
+//   - ConstraintKind_MinExcl
+//   - ConstraintKind_MaxIncl
+//   - ConstraintKind_MaxExcl
+//
+// # JSON data supports:
+//   - ConstraintKind_MinLen
+//   - ConstraintKind_MaxLen
 func (k DataKind) IsCompatibleWithConstraint(c ConstraintKind) bool {
 	switch k {
 	case DataKind_bytes:
@@ -77,6 +105,13 @@ func (k DataKind) IsCompatibleWithConstraint(c ConstraintKind) bool {
 			ConstraintKind_Enum:
 			return true
 		}
+	case DataKind_json:
+		switch c {
+		case
+			ConstraintKind_MinLen,
+			ConstraintKind_MaxLen:
+			return true
+		}
 	case DataKind_int8, DataKind_int16, // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
 		DataKind_int32, DataKind_int64, DataKind_float32, DataKind_float64:
 		switch c {
@@ -88,10 +123,36 @@ func (k DataKind) IsCompatibleWithConstraint(c ConstraintKind) bool {
 			ConstraintKind_Enum:
 			return true
 		}
+	case DataKind_decimal:
+		switch c {
+		case
+			ConstraintKind_Precision,
+			ConstraintKind_Scale,
+			ConstraintKind_MinIncl,
+			ConstraintKind_MinExcl,
+			ConstraintKind_MaxIncl,
+			ConstraintKind_MaxExcl:
+			return true
+		}
 	}
 	return false
 }
 
+// Returns decimal precision and scale from specified constraints.
+//
+// If precision or scale constraint is missed, then DefaultDecimalPrecision or
+// DefaultDecimalScale is returned.
+func DecimalPrecisionScale(cc map[ConstraintKind]IConstraint) (precision, scale uint8) {
+	precision, scale = DefaultDecimalPrecision, DefaultDecimalScale
+	if c, ok := cc[ConstraintKind_Precision]; ok {
+		precision = c.Value().(uint8)
+	}
+	if c, ok := cc[ConstraintKind_Scale]; ok {
+		scale = c.Value().(uint8)
+	}
+	return precision, scale
+}
+
 func (k DataKind) MarshalText() ([]byte, error) {
 	var s string
 	if k < DataKind_FakeLast {
@@ -122,6 +122,24 @@ func TestDataKindType_IsFixed(t *testing.T) {
 		{name: "int32 must be fixed",
 			args: args{kind: appdef.DataKind_int32},
 			want: true},
+		{name: "decimal must be fixed",
+			args: args{kind: appdef.DataKind_decimal},
+			want: true},
+		{name: "timestamp must be fixed",
+			args: args{kind: appdef.DataKind_timestamp},
+			want: true},
+		{name: "date must be fixed",
+			args: args{kind: appdef.DataKind_date},
+			want: true},
+		{name: "interval must be fixed",
+			args: args{kind: appdef.DataKind_interval},
+			want: true},
+		{name: "uuid must be fixed",
+			args: args{kind: appdef.DataKind_uuid},
+			want: true},
+		{name: "json must be variable",
+			args: args{kind: appdef.DataKind_json},
+			want: false},
 		{name: "string must be variable",
 			args: args{kind: appdef.DataKind_string},
 			want: false},
@@ -135,6 +153,15 @@ func TestDataKindType_IsFixed(t *testing.T) {
 	}
 }
 
+func TestDataKindType_IsTemporal(t *testing.T) {
+	for k := appdef.DataKind_null; k < appdef.DataKind_FakeLast; k++ {
+		want := k == appdef.DataKind_timestamp || k == appdef.DataKind_date || k == appdef.DataKind_interval
+		if got := k.IsTemporal(); got != want {
+			t.Errorf("%v.IsTemporal() = %v, want %v", k, got, want)
+		}
+	}
+}
+
 func TestDataKindType_MarshalText(t *testing.T) {
 	tests := []struct {
 		name string
@@ -284,6 +311,24 @@ func TestDataKind_IsSupportedConstraint(t *testing.T) {
 		{"float64: MaxIncl", appdef.DataKind_float64, args{appdef.ConstraintKind_MaxIncl}, true},
 		{"float64: MaxExcl", appdef.DataKind_float64, args{appdef.ConstraintKind_MaxExcl}, true},
 		{"float64: Enum", appdef.DataKind_float64, args{appdef.ConstraintKind_Enum}, true},
+		{"float64: Precision", appdef.DataKind_float64, args{appdef.ConstraintKind_Precision}, false},
+		//-
+		{"decimal: MaxLen", appdef.DataKind_decimal, args{appdef.ConstraintKind_MaxLen}, false},
+		{"decimal: Precision", appdef.DataKind_decimal, args{appdef.ConstraintKind_Precision}, true},
+		{"decimal: Scale", appdef.DataKind_decimal, args{appdef.ConstraintKind_Scale}, true},
+		{"decimal: MinIncl", appdef.DataKind_decimal, args{appdef.ConstraintKind_MinIncl}, true},
+		{"decimal: MaxExcl", appdef.DataKind_decimal, args{appdef.ConstraintKind_MaxExcl}, true},
+		{"decimal: Enum", appdef.DataKind_decimal, args{appdef.ConstraintKind_Enum}, false},
+		//-
+		{"timestamp: MinIncl", appdef.DataKind_timestamp, args{appdef.ConstraintKind_MinIncl}, false},
+		{"date: Enum", appdef.DataKind_date, args{appdef.ConstraintKind_Enum}, false},
+		{"interval: MaxLen", appdef.DataKind_interval, args{appdef.ConstraintKind_MaxLen}, false},
+		//-
+		{"uuid: MaxLen", appdef.DataKind_uuid, args{appdef.ConstraintKind_MaxLen}, false},
+		{"uuid: Pattern", appdef.DataKind_uuid, args{appdef.ConstraintKind_Pattern}, false},
+		{"json: MinLen", appdef.DataKind_json, args{appdef.ConstraintKind_MinLen}, true},
+		{"json: MaxLen", appdef.DataKind_json, args{appdef.ConstraintKind_MaxLen}, true},
+		{"json: Pattern", appdef.DataKind_json, args{appdef.ConstraintKind_Pattern}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,12 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef
+
+import "fmt"
+
+// Constructs and returns QName for the index for the record.
+func IndexQName(recQName QName, indexName string) QName {
+	return NewQName(recQName.Pkg(), fmt.Sprintf("%s$indexes$%s", recQName.Entity(), indexName))
+}
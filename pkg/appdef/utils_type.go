@@ -440,6 +440,12 @@ var (
 		DataKind_QName,
 		DataKind_bool,
 		DataKind_RecordID,
+		DataKind_decimal,
+		DataKind_timestamp,
+		DataKind_date,
+		DataKind_interval,
+		DataKind_uuid,
+		DataKind_json,
 	)
 
 	typeKindStructProps = map[TypeKind]*structuralTypeProps{
@@ -552,6 +558,12 @@ var (
 				DataKind_RecordID,
 				DataKind_Record,
 				DataKind_Event,
+				DataKind_decimal,
+				DataKind_timestamp,
+				DataKind_date,
+				DataKind_interval,
+				DataKind_uuid,
+				DataKind_json,
 			),
 			systemFields: map[FieldName]bool{
 				SystemField_QName: true,
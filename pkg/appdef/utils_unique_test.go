@@ -28,3 +28,10 @@ func Test_UniqueQName(t *testing.T) {
 			appdef.UniqueQName(appdef.MustParseQName(tt.d), tt.n))
 	}
 }
+
+func Test_IndexQName(t *testing.T) {
+	require := require.New(t)
+	require.Equal(
+		appdef.MustParseQName("test.table$indexes$idx"),
+		appdef.IndexQName(appdef.MustParseQName("test.table"), "idx"))
+}
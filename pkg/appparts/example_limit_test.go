@@ -94,36 +94,42 @@ func ExampleIAppPartition_IsLimitExceeded() {
 	}
 	defer partition.Release()
 
+	isLimitExceeded := func(cmd appdef.QName) (bool, appdef.QName) {
+		exceeded, limit, _ := partition.IsLimitExceeded(cmd, appdef.OperationKind_Execute, 1, `addr1`)
+		return exceeded, limit
+	}
+
 	// Check limits for cmd1
-	fmt.Println(partition.IsLimitExceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(isLimitExceeded(cmd1Name))
+	fmt.Println(isLimitExceeded(cmd1Name))
+	fmt.Println(isLimitExceeded(cmd1Name))
 
-	fmt.Println(partition.IsLimitExceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`)) // Exceeded IP
+	_, _, state := partition.IsLimitExceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`) // Exceeded IP
+	fmt.Println(state.MaxTokensPerPeriod, state.Period)
 
 	// Check limits for cmd2
-	fmt.Println(partition.IsLimitExceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(isLimitExceeded(cmd2Name))
+	fmt.Println(isLimitExceeded(cmd2Name))
 
-	fmt.Println(partition.IsLimitExceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`)) // Exceeded WS
+	fmt.Println(isLimitExceeded(cmd2Name)) // Exceeded WS
 
 	testingu.MockTime.Add(time.Minute) // Wait for the next minute
 
 	// check limits is respawned
-	fmt.Println(partition.IsLimitExceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(isLimitExceeded(cmd1Name))
+	fmt.Println(isLimitExceeded(cmd2Name))
 
 	// check limits for unlimited command
-	fmt.Println(partition.IsLimitExceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(partition.IsLimitExceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(isLimitExceeded(appdef.NewQName("test", "cmd3")))
+	fmt.Println(isLimitExceeded(appdef.NewQName("test", "cmd3")))
+	fmt.Println(isLimitExceeded(appdef.NewQName("test", "cmd3")))
+	fmt.Println(isLimitExceeded(appdef.NewQName("test", "cmd3")))
 
 	// Output:
 	// false .
 	// false .
 	// false .
-	// true test.ipLimit
+	// 3 1m0s
 	// false .
 	// false .
 	// true test.wsLimit
@@ -18,6 +18,7 @@ import (
 	"github.com/voedger/voedger/pkg/appparts/internal/pool"
 	"github.com/voedger/voedger/pkg/appparts/internal/schedulers"
 	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
@@ -167,7 +168,7 @@ func newAppPartitionRT(app *appRT, id istructs.PartitionID) *appPartitionRT {
 		syncActualizer: app.apps.syncActualizerFactory(as, id),
 		actualizers:    actualizers.New(app.name, id),
 		schedulers:     schedulers.New(app.name, app.partsCount, as.NumAppWorkspaces(), id),
-		limiter:        limiter.New(app.lastestVersion.appDef(), buckets),
+		limiter:        limiter.New(app.name, app.lastestVersion.appDef(), buckets),
 	}
 	return part
 }
@@ -245,7 +246,7 @@ func (bp *borrowedPartition) Invoke(ctx context.Context, name appdef.QName, stat
 	return extEngine.Invoke(ctx, extName, io)
 }
 
-func (bp *borrowedPartition) IsLimitExceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (bool, appdef.QName) {
+func (bp *borrowedPartition) IsLimitExceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (bool, appdef.QName, irates.BucketState) {
 	return bp.part.limiter.Exceeded(resource, operation, workspace, remoteAddr)
 }
 
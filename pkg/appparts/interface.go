@@ -13,6 +13,7 @@ import (
 	"github.com/voedger/voedger/pkg/pipeline"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -109,8 +110,8 @@ type IAppPartition interface {
 
 	// Return is specified resource (command, query or structure) usage limit is exceeded.
 	//
-	// If resource usage is exceeded then returns name of first exceeded limit.
-	IsLimitExceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (exceed bool, limit appdef.QName)
+	// If resource usage is exceeded then returns name of first exceeded limit and state of its bucket.
+	IsLimitExceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (exceed bool, limit appdef.QName, state irates.BucketState)
 }
 
 // Async actualizer runner.
@@ -54,38 +54,43 @@ func Example() {
 
 	buckets := iratesce.TestBucketsFactory()
 
-	Limiter := limiter.New(app, buckets)
+	Limiter := limiter.New(appdef.NewAppQName("test", "app"), app, buckets)
+
+	exceeded := func(cmd appdef.QName) (bool, appdef.QName) {
+		exceeded, limit, _ := Limiter.Exceeded(cmd, appdef.OperationKind_Execute, 1, `addr1`)
+		return exceeded, limit
+	}
 
 	// Check limits for cmd1
-	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(exceeded(cmd1Name))
+	fmt.Println(exceeded(cmd1Name))
+	fmt.Println(exceeded(cmd1Name))
 
-	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`)) // Exceeded IP
+	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`)) // Exceeded IP, bucket state is returned
 
 	// Check limits for cmd2
-	fmt.Println(Limiter.Exceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(exceeded(cmd2Name))
+	fmt.Println(exceeded(cmd2Name))
 
-	fmt.Println(Limiter.Exceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`)) // Exceeded WS
+	fmt.Println(exceeded(cmd2Name)) // Exceeded WS
 
 	testingu.MockTime.Add(time.Minute) // Wait for the next minute
 
 	// check limits is respawned
-	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(exceeded(cmd1Name))
+	fmt.Println(exceeded(cmd2Name))
 
 	// check limits for unlimited command
-	fmt.Println(Limiter.Exceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
-	fmt.Println(Limiter.Exceeded(appdef.NewQName("test", "cmd3"), appdef.OperationKind_Execute, 1, `addr1`))
+	fmt.Println(exceeded(appdef.NewQName("test", "cmd3")))
+	fmt.Println(exceeded(appdef.NewQName("test", "cmd3")))
+	fmt.Println(exceeded(appdef.NewQName("test", "cmd3")))
+	fmt.Println(exceeded(appdef.NewQName("test", "cmd3")))
 
 	// Output:
 	// false .
 	// false .
 	// false .
-	// true test.ipLimit
+	// true test.ipLimit {1m0s 3 3}
 	// false .
 	// false .
 	// true test.wsLimit
@@ -12,27 +12,30 @@ import (
 )
 
 type Limiter struct {
+	name    appdef.AppQName
 	app     appdef.IAppDef
 	buckets irates.IBuckets
 	limits  map[appdef.QName][]appdef.ILimit
 }
 
-func New(app appdef.IAppDef, buckets irates.IBuckets) *Limiter {
-	l := &Limiter{app, buckets, make(map[appdef.QName][]appdef.ILimit)}
+// name is the part of each bucket key since buckets could be shared among apps
+func New(name appdef.AppQName, app appdef.IAppDef, buckets irates.IBuckets) *Limiter {
+	l := &Limiter{name, app, buckets, make(map[appdef.QName][]appdef.ILimit)}
 	l.init()
 	return l
 }
 
 // Return is specified resource (command, query or structure) usage limit is exceeded.
 //
-// If resource usage is exceeded then returns name of first exceeded limit.
-func (l *Limiter) Exceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (bool, appdef.QName) {
+// If resource usage is exceeded then returns name and bucket state of first exceeded limit.
+func (l *Limiter) Exceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (bool, appdef.QName, irates.BucketState) {
 	if limits, ok := l.limits[resource]; ok {
 		keys := make([]irates.BucketKey, 0, len(limits))
 		for _, limit := range limits {
 			if limit.Op(operation) {
 				key := irates.BucketKey{
 					RateLimitName: limit.QName(),
+					App:           l.name,
 				}
 				if limit.Rate().Scope(appdef.RateScope_Workspace) {
 					key.Workspace = workspace
@@ -47,12 +50,25 @@ func (l *Limiter) Exceeded(resource appdef.QName, operation appdef.OperationKind
 			}
 		}
 		if len(keys) > 0 {
-			ok, excLimit := l.buckets.TakeTokens(keys, 1)
-			return !ok, excLimit
+			if ok, excLimit := l.buckets.TakeTokens(keys, 1); !ok {
+				return true, excLimit, l.state(keys, excLimit)
+			}
 		}
 	}
 
-	return false, appdef.NullQName
+	return false, appdef.NullQName, irates.BucketState{}
+}
+
+// returns state of the bucket of the exceeded limit
+func (l *Limiter) state(keys []irates.BucketKey, limit appdef.QName) irates.BucketState {
+	for _, key := range keys {
+		if key.RateLimitName == limit {
+			state, _ := l.buckets.GetBucketState(key)
+			return state
+		}
+	}
+	// notest: exceeded limit is one of the keys
+	return irates.BucketState{}
 }
 
 func (l *Limiter) init() {
@@ -8,7 +8,9 @@ package btstrp
 import (
 	"fmt"
 	"net/url"
+	"slices"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/coreutils"
@@ -25,7 +27,7 @@ import (
 
 func Bootstrap(federation federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, appparts appparts.IAppPartitions,
 	clusterApp ClusterBuiltInApp, otherApps []appparts.BuiltInApp, sidecarApps []appparts.SidecarApp, itokens itokens.ITokens, storageProvider istorage.IAppStorageProvider,
-	blobberAppStoragePtr iblobstoragestg.BlobAppStoragePtr, routerAppStoragePtr dbcertcache.RouterAppStoragePtr) (err error) {
+	blobberAppStoragePtr iblobstoragestg.BlobAppStoragePtr, routerAppStoragePtr dbcertcache.RouterAppStoragePtr, maintenanceApps []appdef.AppQName) (err error) {
 
 	// initialize cluster app workspace, use app ws amount 0
 	if err := initClusterAppWS(asp, time); err != nil {
@@ -62,17 +64,21 @@ func Bootstrap(federation federation.IFederation, asp istructs.IAppStructsProvid
 
 	// For each app builtInApps: deploy a builtin app
 	for _, app := range otherApps {
-		deployAppPartitions(appparts, app, nil)
+		deployAppPartitions(appparts, app, nil, maintenanceApps)
 	}
 	for _, app := range sidecarApps {
-		deployAppPartitions(appparts, app.BuiltInApp, app.ExtModuleURLs)
+		deployAppPartitions(appparts, app.BuiltInApp, app.ExtModuleURLs, maintenanceApps)
 	}
 
 	return nil
 }
 
-func deployAppPartitions(appparts appparts.IAppPartitions, app appparts.BuiltInApp, extModuleURLs map[string]*url.URL) {
+func deployAppPartitions(appparts appparts.IAppPartitions, app appparts.BuiltInApp, extModuleURLs map[string]*url.URL, maintenanceApps []appdef.AppQName) {
 	appparts.DeployApp(app.Name, extModuleURLs, app.Def, app.NumParts, app.EnginePoolSize, app.NumAppWorkspaces)
+	if slices.Contains(maintenanceApps, app.Name) {
+		// the app is known but its partitions are stopped
+		return
+	}
 	partitionIDs := make([]istructs.PartitionID, app.NumParts)
 	for id := istructs.NumAppPartitions(0); id < app.NumParts; id++ {
 		partitionIDs[id] = istructs.PartitionID(id)
@@ -91,10 +91,10 @@ func handlePanic(r interface{}) error {
 	}
 }
 
-func (r *implIResponder) InitResponse(statusCode int) IResponseWriter {
+func (r *implIResponder) InitResponse(statusCode int, headersKV ...string) IResponseWriter {
 	r.checkStarted()
 	select {
-	case r.responseMetaCh <- ResponseMeta{ContentType: coreutils.ContentType_ApplicationJSON, StatusCode: statusCode}:
+	case r.responseMetaCh <- ResponseMeta{ContentType: coreutils.ContentType_ApplicationJSON, StatusCode: statusCode, Headers: headersKV}:
 	default:
 		// do nothing if no consumer already.
 		// will get ErrNoConsumer on the next Write()
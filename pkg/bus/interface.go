@@ -23,7 +23,8 @@ type RequestHandler func(requestCtx context.Context, request Request, responder
 type IResponder interface {
 	// panics if called >1 times or after Respond
 	// ContentType is ApplicationJSON
-	InitResponse(statusCode int) IResponseWriter
+	// headersKV are key-value pairs of additional HTTP headers
+	InitResponse(statusCode int, headersKV ...string) IResponseWriter
 
 	// panics if called >1 times or after InitResponse
 	Respond(responseMeta ResponseMeta, obj any) error
@@ -35,7 +35,10 @@ type Request struct {
 type ResponseMeta struct {
 	ContentType string
 	StatusCode  int
-	mode        RespondMode
+
+	// key-value pairs of additional HTTP headers, e.g. Retry-After
+	Headers []string
+	mode    RespondMode
 }
 
 type RespondMode int
@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import "time"
+
+// channel to watch PLog updates lives while the stream is read
+const n10nChannelDuration = 100 * 365 * 24 * time.Hour
+
+// channels of named consumers are limited by the in10n per subject quotas together
+// each stream without the consumer has its own subject: "cdc#" + sequence number
+const (
+	subjectLoginPrefix          = "cdc."
+	anonymousSubjectLoginPrefix = "cdc#"
+)
@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import "errors"
+
+var (
+	ErrInvalidConsumer = errors.New("invalid consumer name")
+	ErrInvalidOffset   = errors.New("invalid offset")
+	ErrOffsetBehind    = errors.New("offset is behind the committed one")
+
+	errLimitReached = errors.New("limit reached")
+)
@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/sys"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/processors/actualizers"
+)
+
+func (c *implICDC) Stream(ctx context.Context, params StreamParams, cb EventCallback) error {
+	if len(params.Consumer) > 0 {
+		if err := validateConsumer(params.Consumer); err != nil {
+			return err
+		}
+	}
+	if params.From == istructs.NullOffset && len(params.Consumer) == 0 {
+		return fmt.Errorf("%w: neither the offset nor the consumer is specified", ErrInvalidOffset)
+	}
+	if params.Limit < 0 {
+		return fmt.Errorf("%w: negative limit %d", ErrInvalidOffset, params.Limit)
+	}
+
+	as, err := c.appStructsProvider.BuiltIn(params.App)
+	if err != nil {
+		return err
+	}
+
+	s := &stream{
+		params:     params,
+		appStructs: as,
+		cb:         cb,
+		offset:     params.From,
+	}
+	if s.offset == istructs.NullOffset {
+		committed, err := committedOffset(as, params.Partition, params.Consumer)
+		if err != nil {
+			return err
+		}
+		s.offset = committed + 1
+	}
+
+	if !params.Follow {
+		return s.readToTheEnd(ctx)
+	}
+	return c.follow(ctx, s)
+}
+
+// reads the partition to the end then reads new events on PLog updates notifications
+func (c *implICDC) follow(ctx context.Context, s *stream) (err error) {
+	channel, err := c.broker.NewChannel(c.subject(s.params.Consumer), n10nChannelDuration)
+	if err != nil {
+		return err
+	}
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe before the first read, so events committed during the read are notified
+	if err = c.broker.Subscribe(channel, in10n.ProjectionKey{
+		App:        s.params.App,
+		Projection: actualizers.PLogUpdatesQName,
+		WS:         istructs.WSID(s.params.Partition),
+	}); err == nil {
+		err = s.readToTheEnd(watchCtx)
+	}
+	if err != nil || s.finished() {
+		// WatchChannel returns at once, the channel is removed by WatchChannel only
+		cancel()
+	}
+
+	c.broker.WatchChannel(watchCtx, channel, func(_ in10n.ProjectionKey, offset istructs.Offset) {
+		if offset < s.offset {
+			return
+		}
+		if err = s.readToTheEnd(watchCtx); err != nil || s.finished() {
+			cancel()
+		}
+	})
+	return err
+}
+
+func (c *implICDC) subject(consumer string) istructs.SubjectLogin {
+	if len(consumer) == 0 {
+		return istructs.SubjectLogin(anonymousSubjectLoginPrefix + strconv.FormatUint(c.anonymousStreams.Add(1), utils.DecimalBase))
+	}
+	return istructs.SubjectLogin(subjectLoginPrefix + consumer)
+}
+
+type stream struct {
+	params     StreamParams
+	appStructs istructs.IAppStructs
+	cb         EventCallback
+
+	// offset of the next event to read
+	offset istructs.Offset
+	count  int
+}
+
+func (s *stream) finished() bool {
+	return s.params.Limit > 0 && s.count >= s.params.Limit
+}
+
+func (s *stream) readToTheEnd(ctx context.Context) error {
+	if s.finished() {
+		return nil
+	}
+	err := s.appStructs.Events().ReadPLog(ctx, s.params.Partition, s.offset, istructs.ReadToTheEnd,
+		func(plogOffset istructs.Offset, event istructs.IPLogEvent) error {
+			if err := s.cb(newEvent(s.params.Partition, plogOffset, event, s.appStructs.AppDef())); err != nil {
+				return err
+			}
+			s.offset = plogOffset + 1
+			s.count++
+			if s.finished() {
+				return errLimitReached
+			}
+			return nil
+		})
+	if errors.Is(err, errLimitReached) || errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+func newEvent(partition istructs.PartitionID, plogOffset istructs.Offset, event istructs.IPLogEvent, appDef appdef.IAppDef) Event {
+	res := Event{
+		Partition:    partition,
+		PLogOffset:   plogOffset,
+		WSID:         event.Workspace(),
+		WLogOffset:   event.WLogOffset(),
+		QName:        event.QName().String(),
+		RegisteredAt: event.RegisteredAt(),
+		DeviceID:     event.DeviceID(),
+		Synced:       event.Synced(),
+		SyncedAt:     event.SyncedAt(),
+	}
+	if eventErr := event.Error(); !eventErr.ValidEvent() {
+		// argument and CUDs of the not applied event could be not valid
+		res.Error = &EventError{
+			ErrStr:          eventErr.ErrStr(),
+			QNameFromParams: eventErr.QNameFromParams().String(),
+		}
+		return res
+	}
+	if args := coreutils.ObjectToMap(event.ArgumentObject(), appDef); len(args) > 0 {
+		res.Args = args
+	}
+	if cuds := coreutils.CUDsToMap(event, appDef); len(cuds) > 0 {
+		res.CUDs = cuds
+	}
+	return res
+}
+
+func (c *implICDC) CommittedOffset(app appdef.AppQName, partition istructs.PartitionID, consumer string) (istructs.Offset, error) {
+	if err := validateConsumer(consumer); err != nil {
+		return istructs.NullOffset, err
+	}
+	as, err := c.appStructsProvider.BuiltIn(app)
+	if err != nil {
+		return istructs.NullOffset, err
+	}
+	return committedOffset(as, partition, consumer)
+}
+
+func (c *implICDC) Commit(app appdef.AppQName, partition istructs.PartitionID, consumer string, offset istructs.Offset) error {
+	if err := validateConsumer(consumer); err != nil {
+		return err
+	}
+	if offset == istructs.NullOffset {
+		return fmt.Errorf("%w: offset to commit must be positive", ErrInvalidOffset)
+	}
+	as, err := c.appStructsProvider.BuiltIn(app)
+	if err != nil {
+		return err
+	}
+	lock := c.commitLock(commitKey{app: app, partition: partition, consumer: consumer})
+	lock.Lock()
+	defer lock.Unlock()
+	committed, err := committedOffset(as, partition, consumer)
+	if err != nil {
+		return err
+	}
+	if offset < committed {
+		return fmt.Errorf("%w: %d < %d", ErrOffsetBehind, offset, committed)
+	}
+	value := as.ViewRecords().NewValueBuilder(sys.CDCOffsetsView.Name)
+	value.PutInt64(sys.CDCOffsetsView.Fields.Offset, int64(offset)) // nolint G115
+	return as.ViewRecords().Put(istructs.NullWSID, offsetKey(as, partition, consumer), value)
+}
+
+// locks are never deleted, their count is limited by the count of consumers and partitions
+func (c *implICDC) commitLock(key commitKey) *sync.Mutex {
+	c.commitLocksMu.Lock()
+	defer c.commitLocksMu.Unlock()
+	lock, ok := c.commitLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.commitLocks[key] = lock
+	}
+	return lock
+}
+
+func committedOffset(as istructs.IAppStructs, partition istructs.PartitionID, consumer string) (istructs.Offset, error) {
+	value, err := as.ViewRecords().Get(istructs.NullWSID, offsetKey(as, partition, consumer))
+	if errors.Is(err, istructs.ErrRecordNotFound) {
+		return istructs.NullOffset, nil
+	}
+	if err != nil {
+		return istructs.NullOffset, err
+	}
+	return istructs.Offset(value.AsInt64(sys.CDCOffsetsView.Fields.Offset)), nil // nolint G115
+}
+
+func offsetKey(as istructs.IAppStructs, partition istructs.PartitionID, consumer string) istructs.IKeyBuilder {
+	key := as.ViewRecords().KeyBuilder(sys.CDCOffsetsView.Name)
+	key.PutInt32(sys.CDCOffsetsView.Fields.Partition, int32(partition)) // nolint G115
+	key.PutString(sys.CDCOffsetsView.Fields.Consumer, consumer)
+	return key
+}
+
+func validateConsumer(consumer string) error {
+	if len(consumer) == 0 || len(consumer) > int(appdef.DefaultFieldMaxLength) {
+		return fmt.Errorf("%w: length of %q must be from 1 to %d", ErrInvalidConsumer, consumer, appdef.DefaultFieldMaxLength)
+	}
+	return nil
+}
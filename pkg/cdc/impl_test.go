@@ -0,0 +1,374 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10nmem"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/processors/actualizers"
+)
+
+var (
+	testAppQName = istructs.AppQName_test1_app1
+	qNameDoc     = appdef.NewQName("test", "doc")
+	qNameCmd     = appdef.NewQName("test", "cmd")
+	qNameParam   = appdef.NewQName("test", "param")
+)
+
+const (
+	testWSID      = istructs.WSID(1001)
+	testPartition = istructs.PartitionID(0)
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	app := newTestApp(t)
+
+	app.putDoc("first")
+	app.putCmd(42)
+	app.putError()
+
+	t.Run("read the partition", func(t *testing.T) {
+		events := app.read(StreamParams{App: testAppQName, Partition: testPartition, From: istructs.FirstOffset})
+		require.Len(events, 3)
+
+		require.Equal(istructs.FirstOffset, events[0].PLogOffset)
+		require.Equal(testWSID, events[0].WSID)
+		require.Equal(istructs.QNameCommandCUD.String(), events[0].QName)
+		require.Len(events[0].CUDs, 1)
+		require.Equal(qNameDoc.String(), events[0].CUDs[0]["sys.QName"])
+		require.Equal(true, events[0].CUDs[0]["IsNew"])
+		require.Equal("first", events[0].CUDs[0]["fields"].(map[string]interface{})["name"])
+		require.Nil(events[0].Error)
+
+		require.Equal(istructs.Offset(2), events[1].PLogOffset)
+		require.Equal(qNameCmd.String(), events[1].QName)
+		require.Equal(int32(42), events[1].Args["number"])
+		require.Empty(events[1].CUDs)
+
+		require.Equal(istructs.Offset(3), events[2].PLogOffset)
+		require.NotNil(events[2].Error)
+		require.Equal("test error", events[2].Error.ErrStr)
+		require.Nil(events[2].Args)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		events := app.read(StreamParams{App: testAppQName, Partition: testPartition, From: 2, Limit: 1})
+		require.Len(events, 1)
+		require.Equal(istructs.Offset(2), events[0].PLogOffset)
+	})
+
+	t.Run("consumer resumes from the committed offset", func(t *testing.T) {
+		offset, err := app.cdc.CommittedOffset(testAppQName, testPartition, "analytics")
+		require.NoError(err)
+		require.Equal(istructs.NullOffset, offset)
+
+		events := app.read(StreamParams{App: testAppQName, Partition: testPartition, Consumer: "analytics", Limit: 2})
+		require.Len(events, 2)
+		require.NoError(app.cdc.Commit(testAppQName, testPartition, "analytics", events[1].PLogOffset))
+
+		offset, err = app.cdc.CommittedOffset(testAppQName, testPartition, "analytics")
+		require.NoError(err)
+		require.Equal(istructs.Offset(2), offset)
+
+		events = app.read(StreamParams{App: testAppQName, Partition: testPartition, Consumer: "analytics"})
+		require.Len(events, 1)
+		require.Equal(istructs.Offset(3), events[0].PLogOffset)
+
+		t.Run("committed offset does not move backwards", func(t *testing.T) {
+			require.NoError(app.cdc.Commit(testAppQName, testPartition, "analytics", 2))
+			require.ErrorIs(app.cdc.Commit(testAppQName, testPartition, "analytics", 1), ErrOffsetBehind)
+			offset, err := app.cdc.CommittedOffset(testAppQName, testPartition, "analytics")
+			require.NoError(err)
+			require.Equal(istructs.Offset(2), offset)
+		})
+
+		t.Run("concurrent commits do not move the offset backwards", func(t *testing.T) {
+			const commits = 50
+			wg := sync.WaitGroup{}
+			for i := commits; i > 0; i-- {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := app.cdc.Commit(testAppQName, testPartition, "concurrent", istructs.Offset(i)) // nolint G115
+					if err != nil {
+						require.ErrorIs(err, ErrOffsetBehind)
+					}
+				}()
+			}
+			wg.Wait()
+			offset, err := app.cdc.CommittedOffset(testAppQName, testPartition, "concurrent")
+			require.NoError(err)
+			require.Equal(istructs.Offset(commits), offset)
+		})
+
+		t.Run("offsets are committed per partition", func(t *testing.T) {
+			offset, err := app.cdc.CommittedOffset(testAppQName, testPartition+1, "analytics")
+			require.NoError(err)
+			require.Equal(istructs.NullOffset, offset)
+		})
+
+		t.Run("From has the priority", func(t *testing.T) {
+			events = app.read(StreamParams{App: testAppQName, Partition: testPartition, Consumer: "analytics", From: istructs.FirstOffset})
+			require.Len(events, 3)
+		})
+	})
+}
+
+func TestFollow(t *testing.T) {
+	require := require.New(t)
+	app := newTestApp(t)
+	app.putDoc("first")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventsCh := make(chan Event)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.cdc.Stream(ctx, StreamParams{App: testAppQName, Partition: testPartition, Consumer: "c", Follow: true, Limit: 3},
+			func(event Event) error {
+				eventsCh <- event
+				return nil
+			})
+	}()
+
+	require.Equal(istructs.FirstOffset, (<-eventsCh).PLogOffset)
+
+	app.putDoc("second")
+	require.Equal(istructs.Offset(2), (<-eventsCh).PLogOffset)
+
+	app.putDoc("third")
+	require.Equal(istructs.Offset(3), (<-eventsCh).PLogOffset)
+
+	// limit is reached
+	require.NoError(<-errCh)
+
+	t.Run("stream is finished on ctx done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			errCh <- app.cdc.Stream(ctx, StreamParams{App: testAppQName, Partition: testPartition, From: 4, Follow: true},
+				func(Event) error { return nil })
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		require.NoError(<-errCh)
+	})
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+	app := newTestApp(t)
+	app.putDoc("first")
+
+	t.Run("invalid params", func(t *testing.T) {
+		err := app.cdc.Stream(context.Background(), StreamParams{App: testAppQName, Partition: testPartition}, nil)
+		require.ErrorIs(err, ErrInvalidOffset)
+
+		err = app.cdc.Stream(context.Background(), StreamParams{App: testAppQName, From: 1, Limit: -1}, nil)
+		require.ErrorIs(err, ErrInvalidOffset)
+
+		err = app.cdc.Stream(context.Background(), StreamParams{App: testAppQName, Consumer: string(make([]byte, 256))}, nil)
+		require.ErrorIs(err, ErrInvalidConsumer)
+
+		_, err = app.cdc.CommittedOffset(testAppQName, testPartition, "")
+		require.ErrorIs(err, ErrInvalidConsumer)
+
+		require.ErrorIs(app.cdc.Commit(testAppQName, testPartition, "", 1), ErrInvalidConsumer)
+		require.ErrorIs(app.cdc.Commit(testAppQName, testPartition, "c", istructs.NullOffset), ErrInvalidOffset)
+	})
+
+	t.Run("unknown app", func(t *testing.T) {
+		err := app.cdc.Stream(context.Background(), StreamParams{App: istructs.AppQName_test1_app2, From: 1}, nil)
+		require.ErrorIs(err, istructs.ErrAppNotFound)
+		_, err = app.cdc.CommittedOffset(istructs.AppQName_test1_app2, testPartition, "c")
+		require.ErrorIs(err, istructs.ErrAppNotFound)
+		require.ErrorIs(app.cdc.Commit(istructs.AppQName_test1_app2, testPartition, "c", 1), istructs.ErrAppNotFound)
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		for _, follow := range []bool{false, true} {
+			err := app.cdc.Stream(context.Background(), StreamParams{App: testAppQName, From: 1, Follow: follow},
+				func(Event) error { return testErr })
+			require.ErrorIs(err, testErr)
+		}
+	})
+
+	t.Run("channels quota", func(t *testing.T) {
+		quotas := in10n.Quotas{Channels: 10, ChannelsPerSubject: 1, Subscriptions: 10, SubscriptionsPerSubject: 10}
+		broker, cleanup := in10nmem.NewN10nBroker(quotas, testingu.MockTime)
+		defer cleanup()
+		_, err := broker.NewChannel(istructs.SubjectLogin(subjectLoginPrefix+"c"), time.Hour)
+		require.NoError(err)
+
+		cdc := Provide(app.asp, broker)
+		err = cdc.Stream(context.Background(), StreamParams{App: testAppQName, Consumer: "c", Follow: true}, nil)
+		require.ErrorIs(err, in10n.ErrQuotaExceeded_ChannelsPerSubject)
+
+		t.Run("streams without the consumer do not share the quota", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			errCh := make(chan error, 2)
+			events := make(chan Event, 2)
+			for range 2 {
+				go func() {
+					errCh <- cdc.Stream(ctx, StreamParams{App: testAppQName, Partition: testPartition, From: istructs.FirstOffset, Follow: true},
+						func(event Event) error {
+							events <- event
+							return nil
+						})
+				}()
+			}
+			for range 2 {
+				select {
+				case <-events:
+				case err := <-errCh:
+					require.Fail("stream is failed", err)
+				}
+			}
+			cancel()
+			require.NoError(<-errCh)
+			require.NoError(<-errCh)
+		})
+	})
+}
+
+type testApp struct {
+	t      *testing.T
+	as     istructs.IAppStructs
+	asp    istructs.IAppStructsProvider
+	broker in10n.IN10nBroker
+	cdc    ICDC
+	plog   istructs.Offset
+	wlog   istructs.Offset
+	lock   sync.Mutex
+	id     istructs.RecordID
+}
+
+func newTestApp(t *testing.T) *testApp {
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+	wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+	wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+	wsb.AddCDoc(qNameDoc).AddField("name", appdef.DataKind_string, true)
+	wsb.AddODoc(qNameParam).AddField("number", appdef.DataKind_int32, true)
+	wsb.AddCommand(qNameCmd).SetParam(qNameParam)
+
+	cfgs := make(istructsmem.AppConfigsType, 1)
+	cfg := cfgs.AddBuiltInAppConfig(testAppQName, adb)
+	cfg.SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory,
+		payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()), provider.Provide(mem.Provide(testingu.MockTime)), isequencer.SequencesTrustLevel_0)
+	as, err := asp.BuiltIn(testAppQName)
+	require.NoError(t, err)
+
+	broker, cleanup := in10nmem.NewN10nBroker(in10n.Quotas{
+		Channels:                10,
+		ChannelsPerSubject:      10,
+		Subscriptions:           10,
+		SubscriptionsPerSubject: 10,
+	}, testingu.MockTime)
+	t.Cleanup(cleanup)
+
+	app := &testApp{
+		t:      t,
+		as:     as,
+		asp:    asp,
+		broker: broker,
+		cdc:    Provide(asp, broker),
+		plog:   istructs.FirstOffset,
+		wlog:   istructs.FirstOffset,
+		id:     istructs.FirstUserRecordID,
+	}
+	return app
+}
+
+func (app *testApp) read(params StreamParams) (events []Event) {
+	require.NoError(app.t, app.cdc.Stream(context.Background(), params, func(event Event) error {
+		events = append(events, event)
+		return nil
+	}))
+	return events
+}
+
+func (app *testApp) putDoc(name string) {
+	app.put(istructs.QNameCommandCUD, nil, func(reb istructs.IRawEventBuilder) {
+		doc := reb.CUDBuilder().Create(qNameDoc)
+		doc.PutRecordID(appdef.SystemField_ID, app.id)
+		doc.PutString("name", name)
+		app.id++
+	})
+}
+
+func (app *testApp) putCmd(number int32) {
+	app.put(qNameCmd, nil, func(reb istructs.IRawEventBuilder) {
+		arg := reb.ArgumentObjectBuilder()
+		arg.PutRecordID(appdef.SystemField_ID, app.id)
+		arg.PutInt32("number", number)
+		app.id++
+	})
+}
+
+func (app *testApp) putError() {
+	app.put(qNameCmd, errors.New("test error"), func(istructs.IRawEventBuilder) {})
+}
+
+// puts the event and notifies about the PLog update as the command processor does
+func (app *testApp) put(qName appdef.QName, buildErr error, fill func(reb istructs.IRawEventBuilder)) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	require := require.New(app.t)
+	reb := app.as.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+		GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+			HandlingPartition: testPartition,
+			PLogOffset:        app.plog,
+			Workspace:         testWSID,
+			WLogOffset:        app.wlog,
+			QName:             qName,
+			RegisteredAt:      istructs.UnixMilli(app.wlog),
+		},
+		SyncedAt: istructs.UnixMilli(app.wlog),
+	})
+	fill(reb)
+	rawEvent, err := reb.BuildRawEvent()
+	if buildErr == nil {
+		require.NoError(err)
+	}
+	plogEvent, err := app.as.Events().PutPlog(rawEvent, buildErr, istructsmem.NewIDGenerator())
+	require.NoError(err)
+	if buildErr == nil {
+		require.NoError(app.as.Records().Apply(plogEvent))
+	}
+	require.NoError(app.as.Events().PutWlog(plogEvent))
+	plogEvent.Release()
+	app.broker.Update(in10n.ProjectionKey{
+		App:        testAppQName,
+		Projection: actualizers.PLogUpdatesQName,
+		WS:         istructs.WSID(testPartition),
+	}, app.plog)
+	app.plog++
+	app.wlog++
+}
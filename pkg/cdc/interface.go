@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// ICDC provides the change feed of applications PLog partitions
+//
+// Events are streamed in the PLog order, so the feed is durable as the PLog is
+// Consumers resume the stream from the committed offset
+type ICDC interface {
+	// Streams events of the partition to cb
+	// Returns when the limit is reached, ctx is done or cb returns an error
+	// Errors: ErrInvalidConsumer, ErrInvalidOffset
+	Stream(ctx context.Context, params StreamParams, cb EventCallback) error
+
+	// Returns the last offset committed by the consumer
+	// NullOffset if nothing is committed
+	// Errors: ErrInvalidConsumer
+	CommittedOffset(app appdef.AppQName, partition istructs.PartitionID, consumer string) (istructs.Offset, error)
+
+	// Commits the offset of the last event handled by the consumer
+	// The committed offset never moves backwards, use StreamParams.From to re-read events
+	// Commits of the same consumer and partition are serialized within the VVM only,
+	// so the consumer must commit through one VVM at a time, otherwise the offset could move backwards
+	// @ConcurrentAccess
+	// Errors: ErrInvalidConsumer, ErrInvalidOffset, ErrOffsetBehind
+	Commit(app appdef.AppQName, partition istructs.PartitionID, consumer string, offset istructs.Offset) error
+}
@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import (
+	"sync"
+
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Provide s.e.
+// broker is used to be notified about new PLog events if the stream follows the partition
+func Provide(appStructsProvider istructs.IAppStructsProvider, broker in10n.IN10nBroker) ICDC {
+	return &implICDC{
+		appStructsProvider: appStructsProvider,
+		broker:             broker,
+		commitLocks:        map[commitKey]*sync.Mutex{},
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cdc
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type StreamParams struct {
+	App       appdef.AppQName
+	Partition istructs.PartitionID
+
+	// Stream starts from the event which follows the offset committed by the consumer
+	// Could be empty if From is set
+	Consumer string
+
+	// Stream starts from the event with the offset if not NullOffset
+	// Has the priority over the consumer committed offset
+	From istructs.Offset
+
+	// true -> new events are waited for when the end of the partition is reached
+	// false -> stream is finished when the end of the partition is reached
+	Follow bool
+
+	// Max number of events to stream, 0 -> unlimited
+	Limit int
+}
+
+type EventCallback func(event Event) error
+
+// Event is the PLog event of the change feed
+type Event struct {
+	Partition    istructs.PartitionID       `json:"partition"`
+	PLogOffset   istructs.Offset            `json:"plogOffset"`
+	WSID         istructs.WSID              `json:"wsid"`
+	WLogOffset   istructs.Offset            `json:"wlogOffset"`
+	QName        string                     `json:"qname"`
+	RegisteredAt istructs.UnixMilli         `json:"registeredAt"`
+	DeviceID     istructs.ConnectedDeviceID `json:"deviceID,omitempty"`
+	Synced       bool                       `json:"synced,omitempty"`
+	SyncedAt     istructs.UnixMilli         `json:"syncedAt,omitempty"`
+	Args         map[string]interface{}     `json:"args,omitempty"`
+	CUDs         []map[string]interface{}   `json:"cuds,omitempty"`
+	Error        *EventError                `json:"error,omitempty"`
+}
+
+// EventError describes the not valid event which is stored in the PLog but is not applied
+type EventError struct {
+	ErrStr          string `json:"errStr"`
+	QNameFromParams string `json:"qnameFromParams"`
+}
+
+type implICDC struct {
+	appStructsProvider istructs.IAppStructsProvider
+	broker             in10n.IN10nBroker
+	anonymousStreams   atomic.Uint64
+
+	// serializes read-check-put of the committed offset
+	commitLocksMu sync.Mutex
+	commitLocks   map[commitKey]*sync.Mutex
+}
+
+type commitKey struct {
+	app       appdef.AppQName
+	partition istructs.PartitionID
+	consumer  string
+}
@@ -24,14 +24,22 @@ const (
 	Field_NumAppWorkspaces = "NumAppWorkspaces"
 	field_Query            = "Query"
 	field_NewID            = "NewID"
+	field_WSID             = "WSID"
+	field_FilePath         = "FilePath"
+	field_Events           = "Events"
+	field_Records          = "Records"
+	field_ViewRecords      = "ViewRecords"
+	field_BLOBs            = "BLOBs"
 )
 
 var (
-	qNameWDocApp          = appdef.NewQName(ClusterPackage, "App")
-	plog                  = appdef.NewQName(appdef.SysPackage, "PLog")
-	wlog                  = appdef.NewQName(appdef.SysPackage, "WLog")
-	qNameVSqlUpdateResult = appdef.NewQName(ClusterPackage, "VSqlUpdateResult")
-	updateDeniedFields    = map[string]bool{
+	qNameWDocApp                 = appdef.NewQName(ClusterPackage, "App")
+	plog                         = appdef.NewQName(appdef.SysPackage, "PLog")
+	wlog                         = appdef.NewQName(appdef.SysPackage, "WLog")
+	qNameVSqlUpdateResult        = appdef.NewQName(ClusterPackage, "VSqlUpdateResult")
+	qNameWSBackupResult          = appdef.NewQName(ClusterPackage, "WorkspaceBackupResult")
+	qNameAPApplyRestoreWorkspace = appdef.NewQName(ClusterPackage, "ApplyRestoreWorkspace")
+	updateDeniedFields           = map[string]bool{
 		appdef.SystemField_ID:    true,
 		appdef.SystemField_QName: true,
 	}
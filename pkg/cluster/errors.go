@@ -12,4 +12,8 @@ var (
 	ErrNumAppWorkspacesChanged = errors.New("num application workspaces changed")
 	errWrongWhereForView       = errors.New("'where viewField1 = val1 [and viewField2 = val2 ...]' condition is only supported")
 	errNullValueNoSupported    = errors.New("null value is not supported")
+
+	ErrWorkspaceBackupPathNotConfigured = errors.New("workspace backup path is not configured")
+	ErrWorkspaceBackupPathNotLocal      = errors.New("archive path must be relative to the workspace backup path")
+	ErrPartitionDeployed                = errors.New("partition must be stopped on all VVMs of the cluster to import the workspace")
 )
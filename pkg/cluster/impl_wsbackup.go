@@ -0,0 +1,303 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/iblobstoragestg"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/wsbackup"
+)
+
+// view records are read by scanning all partition keys of the application storage, so the backup takes time
+// proportional to the application size, not to the workspace size. It is a full table scan on Cassandra and DynamoDB
+func provideCmdBackupWorkspace(asp istructs.IAppStructsProvider, storageProvider istorage.IAppStorageProvider, time timeu.ITime,
+	backupPath WorkspaceBackupPath) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		appStructs, wsid, fileName, err := parseWorkspaceBackupArgs(args.ArgumentObject, asp)
+		if err != nil {
+			return err
+		}
+		root, err := openWorkspaceBackupRoot(backupPath)
+		if err != nil {
+			return err
+		}
+		defer root.Close()
+		blobStorage, err := provideBLOBStorage(storageProvider, time)
+		if err != nil {
+			// notest
+			return err
+		}
+
+		f, err := root.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, coreutils.FileMode_rw_rw_rw_)
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		m, err := wsbackup.Export(context.Background(), wsbackup.ExportParams{
+			AppStructs:  appStructs,
+			WSID:        wsid,
+			BLOBStorage: blobStorage,
+		}, f)
+		if err = errors.Join(err, f.Close()); err != nil {
+			_ = root.Remove(fileName)
+			return fmt.Errorf("failed to backup workspace %d of %s: %w", wsid, appStructs.AppQName(), err)
+		}
+		return putWorkspaceBackupResult(args, m.Events, m.Records, m.ViewRecords, m.BLOBs)
+	}
+}
+
+// the archive of the same workspace is restored in place by ApplyRestoreWorkspace projector, the command validates the archive and returns the changes to be made
+// the archive of another workspace is imported into the empty target workspace which partition must be stopped
+func provideCmdRestoreWorkspace(asp istructs.IAppStructsProvider, storageProvider istorage.IAppStorageProvider, time timeu.ITime,
+	backupPath WorkspaceBackupPath) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		appStructs, wsid, fileName, err := parseWorkspaceBackupArgs(args.ArgumentObject, asp)
+		if err != nil {
+			return err
+		}
+		root, err := openWorkspaceBackupRoot(backupPath)
+		if err != nil {
+			return err
+		}
+		defer root.Close()
+		blobStorage, err := provideBLOBStorage(storageProvider, time)
+		if err != nil {
+			// notest
+			return err
+		}
+
+		f, err := root.Open(fileName)
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		defer f.Close()
+		stat, err := f.Stat()
+		if err != nil {
+			// notest
+			return err
+		}
+		m, err := wsbackup.ReadManifest(f, stat.Size())
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if m.WSID == wsid {
+			res, err := wsbackup.Restore(context.Background(), wsbackup.RestoreParams{
+				AppStructs:  appStructs,
+				WSID:        wsid,
+				BLOBStorage: blobStorage,
+				DryRun:      true,
+			}, f, stat.Size())
+			if err != nil {
+				err = fmt.Errorf("failed to restore workspace %d of %s: %w", wsid, appStructs.AppQName(), err)
+				if errors.Is(err, wsbackup.ErrAppMismatch) {
+					return coreutils.NewHTTPError(http.StatusConflict, err)
+				}
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+			return putWorkspaceBackupResult(args, res.Events, res.Records, 0, res.BLOBs)
+		}
+
+		appParts := args.Workpiece.(interface {
+			AppPartitions() appparts.IAppPartitions
+		}).AppPartitions()
+		partitionID, err := appParts.AppWorkspacePartitionID(appStructs.AppQName(), wsid)
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if err := checkPartitionStopped(appParts, appStructs.AppQName(), partitionID); err != nil {
+			return err
+		}
+		// the sequencer actualizes the workspace sequences from the imported PLog events on the partition recovery
+		m, err = wsbackup.Import(context.Background(), wsbackup.ImportParams{
+			AppStructs:  appStructs,
+			WSID:        wsid,
+			Partition:   partitionID,
+			BLOBStorage: blobStorage,
+		}, f, stat.Size())
+		if err != nil {
+			err = fmt.Errorf("failed to import workspace %d of %s: %w", wsid, appStructs.AppQName(), err)
+			if errors.Is(err, wsbackup.ErrWorkspaceNotEmpty) || errors.Is(err, wsbackup.ErrAppMismatch) {
+				return coreutils.NewHTTPError(http.StatusConflict, err)
+			}
+			return err
+		}
+		return putWorkspaceBackupResult(args, m.Events, m.Records, m.ViewRecords, m.BLOBs)
+	}
+}
+
+// AFTER EXECUTE ON (RestoreWorkspace)
+// CUDs are sent to the workspace asynchronously, otherwise c.sys.CUD could wait for the command processor which executes c.cluster.RestoreWorkspace
+// the restore is idempotent, so the failed one is retried by the actualizer
+func provideAsyncProjectorApplyRestoreWorkspace(asp istructs.IAppStructsProvider, storageProvider istorage.IAppStorageProvider, time timeu.ITime,
+	backupPath WorkspaceBackupPath, federation federation.IFederation, itokens itokens.ITokens) istructs.Projector {
+	return istructs.Projector{
+		Name: qNameAPApplyRestoreWorkspace,
+		CtxFunc: func(ctx context.Context, event istructs.IPLogEvent, _ istructs.IState, _ istructs.IIntents) (err error) {
+			appStructs, wsid, fileName, err := parseWorkspaceBackupArgs(event.ArgumentObject(), asp)
+			if err != nil {
+				// notest: validated by the command
+				return err
+			}
+			root, err := openWorkspaceBackupRoot(backupPath)
+			if err != nil {
+				// notest: validated by the command
+				return err
+			}
+			defer root.Close()
+			f, err := root.Open(fileName)
+			if err != nil {
+				// the archive is removed after the command, the retry would fail the same way
+				logger.Error(fmt.Sprintf("failed to restore workspace %d of %s: %s", wsid, appStructs.AppQName(), err))
+				return nil
+			}
+			defer f.Close()
+			stat, err := f.Stat()
+			if err != nil {
+				// notest
+				return err
+			}
+			m, err := wsbackup.ReadManifest(f, stat.Size())
+			if err != nil {
+				logger.Error(fmt.Sprintf("failed to restore workspace %d of %s: archive %s is replaced after the command: %s", wsid, appStructs.AppQName(), fileName, err))
+				return nil
+			}
+			if m.WSID != wsid {
+				// imported by the command
+				logger.Verbose(fmt.Sprintf("workspace %d of %s is imported from %s by the command", wsid, appStructs.AppQName(), fileName))
+				return nil
+			}
+			blobStorage, err := provideBLOBStorage(storageProvider, time)
+			if err != nil {
+				// notest
+				return err
+			}
+			res, err := wsbackup.Restore(ctx, wsbackup.RestoreParams{
+				AppStructs:  appStructs,
+				WSID:        wsid,
+				BLOBStorage: blobStorage,
+				SendCUDs:    provideSendCUDs(federation, itokens, appStructs.AppQName(), wsid),
+			}, f, stat.Size())
+			if err != nil {
+				return fmt.Errorf("failed to restore workspace %d of %s: %w", wsid, appStructs.AppQName(), err)
+			}
+			logger.Info(fmt.Sprintf("workspace %d of %s restored from %s: %+v", wsid, appStructs.AppQName(), fileName, res))
+			return nil
+		},
+	}
+}
+
+// CUDs are sent to the workspace by c.sys.CUD on behalf of the system
+func provideSendCUDs(federation federation.IFederation, itokens itokens.ITokens, appQName appdef.AppQName, wsid istructs.WSID) func(context.Context, []wsbackup.CUD) error {
+	return func(_ context.Context, cuds []wsbackup.CUD) error {
+		body, err := json.Marshal(map[string]any{"cuds": cuds})
+		if err != nil {
+			// notest
+			return err
+		}
+		sysToken, err := payloads.GetSystemPrincipalToken(itokens, appQName)
+		if err != nil {
+			// notest
+			return err
+		}
+		_, err = federation.Func(fmt.Sprintf("api/%s/%d/c.sys.CUD", appQName, wsid), string(body),
+			coreutils.WithAuthorizeBy(sysToken),
+			coreutils.WithDiscardResponse(),
+		)
+		return err
+	}
+}
+
+// only the applications configured on the VVM (builtin and sidecar ones) are supported
+func parseWorkspaceBackupArgs(arg istructs.IObject, asp istructs.IAppStructsProvider) (appStructs istructs.IAppStructs, wsid istructs.WSID, fileName string, err error) {
+	appQNameStr := arg.AsString(Field_AppQName)
+	appQName, err := appdef.ParseAppQName(appQNameStr)
+	if err != nil {
+		return nil, 0, "", coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("failed to parse AppQName %s: %s", appQNameStr, err.Error()))
+	}
+	if appStructs, err = asp.BuiltIn(appQName); err != nil {
+		return nil, 0, "", coreutils.NewHTTPError(http.StatusBadRequest, err)
+	}
+	wsidInt := arg.AsInt64(field_WSID)
+	if wsidInt <= 0 {
+		return nil, 0, "", coreutils.NewHTTPErrorf(http.StatusBadRequest, "WSID must be >0")
+	}
+	fileName = arg.AsString(field_FilePath)
+	if !filepath.IsLocal(fileName) {
+		return nil, 0, "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrWorkspaceBackupPathNotLocal, fileName))
+	}
+	return appStructs, istructs.WSID(wsidInt), fileName, nil
+}
+
+// the archive is opened through os.Root, so symlinks leading out of the backup path are not followed
+func openWorkspaceBackupRoot(backupPath WorkspaceBackupPath) (*os.Root, error) {
+	if len(backupPath) == 0 {
+		return nil, coreutils.NewHTTPError(http.StatusForbidden, ErrWorkspaceBackupPathNotConfigured)
+	}
+	root, err := os.OpenRoot(string(backupPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workspace backup path: %w", err)
+	}
+	return root, nil
+}
+
+// the events are imported to the PLog of the partition directly, so the command processor and the actualizers
+// of the partition must not work, see VVMConfig.MaintenanceApps
+// only the VVM executing the command is checked, stopping the partition on the other VVMs is up to the operator
+func checkPartitionStopped(appParts appparts.IAppPartitions, appQName appdef.AppQName, partitionID istructs.PartitionID) error {
+	part, err := appParts.Borrow(appQName, partitionID, appparts.ProcessorKind_Command)
+	if errors.Is(err, appparts.ErrNotFound) {
+		return nil
+	}
+	if err == nil {
+		part.Release()
+	}
+	return coreutils.NewHTTPError(http.StatusConflict, fmt.Errorf("%w: partition %d of %s", ErrPartitionDeployed, partitionID, appQName))
+}
+
+// blobber app storage is initialized on VVM bootstrap, so it is got on the command execution
+func provideBLOBStorage(storageProvider istorage.IAppStorageProvider, time timeu.ITime) (iblobstorage.IBLOBStorage, error) {
+	blobberAppStorage, err := storageProvider.AppStorage(istructs.AppQName_sys_blobber)
+	if err != nil {
+		return nil, err
+	}
+	return iblobstoragestg.Provide(&blobberAppStorage, time), nil
+}
+
+func putWorkspaceBackupResult(args istructs.ExecCommandArgs, events, records, viewRecords, blobs int) error {
+	kb, err := args.State.KeyBuilder(sys.Storage_Result, qNameWSBackupResult)
+	if err != nil {
+		// notest
+		return err
+	}
+	result, err := args.Intents.NewValue(kb)
+	if err != nil {
+		// notest
+		return err
+	}
+	result.PutInt32(field_Events, int32(events))           // nolint G115
+	result.PutInt32(field_Records, int32(records))         // nolint G115
+	result.PutInt32(field_ViewRecords, int32(viewRecords)) // nolint G115
+	result.PutInt32(field_BLOBs, int32(blobs))             // nolint G115
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -17,11 +18,19 @@ import (
 )
 
 func Provide(cfg *istructsmem.AppConfigType, asp istructs.IAppStructsProvider, time timeu.ITime,
-	federation federation.IFederation, itokens itokens.ITokens, sidecarApps []appparts.SidecarApp) parser.PackageFS {
+	federation federation.IFederation, itokens itokens.ITokens, sidecarApps []appparts.SidecarApp,
+	storageProvider istorage.IAppStorageProvider, backupPath WorkspaceBackupPath) parser.PackageFS {
 	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName(ClusterPackage, "DeployApp"),
 		provideCmdDeployApp(asp, time, sidecarApps)))
 	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName(ClusterPackage, "VSqlUpdate"),
 		provideExecCmdVSqlUpdate(federation, itokens, time, asp)))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName(ClusterPackage, "BackupWorkspace"),
+		provideCmdBackupWorkspace(asp, storageProvider, time, backupPath)))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName(ClusterPackage, "RestoreWorkspace"),
+		provideCmdRestoreWorkspace(asp, storageProvider, time, backupPath)))
+	cfg.AddAsyncProjectors(
+		provideAsyncProjectorApplyRestoreWorkspace(asp, storageProvider, time, backupPath, federation, itokens),
+	)
 	return parser.PackageFS{
 		Path: ClusterPackageFQN,
 		FS:   schemaFS,
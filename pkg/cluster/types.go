@@ -12,6 +12,10 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
+// host folder the archives of c.cluster.BackupWorkspace and c.cluster.RestoreWorkspace are kept in
+// empty -> the commands are not available
+type WorkspaceBackupPath string
+
 type update struct {
 	dml.Op
 	setFields     map[string]interface{}
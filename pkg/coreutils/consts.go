@@ -23,6 +23,13 @@ const (
 	ContentType_TextPlain                         = "text/plain"
 	ContentType_TextHTML                          = "text/html"
 	ContentType_MultipartFormData                 = "multipart/form-data"
+	ContentType_TextEventStream                   = "text/event-stream"
+	ContentType_ApplicationNDJSON                 = "application/x-ndjson"
+	LastEventID                                   = "Last-Event-ID"
+	RateLimitLimit                                = "RateLimit-Limit"
+	RateLimitRemaining                            = "RateLimit-Remaining"
+	RateLimitReset                                = "RateLimit-Reset"
+	RetryAfter                                    = "Retry-After"
 	BearerPrefix                                  = "Bearer "
 	BlobName                                      = "Blob-Name"
 	Localhost                                     = "127.0.0.1"
@@ -44,6 +51,11 @@ const (
 	maxHTTPRequestTimeout                         = time.Hour
 	defaultRetryDelay                             = 500 * time.Millisecond
 	deviceLoginAndPwdLen                          = 26
+	recoveryCodeLen                               = 10
+	totpSecretLen                                 = 20 // 160 bits, as recommended by RFC 4226
+	TOTPPeriod                                    = 30 * time.Second
+	totpDigits                                    = 6
+	totpAllowedSkewSteps                          = 1
 )
 
 var (
@@ -37,6 +37,21 @@ func (d DataWithExpiration) IsExpired(now time.Time) bool {
 	return d.ExpireAt > 0 && !now.Before(time.UnixMilli(d.ExpireAt))
 }
 
+// returns the remaining TTL as IAppStorage.QueryTTL does: 0 if no expiration, ok == false if expired
+func (d DataWithExpiration) TTLInSeconds(now time.Time) (ttlInSeconds int, ok bool) {
+	if d.IsExpired(now) {
+		return 0, false
+	}
+	if d.ExpireAt <= 0 {
+		return 0, true
+	}
+	ttlInSeconds = int(time.UnixMilli(d.ExpireAt).Sub(now).Seconds())
+	if ttlInSeconds <= 0 {
+		return 0, false
+	}
+	return ttlInSeconds, true
+}
+
 func (d DataWithExpiration) Update(data []byte) DataWithExpiration {
 	d.ExpireAt = int64(binary.BigEndian.Uint64(data[:utils.Uint64Size])) // nolint G115
 	d.Data = data[utils.Uint64Size:]
@@ -44,6 +44,22 @@ func TestDataWithExpiration_BasicUsage(t *testing.T) {
 		require.True(dwe.IsExpired(testingu.MockTime.Now()))
 	})
 
+	t.Run("TTL in seconds", func(t *testing.T) {
+		now := time.UnixMilli(testingu.MockTime.Now().UnixMilli())
+		dwe := DataWithExpiration{ExpireAt: now.Add(10 * time.Second).UnixMilli()}
+		ttl, ok := dwe.TTLInSeconds(now)
+		require.True(ok)
+		require.Equal(10, ttl)
+
+		ttl, ok = dwe.TTLInSeconds(now.Add(10 * time.Second))
+		require.False(ok)
+		require.Zero(ttl)
+
+		ttl, ok = DataWithExpiration{}.TTLInSeconds(now)
+		require.True(ok)
+		require.Zero(ttl)
+	})
+
 	t.Run("no expiration", func(t *testing.T) {
 		expirations := []int64{0, -1}
 		for _, exp := range expirations {
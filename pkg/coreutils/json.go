@@ -59,7 +59,7 @@ func ClarifyJSONNumber(value json.Number, kind appdef.DataKind) (val interface{}
 			return nil, errNumberOverflow(value, kind.TrimString())
 		}
 		return int32(int64Val), nil
-	case appdef.DataKind_int64:
+	case appdef.DataKind_int64, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
 		int64Val, err := value.Int64()
 		if err != nil {
 			return nil, errFailedToCast(value, kind.TrimString(), err)
@@ -40,6 +40,9 @@ func TestClarifyJSONNumber(t *testing.T) {
 		{json.Number("1"), appdef.DataKind_float32, float32(1)},
 		{json.Number("1"), appdef.DataKind_float64, float64(1)},
 		{json.Number("1"), appdef.DataKind_RecordID, istructs.RecordID(1)},
+		{json.Number("1"), appdef.DataKind_timestamp, int64(1)},
+		{json.Number("1"), appdef.DataKind_date, int64(1)},
+		{json.Number("1"), appdef.DataKind_interval, int64(1)},
 	}
 
 	for _, c := range cases {
@@ -7,8 +7,10 @@ package coreutils
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -44,6 +46,17 @@ func ReadByKind(name appdef.FieldName, kind appdef.DataKind, rr istructs.IRowRea
 		return rr.AsQName(name) // not .String(), see https://github.com/voedger/voedger/issues/3477
 	case appdef.DataKind_bool:
 		return rr.AsBool(name)
+	case appdef.DataKind_decimal:
+		return rr.AsString(name) // exact decimal string, e.g. "123.45"
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		return rr.AsString(name) // RFC 3339 or ISO 8601 duration string
+	case appdef.DataKind_uuid:
+		return rr.AsString(name) // canonical UUID string
+	case appdef.DataKind_json:
+		if s := rr.AsString(name); len(s) > 0 {
+			return json.RawMessage(s) // embedded JSON value, not a string
+		}
+		return nil
 	default:
 		panic("unsupported kind " + fmt.Sprint(kind) + " for field " + name)
 	}
@@ -213,7 +226,7 @@ func CheckValueByKind(val interface{}, kind appdef.DataKind) error {
 	case int32:
 		ok = kind == appdef.DataKind_int32
 	case int64:
-		ok = kind == appdef.DataKind_int64 || kind == appdef.DataKind_RecordID
+		ok = kind == appdef.DataKind_int64 || kind == appdef.DataKind_RecordID || kind == appdef.DataKind_decimal || kind.IsTemporal()
 	case float32:
 		ok = kind == appdef.DataKind_float32
 	case float64:
@@ -221,14 +234,27 @@ func CheckValueByKind(val interface{}, kind appdef.DataKind) error {
 	case bool:
 		ok = kind == appdef.DataKind_bool
 	case string:
-		if kind == appdef.DataKind_QName {
+		switch kind {
+		case appdef.DataKind_QName:
 			_, err := appdef.ParseQName(typed)
 			ok = err == nil
-		} else {
+		case appdef.DataKind_decimal:
+			_, ok = new(big.Rat).SetString(typed)
+		case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+			_, err := ParseTemporal(kind, typed)
+			ok = err == nil
+		case appdef.DataKind_uuid:
+			_, err := utils.ParseUUID(typed)
+			ok = err == nil
+		case appdef.DataKind_json:
+			ok = json.Valid([]byte(typed))
+		default:
 			ok = kind == appdef.DataKind_string
 		}
 	case []byte:
-		ok = kind == appdef.DataKind_bytes
+		ok = kind == appdef.DataKind_bytes || (kind == appdef.DataKind_uuid && len(typed) == utils.UUIDSize)
+	case json.RawMessage:
+		ok = kind == appdef.DataKind_json && json.Valid(typed)
 	case istructs.RecordID:
 		ok = kind == appdef.DataKind_RecordID || kind == appdef.DataKind_int64
 	case appdef.QName:
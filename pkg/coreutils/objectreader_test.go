@@ -5,6 +5,7 @@
 package coreutils
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -190,6 +191,25 @@ func TestReadValue(t *testing.T) {
 	})
 }
 
+func TestReadByKind_JSON(t *testing.T) {
+	require := require.New(t)
+
+	obj := &TestObject{Data: map[string]interface{}{"settings": `{"a":[1,2]}`, "empty": ""}}
+
+	t.Run("json field should be embedded JSON value", func(t *testing.T) {
+		v := ReadByKind("settings", appdef.DataKind_json, obj)
+		require.Equal(json.RawMessage(`{"a":[1,2]}`), v)
+
+		b, err := json.Marshal(map[string]interface{}{"settings": v})
+		require.NoError(err)
+		require.JSONEq(`{"settings":{"a":[1,2]}}`, string(b))
+	})
+
+	t.Run("empty json field should be nil", func(t *testing.T) {
+		require.Nil(ReadByKind("empty", appdef.DataKind_json, obj))
+	})
+}
+
 func TestObjectReaderErrors(t *testing.T) {
 	require := require.New(t)
 	require.Panics(func() { ReadByKind("", appdef.DataKind_FakeLast, nil) })
@@ -237,6 +257,16 @@ func TestCheckValueByKind(t *testing.T) {
 		{istructs.RecordID(10), appdef.DataKind_RecordID},
 		{int64(11), appdef.DataKind_RecordID},
 		{appdef.NewQName("1", "1"), appdef.DataKind_QName},
+		{"-123.45", appdef.DataKind_decimal},
+		{int64(12345), appdef.DataKind_decimal},
+		{"2025-03-01T10:20:30.123Z", appdef.DataKind_timestamp},
+		{int64(1740824430123), appdef.DataKind_timestamp},
+		{"2025-03-01", appdef.DataKind_date},
+		{"PT1H30M", appdef.DataKind_interval},
+		{"123e4567-e89b-12d3-a456-426614174000", appdef.DataKind_uuid},
+		{make([]byte, 16), appdef.DataKind_uuid},
+		{`{"a":[1,2]}`, appdef.DataKind_json},
+		{json.RawMessage(`"str"`), appdef.DataKind_json},
 	}
 	for _, c := range okCases {
 		t.Run(fmt.Sprintf("%v", c.val), func(t *testing.T) {
@@ -244,6 +274,18 @@ func TestCheckValueByKind(t *testing.T) {
 		})
 	}
 
+	t.Run("invalid temporal strings", func(t *testing.T) {
+		require.Error(t, CheckValueByKind("2025-03-01", appdef.DataKind_timestamp))
+		require.Error(t, CheckValueByKind("P1Y", appdef.DataKind_interval))
+	})
+
+	t.Run("invalid uuid and json values", func(t *testing.T) {
+		require.Error(t, CheckValueByKind("123e4567e89b12d3a456426614174000", appdef.DataKind_uuid))
+		require.Error(t, CheckValueByKind([]byte{1, 2, 3}, appdef.DataKind_uuid))
+		require.Error(t, CheckValueByKind(`{"a":`, appdef.DataKind_json))
+		require.Error(t, CheckValueByKind(json.RawMessage(`{"a":1}`), appdef.DataKind_string))
+	})
+
 	t.Run("not ok", func(t *testing.T) {
 		for kind := appdef.DataKind(1); kind < appdef.DataKind_FakeLast; kind++ {
 			t.Run(kind.String(), func(t *testing.T) {
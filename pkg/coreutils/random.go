@@ -17,6 +17,11 @@ func EmailVerificationCode() (verificationCode string) {
 	return randomString(emailVerificationCodeAlphabet, emailVerificationCodeLength)
 }
 
+// single-use code to be kept by the user in case the TOTP device is lost
+func RecoveryCode() string {
+	return randomString(lowercaseDigitsAlphabet, recoveryCodeLen)
+}
+
 func randomString(alphabet string, l int) string {
 	src := make([]byte, l)
 	_, err := rand.Read(src)
@@ -27,4 +27,8 @@ func TestRandom(t *testing.T) {
 	log.Println(evc)
 	evc1 := EmailVerificationCode()
 	require.NotEqual(t, evc, evc1)
+
+	rc := RecoveryCode()
+	require.Len(t, rc, recoveryCodeLen)
+	require.NotEqual(t, rc, RecoveryCode())
 }
@@ -8,9 +8,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/irates"
 )
 
 type SysError struct {
@@ -18,12 +22,31 @@ type SysError struct {
 	QName      appdef.QName
 	Message    string
 	Data       string
+
+	// key-value pairs of HTTP headers to be sent with the error, not serialized to JSON
+	Headers []string
 }
 
 func NewSysError(statusCode int) error {
 	return SysError{HTTPStatus: statusCode}
 }
 
+// 429 with RateLimit-* and Retry-After headers, state is the state of the exceeded bucket
+//
+// the exceeded bucket could be not empty if there are not enough tokens for the request, so RateLimit-Remaining is 0 anyway
+func NewTooManyRequestsError(state irates.BucketState) SysError {
+	retryAfter := durationToSeconds(state.TokenInterval())
+	return SysError{
+		HTTPStatus: http.StatusTooManyRequests,
+		Headers: []string{
+			RateLimitLimit, strconv.FormatUint(uint64(state.MaxTokensPerPeriod), 10),
+			RateLimitRemaining, "0",
+			RateLimitReset, strconv.Itoa(max(retryAfter, durationToSeconds(state.ResetAfter()))),
+			RetryAfter, strconv.Itoa(retryAfter),
+		},
+	}
+}
+
 func WrapSysErrorToExact(err error, defaultStatusCode int) SysError {
 	if err == nil {
 		return SysError{}
@@ -73,3 +96,8 @@ func (he SysError) ToJSON_APIV2() string {
 	b.WriteString("}")
 	return b.String()
 }
+
+// rounded up, at least 1 second
+func durationToSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
@@ -9,10 +9,12 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/irates"
 )
 
 func TestBasicUsage_SysError(t *testing.T) {
@@ -83,4 +85,25 @@ func TestBasicUsage_SysError(t *testing.T) {
 		sysErr := SysError{}
 		require.Empty(sysErr.Error())
 	})
+
+	t.Run("NewTooManyRequestsError", func(t *testing.T) {
+		sysErr := NewTooManyRequestsError(irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 4, TakenTokens: 3})
+		require.Equal(http.StatusTooManyRequests, sysErr.HTTPStatus)
+		require.Equal([]string{
+			RateLimitLimit, "4",
+			RateLimitRemaining, "0",
+			RateLimitReset, "45",
+			RetryAfter, "15",
+		}, sysErr.Headers)
+
+		t.Run("at least 1 second", func(t *testing.T) {
+			sysErr := NewTooManyRequestsError(irates.BucketState{Period: time.Second, MaxTokensPerPeriod: 100})
+			require.Equal([]string{
+				RateLimitLimit, "100",
+				RateLimitRemaining, "0",
+				RateLimitReset, "1",
+				RetryAfter, "1",
+			}, sysErr.Headers)
+		})
+	})
 }
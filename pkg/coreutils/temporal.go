@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+// Returns JSON string representation of temporal value, which is stored as int64 milliseconds:
+//   - timestamp: RFC 3339 date-time, e.g. "2025-01-31T10:20:30.123Z",
+//   - date: RFC 3339 full-date, e.g. "2025-01-31",
+//   - interval: ISO 8601 duration, e.g. "P1DT2H30M".
+//
+// Panics if kind is not temporal
+func FormatTemporal(kind appdef.DataKind, ms int64) string {
+	switch kind {
+	case appdef.DataKind_timestamp:
+		return utils.FormatTimestamp(ms)
+	case appdef.DataKind_date:
+		return utils.FormatDate(ms)
+	case appdef.DataKind_interval:
+		return utils.FormatInterval(ms)
+	}
+	panic(fmt.Sprintf("unsupported data kind %s for temporal value", kind.TrimString()))
+}
+
+// Parses JSON string representation of temporal value and returns int64 milliseconds.
+//
+// Panics if kind is not temporal
+func ParseTemporal(kind appdef.DataKind, s string) (int64, error) {
+	switch kind {
+	case appdef.DataKind_timestamp:
+		return utils.ParseTimestamp(s)
+	case appdef.DataKind_date:
+		return utils.ParseDate(s)
+	case appdef.DataKind_interval:
+		return utils.ParseInterval(s)
+	}
+	panic(fmt.Sprintf("unsupported data kind %s for temporal value", kind.TrimString()))
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+func TestTemporal(t *testing.T) {
+	require := require.New(t)
+
+	cases := []struct {
+		kind appdef.DataKind
+		ms   int64
+		s    string
+	}{
+		{appdef.DataKind_timestamp, 1740824430123, "2025-03-01T10:20:30.123Z"},
+		{appdef.DataKind_date, 1740787200000, "2025-03-01"},
+		{appdef.DataKind_interval, 90 * utils.MillisecondsPerMinute, "PT1H30M"},
+	}
+	for _, c := range cases {
+		require.Equal(c.s, FormatTemporal(c.kind, c.ms))
+		ms, err := ParseTemporal(c.kind, c.s)
+		require.NoError(err)
+		require.Equal(c.ms, ms)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ParseTemporal(appdef.DataKind_timestamp, "2025-03-01")
+		require.ErrorIs(err, utils.ErrInvalidTimestamp)
+		_, err = ParseTemporal(appdef.DataKind_date, "2025-03-01T10:20:30Z")
+		require.ErrorIs(err, utils.ErrInvalidDate)
+		_, err = ParseTemporal(appdef.DataKind_interval, "P1Y")
+		require.ErrorIs(err, utils.ErrInvalidInterval)
+
+		require.Panics(func() { FormatTemporal(appdef.DataKind_int64, 0) })
+		require.Panics(func() { _, _ = ParseTemporal(appdef.DataKind_string, "") })
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint G505 HMAC-SHA1 is required by RFC 6238 for compatibility with the authenticator apps
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+var totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func NewTOTPSecret() []byte {
+	secret := make([]byte, totpSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		// notest
+		panic(err)
+	}
+	return secret
+}
+
+// as it is entered into the authenticator app
+func TOTPSecretString(secret []byte) string {
+	return totpSecretEncoding.EncodeToString(secret)
+}
+
+// RFC 6238: HMAC-SHA1, 30 seconds period, 6 digits
+func TOTPCode(secret []byte, t time.Time) string {
+	return hotp(secret, totpCounter(t), totpDigits)
+}
+
+// the code of the previous and the next period is accepted as well to tolerate the clock skew
+func CheckTOTPCode(secret []byte, code string, t time.Time) bool {
+	_, ok := MatchTOTPCode(secret, code, t)
+	return ok
+}
+
+// returns the time step the code is generated for, so that the code could be accepted once
+func MatchTOTPCode(secret []byte, code string, t time.Time) (step uint64, ok bool) {
+	counter := totpCounter(t)
+	for skew := -totpAllowedSkewSteps; skew <= totpAllowedSkewSteps; skew++ {
+		candidate := counter + uint64(skew) // nolint G115 overflow is not a problem, the counter is used as the HMAC message only
+		expected := hotp(secret, candidate, totpDigits)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			step, ok = candidate, true
+		}
+	}
+	return step, ok
+}
+
+func totpCounter(t time.Time) uint64 {
+	return uint64(t.Unix() / int64(TOTPPeriod/time.Second)) // nolint G115 the time is after the epoch
+}
+
+// RFC 4226
+func hotp(secret []byte, counter uint64, digits int) string {
+	msg := make([]byte, 8) // nolint mnd
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	binCode := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, binCode%mod)
+}
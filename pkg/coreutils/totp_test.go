@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("RFC 6238 test vectors", func(t *testing.T) {
+		secret := []byte("12345678901234567890")
+		cases := []struct {
+			unix int64
+			code string
+		}{
+			{59, "94287082"},
+			{1111111109, "07081804"},
+			{1111111111, "14050471"},
+			{1234567890, "89005924"},
+			{2000000000, "69279037"},
+			{20000000000, "65353130"},
+		}
+		for _, c := range cases {
+			require.Equal(c.code, hotp(secret, totpCounter(time.Unix(c.unix, 0)), 8))
+			require.Equal(c.code[2:], TOTPCode(secret, time.Unix(c.unix, 0)))
+		}
+	})
+
+	t.Run("check", func(t *testing.T) {
+		secret := NewTOTPSecret()
+		require.Len(secret, totpSecretLen)
+		require.NotEqual(secret, NewTOTPSecret())
+		now := time.Unix(1700000000, 0)
+		code := TOTPCode(secret, now)
+		require.Len(code, totpDigits)
+		require.True(CheckTOTPCode(secret, code, now))
+		require.True(CheckTOTPCode(secret, code, now.Add(TOTPPeriod)))
+		require.True(CheckTOTPCode(secret, code, now.Add(-TOTPPeriod)))
+		require.False(CheckTOTPCode(secret, code, now.Add(3*TOTPPeriod)))
+		require.False(CheckTOTPCode(secret, "", now))
+		require.False(CheckTOTPCode(NewTOTPSecret(), code, now))
+	})
+
+	t.Run("match returns the step of the code", func(t *testing.T) {
+		secret := NewTOTPSecret()
+		now := time.Unix(1700000000, 0)
+		step, ok := MatchTOTPCode(secret, TOTPCode(secret, now), now.Add(TOTPPeriod))
+		require.True(ok)
+		require.Equal(totpCounter(now), step)
+		_, ok = MatchTOTPCode(secret, "000000x", now)
+		require.False(ok)
+	})
+
+	t.Run("secret string", func(t *testing.T) {
+		require.Equal("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", TOTPSecretString([]byte("12345678901234567890")))
+	})
+}
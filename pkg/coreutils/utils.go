@@ -40,6 +40,11 @@ func IsDynamoDBStorage() bool {
 	return ok
 }
 
+func IsPostgreSQLStorage() bool {
+	_, ok := os.LookupEnv("POSTGRES_TESTS_ENABLED")
+	return ok
+}
+
 func ServerAddress(port int) string {
 	addr := ""
 	if IsTest() {
@@ -8,6 +8,28 @@ package utils
 const (
 	DecimalBase = 10
 	BitSize64   = 64
+	BitSize16   = 16
 	Uint64Size  = 8
 	Uint32Size  = 4
 )
+
+const (
+	// the same as appdef.MaxDecimalPrecision, appdef could not be imported here
+	maxDecimalDigits = 18
+
+	// digits of math.MaxInt64
+	maxInt64Digits = 19
+)
+
+const (
+	// RFC 3339 date-time layout with fixed milliseconds. Values formatted in UTC are sorted lexicographically
+	TimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+	// RFC 3339 full-date layout
+	DateLayout = "2006-01-02"
+
+	MillisecondsPerSecond = int64(1000)
+	MillisecondsPerMinute = 60 * MillisecondsPerSecond
+	MillisecondsPerHour   = 60 * MillisecondsPerMinute
+	MillisecondsPerDay    = 24 * MillisecondsPerHour
+)
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Returns exact string representation of decimal value, which is stored as unscaled int64.
+//
+// FormatDecimal(-12345, 2) returns "-123.45"
+func FormatDecimal(unscaled int64, scale uint8) string {
+	if scale == 0 {
+		return IntToString(unscaled)
+	}
+	s := UintToString(absUint64(unscaled))
+	if l := int(scale) + 1; len(s) < l {
+		s = strings.Repeat("0", l-len(s)) + s
+	}
+	dot := len(s) - int(scale)
+	s = s[:dot] + "." + s[dot:]
+	if unscaled < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Parses decimal string and returns value scaled by 10^scale.
+//
+// Accepted grammar is [+-]digits[.digits][(e|E)[+-]digits], e.g. "1.5e2" is 150.
+// Exponent absolute value must not exceed maxDecimalDigits+scale.
+//
+// Returns error if:
+//   - string does not match the grammar,
+//   - exponent is out of range,
+//   - value has more fractional digits than scale,
+//   - scaled value is out of int64 range.
+func ParseDecimal(s string, scale uint8) (int64, error) {
+	rest, neg := s, false
+	if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+	}
+	intPart, rest := leadingDigits(rest)
+	if len(intPart) == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	fracPart := ""
+	if len(rest) > 0 && rest[0] == '.' {
+		if fracPart, rest = leadingDigits(rest[1:]); len(fracPart) == 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+	}
+	exp := 0
+	if len(rest) > 0 && (rest[0] == 'e' || rest[0] == 'E') {
+		rest = rest[1:]
+		expNeg := false
+		if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+			expNeg = rest[0] == '-'
+			rest = rest[1:]
+		}
+		expDigits := ""
+		if expDigits, rest = leadingDigits(rest); len(expDigits) == 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+		maxExp := maxDecimalDigits + int(scale)
+		// the length is checked first to avoid parsing of huge exponents
+		if expDigits = strings.TrimLeft(expDigits, "0"); len(expDigits) > len(strconv.Itoa(maxExp)) {
+			return 0, fmt.Errorf("%w: %q exponent is out of range ±%d", ErrInvalidDecimal, s, maxExp)
+		}
+		for _, d := range expDigits {
+			exp = exp*DecimalBase + int(d-'0')
+		}
+		if exp > maxExp {
+			return 0, fmt.Errorf("%w: %q exponent is out of range ±%d", ErrInvalidDecimal, s, maxExp)
+		}
+		if expNeg {
+			exp = -exp
+		}
+	}
+	if len(rest) > 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	// unscaled digits are shifted left by shift positions
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	shift := exp + int(scale) - len(fracPart)
+	if shift < 0 {
+		cut := min(-shift, len(digits))
+		if len(strings.TrimRight(digits[len(digits)-cut:], "0")) > 0 {
+			return 0, fmt.Errorf("%w: %q has more than %d fractional digits", ErrInvalidDecimal, s, scale)
+		}
+		digits = digits[:len(digits)-cut]
+	} else if len(digits) > 0 {
+		if len(digits)+shift > maxInt64Digits {
+			return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidDecimal, s)
+		}
+		digits += strings.Repeat("0", shift)
+	}
+	if len(digits) == 0 {
+		return 0, nil
+	}
+	if len(digits) > maxInt64Digits {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidDecimal, s)
+	}
+	if neg {
+		digits = "-" + digits
+	}
+	v, err := strconv.ParseInt(digits, DecimalBase, BitSize64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidDecimal, s)
+	}
+	return v, nil
+}
+
+// splits s into leading ASCII digits and the rest
+func leadingDigits(s string) (digits, rest string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// Returns decimal value as float64. Result may be inexact.
+func DecimalToFloat64(unscaled int64, scale uint8) float64 {
+	return float64(unscaled) / math.Pow10(int(scale))
+}
+
+// Returns number of significant integer digits of unscaled decimal value.
+func DecimalDigits(unscaled int64) uint8 {
+	d := uint8(0)
+	for v := absUint64(unscaled); v > 0; v /= DecimalBase {
+		d++
+	}
+	return d
+}
+
+func absUint64(v int64) uint64 {
+	if v < 0 {
+		return uint64(^v) + 1 // nolint G115 two's complement of negative int64 fits uint64
+	}
+	return uint64(v)
+}
+
+var ErrInvalidDecimal = errors.New("invalid decimal")
@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Validates specified JSON text and returns it in compact form, without insignificant whitespaces.
+//
+// CompactJSON(`{ "a": [1, 2] }`) returns `{"a":[1,2]}`
+func CompactJSON(s string) (string, error) {
+	buf := bytes.Buffer{}
+	if err := json.Compact(&buf, []byte(s)); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	return buf.String(), nil
+}
+
+var ErrInvalidJSON = errors.New("invalid json")
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Returns RFC 3339 representation of timestamp, which is stored as Unix milliseconds.
+//
+// FormatTimestamp(0) returns "1970-01-01T00:00:00.000Z"
+func FormatTimestamp(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(TimestampLayout)
+}
+
+// Parses RFC 3339 date-time string and returns Unix milliseconds.
+//
+// Time zone offset is taken into account, fractional seconds beyond milliseconds are truncated.
+func ParseTimestamp(s string) (int64, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimestamp, s)
+	}
+	return t.UnixMilli(), nil
+}
+
+// Returns RFC 3339 full-date representation of date, which is stored as Unix milliseconds of UTC midnight.
+//
+// FormatDate(0) returns "1970-01-01"
+func FormatDate(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(DateLayout)
+}
+
+// Parses RFC 3339 full-date string, e.g. "2025-01-31", and returns Unix milliseconds of UTC midnight.
+func ParseDate(s string) (int64, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+	}
+	return t.UnixMilli(), nil
+}
+
+// Returns Unix milliseconds of UTC midnight of the day, which contains specified Unix milliseconds.
+func TruncateToDate(ms int64) int64 {
+	d := ms / MillisecondsPerDay
+	if ms%MillisecondsPerDay < 0 {
+		d--
+	}
+	return d * MillisecondsPerDay
+}
+
+// Returns ISO 8601 duration representation of interval, which is stored as milliseconds.
+//
+// FormatInterval(93784005) returns "P1DT2H3M4.005S"
+func FormatInterval(ms int64) string {
+	if ms == 0 {
+		return "PT0S"
+	}
+	b := strings.Builder{}
+	if ms < 0 {
+		b.WriteByte('-')
+	}
+	abs := absUint64(ms)
+	const (
+		day    = uint64(MillisecondsPerDay)
+		hour   = uint64(MillisecondsPerHour)
+		minute = uint64(MillisecondsPerMinute)
+		second = uint64(MillisecondsPerSecond)
+	)
+	b.WriteByte('P')
+	if d := abs / day; d > 0 {
+		b.WriteString(UintToString(d))
+		b.WriteByte('D')
+	}
+	if abs%day == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	if h := abs % day / hour; h > 0 {
+		b.WriteString(UintToString(h))
+		b.WriteByte('H')
+	}
+	if m := abs % hour / minute; m > 0 {
+		b.WriteString(UintToString(m))
+		b.WriteByte('M')
+	}
+	if s, f := abs%minute/second, abs%second; s > 0 || f > 0 {
+		b.WriteString(UintToString(s))
+		if f > 0 {
+			b.WriteString(strings.TrimRight(fmt.Sprintf(".%03d", f), "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// Parses ISO 8601 duration string and returns milliseconds.
+//
+// Weeks, days, hours, minutes and seconds are supported, e.g. "P1DT2H3M4.005S", "-PT90M" or "P2W".
+// Years and months are not supported, since their length is not fixed.
+//
+// Returns error if:
+//   - string is not valid ISO 8601 duration,
+//   - duration has fractional milliseconds,
+//   - duration is out of int64 milliseconds range.
+func ParseInterval(s string) (int64, error) {
+	invalid := func() (int64, error) { return 0, fmt.Errorf("%w: %q", ErrInvalidInterval, s) }
+
+	str, neg := s, false
+	if rest, ok := strings.CutPrefix(str, "-"); ok {
+		str, neg = rest, true
+	} else {
+		str, _ = strings.CutPrefix(str, "+")
+	}
+	str, ok := strings.CutPrefix(str, "P")
+	if !ok || str == "" || str == "T" {
+		return invalid()
+	}
+
+	// designators must follow in this order and must not be repeated
+	dateUnits := map[byte]int64{'W': 7 * MillisecondsPerDay, 'D': MillisecondsPerDay}
+	timeUnits := map[byte]int64{'H': MillisecondsPerHour, 'M': MillisecondsPerMinute, 'S': MillisecondsPerSecond}
+	units, order, inTime := dateUnits, "WD", false
+
+	total := new(big.Rat)
+	for str != "" {
+		if str[0] == 'T' {
+			if inTime || len(str) == 1 {
+				return invalid()
+			}
+			units, order, inTime = timeUnits, "HMS", true
+			str = str[1:]
+			continue
+		}
+		i := strings.IndexFunc(str, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return invalid()
+		}
+		pos := strings.IndexByte(order, str[i])
+		if pos < 0 {
+			return invalid()
+		}
+		order = order[pos+1:]
+		v, ok := new(big.Rat).SetString(str[:i])
+		if !ok {
+			return invalid()
+		}
+		total.Add(total, v.Mul(v, new(big.Rat).SetInt64(units[str[i]])))
+		str = str[i+1:]
+	}
+	if !total.IsInt() {
+		return 0, fmt.Errorf("%w: %q has fractional milliseconds", ErrInvalidInterval, s)
+	}
+	n := total.Num()
+	if neg {
+		n.Neg(n)
+	}
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidInterval, s)
+	}
+	return n.Int64(), nil
+}
+
+var (
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	ErrInvalidDate      = errors.New("invalid date")
+	ErrInvalidInterval  = errors.New("invalid interval")
+)
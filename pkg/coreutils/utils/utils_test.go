@@ -6,6 +6,8 @@
 package utils
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -30,3 +32,211 @@ func TestIntToString(t *testing.T) {
 	var nTyped intType = 43
 	require.Equal(t, "43", IntToString(nTyped))
 }
+
+func TestDecimal(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("format", func(t *testing.T) {
+		cases := []struct {
+			unscaled int64
+			scale    uint8
+			want     string
+		}{
+			{0, 0, "0"},
+			{0, 2, "0.00"},
+			{12345, 2, "123.45"},
+			{-12345, 2, "-123.45"},
+			{5, 3, "0.005"},
+			{-5, 3, "-0.005"},
+			{42, 0, "42"},
+			{math.MinInt64, 2, "-92233720368547758.08"},
+		}
+		for _, c := range cases {
+			require.Equal(c.want, FormatDecimal(c.unscaled, c.scale))
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		cases := []struct {
+			s     string
+			scale uint8
+			want  int64
+		}{
+			{"0", 2, 0},
+			{"123.45", 2, 12345},
+			{"-123.45", 2, -12345},
+			{"123.4", 2, 12340},
+			{"123", 2, 12300},
+			{"0.005", 3, 5},
+			{"1.5e2", 0, 150},
+			{"+1", 0, 1},
+			{"1E2", 0, 100},
+			{"-0.5e1", 0, -5},
+			{"100e-2", 0, 1},
+			{"1.50", 1, 15},
+			{"0001.5", 1, 15},
+			{"-0", 2, 0},
+			{"0e-18", 0, 0},
+			{"9223372036854775807", 0, math.MaxInt64},
+			{"-9223372036854775808", 0, math.MinInt64},
+			{"-92233720368547758.08", 2, math.MinInt64},
+		}
+		for _, c := range cases {
+			v, err := ParseDecimal(c.s, c.scale)
+			require.NoError(err, c.s)
+			require.Equal(c.want, v, c.s)
+		}
+	})
+
+	t.Run("parse errors", func(t *testing.T) {
+		for _, s := range []string{"", "abc", "1/2", "1_000", "0.001", "1e20", "NaN", "Inf",
+			".5", "5.", "+", "-", "--1", " 1", "1 ", "1.2.3", "1e", "1e+", "1e2.5", "9223372036854775808",
+			"0x10", "0X10", "0b101", "0o17", "0x1p4",
+		} {
+			_, err := ParseDecimal(s, 2)
+			require.ErrorIs(err, ErrInvalidDecimal, s)
+		}
+	})
+
+	t.Run("exponent is limited by the precision and scale", func(t *testing.T) {
+		v, err := ParseDecimal("1e-20", 2)
+		require.ErrorIs(err, ErrInvalidDecimal)
+		require.Zero(v)
+		for _, s := range []string{"1e21", "1e-21", "0e999", "1e999999", "1e-999999", "1e" + strings.Repeat("9", 10000)} {
+			_, err := ParseDecimal(s, 2)
+			require.ErrorIs(err, ErrInvalidDecimal, s)
+			require.Contains(err.Error(), "exponent is out of range", s)
+		}
+	})
+
+	t.Run("float and digits", func(t *testing.T) {
+		require.InDelta(123.45, DecimalToFloat64(12345, 2), 1e-9)
+		require.Equal(uint8(0), DecimalDigits(0))
+		require.Equal(uint8(5), DecimalDigits(-12345))
+		require.Equal(uint8(19), DecimalDigits(math.MinInt64))
+	})
+}
+
+func TestTime(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("timestamp", func(t *testing.T) {
+		require.Equal("1970-01-01T00:00:00.000Z", FormatTimestamp(0))
+		require.Equal("2025-03-01T10:20:30.123Z", FormatTimestamp(1740824430123))
+		require.Equal("1969-12-31T23:59:59.999Z", FormatTimestamp(-1))
+
+		cases := []struct {
+			s    string
+			want int64
+		}{
+			{"1970-01-01T00:00:00Z", 0},
+			{"2025-03-01T10:20:30.123Z", 1740824430123},
+			{"2025-03-01T12:20:30.123+02:00", 1740824430123},
+			{"2025-03-01T10:20:30.123456Z", 1740824430123},
+		}
+		for _, c := range cases {
+			v, err := ParseTimestamp(c.s)
+			require.NoError(err, c.s)
+			require.Equal(c.want, v, c.s)
+		}
+
+		for _, s := range []string{"", "2025-03-01", "2025-03-01 10:20:30Z", "1740824430123"} {
+			_, err := ParseTimestamp(s)
+			require.ErrorIs(err, ErrInvalidTimestamp, s)
+		}
+	})
+
+	t.Run("date", func(t *testing.T) {
+		require.Equal("1970-01-01", FormatDate(0))
+		require.Equal("2025-03-01", FormatDate(1740787200000))
+
+		v, err := ParseDate("2025-03-01")
+		require.NoError(err)
+		require.Equal(int64(1740787200000), v)
+
+		for _, s := range []string{"", "2025-3-1", "2025-03-01T00:00:00Z", "2025-02-30"} {
+			_, err := ParseDate(s)
+			require.ErrorIs(err, ErrInvalidDate, s)
+		}
+
+		require.Equal(int64(1740787200000), TruncateToDate(1740824430123))
+		require.Equal(int64(0), TruncateToDate(MillisecondsPerDay-1))
+		require.Equal(-MillisecondsPerDay, TruncateToDate(-1))
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		cases := []struct {
+			ms int64
+			s  string
+		}{
+			{0, "PT0S"},
+			{1, "PT0.001S"},
+			{1500, "PT1.5S"},
+			{90 * MillisecondsPerMinute, "PT1H30M"},
+			{2 * MillisecondsPerDay, "P2D"},
+			{93784005, "P1DT2H3M4.005S"},
+			{-5 * MillisecondsPerSecond, "-PT5S"},
+		}
+		for _, c := range cases {
+			require.Equal(c.s, FormatInterval(c.ms))
+			v, err := ParseInterval(c.s)
+			require.NoError(err, c.s)
+			require.Equal(c.ms, v, c.s)
+		}
+
+		parse := []struct {
+			s  string
+			ms int64
+		}{
+			{"P2W", 14 * MillisecondsPerDay},
+			{"PT90M", 90 * MillisecondsPerMinute},
+			{"+PT1S", MillisecondsPerSecond},
+			{"PT0.5H", 30 * MillisecondsPerMinute},
+			{"P1W1DT0S", 8 * MillisecondsPerDay},
+		}
+		for _, c := range parse {
+			v, err := ParseInterval(c.s)
+			require.NoError(err, c.s)
+			require.Equal(c.ms, v, c.s)
+		}
+
+		for _, s := range []string{"", "P", "PT", "P1DT", "1D", "P1Y", "P1M", "PT1D", "P1D1W", "PT1S1M", "PT1H1H", "PT0.0001S", "PTS", "P1.2.3D", "PT9999999999999999999S"} {
+			_, err := ParseInterval(s)
+			require.ErrorIs(err, ErrInvalidInterval, s)
+		}
+	})
+}
+
+func TestUUID(t *testing.T) {
+	require := require.New(t)
+
+	b, err := ParseUUID("123E4567-e89b-12d3-A456-426614174000")
+	require.NoError(err)
+	require.Len(b, UUIDSize)
+	require.Equal("123e4567-e89b-12d3-a456-426614174000", FormatUUID(b))
+
+	require.Empty(FormatUUID(nil))
+	require.Panics(func() { FormatUUID([]byte{1, 2, 3}) })
+
+	for _, s := range []string{"", "123e4567e89b12d3a456426614174000", "{123e4567-e89b-12d3-a456-426614174000}", "123e4567-e89b-12d3-a456-42661417400z"} {
+		_, err := ParseUUID(s)
+		require.ErrorIs(err, ErrInvalidUUID, s)
+	}
+}
+
+func TestCompactJSON(t *testing.T) {
+	require := require.New(t)
+
+	s, err := CompactJSON(` { "a" : [ 1, 2 ], "b": { "c": null } } `)
+	require.NoError(err)
+	require.Equal(`{"a":[1,2],"b":{"c":null}}`, s)
+
+	s, err = CompactJSON(`"str"`)
+	require.NoError(err)
+	require.Equal(`"str"`, s)
+
+	for _, s := range []string{"", "{", `{"a":}`, "[1,2", "undefined"} {
+		_, err := CompactJSON(s)
+		require.ErrorIs(err, ErrInvalidJSON, s)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UUID length in bytes
+const UUIDSize = 16
+
+// UUID canonical string length, e.g. "123e4567-e89b-12d3-a456-426614174000"
+const UUIDStringLen = 36
+
+// Returns canonical lowercase representation of 16-byte UUID, e.g. "123e4567-e89b-12d3-a456-426614174000".
+//
+// Returns empty string if specified bytes are empty.
+// Panics if specified bytes length is neither 0 nor 16.
+func FormatUUID(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return uuid.Must(uuid.FromBytes(b)).String()
+}
+
+// Parses canonical UUID string, e.g. "123E4567-E89B-12D3-A456-426614174000", and returns 16 bytes.
+//
+// Hex digits are case-insensitive, other representations (braces, URN, no dashes) are not accepted.
+func ParseUUID(s string) ([]byte, error) {
+	if len(s) != UUIDStringLen {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	return u[:], nil
+}
+
+var ErrInvalidUUID = errors.New("invalid uuid")
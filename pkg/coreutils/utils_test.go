@@ -51,6 +51,11 @@ func TestIsDynamoDBStorage(t *testing.T) {
 	require.True(t, IsDynamoDBStorage())
 }
 
+func TestIsPostgreSQLStorage(t *testing.T) {
+	t.Setenv("POSTGRES_TESTS_ENABLED", "1")
+	require.True(t, IsPostgreSQLStorage())
+}
+
 func TestServerAddress(t *testing.T) {
 	require.Equal(t, "127.0.0.1:0", ServerAddress(0))
 	require.Equal(t, "127.0.0.1:8080", ServerAddress(8080))
@@ -30,6 +30,11 @@ func (i TIntent) PutString(name string, value string) {
 	internal.SafeStateAPI.IntentPutString(safe.TIntent(i), name, value)
 }
 
+// Puts decimal field value from exact string, e.g. "-12.50"
+func (i TIntent) PutDecimal(name string, value string) {
+	internal.SafeStateAPI.IntentPutString(safe.TIntent(i), name, value)
+}
+
 func (i TIntent) PutBytes(name string, value []byte) {
 	internal.SafeStateAPI.IntentPutBytes(safe.TIntent(i), name, value)
 }
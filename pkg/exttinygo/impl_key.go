@@ -34,6 +34,11 @@ func (k TKey) AsString(name string) string {
 	return internal.SafeStateAPI.KeyAsString(safe.TKey(k), name)
 }
 
+// Returns decimal field value as exact string, e.g. "-12.50"
+func (k TKey) AsDecimal(name string) string {
+	return internal.SafeStateAPI.KeyAsString(safe.TKey(k), name)
+}
+
 func (k TKey) AsQName(name string) QName {
 	return QName(internal.SafeStateAPI.KeyAsQName(safe.TKey(k), name))
 }
@@ -34,6 +34,11 @@ func (kb TKeyBuilder) PutString(name string, value string) {
 	internal.SafeStateAPI.KeyBuilderPutString(safe.TKeyBuilder(kb), name, value)
 }
 
+// Puts decimal field value from exact string, e.g. "-12.50"
+func (kb TKeyBuilder) PutDecimal(name string, value string) {
+	internal.SafeStateAPI.KeyBuilderPutString(safe.TKeyBuilder(kb), name, value)
+}
+
 func (kb TKeyBuilder) PutBytes(name string, value []byte) {
 	internal.SafeStateAPI.KeyBuilderPutBytes(safe.TKeyBuilder(kb), name, value)
 }
@@ -30,6 +30,11 @@ func (v TValue) AsString(name string) string {
 	return internal.SafeStateAPI.ValueAsString(safe.TValue(v), name)
 }
 
+// Returns decimal field value as exact string, e.g. "-12.50"
+func (v TValue) AsDecimal(name string) string {
+	return internal.SafeStateAPI.ValueAsString(safe.TValue(v), name)
+}
+
 func (v TValue) AsBytes(name string) []byte {
 	return internal.SafeStateAPI.ValueAsBytes(safe.TValue(v), name)
 }
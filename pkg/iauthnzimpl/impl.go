@@ -49,7 +49,12 @@ func (i *implIAuthenticator) Authenticate(requestContext context.Context, as ist
 		return principals, principalPayload, nil
 	}
 
-	if _, err = appTokens.ValidateToken(req.Token, &principalPayload); err != nil {
+	gp, err := appTokens.ValidateToken(req.Token, &principalPayload)
+	if err != nil {
+		return nil, principalPayload, err
+	}
+
+	if err = i.checkSession(as, principalPayload, gp); err != nil {
 		return nil, principalPayload, err
 	}
 
@@ -218,3 +223,12 @@ func (i *implIAuthenticator) rolesFromSubjects(requestContext context.Context, n
 	}
 	return res, nil
 }
+
+// system and API tokens have no session
+// called on each request, so sessions are expected to cache the revocation state, see vvm.provideSessions
+func (i *implIAuthenticator) checkSession(as istructs.IAppStructs, principalPayload payloads.PrincipalPayload, gp istructs.GenericPayload) error {
+	if i.sessions == nil || principalPayload.IsAPIToken || principalPayload.ProfileWSID == istructs.NullWSID {
+		return nil
+	}
+	return i.sessions.Check(as.AppQName(), principalPayload.Login, principalPayload.SessionID, gp.IssuedAt)
+}
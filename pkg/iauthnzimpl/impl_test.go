@@ -16,6 +16,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -71,7 +72,7 @@ func TestBasicUsage(t *testing.T) {
 			},
 		},
 	})
-	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs)
+	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs, nil)
 	t.Run("authenticate in the profile", func(t *testing.T) {
 		req := iauthnz.AuthnRequest{
 			Host:        "127.0.0.1",
@@ -389,7 +390,7 @@ func TestAuthenticate(t *testing.T) {
 	subjectsGetter := func(context.Context, string, istructs.IAppStructs, istructs.WSID) ([]appdef.QName, error) {
 		return *subjects, nil
 	}
-	authn := NewDefaultAuthenticator(subjectsGetter, TestIsDeviceAllowedFuncs)
+	authn := NewDefaultAuthenticator(subjectsGetter, TestIsDeviceAllowedFuncs, nil)
 	for _, tc := range testCases {
 		localVarSubjects := &tc.subjects
 		t.Run(tc.desc, func(t *testing.T) {
@@ -408,7 +409,7 @@ func TestErrors(t *testing.T) {
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(istructs.AppQName_test1_app1)
 
 	appStructs := &implIAppStructs{}
-	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs)
+	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs, nil)
 
 	t.Run("wrong token", func(t *testing.T) {
 		req := iauthnz.AuthnRequest{
@@ -436,6 +437,32 @@ func TestErrors(t *testing.T) {
 		require.Error(err)
 	})
 
+	t.Run("revoked session", func(t *testing.T) {
+		authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs, &testSessions{revoked: "revoked"})
+		pp := payloads.PrincipalPayload{
+			Login:       "testlogin",
+			SubjectKind: istructs.SubjectKind_User,
+			ProfileWSID: 1,
+			SessionID:   "revoked",
+		}
+		token, err := appTokens.IssueToken(time.Minute, &pp)
+		require.NoError(err)
+		req := iauthnz.AuthnRequest{
+			RequestWSID: 1,
+			Token:       token,
+		}
+		_, _, err = authn.Authenticate(context.Background(), appStructs, appTokens, req)
+		require.ErrorIs(err, isessions.ErrSessionRevoked)
+
+		// API token outlives the session
+		apiToken, err := IssueAPIToken(appTokens, time.Hour, []appdef.QName{appdef.NewQName(appdef.SysPackage, "test")}, 1, pp)
+		require.NoError(err)
+		apiPayload := payloads.PrincipalPayload{}
+		_, err = appTokens.ValidateToken(apiToken, &apiPayload)
+		require.NoError(err)
+		require.Empty(apiPayload.SessionID)
+	})
+
 	t.Run("personal access token for a system role", func(t *testing.T) {
 		for _, sysRole := range iauthnz.SysRoles {
 			token, err := IssueAPIToken(appTokens, time.Hour, []appdef.QName{sysRole}, 1, payloads.PrincipalPayload{})
@@ -451,6 +478,18 @@ func TestErrors(t *testing.T) {
 	})
 }
 
+type testSessions struct {
+	isessions.ISessions
+	revoked string
+}
+
+func (s *testSessions) Check(_ appdef.AppQName, _ string, sessionID string, _ time.Time) error {
+	if sessionID == s.revoked {
+		return isessions.ErrSessionRevoked
+	}
+	return nil
+}
+
 func AppStructsWithTestStorage(appQName appdef.AppQName, data map[istructs.WSID]map[appdef.QName]map[istructs.RecordID]map[string]interface{}) istructs.IAppStructs {
 	recs := &implIRecords{data: data}
 	return &implIAppStructs{records: recs, views: &implIViewRecords{records: recs}, appQName: appQName}
@@ -6,13 +6,16 @@ package iauthnzimpl
 
 import (
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
-func NewDefaultAuthenticator(subjectRolesGetter SubjectGetterFunc, isDeviceAllowedFuncs IsDeviceAllowedFuncs) iauthnz.IAuthenticator {
+// sessions could be nil -> principal tokens are not checked for revocation
+func NewDefaultAuthenticator(subjectRolesGetter SubjectGetterFunc, isDeviceAllowedFuncs IsDeviceAllowedFuncs, sessions isessions.ISessions) iauthnz.IAuthenticator {
 	return &implIAuthenticator{
 		subjectRolesGetter:   subjectRolesGetter,
 		isDeviceAllowedFuncs: isDeviceAllowedFuncs,
+		sessions:             sessions,
 	}
 }
 
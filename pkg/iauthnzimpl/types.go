@@ -8,12 +8,14 @@ import (
 	"context"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
 type implIAuthenticator struct {
 	subjectRolesGetter   SubjectGetterFunc
 	isDeviceAllowedFuncs IsDeviceAllowedFuncs
+	sessions             isessions.ISessions
 }
 
 type SubjectGetterFunc = func(requestContext context.Context, name string, as istructs.IAppStructs, wsid istructs.WSID) ([]appdef.QName, error)
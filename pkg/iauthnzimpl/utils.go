@@ -32,5 +32,6 @@ func IssueAPIToken(appTokens istructs.IAppTokens, duration time.Duration, roles
 		})
 	}
 	currentPrincipalPayload.IsAPIToken = true
+	currentPrincipalPayload.SessionID = "" // API token outlives the session it is issued within
 	return appTokens.IssueToken(duration, &currentPrincipalPayload)
 }
@@ -42,6 +42,20 @@ type IN10nBroker interface {
 	//
 	WatchChannel(ctx context.Context, channelID ChannelID, notifySubscriber func(projection ProjectionKey, offset istructs.Offset))
 
+	// Same as WatchChannel but:
+	// - notifySubscriber gets the ID of the notification, IDs increase within the channel
+	// - if ctx is done the channel is not terminated but detached: it is kept with its subscriptions
+	//   for the grace period given to the broker, so that the client could resume watching after a short disconnect, see ResumeChannel()
+	// - the channel is terminated as WatchChannel does if the grace period is zero or the channelDuration is expired
+	WatchChannelEx(ctx context.Context, channelID ChannelID, notifySubscriber func(eventID EventID, projection ProjectionKey, offset istructs.Offset))
+
+	// Attaches the detached channel, WatchChannelEx() must be called then
+	// lastEventID is the ID of the last notification received by the client:
+	//   the latest offsets of the projections notified after lastEventID are notified again
+	// Errors: ErrChannelDoesNotExist (incl. the grace period is over or the channel belongs to another subject), ErrChannelIsWatched
+	// @ConcurrentAccess
+	ResumeChannel(channelID ChannelID, subject istructs.SubjectLogin, lastEventID EventID) (err error)
+
 	// This method MUST NOT BLOCK longer than 500 ns
 	// Updates all channels which subscribed for this projection
 	// @ConcurrentAccess
@@ -63,9 +77,16 @@ type IN10nBroker interface {
 
 	// @ConcurrentAccess
 	MetricNumProjectionSubscriptions(projection ProjectionKey) int
+
+	// Number of channels which are being watched by WatchChannel*() right now
+	// @ConcurrentAccess
+	MetricNumWatchedChannels() int
 }
 
 type ChannelID string
+
+// Identifies the notification within the channel
+type EventID uint64
 type SubscriptionID string
 
 type ProjectionKey struct {
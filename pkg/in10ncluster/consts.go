@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import "time"
+
+const (
+	// delay before the failed Publish() or Receive() is retried
+	retryDelay = time.Second
+
+	// how often the storage transport polls streams of other nodes
+	DefaultPollInterval = 100 * time.Millisecond
+
+	// the poll interval is doubled up to that while nothing is received
+	maxPollInterval = time.Second
+
+	// how long the published batches are kept in the storage
+	streamTTLSeconds = 60
+
+	// how long the node is considered alive without refreshing its registration
+	nodeTTLSeconds = 30
+
+	// registration is refreshed each nodeTTLSeconds/nodeRefreshFactor
+	nodeRefreshFactor = 3
+
+	// how many taken seqs Publish() skips before failing
+	maxTakenSeqs = 10
+
+	// the latest offset of the projection which is not subscribed on the node is dropped if not updated for that long
+	// batches are kept in the storage for streamTTLSeconds, so the dropped offset could not be redelivered
+	latestTTL = streamTTLSeconds * time.Second
+)
+
+const (
+	keyKind_null byte = iota
+
+	// pKey: keyKind_Nodes, cCols: nodeID
+	keyKind_Nodes
+
+	// pKey: keyKind_Stream + nodeID, cCols: seq
+	keyKind_Stream
+)
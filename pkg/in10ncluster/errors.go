@@ -0,0 +1,12 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import "errors"
+
+var (
+	ErrBatchAlreadyPublished = errors.New("batch with the same sequence number is already published")
+	ErrMalformedBatch        = errors.New("malformed updates batch")
+)
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Update @ConcurrentAccess
+// Updates the local broker and schedules the update to be published to other nodes
+// Does not wait for the transport, updates of the same projection are coalesced up to the latest offset
+func (b *broker) Update(projection in10n.ProjectionKey, offset istructs.Offset) {
+	b.mu.Lock()
+	b.IN10nBroker.Update(projection, offset)
+	if projection.Projection == in10n.QNameHeartbeat30 {
+		// each node generates its own heartbeats
+		b.mu.Unlock()
+		return
+	}
+	b.latest[projection] = latestOffset{offset: offset, updatedAt: b.time.Now()}
+	if offset > b.pending[projection] {
+		b.pending[projection] = offset
+	}
+	b.mu.Unlock()
+
+	select {
+	case b.signal <- struct{}{}:
+	default:
+	}
+}
+
+// applies updates received from other nodes
+func (b *broker) applyRemote(batch []Update) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, u := range batch {
+		if u.Offset <= b.latest[u.Projection].offset {
+			continue
+		}
+		b.latest[u.Projection] = latestOffset{offset: u.Offset, updatedAt: b.time.Now()}
+		b.IN10nBroker.Update(u.Projection, u.Offset)
+	}
+}
+
+// drops the latest offsets of the projections which are not updated for latestTTL and are not subscribed on this node
+// the local broker is not called under the lock
+func (b *broker) evictLatest() {
+	b.mu.Lock()
+	now := b.time.Now()
+	expired := []in10n.ProjectionKey{}
+	for prj, latest := range b.latest {
+		if now.Sub(latest.updatedAt) >= latestTTL {
+			expired = append(expired, prj)
+		}
+	}
+	b.mu.Unlock()
+
+	unsubscribed := []in10n.ProjectionKey{}
+	for _, prj := range expired {
+		if b.IN10nBroker.MetricNumProjectionSubscriptions(prj) == 0 {
+			unsubscribed = append(unsubscribed, prj)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, prj := range unsubscribed {
+		// could be updated meanwhile
+		if now.Sub(b.latest[prj].updatedAt) >= latestTTL {
+			delete(b.latest, prj)
+		}
+	}
+}
+
+func (b *broker) takePending() (batch []Update) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for prj, offset := range b.pending {
+		batch = append(batch, Update{Projection: prj, Offset: offset})
+	}
+	clear(b.pending)
+	return batch
+}
+
+// returns the failed batch to pending, newer offsets win
+func (b *broker) restorePending(batch []Update) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, u := range batch {
+		if u.Offset > b.pending[u.Projection] {
+			b.pending[u.Projection] = u.Offset
+		}
+	}
+}
+
+func (b *broker) publisher(ctx context.Context) {
+	defer func() {
+		logger.Info("in10ncluster publisher goroutine stopped")
+		b.wg.Done()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.signal:
+		}
+		batch := b.takePending()
+		if len(batch) == 0 {
+			continue
+		}
+		if err := b.transport.Publish(ctx, batch); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error(fmt.Sprintf("failed to publish %d n10n updates, will retry: %s", len(batch), err))
+			b.restorePending(batch)
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.time.NewTimerChan(retryDelay):
+			}
+			select {
+			case b.signal <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (b *broker) receiver(ctx context.Context) {
+	defer func() {
+		logger.Info("in10ncluster receiver goroutine stopped")
+		b.wg.Done()
+	}()
+	for ctx.Err() == nil {
+		err := b.transport.Receive(ctx, b.applyRemote)
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to receive n10n updates, will retry: %v", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.time.NewTimerChan(retryDelay):
+		}
+	}
+}
+
+func (b *broker) evicter(ctx context.Context) {
+	defer func() {
+		logger.Info("in10ncluster evicter goroutine stopped")
+		b.wg.Done()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.time.NewTimerChan(latestTTL):
+		}
+		b.evictLatest()
+	}
+}
+
+func (b *broker) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.wg.Add(3)
+	go b.publisher(ctx)
+	go b.receiver(ctx)
+	go b.evicter(ctx)
+}
+
+func (b *broker) stop() {
+	b.cancel()
+	b.wg.Wait()
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"context"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
+
+// Returns the transport of the new node connected to the hub
+func (h *Hub) NewTransport() ITransport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastID++
+	return &hubTransport{hub: h, id: h.lastID}
+}
+
+// TransportFactory that connects each VVM to the hub, storage is not used
+func (h *Hub) TransportFactory() TransportFactory {
+	return func(IStorage, timeu.ITime) (ITransport, error) {
+		return h.NewTransport(), nil
+	}
+}
+
+func (t *hubTransport) Publish(_ context.Context, batch []Update) error {
+	t.hub.mu.Lock()
+	defer t.hub.mu.Unlock()
+	for _, u := range batch {
+		if u.Offset > t.hub.retained[u.Projection] {
+			t.hub.retained[u.Projection] = u.Offset
+		}
+	}
+	for id, cb := range t.hub.receivers {
+		if id != t.id {
+			cb(slices.Clone(batch))
+		}
+	}
+	return nil
+}
+
+// The latest offsets published before are delivered to cb first
+func (t *hubTransport) Receive(ctx context.Context, cb func(batch []Update)) error {
+	t.hub.mu.Lock()
+	t.hub.receivers[t.id] = cb
+	retained := make([]Update, 0, len(t.hub.retained))
+	for prj, offset := range t.hub.retained {
+		retained = append(retained, Update{Projection: prj, Offset: offset})
+	}
+	if len(retained) > 0 {
+		cb(retained)
+	}
+	t.hub.mu.Unlock()
+
+	<-ctx.Done()
+
+	t.hub.mu.Lock()
+	delete(t.hub.receivers, t.id)
+	t.hub.mu.Unlock()
+	return ctx.Err()
+}
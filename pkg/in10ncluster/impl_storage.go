@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// the seq could be taken by the batch which insert is failed for the caller but is succeeded on the storage side
+// the next seq is tried then: batches are delivered at-least-once anyway
+func (t *storageTransport) Publish(_ context.Context, batch []Update) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	value := encodeBatch(batch)
+	for i := 0; i < maxTakenSeqs; i++ {
+		seq := t.lastSeq + 1
+		ok, err := t.storage.InsertIfNotExists(streamPKey(t.nodeID), uint64Bytes(seq), value, streamTTLSeconds)
+		if err != nil {
+			return err
+		}
+		t.lastSeq = seq
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("node %d, seq %d: %w", t.nodeID, t.lastSeq, ErrBatchAlreadyPublished)
+}
+
+func (t *storageTransport) Receive(ctx context.Context, cb func(batch []Update)) error {
+	// nodeID -> last read seq
+	// batches published by the node before it is seen are read too: stale offsets are dropped by the broker
+	lastRead := map[uint64]uint64{}
+	var registeredAt time.Time
+	// each idle poll doubles the interval up to maxPollInterval, so idle nodes do not keep polling all other nodes each pollInterval
+	interval := t.pollInterval
+	for {
+		if now := t.time.Now(); now.Sub(registeredAt) >= nodeTTLSeconds*time.Second/nodeRefreshFactor {
+			if err := t.register(); err != nil {
+				return err
+			}
+			registeredAt = now
+		}
+		received, err := t.poll(ctx, lastRead, cb)
+		if err != nil {
+			return err
+		}
+		if received {
+			interval = t.pollInterval
+		} else {
+			interval = min(2*interval, max(maxPollInterval, t.pollInterval))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.time.NewTimerChan(interval):
+		}
+	}
+}
+
+// inserts or prolongs the registration of the node
+func (t *storageTransport) register() error {
+	pKey, cCols, value := nodesPKey(), uint64Bytes(t.nodeID), uint64Bytes(t.nodeID)
+	ok, err := t.storage.CompareAndSwap(pKey, cCols, value, value, nodeTTLSeconds)
+	if err != nil || ok {
+		return err
+	}
+	_, err = t.storage.InsertIfNotExists(pKey, cCols, value, nodeTTLSeconds)
+	return err
+}
+
+// returns true if any batch is received
+func (t *storageTransport) poll(ctx context.Context, lastRead map[uint64]uint64, cb func(batch []Update)) (received bool, err error) {
+	alive := map[uint64]bool{}
+	err = t.storage.TTLRead(ctx, nodesPKey(), nil, nil, func(cCols []byte, _ []byte) error {
+		if nodeID := binary.BigEndian.Uint64(cCols); nodeID != t.nodeID {
+			alive[nodeID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	for nodeID := range lastRead {
+		if !alive[nodeID] {
+			delete(lastRead, nodeID)
+		}
+	}
+	for nodeID := range alive {
+		err := t.storage.TTLRead(ctx, streamPKey(nodeID), uint64Bytes(lastRead[nodeID]+1), nil, func(cCols []byte, value []byte) error {
+			batch, err := decodeBatch(value)
+			if err != nil {
+				return fmt.Errorf("node %d: %w", nodeID, err)
+			}
+			lastRead[nodeID] = binary.BigEndian.Uint64(cCols)
+			received = true
+			cb(batch)
+			return nil
+		})
+		if err != nil {
+			return received, err
+		}
+	}
+	return received, nil
+}
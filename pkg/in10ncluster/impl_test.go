@@ -0,0 +1,348 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10nmem"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+var (
+	testQuotas = in10n.Quotas{
+		Channels:                10,
+		ChannelsPerSubject:      2,
+		Subscriptions:           10,
+		SubscriptionsPerSubject: 2,
+	}
+	testProjection = in10n.ProjectionKey{
+		App:        istructs.AppQName_test1_app1,
+		Projection: appdef.NewQName("test", "restaurant"),
+		WS:         istructs.WSID(1),
+	}
+)
+
+func TestBasicUsage_Hub(t *testing.T) {
+	require := require.New(t)
+	hub := NewHub()
+	node1 := newTestNode(t, hub.NewTransport())
+	node2 := newTestNode(t, hub.NewTransport())
+
+	notifications := node2.watch(t, testProjection)
+
+	node1.broker.Update(testProjection, 1)
+	node1.broker.Update(testProjection, 2)
+	waitOffset(t, notifications, 2)
+
+	t.Run("update on the subscriber node", func(t *testing.T) {
+		node2.broker.Update(testProjection, 3)
+		waitOffset(t, notifications, 3)
+	})
+
+	t.Run("stale remote update is dropped", func(t *testing.T) {
+		node2.broker.(*broker).applyRemote([]Update{{Projection: testProjection, Offset: 1}})
+		node1.broker.Update(testProjection, 4)
+		waitOffset(t, notifications, 4)
+	})
+
+	t.Run("quotas are enforced by the local broker", func(t *testing.T) {
+		_, err := node1.broker.NewChannel("paa", time.Hour)
+		require.NoError(err)
+		_, err = node1.broker.NewChannel("paa", time.Hour)
+		require.NoError(err)
+		_, err = node1.broker.NewChannel("paa", time.Hour)
+		require.ErrorIs(err, in10n.ErrQuotaExceeded_ChannelsPerSubject)
+	})
+
+	t.Run("heartbeats are not published", func(t *testing.T) {
+		node1.broker.Update(in10n.Heartbeat30ProjectionKey, 100)
+		require.Empty(node1.broker.(*broker).takePending())
+	})
+}
+
+func TestBasicUsage_StorageTransport(t *testing.T) {
+	iTime := timeu.NewITime()
+	appStorage, err := provider.Provide(mem.Provide(iTime)).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(t, err)
+	storage := &testStorage{appStorage}
+
+	node1 := newTestNode(t, NewStorageTransportEx(storage, iTime, 10*time.Millisecond))
+	node2 := newTestNode(t, NewStorageTransportEx(storage, iTime, 10*time.Millisecond))
+
+	notifications := node2.watch(t, testProjection)
+	node1.broker.Update(testProjection, 1)
+	waitOffset(t, notifications, 1)
+
+	node1.broker.Update(testProjection, 5)
+	waitOffset(t, notifications, 5)
+
+	t.Run("late node gets the latest offset", func(t *testing.T) {
+		node3 := newTestNode(t, NewStorageTransportEx(storage, iTime, 10*time.Millisecond))
+		notifications := node3.watch(t, testProjection)
+		waitOffset(t, notifications, 5)
+	})
+}
+
+func TestLatestEviction(t *testing.T) {
+	require := require.New(t)
+	mockTime := testingu.NewMockTime()
+	local, localCleanup := in10nmem.ProvideEx2(testQuotas, mockTime)
+	defer localCleanup()
+	nb, cleanup := Provide(local, idleTransport{}, mockTime)
+	defer cleanup()
+	b := nb.(*broker)
+
+	subscribed := testProjection
+	unsubscribed := in10n.ProjectionKey{App: testProjection.App, Projection: testProjection.Projection, WS: 2}
+	channelID, err := b.NewChannel("subject", time.Hour)
+	require.NoError(err)
+	require.NoError(b.Subscribe(channelID, subscribed))
+	// subscriptions are merged by the notifier goroutine of the local broker
+	require.Eventually(func() bool { return b.MetricNumProjectionSubscriptions(subscribed) == 1 }, 5*time.Second, time.Millisecond)
+	b.Update(subscribed, 1)
+	b.Update(unsubscribed, 1)
+	latest := func() map[in10n.ProjectionKey]latestOffset {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return maps.Clone(b.latest)
+	}
+
+	t.Run("recently updated projections are kept", func(t *testing.T) {
+		mockTime.Add(latestTTL / 2)
+		b.evictLatest()
+		require.Len(latest(), 2)
+	})
+
+	t.Run("unsubscribed projections are dropped", func(t *testing.T) {
+		mockTime.Add(latestTTL / 2)
+		b.evictLatest()
+		require.Len(latest(), 1)
+		require.EqualValues(1, latest()[subscribed].offset)
+	})
+}
+
+func TestPollBackoff(t *testing.T) {
+	require := require.New(t)
+	iTime := timeu.NewITime()
+	appStorage, err := provider.Provide(mem.Provide(iTime)).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+	storage := &testStorage{appStorage}
+	pollInterval := 300 * time.Millisecond
+
+	publisher := NewStorageTransportEx(storage, iTime, pollInterval).(*storageTransport)
+	require.NoError(publisher.register())
+
+	recTime := &recordingTime{ITime: iTime, intervals: make(chan time.Duration)}
+	receiver := NewStorageTransportEx(storage, recTime, pollInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- receiver.Receive(ctx, func([]Update) {})
+	}()
+
+	// idle polls
+	require.Equal(2*pollInterval, <-recTime.intervals)
+	require.Equal(maxPollInterval, <-recTime.intervals)
+	require.Equal(maxPollInterval, <-recTime.intervals)
+
+	// the interval is reset once the batch is received
+	require.NoError(publisher.Publish(ctx, []Update{{Projection: testProjection, Offset: 1}}))
+	for interval := range recTime.intervals {
+		if interval == pollInterval {
+			break
+		}
+		require.Equal(maxPollInterval, interval)
+	}
+
+	cancel()
+	go func() {
+		for range recTime.intervals {
+		}
+	}()
+	require.ErrorIs(<-done, context.Canceled)
+}
+
+func TestPublishAfterFailedInsert(t *testing.T) {
+	require := require.New(t)
+	iTime := timeu.NewITime()
+	appStorage, err := provider.Provide(mem.Provide(iTime)).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+	storage := &failingStorage{IStorage: &testStorage{appStorage}}
+	transport := NewStorageTransportEx(storage, iTime, 10*time.Millisecond).(*storageTransport)
+
+	// inserted but failed for the caller
+	storage.fails.Store(1)
+	require.ErrorIs(transport.Publish(context.Background(), []Update{{Projection: testProjection, Offset: 1}}), errTestInsert)
+	require.Zero(transport.lastSeq)
+
+	// the taken seq is skipped
+	require.NoError(transport.Publish(context.Background(), []Update{{Projection: testProjection, Offset: 2}}))
+	require.EqualValues(2, transport.lastSeq)
+	require.NoError(transport.Publish(context.Background(), []Update{{Projection: testProjection, Offset: 3}}))
+	require.EqualValues(3, transport.lastSeq)
+
+	offsets := []istructs.Offset{}
+	require.NoError(storage.TTLRead(context.Background(), streamPKey(transport.nodeID), nil, nil, func(_ []byte, value []byte) error {
+		batch, err := decodeBatch(value)
+		require.NoError(err)
+		offsets = append(offsets, batch[0].Offset)
+		return nil
+	}))
+	require.Equal([]istructs.Offset{1, 2, 3}, offsets)
+}
+
+func TestPublishRetry(t *testing.T) {
+	hub := NewHub()
+	failing := &failingTransport{ITransport: hub.NewTransport()}
+	failing.fails.Store(2)
+	node1 := newTestNode(t, failing)
+	node2 := newTestNode(t, hub.NewTransport())
+
+	notifications := node2.watch(t, testProjection)
+	node1.broker.Update(testProjection, 7)
+	waitOffset(t, notifications, 7)
+	require.Zero(t, failing.fails.Load())
+}
+
+func TestBatchEncoding(t *testing.T) {
+	require := require.New(t)
+	batch := []Update{
+		{Projection: testProjection, Offset: 42},
+		{Projection: in10n.ProjectionKey{App: istructs.AppQName_sys_registry, Projection: appdef.NewQName("sys", "prj"), WS: 2}, Offset: 1},
+	}
+	decoded, err := decodeBatch(encodeBatch(batch))
+	require.NoError(err)
+	require.Equal(batch, decoded)
+
+	data := encodeBatch(batch)
+	_, err = decodeBatch(data[:len(data)-1])
+	require.ErrorIs(err, ErrMalformedBatch)
+}
+
+type testNode struct {
+	broker in10n.IN10nBroker
+}
+
+func newTestNode(t *testing.T, transport ITransport) *testNode {
+	local, localCleanup := in10nmem.ProvideEx2(testQuotas, timeu.NewITime())
+	broker, cleanup := Provide(local, transport, timeu.NewITime())
+	t.Cleanup(func() {
+		cleanup()
+		localCleanup()
+	})
+	return &testNode{broker: broker}
+}
+
+func (n *testNode) watch(t *testing.T, projection in10n.ProjectionKey) chan istructs.Offset {
+	channelID, err := n.broker.NewChannel("subject", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, n.broker.Subscribe(channelID, projection))
+	notifications := make(chan istructs.Offset, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		n.broker.WatchChannel(ctx, channelID, func(_ in10n.ProjectionKey, offset istructs.Offset) {
+			notifications <- offset
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	return notifications
+}
+
+func waitOffset(t *testing.T, notifications chan istructs.Offset, expected istructs.Offset) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case offset := <-notifications:
+			if offset == expected {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("offset %d is not notified", expected)
+		}
+	}
+}
+
+type testStorage struct {
+	istorage.IAppStorage
+}
+
+func (s *testStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) error {
+	return s.IAppStorage.TTLRead(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+// neither publishes nor receives
+type idleTransport struct{}
+
+func (idleTransport) Publish(context.Context, []Update) error { return nil }
+
+func (idleTransport) Receive(ctx context.Context, _ func([]Update)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// records poll intervals, timers are fired immediately
+type recordingTime struct {
+	timeu.ITime
+	intervals chan time.Duration
+}
+
+func (t *recordingTime) NewTimerChan(d time.Duration) <-chan time.Time {
+	t.intervals <- d
+	c := make(chan time.Time, 1)
+	c <- t.Now()
+	return c
+}
+
+type failingTransport struct {
+	ITransport
+	fails atomic.Int32
+}
+
+func (t *failingTransport) Publish(ctx context.Context, batch []Update) error {
+	if t.fails.Load() > 0 {
+		t.fails.Add(-1)
+		return errors.New("test error")
+	}
+	return t.ITransport.Publish(ctx, batch)
+}
+
+var errTestInsert = errors.New("test insert error")
+
+// inserts and returns an error while fails > 0
+type failingStorage struct {
+	IStorage
+	fails atomic.Int32
+}
+
+func (s *failingStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (bool, error) {
+	ok, err := s.IStorage.InsertIfNotExists(pKey, cCols, value, ttlSeconds)
+	if err == nil && s.fails.Load() > 0 {
+		s.fails.Add(-1)
+		return false, errTestInsert
+	}
+	return ok, err
+}
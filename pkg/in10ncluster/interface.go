@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
+
+// Delivers updates between brokers of different VVMs
+//
+// Delivery is at-least-once: batches can be redelivered and come out of order.
+// The broker applies a remote update only if its offset is greater than the latest known one
+type ITransport interface {
+	// Publishes the batch to all other nodes
+	// Must not deliver the batch back to the publisher
+	// @ConcurrentAccess
+	Publish(ctx context.Context, batch []Update) error
+
+	// Calls cb for each batch published by other nodes
+	// Blocks until ctx is done or an error occurs
+	Receive(ctx context.Context, cb func(batch []Update)) error
+}
+
+// Storage that keeps published batches and the registry of nodes
+//
+// Implemented e.g. by the VVM storage adapter which prefixes pKeys to avoid collisions with other data
+type IStorage interface {
+	InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error)
+	CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error)
+	TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) (err error)
+}
+
+// Creates the transport for the VVM
+//
+// storage is shared among VVMs of the cluster
+type TransportFactory func(storage IStorage, time timeu.ITime) (ITransport, error)
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Wraps the process-local broker so that Update() is fanned out to other VVMs via the transport
+//
+// Channels and subscriptions are kept by the local broker, so quotas are enforced per VVM as before.
+// It is still guaranteed that the subscriber is eventually notified about the latest offset
+// used in Update() on any VVM of the cluster.
+// cleanup stops the goroutines of the clustered broker, the local broker must be cleaned up by the caller
+func Provide(local in10n.IN10nBroker, transport ITransport, iTime timeu.ITime) (nb in10n.IN10nBroker, cleanup func()) {
+	b := &broker{
+		IN10nBroker: local,
+		transport:   transport,
+		time:        iTime,
+		pending:     map[in10n.ProjectionKey]istructs.Offset{},
+		latest:      map[in10n.ProjectionKey]latestOffset{},
+		signal:      make(chan struct{}, 1),
+	}
+	b.start()
+	return b, b.stop
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		receivers: map[int]func([]Update){},
+		retained:  map[in10n.ProjectionKey]istructs.Offset{},
+	}
+}
+
+// Transport that publishes batches to the storage shared among VVMs and polls batches of other VVMs
+//
+// Each VVM gets the random node ID, so the restarted VVM is a new node
+func NewStorageTransport(storage IStorage, iTime timeu.ITime) (ITransport, error) {
+	return NewStorageTransportEx(storage, iTime, DefaultPollInterval), nil
+}
+
+func NewStorageTransportEx(storage IStorage, iTime timeu.ITime, pollInterval time.Duration) ITransport {
+	return &storageTransport{
+		storage:      storage,
+		time:         iTime,
+		nodeID:       rand.Uint64(),
+		pollInterval: pollInterval,
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type Update struct {
+	Projection in10n.ProjectionKey
+	Offset     istructs.Offset
+}
+
+type broker struct {
+	in10n.IN10nBroker
+	transport ITransport
+	time      timeu.ITime
+
+	mu sync.Mutex
+	// offsets to be published, coalesced by projection
+	pending map[in10n.ProjectionKey]istructs.Offset
+	// latest offsets known by this node, used to drop stale remote updates
+	latest map[in10n.ProjectionKey]latestOffset
+	signal chan struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type latestOffset struct {
+	offset    istructs.Offset
+	updatedAt time.Time
+}
+
+// In-process stand-in of the message bus, e.g. for tests
+type Hub struct {
+	mu        sync.Mutex
+	receivers map[int]func([]Update)
+	// latest published offsets, delivered to the nodes connected later
+	retained map[in10n.ProjectionKey]istructs.Offset
+	lastID   int
+}
+
+type hubTransport struct {
+	hub *Hub
+	id  int
+}
+
+type storageTransport struct {
+	storage      IStorage
+	time         timeu.ITime
+	nodeID       uint64
+	pollInterval time.Duration
+
+	mu      sync.Mutex
+	lastSeq uint64
+}
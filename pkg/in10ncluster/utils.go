@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package in10ncluster
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func nodesPKey() []byte {
+	return []byte{keyKind_Nodes}
+}
+
+func streamPKey(nodeID uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{keyKind_Stream}, nodeID)
+}
+
+func uint64Bytes(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+// [app][projection][ws uint64][offset uint64] for each update, strings are uint16 length-prefixed
+func encodeBatch(batch []Update) []byte {
+	buf := bytes.NewBuffer(nil)
+	writeString := func(s string) {
+		_ = binary.Write(buf, binary.BigEndian, uint16(min(len(s), math.MaxUint16))) // nolint G115 checked by min
+		buf.WriteString(s)
+	}
+	for _, u := range batch {
+		writeString(u.Projection.App.String())
+		writeString(u.Projection.Projection.String())
+		_ = binary.Write(buf, binary.BigEndian, uint64(u.Projection.WS))
+		_ = binary.Write(buf, binary.BigEndian, uint64(u.Offset))
+	}
+	return buf.Bytes()
+}
+
+func decodeBatch(data []byte) (batch []Update, err error) {
+	buf := bytes.NewBuffer(data)
+	readString := func() (string, error) {
+		var l uint16
+		if err := binary.Read(buf, binary.BigEndian, &l); err != nil {
+			return "", err
+		}
+		if buf.Len() < int(l) {
+			return "", fmt.Errorf("%w: string length %d exceeds remaining %d bytes", ErrMalformedBatch, l, buf.Len())
+		}
+		return string(buf.Next(int(l))), nil
+	}
+	for buf.Len() > 0 {
+		app, err := readString()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBatch, err)
+		}
+		prj, err := readString()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBatch, err)
+		}
+		var ws, offset uint64
+		if err := binary.Read(buf, binary.BigEndian, &ws); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBatch, err)
+		}
+		if err := binary.Read(buf, binary.BigEndian, &offset); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBatch, err)
+		}
+		u := Update{Offset: istructs.Offset(offset), Projection: in10n.ProjectionKey{WS: istructs.WSID(ws)}}
+		if u.Projection.App, err = appdef.ParseAppQName(app); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBatch, err)
+		}
+		if u.Projection.Projection, err = appdef.ParseQName(prj); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedBatch, err)
+		}
+		batch = append(batch, u)
+	}
+	return batch, nil
+}
@@ -37,6 +37,10 @@ type N10nBroker struct {
 	numSubscriptions int
 	time             timeu.ITime
 	events           chan event
+
+	// detached resumable channels are kept during this period
+	gracePeriod        time.Duration
+	numWatchedChannels int
 }
 
 type event struct {
@@ -55,8 +59,9 @@ type projection struct {
 }
 
 type subscription struct {
-	deliveredOffset istructs.Offset
-	currentOffset   *istructs.Offset
+	deliveredOffset  istructs.Offset
+	currentOffset    *istructs.Offset
+	deliveredEventID in10n.EventID
 }
 
 type channel struct {
@@ -66,6 +71,12 @@ type channel struct {
 	createTime      time.Time
 	cchan           chan struct{}
 	terminated      bool
+
+	// true while WatchChannel*() runs or the channel is reserved by ResumeChannel() or by the cleaner
+	watched bool
+	// zero if the channel is not detached
+	detachedAt  time.Time
+	lastEventID in10n.EventID
 }
 
 type metricType struct {
@@ -235,10 +246,54 @@ func (nb *N10nBroker) Unsubscribe(channelID in10n.ChannelID, projectionKey in10n
 
 // Implementation of the in10n.IN10nBroker
 func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelID, notifySubscriber func(projection in10n.ProjectionKey, offset istructs.Offset)) {
+	nb.watchChannel(ctx, channelID, false, func(_ in10n.EventID, projection in10n.ProjectionKey, offset istructs.Offset) {
+		notifySubscriber(projection, offset)
+	})
+}
+
+// Implementation of the in10n.IN10nBroker
+func (nb *N10nBroker) WatchChannelEx(ctx context.Context, channelID in10n.ChannelID, notifySubscriber func(eventID in10n.EventID, projection in10n.ProjectionKey, offset istructs.Offset)) {
+	nb.watchChannel(ctx, channelID, true, notifySubscriber)
+}
+
+// Implementation of the in10n.IN10nBroker
+func (nb *N10nBroker) ResumeChannel(channelID in10n.ChannelID, subject istructs.SubjectLogin, lastEventID in10n.EventID) (err error) {
+	nb.Lock()
+	defer nb.Unlock()
+	channel, channelOK := nb.channels[channelID]
+	if !channelOK || channel.terminated || channel.subject != subject || nb.validateChannel(channel) != nil {
+		return in10n.ErrChannelDoesNotExist
+	}
+	if channel.watched {
+		return in10n.ErrChannelIsWatched
+	}
+	channel.watched = true
+	channel.detachedAt = time.Time{}
+
+	// client has not received notifications after lastEventID -> notify about the latest offsets again
+	for _, sub := range channel.subscriptions {
+		if sub.deliveredEventID > lastEventID {
+			sub.deliveredOffset = istructs.Offset(0)
+		}
+	}
+	select {
+	case channel.cchan <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+type eventUnit struct {
+	UpdateUnit
+	eventID in10n.EventID
+}
+
+func (nb *N10nBroker) watchChannel(ctx context.Context, channelID in10n.ChannelID, resumable bool,
+	notifySubscriber func(eventID in10n.EventID, projection in10n.ProjectionKey, offset istructs.Offset)) {
 	// check that the channelID with the given ChannelID exists
 	channel, metric := func() (*channel, *metricType) {
-		nb.RLock()
-		defer nb.RUnlock()
+		nb.Lock()
+		defer nb.Unlock()
 		channel, channelOK := nb.channels[channelID]
 		if !channelOK {
 			panic(fmt.Errorf("channel with channelID: %s must exists %w", channelID, in10n.ErrChannelDoesNotExist))
@@ -247,12 +302,28 @@ func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelI
 		if !metricOK {
 			panic(fmt.Errorf("metric for channel with channelID: %s must exists", channelID))
 		}
+		channel.watched = true
+		channel.detachedAt = time.Time{}
+		nb.numWatchedChannels++
 		return channel, metric
 	}()
 
-	defer nb.cleanupChannel(channel, channelID, metric)
+	// channel is detached on ctx done if resumable, terminated otherwise
+	detach := resumable && nb.gracePeriod > 0
+	defer func() {
+		nb.Lock()
+		nb.numWatchedChannels--
+		if detach {
+			channel.watched = false
+			channel.detachedAt = nb.time.Now()
+			nb.Unlock()
+			return
+		}
+		nb.Unlock()
+		nb.cleanupChannel(channel, channelID, metric)
+	}()
 
-	updateUnits := make([]UpdateUnit, 0)
+	eventUnits := make([]eventUnit, 0)
 
 	// cycle for channel.cchan and ctx
 	for ctx.Err() == nil {
@@ -260,7 +331,7 @@ func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelI
 		case <-ctx.Done():
 			return
 		case <-channel.cchan:
-			
+
 			if logger.IsTrace() {
 				logger.Trace("notified: ", channelID)
 			}
@@ -272,6 +343,7 @@ func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelI
 			err := nb.validateChannel(channel)
 			if err != nil {
 				logger.Error(fmt.Sprintf("%s: subjectlogin %s", err.Error(), channel.subject))
+				detach = false
 				return
 			}
 
@@ -279,22 +351,27 @@ func (nb *N10nBroker) WatchChannel(ctx context.Context, channelID in10n.ChannelI
 			nb.Lock()
 			for projection, channelOffsets := range channel.subscriptions {
 				if *channelOffsets.currentOffset > channelOffsets.deliveredOffset {
-					updateUnits = append(updateUnits,
-						UpdateUnit{
-							Projection: projection,
-							Offset:     *channelOffsets.currentOffset,
+					channel.lastEventID++
+					eventUnits = append(eventUnits,
+						eventUnit{
+							UpdateUnit: UpdateUnit{
+								Projection: projection,
+								Offset:     *channelOffsets.currentOffset,
+							},
+							eventID: channel.lastEventID,
 						})
 					channelOffsets.deliveredOffset = *channelOffsets.currentOffset
+					channelOffsets.deliveredEventID = channel.lastEventID
 				}
 			}
 			nb.Unlock()
-			for _, unit := range updateUnits {
+			for _, unit := range eventUnits {
 				if logger.IsTrace() {
 					logTrace("before notifySubscriber", unit.Projection, unit.Offset)
 				}
-				notifySubscriber(unit.Projection, unit.Offset)
+				notifySubscriber(unit.eventID, unit.Projection, unit.Offset)
 			}
-			updateUnits = updateUnits[:0]
+			eventUnits = eventUnits[:0]
 		}
 
 	}
@@ -414,6 +491,13 @@ func (nb *N10nBroker) Update(projection in10n.ProjectionKey, offset istructs.Off
 	}
 }
 
+// MetricNumWatchedChannels @ConcurrentAccess
+func (nb *N10nBroker) MetricNumWatchedChannels() int {
+	nb.RLock()
+	defer nb.RUnlock()
+	return nb.numWatchedChannels
+}
+
 // MetricNumChannels @ConcurrentAccess
 // return channels count
 func (nb *N10nBroker) MetricNumChannels() int {
@@ -459,6 +543,11 @@ func (nb *N10nBroker) MetricNumProjectionSubscriptions(projection in10n.Projecti
 }
 
 func NewN10nBroker(quotas in10n.Quotas, time timeu.ITime) (nb *N10nBroker, cleanup func()) {
+	return NewN10nBrokerEx(quotas, time, 0)
+}
+
+// gracePeriod: how long the channel detached by WatchChannelEx() is kept, 0 -> channels are not resumable
+func NewN10nBrokerEx(quotas in10n.Quotas, time timeu.ITime, gracePeriod time.Duration) (nb *N10nBroker, cleanup func()) {
 	broker := N10nBroker{
 		projections:     make(map[in10n.ProjectionKey]*projection),
 		channels:        make(map[in10n.ChannelID]*channel),
@@ -466,6 +555,7 @@ func NewN10nBroker(quotas in10n.Quotas, time timeu.ITime) (nb *N10nBroker, clean
 		quotas:          quotas,
 		time:            time,
 		events:          make(chan event, eventsChannelSize),
+		gracePeriod:     gracePeriod,
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	wg := sync.WaitGroup{}
@@ -478,6 +568,12 @@ func NewN10nBroker(quotas in10n.Quotas, time timeu.ITime) (nb *N10nBroker, clean
 	go notifier(ctx, &wg, broker.events)
 	wg.Add(1)
 	go broker.heartbeat30(ctx, &wg)
+	if gracePeriod > 0 {
+		// the first timer is armed here, not in the goroutine, to not depend on goroutine scheduling
+		timer := broker.time.NewTimerChan(gracePeriod)
+		wg.Add(1)
+		go broker.detachedChannelsCleaner(ctx, &wg, timer)
+	}
 
 	return &broker, cleanup
 }
@@ -510,6 +606,43 @@ func (nb *N10nBroker) heartbeat30(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
+// Terminates detached channels once the grace period is over
+func (nb *N10nBroker) detachedChannelsCleaner(ctx context.Context, wg *sync.WaitGroup, timer <-chan time.Time) {
+	defer func() {
+		logger.Info("detached channels cleaner goroutine stopped")
+		wg.Done()
+	}()
+
+	type toCleanup struct {
+		channelID in10n.ChannelID
+		channel   *channel
+		metric    *metricType
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer:
+		}
+		timer = nb.time.NewTimerChan(nb.gracePeriod)
+		now := nb.time.Now()
+		channels := []toCleanup{}
+		nb.Lock()
+		for channelID, channel := range nb.channels {
+			if channel.watched || channel.terminated || channel.detachedAt.IsZero() || now.Sub(channel.detachedAt) < nb.gracePeriod {
+				continue
+			}
+			// reserve the channel so that it could not be resumed
+			channel.watched = true
+			channels = append(channels, toCleanup{channelID: channelID, channel: channel, metric: nb.metricBySubject[channel.subject]})
+		}
+		nb.Unlock()
+		for _, c := range channels {
+			nb.cleanupChannel(c.channel, c.channelID, c.metric)
+		}
+	}
+}
+
 func (nb *N10nBroker) validateChannel(channel *channel) error {
 	// if channel lifetime > channelDuration defined in NewChannel when create channel - must exit
 	if nb.time.Now().Sub(channel.createTime) > channel.channelDuration {
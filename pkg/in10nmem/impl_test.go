@@ -565,3 +565,117 @@ func reqEventuallyEqual(t *testing.T, expected int, fn func() int) {
 	}
 	t.Errorf("In one second, expected %d, got %d", expected, fn())
 }
+
+func TestResumableChannel(t *testing.T) {
+	require := require.New(t)
+	quotasExample := in10n.Quotas{
+		Channels:                10,
+		ChannelsPerSubject:      10,
+		Subscriptions:           10,
+		SubscriptionsPerSubject: 10,
+	}
+	mockTime := testingu.NewMockTime()
+	const gracePeriod = 30 * time.Second
+	broker, cleanup := ProvideEx3(quotasExample, mockTime, gracePeriod)
+	defer cleanup()
+
+	subject := istructs.SubjectLogin("test")
+	channelID, err := broker.NewChannel(subject, time.Hour)
+	require.NoError(err)
+	prj1 := in10n.ProjectionKey{App: istructs.AppQName_test1_app1, Projection: appdef.NewQName("test", "prj1"), WS: 1}
+	prj2 := in10n.ProjectionKey{App: istructs.AppQName_test1_app1, Projection: appdef.NewQName("test", "prj2"), WS: 1}
+	require.NoError(broker.Subscribe(channelID, prj1))
+	require.NoError(broker.Subscribe(channelID, prj2))
+
+	type event struct {
+		id     in10n.EventID
+		prj    in10n.ProjectionKey
+		offset istructs.Offset
+	}
+	watch := func() (events chan event, stop func()) {
+		events = make(chan event, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			broker.WatchChannelEx(ctx, channelID, func(eventID in10n.EventID, projection in10n.ProjectionKey, offset istructs.Offset) {
+				events <- event{eventID, projection, offset}
+			})
+		}()
+		return events, func() {
+			cancel()
+			wg.Wait()
+		}
+	}
+
+	events, stop := watch()
+	broker.Update(prj1, 1)
+	require.Equal(event{1, prj1, 1}, <-events)
+	broker.Update(prj2, 2)
+	require.Equal(event{2, prj2, 2}, <-events)
+	require.Equal(1, broker.MetricNumWatchedChannels())
+
+	t.Run("channel is already watched", func(t *testing.T) {
+		require.ErrorIs(broker.ResumeChannel(channelID, subject, 2), in10n.ErrChannelIsWatched)
+	})
+
+	// disconnect -> the channel is detached, subscriptions are kept
+	stop()
+	require.Zero(broker.MetricNumWatchedChannels())
+	require.Equal(1, broker.MetricNumChannels())
+	require.Equal(2, broker.MetricNumSubcriptions())
+
+	t.Run("wrong subject", func(t *testing.T) {
+		require.ErrorIs(broker.ResumeChannel(channelID, "other", 2), in10n.ErrChannelDoesNotExist)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		require.ErrorIs(broker.ResumeChannel("unknown", subject, 2), in10n.ErrChannelDoesNotExist)
+	})
+
+	// update during the disconnect
+	broker.Update(prj1, 3)
+
+	// resume: the client has received event 1 only -> prj2 is notified again, prj1 is notified with the new offset
+	require.NoError(broker.ResumeChannel(channelID, subject, 1))
+	events, stop = watch()
+	received := map[in10n.ProjectionKey]event{}
+	for range 2 {
+		e := <-events
+		received[e.prj] = e
+	}
+	require.Equal(istructs.Offset(3), received[prj1].offset)
+	require.Equal(istructs.Offset(2), received[prj2].offset)
+	require.Greater(received[prj1].id, in10n.EventID(2))
+	require.Greater(received[prj2].id, in10n.EventID(2))
+	stop()
+
+	// the grace period is over -> the channel is terminated
+	mockTime.Sleep(2 * gracePeriod)
+	require.Eventually(func() bool {
+		return broker.MetricNumChannels() == 0 && broker.MetricNumSubcriptions() == 0
+	}, time.Second, 10*time.Millisecond)
+	require.ErrorIs(broker.ResumeChannel(channelID, subject, 0), in10n.ErrChannelDoesNotExist)
+}
+
+func TestNotResumableWithoutGracePeriod(t *testing.T) {
+	require := require.New(t)
+	quotasExample := in10n.Quotas{
+		Channels:                1,
+		ChannelsPerSubject:      1,
+		Subscriptions:           1,
+		SubscriptionsPerSubject: 1,
+	}
+	broker, cleanup := ProvideEx2(quotasExample, timeu.NewITime())
+	defer cleanup()
+
+	subject := istructs.SubjectLogin("test")
+	channelID, err := broker.NewChannel(subject, time.Hour)
+	require.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	broker.WatchChannelEx(ctx, channelID, func(in10n.EventID, in10n.ProjectionKey, istructs.Offset) {})
+	require.Zero(broker.MetricNumChannels())
+	require.ErrorIs(broker.ResumeChannel(channelID, subject, 0), in10n.ErrChannelDoesNotExist)
+}
@@ -9,6 +9,8 @@
 package in10nmem
 
 import (
+	"time"
+
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/in10n"
 )
@@ -16,3 +18,8 @@ import (
 func ProvideEx2(quotas in10n.Quotas, time timeu.ITime) (nb in10n.IN10nBroker, cleanup func()) {
 	return NewN10nBroker(quotas, time)
 }
+
+// channelGracePeriod: how long the disconnected channel is kept to be resumed, see in10n.IN10nBroker.ResumeChannel()
+func ProvideEx3(quotas in10n.Quotas, time timeu.ITime, channelGracePeriod time.Duration) (nb in10n.IN10nBroker, cleanup func()) {
+	return NewN10nBrokerEx(quotas, time, channelGracePeriod)
+}
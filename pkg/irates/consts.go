@@ -23,6 +23,6 @@ func (NullBucket) SetDefaultBucketState(appdef.QName, BucketState)  {}
 func (NullBucket) GetDefaultBucketsState(appdef.QName) (BucketState, error) {
 	return BucketState{}, nil
 }
-func (NullBucket) ResetRateBuckets(appdef.QName, BucketState)    {}
-func (NullBucket) SetBucketState(BucketKey, BucketState) error   { return nil }
-func (NullBucket) GetBucketState(BucketKey) (BucketState, error) { return BucketState{}, nil }
+func (NullBucket) ResetRateBuckets(appdef.QName, BucketState) error { return nil }
+func (NullBucket) SetBucketState(BucketKey, BucketState) error      { return nil }
+func (NullBucket) GetBucketState(BucketKey) (BucketState, error)    { return BucketState{}, nil }
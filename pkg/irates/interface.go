@@ -32,7 +32,8 @@ type IBuckets interface {
 	GetDefaultBucketsState(RateLimitName appdef.QName) (state BucketState, err error)
 
 	// Reset all buckets with given RateLimitName to given state
-	ResetRateBuckets(RateLimitName appdef.QName, bucketState BucketState)
+	// returns error if the buckets could not be reset
+	ResetRateBuckets(RateLimitName appdef.QName, bucketState BucketState) (err error)
 
 	// returns ErrorRateLimitNotFound
 	SetBucketState(bucketKey BucketKey, state BucketState) (err error)
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package irates
+
+import "time"
+
+// number of tokens which could be taken from the bucket
+func (bs BucketState) Remaining() NumTokensType {
+	if bs.TakenTokens >= bs.MaxTokensPerPeriod {
+		return 0
+	}
+	return bs.MaxTokensPerPeriod - bs.TakenTokens
+}
+
+// time between two tokens are added to the bucket
+//
+// zero MaxTokensPerPeriod means that tokens are never added, Period is returned then
+func (bs BucketState) TokenInterval() time.Duration {
+	if bs.MaxTokensPerPeriod == 0 {
+		return bs.Period
+	}
+	return bs.Period / time.Duration(bs.MaxTokensPerPeriod)
+}
+
+// time until the bucket is full again
+func (bs BucketState) ResetAfter() time.Duration {
+	return time.Duration(bs.TakenTokens) * bs.TokenInterval()
+}
@@ -128,7 +128,7 @@ func (b *bucketsType) GetDefaultBucketsState(rateLimitName appdef.QName) (state
 
 // change the restriction parameters with the name RateLimitName for running buckets on bucketState
 // the corresponding buckets will be "reset" to the maximum allowed number of available tokens
-func (b *bucketsType) ResetRateBuckets(rateLimitName appdef.QName, bucketState irates.BucketState) {
+func (b *bucketsType) ResetRateBuckets(rateLimitName appdef.QName, bucketState irates.BucketState) (err error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	_, ok := b.defaultStates[rateLimitName]
@@ -136,7 +136,7 @@ func (b *bucketsType) ResetRateBuckets(rateLimitName appdef.QName, bucketState i
 	// if the "default" parameters for this restriction were not set earlier, then
 	// there are definitely no buckets for this restriction. Just leave
 	if !ok {
-		return
+		return nil
 	}
 
 	for bucketKey, bucket := range b.buckets {
@@ -144,6 +144,7 @@ func (b *bucketsType) ResetRateBuckets(rateLimitName appdef.QName, bucketState i
 			bucket.resetToState(bucketState, b.time.Now())
 		}
 	}
+	return nil
 }
 
 // getting the restriction parameters for the bucket corresponding to the transmitted key
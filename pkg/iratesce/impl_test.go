@@ -211,7 +211,7 @@ func TestBucketsNew(t *testing.T) {
 	require.NoError(err)
 	require.Equal(bs.TakenTokens, irates.NumTokensType(10))
 
-	buckets.ResetRateBuckets(totalRegLimitName, totalRegistrationQuota)
+	require.NoError(buckets.ResetRateBuckets(totalRegLimitName, totalRegistrationQuota))
 	bs, err = buckets.GetBucketState(totalRegKey)
 	require.NoError(err)
 	require.Equal(bs.TakenTokens, irates.NumTokensType(0))
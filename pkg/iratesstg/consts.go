@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+const (
+	// how many times the bucket update is retried if the bucket is concurrently modified by other VVM
+	maxCASAttempts = 10
+
+	// period, max tokens, tokens, last update, index entry expiration
+	bucketValueSize = 8 + 4 + 8 + 8 + 8
+
+	// the lease is 1/leaseFraction of the bucket capacity and lasts 1/leaseFraction of the period
+	leaseFraction = 10
+
+	// value of the index entry, the entry itself is the reference to the bucket
+	indexEntryValue = 1
+)
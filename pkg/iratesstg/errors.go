@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import "errors"
+
+var (
+	ErrTooManyConflicts = errors.New("bucket is concurrently modified too often")
+	ErrMalformedBucket  = errors.New("malformed bucket value")
+	ErrMalformedIndex   = errors.New("malformed bucket index entry")
+)
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+// Tokens are taken from the buckets one by one and are given back to leases if any bucket has not enough tokens,
+// so the concurrent request could be denied because of tokens which are given back later
+func (b *buckets) TakeTokens(bucketKeys []irates.BucketKey, n int) (ok bool, excLimit appdef.QName) {
+	leased := make([]irates.BucketKey, 0, len(bucketKeys))
+	for _, key := range bucketKeys {
+		allowed, fromLease, err := b.take(key, n)
+		if err == nil && allowed {
+			if fromLease {
+				leased = append(leased, key)
+			}
+			continue
+		}
+		for _, prevKey := range leased {
+			b.leases.give(prevKey, n)
+		}
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to take tokens from bucket %v, process-local buckets are used: %s", key, err))
+			return b.local.TakeTokens(bucketKeys, n)
+		}
+		return false, key.RateLimitName
+	}
+	return true, appdef.NullQName
+}
+
+// tokens are taken from the lease, the new lease is taken from the stored bucket if the lease has not enough tokens
+// the bucket of the limit which is not declared is not taken into account
+func (b *buckets) take(key irates.BucketKey, n int) (ok, fromLease bool, err error) {
+	now := b.time.Now()
+	if b.leases.take(key, n, now) {
+		return true, true, nil
+	}
+	released := b.leases.release(key)
+	leaseTokens := 0
+	var leaseDuration time.Duration
+	found, err := b.update(key, func(bkt *bucket, _ bool) bool {
+		leaseTokens, leaseDuration = 0, bkt.leaseDuration()
+		if bkt.period == 0 {
+			// infinite rate or denied at all, nothing to lease
+			ok = bkt.take(n)
+			return false
+		}
+		bkt.give(released)
+		switch {
+		case bkt.take(max(n, bkt.leaseSize())):
+			ok, leaseTokens = true, max(n, bkt.leaseSize())-n
+		case bkt.take(n):
+			ok = true
+		default:
+			ok = false
+		}
+		return ok || released > 0
+	})
+	if err != nil || !found {
+		return !found, false, err
+	}
+	if !ok || leaseDuration == 0 {
+		return ok, false, nil
+	}
+	b.leases.set(key, float64(leaseTokens), now.Add(leaseDuration))
+	return true, true, nil
+}
+
+func (b *buckets) SetDefaultBucketState(rateLimitName appdef.QName, bucketState irates.BucketState) {
+	b.local.SetDefaultBucketState(rateLimitName, bucketState)
+}
+
+func (b *buckets) GetDefaultBucketsState(rateLimitName appdef.QName) (state irates.BucketState, err error) {
+	return b.local.GetDefaultBucketsState(rateLimitName)
+}
+
+// buckets which are full already are in the default state, so only stored buckets are reset
+// stored buckets are found by the index of the rate limit
+// tokens leased by other VVMs are used until their leases expire
+func (b *buckets) ResetRateBuckets(rateLimitName appdef.QName, bucketState irates.BucketState) (err error) {
+	if err := b.local.ResetRateBuckets(rateLimitName, bucketState); err != nil {
+		// notest
+		return err
+	}
+	b.leases.drop(func(key irates.BucketKey) bool { return key.RateLimitName == rateLimitName })
+	if _, err := b.local.GetDefaultBucketsState(rateLimitName); err != nil {
+		// not declared -> no buckets
+		return nil
+	}
+	indexPKey := limitPKeyPrefix(rateLimitName)
+	entries := [][]byte{}
+	if err := b.storage.TTLRead(context.Background(), indexPKey, nil, nil, func(cCols []byte, _ []byte) error {
+		entries = append(entries, bytes.Clone(cCols))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to read buckets index of rate limit %s: %w", rateLimitName, err)
+	}
+	for _, entry := range entries {
+		pKey, cCols, err := bucketKeysFromIndex(entry)
+		if err != nil {
+			return err
+		}
+		// the bucket could expire already, the missing bucket is in the default state
+		if err := b.updateStored(indexPKey, pKey, cCols, bucketState, func(bkt *bucket, exists bool) bool {
+			if exists {
+				bkt.reset(bucketState, b.time.Now())
+			}
+			return exists
+		}); err != nil {
+			return fmt.Errorf("failed to reset buckets of rate limit %s: %w", rateLimitName, err)
+		}
+	}
+	return nil
+}
+
+// returns irates.ErrorRateLimitNotFound
+func (b *buckets) SetBucketState(bucketKey irates.BucketKey, state irates.BucketState) (err error) {
+	b.leases.drop(func(key irates.BucketKey) bool { return key == bucketKey })
+	found, err := b.update(bucketKey, func(bkt *bucket, _ bool) bool {
+		bkt.reset(state, b.time.Now())
+		return true
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to set state of bucket %v, process-local bucket is used: %s", bucketKey, err))
+		return b.local.SetBucketState(bucketKey, state)
+	}
+	if !found {
+		return irates.ErrorRateLimitNotFound
+	}
+	return nil
+}
+
+// returns irates.ErrorRateLimitNotFound
+// tokens leased by VVMs are counted as taken
+func (b *buckets) GetBucketState(bucketKey irates.BucketKey) (state irates.BucketState, err error) {
+	found, err := b.update(bucketKey, func(bkt *bucket, _ bool) bool {
+		state = bkt.state()
+		return false
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get state of bucket %v, process-local bucket is used: %s", bucketKey, err))
+		return b.local.GetBucketState(bucketKey)
+	}
+	if !found {
+		return state, irates.ErrorRateLimitNotFound
+	}
+	return state, nil
+}
+
+// found is false if there is no default state for the rate limit, i.e. the limit is not declared
+func (b *buckets) update(key irates.BucketKey, f bucketUpdateFunc) (found bool, err error) {
+	defaultState, err := b.local.GetDefaultBucketsState(key.RateLimitName)
+	if errors.Is(err, irates.ErrorRateLimitNotFound) {
+		return false, nil
+	}
+	pKey, cCols := bucketKeys(key)
+	return true, b.updateStored(limitPKeyPrefix(key.RateLimitName), pKey, cCols, defaultState, f)
+}
+
+// the missing bucket is the full bucket in the default state
+// the index entry is written before the bucket if it could expire earlier than the bucket
+func (b *buckets) updateStored(indexPKey, pKey, cCols []byte, defaultState irates.BucketState, f bucketUpdateFunc) error {
+	for range maxCASAttempts {
+		now := b.time.Now()
+		data := []byte{}
+		exists, err := b.storage.TTLGet(pKey, cCols, &data)
+		if err != nil {
+			return err
+		}
+		bkt := newBucket(defaultState, now)
+		if exists {
+			if bkt, err = bucketFromBytes(data); err != nil {
+				return err
+			}
+			bkt.refill(now)
+		}
+		if !f(&bkt, exists) {
+			return nil
+		}
+		if bkt.indexedUntil < now.UnixNano()+int64(bkt.ttlSeconds())*int64(time.Second) {
+			if err := b.index(indexPKey, pKey, cCols, bkt.indexTTLSeconds()); err != nil {
+				return err
+			}
+			bkt.indexedUntil = now.UnixNano() + int64(bkt.indexTTLSeconds())*int64(time.Second)
+		}
+		var ok bool
+		if exists {
+			ok, err = b.storage.CompareAndSwap(pKey, cCols, data, bkt.bytes(), bkt.ttlSeconds())
+		} else {
+			ok, err = b.storage.InsertIfNotExists(pKey, cCols, bkt.bytes(), bkt.ttlSeconds())
+		}
+		if err != nil || ok {
+			return err
+		}
+		// concurrently modified by other VVM, retry on the actual bucket
+	}
+	return ErrTooManyConflicts
+}
+
+// writes the index entry or prolongs the existing one
+func (b *buckets) index(indexPKey, pKey, cCols []byte, ttlSeconds int) error {
+	entryCCols := indexCCols(pKey, cCols)
+	value := []byte{indexEntryValue}
+	for range maxCASAttempts {
+		ok, err := b.storage.InsertIfNotExists(indexPKey, entryCCols, value, ttlSeconds)
+		if err != nil || ok {
+			return err
+		}
+		if ok, err = b.storage.CompareAndSwap(indexPKey, entryCCols, value, value, ttlSeconds); err != nil || ok {
+			return err
+		}
+		// expired between calls
+	}
+	return ErrTooManyConflicts
+}
@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+var (
+	testLimitName = appdef.NewQName("test", "limit")
+	testState     = irates.BucketState{
+		Period:             time.Minute,
+		MaxTokensPerPeriod: 10,
+	}
+	testKey = irates.BucketKey{
+		RateLimitName: testLimitName,
+		App:           istructs.AppQName_test1_app1,
+		Workspace:     1,
+		RemoteAddr:    "127.0.0.1",
+	}
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	storage := newTestStorage(t)
+
+	// buckets of two VVMs of the cluster
+	vvm1 := Provide(storage, testingu.MockTime)
+	vvm2 := Provide(storage, testingu.MockTime)
+	vvm1.SetDefaultBucketState(testLimitName, testState)
+	vvm2.SetDefaultBucketState(testLimitName, testState)
+
+	for range 6 {
+		ok, _ := vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.True(ok)
+	}
+	ok, _ := vvm2.TakeTokens([]irates.BucketKey{testKey}, 4)
+	require.True(ok)
+
+	t.Run("limit is shared among VVMs", func(t *testing.T) {
+		for _, vvm := range []irates.IBuckets{vvm1, vvm2} {
+			ok, excLimit := vvm.TakeTokens([]irates.BucketKey{testKey}, 1)
+			require.False(ok)
+			require.Equal(testLimitName, excLimit)
+		}
+		state, err := vvm2.GetBucketState(testKey)
+		require.NoError(err)
+		require.Equal(irates.NumTokensType(10), state.TakenTokens)
+	})
+
+	t.Run("other buckets are not affected", func(t *testing.T) {
+		otherKey := testKey
+		otherKey.App = istructs.AppQName_test1_app2
+		ok, _ := vvm1.TakeTokens([]irates.BucketKey{otherKey}, 1)
+		require.True(ok)
+	})
+
+	t.Run("tokens are refilled in time", func(t *testing.T) {
+		testingu.MockTime.Add(testState.Period / 10)
+		ok, _ := vvm2.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.True(ok)
+		ok, _ = vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.False(ok)
+	})
+
+	t.Run("bucket record expires when the bucket is full", func(t *testing.T) {
+		testingu.MockTime.Add(testState.Period)
+		pKey, cCols := bucketKeys(testKey)
+		data := []byte{}
+		ok, err := storage.TTLGet(pKey, cCols, &data)
+		require.NoError(err)
+		require.False(ok)
+
+		state, err := vvm1.GetBucketState(testKey)
+		require.NoError(err)
+		require.Equal(testState, state)
+	})
+
+	t.Run("not declared limit is not taken into account", func(t *testing.T) {
+		unknownKey := testKey
+		unknownKey.RateLimitName = appdef.NewQName("test", "unknown")
+		for range 20 {
+			ok, _ := vvm1.TakeTokens([]irates.BucketKey{unknownKey}, 1)
+			require.True(ok)
+		}
+		_, err := vvm1.GetBucketState(unknownKey)
+		require.ErrorIs(err, irates.ErrorRateLimitNotFound)
+		require.ErrorIs(vvm1.SetBucketState(unknownKey, testState), irates.ErrorRateLimitNotFound)
+	})
+}
+
+func TestTakeTokensAtomicity(t *testing.T) {
+	require := require.New(t)
+	buckets := Provide(newTestStorage(t), testingu.MockTime)
+
+	wsLimitName := appdef.NewQName("test", "wsLimit")
+	buckets.SetDefaultBucketState(testLimitName, testState)
+	buckets.SetDefaultBucketState(wsLimitName, irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 1})
+	wsKey := testKey
+	wsKey.RateLimitName = wsLimitName
+
+	ok, _ := buckets.TakeTokens([]irates.BucketKey{testKey, wsKey}, 1)
+	require.True(ok)
+	ok, excLimit := buckets.TakeTokens([]irates.BucketKey{testKey, wsKey}, 1)
+	require.False(ok)
+	require.Equal(wsLimitName, excLimit)
+
+	// the token is given back to the lease of the first bucket, i.e. it is still taken in the storage
+	state, err := buckets.GetBucketState(testKey)
+	require.NoError(err)
+	require.Equal(irates.NumTokensType(2), state.TakenTokens)
+
+	ok, _ = buckets.TakeTokens([]irates.BucketKey{testKey}, 1)
+	require.True(ok)
+	state, err = buckets.GetBucketState(testKey)
+	require.NoError(err)
+	require.Equal(irates.NumTokensType(2), state.TakenTokens)
+}
+
+func TestLeases(t *testing.T) {
+	require := require.New(t)
+	storage := &countingStorage{IStorage: newTestStorage(t)}
+	vvm1 := Provide(storage, testingu.MockTime)
+	vvm2 := Provide(storage, testingu.MockTime)
+	state := irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 100}
+	vvm1.SetDefaultBucketState(testLimitName, state)
+	vvm2.SetDefaultBucketState(testLimitName, state)
+
+	// 1/10 of the bucket is leased
+	ok, _ := vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+	require.True(ok)
+	bktState, err := vvm2.GetBucketState(testKey)
+	require.NoError(err)
+	require.Equal(irates.NumTokensType(10), bktState.TakenTokens)
+
+	t.Run("leased tokens are taken without the storage", func(t *testing.T) {
+		storage.gets = 0
+		for range 9 {
+			ok, _ := vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+			require.True(ok)
+		}
+		require.Zero(storage.gets)
+	})
+
+	t.Run("leased tokens are not available for the other VVM", func(t *testing.T) {
+		ok, _ := vvm2.TakeTokens([]irates.BucketKey{testKey}, 90)
+		require.True(ok)
+		ok, _ = vvm2.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.False(ok)
+	})
+
+	t.Run("unused tokens of the expired lease are given back", func(t *testing.T) {
+		testingu.MockTime.Add(state.Period) // the bucket is full again
+		ok, _ := vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.True(ok)
+		ok, _ = vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.True(ok)
+		ok, _ = vvm2.TakeTokens([]irates.BucketKey{testKey}, 80)
+		require.True(ok)
+
+		// lease expires: 10 tokens are refilled, 8 unused tokens are given back and the new lease is taken
+		testingu.MockTime.Add(state.Period / leaseFraction)
+		ok, _ = vvm1.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.True(ok)
+		bktState, err := vvm2.GetBucketState(testKey)
+		require.NoError(err)
+		require.Equal(irates.NumTokensType(90-10-8+10), bktState.TakenTokens)
+	})
+}
+
+func TestResetRateBuckets(t *testing.T) {
+	require := require.New(t)
+	storage := newTestStorage(t)
+	vvm1 := Provide(storage, testingu.MockTime)
+	vvm2 := Provide(storage, testingu.MockTime)
+	vvm1.SetDefaultBucketState(testLimitName, testState)
+	vvm2.SetDefaultBucketState(testLimitName, testState)
+
+	otherKey := testKey
+	otherKey.Workspace = 2
+	for _, key := range []irates.BucketKey{testKey, otherKey} {
+		ok, _ := vvm1.TakeTokens([]irates.BucketKey{key}, 10)
+		require.True(ok)
+	}
+
+	newState := irates.BucketState{Period: time.Hour, MaxTokensPerPeriod: 20}
+	require.NoError(vvm2.ResetRateBuckets(testLimitName, newState))
+
+	for _, key := range []irates.BucketKey{testKey, otherKey} {
+		state, err := vvm1.GetBucketState(key)
+		require.NoError(err)
+		require.Equal(newState, state)
+	}
+
+	t.Run("set bucket state", func(t *testing.T) {
+		require.NoError(vvm1.SetBucketState(testKey, irates.BucketState{Period: time.Hour, MaxTokensPerPeriod: 20, TakenTokens: 19}))
+		ok, _ := vvm2.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.True(ok)
+		ok, _ = vvm2.TakeTokens([]irates.BucketKey{testKey}, 1)
+		require.False(ok)
+	})
+
+	t.Run("index entry outlives the bucket", func(t *testing.T) {
+		testingu.MockTime.Add(newState.Period + time.Second)
+		pKey, cCols := bucketKeys(testKey)
+		data := []byte{}
+		ok, err := storage.TTLGet(pKey, cCols, &data)
+		require.NoError(err)
+		require.False(ok)
+		ok, err = storage.TTLGet(limitPKeyPrefix(testLimitName), indexCCols(pKey, cCols), &data)
+		require.NoError(err)
+		require.True(ok)
+
+		// expired bucket is not restored on reset
+		require.NoError(vvm2.ResetRateBuckets(testLimitName, testState))
+		ok, err = storage.TTLGet(pKey, cCols, &data)
+		require.NoError(err)
+		require.False(ok)
+	})
+
+	t.Run("error if the index could not be read", func(t *testing.T) {
+		failing := Provide(&failingStorage{IStorage: storage, err: errors.New("storage is unavailable")}, testingu.MockTime)
+		failing.SetDefaultBucketState(testLimitName, testState)
+		require.Error(failing.ResetRateBuckets(testLimitName, testState))
+	})
+}
+
+func TestStorageFailure(t *testing.T) {
+	require := require.New(t)
+	storage := &failingStorage{IStorage: newTestStorage(t)}
+	buckets := Provide(storage, testingu.MockTime)
+	buckets.SetDefaultBucketState(testLimitName, testState)
+
+	storage.err = errors.New("storage is unavailable")
+
+	// process-local buckets are used
+	ok, _ := buckets.TakeTokens([]irates.BucketKey{testKey}, 10)
+	require.True(ok)
+	ok, excLimit := buckets.TakeTokens([]irates.BucketKey{testKey}, 1)
+	require.False(ok)
+	require.Equal(testLimitName, excLimit)
+
+	storage.err = nil
+	ok, _ = buckets.TakeTokens([]irates.BucketKey{testKey}, 10)
+	require.True(ok)
+}
+
+func TestBucketEncoding(t *testing.T) {
+	require := require.New(t)
+	bkt := newBucket(irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 10, TakenTokens: 3}, testingu.MockTime.Now())
+	actual, err := bucketFromBytes(bkt.bytes())
+	require.NoError(err)
+	require.Equal(bkt, actual)
+
+	_, err = bucketFromBytes([]byte{1, 2, 3})
+	require.ErrorIs(err, ErrMalformedBucket)
+
+	pKey, cCols := bucketKeys(testKey)
+	actualPKey, actualCCols, err := bucketKeysFromIndex(indexCCols(pKey, cCols))
+	require.NoError(err)
+	require.Equal(pKey, actualPKey)
+	require.Equal(cCols, actualCCols)
+
+	_, _, err = bucketKeysFromIndex([]byte{0, 10, 1})
+	require.ErrorIs(err, ErrMalformedIndex)
+}
+
+func newTestStorage(t *testing.T) IStorage {
+	appStorage, err := provider.Provide(mem.Provide(testingu.MockTime)).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(t, err)
+	return &testStorage{appStorage}
+}
+
+type testStorage struct {
+	istorage.IAppStorage
+}
+
+func (s *testStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) error {
+	return s.IAppStorage.TTLRead(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+type failingStorage struct {
+	IStorage
+	err error
+}
+
+func (s *failingStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	return s.IStorage.TTLGet(pKey, cCols, data)
+}
+
+func (s *failingStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.IStorage.TTLRead(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+type countingStorage struct {
+	IStorage
+	gets int
+}
+
+func (s *countingStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (bool, error) {
+	s.gets++
+	return s.IStorage.TTLGet(pKey, cCols, data)
+}
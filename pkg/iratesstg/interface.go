@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"context"
+)
+
+// Storage that keeps buckets shared among VVMs
+//
+// Implemented e.g. by the VVM storage adapter which prefixes pKeys to avoid collisions with other data
+type IStorage interface {
+	TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
+	InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error)
+	CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error)
+	TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) (err error)
+}
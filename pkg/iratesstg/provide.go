@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/iratesce"
+)
+
+// Buckets kept in the storage shared among VVMs, so that limits are enforced cluster-wide
+//
+// Default states are kept in memory, each VVM sets them on app deployment.
+// The bucket record is written on the first token taken and expires once the bucket is full again,
+// i.e. the missing record is the full bucket in the default state.
+// If the storage fails then the process-local buckets are used, i.e. limits are enforced per VVM.
+//
+// The VVM takes 1/10 of the bucket capacity at once and uses these tokens locally for 1/10 of the period,
+// so the storage is not accessed on each request. Leased tokens are counted as taken,
+// i.e. other VVMs could be denied a bit earlier. Unused tokens are given back on the next lease.
+//
+// Stored buckets are indexed by the rate limit name, so ResetRateBuckets does not scan the storage.
+func Provide(storage IStorage, time timeu.ITime) irates.IBuckets {
+	return &buckets{
+		storage: storage,
+		local:   iratesce.Provide(time),
+		time:    time,
+		leases:  leases{leases: map[irates.BucketKey]*lease{}},
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+type buckets struct {
+	storage IStorage
+
+	// keeps default states and is used if the storage fails
+	local  irates.IBuckets
+	time   timeu.ITime
+	leases leases
+}
+
+// bucket as it is kept in the storage
+//
+// tokens are refilled on read, so that the bucket is not written until the tokens are taken
+type bucket struct {
+	period appdef.RatePeriod
+	max    irates.NumTokensType
+	tokens float64
+
+	// unix nanoseconds of the last refill
+	last int64
+
+	// unix nanoseconds when the index entry of the bucket expires
+	indexedUntil int64
+}
+
+// called with the refilled bucket, returns if the bucket must be written
+type bucketUpdateFunc func(bkt *bucket, exists bool) (write bool)
+
+// tokens taken from the stored bucket in advance, so that the storage is not accessed on each request
+type lease struct {
+	tokens   float64
+	expireAt time.Time
+}
+
+type leases struct {
+	mu     sync.Mutex
+	leases map[irates.BucketKey]*lease
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+// pKey: RateLimitName, App, Workspace, RemoteAddr, so that buckets of different workspaces and addresses are in different partitions
+// cCols: QName, ID
+func bucketKeys(key irates.BucketKey) (pKey, cCols []byte) {
+	pKey = limitPKeyPrefix(key.RateLimitName)
+	pKey = appendString(pKey, key.App.String())
+	pKey = binary.BigEndian.AppendUint64(pKey, uint64(key.Workspace))
+	pKey = append(pKey, key.RemoteAddr...)
+	cCols = appendString(nil, key.QName.String())
+	cCols = binary.BigEndian.AppendUint64(cCols, uint64(key.ID))
+	return pKey, cCols
+}
+
+// pKeys of all buckets of the rate limit start with it
+// also the pKey of the index of the rate limit buckets, bucket pKeys are always longer
+func limitPKeyPrefix(rateLimitName appdef.QName) []byte {
+	return appendString(nil, rateLimitName.String())
+}
+
+// cCols of the index entry: bucket pKey prefixed with the length, bucket cCols
+func indexCCols(pKey, cCols []byte) []byte {
+	res := make([]byte, 0, 2+len(pKey)+len(cCols))
+	res = binary.BigEndian.AppendUint16(res, uint16(len(pKey))) // nolint G115 bucket pKeys are short
+	res = append(res, pKey...)
+	return append(res, cCols...)
+}
+
+func bucketKeysFromIndex(indexCCols []byte) (pKey, cCols []byte, err error) {
+	if len(indexCCols) < 2 {
+		return nil, nil, fmt.Errorf("%w: %d bytes", ErrMalformedIndex, len(indexCCols))
+	}
+	pKeyLen := int(binary.BigEndian.Uint16(indexCCols))
+	if len(indexCCols) < 2+pKeyLen {
+		return nil, nil, fmt.Errorf("%w: %d bytes, bucket pKey length %d", ErrMalformedIndex, len(indexCCols), pKeyLen)
+	}
+	return indexCCols[2 : 2+pKeyLen], indexCCols[2+pKeyLen:], nil
+}
+
+// the length is prefixed so that the next fields are not mixed up with the string
+func appendString(b []byte, str string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(str))) // nolint G115 names are short
+	return append(b, str...)
+}
+
+// full bucket with the state applied
+func newBucket(state irates.BucketState, now time.Time) bucket {
+	bkt := bucket{
+		period: state.Period,
+		max:    state.MaxTokensPerPeriod,
+		last:   now.UnixNano(),
+	}
+	if state.TakenTokens < state.MaxTokensPerPeriod {
+		bkt.tokens = float64(state.MaxTokensPerPeriod - state.TakenTokens)
+	}
+	return bkt
+}
+
+func bucketFromBytes(data []byte) (bkt bucket, err error) {
+	if len(data) != bucketValueSize {
+		return bkt, fmt.Errorf("%w: %d bytes, expected %d", ErrMalformedBucket, len(data), bucketValueSize)
+	}
+	bkt.period = time.Duration(binary.BigEndian.Uint64(data)) // nolint G115 written by bytes()
+	bkt.max = binary.BigEndian.Uint32(data[8:])
+	bkt.tokens = math.Float64frombits(binary.BigEndian.Uint64(data[12:]))
+	bkt.last = int64(binary.BigEndian.Uint64(data[20:]))         // nolint G115 written by bytes()
+	bkt.indexedUntil = int64(binary.BigEndian.Uint64(data[28:])) // nolint G115 written by bytes()
+	return bkt, nil
+}
+
+func (bkt *bucket) bytes() []byte {
+	data := make([]byte, 0, bucketValueSize)
+	data = binary.BigEndian.AppendUint64(data, uint64(bkt.period)) // nolint G115 period is not negative
+	data = binary.BigEndian.AppendUint32(data, bkt.max)
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(bkt.tokens))
+	data = binary.BigEndian.AppendUint64(data, uint64(bkt.last))         // nolint G115 unix time is not negative
+	return binary.BigEndian.AppendUint64(data, uint64(bkt.indexedUntil)) // nolint G115 unix time is not negative
+}
+
+// applies the state, the index entry is kept
+func (bkt *bucket) reset(state irates.BucketState, now time.Time) {
+	indexedUntil := bkt.indexedUntil
+	*bkt = newBucket(state, now)
+	bkt.indexedUntil = indexedUntil
+}
+
+// adds tokens accumulated since the last refill
+func (bkt *bucket) refill(now time.Time) {
+	elapsed := now.UnixNano() - bkt.last
+	if elapsed <= 0 {
+		return
+	}
+	bkt.last = now.UnixNano()
+	if bkt.period <= 0 {
+		return
+	}
+	bkt.tokens = min(float64(bkt.max), bkt.tokens+float64(elapsed)*float64(bkt.max)/float64(bkt.period))
+}
+
+// zero period is the infinite rate, zero max tokens denies everything
+func (bkt *bucket) take(n int) (ok bool) {
+	if bkt.max > 0 && bkt.period == 0 {
+		return true
+	}
+	if bkt.tokens < float64(n) {
+		return false
+	}
+	bkt.tokens -= float64(n)
+	return true
+}
+
+func (bkt *bucket) give(tokens float64) {
+	bkt.tokens = min(float64(bkt.max), bkt.tokens+tokens)
+}
+
+// tokens taken at once to be used locally
+func (bkt *bucket) leaseSize() int {
+	return max(1, int(bkt.max)/leaseFraction)
+}
+
+func (bkt *bucket) leaseDuration() time.Duration {
+	return bkt.period / leaseFraction
+}
+
+func (bkt *bucket) state() irates.BucketState {
+	return irates.BucketState{
+		Period:             bkt.period,
+		MaxTokensPerPeriod: bkt.max,
+		TakenTokens:        irates.NumTokensType(max(0, float64(bkt.max)-bkt.tokens)),
+	}
+}
+
+// the bucket is full when the record expires
+func (bkt *bucket) ttlSeconds() int {
+	return max(1, int(math.Ceil(bkt.period.Seconds())))
+}
+
+// the index entry outlives the bucket, so it is rewritten once per bucket TTL only
+func (bkt *bucket) indexTTLSeconds() int {
+	return 2 * bkt.ttlSeconds()
+}
+
+// takes n tokens from the lease which is not expired yet
+func (l *leases) take(key irates.BucketKey, n int, now time.Time) (ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ls, ok := l.leases[key]
+	if !ok || !now.Before(ls.expireAt) || ls.tokens < float64(n) {
+		return false
+	}
+	ls.tokens -= float64(n)
+	return true
+}
+
+// removes the lease, returns unused tokens to be given back to the stored bucket
+func (l *leases) release(key irates.BucketKey) (tokens float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ls, ok := l.leases[key]; ok {
+		tokens = ls.tokens
+		delete(l.leases, key)
+	}
+	return tokens
+}
+
+// tokens of the lease set concurrently are kept
+func (l *leases) set(key irates.BucketKey, tokens float64, expireAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ls, ok := l.leases[key]; ok {
+		tokens += ls.tokens
+	}
+	l.leases[key] = &lease{tokens: tokens, expireAt: expireAt}
+}
+
+// tokens are lost if the lease is released already, i.e. they are counted as taken until the bucket is refilled
+func (l *leases) give(key irates.BucketKey, n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ls, ok := l.leases[key]; ok {
+		ls.tokens += float64(n)
+	}
+}
+
+// leased tokens are not given back since the bucket state is replaced
+func (l *leases) drop(match func(key irates.BucketKey) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key := range l.leases {
+		if match(key) {
+			delete(l.leases, key)
+		}
+	}
+}
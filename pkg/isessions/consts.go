@@ -0,0 +1,24 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import "time"
+
+const (
+	// how many times the session update is retried if the session is concurrently modified by other VVM
+	maxCASAttempts = 10
+
+	sessionIDLen = 16
+
+	// revocation marks must outlive any token of the session, including derived ones
+	// the max principal token TTL is 1 week, see registry.maxTokenTTLHours
+	revokedTTL = 7*24*time.Hour + time.Hour
+
+	// max number of sessions and logins which revocation state is cached
+	checkCacheSize = 10000
+)
+
+// sessionIDs are hex strings, so the record of RevokeAll() is never read as a session
+var revokedAllCCols = []byte{0}
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import "errors"
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionRevoked   = errors.New("session is revoked")
+	ErrTooManyConflicts = errors.New("session is concurrently modified too often")
+)
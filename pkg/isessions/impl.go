@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+func (s *sessions) Create(app appdef.AppQName, login string, ttl time.Duration, device, remoteAddr string) (sessionID string, err error) {
+	now := s.time.Now()
+	rec := sessionRecord{
+		CreatedAt:  now.UnixMilli(),
+		ExpiresAt:  now.Add(ttl).UnixMilli(),
+		Device:     device,
+		RemoteAddr: remoteAddr,
+	}
+	for range maxCASAttempts {
+		if sessionID, err = newSessionID(); err != nil {
+			// notest
+			return "", err
+		}
+		ok, err := s.storage.InsertIfNotExists(sessionsPKey(app, login), []byte(sessionID), rec.bytes(), ttlSeconds(ttl))
+		if err != nil || ok {
+			return sessionID, err
+		}
+		// notest: random sessionID collision
+	}
+	return "", ErrTooManyConflicts
+}
+
+func (s *sessions) Prolong(app appdef.AppQName, login string, sessionID string, ttl time.Duration) error {
+	return s.update(app, login, sessionID, func(rec *sessionRecord) (time.Duration, error) {
+		if rec.Revoked {
+			return 0, ErrSessionRevoked
+		}
+		rec.ExpiresAt = max(rec.ExpiresAt, s.time.Now().Add(ttl).UnixMilli())
+		return time.UnixMilli(rec.ExpiresAt).Sub(s.time.Now()), nil
+	})
+}
+
+// the missing session record is expired or lost, e.g. token enriched at the end of the session could outlive it
+// RevokeAll() is checked always: it could be interrupted before the session record is revoked
+func (s *sessions) Check(app appdef.AppQName, login string, sessionID string, issuedAt time.Time) error {
+	pKey := sessionsPKey(app, login)
+	session := revocation{}
+	if len(sessionID) > 0 {
+		r, err := s.revocation(pKey, []byte(sessionID))
+		if err != nil {
+			return err
+		}
+		if r.ok && r.revoked {
+			return ErrSessionRevoked
+		}
+		session = r
+	}
+	r, err := s.revocation(pKey, revokedAllCCols)
+	if err != nil {
+		return err
+	}
+	if !r.ok || session.ok && session.createdAt >= r.revokedAt {
+		// the session is created after the last RevokeAll()
+		// the session created within the same millisecond is revoked by RevokeAll() itself
+		return nil
+	}
+	// token iat is in seconds, so the token issued within the same second is revoked too
+	if issuedAt.Unix() <= time.UnixMilli(r.revokedAt).Unix() {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+func (s *sessions) List(ctx context.Context, app appdef.AppQName, login string) (res []Session, err error) {
+	err = s.storage.TTLRead(ctx, sessionsPKey(app, login), nil, nil, func(cCols []byte, value []byte) error {
+		if string(cCols) == string(revokedAllCCols) {
+			return nil
+		}
+		rec, err := sessionRecordFromBytes(value)
+		if err != nil {
+			return err
+		}
+		if !rec.Revoked {
+			res = append(res, rec.session(string(cCols)))
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (s *sessions) Revoke(app appdef.AppQName, login string, sessionID string) error {
+	return s.update(app, login, sessionID, func(rec *sessionRecord) (time.Duration, error) {
+		if rec.Revoked {
+			return 0, nil
+		}
+		rec.Revoked = true
+		return revokedTTL, nil
+	})
+}
+
+func (s *sessions) RevokeAll(ctx context.Context, app appdef.AppQName, login string) error {
+	if err := s.markRevokedAll(app, login); err != nil {
+		return err
+	}
+	list, err := s.List(ctx, app, login)
+	if err != nil {
+		return err
+	}
+	for _, session := range list {
+		if err := s.Revoke(app, login, session.ID); err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sessions) markRevokedAll(app appdef.AppQName, login string) error {
+	pKey := sessionsPKey(app, login)
+	newData := binary.BigEndian.AppendUint64(nil, uint64(s.time.Now().UnixMilli())) // nolint G115 unix time is not negative
+	for range maxCASAttempts {
+		data := []byte{}
+		exists, err := s.storage.TTLGet(pKey, revokedAllCCols, &data)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if exists {
+			ok, err = s.storage.CompareAndSwap(pKey, revokedAllCCols, data, newData, ttlSeconds(revokedTTL))
+		} else {
+			ok, err = s.storage.InsertIfNotExists(pKey, revokedAllCCols, newData, ttlSeconds(revokedTTL))
+		}
+		if ok {
+			s.invalidate(pKey, revokedAllCCols)
+		}
+		if err != nil || ok {
+			return err
+		}
+		// concurrently revoked by other VVM, retry
+	}
+	return ErrTooManyConflicts
+}
+
+// revocation state is cached for checkCacheTTL, so that the storage is not read on each request
+// revocation made by other VVM takes effect here after checkCacheTTL at most
+func (s *sessions) revocation(pKey, cCols []byte) (r revocation, err error) {
+	key := revocationKey{pKey: string(pKey), cCols: string(cCols)}
+	now := s.time.Now()
+	if r, ok := s.cache.Get(key); ok && now.Sub(r.obtainedAt) < s.checkCacheTTL {
+		return r, nil
+	}
+	data := []byte{}
+	if r.ok, err = s.storage.TTLGet(pKey, cCols, &data); err != nil {
+		return r, err
+	}
+	if r.ok {
+		if string(cCols) == string(revokedAllCCols) {
+			r.revokedAt = revokedAllFromBytes(data)
+		} else {
+			rec, err := sessionRecordFromBytes(data)
+			if err != nil {
+				return r, err
+			}
+			r.revoked = rec.Revoked
+			r.createdAt = rec.CreatedAt
+		}
+	}
+	r.obtainedAt = now
+	s.cache.Put(key, r)
+	return r, nil
+}
+
+// changes made by this VVM take effect here immediately
+func (s *sessions) invalidate(pKey, cCols []byte) {
+	s.cache.Put(revocationKey{pKey: string(pKey), cCols: string(cCols)}, revocation{})
+}
+
+func (s *sessions) get(pKey, cCols []byte) (rec sessionRecord, ok bool, err error) {
+	data := []byte{}
+	if ok, err = s.storage.TTLGet(pKey, cCols, &data); err != nil || !ok {
+		return rec, ok, err
+	}
+	rec, err = sessionRecordFromBytes(data)
+	return rec, err == nil, err
+}
+
+// f returns the TTL of the updated record, zero TTL -> the record is not written
+func (s *sessions) update(app appdef.AppQName, login string, sessionID string, f func(rec *sessionRecord) (time.Duration, error)) error {
+	pKey, cCols := sessionsPKey(app, login), []byte(sessionID)
+	for range maxCASAttempts {
+		data := []byte{}
+		exists, err := s.storage.TTLGet(pKey, cCols, &data)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrSessionNotFound
+		}
+		rec, err := sessionRecordFromBytes(data)
+		if err != nil {
+			return err
+		}
+		ttl, err := f(&rec)
+		if err != nil || ttl <= 0 {
+			return err
+		}
+		ok, err := s.storage.CompareAndSwap(pKey, cCols, data, rec.bytes(), ttlSeconds(ttl))
+		if ok {
+			s.invalidate(pKey, cCols)
+		}
+		if err != nil || ok {
+			return err
+		}
+		// concurrently modified by other VVM, retry on the actual session
+	}
+	return ErrTooManyConflicts
+}
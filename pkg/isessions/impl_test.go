@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+const (
+	testLogin         = "login"
+	testCheckCacheTTL = time.Second
+)
+
+var testApp = istructs.AppQName_test1_app1
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	storage := newTestStorage(t)
+
+	// sessions of two VVMs of the cluster
+	vvm1 := Provide(storage, testingu.MockTime, testCheckCacheTTL)
+	vvm2 := Provide(storage, testingu.MockTime, testCheckCacheTTL)
+
+	phone, err := vvm1.Create(testApp, testLogin, time.Hour, "phone", "10.0.0.1")
+	require.NoError(err)
+	laptop, err := vvm2.Create(testApp, testLogin, time.Hour, "laptop", "10.0.0.2")
+	require.NoError(err)
+	require.NotEqual(phone, laptop)
+
+	t.Run("list", func(t *testing.T) {
+		list, err := vvm2.List(context.Background(), testApp, testLogin)
+		require.NoError(err)
+		require.Len(list, 2)
+		for _, session := range list {
+			require.Equal(testingu.MockTime.Now().Add(time.Hour).UnixMilli(), session.ExpiresAt.UnixMilli())
+			switch session.ID {
+			case phone:
+				require.Equal("phone", session.Device)
+				require.Equal("10.0.0.1", session.RemoteAddr)
+			case laptop:
+				require.Equal("laptop", session.Device)
+			default:
+				t.Fatal(session.ID)
+			}
+		}
+
+		// sessions of other login or app are not listed
+		list, err = vvm2.List(context.Background(), istructs.AppQName_test1_app2, testLogin)
+		require.NoError(err)
+		require.Empty(list)
+	})
+
+	t.Run("revoke on one VVM takes effect on another", func(t *testing.T) {
+		require.NoError(vvm1.Check(testApp, testLogin, phone, testingu.MockTime.Now()))
+		require.NoError(vvm2.Revoke(testApp, testLogin, phone))
+		require.ErrorIs(vvm2.Check(testApp, testLogin, phone, testingu.MockTime.Now()), ErrSessionRevoked)
+
+		// the revocation state is cached by vvm1
+		require.NoError(vvm1.Check(testApp, testLogin, phone, testingu.MockTime.Now()))
+		testingu.MockTime.Add(testCheckCacheTTL)
+		require.ErrorIs(vvm1.Check(testApp, testLogin, phone, testingu.MockTime.Now()), ErrSessionRevoked)
+		require.NoError(vvm1.Check(testApp, testLogin, laptop, testingu.MockTime.Now()))
+
+		// revoked session is not listed and could not be prolonged
+		list, err := vvm1.List(context.Background(), testApp, testLogin)
+		require.NoError(err)
+		require.Len(list, 1)
+		require.Equal(laptop, list[0].ID)
+		require.ErrorIs(vvm1.Prolong(testApp, testLogin, phone, time.Hour), ErrSessionRevoked)
+
+		// idempotent
+		require.NoError(vvm1.Revoke(testApp, testLogin, phone))
+	})
+
+	t.Run("prolong", func(t *testing.T) {
+		testingu.MockTime.Add(50 * time.Minute)
+		require.NoError(vvm1.Prolong(testApp, testLogin, laptop, time.Hour))
+		testingu.MockTime.Add(50 * time.Minute)
+		list, err := vvm1.List(context.Background(), testApp, testLogin)
+		require.NoError(err)
+		require.Len(list, 1)
+		require.Equal(testingu.MockTime.Now().Add(10*time.Minute).UnixMilli(), list[0].ExpiresAt.UnixMilli())
+
+		// revoked session is kept even after the session expiration
+		require.ErrorIs(vvm1.Check(testApp, testLogin, phone, testingu.MockTime.Now()), ErrSessionRevoked)
+	})
+
+	t.Run("unknown or expired session", func(t *testing.T) {
+		require.ErrorIs(vvm1.Revoke(testApp, testLogin, "unknown"), ErrSessionNotFound)
+		require.ErrorIs(vvm1.Prolong(testApp, testLogin, "unknown", time.Hour), ErrSessionNotFound)
+		require.NoError(vvm1.Check(testApp, testLogin, "unknown", testingu.MockTime.Now()))
+
+		testingu.MockTime.Add(time.Hour)
+		list, err := vvm1.List(context.Background(), testApp, testLogin)
+		require.NoError(err)
+		require.Empty(list)
+	})
+}
+
+func TestRevokeAll(t *testing.T) {
+	require := require.New(t)
+	sessions := Provide(newTestStorage(t), testingu.MockTime, testCheckCacheTTL)
+
+	ids := []string{}
+	for range 3 {
+		id, err := sessions.Create(testApp, testLogin, time.Hour, "", "")
+		require.NoError(err)
+		ids = append(ids, id)
+	}
+	otherLoginSession, err := sessions.Create(testApp, "other", time.Hour, "", "")
+	require.NoError(err)
+	issuedWithoutSession := testingu.MockTime.Now()
+	require.NoError(sessions.Check(testApp, testLogin, "", issuedWithoutSession))
+
+	testingu.MockTime.Add(time.Second)
+	require.NoError(sessions.RevokeAll(context.Background(), testApp, testLogin))
+
+	for _, id := range ids {
+		require.ErrorIs(sessions.Check(testApp, testLogin, id, testingu.MockTime.Now()), ErrSessionRevoked)
+	}
+	require.ErrorIs(sessions.Check(testApp, testLogin, "", issuedWithoutSession), ErrSessionRevoked)
+	list, err := sessions.List(context.Background(), testApp, testLogin)
+	require.NoError(err)
+	require.Empty(list)
+
+	// other logins are not affected
+	require.NoError(sessions.Check(testApp, "other", otherLoginSession, testingu.MockTime.Now()))
+	require.NoError(sessions.Check(testApp, "other", "", issuedWithoutSession))
+
+	// sessions created after are not affected
+	testingu.MockTime.Add(time.Second)
+	id, err := sessions.Create(testApp, testLogin, time.Hour, "", "")
+	require.NoError(err)
+	require.NoError(sessions.Check(testApp, testLogin, id, testingu.MockTime.Now()))
+	require.NoError(sessions.Check(testApp, testLogin, "", testingu.MockTime.Now()))
+
+	// revoke all again
+	testingu.MockTime.Add(time.Second)
+	require.NoError(sessions.RevokeAll(context.Background(), testApp, testLogin))
+	require.ErrorIs(sessions.Check(testApp, testLogin, id, testingu.MockTime.Now()), ErrSessionRevoked)
+}
+
+func TestCheckInterruptedRevokeAll(t *testing.T) {
+	require := require.New(t)
+	s := Provide(newTestStorage(t), testingu.MockTime, testCheckCacheTTL)
+
+	id, err := s.Create(testApp, testLogin, time.Hour, "", "")
+	require.NoError(err)
+	issuedBefore := testingu.MockTime.Now()
+	require.NoError(s.Check(testApp, testLogin, id, issuedBefore))
+
+	// RevokeAll() is interrupted after the mark, session record is not revoked
+	testingu.MockTime.Add(time.Second)
+	require.NoError(s.(*sessions).markRevokedAll(testApp, testLogin))
+	require.ErrorIs(s.Check(testApp, testLogin, id, issuedBefore), ErrSessionRevoked)
+
+	// tokens issued after are accepted
+	testingu.MockTime.Add(time.Second)
+	require.NoError(s.Check(testApp, testLogin, id, testingu.MockTime.Now()))
+}
+
+func TestCheckMissingSession(t *testing.T) {
+	require := require.New(t)
+	sessions := Provide(newTestStorage(t), testingu.MockTime, testCheckCacheTTL)
+
+	// e.g. the token is enriched at the end of the session and outlives the session record
+	issuedBefore := testingu.MockTime.Now()
+	require.NoError(sessions.Check(testApp, testLogin, "expired", issuedBefore))
+
+	testingu.MockTime.Add(time.Second)
+	require.NoError(sessions.RevokeAll(context.Background(), testApp, testLogin))
+	require.ErrorIs(sessions.Check(testApp, testLogin, "expired", issuedBefore), ErrSessionRevoked)
+
+	testingu.MockTime.Add(time.Second)
+	require.NoError(sessions.Check(testApp, testLogin, "expired", testingu.MockTime.Now()))
+}
+
+func newTestStorage(t *testing.T) IStorage {
+	appStorage, err := provider.Provide(mem.Provide(testingu.MockTime)).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(t, err)
+	return &testStorage{appStorage}
+}
+
+type testStorage struct {
+	istorage.IAppStorage
+}
+
+func (s *testStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) error {
+	return s.IAppStorage.TTLRead(ctx, pKey, startCCols, finishCCols, cb)
+}
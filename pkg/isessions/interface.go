@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import (
+	"context"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// Sessions of logins, each principal token issued on login belongs to a session
+//
+// Tokens derived from the principal token (refreshed, enriched) belong to the same session
+// so that revocation of the session makes all of them invalid
+type ISessions interface {
+	// Creates the new session of the login which lasts for ttl
+	// Returned ID must be put into the principal token payload
+	Create(app appdef.AppQName, login string, ttl time.Duration, device, remoteAddr string) (sessionID string, err error)
+
+	// Prolongs the session on the token refresh so that it lasts for ttl from now
+	// ErrSessionNotFound, ErrSessionRevoked
+	Prolong(app appdef.AppQName, login string, sessionID string, ttl time.Duration) error
+
+	// Called on each authenticated request
+	// sessionID is empty for tokens issued without session, these are checked against RevokeAll() only
+	// ErrSessionRevoked
+	Check(app appdef.AppQName, login string, sessionID string, issuedAt time.Time) error
+
+	// Not expired and not revoked sessions of the login
+	List(ctx context.Context, app appdef.AppQName, login string) ([]Session, error)
+
+	// ErrSessionNotFound
+	Revoke(app appdef.AppQName, login string, sessionID string) error
+
+	// Revokes all sessions of the login and all tokens of the login issued without session
+	RevokeAll(ctx context.Context, app appdef.AppQName, login string) error
+}
+
+// Storage that keeps sessions shared among VVMs
+//
+// Implemented e.g. by the VVM storage adapter which prefixes pKeys to avoid collisions with other data
+type IStorage interface {
+	TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
+	InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error)
+	CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error)
+	TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) (err error)
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/objcache"
+)
+
+// Sessions are kept in the storage shared among VVMs, so that revocation takes effect cluster-wide
+//
+// Session record expires together with the last token issued within the session.
+// Revoked session record is kept until any token of the session could be valid.
+//
+// Check() results are cached for checkCacheTTL, so revocation made by other VVM takes effect after checkCacheTTL at most.
+// Zero checkCacheTTL -> the storage is read on each Check()
+func Provide(storage IStorage, time timeu.ITime, checkCacheTTL time.Duration) ISessions {
+	return &sessions{
+		storage:       storage,
+		time:          time,
+		cache:         objcache.New[revocationKey, revocation](checkCacheSize),
+		checkCacheTTL: checkCacheTTL,
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/objcache"
+)
+
+type Session struct {
+	ID        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+
+	// as reported by the client on login, e.g. User-Agent
+	Device     string
+	RemoteAddr string
+}
+
+type sessions struct {
+	storage       IStorage
+	time          timeu.ITime
+	cache         objcache.ICache[revocationKey, revocation]
+	checkCacheTTL time.Duration
+}
+
+type revocationKey struct {
+	pKey  string
+	cCols string
+}
+
+// revocation state of the session or of the login as it was read from the storage
+type revocation struct {
+	obtainedAt time.Time
+	ok         bool  // the session record or the RevokeAll() mark exists
+	revoked    bool  // the session is revoked
+	createdAt  int64 // unix milliseconds of the session creation
+	revokedAt  int64 // unix milliseconds of the last RevokeAll()
+}
+
+// session as it is kept in the storage
+type sessionRecord struct {
+	CreatedAt  int64  // unix milliseconds
+	ExpiresAt  int64  // unix milliseconds
+	Device     string `json:",omitempty"`
+	RemoteAddr string `json:",omitempty"`
+	Revoked    bool   `json:",omitempty"`
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package isessions
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// pKey: App, Login, so that all sessions of the login could be listed
+// cCols: SessionID
+func sessionsPKey(app appdef.AppQName, login string) []byte {
+	appStr := app.String()
+	pKey := make([]byte, 0, 2+len(appStr)+len(login))
+	pKey = binary.BigEndian.AppendUint16(pKey, uint16(len(appStr))) // nolint G115 app name is short
+	pKey = append(pKey, appStr...)
+	return append(pKey, login...)
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, sessionIDLen)
+	if _, err := rand.Read(b); err != nil {
+		// notest
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func ttlSeconds(ttl time.Duration) int {
+	return max(1, int(math.Ceil(ttl.Seconds())))
+}
+
+func revokedAllFromBytes(data []byte) int64 {
+	if len(data) < 8 {
+		// notest
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(data)) // nolint G115 written by markRevokedAll()
+}
+
+func sessionRecordFromBytes(data []byte) (rec sessionRecord, err error) {
+	if err = json.Unmarshal(data, &rec); err != nil {
+		err = fmt.Errorf("malformed session record: %w", err)
+	}
+	return rec, err
+}
+
+func (rec sessionRecord) bytes() []byte {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		// notest
+		panic(err)
+	}
+	return data
+}
+
+func (rec sessionRecord) session(id string) Session {
+	return Session{
+		ID:         id,
+		CreatedAt:  time.UnixMilli(rec.CreatedAt),
+		ExpiresAt:  time.UnixMilli(rec.ExpiresAt),
+		Device:     rec.Device,
+		RemoteAddr: rec.RemoteAddr,
+	}
+}
@@ -12,6 +12,9 @@ const (
 	sortKeyAttributeName      = "c_col"
 	valueAttributeName        = "value"
 	expireAtAttributeName     = "expire_at"
+
+	// DynamoDB parallel scan segments count used by ScanPKeys
+	scanTotalSegments = 1024
 )
 
 var DefaultDynamoDBParams = DynamoDBParams{
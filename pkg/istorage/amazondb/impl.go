@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"math"
 	"strconv"
 	"time"
 
@@ -461,6 +462,72 @@ func (s *implIAppStorage) read(ctx context.Context, pKey []byte, startCCols, fin
 	return nil
 }
 
+// Scan returns items of the same partition key one after another, so it is enough to skip repeated consecutive keys
+// order of partition keys is defined by DynamoDB hash function, it is the same for the unchanged table
+// tokens are mapped to DynamoDB parallel scan segments: the token range [start of segment i, start of segment i+1)
+// belongs to segment i, the partition key token is the start token of the segment where the key is stored
+// so the range scans only segments which start tokens are within the range and each segment is scanned once by adjacent ranges
+func (s *implIAppStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	for _, segment := range scanSegments(tokens) {
+		if err := s.scanSegment(ctx, segment, cb); err != nil || ctx.Err() != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *implIAppStorage) scanSegment(ctx context.Context, segment int32, cb istorage.PKeyCallback) error {
+	params := dynamodb.ScanInput{
+		TableName:            aws.String(s.keySpace),
+		ProjectionExpression: aws.String(partitionKeyAttributeName),
+		ConsistentRead:       aws.Bool(true),
+		Segment:              aws.Int32(segment),
+		TotalSegments:        aws.Int32(scanTotalSegments),
+	}
+	// items of the same partition key are stored in the same segment and are returned one after another
+	var lastPKey []byte
+	paginator := dynamodb.NewScanPaginator(s.client, &params)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil // TCK contract
+			}
+			return err
+		}
+		for _, item := range page.Items {
+			if ctx.Err() != nil {
+				return nil // TCK contract
+			}
+			pKey := item[partitionKeyAttributeName].(*types.AttributeValueMemberB).Value
+			if lastPKey != nil && bytes.Equal(pKey, lastPKey) {
+				continue
+			}
+			lastPKey = pKey
+			if err := cb(pKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// returns segments which start tokens are within tokens
+func scanSegments(tokens istorage.TokenRange) (res []int32) {
+	for segment := int32(0); segment < scanTotalSegments; segment++ {
+		if tokens.Contains(segmentStartToken(segment)) {
+			res = append(res, segment)
+		}
+	}
+	return res
+}
+
+func segmentStartToken(segment int32) int64 {
+	// segments split the token space into equal parts, uint64 arithmetic to avoid overflow
+	step := uint64(math.MaxUint64/scanTotalSegments + 1)
+	return istorage.FullTokenRange.Start + int64(uint64(segment)*step) // nolint G115
+}
+
 func getClient(cfg aws.Config) *dynamodb.Client {
 	return dynamodb.NewFromConfig(cfg)
 }
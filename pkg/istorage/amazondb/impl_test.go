@@ -8,6 +8,8 @@ package amazondb
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/istorage"
@@ -20,3 +22,24 @@ func TestBasicUsage(t *testing.T) {
 	asf := Provide(DefaultDynamoDBParams, testingu.MockTime)
 	istorage.TechnologyCompatibilityKit(t, asf)
 }
+
+func TestScanSegments(t *testing.T) {
+	require := require.New(t)
+
+	require.Len(scanSegments(istorage.FullTokenRange), scanTotalSegments)
+	require.Equal(istorage.FullTokenRange.Start, segmentStartToken(0))
+	require.Less(segmentStartToken(scanTotalSegments-1), istorage.FullTokenRange.End)
+
+	for _, n := range []int{1, 2, 3, 7, 1024, 2000} {
+		found := map[int32]int{}
+		for _, part := range istorage.FullTokenRange.Split(n) {
+			for _, segment := range scanSegments(part) {
+				found[segment]++
+			}
+		}
+		require.Len(found, scanTotalSegments, "n = %d", n)
+		for segment, times := range found {
+			require.Equal(1, times, "segment %d is scanned %d times, n = %d", segment, times, n)
+		}
+	}
+}
@@ -11,6 +11,9 @@ const (
 	ttlBucketName   = "ttlBucket"
 	dataBucketName  = "dataBucket"
 	cleanupInterval = time.Hour
+
+	// partition keys are read by chunks to not to keep the read transaction while the callback is working
+	scanPKeysChunkSize = 1000
 )
 
 // bolt cannot use empty keys so we declare nullKey
@@ -315,6 +315,31 @@ func (s *appStorageType) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int,
 	return
 }
 
+func (s *appStorageType) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	for i := range items {
+		items[i].TTLInSeconds, items[i].Ok = 0, false
+	}
+	return s.db.View(func(tx *bolt.Tx) error {
+		dataBucket := tx.Bucket([]byte(dataBucketName))
+		if dataBucket == nil {
+			return ErrDataBucketNotFound
+		}
+
+		bucket := dataBucket.Bucket(pKey)
+		if bucket == nil {
+			return nil
+		}
+
+		now := s.iTime.Now()
+		for i := range items {
+			if v := bucket.Get(safeKey(items[i].CCols)); v != nil {
+				items[i].TTLInSeconds, items[i].Ok = coreutils.ReadWithExpiration(v).TTLInSeconds(now)
+			}
+		}
+		return nil
+	})
+}
+
 // istorage.IAppStorage.Put(pKey []byte, cCols []byte, value []byte) (err error)
 func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error) {
 	return s.db.Update(func(tx *bolt.Tx) error {
@@ -348,7 +373,7 @@ func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
 			}
 
 			d := coreutils.DataWithExpiration{Data: items[i].Value}
-			if err := bucket.Put(items[i].CCols, d.ToBytes()); err != nil {
+			if err := bucket.Put(safeKey(items[i].CCols), d.ToBytes()); err != nil {
 				return err
 			}
 		}
@@ -423,6 +448,59 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 	})
 }
 
+// bbolt: read-only transactions should not be nested in the same goroutine
+// so partition keys are read by chunks and cb is called out of the transaction
+func (s *appStorageType) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	var lastPKey []byte
+	for ctx.Err() == nil {
+		pKeys := make([][]byte, 0, scanPKeysChunkSize)
+		scanned := 0
+		err = s.db.View(func(tx *bolt.Tx) error {
+			dataBucket := tx.Bucket([]byte(dataBucketName))
+			if dataBucket == nil {
+				return ErrDataBucketNotFound
+			}
+
+			cr := dataBucket.Cursor()
+			var k, v []byte
+			if lastPKey == nil {
+				k, v = cr.First()
+			} else if k, v = cr.Seek(lastPKey); bytes.Equal(k, lastPKey) {
+				k, v = cr.Next()
+			}
+			for ; k != nil && scanned < scanPKeysChunkSize; k, v = cr.Next() {
+				// partitions are nested buckets, nested buckets have nil values
+				if v != nil {
+					continue
+				}
+				scanned++
+				lastPKey = bytes.Clone(k)
+				if tokens.Contains(istorage.PKeyToken(k)) {
+					pKeys = append(pKeys, lastPKey)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, pKey := range pKeys {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err = cb(pKey); err != nil {
+				return err
+			}
+		}
+
+		if scanned < scanPKeysChunkSize {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, checkTTL bool) (err error) {
 	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
 		return nil // absurd range
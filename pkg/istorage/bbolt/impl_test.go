@@ -84,6 +84,28 @@ func Test_PutGet(t *testing.T) {
 	require.Equal("Molchanovsky Dmitry Anatolyevich", string(value))
 }
 
+func Test_PutBatchEmptyCCols(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params, testingu.MockTime)
+	storageProvider := istorageimpl.Provide(factory)
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	// bbolt does not accept empty keys, so empty clustering columns must be put as nullKey
+	err = appStorage.PutBatch([]istorage.BatchItem{{PKey: []byte("pKey"), CCols: nil, Value: []byte("test data string")}})
+	require.NoError(err)
+
+	value := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("pKey"), nil, &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal([]byte("test data string"), value)
+}
+
 func TestBackgroundCleaner(t *testing.T) {
 	params := prepareTestData()
 	defer cleanupTestData(params)
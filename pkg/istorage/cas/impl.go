@@ -209,6 +209,49 @@ func (s *appStorageType) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int,
 	return ttlInSeconds, true, nil
 }
 
+func (s *appStorageType) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	if len(items) == 0 {
+		return nil // `c_col in ()` is not a valid CQL
+	}
+	ccToIdx := make(map[string][]int)
+	values := make([]interface{}, 0, len(items)+1)
+	values = append(values, pKey)
+
+	stmt := strings.Builder{}
+	stmt.WriteString("select c_col, TTL(value) from ")
+	stmt.WriteString(s.keyspace)
+	stmt.WriteString(".values where p_key=? and ")
+	stmt.WriteString("c_col in (")
+	for i, item := range items {
+		items[i].Ok, items[i].TTLInSeconds = false, 0
+		values = append(values, safeCcols(item.CCols))
+		ccToIdx[string(item.CCols)] = append(ccToIdx[string(item.CCols)], i)
+		stmt.WriteRune('?')
+		if i < len(items)-1 {
+			stmt.WriteRune(',')
+		}
+	}
+	stmt.WriteRune(')')
+
+	scanner := s.session.Query(stmt.String(), values...).
+		Consistency(gocql.Quorum).
+		Iter().
+		Scanner()
+
+	ccols := make([]byte, 0)
+	for scanner.Next() {
+		ccols = ccols[:0]
+		ttlInSeconds := 0
+		if err := scanner.Scan(&ccols, &ttlInSeconds); err != nil {
+			return scannerCloser(scanner, err)
+		}
+		for _, i := range ccToIdx[string(ccols)] {
+			items[i].Ok, items[i].TTLInSeconds = true, ttlInSeconds
+		}
+	}
+	return scannerCloser(scanner, nil)
+}
+
 func getSession(cluster *gocql.ClusterConfig) (*gocql.Session, error) {
 	session, err := cluster.CreateSession()
 	if err != nil {
@@ -305,6 +348,34 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 	return scanViewQuery(ctx, q, cb)
 }
 
+// partition keys are returned in the order of their tokens
+func (s *appStorageType) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	q := fmt.Sprintf("select distinct p_key from %s.values where token(p_key)>=? and token(p_key)<=?", s.keyspace)
+	scanner := s.session.Query(q, tokens.Start, tokens.End).
+		Consistency(gocql.Quorum).
+		WithContext(ctx).
+		Iter().
+		Scanner()
+	for scanner.Next() {
+		if ctx.Err() != nil {
+			// closes the iterator, its error is caused by the context
+			_ = scannerCloser(scanner, nil)
+			return nil // TCK contract
+		}
+		pKey := make([]byte, 0)
+		if err = scanner.Scan(&pKey); err != nil {
+			return scannerCloser(scanner, err)
+		}
+		if err = cb(pKey); err != nil {
+			return scannerCloser(scanner, err)
+		}
+	}
+	if err = scanner.Err(); err != nil && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
 func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	*data = (*data)[0:0]
 	q := fmt.Sprintf("select value from %s.values where p_key=? and c_col=?", s.keyspace)
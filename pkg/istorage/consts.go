@@ -4,6 +4,8 @@
 
 package istorage
 
+import "math"
+
 const (
 	AppStorageStatus_Pending AppStorageStatus = iota
 	AppStorageStatus_Done
@@ -18,5 +20,5 @@ const (
 
 var (
 	SysMetaSafeName = SafeAppName{name: "sysmeta"}
+	FullTokenRange  = TokenRange{Start: math.MinInt64, End: math.MaxInt64}
 )
-
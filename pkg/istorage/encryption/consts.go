@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+// the key ring of the application is read from the secret DefaultSecretNamePrefix + SafeAppName
+const DefaultSecretNamePrefix = "storage-key-"
+
+// clustering columns are encrypted by the key with this ID, the key is never rotated
+const CColsKeyID KeyID = 0
+
+// records are read and re-encrypted by batches of this size
+const DefaultReEncryptBatchSize = 256
+
+const (
+	// value layout: valueFormat_v1, key ID, nonce, ciphertext with GCM tag
+	valueFormat_v1  byte = 1
+	valueHeaderSize      = 2
+	nonceSize            = 12
+)
+
+const (
+	keyRingSeparator  = ":"
+	keyRingComment    = "#"
+	ccolsCipherInfo   = "voedger.istorage.encryption.ccols.cipher"
+	ccolsNonceMACInfo = "voedger.istorage.encryption.ccols.nonce"
+)
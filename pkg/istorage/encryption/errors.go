@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import "errors"
+
+var (
+	ErrWrongKeyRing     = errors.New("wrong storage encryption key ring")
+	ErrUnknownKey       = errors.New("unknown storage encryption key")
+	ErrWrongValueFormat = errors.New("wrong encrypted value format")
+	ErrDecryptionFailed = errors.New("storage record decryption failed")
+	errBatchFull        = errors.New("batch is full")
+)
@@ -0,0 +1,283 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func (f *appStorageFactory) AppStorage(appName istorage.SafeAppName) (istorage.IAppStorage, error) {
+	storage, err := f.factory.AppStorage(appName)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := f.readKeyRing(appName)
+	if err != nil {
+		return nil, err
+	}
+	s := &appStorage{storage: storage, keys: keys}
+	if !f.params.DisableReEncryption && keys.hasRetiredKeys() {
+		f.startReEncryption(appName, s)
+	}
+	return s, nil
+}
+
+// the key ring is checked before the storage is created
+func (f *appStorageFactory) Init(appName istorage.SafeAppName) error {
+	if _, err := f.readKeyRing(appName); err != nil {
+		return err
+	}
+	return f.factory.Init(appName)
+}
+
+func (f *appStorageFactory) Time() timeu.ITime {
+	return f.factory.Time()
+}
+
+func (f *appStorageFactory) StopGoroutines() {
+	f.cancel()
+	f.wg.Wait()
+	f.factory.StopGoroutines()
+}
+
+func (f *appStorageFactory) readKeyRing(appName istorage.SafeAppName) (*keyRing, error) {
+	secretName := f.params.SecretNamePrefix + appName.String()
+	secret, err := f.secretReader.ReadSecret(secretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s: %w", secretName, err)
+	}
+	keys, err := parseKeyRing(secret, f.params.EncryptCCols)
+	if err != nil {
+		return nil, fmt.Errorf("secret %s: %w", secretName, err)
+	}
+	return keys, nil
+}
+
+// the storage is re-encrypted once per factory, the interrupted re-encryption is started again by the next process
+func (f *appStorageFactory) startReEncryption(appName istorage.SafeAppName, s *appStorage) {
+	f.reEncryptedMu.Lock()
+	defer f.reEncryptedMu.Unlock()
+	if f.reEncrypted[appName] {
+		return
+	}
+	f.reEncrypted[appName] = true
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		logger.Info(fmt.Sprintf("%s: re-encryption by key %d is started", appName, s.keys.current))
+		res, err := s.reEncrypt(f.ctx, f.params.ReEncryptBatchSize)
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: re-encryption failed: %s", appName, err))
+		} else {
+			logger.Info(fmt.Sprintf("%s: re-encryption is finished: %+v", appName, res))
+		}
+		if f.params.OnReEncrypted != nil {
+			f.params.OnReEncrypted(appName, res, err)
+		}
+	}()
+}
+
+func (s *appStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	return s.storage.Put(pKey, s.keys.encryptCCols(pKey, cCols), s.keys.encryptValue(pKey, cCols, value))
+}
+
+func (s *appStorage) PutBatch(items []istorage.BatchItem) (err error) {
+	encrypted := make([]istorage.BatchItem, len(items))
+	for i, item := range items {
+		encrypted[i] = istorage.BatchItem{
+			PKey:  item.PKey,
+			CCols: s.keys.encryptCCols(item.PKey, item.CCols),
+			Value: s.keys.encryptValue(item.PKey, item.CCols, item.Value),
+		}
+	}
+	return s.storage.PutBatch(encrypted)
+}
+
+func (s *appStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.get(s.storage.Get, pKey, cCols, data)
+}
+
+func (s *appStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.get(s.storage.TTLGet, pKey, cCols, data)
+}
+
+// the encrypted value is read to data and then decrypted in place
+func (s *appStorage) get(get func([]byte, []byte, *[]byte) (bool, error), pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	if ok, err = get(pKey, s.keys.encryptCCols(pKey, cCols), data); !ok || err != nil {
+		return ok, err
+	}
+	return true, s.decryptInPlace(pKey, cCols, data)
+}
+
+func (s *appStorage) decryptInPlace(pKey []byte, cCols []byte, data *[]byte) error {
+	raw := *data
+	if _, err := valueKeyID(raw); err != nil {
+		return err
+	}
+	ciphertext := raw[valueHeaderSize+nonceSize:]
+	value, err := s.keys.decryptValue(ciphertext[:0], pKey, cCols, raw)
+	if err != nil {
+		return err
+	}
+	*data = append(raw[:0], value...)
+	return nil
+}
+
+func (s *appStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	encrypted := make([]istorage.GetBatchItem, len(items))
+	for i, item := range items {
+		encrypted[i] = istorage.GetBatchItem{CCols: s.keys.encryptCCols(pKey, item.CCols), Data: item.Data}
+	}
+	if err := s.storage.GetBatch(pKey, encrypted); err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Ok = encrypted[i].Ok
+		if !items[i].Ok {
+			continue
+		}
+		if err := s.decryptInPlace(pKey, items[i].CCols, items[i].Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *appStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, s.storage.Read, pKey, startCCols, finishCCols, cb)
+}
+
+func (s *appStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, s.storage.TTLRead, pKey, startCCols, finishCCols, cb)
+}
+
+type readFunc func(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error)
+
+func (s *appStorage) read(ctx context.Context, read readFunc, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if s.keys.ccols != nil {
+		return s.readSorted(ctx, read, pKey, startCCols, finishCCols, cb)
+	}
+	return read(ctx, pKey, startCCols, finishCCols, func(cCols []byte, raw []byte) error {
+		value, err := s.keys.decryptValue(nil, pKey, cCols, raw)
+		if err != nil {
+			return err
+		}
+		return cb(cCols, value)
+	})
+}
+
+// the order of encrypted clustering columns differs from the order of the plain ones
+// so the whole partition is read, filtered by the range and sorted
+func (s *appStorage) readSorted(ctx context.Context, read readFunc, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if len(startCCols) > 0 && len(finishCCols) > 0 && bytes.Compare(startCCols, finishCCols) >= 0 {
+		return nil // absurd range
+	}
+	records := []record{}
+	err = read(ctx, pKey, nil, nil, func(rawCCols []byte, raw []byte) error {
+		cCols, err := s.keys.decryptCCols(pKey, rawCCols)
+		if err != nil {
+			return err
+		}
+		if len(startCCols) > 0 && bytes.Compare(cCols, startCCols) < 0 {
+			return nil
+		}
+		if len(finishCCols) > 0 && bytes.Compare(cCols, finishCCols) >= 0 {
+			return nil
+		}
+		value, err := s.keys.decryptValue(nil, pKey, cCols, raw)
+		if err != nil {
+			return err
+		}
+		records = append(records, record{cCols: bytes.Clone(cCols), value: value})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(records, func(a, b record) int { return bytes.Compare(a.cCols, b.cCols) })
+	for _, r := range records {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := cb(r.cCols, r.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *appStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.storage.InsertIfNotExists(pKey, s.keys.encryptCCols(pKey, cCols), s.keys.encryptValue(pKey, cCols, value), ttlSeconds)
+}
+
+// encrypted values of the same plain value differ, so the current encrypted value is compared to oldValue
+// and then swapped by the underlying storage if it is not changed since that
+func (s *appStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	rawCCols := s.keys.encryptCCols(pKey, cCols)
+	raw, ok, err := s.currentRaw(pKey, rawCCols, cCols, oldValue)
+	if !ok || err != nil {
+		return false, err
+	}
+	return s.storage.CompareAndSwap(pKey, rawCCols, raw, s.keys.encryptValue(pKey, cCols, newValue), ttlSeconds)
+}
+
+func (s *appStorage) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	rawCCols := s.keys.encryptCCols(pKey, cCols)
+	if expectedValue == nil {
+		return s.storage.CompareAndDelete(pKey, rawCCols, nil)
+	}
+	raw, ok, err := s.currentRaw(pKey, rawCCols, cCols, expectedValue)
+	if !ok || err != nil {
+		return false, err
+	}
+	return s.storage.CompareAndDelete(pKey, rawCCols, raw)
+}
+
+// returns the current encrypted value if its plain value equals to expected
+func (s *appStorage) currentRaw(pKey []byte, rawCCols []byte, cCols []byte, expected []byte) (raw []byte, ok bool, err error) {
+	raw = []byte{}
+	if ok, err = s.storage.TTLGet(pKey, rawCCols, &raw); !ok || err != nil {
+		return nil, false, err
+	}
+	value, err := s.keys.decryptValue(nil, pKey, cCols, raw)
+	if err != nil {
+		return nil, false, err
+	}
+	return raw, bytes.Equal(value, expected), nil
+}
+
+func (s *appStorage) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	return s.storage.QueryTTL(pKey, s.keys.encryptCCols(pKey, cCols))
+}
+
+func (s *appStorage) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	encrypted := make([]istorage.QueryTTLBatchItem, len(items))
+	for i := range items {
+		encrypted[i].CCols = s.keys.encryptCCols(pKey, items[i].CCols)
+	}
+	if err := istorage.QueryTTLBatch(s.storage, pKey, encrypted); err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Ok, items[i].TTLInSeconds = encrypted[i].Ok, encrypted[i].TTLInSeconds
+	}
+	return nil
+}
+
+// partition keys are not encrypted
+func (s *appStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	scanner, ok := s.storage.(istorage.IPKeysScanner)
+	if !ok {
+		return istorage.ErrPKeysScanNotSupported
+	}
+	return scanner.ScanPKeys(ctx, tokens, cb)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseKeyRing parses the secret of the following format:
+//
+//	# comment
+//	<key ID>:<base64 of 16, 24 or 32 bytes AES key>
+//
+// the key with the highest ID is used to encrypt, the others are used to decrypt only
+// the key with CColsKeyID is required if encryptCCols
+func parseKeyRing(secret []byte, encryptCCols bool) (*keyRing, error) {
+	kr := &keyRing{keys: map[KeyID]cipher.AEAD{}}
+	ccolsKey := []byte(nil)
+	scanner := bufio.NewScanner(bytes.NewReader(secret))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, keyRingComment) {
+			continue
+		}
+		idStr, keyStr, ok := strings.Cut(line, keyRingSeparator)
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: <key ID>%s<base64 key> expected", ErrWrongKeyRing, lineNum, keyRingSeparator)
+		}
+		id, err := strconv.ParseUint(strings.TrimSpace(idStr), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: wrong key ID: %w", ErrWrongKeyRing, lineNum, err)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyStr))
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %w", ErrWrongKeyRing, id, err)
+		}
+		if _, exists := kr.keys[KeyID(id)]; exists || (KeyID(id) == CColsKeyID && ccolsKey != nil) {
+			return nil, fmt.Errorf("%w: key %d is duplicated", ErrWrongKeyRing, id)
+		}
+		if KeyID(id) == CColsKeyID {
+			ccolsKey = key
+			continue
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %w", ErrWrongKeyRing, id, err)
+		}
+		kr.keys[KeyID(id)] = aead
+		kr.current = max(kr.current, KeyID(id))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWrongKeyRing, err)
+	}
+	if len(kr.keys) == 0 {
+		return nil, fmt.Errorf("%w: no keys with ID > %d", ErrWrongKeyRing, CColsKeyID)
+	}
+	if encryptCCols {
+		if ccolsKey == nil {
+			return nil, fmt.Errorf("%w: key %d for clustering columns is required", ErrWrongKeyRing, CColsKeyID)
+		}
+		aead, err := newAEAD(deriveKey(ccolsKey, ccolsCipherInfo)[:len(ccolsKey)])
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %w", ErrWrongKeyRing, CColsKeyID, err)
+		}
+		kr.ccols = &ccolsCipher{aead: aead, nonceKey: deriveKey(ccolsKey, ccolsNonceMACInfo)}
+	}
+	return kr, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func deriveKey(key []byte, info string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(info))
+	return mac.Sum(nil)
+}
+
+// true if the key ring contains keys which are not used to encrypt
+func (kr *keyRing) hasRetiredKeys() bool {
+	return len(kr.keys) > 1
+}
+
+// binds the value to its place in the storage, so the value moved to another record is not decrypted
+func valueAAD(pKey []byte, cCols []byte) []byte {
+	aad := make([]byte, 0, 2+len(pKey)+len(cCols))
+	aad = binary.BigEndian.AppendUint16(aad, uint16(len(pKey))) // nolint G115: pKey length is limited by drivers
+	aad = append(aad, pKey...)
+	return append(aad, cCols...)
+}
+
+func (kr *keyRing) encryptValue(pKey []byte, cCols []byte, value []byte) []byte {
+	aead := kr.keys[kr.current]
+	res := make([]byte, valueHeaderSize+nonceSize, valueHeaderSize+nonceSize+len(value)+aead.Overhead())
+	res[0] = valueFormat_v1
+	res[1] = kr.current
+	if _, err := rand.Read(res[valueHeaderSize:]); err != nil {
+		// notest: crypto/rand never returns error
+		panic(err)
+	}
+	return aead.Seal(res, res[valueHeaderSize:], value, valueAAD(pKey, cCols))
+}
+
+// appends the decrypted value to dst
+// cCols are not encrypted ones
+func (kr *keyRing) decryptValue(dst []byte, pKey []byte, cCols []byte, raw []byte) ([]byte, error) {
+	id, err := valueKeyID(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, ok := kr.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKey, id)
+	}
+	nonce := raw[valueHeaderSize : valueHeaderSize+nonceSize]
+	res, err := aead.Open(dst, nonce, raw[valueHeaderSize+nonceSize:], valueAAD(pKey, cCols))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+	return res, nil
+}
+
+func valueKeyID(raw []byte) (KeyID, error) {
+	if len(raw) < valueHeaderSize+nonceSize || raw[0] != valueFormat_v1 {
+		return 0, ErrWrongValueFormat
+	}
+	return raw[1], nil
+}
+
+// empty clustering columns are not encrypted to keep nil and empty clustering columns equal
+func (kr *keyRing) encryptCCols(pKey []byte, cCols []byte) []byte {
+	if kr.ccols == nil || len(cCols) == 0 {
+		return cCols
+	}
+	mac := hmac.New(sha256.New, kr.ccols.nonceKey)
+	_, _ = mac.Write(valueAAD(pKey, cCols))
+	nonce := mac.Sum(nil)[:nonceSize]
+	res := make([]byte, nonceSize, nonceSize+len(cCols)+kr.ccols.aead.Overhead())
+	copy(res, nonce)
+	return kr.ccols.aead.Seal(res, nonce, cCols, pKey)
+}
+
+func (kr *keyRing) decryptCCols(pKey []byte, raw []byte) ([]byte, error) {
+	if kr.ccols == nil || len(raw) == 0 {
+		return raw, nil
+	}
+	if len(raw) < nonceSize {
+		return nil, fmt.Errorf("%w: clustering columns are too short", ErrDecryptionFailed)
+	}
+	res, err := kr.ccols.aead.Open(nil, raw[:nonceSize], raw[nonceSize:], pKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: clustering columns: %w", ErrDecryptionFailed, err)
+	}
+	return res, nil
+}
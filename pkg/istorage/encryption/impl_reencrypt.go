@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// reEncrypt re-encrypts by the current key all records encrypted by the retired keys
+// records are swapped by CompareAndSwap, so records changed concurrently are not overwritten
+// returns nil if ctx is done
+func (s *appStorage) reEncrypt(ctx context.Context, batchSize int) (res ReEncryptResult, err error) {
+	scanner, ok := s.storage.(istorage.IPKeysScanner)
+	if !ok {
+		return res, istorage.ErrPKeysScanNotSupported
+	}
+	err = scanner.ScanPKeys(ctx, istorage.FullTokenRange, func(pKey []byte) error {
+		pKey = bytes.Clone(pKey)
+		err := s.readRawByBatches(ctx, pKey, batchSize, func(records []record) error {
+			for _, r := range records {
+				if err := s.reEncryptRecord(pKey, r, &res); err != nil {
+					return fmt.Errorf("failed to re-encrypt record %x of partition %x: %w", r.cCols, pKey, err)
+				}
+			}
+			return nil
+		})
+		if err == nil && ctx.Err() == nil {
+			res.Partitions++
+		}
+		return err
+	})
+	return res, err
+}
+
+func (s *appStorage) reEncryptRecord(pKey []byte, r record, res *ReEncryptResult) error {
+	id, err := valueKeyID(r.value)
+	if err != nil {
+		return err
+	}
+	if id == s.keys.current {
+		return nil
+	}
+	cCols, err := s.keys.decryptCCols(pKey, r.cCols)
+	if err != nil {
+		return err
+	}
+	value, err := s.keys.decryptValue(nil, pKey, cCols, r.value)
+	if err != nil {
+		return err
+	}
+	ttlSeconds, ok, err := s.storage.QueryTTL(pKey, r.cCols)
+	if err != nil {
+		return err
+	}
+	if ok {
+		ok, err = s.storage.CompareAndSwap(pKey, r.cCols, r.value, s.keys.encryptValue(pKey, cCols, value), ttlSeconds)
+		if err != nil {
+			return err
+		}
+	}
+	if ok {
+		res.Records++
+	} else {
+		// changed, deleted or expired since read
+		res.Skipped++
+	}
+	return nil
+}
+
+// reads the partition of the underlying storage by ranges of batchSize records
+// the storage is not accessed from the istorage.ReadCallback so cb is free to use it
+func (s *appStorage) readRawByBatches(ctx context.Context, pKey []byte, batchSize int, cb func([]record) error) error {
+	var startCCols, lastCCols []byte
+	started := false
+	for ctx.Err() == nil {
+		records := make([]record, 0, batchSize)
+		err := s.storage.Read(ctx, pKey, startCCols, nil, func(cCols []byte, value []byte) error {
+			if started && bytes.Compare(cCols, lastCCols) <= 0 {
+				return nil
+			}
+			records = append(records, record{cCols: bytes.Clone(cCols), value: bytes.Clone(value)})
+			if len(records) == batchSize {
+				return errBatchFull
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errBatchFull) {
+			return err
+		}
+		if len(records) > 0 {
+			if err := cb(records); err != nil {
+				return err
+			}
+		}
+		if err == nil {
+			return nil
+		}
+		// next range starts right after the last read clustering columns
+		started = true
+		lastCCols = records[len(records)-1].cCols
+		startCCols = append(bytes.Clone(lastCCols), 0)
+	}
+	return nil
+}
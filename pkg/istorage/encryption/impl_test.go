@@ -0,0 +1,335 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	memFactory := mem.Provide(testingu.MockTime)
+	secrets := &testSecretReader{keyRing: testKeyRing(1)}
+	storageProvider := provider.Provide(Provide(memFactory, secrets, Params{}))
+	defer storageProvider.Stop()
+
+	// the factory is used by the storage provider as the factory of any driver
+	storage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	require.NoError(storage.Put([]byte("pKey"), []byte("cCols"), []byte("value")))
+
+	storage = appStorageOf(t, Provide(memFactory, secrets, Params{}))
+	require.NoError(storage.Put([]byte("pKey"), []byte("cCols"), []byte("test data string")))
+
+	value := []byte{}
+	ok, err := storage.Get([]byte("pKey"), []byte("cCols"), &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal([]byte("test data string"), value)
+
+	t.Run("value is encrypted in the underlying storage", func(t *testing.T) {
+		raw := []byte{}
+		ok, err := rawStorage(t, memFactory).Get([]byte("pKey"), []byte("cCols"), &raw)
+		require.NoError(err)
+		require.True(ok)
+		require.NotContains(string(raw), "test data string")
+		require.Equal(KeyID(1), raw[1])
+	})
+}
+
+func TestTCK(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		factory := Provide(mem.Provide(testingu.MockTime), &testSecretReader{keyRing: testKeyRing(1, 2)}, Params{DisableReEncryption: true})
+		istorage.TechnologyCompatibilityKit(t, factory)
+	})
+	t.Run("values and clustering columns", func(t *testing.T) {
+		factory := Provide(mem.Provide(testingu.MockTime), &testSecretReader{keyRing: testKeyRing(0, 1)}, Params{EncryptCCols: true})
+		istorage.TechnologyCompatibilityKit(t, factory)
+	})
+}
+
+func TestClusteringColumnsEncryption(t *testing.T) {
+	require := require.New(t)
+
+	memFactory := mem.Provide(testingu.MockTime)
+	secrets := &testSecretReader{keyRing: testKeyRing(0, 1)}
+	storage := appStorageOf(t, Provide(memFactory, secrets, Params{EncryptCCols: true}))
+
+	pKey := []byte("pKey")
+	for _, cCols := range []string{"c", "a", "d", "b"} {
+		require.NoError(storage.Put(pKey, []byte(cCols), []byte("value "+cCols)))
+	}
+
+	t.Run("clustering columns are encrypted in the underlying storage", func(t *testing.T) {
+		raw := rawStorage(t, memFactory)
+		value := []byte{}
+		ok, err := raw.Get(pKey, []byte("a"), &value)
+		require.NoError(err)
+		require.False(ok)
+
+		count := 0
+		require.NoError(raw.Read(t.Context(), pKey, nil, nil, func(cCols []byte, _ []byte) error {
+			require.Greater(len(cCols), nonceSize)
+			count++
+			return nil
+		}))
+		require.Equal(4, count)
+	})
+
+	t.Run("the same clustering columns of another partition are encrypted differently", func(t *testing.T) {
+		kr, err := parseKeyRing(testKeyRing(0, 1), true)
+		require.NoError(err)
+		require.Equal(kr.encryptCCols(pKey, []byte("a")), kr.encryptCCols(pKey, []byte("a")))
+		require.NotEqual(kr.encryptCCols(pKey, []byte("a")), kr.encryptCCols([]byte("pKey2"), []byte("a")))
+	})
+
+	t.Run("read range in the order of the plain clustering columns", func(t *testing.T) {
+		read := []string{}
+		require.NoError(storage.Read(t.Context(), pKey, []byte("b"), []byte("d"), func(cCols []byte, value []byte) error {
+			read = append(read, string(cCols)+"="+string(value))
+			return nil
+		}))
+		require.Equal([]string{"b=value b", "c=value c"}, read)
+	})
+
+	t.Run("whole partition is read and kept in memory for any range", func(t *testing.T) {
+		counting := &readCountingFactory{IAppStorageFactory: memFactory}
+		storage := appStorageOf(t, Provide(counting, secrets, Params{EncryptCCols: true}))
+		read := 0
+		require.NoError(storage.Read(t.Context(), pKey, []byte("a"), []byte("b"), func([]byte, []byte) error {
+			require.Equal(4, counting.records, "records are passed to the callback after the whole partition is read")
+			read++
+			return nil
+		}))
+		require.Equal(1, read)
+		require.Equal(4, counting.records)
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	for _, encryptCCols := range []bool{false, true} {
+		t.Run(fmt.Sprintf("encryptCCols=%v", encryptCCols), func(t *testing.T) {
+			require := require.New(t)
+
+			memFactory := mem.Provide(testingu.NewMockTime())
+			secrets := &testSecretReader{keyRing: testKeyRing(0, 1)}
+			params := Params{EncryptCCols: encryptCCols, ReEncryptBatchSize: 3}
+			storage := appStorageOf(t, Provide(memFactory, secrets, params))
+
+			const records = 10
+			pKeys := [][]byte{[]byte("pKey1"), []byte("pKey2")}
+			for _, pKey := range pKeys {
+				for i := range records {
+					require.NoError(storage.Put(pKey, fmt.Appendf(nil, "cCols%d", i), fmt.Appendf(nil, "value%d", i)))
+				}
+			}
+			ok, err := storage.InsertIfNotExists(pKeys[0], []byte("ttl"), []byte("ttl value"), 100)
+			require.NoError(err)
+			require.True(ok)
+
+			// key 2 is added
+			secrets.set(testKeyRing(0, 1, 2))
+			reEncrypted := make(chan ReEncryptResult, 1)
+			params.OnReEncrypted = func(_ istorage.SafeAppName, res ReEncryptResult, err error) {
+				require.NoError(err)
+				reEncrypted <- res
+			}
+			rotated := Provide(memFactory, secrets, params)
+			storage = appStorageOf(t, rotated)
+			require.Equal(ReEncryptResult{Partitions: len(pKeys), Records: len(pKeys)*records + 1}, <-reEncrypted)
+			rotated.StopGoroutines()
+
+			t.Run("all records are encrypted by the current key", func(t *testing.T) {
+				for _, pKey := range pKeys {
+					require.NoError(rawStorage(t, memFactory).Read(t.Context(), pKey, nil, nil, func(_ []byte, raw []byte) error {
+						require.Equal(KeyID(2), raw[1])
+						return nil
+					}))
+				}
+			})
+
+			t.Run("TTL is kept", func(t *testing.T) {
+				ttl, ok, err := storage.QueryTTL(pKeys[0], []byte("ttl"))
+				require.NoError(err)
+				require.True(ok)
+				require.Positive(ttl)
+			})
+
+			// key 1 is retired
+			secrets.set(testKeyRing(0, 2))
+			storage = appStorageOf(t, Provide(memFactory, secrets, params))
+			for _, pKey := range pKeys {
+				count := 0
+				require.NoError(storage.Read(t.Context(), pKey, nil, nil, func(cCols []byte, value []byte) error {
+					if !bytes.Equal(cCols, []byte("ttl")) {
+						require.Equal(bytes.Replace(cCols, []byte("cCols"), []byte("value"), 1), value)
+					}
+					count++
+					return nil
+				}))
+				require.Positive(count)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	t.Run("wrong key ring", func(t *testing.T) {
+		cases := map[string]string{
+			"empty":                  "",
+			"comments only":          "# 1:" + key,
+			"no separator":           "1" + key,
+			"wrong ID":               "x:" + key,
+			"ID out of range":        "256:" + key,
+			"wrong base64":           "1:???",
+			"wrong key length":       "1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+			"duplicated key":         "1:" + key + "\n1:" + key,
+			"ccols key only":         "0:" + key,
+			"no ccols key":           "1:" + key,
+			"wrong ccols key length": "0:" + base64.StdEncoding.EncodeToString([]byte("short")) + "\n1:" + key,
+		}
+		for name, secret := range cases {
+			t.Run(name, func(t *testing.T) {
+				factory := Provide(mem.Provide(testingu.MockTime), &testSecretReader{keyRing: []byte(secret)}, Params{EncryptCCols: true})
+				err := factory.Init(istorage.NewTestSafeName("test"))
+				require.ErrorIs(err, ErrWrongKeyRing)
+				t.Log(err)
+			})
+		}
+	})
+
+	t.Run("secret read error", func(t *testing.T) {
+		factory := Provide(mem.Provide(testingu.MockTime), &testSecretReader{}, Params{})
+		require.Error(factory.Init(istorage.NewTestSafeName("test")))
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		memFactory := mem.Provide(testingu.MockTime)
+		secrets := &testSecretReader{keyRing: testKeyRing(2)}
+		storage := appStorageOf(t, Provide(memFactory, secrets, Params{}))
+		require.NoError(storage.Put([]byte("pKey"), nil, []byte("value")))
+
+		secrets.set(testKeyRing(1))
+		storage, err := Provide(memFactory, secrets, Params{}).AppStorage(istorage.NewTestSafeName("test"))
+		require.NoError(err)
+		value := []byte{}
+		_, err = storage.Get([]byte("pKey"), nil, &value)
+		require.ErrorIs(err, ErrUnknownKey)
+	})
+
+	t.Run("value moved to another record is not decrypted", func(t *testing.T) {
+		memFactory := mem.Provide(testingu.MockTime)
+		secrets := &testSecretReader{keyRing: testKeyRing(1)}
+		storage := appStorageOf(t, Provide(memFactory, secrets, Params{}))
+		require.NoError(storage.Put([]byte("pKey"), []byte("cCols1"), []byte("value")))
+
+		raw := rawStorage(t, memFactory)
+		value := []byte{}
+		ok, err := raw.Get([]byte("pKey"), []byte("cCols1"), &value)
+		require.NoError(err)
+		require.True(ok)
+		require.NoError(raw.Put([]byte("pKey"), []byte("cCols2"), value))
+
+		_, err = storage.Get([]byte("pKey"), []byte("cCols2"), &value)
+		require.ErrorIs(err, ErrDecryptionFailed)
+	})
+
+	t.Run("not encrypted value", func(t *testing.T) {
+		memFactory := mem.Provide(testingu.MockTime)
+		secrets := &testSecretReader{keyRing: testKeyRing(1)}
+		storage := appStorageOf(t, Provide(memFactory, secrets, Params{}))
+		require.NoError(rawStorage(t, memFactory).Put([]byte("pKey"), nil, []byte("value")))
+
+		value := []byte{}
+		_, err := storage.Get([]byte("pKey"), nil, &value)
+		require.ErrorIs(err, ErrWrongValueFormat)
+	})
+}
+
+type testSecretReader struct {
+	sync.Mutex
+	keyRing []byte
+}
+
+func (r *testSecretReader) ReadSecret(name string) ([]byte, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.keyRing == nil {
+		return nil, fmt.Errorf("secret %s not found", name)
+	}
+	return r.keyRing, nil
+}
+
+func (r *testSecretReader) set(keyRing []byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.keyRing = keyRing
+}
+
+func testKeyRing(ids ...KeyID) []byte {
+	res := []byte("# test key ring\n")
+	for _, id := range ids {
+		res = fmt.Appendf(res, "%d:%s\n", id, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{id + 1}, 32)))
+	}
+	return res
+}
+
+// storage of the test app, initialized if not yet
+func appStorageOf(t *testing.T, factory istorage.IAppStorageFactory) istorage.IAppStorage {
+	appName := istorage.NewTestSafeName("test")
+	if err := factory.Init(appName); err != nil {
+		require.ErrorIs(t, err, istorage.ErrStorageAlreadyExists)
+	}
+	storage, err := factory.AppStorage(appName)
+	require.NoError(t, err)
+	return storage
+}
+
+// the underlying storage of the app initialized by appStorageOf
+func rawStorage(t *testing.T, memFactory istorage.IAppStorageFactory) istorage.IAppStorage {
+	storage, err := memFactory.AppStorage(istorage.NewTestSafeName("test"))
+	require.NoError(t, err)
+	return storage
+}
+
+// counts records read from the underlying storage
+type readCountingFactory struct {
+	istorage.IAppStorageFactory
+	records int
+}
+
+type readCountingStorage struct {
+	istorage.IAppStorage
+	factory *readCountingFactory
+}
+
+func (f *readCountingFactory) AppStorage(appName istorage.SafeAppName) (istorage.IAppStorage, error) {
+	storage, err := f.IAppStorageFactory.AppStorage(appName)
+	return &readCountingStorage{IAppStorage: storage, factory: f}, err
+}
+
+func (s *readCountingStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) error {
+	return s.IAppStorage.Read(ctx, pKey, startCCols, finishCCols, func(cCols []byte, value []byte) error {
+		s.factory.records++
+		return cb(cCols, value)
+	})
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/isecrets"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// Provide returns the factory of storages which encrypt values (and clustering columns if params.EncryptCCols) of the storages
+// provided by the factory by AES-GCM using the application key ring read from secretReader
+func Provide(factory istorage.IAppStorageFactory, secretReader isecrets.ISecretReader, params Params) istorage.IAppStorageFactory {
+	if len(params.SecretNamePrefix) == 0 {
+		params.SecretNamePrefix = DefaultSecretNamePrefix
+	}
+	if params.ReEncryptBatchSize <= 0 {
+		params.ReEncryptBatchSize = DefaultReEncryptBatchSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &appStorageFactory{
+		factory:      factory,
+		secretReader: secretReader,
+		params:       params,
+		ctx:          ctx,
+		cancel:       cancel,
+		reEncrypted:  map[istorage.SafeAppName]bool{},
+	}
+}
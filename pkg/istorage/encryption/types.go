@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/isecrets"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+type Params struct {
+	// DefaultSecretNamePrefix is used if empty
+	SecretNamePrefix string
+
+	// clustering columns are encrypted deterministically by the key with CColsKeyID
+	// the order of clustering columns is lost, so Read and TTLRead read the whole partition and sort it in memory for any range
+	// i.e. memory used by Read is proportional to the partition size, so should not be used for apps with large partitions, e.g. big views
+	EncryptCCols bool
+
+	// records encrypted by the retired keys are not re-encrypted by the current key in the background
+	DisableReEncryption bool
+
+	// DefaultReEncryptBatchSize is used if zero
+	ReEncryptBatchSize int
+
+	// called when the background re-encryption of the application storage is finished
+	OnReEncrypted func(appName istorage.SafeAppName, res ReEncryptResult, err error)
+}
+
+type ReEncryptResult struct {
+	Partitions int
+
+	// records re-encrypted by the current key
+	Records int
+
+	// records changed concurrently during the re-encryption, these are encrypted by the current key already
+	Skipped int
+}
+
+// 0 is CColsKeyID, the key with the highest ID is the current one
+type KeyID = byte
+
+type appStorageFactory struct {
+	factory      istorage.IAppStorageFactory
+	secretReader isecrets.ISecretReader
+	params       Params
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+
+	// application storages re-encrypted or being re-encrypted by this factory
+	reEncrypted   map[istorage.SafeAppName]bool
+	reEncryptedMu sync.Mutex
+}
+
+type appStorage struct {
+	storage istorage.IAppStorage
+	keys    *keyRing
+}
+
+type keyRing struct {
+	keys    map[KeyID]cipher.AEAD
+	current KeyID
+
+	// nil if clustering columns are not encrypted
+	ccols *ccolsCipher
+}
+
+// deterministic encryption: the nonce is MAC of the partition key and clustering columns
+// so the same clustering columns of the same partition are always encrypted to the same bytes
+type ccolsCipher struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+type record struct {
+	cCols []byte
+	value []byte
+}
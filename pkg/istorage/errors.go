@@ -7,7 +7,8 @@ package istorage
 import "errors"
 
 var (
-	ErrStorageAlreadyExists = errors.New("storage already exists")
-	ErrStorageDoesNotExist  = errors.New("storage does not exist")
-	ErrNoSafeAppName        = errors.New("no safe app name")
+	ErrStorageAlreadyExists  = errors.New("storage already exists")
+	ErrStorageDoesNotExist   = errors.New("storage does not exist")
+	ErrNoSafeAppName         = errors.New("no safe app name")
+	ErrPKeysScanNotSupported = errors.New("partition keys scanning is not supported by the storage")
 )
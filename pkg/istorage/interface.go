@@ -132,9 +132,32 @@ type IAppStorage interface {
 	QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error)
 }
 
+// Optional, implemented by IAppStorage of drivers which are able to enumerate partition keys
+// Used to process the entire storage: migration, backup, consistency checks, garbage collection etc
+type IPKeysScanner interface {
+	// calls cb for each partition key which token is within tokens
+	// FullTokenRange covers all partition keys, use TokenRange.Split() to scan parts of the storage in parallel
+	// tokens are driver-specific: Cassandra uses native token(p_key), DynamoDB maps tokens to parallel scan segments, other drivers use PKeyToken()
+	// order of partition keys is undefined but stable between calls for the unchanged storage
+	// the storage may be accessed from cb
+	// returns nil if ctx is done
+	ScanPKeys(ctx context.Context, tokens TokenRange, cb PKeyCallback) (err error)
+}
+
+// Optional, implemented by IAppStorage of drivers which are able to query TTL of several records of the partition at once
+// Use QueryTTLBatch() to query TTL of records of any storage
+type ITTLBatchQuerier interface {
+	// sets items[i].Ok & TTLInSeconds as QueryTTL does for items[i].CCols
+	// items[i].Ok & TTLInSeconds are undefined in case of error
+	QueryTTLBatch(pKey []byte, items []QueryTTLBatchItem) (err error)
+}
+
 // ccols and viewRecord are temporary internal values, must NOT be changed
 type ReadCallback func(ccols []byte, viewRecord []byte) (err error)
 
+// pKey is a temporary internal value, must NOT be changed
+type PKeyCallback func(pKey []byte) (err error)
+
 type BatchItem struct {
 	PKey  []byte
 	CCols []byte
@@ -146,3 +169,9 @@ type GetBatchItem struct {
 	Ok    bool
 	Data  *[]byte
 }
+
+type QueryTTLBatchItem struct {
+	CCols        []byte
+	Ok           bool
+	TTLInSeconds int
+}
@@ -217,6 +217,21 @@ func (s *appStorage) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok b
 	return ttlInSeconds, true, nil
 }
 
+func (s *appStorage) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	p := s.storage[string(pKey)]
+	now := s.iTime.Now()
+	for i := range items {
+		items[i].TTLInSeconds, items[i].Ok = 0, false
+		if record, exists := p[string(items[i].CCols)]; exists {
+			items[i].TTLInSeconds, items[i].Ok = record.TTLInSeconds(now)
+		}
+	}
+	return nil
+}
+
 func (s *appStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -393,6 +408,29 @@ func (s *appStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err e
 	return
 }
 
+func (s *appStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	s.lock.RLock()
+	pKeys := make([]string, 0, len(s.storage))
+	for pKey := range s.storage {
+		if tokens.Contains(istorage.PKeyToken([]byte(pKey))) {
+			pKeys = append(pKeys, pKey)
+		}
+	}
+	s.lock.RUnlock()
+
+	// map iteration order is random, sort to make the order stable
+	sort.Strings(pKeys)
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err = cb([]byte(pKey)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func copySlice(src []byte) []byte {
 	dst := make([]byte, len(src))
 	copy(dst, src)
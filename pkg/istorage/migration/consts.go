@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package migration
+
+const (
+	DefaultBatchSize       = 100
+	DefaultCheckpointEvery = 100
+	DefaultCatchUpPasses   = 3
+)
+
+// the token space is copied by this count of ranges, partitions copied in the current range are kept in the checkpoint
+const checkpointTokenRanges = 256
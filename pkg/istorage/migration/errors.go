@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package migration
+
+import "errors"
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrSourceChanged    = errors.New("source storage is changed during the migration")
+
+	// used to stop reading the partition when the batch is full
+	errBatchFull = errors.New("batch is full")
+)
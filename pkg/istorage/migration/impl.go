@@ -0,0 +1,512 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// Migrate copies all records of src to dst
+// src must implement istorage.IPKeysScanner, istorage.ErrPKeysScanNotSupported otherwise
+// records written with TTL are copied with the remaining TTL, expired records are skipped
+// dst is expected to be empty or to contain the result of the previous interrupted migration of the same src
+// the token space is split into ranges copied one by one, the checkpoint keeps completed ranges and partitions copied in the current range
+// src could be changed during the migration: after the copy src is scanned again up to params.CatchUpPasses times
+// and partitions changed or added since they were copied are copied once again
+// records written without TTL could not be deleted from dst, so src is expected to not to delete them
+// to switch to dst: stop VVMs that use src then run Migrate with the same checkpoint file once again to catch up the rest of changes
+// if params.Verify then src is read once again after catch-up passes and compared to dst, ErrSourceChanged is returned if src is still changed
+func Migrate(ctx context.Context, src, dst istorage.IAppStorage, params Params) (res Result, err error) {
+	scanner, ok := src.(istorage.IPKeysScanner)
+	if !ok {
+		return res, istorage.ErrPKeysScanNotSupported
+	}
+	if params.BatchSize <= 0 {
+		params.BatchSize = DefaultBatchSize
+	}
+	if params.CheckpointEvery <= 0 {
+		params.CheckpointEvery = DefaultCheckpointEvery
+	}
+	if params.CatchUpPasses == 0 {
+		params.CatchUpPasses = DefaultCatchUpPasses
+	}
+
+	cp, err := readCheckpoint(params.CheckpointFile)
+	if err != nil {
+		return res, err
+	}
+	res = cp.Result
+	checksum, err := decodeChecksum(res.Checksum)
+	if err != nil {
+		return res, fmt.Errorf("wrong checkpoint checksum: %w", err)
+	}
+
+	// replaced is the previous copy of the partition in dst, empty if the partition is copied first time
+	applyPartition := func(partition, replaced partitionResult) {
+		if replaced.records == 0 {
+			res.Partitions++
+		}
+		res.Records += partition.records - replaced.records
+		res.TTLRecords += partition.ttlRecords - replaced.ttlRecords
+		xorChecksum(checksum, replaced.sum)
+		xorChecksum(checksum, partition.sum)
+		res.Checksum = hex.EncodeToString(checksum)
+	}
+
+	m := &migrator{src: src, dst: dst, params: params}
+	sinceCheckpoint := 0
+	copied := map[string]bool{}
+	for _, pKey := range cp.PKeys {
+		copied[string(pKey)] = true
+	}
+	// ranges are processed one by one so partitions of completed ranges are not scanned on continue whatever the scan order is
+	tokenRanges := istorage.FullTokenRange.Split(checkpointTokenRanges)
+	for ; cp.TokenRanges < len(tokenRanges) && ctx.Err() == nil; cp.TokenRanges++ {
+		err = scanner.ScanPKeys(ctx, tokenRanges[cp.TokenRanges], func(pKey []byte) error {
+			if copied[string(pKey)] {
+				return nil
+			}
+
+			partition, err := m.copyPartition(ctx, pKey)
+			if err != nil {
+				return fmt.Errorf("failed to copy partition %x: %w", pKey, err)
+			}
+			if ctx.Err() != nil {
+				// partition is copied partially
+				return nil
+			}
+			if params.Verify {
+				if err := m.verifyPartition(ctx, pKey, partition.fullSum); err != nil {
+					return err
+				}
+			}
+
+			applyPartition(partition, partitionResult{})
+
+			copied[string(pKey)] = true
+			cp.PKeys = append(cp.PKeys, bytes.Clone(pKey))
+			cp.Result = res
+			if sinceCheckpoint++; sinceCheckpoint >= params.CheckpointEvery {
+				sinceCheckpoint = 0
+				if err := writeCheckpoint(params.CheckpointFile, cp); err != nil {
+					return err
+				}
+			}
+			if params.OnPartition != nil {
+				params.OnPartition(pKey, res)
+			}
+			return nil
+		})
+		if err != nil {
+			return res, err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		// the range is completed, keys of its partitions are not needed anymore
+		copied = map[string]bool{}
+		cp.PKeys = nil
+	}
+	for pass := 0; pass < params.CatchUpPasses && ctx.Err() == nil; pass++ {
+		caughtUp, err := m.catchUp(ctx, scanner, func(partition, replaced partitionResult) {
+			res.CaughtUp++
+			applyPartition(partition, replaced)
+		})
+		if err != nil {
+			return res, err
+		}
+		if caughtUp == 0 {
+			break
+		}
+	}
+	cp.Result = res
+	if err := writeCheckpoint(params.CheckpointFile, cp); err != nil {
+		return res, err
+	}
+	if ctx.Err() != nil {
+		return res, ctx.Err()
+	}
+	if params.Verify {
+		if err := m.verifySourceUnchanged(ctx, scanner, res.Partitions); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+type migrator struct {
+	src    istorage.IAppStorage
+	dst    istorage.IAppStorage
+	params Params
+}
+
+type partitionResult struct {
+	records    int
+	ttlRecords int
+
+	// hash of the records written without TTL, nil if there are no such records
+	// records written with TTL could expire in dst at any moment, so they are not reflected in Result.Checksum
+	sum []byte
+
+	// hash of all records, used to compare the partition in src and dst
+	fullSum []byte
+}
+
+type partitionSummer struct {
+	res   partitionResult
+	full  hash.Hash
+	noTTL hash.Hash
+}
+
+type record struct {
+	cCols []byte
+	value []byte
+}
+
+func (m *migrator) copyPartition(ctx context.Context, pKey []byte) (partitionResult, error) {
+	summer := newPartitionSummer(pKey)
+	err := m.readByBatches(ctx, m.src, pKey, func(records []record) error {
+		ttls, err := queryTTL(m.src, pKey, records)
+		if err != nil {
+			return err
+		}
+		batch := make([]istorage.BatchItem, 0, len(records))
+		for i, r := range records {
+			if !ttls[i].Ok {
+				// expired after read
+				continue
+			}
+			ttlSeconds := ttls[i].TTLInSeconds
+			summer.add(r, ttlSeconds > 0)
+			if ttlSeconds == 0 {
+				batch = append(batch, istorage.BatchItem{PKey: pKey, CCols: r.cCols, Value: r.value})
+				continue
+			}
+			if err := m.putTTL(pKey, r, ttlSeconds); err != nil {
+				return err
+			}
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		return m.dst.PutBatch(batch)
+	})
+	return summer.result(), err
+}
+
+// the record could exist in dst already if the migration is continued from the checkpoint
+func (m *migrator) putTTL(pKey []byte, r record, ttlSeconds int) error {
+	ok, err := m.dst.InsertIfNotExists(pKey, r.cCols, r.value, ttlSeconds)
+	if err != nil || ok {
+		return err
+	}
+	current := []byte{}
+	if ok, err = m.dst.TTLGet(pKey, r.cCols, &current); err != nil {
+		return err
+	}
+	if !ok {
+		// expired right now
+		ok, err = m.dst.InsertIfNotExists(pKey, r.cCols, r.value, ttlSeconds)
+	} else {
+		ok, err = m.dst.CompareAndSwap(pKey, r.cCols, current, r.value, ttlSeconds)
+	}
+	if err == nil && !ok {
+		err = fmt.Errorf("TTL record %x is concurrently changed in the destination storage", r.cCols)
+	}
+	return err
+}
+
+func (m *migrator) verifyPartition(ctx context.Context, pKey []byte, expected []byte) error {
+	h := newPartitionHash(pKey)
+	err := m.readByBatches(ctx, m.dst, pKey, func(records []record) error {
+		for _, r := range records {
+			writeRecordHash(h, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if ctx.Err() == nil && !bytes.Equal(h.Sum(nil), expected) {
+		return fmt.Errorf("partition %x: %w", pKey, ErrChecksumMismatch)
+	}
+	return nil
+}
+
+// copies again partitions which are changed or added in src since they were copied, returns their count
+// records written with TTL and deleted from src are deleted from dst too
+// onCaughtUp gets the previous copy of the partition read from dst before it is replaced
+func (m *migrator) catchUp(ctx context.Context, scanner istorage.IPKeysScanner, onCaughtUp func(partition, replaced partitionResult)) (caughtUp int, err error) {
+	err = scanner.ScanPKeys(ctx, istorage.FullTokenRange, func(pKey []byte) error {
+		srcPartition, err := m.readPartition(ctx, m.src, pKey)
+		if err != nil {
+			return err
+		}
+		replaced, err := m.readPartition(ctx, m.dst, pKey)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil || bytes.Equal(srcPartition.fullSum, replaced.fullSum) {
+			return nil
+		}
+		partition, err := m.copyPartition(ctx, pKey)
+		if err != nil {
+			return fmt.Errorf("failed to catch up partition %x: %w", pKey, err)
+		}
+		if err := m.deleteStaleTTLRecords(ctx, pKey); err != nil {
+			return fmt.Errorf("failed to catch up partition %x: %w", pKey, err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		caughtUp++
+		onCaughtUp(partition, replaced)
+		return nil
+	})
+	return caughtUp, err
+}
+
+func (m *migrator) deleteStaleTTLRecords(ctx context.Context, pKey []byte) error {
+	stale := []record{}
+	err := m.readByBatches(ctx, m.dst, pKey, func(records []record) error {
+		dstTTLs, err := queryTTL(m.dst, pKey, records)
+		if err != nil {
+			return err
+		}
+		ttlRecords := make([]record, 0, len(records))
+		for i, r := range records {
+			if dstTTLs[i].Ok && dstTTLs[i].TTLInSeconds > 0 {
+				ttlRecords = append(ttlRecords, r)
+			}
+		}
+		if len(ttlRecords) == 0 {
+			return nil
+		}
+		srcTTLs, err := queryTTL(m.src, pKey, ttlRecords)
+		if err != nil {
+			return err
+		}
+		for i, r := range ttlRecords {
+			if !srcTTLs[i].Ok {
+				stale = append(stale, r)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range stale {
+		// false means the record is expired already
+		if _, err := m.dst.CompareAndDelete(pKey, r.cCols, r.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partitions changed in src after they were copied are not detected by verifyPartition
+// records written with TTL are not compared since they could expire during the migration
+func (m *migrator) verifySourceUnchanged(ctx context.Context, scanner istorage.IPKeysScanner, partitions int) error {
+	found := 0
+	err := scanner.ScanPKeys(ctx, istorage.FullTokenRange, func(pKey []byte) error {
+		found++
+		srcPartition, err := m.readPartition(ctx, m.src, pKey)
+		if err != nil {
+			return err
+		}
+		dstPartition, err := m.readPartition(ctx, m.dst, pKey)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() == nil && !bytes.Equal(srcPartition.sum, dstPartition.sum) {
+			return fmt.Errorf("partition %x: %w", pKey, ErrSourceChanged)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if found != partitions {
+		return fmt.Errorf("%d partitions copied, %d partitions found: %w", partitions, found, ErrSourceChanged)
+	}
+	return nil
+}
+
+// reads the partition and returns the same result as copyPartition() would return
+func (m *migrator) readPartition(ctx context.Context, storage istorage.IAppStorage, pKey []byte) (partitionResult, error) {
+	summer := newPartitionSummer(pKey)
+	err := m.readByBatches(ctx, storage, pKey, func(records []record) error {
+		ttls, err := queryTTL(storage, pKey, records)
+		if err != nil {
+			return err
+		}
+		for i, r := range records {
+			if ttls[i].Ok {
+				summer.add(r, ttls[i].TTLInSeconds > 0)
+			}
+		}
+		return nil
+	})
+	return summer.result(), err
+}
+
+// reads the partition by ranges of params.BatchSize records
+// storage is not accessed from the istorage.ReadCallback so cb is free to use it
+func (m *migrator) readByBatches(ctx context.Context, storage istorage.IAppStorage, pKey []byte, cb func([]record) error) error {
+	var startCCols, lastCCols []byte
+	started := false
+	for ctx.Err() == nil {
+		records := make([]record, 0, m.params.BatchSize)
+		err := storage.Read(ctx, pKey, startCCols, nil, func(ccols []byte, viewRecord []byte) error {
+			if started && bytes.Compare(ccols, lastCCols) <= 0 {
+				// e.g. bbolt stores empty clustering columns as {0}
+				return nil
+			}
+			records = append(records, record{cCols: bytes.Clone(ccols), value: bytes.Clone(viewRecord)})
+			if len(records) == m.params.BatchSize {
+				return errBatchFull
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errBatchFull) {
+			return err
+		}
+		if len(records) > 0 {
+			if err := cb(records); err != nil {
+				return err
+			}
+		}
+		if err == nil {
+			return nil
+		}
+		// next range starts right after the last read clustering columns
+		started = true
+		lastCCols = records[len(records)-1].cCols
+		startCCols = append(bytes.Clone(lastCCols), 0)
+	}
+	return nil
+}
+
+// TTL of all records is queried at once if the storage implements istorage.ITTLBatchQuerier
+func queryTTL(storage istorage.IAppStorage, pKey []byte, records []record) ([]istorage.QueryTTLBatchItem, error) {
+	items := make([]istorage.QueryTTLBatchItem, len(records))
+	for i, r := range records {
+		items[i].CCols = r.cCols
+	}
+	return items, istorage.QueryTTLBatch(storage, pKey, items)
+}
+
+func newPartitionSummer(pKey []byte) *partitionSummer {
+	return &partitionSummer{
+		full:  newPartitionHash(pKey),
+		noTTL: newPartitionHash(pKey),
+	}
+}
+
+// records must be added in the clustering columns order
+func (s *partitionSummer) add(r record, withTTL bool) {
+	writeRecordHash(s.full, r)
+	s.res.records++
+	if withTTL {
+		s.res.ttlRecords++
+		return
+	}
+	writeRecordHash(s.noTTL, r)
+}
+
+func (s *partitionSummer) result() partitionResult {
+	res := s.res
+	res.fullSum = s.full.Sum(nil)
+	if res.records > res.ttlRecords {
+		res.sum = s.noTTL.Sum(nil)
+	}
+	return res
+}
+
+// sum could be nil
+func xorChecksum(checksum []byte, sum []byte) {
+	for i := range sum {
+		checksum[i] ^= sum[i]
+	}
+}
+
+func newPartitionHash(pKey []byte) hash.Hash {
+	h := sha256.New()
+	writeLenPrefixed(h, pKey)
+	return h
+}
+
+func writeRecordHash(h hash.Hash, r record) {
+	writeLenPrefixed(h, r.cCols)
+	writeLenPrefixed(h, r.value)
+}
+
+func writeLenPrefixed(h hash.Hash, b []byte) {
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(b))))
+	_, _ = h.Write(b)
+}
+
+func decodeChecksum(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return make([]byte, sha256.Size), nil
+	}
+	res, err := hex.DecodeString(s)
+	if err == nil && len(res) != sha256.Size {
+		err = fmt.Errorf("%d bytes expected, got %d", sha256.Size, len(res))
+	}
+	return res, err
+}
+
+func readCheckpoint(fileName string) (cp checkpoint, err error) {
+	if len(fileName) == 0 {
+		return cp, nil
+	}
+	exists, err := coreutils.Exists(fileName)
+	if err != nil || !exists {
+		return cp, err
+	}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		// notest
+		return cp, err
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, fmt.Errorf("failed to read checkpoint %s: %w", fileName, err)
+	}
+	return cp, nil
+}
+
+func writeCheckpoint(fileName string, cp checkpoint) error {
+	if len(fileName) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(&cp)
+	if err != nil {
+		// notest
+		return err
+	}
+	// write to the temp file first to not to lose the previous checkpoint on failure
+	tmpFileName := fileName + ".tmp"
+	if err := os.WriteFile(tmpFileName, data, coreutils.FileMode_rw_rw_rw_); err != nil {
+		return err
+	}
+	return os.Rename(tmpFileName, fileName)
+}
@@ -0,0 +1,330 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	src := newMemStorage(t)
+	dst := newBboltStorage(t)
+
+	const partitions = 1500 // more than bbolt scans at once
+	for i := 0; i < partitions; i++ {
+		require.NoError(src.Put(pKey(i), []byte{1}, []byte(fmt.Sprint("value", i))))
+		require.NoError(src.Put(pKey(i), nil, []byte("empty ccols")))
+	}
+	ok, err := src.InsertIfNotExists(pKey(0), []byte{2}, []byte("ttl value"), 100)
+	require.NoError(err)
+	require.True(ok)
+
+	res, err := Migrate(context.Background(), src, dst, Params{Verify: true, BatchSize: 1})
+	require.NoError(err)
+	require.Equal(partitions, res.Partitions)
+	require.Equal(partitions*2+1, res.Records)
+	require.Equal(1, res.TTLRecords)
+	require.NotEmpty(res.Checksum)
+
+	value := []byte{}
+	ok, err = dst.Get(pKey(1499), []byte{1}, &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("value1499", string(value))
+
+	t.Run("TTL is preserved", func(t *testing.T) {
+		ttl, ok, err := dst.QueryTTL(pKey(0), []byte{2})
+		require.NoError(err)
+		require.True(ok)
+		require.Positive(ttl)
+		require.LessOrEqual(ttl, 100)
+	})
+
+	t.Run("same checksum on migration of the same data", func(t *testing.T) {
+		res2, err := Migrate(context.Background(), dst, newMemStorage(t), Params{Verify: true})
+		require.NoError(err)
+		require.Equal(res, res2)
+	})
+}
+
+func TestCheckpoints(t *testing.T) {
+	require := require.New(t)
+
+	src := newMemStorage(t)
+	dst := newMemStorage(t)
+	for i := 0; i < 10; i++ {
+		require.NoError(src.Put(pKey(i), []byte{1}, []byte{byte(i)}))
+	}
+	ok, err := src.InsertIfNotExists(pKey(5), []byte{2}, []byte("ttl value"), 100)
+	require.NoError(err)
+	require.True(ok)
+
+	expected, err := Migrate(context.Background(), src, newMemStorage(t), Params{})
+	require.NoError(err)
+
+	cpFile := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	// interrupt after 7 partitions
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = Migrate(ctx, src, dst, Params{
+		CheckpointFile:  cpFile,
+		CheckpointEvery: 3,
+		OnPartition: func(_ []byte, res Result) {
+			if res.Partitions == 7 {
+				cancel()
+			}
+		},
+	})
+	require.ErrorIs(err, context.Canceled)
+
+	cp, err := readCheckpoint(cpFile)
+	require.NoError(err)
+	require.Equal(7, cp.Result.Partitions)
+
+	// continue, partitions are scanned in the other order
+	copied := 0
+	res, err := Migrate(context.Background(), &reversedScanStorage{src}, dst, Params{
+		CheckpointFile: cpFile,
+		Verify:         true,
+		OnPartition:    func([]byte, Result) { copied++ },
+	})
+	require.NoError(err)
+	require.Equal(3, copied)
+	require.Equal(expected, res)
+
+	t.Run("continue from the checkpoint of the finished migration", func(t *testing.T) {
+		res, err := Migrate(context.Background(), src, dst, Params{CheckpointFile: cpFile})
+		require.NoError(err)
+		require.Equal(expected, res)
+	})
+
+	t.Run("TTL record exists in dst already", func(t *testing.T) {
+		require.NoError(os.Remove(cpFile))
+		res, err := Migrate(context.Background(), src, dst, Params{CheckpointFile: cpFile, Verify: true})
+		require.NoError(err)
+		require.Equal(expected, res)
+	})
+}
+
+func TestExpiredRecordsAreSkipped(t *testing.T) {
+	require := require.New(t)
+
+	src := newMemStorage(t)
+	ok, err := src.InsertIfNotExists(pKey(1), []byte{1}, []byte("ttl value"), 1)
+	require.NoError(err)
+	require.True(ok)
+	require.NoError(src.Put(pKey(1), []byte{2}, []byte("value")))
+	testingu.MockTime.Sleep(2 * time.Second)
+
+	res, err := Migrate(context.Background(), src, newMemStorage(t), Params{Verify: true})
+	require.NoError(err)
+	require.Equal(1, res.Records)
+	require.Zero(res.TTLRecords)
+}
+
+func TestCatchUp(t *testing.T) {
+	require := require.New(t)
+
+	src := newMemStorage(t)
+	dst := newMemStorage(t)
+	for i := 0; i < 3; i++ {
+		require.NoError(src.Put(pKey(i), []byte{1}, []byte("value")))
+	}
+	ok, err := src.InsertIfNotExists(pKey(0), []byte{2}, []byte("ttl value"), 100)
+	require.NoError(err)
+	require.True(ok)
+
+	res, err := Migrate(context.Background(), src, dst, Params{
+		Verify: true,
+		OnPartition: func(_ []byte, res Result) {
+			if res.Partitions != 3 {
+				return
+			}
+			// changes made after partitions are copied
+			require.NoError(src.Put(pKey(1), []byte{1}, []byte("new value")))
+			require.NoError(src.Put(pKey(3), []byte{1}, []byte("new partition")))
+			ok, err := src.CompareAndDelete(pKey(0), []byte{2}, []byte("ttl value"))
+			require.NoError(err)
+			require.True(ok)
+		},
+	})
+	require.NoError(err)
+	require.Equal(4, res.Partitions)
+	require.Equal(3, res.CaughtUp)
+
+	t.Run("result describes the caught up data", func(t *testing.T) {
+		expected, err := Migrate(context.Background(), src, newMemStorage(t), Params{Verify: true})
+		require.NoError(err)
+		require.Equal(expected.Checksum, res.Checksum)
+		require.Equal(expected.Partitions, res.Partitions)
+		require.Equal(expected.Records, res.Records)
+		require.Zero(res.TTLRecords)
+
+		fromDst, err := Migrate(context.Background(), dst, newMemStorage(t), Params{Verify: true})
+		require.NoError(err)
+		require.Equal(fromDst, expected)
+	})
+
+	value := []byte{}
+	ok, err = dst.Get(pKey(1), []byte{1}, &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("new value", string(value))
+
+	ok, err = dst.Get(pKey(3), []byte{1}, &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("new partition", string(value))
+
+	ok, err = dst.TTLGet(pKey(0), []byte{2}, &value)
+	require.NoError(err)
+	require.False(ok)
+
+	t.Run("nothing to catch up on the next run with the same dst", func(t *testing.T) {
+		res, err := Migrate(context.Background(), src, dst, Params{Verify: true})
+		require.NoError(err)
+		require.Zero(res.CaughtUp)
+	})
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("ErrPKeysScanNotSupported", func(t *testing.T) {
+		_, err := Migrate(context.Background(), struct{ istorage.IAppStorage }{newMemStorage(t)}, newMemStorage(t), Params{})
+		require.ErrorIs(err, istorage.ErrPKeysScanNotSupported)
+	})
+
+	t.Run("ErrChecksumMismatch if dst is not empty", func(t *testing.T) {
+		src := newMemStorage(t)
+		dst := newMemStorage(t)
+		require.NoError(src.Put(pKey(1), []byte{1}, []byte("value")))
+		require.NoError(dst.Put(pKey(1), []byte{2}, []byte("other value")))
+
+		_, err := Migrate(context.Background(), src, dst, Params{Verify: true})
+		require.ErrorIs(err, ErrChecksumMismatch)
+	})
+
+	t.Run("ErrSourceChanged", func(t *testing.T) {
+		// lastPKey is the last copied partition
+		for name, change := range map[string]func(src istorage.IAppStorage, lastPKey []byte) error{
+			"copied partition is changed": func(src istorage.IAppStorage, _ []byte) error {
+				return src.Put(pKey(0), []byte{2}, []byte("new value"))
+			},
+			"partition is added": func(src istorage.IAppStorage, lastPKey []byte) error {
+				// the token range of the added partition is scanned already
+				added := []byte("added")
+				for i := 0; istorage.PKeyToken(added) > istorage.PKeyToken(lastPKey); i++ {
+					added = []byte(fmt.Sprint("added", i))
+				}
+				return src.Put(added, []byte{1}, []byte("new value"))
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				src := newMemStorage(t)
+				for i := 0; i < 3; i++ {
+					require.NoError(src.Put(pKey(i), []byte{1}, []byte("value")))
+				}
+				_, err := Migrate(context.Background(), src, newMemStorage(t), Params{
+					Verify:        true,
+					CatchUpPasses: -1,
+					OnPartition: func(pKey []byte, res Result) {
+						if res.Partitions == 3 {
+							require.NoError(change(src, pKey))
+						}
+					},
+				})
+				require.ErrorIs(err, ErrSourceChanged)
+			})
+		}
+	})
+
+	t.Run("ErrSourceChanged if src is changed on each catch-up pass", func(t *testing.T) {
+		src := &changingStorage{IAppStorage: newMemStorage(t)}
+		require.NoError(src.Put(pKey(0), []byte{1}, []byte("value")))
+
+		res, err := Migrate(context.Background(), src, newMemStorage(t), Params{Verify: true, CatchUpPasses: 2})
+		require.ErrorIs(err, ErrSourceChanged)
+		require.Equal(2, res.CaughtUp)
+	})
+
+	t.Run("wrong checkpoint file", func(t *testing.T) {
+		cpFile := filepath.Join(t.TempDir(), "checkpoint.json")
+		require.NoError(os.WriteFile(cpFile, []byte("wrong"), 0600))
+
+		_, err := Migrate(context.Background(), newMemStorage(t), newMemStorage(t), Params{CheckpointFile: cpFile})
+		require.Error(err)
+
+		require.NoError(writeCheckpoint(cpFile, checkpoint{Result: Result{Checksum: "00"}}))
+		_, err = Migrate(context.Background(), newMemStorage(t), newMemStorage(t), Params{CheckpointFile: cpFile})
+		require.ErrorContains(err, "wrong checkpoint checksum")
+	})
+}
+
+// changes the partition each time the storage is scanned
+type changingStorage struct {
+	istorage.IAppStorage
+	scans int
+}
+
+func (s *changingStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) error {
+	s.scans++
+	if err := s.Put(pKey(0), []byte{2}, []byte(fmt.Sprint("value", s.scans))); err != nil {
+		return err
+	}
+	return s.IAppStorage.(istorage.IPKeysScanner).ScanPKeys(ctx, tokens, cb)
+}
+
+// scans partitions in the reversed order
+type reversedScanStorage struct {
+	istorage.IAppStorage
+}
+
+func (s *reversedScanStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) error {
+	pKeys := [][]byte{}
+	err := s.IAppStorage.(istorage.IPKeysScanner).ScanPKeys(ctx, tokens, func(pKey []byte) error {
+		pKeys = append(pKeys, bytes.Clone(pKey))
+		return nil
+	})
+	for i := len(pKeys) - 1; i >= 0 && err == nil; i-- {
+		err = cb(pKeys[i])
+	}
+	return err
+}
+
+func pKey(i int) []byte {
+	return []byte(fmt.Sprintf("pkey%04d", i))
+}
+
+func newMemStorage(t *testing.T) istorage.IAppStorage {
+	storage, err := provider.Provide(mem.Provide(testingu.MockTime)).AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(t, err)
+	return storage
+}
+
+func newBboltStorage(t *testing.T) istorage.IAppStorage {
+	asp := provider.Provide(bbolt.Provide(bbolt.ParamsType{DBDir: t.TempDir()}, testingu.MockTime))
+	t.Cleanup(asp.Stop)
+	storage, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(t, err)
+	return storage
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package migration
+
+type Params struct {
+	// max records count read from the source storage at once and written to the destination storage by one PutBatch
+	// DefaultBatchSize is used if zero
+	BatchSize int
+
+	// path to the checkpoint file, checkpoints are not used if empty
+	// the migration is continued from the checkpoint if the file exists
+	CheckpointFile string
+
+	// checkpoint is written each CheckpointEvery partitions and on finish
+	// DefaultCheckpointEvery is used if zero
+	CheckpointEvery int
+
+	// max count of passes over src made after the copy to copy again partitions changed or added during the previous pass
+	// passes are stopped when a pass finds no changes
+	// DefaultCatchUpPasses is used if zero, catch-up is disabled if negative
+	CatchUpPasses int
+
+	// each copied partition is read from the destination storage and its checksum is compared to the source one
+	// then, after catch-up passes, the whole source storage is read once again and compared to the destination one to detect changes that are not caught up
+	Verify bool
+
+	// called after each copied partition
+	OnPartition func(pKey []byte, result Result)
+}
+
+type Result struct {
+	Partitions int
+	Records    int
+
+	// records written with TTL, remaining TTL is preserved
+	TTLRecords int
+
+	// order-independent checksum of the copied records written without TTL, hex
+	// partitions copied again by catch-up passes are reflected as they are copied last time,
+	// so the checksum describes the records which exist in the destination storage
+	Checksum string
+
+	// partitions copied again by catch-up passes, including partitions added to src during the migration
+	CaughtUp int
+}
+
+// stored as JSON in Params.CheckpointFile
+type checkpoint struct {
+	// count of token ranges which partitions are fully copied
+	TokenRanges int
+
+	// partitions fully copied in the current token range
+	PKeys  [][]byte
+	Result Result
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pebble
+
+import "time"
+
+const (
+	dbDirSuffix     = ".pebble"
+	cleanupInterval = time.Hour
+)
+
+// first byte of each key
+const (
+	// dataKeyPrefix + uint32(len(pKey)) + pKey + cCols -> uint64(expireAt) + value
+	dataKeyPrefix byte = 1
+
+	// ttlKeyPrefix + uint64(expireAt) + data key -> nil
+	ttlKeyPrefix byte = 2
+)
+
+const (
+	pKeyLenSize  = 4
+	expireAtSize = 8
+)
@@ -0,0 +1,469 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pebbledb "github.com/cockroachdb/pebble"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func (p *appStorageFactory) AppStorage(appName istorage.SafeAppName) (s istorage.IAppStorage, err error) {
+	p.dbsMu.Lock()
+	defer p.dbsMu.Unlock()
+
+	db, ok := p.dbs[appName.String()]
+	if !ok {
+		dbDir := p.dbDir(appName)
+		exists, err := coreutils.Exists(dbDir)
+		if err != nil {
+			// notest
+			return nil, err
+		}
+		if !exists {
+			return nil, istorage.ErrStorageDoesNotExist
+		}
+		if db, err = p.openDB(appName); err != nil {
+			// notest
+			return nil, err
+		}
+	}
+
+	writeOpts := pebbledb.Sync
+	if p.params.NoSync {
+		writeOpts = pebbledb.NoSync
+	}
+	return &appStorageType{appDB: db, iTime: p.iTime, writeOpts: writeOpts}, nil
+}
+
+func (p *appStorageFactory) Init(appName istorage.SafeAppName) error {
+	p.dbsMu.Lock()
+	defer p.dbsMu.Unlock()
+
+	exists, err := coreutils.Exists(p.dbDir(appName))
+	if err != nil {
+		// notest
+		return err
+	}
+	if exists {
+		return istorage.ErrStorageAlreadyExists
+	}
+	if err = os.MkdirAll(p.params.DBDir, coreutils.FileMode_rwxrwxrwx); err != nil {
+		// notest
+		return err
+	}
+	_, err = p.openDB(appName)
+	return err
+}
+
+func (p *appStorageFactory) Time() timeu.ITime {
+	return p.iTime
+}
+
+// pebble runs its own goroutines (flushes, compactions), so DBs are closed here as well
+func (p *appStorageFactory) StopGoroutines() {
+	p.cancel()
+	p.wg.Wait()
+
+	p.dbsMu.Lock()
+	defer p.dbsMu.Unlock()
+	for name, db := range p.dbs {
+		if err := db.db.Close(); err != nil {
+			logger.Error("pebble storage: failed to close " + name + ": " + err.Error())
+		}
+		delete(p.dbs, name)
+	}
+}
+
+func (p *appStorageFactory) dbDir(appName istorage.SafeAppName) string {
+	return filepath.Join(p.params.DBDir, appName.String()+dbDirSuffix)
+}
+
+// must be called under p.dbsMu
+func (p *appStorageFactory) openDB(appName istorage.SafeAppName) (*appDB, error) {
+	db, err := pebbledb.Open(p.dbDir(appName), &pebbledb.Options{})
+	if err != nil {
+		return nil, err
+	}
+	res := &appDB{db: db}
+	p.dbs[appName.String()] = res
+
+	// start background cleaner
+	p.wg.Add(1)
+	go res.backgroundCleaner(p.ctx, p.wg, p.iTime)
+
+	return res, nil
+}
+
+func (s *appStorageType) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := dataKey(pKey, cCols)
+	current, found, err := s.getData(key)
+	if err != nil {
+		return false, err
+	}
+	if found && !current.IsExpired(s.iTime.Now()) {
+		return false, nil
+	}
+
+	return true, s.putValue(key, current, value, ttlSeconds)
+}
+
+func (s *appStorageType) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := dataKey(pKey, cCols)
+	current, found, err := s.getData(key)
+	if err != nil {
+		return false, err
+	}
+	if !found || current.IsExpired(s.iTime.Now()) || !bytes.Equal(current.Data, oldValue) {
+		return false, nil
+	}
+
+	return true, s.putValue(key, current, newValue, ttlSeconds)
+}
+
+func (s *appStorageType) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := dataKey(pKey, cCols)
+	current, found, err := s.getData(key)
+	if err != nil {
+		return false, err
+	}
+	if !found || current.IsExpired(s.iTime.Now()) || !bytes.Equal(current.Data, expectedValue) {
+		return false, nil
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Delete(key, nil); err != nil {
+		// notest
+		return false, err
+	}
+	if current.ExpireAt > 0 {
+		if err := batch.Delete(ttlKey(current.ExpireAt, key), nil); err != nil {
+			// notest
+			return false, err
+		}
+	}
+	if err := batch.Commit(s.writeOpts); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.Get(pKey, cCols, data)
+}
+
+func (s *appStorageType) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.Read(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+func (s *appStorageType) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	d, found, err := s.getData(dataKey(pKey, cCols))
+	if err != nil || !found {
+		return 0, false, err
+	}
+
+	now := s.iTime.Now()
+	if d.IsExpired(now) {
+		return 0, false, nil
+	}
+
+	// If no expiration is set
+	if d.ExpireAt == 0 {
+		return 0, true, nil
+	}
+
+	ttlInSeconds = int(time.UnixMilli(d.ExpireAt).Sub(now).Seconds())
+	if ttlInSeconds <= 0 {
+		return 0, false, nil
+	}
+	return ttlInSeconds, true, nil
+}
+
+func (s *appStorageType) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	now := s.iTime.Now()
+	for i := range items {
+		d, found, err := s.getData(dataKey(pKey, items[i].CCols))
+		if err != nil {
+			return err
+		}
+		items[i].TTLInSeconds, items[i].Ok = 0, false
+		if found {
+			items[i].TTLInSeconds, items[i].Ok = d.TTLInSeconds(now)
+		}
+	}
+	return nil
+}
+
+func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	d := coreutils.DataWithExpiration{Data: value}
+	return s.db.Set(dataKey(pKey, cCols), d.ToBytes(), s.writeOpts)
+}
+
+func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	for _, item := range items {
+		d := coreutils.DataWithExpiration{Data: item.Value}
+		if err := batch.Set(dataKey(item.PKey, item.CCols), d.ToBytes(), nil); err != nil {
+			// notest
+			return err
+		}
+	}
+	return batch.Commit(s.writeOpts)
+}
+
+func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	*data = (*data)[0:0]
+
+	d, found, err := s.getData(dataKey(pKey, cCols))
+	if err != nil || !found {
+		return false, err
+	}
+	if d.IsExpired(s.iTime.Now()) {
+		return false, nil
+	}
+	*data = append(*data, d.Data...)
+	return true, nil
+}
+
+func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	for i := range items {
+		if items[i].Ok, err = s.Get(pKey, items[i].CCols, items[i].Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+
+	prefix := dataKey(pKey, nil)
+	iterOpts := &pebbledb.IterOptions{
+		LowerBound: dataKey(pKey, startCCols),
+		UpperBound: keyUpperBound(prefix),
+	}
+	if len(finishCCols) > 0 {
+		iterOpts.UpperBound = dataKey(pKey, finishCCols)
+	}
+
+	iter, err := s.db.NewIter(iterOpts)
+	if err != nil {
+		// notest
+		return err
+	}
+
+	var d coreutils.DataWithExpiration
+	for iter.First(); iter.Valid(); iter.Next() {
+		if ctx.Err() != nil {
+			return iter.Close()
+		}
+		d = d.Update(iter.Value())
+		if d.IsExpired(s.iTime.Now()) {
+			continue
+		}
+		if cb != nil {
+			// iterator memory is reused on Next()
+			if err := cb(bytes.Clone(iter.Key()[len(prefix):]), bytes.Clone(d.Data)); err != nil {
+				return errors.Join(err, iter.Close())
+			}
+		}
+	}
+	return iter.Close()
+}
+
+// partitions are skipped by seeking to the next possible partition key, records are not read
+func (s *appStorageType) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	iter, err := s.db.NewIter(&pebbledb.IterOptions{
+		LowerBound: []byte{dataKeyPrefix},
+		UpperBound: []byte{dataKeyPrefix + 1},
+	})
+	if err != nil {
+		// notest
+		return err
+	}
+
+	for valid := iter.First(); valid && ctx.Err() == nil; {
+		k := iter.Key()
+		pKeyLen := int(binary.BigEndian.Uint32(k[1 : 1+pKeyLenSize]))
+		pKey := bytes.Clone(k[1+pKeyLenSize : 1+pKeyLenSize+pKeyLen])
+		if tokens.Contains(istorage.PKeyToken(pKey)) {
+			if err := cb(pKey); err != nil {
+				return errors.Join(err, iter.Close())
+			}
+		}
+		valid = iter.SeekGE(keyUpperBound(dataKey(pKey, nil)))
+	}
+	return iter.Close()
+}
+
+// result Data is a copy
+func (s *appStorageType) getData(key []byte) (d coreutils.DataWithExpiration, found bool, err error) {
+	v, closer, err := s.db.Get(key)
+	if errors.Is(err, pebbledb.ErrNotFound) {
+		return d, false, nil
+	}
+	if err != nil {
+		return d, false, err
+	}
+	d = coreutils.ReadWithExpiration(bytes.Clone(v))
+	return d, true, closer.Close()
+}
+
+// must be called under exclusive lock
+// prev is the current record which is replaced, its TTL index key is removed
+func (s *appStorageType) putValue(key []byte, prev coreutils.DataWithExpiration, value []byte, ttlSeconds int) error {
+	d := coreutils.DataWithExpiration{Data: value}
+	if ttlSeconds > 0 {
+		d.ExpireAt = s.iTime.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if prev.ExpireAt > 0 && prev.ExpireAt != d.ExpireAt {
+		if err := batch.Delete(ttlKey(prev.ExpireAt, key), nil); err != nil {
+			// notest
+			return err
+		}
+	}
+	if err := batch.Set(key, d.ToBytes(), nil); err != nil {
+		// notest
+		return err
+	}
+	if d.ExpireAt > 0 {
+		if err := batch.Set(ttlKey(d.ExpireAt, key), nil, nil); err != nil {
+			// notest
+			return err
+		}
+	}
+	return batch.Commit(s.writeOpts)
+}
+
+func (db *appDB) backgroundCleaner(ctx context.Context, wg *sync.WaitGroup, iTime timeu.ITime) {
+	defer wg.Done()
+
+	for ctx.Err() == nil {
+		timerCh := iTime.NewTimerChan(cleanupInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timerCh:
+			if err := db.cleanupExpired(ctx, iTime.Now()); err != nil {
+				logger.Error("pebble storage: failed to cleanup expired records: " + err.Error())
+			}
+		}
+	}
+}
+
+// removes records which TTL index keys are expired
+// a record could be overwritten by Put after it was inserted with TTL, so the record itself is removed only if its expiration matches the TTL index key
+func (db *appDB) cleanupExpired(ctx context.Context, now time.Time) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	iter, err := db.db.NewIter(&pebbledb.IterOptions{
+		LowerBound: []byte{ttlKeyPrefix},
+		UpperBound: ttlKey(now.UnixMilli()+1, nil),
+	})
+	if err != nil {
+		// notest
+		return err
+	}
+
+	batch := db.db.NewBatch()
+	defer batch.Close()
+	for iter.First(); iter.Valid() && ctx.Err() == nil; iter.Next() {
+		k := iter.Key()
+		expireAt := int64(binary.BigEndian.Uint64(k[1 : 1+expireAtSize])) // nolint G115
+		key := k[1+expireAtSize:]
+
+		v, closer, err := db.db.Get(key)
+		switch {
+		case err == nil:
+			matches := coreutils.ReadWithExpiration(v).ExpireAt == expireAt
+			if err := closer.Close(); err != nil {
+				// notest
+				return errors.Join(err, iter.Close())
+			}
+			if matches {
+				if err := batch.Delete(key, nil); err != nil {
+					// notest
+					return errors.Join(err, iter.Close())
+				}
+			}
+		case !errors.Is(err, pebbledb.ErrNotFound):
+			// notest
+			return errors.Join(err, iter.Close())
+		}
+		if err := batch.Delete(k, nil); err != nil {
+			// notest
+			return errors.Join(err, iter.Close())
+		}
+	}
+	if err := iter.Close(); err != nil {
+		// notest
+		return err
+	}
+	return batch.Commit(pebbledb.Sync)
+}
+
+func dataKey(pKey, cCols []byte) []byte {
+	res := make([]byte, 0, 1+pKeyLenSize+len(pKey)+len(cCols))
+	res = append(res, dataKeyPrefix)
+	res = binary.BigEndian.AppendUint32(res, uint32(len(pKey))) // nolint G115
+	res = append(res, pKey...)
+	return append(res, cCols...)
+}
+
+func ttlKey(expireAt int64, dataKey []byte) []byte {
+	res := make([]byte, 0, 1+expireAtSize+len(dataKey))
+	res = append(res, ttlKeyPrefix)
+	res = binary.BigEndian.AppendUint64(res, uint64(expireAt)) // nolint G115
+	return append(res, dataKey...)
+}
+
+// returns the smallest key which is greater than all keys having the given prefix
+// prefix always starts with dataKeyPrefix or ttlKeyPrefix so it could not consist of 0xff only
+func keyUpperBound(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	// notest
+	return nil
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pebble
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params, testingu.MockTime)
+	storageProvider := istorageimpl.Provide(factory)
+	defer storageProvider.Stop()
+
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	err = appStorage.Put([]byte("pKey"), []byte("cCols"), []byte("test data string"))
+	require.NoError(err)
+
+	value := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("pKey"), []byte("cCols"), &value)
+	require.True(ok)
+	require.NoError(err)
+	require.Equal([]byte("test data string"), value)
+}
+
+func TestTCK(t *testing.T) {
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params, testingu.MockTime)
+	istorage.TechnologyCompatibilityKit(t, factory)
+}
+
+func TestPartitionsDoNotOverlap(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params, testingu.MockTime)
+	storageProvider := istorageimpl.Provide(factory)
+	defer storageProvider.Stop()
+
+	storage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	// pKey "a" + cCols "b" must not be read as pKey "ab"
+	require.NoError(storage.Put([]byte("a"), []byte("b"), []byte("1")))
+	require.NoError(storage.Put([]byte("ab"), nil, []byte("2")))
+	require.NoError(storage.Put([]byte{0xff}, []byte{0xff}, []byte("3")))
+
+	values := []string{}
+	err = storage.Read(t.Context(), []byte("ab"), nil, nil, func(_, viewRecord []byte) error {
+		values = append(values, string(viewRecord))
+		return nil
+	})
+	require.NoError(err)
+	require.Equal([]string{"2"}, values)
+
+	values = values[:0]
+	err = storage.Read(t.Context(), []byte{0xff}, nil, nil, func(_, viewRecord []byte) error {
+		values = append(values, string(viewRecord))
+		return nil
+	})
+	require.NoError(err)
+	require.Equal([]string{"3"}, values)
+}
+
+func TestBackgroundCleaner(t *testing.T) {
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	r := require.New(t)
+	iTime := testingu.MockTime
+	factory := Provide(params, iTime)
+	storageProvider := istorageimpl.Provide(factory)
+	defer storageProvider.Stop()
+
+	storage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	r.NoError(err)
+
+	// cleanup interval is 1 hour
+	// this value expires in 1 hour
+	ok, err := storage.InsertIfNotExists([]byte("pKey"), []byte("cCols1"), []byte("value1"), 50*60)
+	r.NoError(err)
+	r.True(ok)
+	// this value does NOT expire in 1 hour
+	ok, err = storage.InsertIfNotExists([]byte("pKey"), []byte("cCols2"), []byte("value2"), 61*60)
+	r.NoError(err)
+	r.True(ok)
+	// this value is inserted with TTL and then overwritten without TTL so must survive
+	ok, err = storage.InsertIfNotExists([]byte("pKey"), []byte("cCols3"), []byte("value3"), 50*60)
+	r.NoError(err)
+	r.True(ok)
+	r.NoError(storage.Put([]byte("pKey"), []byte("cCols3"), []byte("value3")))
+
+	// the cleaner goroutine could miss the mock timer, so cleanup is called directly
+	db := storage.(*appStorageType).appDB
+	r.NoError(db.cleanupExpired(context.Background(), iTime.Now().Add(time.Hour)))
+	iTime.Sleep(time.Hour)
+
+	_, _, err = db.db.Get(dataKey([]byte("pKey"), []byte("cCols1")))
+	r.ErrorIs(err, pebbledb.ErrNotFound)
+
+	value := make([]byte, 0)
+	ok, err = storage.TTLGet([]byte("pKey"), []byte("cCols2"), &value)
+	r.NoError(err)
+	r.True(ok)
+
+	ok, err = storage.Get([]byte("pKey"), []byte("cCols3"), &value)
+	r.NoError(err)
+	r.True(ok)
+	r.Equal([]byte("value3"), value)
+}
+
+func TestBackgroundCleanerGoroutine(t *testing.T) {
+	r := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	// own mock time and a single DB to be sure the timer is got by the cleaner under test
+	iTime := testingu.NewMockTime()
+	timerGot := make(chan struct{})
+	iTime.SetOnNextNewTimerChan(func() { close(timerGot) })
+
+	factory := Provide(params, iTime)
+	defer factory.StopGoroutines()
+	appName := istorage.NewTestSafeName("cleaner")
+	r.NoError(factory.Init(appName))
+	storage, err := factory.AppStorage(appName)
+	r.NoError(err)
+
+	ok, err := storage.InsertIfNotExists([]byte("pKey"), []byte("cCols1"), []byte("value1"), 50*60)
+	r.NoError(err)
+	r.True(ok)
+	ok, err = storage.InsertIfNotExists([]byte("pKey"), []byte("cCols2"), []byte("value2"), 61*60)
+	r.NoError(err)
+	r.True(ok)
+
+	<-timerGot
+
+	// the cleaner gets the next timer after the cleanup is done
+	cleaned := make(chan struct{})
+	iTime.SetOnNextNewTimerChan(func() { close(cleaned) })
+	iTime.Sleep(cleanupInterval)
+	<-cleaned
+
+	db := storage.(*appStorageType).appDB
+	_, _, err = db.db.Get(dataKey([]byte("pKey"), []byte("cCols1")))
+	r.ErrorIs(err, pebbledb.ErrNotFound)
+
+	v, closer, err := db.db.Get(dataKey([]byte("pKey"), []byte("cCols2")))
+	r.NoError(err)
+	r.Equal([]byte("value2"), coreutils.ReadWithExpiration(v).Data)
+	r.NoError(closer.Close())
+
+	// the TTL index keeps the not expired record only
+	iter, err := db.db.NewIter(&pebbledb.IterOptions{LowerBound: []byte{ttlKeyPrefix}, UpperBound: []byte{ttlKeyPrefix + 1}})
+	r.NoError(err)
+	ttlKeys := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		ttlKeys++
+	}
+	r.NoError(iter.Close())
+	r.Equal(1, ttlKeys)
+}
+
+func TestAppStorageFactory_StopGoroutines(t *testing.T) {
+	require := require.New(t)
+
+	params := prepareTestData()
+	defer cleanupTestData(params)
+
+	factory := Provide(params, testingu.MockTime)
+	storageProvider := istorageimpl.Provide(factory)
+
+	_, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	storageProvider.Stop()
+
+	implFactory := factory.(*appStorageFactory)
+	require.Error(implFactory.ctx.Err())
+	require.Empty(implFactory.dbs)
+
+	_, err = storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.ErrorIs(err, istorageimpl.ErrStoppingState)
+}
+
+func Test_keyUpperBound(t *testing.T) {
+	require := require.New(t)
+	require.Equal([]byte{1, 2, 4}, keyUpperBound([]byte{1, 2, 3}))
+	require.Equal([]byte{1, 3}, keyUpperBound([]byte{1, 2, 0xff}))
+	require.Equal([]byte{2}, keyUpperBound([]byte{1, 0xff, 0xff}))
+}
+
+func prepareTestData() (params ParamsType) {
+	dbDir, err := os.MkdirTemp("", "pebble")
+	if err != nil {
+		panic(err)
+	}
+	params.DBDir = dbDir
+	return
+}
+
+func cleanupTestData(params ParamsType) {
+	if params.DBDir != "" {
+		os.RemoveAll(params.DBDir)
+	}
+}
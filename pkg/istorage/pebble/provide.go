@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pebble
+
+import (
+	"context"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func Provide(params ParamsType, iTime timeu.ITime) istorage.IAppStorageFactory {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &appStorageFactory{
+		params: params,
+		iTime:  iTime,
+		ctx:    ctx,
+		cancel: cancel,
+		wg:     &sync.WaitGroup{},
+		dbs:    map[string]*appDB{},
+	}
+}
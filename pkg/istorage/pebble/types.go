@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pebble
+
+import (
+	"context"
+	"sync"
+
+	pebbledb "github.com/cockroachdb/pebble"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
+
+type ParamsType struct {
+	DBDir string
+
+	// false -> each write is synced to disk, as bbolt does
+	NoSync bool
+}
+
+type appStorageFactory struct {
+	params ParamsType
+	iTime  timeu.ITime
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+
+	// pebble DB could not be opened twice, so opened DBs are shared between IAppStorage instances
+	dbs   map[string]*appDB
+	dbsMu sync.Mutex
+}
+
+type appDB struct {
+	db *pebbledb.DB
+
+	// plain writes (Put, PutBatch) are not serialized between each other, that is the point of LSM
+	// conditional writes (InsertIfNotExists, CompareAndSwap, CompareAndDelete) and the TTL cleaner take the exclusive lock
+	// to make read-check-write atomic
+	lock sync.RWMutex
+}
+
+// implemetation for istorage.IAppStorage.
+type appStorageType struct {
+	*appDB
+	iTime     timeu.ITime
+	writeOpts *pebbledb.WriteOptions
+}
@@ -0,0 +1,388 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func (p *implIAppStorageFactory) AppStorage(appName istorage.SafeAppName) (storage istorage.IAppStorage, err error) {
+	schemaExists, err := p.isSchemaExists(appName.String())
+	if err != nil {
+		return nil, err
+	}
+	if !schemaExists {
+		return nil, istorage.ErrStorageDoesNotExist
+	}
+	impl := &appStorageType{
+		pool:  p.pool,
+		table: pgx.Identifier{appName.String(), tableName}.Sanitize(),
+		iTime: p.iTime,
+		ctx:   p.ctx,
+	}
+	// start background cleaner
+	p.wg.Add(1)
+	go impl.backgroundCleaner(p.ctx, p.wg)
+
+	return impl, nil
+}
+
+func (p *implIAppStorageFactory) Init(appName istorage.SafeAppName) error {
+	schema := appName.String()
+	schemaExists, err := p.isSchemaExists(schema)
+	if err != nil {
+		// notest
+		return err
+	}
+	if schemaExists {
+		return istorage.ErrStorageAlreadyExists
+	}
+
+	q := "create schema " + pgx.Identifier{schema}.Sanitize()
+	logScript(q)
+	if _, err = p.pool.Exec(p.ctx, q); err != nil {
+		return fmt.Errorf("failed to create schema %s: %w", schema, err)
+	}
+
+	// bytea is compared bytewise, so c_col ordering matches the clustering columns ordering of other drivers
+	// p_token is istorage.PKeyToken(p_key), so ScanPKeys token ranges are filtered by the index
+	table := pgx.Identifier{schema, tableName}.Sanitize()
+	q = fmt.Sprintf(`create table if not exists %s (
+		p_key bytea not null,
+		c_col bytea not null,
+		value bytea not null,
+		expire_at bigint not null default 0,
+		p_token bigint not null,
+		primary key (p_key, c_col))`, table)
+	logScript(q)
+	if _, err = p.pool.Exec(p.ctx, q); err != nil {
+		return fmt.Errorf("can't create table «%s»: %w", tableName, err)
+	}
+
+	q = fmt.Sprintf(`create index if not exists %s on %s (p_token, p_key)`, pgx.Identifier{tokenIndexName}.Sanitize(), table)
+	logScript(q)
+	if _, err = p.pool.Exec(p.ctx, q); err != nil {
+		return fmt.Errorf("can't create index «%s»: %w", tokenIndexName, err)
+	}
+	return nil
+}
+
+func (p *implIAppStorageFactory) isSchemaExists(name string) (exists bool, err error) {
+	q := "select exists(select 1 from information_schema.schemata where schema_name = $1)"
+	logScript(q)
+	err = p.pool.QueryRow(p.ctx, q, name).Scan(&exists)
+	return exists, err
+}
+
+func (p *implIAppStorageFactory) Time() timeu.ITime {
+	return p.iTime
+}
+
+// in-flight operations are cancelled, then the pool is closed
+func (p *implIAppStorageFactory) StopGoroutines() {
+	p.cancel()
+	p.wg.Wait()
+	p.pool.Close()
+}
+
+func logScript(q string) {
+	if logger.IsVerbose() {
+		logger.Verbose("executing script:", q)
+	}
+}
+
+// pgx writes nil slices as NULL
+func safeBytes(value []byte) []byte {
+	if value == nil {
+		return []byte{}
+	}
+	return value
+}
+
+func (s *appStorageType) nowMs() int64 {
+	return s.iTime.Now().UnixMilli()
+}
+
+func (s *appStorageType) expireAt(ttlSeconds int) int64 {
+	if ttlSeconds > 0 {
+		return s.iTime.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
+	}
+	return noExpiration
+}
+
+func (s *appStorageType) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	// expired record is treated as not existing one, so it is overwritten
+	q := fmt.Sprintf(`insert into %s as v (p_key, c_col, value, expire_at, p_token) values ($1, $2, $3, $4, $5)
+		on conflict (p_key, c_col) do update set value = excluded.value, expire_at = excluded.expire_at
+		where v.expire_at <> 0 and v.expire_at <= $6`, s.table)
+	tag, err := s.pool.Exec(s.ctx, q, pKey, safeBytes(cCols), safeBytes(value), s.expireAt(ttlSeconds), istorage.PKeyToken(pKey), s.nowMs())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *appStorageType) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	q := fmt.Sprintf(`update %s set value = $1, expire_at = $2
+		where p_key = $3 and c_col = $4 and value = $5 and (expire_at = 0 or expire_at > $6)`, s.table)
+	tag, err := s.pool.Exec(s.ctx, q, safeBytes(newValue), s.expireAt(ttlSeconds), pKey, safeBytes(cCols), safeBytes(oldValue), s.nowMs())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *appStorageType) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	q := fmt.Sprintf(`delete from %s where p_key = $1 and c_col = $2 and value = $3 and (expire_at = 0 or expire_at > $4)`, s.table)
+	tag, err := s.pool.Exec(s.ctx, q, pKey, safeBytes(cCols), safeBytes(expectedValue), s.nowMs())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.Get(pKey, cCols, data)
+}
+
+func (s *appStorageType) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.Read(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+func (s *appStorageType) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	q := fmt.Sprintf(`select expire_at from %s where p_key = $1 and c_col = $2`, s.table)
+	expireAt := noExpiration
+	err = s.pool.QueryRow(s.ctx, q, pKey, safeBytes(cCols)).Scan(&expireAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	ttlInSeconds, ok = s.remainingTTL(expireAt)
+	return ttlInSeconds, ok, nil
+}
+
+func (s *appStorageType) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	ccToIdx := make(map[string][]int)
+	cCols := make([][]byte, 0, len(items))
+	for i, item := range items {
+		items[i].Ok, items[i].TTLInSeconds = false, 0
+		cCols = append(cCols, safeBytes(item.CCols))
+		ccToIdx[string(item.CCols)] = append(ccToIdx[string(item.CCols)], i)
+	}
+
+	q := fmt.Sprintf(`select c_col, expire_at from %s where p_key = $1 and c_col = any($2)`, s.table)
+	rows, err := s.pool.Query(s.ctx, q, pKey, cCols)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ccols := make([]byte, 0)
+	expireAt := noExpiration
+	for rows.Next() {
+		if err = rows.Scan(&ccols, &expireAt); err != nil {
+			return err
+		}
+		ttlInSeconds, ok := s.remainingTTL(expireAt)
+		for _, i := range ccToIdx[string(ccols)] {
+			items[i].Ok, items[i].TTLInSeconds = ok, ttlInSeconds
+		}
+	}
+	return rows.Err()
+}
+
+// ok == false means the record is expired
+func (s *appStorageType) remainingTTL(expireAt int64) (ttlInSeconds int, ok bool) {
+	if expireAt == noExpiration {
+		return 0, true
+	}
+	ttlInSeconds = int(time.UnixMilli(expireAt).Sub(s.iTime.Now()).Seconds())
+	if ttlInSeconds <= 0 {
+		return 0, false
+	}
+	return ttlInSeconds, true
+}
+
+func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	_, err = s.pool.Exec(s.ctx, s.upsertStmt(), pKey, safeBytes(cCols), safeBytes(value), istorage.PKeyToken(pKey))
+	return err
+}
+
+// batch is executed as a single implicit transaction
+func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	batch := &pgx.Batch{}
+	stmt := s.upsertStmt()
+	for _, item := range items {
+		batch.Queue(stmt, item.PKey, safeBytes(item.CCols), safeBytes(item.Value), istorage.PKeyToken(item.PKey))
+	}
+	return s.pool.SendBatch(s.ctx, batch).Close()
+}
+
+func (s *appStorageType) upsertStmt() string {
+	return fmt.Sprintf(`insert into %s (p_key, c_col, value, expire_at, p_token) values ($1, $2, $3, 0, $4)
+		on conflict (p_key, c_col) do update set value = excluded.value, expire_at = 0`, s.table)
+}
+
+func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	q := fmt.Sprintf(`select value from %s where p_key = $1 and c_col = $2 and (expire_at = 0 or expire_at > $3)`, s.table)
+	value := []byte{}
+	err = s.pool.QueryRow(s.ctx, q, pKey, safeBytes(cCols), s.nowMs()).Scan(&value)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	*data = append((*data)[0:0], value...)
+	return true, nil
+}
+
+func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	ccToIdx := make(map[string][]int)
+	cCols := make([][]byte, 0, len(items))
+	for i, item := range items {
+		items[i].Ok = false
+		cCols = append(cCols, safeBytes(item.CCols))
+		ccToIdx[string(item.CCols)] = append(ccToIdx[string(item.CCols)], i)
+	}
+
+	q := fmt.Sprintf(`select c_col, value from %s where p_key = $1 and c_col = any($2) and (expire_at = 0 or expire_at > $3)`, s.table)
+	rows, err := s.pool.Query(s.ctx, q, pKey, cCols, s.nowMs())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ccols := make([]byte, 0)
+	value := make([]byte, 0)
+	for rows.Next() {
+		if err = rows.Scan(&ccols, &value); err != nil {
+			return err
+		}
+		for _, i := range ccToIdx[string(ccols)] {
+			items[i].Ok = true
+			*items[i].Data = append((*items[i].Data)[0:0], value...)
+		}
+	}
+	return rows.Err()
+}
+
+func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+
+	qText := strings.Builder{}
+	fmt.Fprintf(&qText, "select c_col, value from %s where p_key = $1 and (expire_at = 0 or expire_at > $2)", s.table)
+	args := []any{pKey, s.nowMs()}
+	if len(startCCols) > 0 {
+		args = append(args, startCCols)
+		fmt.Fprintf(&qText, " and c_col >= $%d", len(args))
+	}
+	if len(finishCCols) > 0 {
+		args = append(args, finishCCols)
+		fmt.Fprintf(&qText, " and c_col < $%d", len(args))
+	}
+	qText.WriteString(" order by c_col")
+
+	rows, err := s.pool.Query(ctx, qText.String(), args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil // TCK contract
+		}
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		clustCols := make([]byte, 0)
+		viewRecord := make([]byte, 0)
+		if err = rows.Scan(&clustCols, &viewRecord); err != nil {
+			return err
+		}
+		if err = cb(clustCols, viewRecord); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil // TCK contract
+		}
+	}
+	if err = rows.Err(); err != nil && ctx.Err() != nil {
+		return nil // TCK contract
+	}
+	return err
+}
+
+// partition keys are read by chunks in (p_token, p_key) order so the connection is not kept while cb is working
+func (s *appStorageType) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	if tokens.Start > tokens.End {
+		return nil
+	}
+	q := fmt.Sprintf(`select distinct p_token, p_key from %s where p_token between $1 and $2 and (p_token, p_key) > ($3, $4)
+		order by p_token, p_key limit %d`, s.table, scanPKeysChunkSize)
+	lastToken, lastPKey := tokens.Start, []byte{}
+	for ctx.Err() == nil {
+		rows, err := s.pool.Query(ctx, q, tokens.Start, tokens.End, lastToken, lastPKey)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		pKeys, err := pgx.CollectRows(rows, pgx.RowToStructByPos[tokenPKey])
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		for _, pKey := range pKeys {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err = cb(pKey.PKey); err != nil {
+				return err
+			}
+		}
+
+		if len(pKeys) < scanPKeysChunkSize {
+			return nil
+		}
+		lastToken, lastPKey = pKeys[len(pKeys)-1].Token, pKeys[len(pKeys)-1].PKey
+	}
+	return nil
+}
+
+func (s *appStorageType) backgroundCleaner(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	q := fmt.Sprintf(`delete from %s where expire_at <> 0 and expire_at <= $1`, s.table)
+	for ctx.Err() == nil {
+		timerCh := s.iTime.NewTimerChan(cleanupInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timerCh:
+			if _, err := s.pool.Exec(ctx, q, s.nowMs()); err != nil && ctx.Err() == nil {
+				logger.Error("pg storage: failed to cleanup expired records: " + err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func TestBasicUsage(t *testing.T) {
+	if !coreutils.IsPostgreSQLStorage() {
+		t.Skip()
+	}
+	asf, err := Provide(ParamsFromEnv(), testingu.MockTime)
+	require.NoError(t, err)
+	istorage.TechnologyCompatibilityKit(t, asf)
+}
+
+func TestMultipleApps(t *testing.T) {
+	if !coreutils.IsPostgreSQLStorage() {
+		t.Skip()
+	}
+	const appCount = 3
+
+	asf, err := Provide(ParamsFromEnv(), testingu.MockTime)
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for appNo := 0; appNo < appCount; appNo++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			istorage.TechnologyCompatibilityKit(t, asf)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestProvideErrors(t *testing.T) {
+	t.Run("empty connection string", func(t *testing.T) {
+		asf, err := Provide(PostgresParamsType{}, testingu.MockTime)
+		require.Error(t, err)
+		require.Nil(t, asf)
+	})
+	t.Run("wrong connection string", func(t *testing.T) {
+		asf, err := Provide(PostgresParamsType{ConnString: "postgres://:wrong port"}, testingu.MockTime)
+		require.Error(t, err)
+		require.Nil(t, asf)
+	})
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package pg
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// DefaultPgParams with the connection string overridden by ConnStringEnv env var if set
+func ParamsFromEnv() PostgresParamsType {
+	params := DefaultPgParams
+	if connString, ok := os.LookupEnv(ConnStringEnv); ok {
+		params.ConnString = connString
+	}
+	return params
+}
+
+// connections are established lazily, on the first storage operation
+func Provide(params PostgresParamsType, iTime timeu.ITime) (asf istorage.IAppStorageFactory, err error) {
+	if len(params.ConnString) == 0 {
+		return nil, errors.New("params.ConnString can not be empty")
+	}
+	poolCfg, err := pgxpool.ParseConfig(params.ConnString)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &implIAppStorageFactory{
+		pool:   pool,
+		iTime:  iTime,
+		ctx:    ctx,
+		cancel: cancel,
+		wg:     &sync.WaitGroup{},
+	}, nil
+}
@@ -0,0 +1,5 @@
+#!/usr/bin/env bash
+
+# local stand-in:
+# docker run --rm -d -p 5432:5432 -e POSTGRES_PASSWORD=postgres postgres:17
+POSTGRES_TESTS_ENABLED=1 go test -v -count=1 ./...
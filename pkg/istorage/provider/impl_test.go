@@ -10,14 +10,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/pg"
 )
 
 func TestBasicUsage(t *testing.T) {
 	require := require.New(t)
-	asf := mem.Provide(testingu.MockTime)
+	asf := testStorageFactory(t)
 	asp := Provide(asf)
 
 	app1 := appdef.NewAppQName("sys", "_") // SafeAppName is "sys"
@@ -62,7 +64,7 @@ func TestBasicUsage(t *testing.T) {
 
 func TestInitErrorPersistence(t *testing.T) {
 	require := require.New(t)
-	asf := mem.Provide(testingu.MockTime)
+	asf := testStorageFactory(t)
 	asp := Provide(asf)
 
 	app1 := appdef.NewAppQName("sys", "_")
@@ -84,3 +86,14 @@ func TestInitErrorPersistence(t *testing.T) {
 	_, err = asp.AppStorage(app1)
 	require.ErrorIs(err, ErrStorageInitError)
 }
+
+// PostgreSQL storage if POSTGRES_TESTS_ENABLED, mem storage otherwise
+func testStorageFactory(t *testing.T) istorage.IAppStorageFactory {
+	if !coreutils.IsPostgreSQLStorage() {
+		return mem.Provide(testingu.MockTime)
+	}
+	asf, err := pg.Provide(pg.ParamsFromEnv(), testingu.MockTime)
+	require.NoError(t, err)
+	t.Cleanup(asf.StopGoroutines)
+	return asf
+}
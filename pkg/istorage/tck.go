@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -37,6 +38,10 @@ func TechnologyCompatibilityKit_Storage(t *testing.T, storage IAppStorage, iTime
 	t.Run("TestAppStorage_TTLGet", func(t *testing.T) { testAppStorage_TTLGet(t, storage, iTime) })
 	t.Run("TestAppStorage_TTLRead", func(t *testing.T) { testAppStorage_TTLRead(t, storage, iTime) })
 	t.Run("TestAppStorage_QueryTTL", func(t *testing.T) { testAppStorage_QueryTTL(t, storage, iTime) })
+	if scanner, ok := storage.(IPKeysScanner); ok {
+		t.Run("TestAppStorage_ScanPKeys", func(t *testing.T) { testAppStorage_ScanPKeys(t, storage, scanner) })
+	}
+	t.Run("TestAppStorage_QueryTTLBatch", func(t *testing.T) { testAppStorage_QueryTTLBatch(t, storage, iTime) })
 }
 
 func testAppStorageFactory(t *testing.T, sf IAppStorageFactory, testAppQName appdef.AppQName) IAppStorage {
@@ -1080,3 +1085,128 @@ func testAppStorage_QueryTTL(t *testing.T, storage IAppStorage, iTime timeu.ITim
 		require.LessOrEqual(seconds, newTTL)
 	})
 }
+
+// QueryTTLBatch() falls back to QueryTTL if the storage does not implement ITTLBatchQuerier
+func testAppStorage_QueryTTLBatch(t *testing.T, storage IAppStorage, iTime timeu.ITime) {
+	require := require.New(t)
+	pKey := []byte("QueryTTLBatchKey")
+
+	require.NoError(storage.Put(pKey, []byte("regular"), []byte("value")))
+	ok, err := storage.InsertIfNotExists(pKey, []byte("ttl"), []byte("value"), 5)
+	require.NoError(err)
+	require.True(ok)
+	ok, err = storage.InsertIfNotExists(pKey, []byte("expired"), []byte("value"), 1)
+	require.NoError(err)
+	require.True(ok)
+	iTime.Sleep(2 * time.Second)
+
+	items := []QueryTTLBatchItem{
+		{CCols: []byte("regular")},
+		{CCols: []byte("ttl")},
+		{CCols: []byte("expired")},
+		{CCols: []byte("unknown")},
+		{CCols: []byte("regular")},
+	}
+	require.NoError(QueryTTLBatch(storage, pKey, items))
+
+	require.True(items[0].Ok)
+	require.Zero(items[0].TTLInSeconds)
+	require.True(items[1].Ok)
+	require.Positive(items[1].TTLInSeconds)
+	require.LessOrEqual(items[1].TTLInSeconds, 3)
+	require.False(items[2].Ok)
+	require.False(items[3].Ok)
+	require.Equal(items[0], items[4])
+
+	require.NoError(QueryTTLBatch(storage, pKey, nil))
+}
+
+func testAppStorage_ScanPKeys(t *testing.T, storage IAppStorage, scanner IPKeysScanner) {
+	// storage is shared between TCK tests so the scan returns partitions of other tests as well
+	prefix := []byte(uuid.NewString())
+	pKeys := map[string]bool{}
+	for i := range 50 {
+		pKey := append(bytes.Clone(prefix), byte(i))
+		pKeys[string(pKey)] = true
+		require.NoError(t, storage.Put(pKey, []byte{1}, []byte("value1")))
+		require.NoError(t, storage.Put(pKey, []byte{2}, []byte("value2")))
+	}
+
+	scan := func(tokens TokenRange) (res []string) {
+		err := scanner.ScanPKeys(context.Background(), tokens, func(pKey []byte) error {
+			res = append(res, string(pKey))
+			return nil
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	all := scan(FullTokenRange)
+
+	t.Run("Should return each partition key once", func(t *testing.T) {
+		require := require.New(t)
+		found := map[string]int{}
+		for _, pKey := range all {
+			found[pKey]++
+			require.Equal(1, found[pKey], "partition key %x is returned twice", pKey)
+		}
+		for pKey := range pKeys {
+			require.Equal(1, found[pKey], "partition key %x is not found", pKey)
+		}
+	})
+
+	t.Run("Should return the same order for the unchanged storage", func(t *testing.T) {
+		require.Equal(t, all, scan(FullTokenRange))
+	})
+
+	t.Run("Splitted ranges should cover the full range", func(t *testing.T) {
+		require := require.New(t)
+		wg := sync.WaitGroup{}
+		parts := FullTokenRange.Split(4)
+		results := make([][]string, len(parts))
+		errs := make([]error, len(parts))
+		for i, part := range parts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = scanner.ScanPKeys(context.Background(), part, func(pKey []byte) error {
+					results[i] = append(results[i], string(pKey))
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+
+		union := []string{}
+		for i, res := range results {
+			require.NoError(errs[i])
+			union = append(union, res...)
+		}
+		require.ElementsMatch(all, union)
+	})
+
+	t.Run("Should handle callback error", func(t *testing.T) {
+		require := require.New(t)
+		errCb := errors.New("callback error")
+		times := 0
+		err := scanner.ScanPKeys(context.Background(), FullTokenRange, func([]byte) error {
+			times++
+			return errCb
+		})
+		require.ErrorIs(err, errCb)
+		require.Equal(1, times)
+	})
+
+	t.Run("Should handle ctx error", func(t *testing.T) {
+		require := require.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		times := 0
+		err := scanner.ScanPKeys(ctx, FullTokenRange, func([]byte) error {
+			times++
+			cancel()
+			return nil
+		})
+		require.NoError(err)
+		require.Equal(1, times)
+	})
+}
@@ -16,6 +16,12 @@ type AppStorageDesc struct {
 
 type AppStorageStatus int
 
+// Part of the partition keys token space, both borders are included
+type TokenRange struct {
+	Start int64
+	End   int64
+}
+
 type SafeAppName struct {
 	name string
 }
@@ -25,4 +31,3 @@ type IStorageDelaySetter interface {
 	SetTestDelayGet(time.Duration)
 	SetTestDelayPut(time.Duration)
 }
-
@@ -6,6 +6,7 @@
 package istorage
 
 import (
+	"hash/fnv"
 	"regexp"
 	"strconv"
 	"strings"
@@ -95,3 +96,55 @@ func (san *SafeAppName) UnmarshalText(text []byte) error {
 	// notest
 	return nil
 }
+
+// PKeyToken returns the token of the partition key for drivers which have no native tokens
+func PKeyToken(pKey []byte) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write(pKey)
+	return int64(h.Sum64()) // nolint G115
+}
+
+func (r TokenRange) Contains(token int64) bool {
+	return token >= r.Start && token <= r.End
+}
+
+// Split splits the range into n adjacent ranges of equal size (the last one could be bigger) which cover the entire range
+// result contains less than n ranges if the range has less than n tokens
+func (r TokenRange) Split(n int) (res []TokenRange) {
+	if r.Start > r.End {
+		return nil
+	}
+	// all arithmetic is in uint64 to avoid overflow on FullTokenRange
+	span := uint64(r.End) - uint64(r.Start) // nolint G115: tokens count - 1
+	if n <= 0 {
+		n = 1
+	}
+	if uint64(n-1) > span {
+		n = int(span + 1) // nolint G115
+	}
+	// step = (span + 1) / n, span + 1 could overflow
+	step := span / uint64(n)
+	if span%uint64(n) == uint64(n-1) {
+		step++
+	}
+	start := r.Start
+	for i := 0; i < n-1; i++ {
+		end := int64(uint64(start) + step - 1) // nolint G115
+		res = append(res, TokenRange{Start: start, End: end})
+		start = end + 1
+	}
+	return append(res, TokenRange{Start: start, End: r.End})
+}
+
+// QueryTTLBatch queries TTL of the records at once if the storage implements ITTLBatchQuerier, record by record otherwise
+func QueryTTLBatch(storage IAppStorage, pKey []byte, items []QueryTTLBatchItem) (err error) {
+	if querier, ok := storage.(ITTLBatchQuerier); ok {
+		return querier.QueryTTLBatch(pKey, items)
+	}
+	for i := range items {
+		if items[i].TTLInSeconds, items[i].Ok, err = storage.QueryTTL(pKey, items[i].CCols); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRangeSplit(t *testing.T) {
+	require := require.New(t)
+
+	requireAdjacent := func(r TokenRange, parts []TokenRange) {
+		require.Equal(r.Start, parts[0].Start)
+		require.Equal(r.End, parts[len(parts)-1].End)
+		for i := 1; i < len(parts); i++ {
+			require.LessOrEqual(parts[i-1].Start, parts[i-1].End)
+			require.Equal(parts[i-1].End+1, parts[i].Start)
+		}
+	}
+
+	t.Run("full range", func(t *testing.T) {
+		for _, n := range []int{1, 2, 3, 4, 7, 16, 1000} {
+			parts := FullTokenRange.Split(n)
+			require.Len(parts, n)
+			requireAdjacent(FullTokenRange, parts)
+		}
+		require.Equal([]TokenRange{{math.MinInt64, -1}, {0, math.MaxInt64}}, FullTokenRange.Split(2))
+	})
+
+	t.Run("small range", func(t *testing.T) {
+		r := TokenRange{Start: -2, End: 7}
+		require.Equal([]TokenRange{{-2, 2}, {3, 7}}, r.Split(2))
+		require.Equal([]TokenRange{{-2, 0}, {1, 3}, {4, 7}}, r.Split(3))
+		requireAdjacent(r, r.Split(4))
+	})
+
+	t.Run("less tokens than parts", func(t *testing.T) {
+		r := TokenRange{Start: 10, End: 12}
+		require.Equal([]TokenRange{{10, 10}, {11, 11}, {12, 12}}, r.Split(5))
+		require.Equal([]TokenRange{{5, 5}}, TokenRange{Start: 5, End: 5}.Split(3))
+	})
+
+	t.Run("wrong params", func(t *testing.T) {
+		require.Equal([]TokenRange{FullTokenRange}, FullTokenRange.Split(0))
+		require.Nil(TokenRange{Start: 1, End: 0}.Split(2))
+	})
+}
+
+func TestTokenRangeContains(t *testing.T) {
+	require := require.New(t)
+	r := TokenRange{Start: -1, End: 1}
+	require.True(r.Contains(-1))
+	require.True(r.Contains(1))
+	require.False(r.Contains(-2))
+	require.False(r.Contains(2))
+	require.True(FullTokenRange.Contains(math.MinInt64))
+	require.True(FullTokenRange.Contains(math.MaxInt64))
+
+	for _, pKey := range [][]byte{nil, {1}, []byte("partition")} {
+		token := PKeyToken(pKey)
+		require.Equal(token, PKeyToken(pKey))
+		found := 0
+		for _, part := range FullTokenRange.Split(8) {
+			if part.Contains(token) {
+				found++
+			}
+		}
+		require.Equal(1, found)
+	}
+}
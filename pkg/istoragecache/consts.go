@@ -5,4 +5,21 @@
 
 package istoragecache
 
-const stackKeySize = 512
+import "time"
+
+const (
+	localTierName = "local"
+	redisTierName = "redis"
+)
+
+const (
+	DefaultRedisKeyPrefix = "voedger:"
+	DefaultRedisMaxTTL    = 10 * time.Minute
+	DefaultRedisLeaseTTL  = 5 * time.Second
+	DefaultRedisPoolSize  = 16
+	DefaultRedisTimeout   = 500 * time.Millisecond
+
+	DefaultRedisBreakInterval = 5 * time.Second
+
+	redisLeaseSize = 16
+)
@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecache
+
+import "errors"
+
+var (
+	ErrRedisTierClosed      = errors.New("redis cache tier is closed")
+	ErrRedisTierUnavailable = errors.New("redis cache tier is unavailable")
+	ErrRedisReply           = errors.New("redis error reply")
+	ErrSharedTierNotLeasing = errors.New("shared cache tier must implement ILeasingCacheTier")
+)
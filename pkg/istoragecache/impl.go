@@ -8,18 +8,15 @@ import (
 	"context"
 	"time"
 
-	"github.com/VictoriaMetrics/fastcache"
-
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
-	"github.com/voedger/voedger/pkg/coreutils/utils"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/istorage"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
 )
 
 type cachedAppStorage struct {
-	cache    *fastcache.Cache
+	tiers    []cacheTier
 	storage  istorage.IAppStorage
 	vvm      string
 	appQName appdef.AppQName
@@ -50,7 +47,7 @@ type cachedAppStorage struct {
 
 type implCachingAppStorageProvider struct {
 	storageProvider istorage.IAppStorageProvider
-	maxBytes        int
+	tiers           []ICacheTierFactory
 	metrics         imetrics.IMetrics
 	vvmName         string
 	iTime           timeu.ITime
@@ -66,6 +63,9 @@ func (asp *implCachingAppStorageProvider) Run(ctx context.Context) {
 
 func (asp *implCachingAppStorageProvider) Stop() {
 	asp.storageProvider.Stop()
+	for _, tier := range asp.tiers {
+		tier.Close()
+	}
 }
 
 // normally must be called once per app
@@ -76,8 +76,13 @@ func (asp *implCachingAppStorageProvider) AppStorage(appQName appdef.AppQName) (
 		return nil, err
 	}
 
+	tiers, err := newCacheTiers(asp.tiers, asp.metrics, asp.vvmName, appQName)
+	if err != nil {
+		return nil, err
+	}
+
 	return newCachingAppStorage(
-		asp.maxBytes,
+		tiers,
 		nonCachingAppStorage,
 		asp.metrics,
 		asp.vvmName,
@@ -87,7 +92,7 @@ func (asp *implCachingAppStorageProvider) AppStorage(appQName appdef.AppQName) (
 }
 
 func newCachingAppStorage(
-	maxBytes int,
+	tiers []cacheTier,
 	nonCachingAppStorage istorage.IAppStorage,
 	metrics imetrics.IMetrics,
 	vvm string,
@@ -95,7 +100,7 @@ func newCachingAppStorage(
 	iTime timeu.ITime,
 ) istorage.IAppStorage {
 	return &cachedAppStorage{
-		cache:                       fastcache.New(maxBytes),
+		tiers:                       tiers,
 		storage:                     nonCachingAppStorage,
 		mGetTotal:                   metrics.AppMetricAddr(getTotal, vvm, appQName),
 		mGetCachedTotal:             metrics.AppMetricAddr(getCachedTotal, vvm, appQName),
@@ -130,22 +135,17 @@ func (s *cachedAppStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []
 		s.mIncreaseIfNotExistsSeconds.Increase(time.Since(start).Seconds())
 	}()
 
+	key := makeKey(pKey, cCols)
 	ok, err = s.storage.InsertIfNotExists(pKey, cCols, value, ttlSeconds)
-	if err != nil {
+	if err != nil || !ok {
+		// cached state of the key could be stale
+		s.cacheDel(key)
 		return false, err
 	}
 
-	if ok {
-		expireAt := int64(0)
-		if ttlSeconds > 0 {
-			expireAt = s.iTime.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
-		}
-
-		d := coreutils.DataWithExpiration{Data: value, ExpireAt: expireAt}
-		s.cache.Set(makeKey(pKey, cCols), d.ToBytes())
-	}
+	s.cacheWrite(key, coreutils.DataWithExpiration{Data: value, ExpireAt: s.expireAt(ttlSeconds)})
 
-	return ok, nil
+	return true, nil
 }
 
 //nolint:revive
@@ -155,22 +155,17 @@ func (s *cachedAppStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, n
 		s.mCompareAndSwapSeconds.Increase(time.Since(start).Seconds())
 	}()
 
+	key := makeKey(pKey, cCols)
 	ok, err = s.storage.CompareAndSwap(pKey, cCols, oldValue, newValue, ttlSeconds)
-	if err != nil {
+	if err != nil || !ok {
+		// cached value could be stale
+		s.cacheDel(key)
 		return false, err
 	}
 
-	if ok {
-		expireAt := int64(0)
-		if ttlSeconds > 0 {
-			expireAt = s.iTime.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
-		}
+	s.cacheWrite(key, coreutils.DataWithExpiration{Data: newValue, ExpireAt: s.expireAt(ttlSeconds)})
 
-		d := coreutils.DataWithExpiration{Data: newValue, ExpireAt: expireAt}
-		s.cache.Set(makeKey(pKey, cCols), d.ToBytes())
-	}
-
-	return ok, nil
+	return true, nil
 }
 
 //nolint:revive
@@ -181,14 +176,14 @@ func (s *cachedAppStorage) CompareAndDelete(pKey []byte, cCols []byte, expectedV
 	}()
 
 	ok, err = s.storage.CompareAndDelete(pKey, cCols, expectedValue)
+
+	// deleted or cached value could be stale
+	s.cacheDel(makeKey(pKey, cCols))
+
 	if err != nil {
 		return false, err
 	}
 
-	if ok {
-		s.cache.Del(makeKey(pKey, cCols))
-	}
-
 	return ok, nil
 }
 
@@ -202,13 +197,13 @@ func (s *cachedAppStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok b
 	var key = makeKey(pKey, cCols)
 
 	*data = (*data)[0:0]
-	cachedData, found := s.cache.HasGet(*data, key)
+	cachedData, found, _ := s.cacheGet(*data, key)
 
 	if found {
 		d := coreutils.ReadWithExpiration(cachedData)
 
-		if d.IsExpired(s.iTime.Now()) {
-			s.cache.Del(key)
+		if s.isExpired(d) {
+			s.cacheDel(key)
 
 			return false, nil
 		}
@@ -241,6 +236,11 @@ func (s *cachedAppStorage) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int
 	return s.storage.QueryTTL(pKey, cCols)
 }
 
+// cache is not involved, TTL is queried by the underlying storage
+func (s *cachedAppStorage) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	return istorage.QueryTTLBatch(s.storage, pKey, items)
+}
+
 func (s *cachedAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
 	start := time.Now()
 	defer func() {
@@ -250,8 +250,7 @@ func (s *cachedAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err err
 	err = s.storage.Put(pKey, cCols, value)
 
 	if err == nil {
-		data := coreutils.DataWithExpiration{Data: value}
-		s.cache.Set(makeKey(pKey, cCols), data.ToBytes())
+		s.cacheWrite(makeKey(pKey, cCols), coreutils.DataWithExpiration{Data: value})
 	}
 
 	return err
@@ -267,10 +266,7 @@ func (s *cachedAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
 
 	err = s.storage.PutBatch(items)
 	if err == nil {
-		for _, i := range items {
-			data := coreutils.DataWithExpiration{Data: i.Value}
-			s.cache.Set(makeKey(i.PKey, i.CCols), data.ToBytes())
-		}
+		s.cacheWriteBatch(items)
 	}
 
 	return err
@@ -285,16 +281,16 @@ func (s *cachedAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool
 
 	key := makeKey(pKey, cCols)
 	*data = (*data)[0:0]
-	cachedData := make([]byte, 0)
-	cachedData, ok = s.cache.HasGet(cachedData, key)
 
-	if ok {
-		s.mGetCachedTotal.Increase(1.0)
-
-		if len(cachedData) != 0 {
-			*data = cachedData[utils.Uint64Size:]
+	cachedData, found, leases := s.cacheGet(*data, key)
+	if found {
+		d := coreutils.ReadWithExpiration(cachedData)
+		if !s.isExpired(d) {
+			s.mGetCachedTotal.Increase(1.0)
+			*data = d.Data
 			return len(*data) != 0, nil
 		}
+		s.cacheDel(key)
 	}
 
 	ok, err = s.storage.Get(pKey, cCols, data)
@@ -306,7 +302,7 @@ func (s *cachedAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool
 	if ok {
 		d.Data = *data
 	}
-	s.cache.Set(key, d.ToBytes())
+	s.cacheSet(key, d, leases)
 
 	return ok, nil
 }
@@ -317,38 +313,59 @@ func (s *cachedAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem)
 		s.mGetBatchSeconds.Increase(time.Since(start).Seconds())
 	}()
 	s.mGetBatchTotal.Increase(1.0)
-	if !s.getBatchFromCache(pKey, items) {
-		return s.getBatchFromStorage(pKey, items)
+	ok, leases := s.getBatchFromCache(pKey, items)
+	if !ok {
+		return s.getBatchFromStorage(pKey, items, leases)
 	}
 	return
 }
 
-func (s *cachedAppStorage) getBatchFromCache(pKey []byte, items []istorage.GetBatchItem) (ok bool) {
+// all items are looked up, so that the shared tiers lease all missed keys
+func (s *cachedAppStorage) getBatchFromCache(pKey []byte, items []istorage.GetBatchItem) (ok bool, leases []cacheLeases) {
+	ok = true
 	for i := range items {
-		cachedData, ok := s.cache.HasGet((*items[i].Data)[0:0], makeKey(pKey, items[i].CCols))
-		if !ok {
-			return false
+		cachedData, found, itemLeases := s.cacheGet((*items[i].Data)[0:0], makeKey(pKey, items[i].CCols))
+		if itemLeases != nil {
+			if leases == nil {
+				leases = make([]cacheLeases, len(items))
+			}
+			leases[i] = itemLeases
+		}
+		if !found {
+			ok = false
+			continue
 		}
 
-		*items[i].Data = cachedData[utils.Uint64Size:]
+		d := coreutils.ReadWithExpiration(cachedData)
+		if s.isExpired(d) {
+			ok = false
+			continue
+		}
+		*items[i].Data = d.Data
 		items[i].Ok = len(*items[i].Data) != 0
 	}
-	s.mGetBatchCachedTotal.Increase(1.0)
-	return true
+	if ok {
+		s.mGetBatchCachedTotal.Increase(1.0)
+	}
+	return ok, leases
 }
 
-func (s *cachedAppStorage) getBatchFromStorage(pKey []byte, items []istorage.GetBatchItem) (err error) {
+func (s *cachedAppStorage) getBatchFromStorage(pKey []byte, items []istorage.GetBatchItem, leases []cacheLeases) (err error) {
 	err = s.storage.GetBatch(pKey, items)
 	if err != nil {
 		return err
 	}
 
-	for _, item := range items {
+	for i, item := range items {
 		d := coreutils.DataWithExpiration{}
 		if item.Ok {
 			d.Data = *item.Data
 		}
-		s.cache.Set(makeKey(pKey, item.CCols), d.ToBytes())
+		var itemLeases cacheLeases
+		if leases != nil {
+			itemLeases = leases[i]
+		}
+		s.cacheSet(makeKey(pKey, item.CCols), d, itemLeases)
 	}
 
 	return err
@@ -364,6 +381,15 @@ func (s *cachedAppStorage) Read(ctx context.Context, pKey []byte, startCCols, fi
 	return s.storage.Read(ctx, pKey, startCCols, finishCCols, cb)
 }
 
+// cache is not involved, partition keys are scanned by the underlying storage
+func (s *cachedAppStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	scanner, ok := s.storage.(istorage.IPKeysScanner)
+	if !ok {
+		return istorage.ErrPKeysScanNotSupported
+	}
+	return scanner.ScanPKeys(ctx, tokens, cb)
+}
+
 func (s *cachedAppStorage) SetTestDelayGet(delay time.Duration) {
 	s.storage.(istorage.IStorageDelaySetter).SetTestDelayGet(delay)
 }
@@ -372,9 +398,17 @@ func (s *cachedAppStorage) SetTestDelayPut(delay time.Duration) {
 	s.storage.(istorage.IStorageDelaySetter).SetTestDelayPut(delay)
 }
 
+// 0 if ttlSeconds is 0
+func (s *cachedAppStorage) expireAt(ttlSeconds int) int64 {
+	if ttlSeconds > 0 {
+		return s.iTime.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
+	}
+	return 0
+}
+
 func makeKey(pKey []byte, cCols []byte) (res []byte) {
-	res = make([]byte, 0, stackKeySize)
-	// res = make([]byte, 0, len(pKey)+len(cCols)) // escapes to heap
+	// key escapes to heap anyway since it is passed to tiers through the interface
+	res = make([]byte, 0, len(pKey)+len(cCols))
 	res = append(res, pKey...)
 	res = append(res, cCols...)
 	return res
@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecache
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// returns the entry or takes the lease of the missed key
+// KEYS: entry key, lease key; ARGV: lease, lease ttl ms
+var redisGetOrLeaseScript = redis.NewScript(`
+local entry = redis.call('GET', KEYS[1])
+if entry then
+	return {1, entry}
+end
+if redis.call('SET', KEYS[2], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return {0, ARGV[1]}
+end
+return {0, ''}
+`)
+
+// sets the entry if the lease is kept, i.e. the key is not invalidated since the lease is taken
+// KEYS: entry key, lease key; ARGV: lease, entry, ttl ms
+var redisSetLeasedScript = redis.NewScript(`
+if redis.call('GET', KEYS[2]) ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+redis.call('DEL', KEYS[2])
+return 1
+`)
+
+func newRedisTierFactory(params RedisParams) *redisTierFactory {
+	if len(params.KeyPrefix) == 0 {
+		params.KeyPrefix = DefaultRedisKeyPrefix
+	}
+	if params.MaxTTL == 0 {
+		params.MaxTTL = DefaultRedisMaxTTL
+	}
+	if params.LeaseTTL == 0 {
+		params.LeaseTTL = DefaultRedisLeaseTTL
+	}
+	if params.PoolSize == 0 {
+		params.PoolSize = DefaultRedisPoolSize
+	}
+	if params.Timeout == 0 {
+		params.Timeout = DefaultRedisTimeout
+	}
+	if params.BreakInterval == 0 {
+		params.BreakInterval = DefaultRedisBreakInterval
+	}
+	return &redisTierFactory{
+		params: params,
+		client: redis.NewClient(&redis.Options{
+			Addr:            params.Addr,
+			Password:        params.Password,
+			DB:              params.DB,
+			PoolSize:        params.PoolSize,
+			DialTimeout:     params.Timeout,
+			ReadTimeout:     params.Timeout,
+			WriteTimeout:    params.Timeout,
+			MaxRetries:      -1, // failures are handled by the circuit breaker
+			DisableIdentity: true,
+		}),
+	}
+}
+
+func (f *redisTierFactory) Name() string { return redisTierName }
+
+func (f *redisTierFactory) Shared() bool { return true }
+
+// connection is not established here, so the storage works while the server is unavailable
+func (f *redisTierFactory) AppTier(appQName appdef.AppQName) (ICacheTier, error) {
+	// app QName contains `/`, so lease keys could not be mixed up with entry keys
+	return &redisTier{
+		f:           f,
+		prefix:      f.params.KeyPrefix + appQName.String() + ":",
+		leasePrefix: f.params.KeyPrefix + "lease:" + appQName.String() + ":",
+	}, nil
+}
+
+func (f *redisTierFactory) Close() {
+	f.client.Close()
+}
+
+func (t *redisTier) Get(dst []byte, key []byte) (entry []byte, ok bool, err error) {
+	err = t.f.do(func(ctx context.Context) error {
+		res, err := t.f.client.Get(ctx, t.key(key)).Bytes()
+		if err == nil {
+			entry, ok = append(dst, res...), true
+		}
+		return err
+	})
+	if errors.Is(err, redis.Nil) {
+		return dst, false, nil
+	}
+	return entry, ok, err
+}
+
+func (t *redisTier) Set(key []byte, entry []byte, ttl time.Duration) error {
+	return t.f.do(func(ctx context.Context) error {
+		return t.f.client.Set(ctx, t.key(key), entry, t.ttl(ttl)).Err()
+	})
+}
+
+// lease keys are deleted too, so the entries read before the invalidation are not set
+func (t *redisTier) Del(keys ...[]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		args = append(args, t.key(key), t.leaseKey(key))
+	}
+	return t.f.do(func(ctx context.Context) error {
+		return t.f.client.Del(ctx, args...).Err()
+	})
+}
+
+func (t *redisTier) GetOrLease(dst []byte, key []byte) (entry []byte, ok bool, lease []byte, err error) {
+	newLease := make([]byte, redisLeaseSize)
+	rand.Read(newLease) // nolint: never returns an error
+	err = t.f.do(func(ctx context.Context) error {
+		res, err := redisGetOrLeaseScript.Run(ctx, t.f.client, []string{t.key(key), t.leaseKey(key)},
+			newLease, t.f.params.LeaseTTL.Milliseconds()).Slice()
+		if err != nil {
+			return err
+		}
+		if len(res) != 2 {
+			// notest
+			return fmt.Errorf("%w: unexpected lease reply %v", ErrRedisReply, res)
+		}
+		value, _ := res[1].(string)
+		if found, _ := res[0].(int64); found == 1 {
+			entry, ok = append(dst, value...), true
+			return nil
+		}
+		if len(value) > 0 {
+			lease = []byte(value)
+		}
+		return nil
+	})
+	if err != nil || !ok {
+		entry = dst
+	}
+	return entry, ok, lease, err
+}
+
+func (t *redisTier) SetLeased(key []byte, entry []byte, ttl time.Duration, lease []byte) error {
+	return t.f.do(func(ctx context.Context) error {
+		return redisSetLeasedScript.Run(ctx, t.f.client, []string{t.key(key), t.leaseKey(key)},
+			lease, entry, t.ttl(ttl).Milliseconds()).Err()
+	})
+}
+
+func (t *redisTier) key(key []byte) string {
+	return t.prefix + string(key)
+}
+
+func (t *redisTier) leaseKey(key []byte) string {
+	return t.leasePrefix + string(key)
+}
+
+// ttl is bounded by MaxTTL, so the entry is dropped anyway if the invalidation is failed
+func (t *redisTier) ttl(ttl time.Duration) time.Duration {
+	if ttl == 0 || ttl > t.f.params.MaxTTL {
+		ttl = t.f.params.MaxTTL
+	}
+	return max(ttl, time.Millisecond)
+}
+
+// executes the command
+// ErrRedisTierUnavailable is returned without accessing the server during params.BreakInterval after the connection failure
+func (f *redisTierFactory) do(cmd func(ctx context.Context) error) error {
+	if !f.allow() {
+		return ErrRedisTierUnavailable
+	}
+	err := cmd(context.Background())
+	var redisErr redis.Error
+	switch {
+	case err == nil, errors.Is(err, redis.Nil):
+	case errors.Is(err, redis.ErrClosed):
+		return ErrRedisTierClosed
+	case errors.As(err, &redisErr):
+		// the server is available
+		err = fmt.Errorf("%w: %w", ErrRedisReply, err)
+	default:
+		f.broken()
+		return err
+	}
+	f.brokenUntil.Store(0)
+	return err
+}
+
+// circuit breaker: the server is not accessed until brokenUntil, then the single probe command is allowed
+func (f *redisTierFactory) allow() bool {
+	until := f.brokenUntil.Load()
+	if until == 0 {
+		return true
+	}
+	now := time.Now().UnixNano()
+	if now < until {
+		return false
+	}
+	// other commands wait for the probe result
+	return f.brokenUntil.CompareAndSwap(until, now+f.params.BreakInterval.Nanoseconds())
+}
+
+func (f *redisTierFactory) broken() {
+	f.brokenUntil.Store(time.Now().Add(f.params.BreakInterval).UnixNano())
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istorage"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+)
+
+func (f *localTierFactory) Name() string { return localTierName }
+
+func (f *localTierFactory) Shared() bool { return false }
+
+func (f *localTierFactory) AppTier(appdef.AppQName) (ICacheTier, error) {
+	return &localTier{cache: fastcache.New(f.maxBytes)}, nil
+}
+
+func (f *localTierFactory) Close() {}
+
+func (t *localTier) Get(dst []byte, key []byte) (entry []byte, ok bool, err error) {
+	entry, ok = t.cache.HasGet(dst, key)
+	return entry, ok, nil
+}
+
+// ttl is not supported by fastcache, expired entries are dropped by the caching storage
+func (t *localTier) Set(key []byte, entry []byte, _ time.Duration) error {
+	t.cache.Set(key, entry)
+	return nil
+}
+
+func (t *localTier) Del(keys ...[]byte) error {
+	for _, key := range keys {
+		t.cache.Del(key)
+	}
+	return nil
+}
+
+func newCacheTiers(factories []ICacheTierFactory, metrics imetrics.IMetrics, vvm string, appQName appdef.AppQName) ([]cacheTier, error) {
+	tiers := make([]cacheTier, 0, len(factories))
+	for _, f := range factories {
+		tier, err := f.AppTier(appQName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s cache tier for %s: %w", f.Name(), appQName, err)
+		}
+		var leasing ILeasingCacheTier
+		if f.Shared() {
+			var ok bool
+			if leasing, ok = tier.(ILeasingCacheTier); !ok {
+				return nil, fmt.Errorf("%s: %w", f.Name(), ErrSharedTierNotLeasing)
+			}
+		}
+		tiers = append(tiers, cacheTier{
+			ICacheTier:   tier,
+			shared:       f.Shared(),
+			leasing:      leasing,
+			mHitsTotal:   metrics.AppMetricAddr(fmt.Sprintf(tierHitsTotal, f.Name()), vvm, appQName),
+			mMissesTotal: metrics.AppMetricAddr(fmt.Sprintf(tierMissesTotal, f.Name()), vvm, appQName),
+			mErrorsTotal: metrics.AppMetricAddr(fmt.Sprintf(tierErrorsTotal, f.Name()), vvm, appQName),
+		})
+	}
+	return tiers, nil
+}
+
+// returns the entry from the first tier which has it
+// the entry found in a lower tier is set to upper tiers
+// leases are taken by the shared tiers which miss the entry, they are used to set the entry read from the storage
+func (s *cachedAppStorage) cacheGet(dst []byte, key []byte) (entry []byte, ok bool, leases cacheLeases) {
+	for i := range s.tiers {
+		t := &s.tiers[i]
+		var err error
+		if t.shared {
+			var lease []byte
+			entry, ok, lease, err = t.leasing.GetOrLease(dst, key)
+			if len(lease) > 0 {
+				if leases == nil {
+					leases = make(cacheLeases, len(s.tiers))
+				}
+				leases[i] = lease
+			}
+		} else {
+			entry, ok, err = t.Get(dst, key)
+		}
+		if err != nil {
+			t.mErrorsTotal.Increase(1.0)
+			continue
+		}
+		if !ok {
+			t.mMissesTotal.Increase(1.0)
+			continue
+		}
+		t.mHitsTotal.Increase(1.0)
+		if i > 0 {
+			if ttl, expired := s.entryTTL(entry); !expired {
+				for j := range s.tiers[:i] {
+					s.tiers[j].setRead(key, entry, ttl, leases.get(j))
+				}
+			}
+		}
+		return entry, true, nil
+	}
+	return nil, false, leases
+}
+
+// sets the entry read from the storage to all tiers
+// shared tiers are set only if the lease taken on the miss is kept, i.e. the entry is not invalidated during the read
+func (s *cachedAppStorage) cacheSet(key []byte, d coreutils.DataWithExpiration, leases cacheLeases) {
+	entry := d.ToBytes()
+	ttl, expired := s.entryTTL(entry)
+	if expired {
+		return
+	}
+	for i := range s.tiers {
+		s.tiers[i].setRead(key, entry, ttl, leases.get(i))
+	}
+}
+
+// sets the entry written to the storage to not shared tiers, shared tiers are invalidated
+func (s *cachedAppStorage) cacheWrite(key []byte, d coreutils.DataWithExpiration) {
+	entry := d.ToBytes()
+	ttl, expired := s.entryTTL(entry)
+	for i := range s.tiers {
+		if expired || s.tiers[i].shared {
+			s.tiers[i].del(key)
+			continue
+		}
+		s.tiers[i].set(key, entry, ttl)
+	}
+}
+
+// same as cacheWrite for the batch of records written without TTL, shared tiers are invalidated by one Del
+func (s *cachedAppStorage) cacheWriteBatch(items []istorage.BatchItem) {
+	keys := make([][]byte, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, makeKey(item.PKey, item.CCols))
+	}
+	for i := range s.tiers {
+		if s.tiers[i].shared {
+			s.tiers[i].del(keys...)
+			continue
+		}
+		for j, item := range items {
+			s.tiers[i].set(keys[j], coreutils.DataWithExpiration{Data: item.Value}.ToBytes(), 0)
+		}
+	}
+}
+
+func (s *cachedAppStorage) cacheDel(key []byte) {
+	for i := range s.tiers {
+		s.tiers[i].del(key)
+	}
+}
+
+// returns the time left to the entry expiration, 0 if the entry does not expire
+func (s *cachedAppStorage) entryTTL(entry []byte) (ttl time.Duration, expired bool) {
+	d := coreutils.ReadWithExpiration(entry)
+	if d.ExpireAt == 0 {
+		return 0, false
+	}
+	ttl = time.UnixMilli(d.ExpireAt).Sub(s.iTime.Now())
+	return ttl, ttl <= 0
+}
+
+// checks the expiration time of the cached entry
+func (s *cachedAppStorage) isExpired(d coreutils.DataWithExpiration) bool {
+	return d.ExpireAt > 0 && d.IsExpired(s.iTime.Now())
+}
+
+func (t *cacheTier) setRead(key []byte, entry []byte, ttl time.Duration, lease []byte) {
+	if !t.shared {
+		t.set(key, entry, ttl)
+		return
+	}
+	if len(lease) == 0 {
+		// the key is leased by another reader or the lease is not taken
+		return
+	}
+	if err := t.leasing.SetLeased(key, entry, ttl, lease); err != nil {
+		t.mErrorsTotal.Increase(1.0)
+	}
+}
+
+func (t *cacheTier) set(key []byte, entry []byte, ttl time.Duration) {
+	if err := t.Set(key, entry, ttl); err != nil {
+		t.mErrorsTotal.Increase(1.0)
+	}
+}
+
+func (t *cacheTier) del(keys ...[]byte) {
+	if err := t.Del(keys...); err != nil {
+		t.mErrorsTotal.Increase(1.0)
+	}
+}
+
+func (l cacheLeases) get(i int) []byte {
+	if l == nil {
+		return nil
+	}
+	return l[i]
+}
@@ -0,0 +1,564 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+)
+
+func TestTiers_BasicUsage(t *testing.T) {
+	require := require.New(t)
+	srv := newTestRedisServer(t, "")
+
+	// two VVMs share the storage and the shared tier
+	storageGets := 0
+	values := map[string][]byte{}
+	ts := &testStorage{
+		put: func(pKey []byte, cCols []byte, value []byte) (err error) {
+			values[string(pKey)+string(cCols)] = value
+			return nil
+		},
+		get: func(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+			storageGets++
+			*data, ok = values[string(pKey)+string(cCols)]
+			return ok, nil
+		},
+	}
+	metrics := imetrics.Provide()
+	newVVMStorage := func(vvm string) istorage.IAppStorage {
+		tiers := []ICacheTierFactory{NewLocalTier(testCacheSize), srv.tier(RedisParams{})}
+		provider := ProvideTiered(tiers, &testStorageProvider{storage: ts}, metrics, vvm, testingu.MockTime)
+		t.Cleanup(provider.Stop)
+		storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		return storage
+	}
+	vvm1 := newVVMStorage("vvm1")
+	vvm2 := newVVMStorage("vvm2")
+	metricValue := func(vvm, tier, name string) float64 {
+		return float64(*metrics.AppMetricAddr(fmt.Sprintf(name, tier), vvm, istructs.AppQName_test1_app1))
+	}
+
+	require.NoError(vvm1.Put([]byte("UK"), []byte("Article"), []byte("Cola")))
+
+	t.Run("write is written through the local tier and invalidates the shared tier", func(t *testing.T) {
+		require.Equal(1, srv.commands("DEL"))
+		require.Zero(srv.len())
+
+		data := []byte{}
+		ok, err := vvm1.Get([]byte("UK"), []byte("Article"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("Cola"), data)
+		require.Zero(storageGets)
+		require.Equal(1.0, metricValue("vvm1", localTierName, tierHitsTotal))
+	})
+
+	t.Run("entry read from the storage is set to all tiers", func(t *testing.T) {
+		data := []byte{}
+		ok, err := vvm2.Get([]byte("UK"), []byte("Article"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("Cola"), data)
+		require.Equal(1, storageGets)
+		require.Equal(1.0, metricValue("vvm2", localTierName, tierMissesTotal))
+		require.Equal(1.0, metricValue("vvm2", redisTierName, tierMissesTotal))
+		require.Equal(1, srv.len())
+	})
+
+	t.Run("entry found in the shared tier is set to the local tier", func(t *testing.T) {
+		vvm3 := newVVMStorage("vvm3")
+		for range 2 {
+			data := []byte{}
+			ok, err := vvm3.Get([]byte("UK"), []byte("Article"), &data)
+			require.NoError(err)
+			require.True(ok)
+			require.Equal([]byte("Cola"), data)
+		}
+		require.Equal(1, storageGets)
+		require.Equal(1.0, metricValue("vvm3", redisTierName, tierHitsTotal))
+		require.Equal(1.0, metricValue("vvm3", localTierName, tierHitsTotal))
+	})
+
+	t.Run("missing key state is shared", func(t *testing.T) {
+		ok, err := vvm1.Get([]byte("UK"), []byte("missing"), &[]byte{})
+		require.NoError(err)
+		require.False(ok)
+		ok, err = vvm2.Get([]byte("UK"), []byte("missing"), &[]byte{})
+		require.NoError(err)
+		require.False(ok)
+		require.Equal(2, storageGets)
+	})
+
+	t.Run("keys are prefixed by the application", func(t *testing.T) {
+		require.True(srv.has(DefaultRedisKeyPrefix + istructs.AppQName_test1_app1.String() + ":UKArticle"))
+	})
+}
+
+func TestTiers_TTL(t *testing.T) {
+	require := require.New(t)
+	srv := newTestRedisServer(t, "")
+	iTime := testingu.NewMockTime()
+
+	asp := istorageimpl.Provide(mem.Provide(iTime))
+	tiers := []ICacheTierFactory{NewLocalTier(testCacheSize), srv.tier(RedisParams{MaxTTL: time.Hour})}
+	metrics := imetrics.Provide()
+	provider := ProvideTiered(tiers, asp, metrics, "vvm", iTime)
+	defer provider.Stop()
+	storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	ok, err := storage.InsertIfNotExists([]byte("pKey"), []byte("cCols"), []byte("value"), 10)
+	require.NoError(err)
+	require.True(ok)
+
+	data := []byte{}
+	ok, err = storage.Get([]byte("pKey"), []byte("cCols"), &data)
+	require.NoError(err)
+	require.True(ok)
+
+	t.Run("written record is kept by the local tier only", func(t *testing.T) {
+		require.Equal(1.0, float64(*metrics.AppMetricAddr(fmt.Sprintf(tierHitsTotal, localTierName), "vvm", istructs.AppQName_test1_app1)))
+		require.Zero(srv.len())
+	})
+
+	t.Run("expired entry is not returned", func(t *testing.T) {
+		iTime.Add(11 * time.Second)
+
+		ok, err := storage.TTLGet([]byte("pKey"), []byte("cCols"), &data)
+		require.NoError(err)
+		require.False(ok)
+
+		ok, err = storage.Get([]byte("pKey"), []byte("cCols"), &data)
+		require.NoError(err)
+		require.False(ok)
+	})
+}
+
+func TestTiers_Invalidation(t *testing.T) {
+	require := require.New(t)
+	srv := newTestRedisServer(t, "")
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	newVVMStorage := func(vvm string) istorage.IAppStorage {
+		tiers := []ICacheTierFactory{NewLocalTier(testCacheSize), srv.tier(RedisParams{})}
+		provider := ProvideTiered(tiers, asp, imetrics.Provide(), vvm, testingu.MockTime)
+		t.Cleanup(provider.Stop)
+		storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		return storage
+	}
+	vvm1 := newVVMStorage("vvm1")
+	vvm2 := newVVMStorage("vvm2")
+
+	pKey, cCols := []byte("pKey"), []byte("cCols")
+	get := func(s istorage.IAppStorage) string {
+		data := []byte{}
+		ok, err := s.Get(pKey, cCols, &data)
+		require.NoError(err)
+		if !ok {
+			return ""
+		}
+		return string(data)
+	}
+
+	ok, err := vvm1.InsertIfNotExists(pKey, cCols, []byte("v1"), 0)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("v1", get(vvm2))
+	require.Equal(1, srv.len())
+
+	t.Run("CompareAndSwap invalidates the shared tier", func(t *testing.T) {
+		ok, err := vvm1.CompareAndSwap(pKey, cCols, []byte("v1"), []byte("v2"), 0)
+		require.NoError(err)
+		require.True(ok)
+		require.Zero(srv.len())
+		require.Equal("v2", get(vvm1))
+	})
+
+	t.Run("failed CompareAndSwap invalidates the stale cached value", func(t *testing.T) {
+		// vvm2 keeps v1 in its local tier
+		require.Equal("v1", get(vvm2))
+		ok, err := vvm2.CompareAndSwap(pKey, cCols, []byte("v1"), []byte("v3"), 0)
+		require.NoError(err)
+		require.False(ok)
+		require.Equal("v2", get(vvm2))
+	})
+
+	t.Run("PutBatch invalidates the shared tier by one command", func(t *testing.T) {
+		data := []byte{}
+		for i := range 3 {
+			require.NoError(vvm1.Put(pKey, []byte{byte(i)}, []byte("value")))
+			ok, err := vvm2.Get(pKey, []byte{byte(i)}, &data) // cached in the shared tier
+			require.NoError(err)
+			require.True(ok)
+		}
+		require.Equal(4, srv.len())
+		dels := srv.commands("DEL")
+
+		items := []istorage.BatchItem{}
+		for i := range 3 {
+			items = append(items, istorage.BatchItem{PKey: pKey, CCols: []byte{byte(i)}, Value: []byte("new value")})
+		}
+		require.NoError(vvm1.PutBatch(items))
+		require.Equal(dels+1, srv.commands("DEL"))
+		require.Equal(1, srv.len())
+	})
+
+	t.Run("CompareAndDelete invalidates all tiers", func(t *testing.T) {
+		require.Equal("v2", get(vvm1))
+		require.Equal(1, srv.len())
+		ok, err := vvm1.CompareAndDelete(pKey, cCols, []byte("v2"))
+		require.NoError(err)
+		require.True(ok)
+		require.Zero(srv.len())
+		require.Empty(get(vvm1))
+	})
+}
+
+func TestTiers_ReadRacesWithInvalidation(t *testing.T) {
+	require := require.New(t)
+	srv := newTestRedisServer(t, "")
+
+	pKey, cCols := []byte("pKey"), []byte("cCols")
+	values := map[string][]byte{"pKeycCols": []byte("v1")}
+	var onGet func()
+	ts := &testStorage{
+		put: func(pKey []byte, cCols []byte, value []byte) (err error) {
+			values[string(pKey)+string(cCols)] = value
+			return nil
+		},
+		get: func(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+			value, ok := values[string(pKey)+string(cCols)]
+			*data = append((*data)[0:0], value...)
+			if onGet != nil {
+				f := onGet
+				onGet = nil
+				f()
+			}
+			return ok, nil
+		},
+	}
+	newVVMStorage := func(vvm string) istorage.IAppStorage {
+		tiers := []ICacheTierFactory{NewLocalTier(testCacheSize), srv.tier(RedisParams{})}
+		provider := ProvideTiered(tiers, &testStorageProvider{storage: ts}, imetrics.Provide(), vvm, testingu.MockTime)
+		t.Cleanup(provider.Stop)
+		storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		return storage
+	}
+	vvm1 := newVVMStorage("vvm1")
+	vvm2 := newVVMStorage("vvm2")
+	get := func(s istorage.IAppStorage) string {
+		data := []byte{}
+		ok, err := s.Get(pKey, cCols, &data)
+		require.NoError(err)
+		require.True(ok)
+		return string(data)
+	}
+
+	// vvm1 writes v2 while vvm2 reads v1 from the storage
+	onGet = func() {
+		require.NoError(vvm1.Put(pKey, cCols, []byte("v2")))
+	}
+	require.Equal("v1", get(vvm2))
+
+	// v1 is not set to the shared tier, so other VVMs read v2
+	require.Zero(srv.len())
+	require.Equal("v2", get(newVVMStorage("vvm3")))
+	require.Equal(1, srv.len())
+}
+
+func TestTiers_TechnologyCompatibilityKit(t *testing.T) {
+	srv := newTestRedisServer(t, "secret")
+	asf := mem.Provide(testingu.MockTime)
+	asp := istorageimpl.Provide(asf)
+	tiers := []ICacheTierFactory{
+		NewLocalTier(testCacheSize),
+		srv.tier(RedisParams{Password: "secret", DB: 1}),
+	}
+	provider := ProvideTiered(tiers, asp, imetrics.Provide(), "vvm", asf.Time())
+	defer provider.Stop()
+	storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(t, err)
+	istorage.TechnologyCompatibilityKit_Storage(t, storage, asf.Time())
+}
+
+func TestTiers_SharedTierFailures(t *testing.T) {
+	require := require.New(t)
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	metrics := imetrics.Provide()
+	errorsTotal := func() float64 {
+		return float64(*metrics.AppMetricAddr(fmt.Sprintf(tierErrorsTotal, redisTierName), "vvm", istructs.AppQName_test1_app1))
+	}
+
+	t.Run("storage works if the server is unavailable", func(t *testing.T) {
+		srv := newTestRedisServer(t, "")
+		tiers := []ICacheTierFactory{NewLocalTier(testCacheSize), srv.tier(RedisParams{})}
+		srv.Close()
+
+		provider := ProvideTiered(tiers, asp, metrics, "vvm", testingu.MockTime)
+		defer provider.Stop()
+		storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+
+		require.NoError(storage.Put([]byte("pKey"), []byte("cCols"), []byte("value")))
+		data := []byte{}
+		ok, err := storage.Get([]byte("pKey"), []byte("cCols2"), &data)
+		require.NoError(err)
+		require.False(ok)
+		require.Equal(2.0, errorsTotal()) // Del on Put and Get on Get, the entry is not set without the lease
+	})
+
+	t.Run("server is not accessed during BreakInterval after the failure", func(t *testing.T) {
+		srv := newTestRedisServer(t, "")
+		const breakInterval = 50 * time.Millisecond
+		f := srv.tier(RedisParams{BreakInterval: breakInterval})
+		defer f.Close()
+		tier, err := f.AppTier(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		require.NoError(tier.Set([]byte("key"), []byte("entry"), 0))
+
+		srv.Close()
+		_, _, err = tier.Get(nil, []byte("key"))
+		require.Error(err)
+		require.NotErrorIs(err, ErrRedisTierUnavailable)
+		gets := srv.commands("GET")
+
+		start := time.Now()
+		require.ErrorIs(tier.Del([]byte("key")), ErrRedisTierUnavailable)
+		_, _, err = tier.Get(nil, []byte("key"))
+		require.ErrorIs(err, ErrRedisTierUnavailable)
+		require.Less(time.Since(start), breakInterval)
+		require.Equal(gets, srv.commands("GET"))
+
+		require.NoError(srv.Restart())
+		time.Sleep(breakInterval)
+		entry, ok, err := tier.Get(nil, []byte("key"))
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("entry"), entry)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		srv := newTestRedisServer(t, "secret")
+		tier, err := srv.tier(RedisParams{Password: "wrong"}).AppTier(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		_, _, err = tier.Get(nil, []byte("key"))
+		require.ErrorIs(err, ErrRedisReply)
+	})
+
+	t.Run("closed tier", func(t *testing.T) {
+		srv := newTestRedisServer(t, "")
+		f := srv.tier(RedisParams{})
+		tier, err := f.AppTier(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		require.NoError(tier.Set([]byte("key"), []byte("entry"), 0))
+		f.Close()
+		_, _, err = tier.Get(nil, []byte("key"))
+		require.ErrorIs(err, ErrRedisTierClosed)
+	})
+}
+
+func TestRedisTier(t *testing.T) {
+	require := require.New(t)
+	srv := newTestRedisServer(t, "")
+	f := srv.tier(RedisParams{MaxTTL: time.Minute, PoolSize: 2})
+	defer f.Close()
+	tier, err := f.AppTier(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	t.Run("entries", func(t *testing.T) {
+		entry, ok, err := tier.Get([]byte("dst"), []byte("key"))
+		require.NoError(err)
+		require.False(ok)
+		require.Equal([]byte("dst"), entry)
+
+		require.NoError(tier.Set([]byte("key"), []byte{}, 0))
+		entry, ok, err = tier.Get([]byte("dst"), []byte("key"))
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("dst"), entry)
+
+		require.NoError(tier.Set([]byte("key"), []byte("entry\r\nwith crlf"), 0))
+		entry, ok, err = tier.Get(nil, []byte("key"))
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("entry\r\nwith crlf"), entry)
+
+		require.NoError(tier.Del([]byte("key")))
+		_, ok, err = tier.Get(nil, []byte("key"))
+		require.NoError(err)
+		require.False(ok)
+	})
+
+	t.Run("Del of several keys", func(t *testing.T) {
+		require.NoError(tier.Set([]byte("key1"), []byte("entry"), 0))
+		require.NoError(tier.Set([]byte("key2"), []byte("entry"), 0))
+		dels := srv.commands("DEL")
+		require.NoError(tier.Del([]byte("key1"), []byte("key2")))
+		require.NoError(tier.Del())
+		require.Equal(dels+1, srv.commands("DEL"))
+		require.Zero(srv.len())
+	})
+
+	t.Run("ttl is bounded by MaxTTL", func(t *testing.T) {
+		require.NoError(tier.Set([]byte("key"), []byte("entry"), 0))
+		require.Equal(time.Minute, srv.ttl(DefaultRedisKeyPrefix+istructs.AppQName_test1_app1.String()+":key"))
+		require.NoError(tier.Set([]byte("key"), []byte("entry"), time.Hour))
+		require.Equal(time.Minute, srv.ttl(DefaultRedisKeyPrefix+istructs.AppQName_test1_app1.String()+":key"))
+		require.NoError(tier.Set([]byte("key"), []byte("entry"), time.Second))
+		require.Equal(time.Second, srv.ttl(DefaultRedisKeyPrefix+istructs.AppQName_test1_app1.String()+":key"))
+	})
+
+	t.Run("lease", func(t *testing.T) {
+		entry, ok, lease, err := tier.(ILeasingCacheTier).GetOrLease([]byte("dst"), []byte("leased"))
+		require.NoError(err)
+		require.False(ok)
+		require.Equal([]byte("dst"), entry)
+		require.NotEmpty(lease)
+
+		// the key is leased by another reader already
+		_, ok, otherLease, err := tier.(ILeasingCacheTier).GetOrLease(nil, []byte("leased"))
+		require.NoError(err)
+		require.False(ok)
+		require.Empty(otherLease)
+
+		require.NoError(tier.(ILeasingCacheTier).SetLeased([]byte("leased"), []byte("entry"), 0, lease))
+		entry, ok, lease, err = tier.(ILeasingCacheTier).GetOrLease(nil, []byte("leased"))
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("entry"), entry)
+		require.Empty(lease)
+
+		t.Run("lease is released by the set", func(t *testing.T) {
+			require.NoError(tier.(ILeasingCacheTier).SetLeased([]byte("leased"), []byte("other"), 0, lease))
+			entry, _, _, err := tier.(ILeasingCacheTier).GetOrLease(nil, []byte("leased"))
+			require.NoError(err)
+			require.Equal([]byte("entry"), entry)
+		})
+
+		t.Run("lease is dropped by Del", func(t *testing.T) {
+			require.NoError(tier.Del([]byte("leased")))
+			_, ok, lease, err := tier.(ILeasingCacheTier).GetOrLease(nil, []byte("leased"))
+			require.NoError(err)
+			require.False(ok)
+			require.NotEmpty(lease)
+
+			require.NoError(tier.Del([]byte("leased")))
+			require.NoError(tier.(ILeasingCacheTier).SetLeased([]byte("leased"), []byte("entry"), 0, lease))
+			_, ok, err = tier.Get(nil, []byte("leased"))
+			require.NoError(err)
+			require.False(ok)
+		})
+
+		t.Run("lease expires", func(t *testing.T) {
+			_, _, lease, err := tier.(ILeasingCacheTier).GetOrLease(nil, []byte("expired"))
+			require.NoError(err)
+			require.NotEmpty(lease)
+
+			srv.FastForward(DefaultRedisLeaseTTL)
+			require.NoError(tier.(ILeasingCacheTier).SetLeased([]byte("expired"), []byte("entry"), 0, lease))
+			_, ok, err := tier.Get(nil, []byte("expired"))
+			require.NoError(err)
+			require.False(ok)
+		})
+	})
+
+	t.Run("concurrent usage", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := []byte(strconv.Itoa(i))
+				for range 100 {
+					require.NoError(tier.Set(key, key, 0))
+					entry, ok, err := tier.Get(nil, key)
+					require.NoError(err)
+					require.True(ok)
+					require.Equal(key, entry)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+// testRedisServer is the in-memory Redis server which counts commands sent by the tiers created by tier()
+type testRedisServer struct {
+	*miniredis.Miniredis
+	lock   sync.Mutex
+	counts map[string]int
+}
+
+func newTestRedisServer(t *testing.T, password string) *testRedisServer {
+	srv := &testRedisServer{
+		Miniredis: miniredis.RunT(t),
+		counts:    map[string]int{},
+	}
+	if len(password) > 0 {
+		srv.RequireAuth(password)
+	}
+	return srv
+}
+
+func (srv *testRedisServer) tier(params RedisParams) ICacheTierFactory {
+	params.Addr = srv.Addr()
+	f := newRedisTierFactory(params)
+	f.client.AddHook(srv)
+	return f
+}
+
+// entries count, leases are not counted
+func (srv *testRedisServer) len() (res int) {
+	for _, key := range srv.Keys() {
+		if !strings.HasPrefix(key, DefaultRedisKeyPrefix+"lease:") {
+			res++
+		}
+	}
+	return res
+}
+
+func (srv *testRedisServer) has(key string) bool { return srv.Exists(key) }
+
+func (srv *testRedisServer) ttl(key string) time.Duration { return srv.TTL(key) }
+
+func (srv *testRedisServer) commands(name string) int {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+	return srv.counts[name]
+}
+
+func (srv *testRedisServer) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (srv *testRedisServer) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		srv.lock.Lock()
+		srv.counts[strings.ToUpper(cmd.Name())]++
+		srv.lock.Unlock()
+		return next(ctx, cmd)
+	}
+}
+
+func (srv *testRedisServer) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
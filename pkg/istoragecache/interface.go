@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecache
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// ICacheTier is a level of the application storage cache
+//
+// Entries are opaque for tiers, the expiration time is kept inside the entry by the caching storage
+type ICacheTier interface {
+	// Appends the entry to dst
+	// Errors are treated as misses by the caching storage
+	Get(dst []byte, key []byte) (entry []byte, ok bool, err error)
+
+	// ttl == 0 -> entry does not expire
+	// Tier could evict the entry before ttl
+	Set(key []byte, entry []byte, ttl time.Duration) error
+
+	// Deletes the entries of all keys at once, e.g. by one command to the remote tier
+	Del(keys ...[]byte) error
+}
+
+// ILeasingCacheTier is the shared tier which protects the entries read from the storage against invalidations made by other VVMs
+//
+// The reader which gets the miss takes the lease of the key, Del of the key drops the lease,
+// so the value read from the storage before the concurrent write is not set
+type ILeasingCacheTier interface {
+	ICacheTier
+
+	// Same as Get, the lease of the key is taken on the miss
+	// lease is empty if the key is leased by another reader
+	GetOrLease(dst []byte, key []byte) (entry []byte, ok bool, lease []byte, err error)
+
+	// Sets the entry if the lease is kept, the lease is released
+	SetLeased(key []byte, entry []byte, ttl time.Duration, lease []byte) error
+}
+
+// ICacheTierFactory creates tiers for application storages
+type ICacheTierFactory interface {
+	// Is used in the tier metrics names, e.g. `local`, `redis`
+	Name() string
+
+	// Shared tier is used by several VVMs, so it is invalidated on writes instead of being written through
+	// Tiers of the shared factory must implement ILeasingCacheTier
+	Shared() bool
+
+	AppTier(appQName appdef.AppQName) (ICacheTier, error)
+
+	// Releases resources shared by tiers
+	// Is called on the caching storage provider Stop()
+	Close()
+}
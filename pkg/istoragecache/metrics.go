@@ -26,3 +26,10 @@ const (
 	queryTTLSeconds          = "voedger_istoragecache_queryttl_seconds"
 	queryTTLTotal            = "voedger_istoragecache_queryttl_total"
 )
+
+// formatted by the tier name
+const (
+	tierHitsTotal   = "voedger_istoragecache_%s_hits_total"
+	tierMissesTotal = "voedger_istoragecache_%s_misses_total"
+	tierErrorsTotal = "voedger_istoragecache_%s_errors_total"
+)
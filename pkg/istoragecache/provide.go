@@ -11,18 +11,43 @@ import (
 )
 
 // Provide s.e.
+// The cache consists of the local tier of maxBytes
 func Provide(
 	maxBytes int,
 	storageProvider istorage.IAppStorageProvider,
 	metrics imetrics.IMetrics,
 	vvmName string,
 	iTime timeu.ITime,
+) istorage.IAppStorageProvider {
+	return ProvideTiered([]ICacheTierFactory{NewLocalTier(maxBytes)}, storageProvider, metrics, vvmName, iTime)
+}
+
+// ProvideTiered provides the cache which consists of tiers
+// Entry is looked up from the first tier to the last one, entry found in a lower tier is set to upper tiers
+func ProvideTiered(
+	tiers []ICacheTierFactory,
+	storageProvider istorage.IAppStorageProvider,
+	metrics imetrics.IMetrics,
+	vvmName string,
+	iTime timeu.ITime,
 ) istorage.IAppStorageProvider {
 	return &implCachingAppStorageProvider{
-		maxBytes:        maxBytes,
+		tiers:           tiers,
 		storageProvider: storageProvider,
 		metrics:         metrics,
 		vvmName:         vvmName,
 		iTime:           iTime,
 	}
 }
+
+// NewLocalTier provides the in-process tier which is bounded by maxBytes per application
+// Note: fastcache is bounded by 32 Mb at least
+func NewLocalTier(maxBytes int) ICacheTierFactory {
+	return &localTierFactory{maxBytes: maxBytes}
+}
+
+// NewRedisTier provides the shared tier which is kept by the Redis server
+// Lua scripts are used to take leases, so the server must support EVAL
+func NewRedisTier(params RedisParams) ICacheTierFactory {
+	return newRedisTierFactory(params)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecache
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/redis/go-redis/v9"
+
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+)
+
+// RedisParams configures the shared cache tier which is kept by the Redis server
+type RedisParams struct {
+	// host:port
+	Addr     string
+	Password string
+	DB       int
+
+	// Keys are `<KeyPrefix><app QName>:<key>`, DefaultRedisKeyPrefix if empty
+	// Different prefixes must be used by clusters which share the same server
+	KeyPrefix string
+
+	// Bounds the time the entry is kept by the tier, DefaultRedisMaxTTL if zero
+	// Entry could be stale if the invalidation is failed, so the entry lifetime is bounded anyway
+	MaxTTL time.Duration
+
+	// Bounds the time between the miss and the set of the entry read from the storage, DefaultRedisLeaseTTL if zero
+	// Entry is not set if the storage read takes longer
+	LeaseTTL time.Duration
+
+	// Max number of connections, DefaultRedisPoolSize if zero
+	PoolSize int
+
+	// Timeout of dial and of each command, DefaultRedisTimeout if zero
+	Timeout time.Duration
+
+	// The server is not accessed during BreakInterval after the connection failure, so the unavailable server does not slow down the storage
+	// DefaultRedisBreakInterval if zero
+	BreakInterval time.Duration
+}
+
+type localTierFactory struct {
+	maxBytes int
+}
+
+type localTier struct {
+	cache *fastcache.Cache
+}
+
+type redisTierFactory struct {
+	params      RedisParams
+	client      *redis.Client
+	brokenUntil atomic.Int64 // unix nanoseconds, 0 if the server is available
+}
+
+type redisTier struct {
+	f           *redisTierFactory
+	prefix      string
+	leasePrefix string
+}
+
+type cacheTier struct {
+	ICacheTier
+	shared  bool
+	leasing ILeasingCacheTier // not nil for the shared tier
+
+	/* metrics */
+	mHitsTotal   *imetrics.MetricValue
+	mMissesTotal *imetrics.MetricValue
+	mErrorsTotal *imetrics.MetricValue
+}
+
+// leases of the shared tiers taken on misses, by tier index
+type cacheLeases [][]byte
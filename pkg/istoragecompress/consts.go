@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+// the value is stored as is if it is shorter, the compression does not pay off for small values
+const DefaultMinSize = 64
+
+// stored value layout: header byte which is the Algorithm, then the value compressed by the Algorithm
+const headerSize = 1
+
+// marker of the application storage which values are written with headers
+// QNameIDs up to istructs.QNameIDSysLast are not used as the partition key prefix of the application data
+var (
+	markerPKey  = []byte("\x00\x00istoragecompress")
+	markerCCols = []byte("enabled")
+)
+
+const (
+	// values written by the decorator, before the compression
+	valuesTotal = "voedger_istoragecompress_values_total"
+	// values stored compressed, the others are stored as is because they are small or incompressible
+	compressedValuesTotal = "voedger_istoragecompress_compressed_values_total"
+	// bytes of values before the compression
+	bytesTotal = "voedger_istoragecompress_bytes_total"
+	// bytes written to the storage, including headers
+	// compression ratio is bytes_total / stored_bytes_total
+	storedBytesTotal  = "voedger_istoragecompress_stored_bytes_total"
+	decompressSeconds = "voedger_istoragecompress_decompress_seconds"
+)
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+import "errors"
+
+var (
+	ErrUnknownAlgorithm   = errors.New("unknown compression algorithm")
+	ErrEmptyValue         = errors.New("stored value has no compression header")
+	ErrAppStorageNotEmpty = errors.New("compression could not be enabled for the application storage which has values written without compression")
+)
@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func (asp *implCompressingAppStorageProvider) Prepare(work any) error {
+	return asp.storageProvider.Prepare(work)
+}
+
+func (asp *implCompressingAppStorageProvider) Run(ctx context.Context) {
+	asp.storageProvider.Run(ctx)
+}
+
+func (asp *implCompressingAppStorageProvider) Stop() {
+	asp.storageProvider.Stop()
+}
+
+func (asp *implCompressingAppStorageProvider) AppStorage(appQName appdef.AppQName) (istorage.IAppStorage, error) {
+	storage, err := asp.storageProvider.AppStorage(appQName)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, ok := asp.params.Apps[appQName]
+	if !ok {
+		return storage, nil
+	}
+	if algorithm >= Algorithm_FakeLast {
+		return nil, fmt.Errorf("%w: %d for %s", ErrUnknownAlgorithm, algorithm, appQName)
+	}
+	if err := enableCompression(storage); err != nil {
+		return nil, fmt.Errorf("%s: %w", appQName, err)
+	}
+	return &compressingAppStorage{
+		storage:                storage,
+		algorithm:              algorithm,
+		minSize:                asp.params.MinSize,
+		mValuesTotal:           asp.metrics.AppMetricAddr(valuesTotal, asp.vvmName, appQName),
+		mCompressedValuesTotal: asp.metrics.AppMetricAddr(compressedValuesTotal, asp.vvmName, appQName),
+		mBytesTotal:            asp.metrics.AppMetricAddr(bytesTotal, asp.vvmName, appQName),
+		mStoredBytesTotal:      asp.metrics.AppMetricAddr(storedBytesTotal, asp.vvmName, appQName),
+		mDecompressSeconds:     asp.metrics.AppMetricAddr(decompressSeconds, asp.vvmName, appQName),
+	}, nil
+}
+
+// stored values have no headers if the application storage was written without compression, so they could not be read
+// the marker is written to the empty storage, the storage with the marker is compressed already
+func enableCompression(storage istorage.IAppStorage) error {
+	ok, err := storage.Get(markerPKey, markerCCols, &[]byte{})
+	if err != nil || ok {
+		return err
+	}
+	scanner, ok := storage.(istorage.IPKeysScanner)
+	if !ok {
+		// notest: all drivers scan partition keys
+		return istorage.ErrPKeysScanNotSupported
+	}
+	err = scanner.ScanPKeys(context.Background(), istorage.FullTokenRange, func([]byte) error {
+		return ErrAppStorageNotEmpty
+	})
+	if err != nil {
+		return err
+	}
+	// the marker is the empty value with the header, so it is read by the compressing storage as well
+	return storage.Put(markerPKey, markerCCols, []byte{byte(Algorithm_None)})
+}
+
+func (s *compressingAppStorage) compress(value []byte) []byte {
+	algorithm := s.algorithm
+	if len(value) < s.minSize {
+		algorithm = Algorithm_None
+	}
+	stored := compress(algorithm, value)
+	s.mValuesTotal.Increase(1)
+	s.mBytesTotal.Increase(float64(len(value)))
+	s.mStoredBytesTotal.Increase(float64(len(stored)))
+	if Algorithm(stored[0]) != Algorithm_None {
+		s.mCompressedValuesTotal.Increase(1)
+	}
+	return stored
+}
+
+func (s *compressingAppStorage) decompress(dst []byte, stored []byte) ([]byte, error) {
+	if len(stored) > 0 && Algorithm(stored[0]) == Algorithm_None {
+		return decompress(dst, stored)
+	}
+	start := time.Now()
+	defer func() {
+		s.mDecompressSeconds.Increase(time.Since(start).Seconds())
+	}()
+	return decompress(dst, stored)
+}
+
+func (s *compressingAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	return s.storage.Put(pKey, cCols, s.compress(value))
+}
+
+func (s *compressingAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
+	compressed := make([]istorage.BatchItem, len(items))
+	for i, item := range items {
+		compressed[i] = istorage.BatchItem{PKey: item.PKey, CCols: item.CCols, Value: s.compress(item.Value)}
+	}
+	return s.storage.PutBatch(compressed)
+}
+
+func (s *compressingAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	if ok, err = s.storage.Get(pKey, cCols, data); !ok || err != nil {
+		return ok, err
+	}
+	return true, s.decompressInPlace(data)
+}
+
+func (s *compressingAppStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	if ok, err = s.storage.TTLGet(pKey, cCols, data); !ok || err != nil {
+		return ok, err
+	}
+	return true, s.decompressInPlace(data)
+}
+
+func (s *compressingAppStorage) decompressInPlace(data *[]byte) error {
+	stored := *data
+	if len(stored) > 0 && Algorithm(stored[0]) == Algorithm_None {
+		*data = append(stored[:0], stored[headerSize:]...)
+		return nil
+	}
+	value, err := s.decompress(nil, stored)
+	if err != nil {
+		return err
+	}
+	*data = append(stored[:0], value...)
+	return nil
+}
+
+func (s *compressingAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	if err := s.storage.GetBatch(pKey, items); err != nil {
+		return err
+	}
+	for i := range items {
+		if !items[i].Ok {
+			continue
+		}
+		if err := s.decompressInPlace(items[i].Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *compressingAppStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.storage.Read(ctx, pKey, startCCols, finishCCols, s.decompressingCallback(cb))
+}
+
+func (s *compressingAppStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.storage.TTLRead(ctx, pKey, startCCols, finishCCols, s.decompressingCallback(cb))
+}
+
+func (s *compressingAppStorage) decompressingCallback(cb istorage.ReadCallback) istorage.ReadCallback {
+	return func(cCols []byte, stored []byte) error {
+		// empty value is not nil as it is read from drivers
+		value, err := s.decompress([]byte{}, stored)
+		if err != nil {
+			return err
+		}
+		return cb(cCols, value)
+	}
+}
+
+func (s *compressingAppStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.storage.InsertIfNotExists(pKey, cCols, s.compress(value), ttlSeconds)
+}
+
+// the stored value could be compressed by another algorithm or stored as is, so it is read and compared decompressed
+// and then swapped by the underlying storage if it is not changed since that
+func (s *compressingAppStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	stored, ok, err := s.currentStored(pKey, cCols, oldValue)
+	if !ok || err != nil {
+		return false, err
+	}
+	return s.storage.CompareAndSwap(pKey, cCols, stored, s.compress(newValue), ttlSeconds)
+}
+
+func (s *compressingAppStorage) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	if expectedValue == nil {
+		return s.storage.CompareAndDelete(pKey, cCols, nil)
+	}
+	stored, ok, err := s.currentStored(pKey, cCols, expectedValue)
+	if !ok || err != nil {
+		return false, err
+	}
+	return s.storage.CompareAndDelete(pKey, cCols, stored)
+}
+
+// returns the current stored value if its decompressed value equals to expected
+func (s *compressingAppStorage) currentStored(pKey []byte, cCols []byte, expected []byte) (stored []byte, ok bool, err error) {
+	stored = []byte{}
+	if ok, err = s.storage.TTLGet(pKey, cCols, &stored); !ok || err != nil {
+		return nil, false, err
+	}
+	value, err := s.decompress(nil, stored)
+	if err != nil {
+		return nil, false, err
+	}
+	return stored, bytes.Equal(value, expected), nil
+}
+
+func (s *compressingAppStorage) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	return s.storage.QueryTTL(pKey, cCols)
+}
+
+func (s *compressingAppStorage) QueryTTLBatch(pKey []byte, items []istorage.QueryTTLBatchItem) (err error) {
+	return istorage.QueryTTLBatch(s.storage, pKey, items)
+}
+
+func (s *compressingAppStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	scanner, ok := s.storage.(istorage.IPKeysScanner)
+	if !ok {
+		return istorage.ErrPKeysScanNotSupported
+	}
+	return scanner.ScanPKeys(ctx, tokens, func(pKey []byte) error {
+		if bytes.Equal(pKey, markerPKey) {
+			return nil
+		}
+		return cb(pKey)
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// encoder and decoder are safe for concurrent EncodeAll and DecodeAll
+var (
+	zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			// notest: options are valid
+			panic(err)
+		}
+		return enc
+	})
+	zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+		dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+		if err != nil {
+			// notest: options are valid
+			panic(err)
+		}
+		return dec
+	})
+)
+
+// returns the stored value: the header and the compressed value
+// the value is stored as is if the compressed one is not shorter
+func compress(algorithm Algorithm, value []byte) []byte {
+	res := []byte(nil)
+	switch algorithm {
+	case Algorithm_Snappy:
+		res = make([]byte, headerSize+snappy.MaxEncodedLen(len(value)))
+		res = res[:headerSize+len(snappy.Encode(res[headerSize:], value))]
+	case Algorithm_Zstd:
+		res = zstdEncoder().EncodeAll(value, make([]byte, headerSize, headerSize+len(value)))
+	}
+	if res == nil || len(res) >= headerSize+len(value) {
+		res = append(make([]byte, headerSize, headerSize+len(value)), value...)
+		algorithm = Algorithm_None
+	}
+	res[0] = byte(algorithm)
+	return res
+}
+
+// appends the value decompressed from the stored one to dst
+func decompress(dst []byte, stored []byte) ([]byte, error) {
+	if len(stored) < headerSize {
+		return nil, ErrEmptyValue
+	}
+	compressed := stored[headerSize:]
+	switch Algorithm(stored[0]) {
+	case Algorithm_None:
+		return append(dst, compressed...), nil
+	case Algorithm_Snappy:
+		n, err := snappy.DecodedLen(compressed)
+		if err != nil {
+			return nil, fmt.Errorf("snappy: %w", err)
+		}
+		dst = slices.Grow(dst, n)
+		if _, err := snappy.Decode(dst[len(dst):len(dst)+n], compressed); err != nil {
+			return nil, fmt.Errorf("snappy: %w", err)
+		}
+		return dst[:len(dst)+n], nil
+	case Algorithm_Zstd:
+		res, err := zstdDecoder().DecodeAll(compressed, dst)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownAlgorithm, stored[0])
+}
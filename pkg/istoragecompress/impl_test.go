@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+)
+
+var testApp = istructs.AppQName_test1_app1
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	storageProvider := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	metrics := imetrics.Provide()
+	compressingProvider := Provide(storageProvider, Params{Apps: map[appdef.AppQName]Algorithm{testApp: Algorithm_Zstd}}, metrics, "vvm")
+
+	storage, err := compressingProvider.AppStorage(testApp)
+	require.NoError(err)
+
+	value := bytes.Repeat([]byte("compressible "), 100)
+	require.NoError(storage.Put([]byte("pKey"), []byte("cCols"), value))
+
+	data := []byte{}
+	ok, err := storage.Get([]byte("pKey"), []byte("cCols"), &data)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(value, data)
+
+	t.Run("value is compressed in the underlying storage", func(t *testing.T) {
+		stored := []byte{}
+		ok, err := underlying(t, storageProvider).Get([]byte("pKey"), []byte("cCols"), &stored)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(byte(Algorithm_Zstd), stored[0])
+		require.Less(len(stored), len(value)/10)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		m := map[string]float64{}
+		require.NoError(metrics.List(func(metric imetrics.IMetric, metricValue float64) error {
+			require.Equal(testApp, metric.App())
+			m[metric.Name()] = metricValue
+			return nil
+		}))
+		require.Equal(float64(1), m[valuesTotal])
+		require.Equal(float64(1), m[compressedValuesTotal])
+		require.Equal(float64(len(value)), m[bytesTotal])
+		require.Less(m[storedBytesTotal], m[bytesTotal]/10)
+		require.Positive(m[decompressSeconds])
+	})
+}
+
+func TestTCK(t *testing.T) {
+	for algorithm := range Algorithm_FakeLast {
+		for _, minSize := range []int{1, DefaultMinSize} {
+			t.Run(fmt.Sprintf("algorithm %d, min size %d", algorithm, minSize), func(t *testing.T) {
+				storageProvider := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+				params := Params{Apps: map[appdef.AppQName]Algorithm{testApp: algorithm}, MinSize: minSize}
+				storage, err := Provide(storageProvider, params, imetrics.Provide(), "vvm").AppStorage(testApp)
+				require.NoError(t, err)
+				istorage.TechnologyCompatibilityKit_Storage(t, storage, testingu.MockTime)
+			})
+		}
+	}
+}
+
+func TestStoredValues(t *testing.T) {
+	require := require.New(t)
+
+	storageProvider := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	newStorage := func(algorithm Algorithm) istorage.IAppStorage {
+		params := Params{Apps: map[appdef.AppQName]Algorithm{testApp: algorithm}}
+		storage, err := Provide(storageProvider, params, imetrics.Provide(), "vvm").AppStorage(testApp)
+		require.NoError(err)
+		return storage
+	}
+	storage := newStorage(Algorithm_Snappy)
+
+	compressible := bytes.Repeat([]byte("compressible "), 100)
+	incompressible := make([]byte, 1000)
+	_, _ = rand.Read(incompressible)
+	small := []byte("small")
+
+	cases := map[string]struct {
+		value     []byte
+		algorithm Algorithm
+	}{
+		"compressible":   {compressible, Algorithm_Snappy},
+		"incompressible": {incompressible, Algorithm_None},
+		"small":          {small, Algorithm_None},
+		"empty":          {[]byte{}, Algorithm_None},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(storage.Put([]byte("pKey"), []byte(name), c.value))
+
+			stored := []byte{}
+			ok, err := underlying(t, storageProvider).Get([]byte("pKey"), []byte(name), &stored)
+			require.NoError(err)
+			require.True(ok)
+			require.Equal(byte(c.algorithm), stored[0])
+
+			data := []byte{}
+			ok, err = storage.Get([]byte("pKey"), []byte(name), &data)
+			require.NoError(err)
+			require.True(ok)
+			require.Equal(c.value, data)
+		})
+	}
+
+	t.Run("values are read after the algorithm is changed", func(t *testing.T) {
+		storage := newStorage(Algorithm_Zstd)
+		require.NoError(storage.Put([]byte("pKey"), []byte("zstd"), compressible))
+
+		read := map[string][]byte{}
+		require.NoError(storage.Read(t.Context(), []byte("pKey"), nil, nil, func(cCols []byte, value []byte) error {
+			read[string(cCols)] = value
+			return nil
+		}))
+		require.Len(read, len(cases)+1)
+		for name, c := range cases {
+			require.Equal(c.value, read[name])
+		}
+		require.Equal(compressible, read["zstd"])
+
+		ok, err := storage.CompareAndSwap([]byte("pKey"), []byte("compressible"), compressible, small, 0)
+		require.NoError(err)
+		require.True(ok)
+		ok, err = storage.CompareAndDelete([]byte("pKey"), []byte("compressible"), small)
+		require.NoError(err)
+		require.True(ok)
+	})
+
+	t.Run("compression is kept enabled for the written application storage", func(t *testing.T) {
+		storage := newStorage(Algorithm_Snappy)
+		data := []byte{}
+		ok, err := storage.Get([]byte("pKey"), []byte("small"), &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(small, data)
+
+		pKeys := []string{}
+		require.NoError(storage.(istorage.IPKeysScanner).ScanPKeys(t.Context(), istorage.FullTokenRange, func(pKey []byte) error {
+			pKeys = append(pKeys, string(pKey))
+			return nil
+		}))
+		require.Equal([]string{"pKey"}, pKeys)
+	})
+
+	t.Run("application is not compressed if absent in params", func(t *testing.T) {
+		storage, err := Provide(storageProvider, Params{}, imetrics.Provide(), "vvm").AppStorage(testApp)
+		require.NoError(err)
+		require.Equal(underlying(t, storageProvider), storage)
+	})
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+	storageProvider := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+
+	t.Run("unknown algorithm in params", func(t *testing.T) {
+		params := Params{Apps: map[appdef.AppQName]Algorithm{testApp: Algorithm_FakeLast}}
+		_, err := Provide(storageProvider, params, imetrics.Provide(), "vvm").AppStorage(testApp)
+		require.ErrorIs(err, ErrUnknownAlgorithm)
+	})
+
+	t.Run("application storage is written without compression", func(t *testing.T) {
+		storageProvider := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+		require.NoError(underlying(t, storageProvider).Put([]byte("pKey"), []byte("cCols"), []byte("value")))
+
+		params := Params{Apps: map[appdef.AppQName]Algorithm{testApp: Algorithm_Snappy}}
+		_, err := Provide(storageProvider, params, imetrics.Provide(), "vvm").AppStorage(testApp)
+		require.ErrorIs(err, ErrAppStorageNotEmpty)
+	})
+
+	params := Params{Apps: map[appdef.AppQName]Algorithm{testApp: Algorithm_Snappy}}
+	storage, err := Provide(storageProvider, params, imetrics.Provide(), "vvm").AppStorage(testApp)
+	require.NoError(err)
+
+	cases := map[string]struct {
+		stored []byte
+		err    error
+	}{
+		"unknown algorithm": {[]byte{byte(Algorithm_FakeLast), 1, 2, 3}, ErrUnknownAlgorithm},
+		"no header":         {[]byte{}, ErrEmptyValue},
+		"wrong snappy":      {[]byte{byte(Algorithm_Snappy), 0xff, 0xff}, nil},
+		"wrong zstd":        {[]byte{byte(Algorithm_Zstd), 0xff, 0xff}, nil},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(underlying(t, storageProvider).Put([]byte("pKey"), []byte(name), c.stored))
+			_, err := storage.Get([]byte("pKey"), []byte(name), &[]byte{})
+			require.Error(err)
+			if c.err != nil {
+				require.ErrorIs(err, c.err)
+			}
+		})
+	}
+}
+
+func underlying(t *testing.T, storageProvider istorage.IAppStorageProvider) istorage.IAppStorage {
+	storage, err := storageProvider.AppStorage(testApp)
+	require.NoError(t, err)
+	return storage
+}
@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+import (
+	"github.com/voedger/voedger/pkg/istorage"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+)
+
+// Provide returns the storage provider which compresses values of storages of applications from params.Apps
+// should wrap the not caching provider, so the cache keeps not compressed values
+// storages of applications which are written without the compression are not provided, see ErrAppStorageNotEmpty
+func Provide(storageProvider istorage.IAppStorageProvider, params Params, metrics imetrics.IMetrics, vvmName string) istorage.IAppStorageProvider {
+	if params.MinSize <= 0 {
+		params.MinSize = DefaultMinSize
+	}
+	return &implCompressingAppStorageProvider{
+		storageProvider: storageProvider,
+		params:          params,
+		metrics:         metrics,
+		vvmName:         vvmName,
+	}
+}
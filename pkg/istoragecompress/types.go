@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istoragecompress
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istorage"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+)
+
+// Algorithm is written as the header byte of each stored value
+// values are decompressed according to the header, so the algorithm of the application could be changed at any time
+type Algorithm byte
+
+const (
+	// value is stored as is, with the header
+	Algorithm_None Algorithm = iota
+	Algorithm_Snappy
+	Algorithm_Zstd
+	Algorithm_FakeLast
+)
+
+type Params struct {
+	// values of the application are compressed by the algorithm
+	// values of applications absent in the map are stored as is, without the header
+	// the application could not be excluded from the map once values are written
+	// the application could not be added to the map once values are written, ErrAppStorageNotEmpty is returned by AppStorage
+	Apps map[appdef.AppQName]Algorithm
+
+	// DefaultMinSize is used if zero
+	MinSize int
+}
+
+type implCompressingAppStorageProvider struct {
+	storageProvider istorage.IAppStorageProvider
+	params          Params
+	metrics         imetrics.IMetrics
+	vvmName         string
+}
+
+type compressingAppStorage struct {
+	storage   istorage.IAppStorage
+	algorithm Algorithm
+	minSize   int
+
+	/* metrics */
+	mValuesTotal           *imetrics.MetricValue
+	mCompressedValuesTotal *imetrics.MetricValue
+	mBytesTotal            *imetrics.MetricValue
+	mStoredBytesTotal      *imetrics.MetricValue
+	mDecompressSeconds     *imetrics.MetricValue
+}
@@ -214,6 +214,23 @@ type IViewRecords interface {
 	Read(ctx context.Context, workspace WSID, key IKeyBuilder, cb ValuesCallback) (err error)
 }
 
+// Optional, implemented by IViewRecords if the application storage is able to enumerate partition keys
+type IWorkspaceViewsReader interface {
+	// Calls cb for each record of each application view of the workspace. System views are not read
+	// All partition keys of the application storage are scanned, so the method is expensive
+	ReadWorkspaceViews(ctx context.Context, workspace WSID, cb ValuesCallback) (err error)
+}
+
+// Optional, implemented by IViewRecords if the application storage is able to read ranges of clustering columns
+type IViewRecordsRangeReader interface {
+	// Calls cb for each view record which clustering columns are between from and to keys, both inclusive.
+	// From and to keys must have the same partition key, clustering columns of both keys may be specified partially.
+	// Records which clustering columns start with the to clustering columns are read also.
+	// If from has no clustering columns, then reading is started from the partition start.
+	// If to has no clustering columns, then reading is finished at the partition end
+	ReadRange(ctx context.Context, workspace WSID, from, to IKeyBuilder, cb ValuesCallback) (err error)
+}
+
 type ViewRecordGetBatchItem struct {
 	Key   IKeyBuilder // in
 	Ok    bool        // out
@@ -4,11 +4,17 @@
 
 package istructs
 
-import "github.com/voedger/voedger/pkg/appdef"
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
 
 type Projector struct {
 	Name appdef.QName
 	Func func(event IPLogEvent, state IState, intents IIntents) (err error)
+	// CtxFunc is called instead of Func if set, ctx is done when the projector is stopped
+	CtxFunc func(ctx context.Context, event IPLogEvent, state IState, intents IIntents) (err error)
 }
 
 type Projectors map[appdef.QName]Projector
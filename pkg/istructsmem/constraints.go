@@ -13,6 +13,7 @@ import (
 	"sort"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
 )
 
 // Checks value by field constraints. Return error if constraints violated
@@ -22,6 +23,8 @@ func checkConstraints(fld appdef.IField, value any) (err error) {
 		err = checkCharsConstraints(fld, value.(string))
 	case appdef.DataKind_bytes:
 		err = checkCharsConstraints(fld, value.([]byte))
+	case appdef.DataKind_json:
+		err = checkCharsConstraints(fld, value.(string))
 	case appdef.DataKind_int8:
 		err = checkNumberConstraints(fld, value.(int8))
 	case appdef.DataKind_int16:
@@ -34,6 +37,8 @@ func checkConstraints(fld appdef.IField, value any) (err error) {
 		err = checkNumberConstraints(fld, value.(float32))
 	case appdef.DataKind_float64:
 		err = checkNumberConstraints(fld, value.(float64))
+	case appdef.DataKind_decimal:
+		err = checkDecimalConstraints(fld, value.(int64))
 	}
 	return err
 }
@@ -80,8 +85,12 @@ func checkCharsConstraints[T chars](fld appdef.IField, value T) (err error) {
 	}
 
 	if !maxLenChecked {
-		if len(value) > int(appdef.DefaultFieldMaxLength) {
-			err = errors.Join(err, ErrDataConstraintViolation(fld, fmt.Sprintf("default MaxLen: %d", appdef.DefaultFieldMaxLength)))
+		maxLen := appdef.DefaultFieldMaxLength
+		if fld.DataKind() == appdef.DataKind_json {
+			maxLen = appdef.MaxFieldLength
+		}
+		if len(value) > int(maxLen) {
+			err = errors.Join(err, ErrDataConstraintViolation(fld, fmt.Sprintf("default MaxLen: %d", maxLen)))
 		}
 	}
 
@@ -93,6 +102,20 @@ type number = interface {
 	int8 | int16 | int32 | int64 | float32 | float64
 }
 
+// Checks unscaled decimal value by decimal field constraints. Return error if constraints violated
+func checkDecimalConstraints(fld appdef.IField, unscaled int64) (err error) {
+	cc := fld.Constraints()
+	precision, scale := appdef.DecimalPrecisionScale(cc)
+	if utils.DecimalDigits(unscaled) > precision {
+		if c, ok := cc[appdef.ConstraintKind_Precision]; ok {
+			err = ErrDataConstraintViolation(fld, c)
+		} else {
+			err = ErrDataConstraintViolation(fld, fmt.Sprintf("default Precision: %d", appdef.DefaultDecimalPrecision))
+		}
+	}
+	return errors.Join(err, checkNumberConstraints(fld, utils.DecimalToFloat64(unscaled, scale)))
+}
+
 func checkNumberConstraints[T number](fld appdef.IField, value T) (err error) {
 	for k, c := range fld.Constraints() {
 		switch k {
@@ -84,7 +84,14 @@ func Test_checkConstraints(t *testing.T) {
 				constraints.MaxExcl(9)).
 			AddField("float64_e", appdef.DataKind_float64, false,
 				constraints.MinExcl(0),
-				constraints.MaxExcl(9))
+				constraints.MaxExcl(9)).
+			// decimal fields to test precision and range constraints
+			AddField("decimal", appdef.DataKind_decimal, false).
+			AddField("decimal_i", appdef.DataKind_decimal, false,
+				constraints.Precision(5),
+				constraints.Scale(2),
+				constraints.MinIncl(1),
+				constraints.MaxIncl(8))
 
 		app, err := adb.Build()
 		require.NoError(err)
@@ -157,6 +164,13 @@ func Test_checkConstraints(t *testing.T) {
 		{"float64_i: enum", args{"float64_i", math.E}, "Enum: [1 3.14159265358"},
 		{"float64_i: ok", args{"float64_i", math.Pi}, ""},
 		//-
+		{"decimal: default precision", args{"decimal", int64(math.MaxInt64)}, "default Precision: 18"},
+		{"decimal: ok", args{"decimal", int64(999999999999999999)}, ""},
+		{"decimal_i: precision", args{"decimal_i", int64(100000)}, "Precision: 5"},
+		{"decimal_i: min inclusive", args{"decimal_i", int64(99)}, "MinIncl: 1"},
+		{"decimal_i: max inclusive", args{"decimal_i", int64(801)}, "MaxIncl: 8"},
+		{"decimal_i: ok", args{"decimal_i", int64(800)}, ""},
+		//-
 	}
 
 	for _, tt := range tests {
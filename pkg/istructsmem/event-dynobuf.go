@@ -324,7 +324,9 @@ func loadEventCUD(rec *recordType, codecVer byte, buf *bytes.Buffer) error {
 					return ErrOutOfBounds("emptied field[%d] index %d should be less than %d", i, idx, len)
 				}
 				f := fields[idx]
-				if k := f.DataKind(); k != appdef.DataKind_string && k != appdef.DataKind_bytes {
+				switch f.DataKind() {
+				case appdef.DataKind_string, appdef.DataKind_bytes, appdef.DataKind_uuid, appdef.DataKind_json:
+				default:
 					return ErrWrongType("emptied %v should be string- (or []byte-) field", f)
 				}
 				rec.checkPutNil(f, nil)
@@ -8,6 +8,7 @@ package istructsmem
 import (
 	"context"
 	"encoding/binary"
+	"slices"
 	"strconv"
 	"sync"
 
@@ -201,30 +202,39 @@ func (app *appStructsType) QNameID(qName appdef.QName) (istructs.QNameID, error)
 }
 
 func (app *appStructsType) IsFunctionRateLimitsExceeded(funcQName appdef.QName, wsid istructs.WSID) bool {
-	rateLimits, ok := app.config.FunctionRateLimits.limits[funcQName]
-	if !ok {
-		return false
+	exceeded, _ := app.FunctionRateLimitsExceeded(funcQName, wsid)
+	return exceeded
+}
+
+// returns the state of the exceeded bucket if the function rate limits are exceeded
+func (app *appStructsType) FunctionRateLimitsExceeded(funcQName appdef.QName, wsid istructs.WSID) (exceeded bool, state irates.BucketState) {
+	keys := app.config.FunctionRateLimits.bucketKeys(app.config.Name, funcQName, wsid)
+	if len(keys) == 0 {
+		return false, state
 	}
-	keys := []irates.BucketKey{}
-	for rlKind := range rateLimits {
-		key := irates.BucketKey{
-			QName:         funcQName,
-			RateLimitName: GetFunctionRateLimitName(funcQName, rlKind),
-		}
-		// already checked for unsupported kind on appStructs.prepare() stage
-		switch rlKind {
-		case istructs.RateLimitKind_byApp:
-			key.App = app.config.Name
-		case istructs.RateLimitKind_byWorkspace:
-			key.Workspace = wsid
-		case istructs.RateLimitKind_byID:
-			// skip
-			continue
+	ok, excLimit := app.buckets.TakeTokens(keys, 1)
+	if ok {
+		return false, state
+	}
+	for _, key := range keys {
+		if key.RateLimitName == excLimit {
+			state, _ = app.buckets.GetBucketState(key)
+			break
 		}
-		keys = append(keys, key)
 	}
-	ok, _ = app.buckets.TakeTokens(keys, 1)
-	return !ok
+	return true, state
+}
+
+// bucket keys of all function rate limits checked on calls in the workspace, ordered by rate limit name
+func (app *appStructsType) FunctionRateLimitsBucketKeys(wsid istructs.WSID) []irates.BucketKey {
+	keys := []irates.BucketKey{}
+	for funcQName := range app.config.FunctionRateLimits.limits {
+		keys = append(keys, app.config.FunctionRateLimits.bucketKeys(app.config.Name, funcQName, wsid)...)
+	}
+	slices.SortFunc(keys, func(a, b irates.BucketKey) int {
+		return appdef.CompareQName(a.RateLimitName, b.RateLimitName)
+	})
+	return keys
 }
 
 // istructs.IAppStructs.SyncProjectors
@@ -43,6 +43,15 @@ func (s *Structure) read(str appdef.IStructure) {
 		s.UniqueField = uf.Name()
 	}
 
+	if rec, ok := str.(appdef.IRecord); ok && rec.IndexCount() > 0 {
+		s.Indexes = make(map[string]*Index)
+		for _, index := range rec.Indexes() {
+			i := newIndex()
+			i.read(index)
+			s.Indexes[index.Name().String()] = i
+		}
+	}
+
 	if singleton, ok := str.(appdef.ISingleton); ok {
 		if singleton.Singleton() {
 			s.Singleton = true
@@ -92,3 +101,14 @@ func (u *Unique) read(unique appdef.IUnique) {
 		u.Fields = append(u.Fields, f.Name())
 	}
 }
+
+func newIndex() *Index { return &Index{} }
+
+func (i *Index) read(index appdef.IIndex) {
+	i.Comment = readComment(index)
+
+	i.Name = index.Name()
+	for _, f := range index.Fields() {
+		i.Fields = append(i.Fields, f.Name())
+	}
+}
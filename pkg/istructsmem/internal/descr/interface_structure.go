@@ -16,6 +16,7 @@ type Structure struct {
 	Containers  []*Container       `json:",omitempty"`
 	Uniques     map[string]*Unique `json:",omitempty"`
 	UniqueField appdef.FieldName   `json:",omitempty"`
+	Indexes     map[string]*Index  `json:",omitempty"`
 	Singleton   bool               `json:",omitempty"`
 }
 
@@ -42,3 +43,9 @@ type Unique struct {
 	Name    appdef.QName `json:"-"`
 	Fields  []appdef.FieldName
 }
+
+type Index struct {
+	Comment string       `json:",omitempty"`
+	Name    appdef.QName `json:"-"`
+	Fields  []appdef.FieldName
+}
@@ -11,20 +11,26 @@ import (
 )
 
 var dataKindToDynoFieldType = map[appdef.DataKind]dynobuffers.FieldType{
-	appdef.DataKind_null:     dynobuffers.FieldTypeUnspecified,
-	appdef.DataKind_int8:     dynobuffers.FieldTypeByte,  // #3434 [small integers : int8]
-	appdef.DataKind_int16:    dynobuffers.FieldTypeInt16, // #3434 [small integers : int16]
-	appdef.DataKind_int32:    dynobuffers.FieldTypeInt32,
-	appdef.DataKind_int64:    dynobuffers.FieldTypeInt64,
-	appdef.DataKind_float32:  dynobuffers.FieldTypeFloat32,
-	appdef.DataKind_float64:  dynobuffers.FieldTypeFloat64,
-	appdef.DataKind_bytes:    dynobuffers.FieldTypeByte,
-	appdef.DataKind_string:   dynobuffers.FieldTypeString,
-	appdef.DataKind_QName:    dynobuffers.FieldTypeByte, // two fixed bytes LittleEndian
-	appdef.DataKind_bool:     dynobuffers.FieldTypeBool,
-	appdef.DataKind_RecordID: dynobuffers.FieldTypeInt64,
-	appdef.DataKind_Record:   dynobuffers.FieldTypeByte,
-	appdef.DataKind_Event:    dynobuffers.FieldTypeByte,
+	appdef.DataKind_null:      dynobuffers.FieldTypeUnspecified,
+	appdef.DataKind_int8:      dynobuffers.FieldTypeByte,  // #3434 [small integers : int8]
+	appdef.DataKind_int16:     dynobuffers.FieldTypeInt16, // #3434 [small integers : int16]
+	appdef.DataKind_int32:     dynobuffers.FieldTypeInt32,
+	appdef.DataKind_int64:     dynobuffers.FieldTypeInt64,
+	appdef.DataKind_float32:   dynobuffers.FieldTypeFloat32,
+	appdef.DataKind_float64:   dynobuffers.FieldTypeFloat64,
+	appdef.DataKind_bytes:     dynobuffers.FieldTypeByte,
+	appdef.DataKind_string:    dynobuffers.FieldTypeString,
+	appdef.DataKind_QName:     dynobuffers.FieldTypeByte, // two fixed bytes LittleEndian
+	appdef.DataKind_bool:      dynobuffers.FieldTypeBool,
+	appdef.DataKind_RecordID:  dynobuffers.FieldTypeInt64,
+	appdef.DataKind_Record:    dynobuffers.FieldTypeByte,
+	appdef.DataKind_Event:     dynobuffers.FieldTypeByte,
+	appdef.DataKind_decimal:   dynobuffers.FieldTypeInt64,  // unscaled value
+	appdef.DataKind_timestamp: dynobuffers.FieldTypeInt64,  // Unix milliseconds
+	appdef.DataKind_date:      dynobuffers.FieldTypeInt64,  // Unix milliseconds of UTC midnight
+	appdef.DataKind_interval:  dynobuffers.FieldTypeInt64,  // milliseconds
+	appdef.DataKind_uuid:      dynobuffers.FieldTypeByte,   // 16 bytes
+	appdef.DataKind_json:      dynobuffers.FieldTypeString, // compact JSON text
 }
 
 const (
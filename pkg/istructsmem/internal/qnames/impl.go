@@ -86,6 +86,12 @@ func (names *QNames) collectAll(appDef appdef.IAppDef) error {
 						names.collect(u.Name()))
 				}
 			}
+			if ii, ok := t.(appdef.IWithIndexes); ok {
+				for _, i := range ii.Indexes() {
+					err = errors.Join(err,
+						names.collect(i.Name()))
+				}
+			}
 		}
 	}
 
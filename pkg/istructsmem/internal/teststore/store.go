@@ -293,3 +293,7 @@ func (s *TestMemStorage) Read(ctx context.Context, pKey []byte, startCCols, fini
 
 	return s.storage.Read(ctx, pKey, startCCols, finishCCols, cbWrap)
 }
+
+func (s *TestMemStorage) ScanPKeys(ctx context.Context, tokens istorage.TokenRange, cb istorage.PKeyCallback) (err error) {
+	return s.storage.(istorage.IPKeysScanner).ScanPKeys(ctx, tokens, cb)
+}
@@ -74,6 +74,12 @@ func WriteInt64(buf *bytes.Buffer, value int64) {
 	buf.Write(s)
 }
 
+// Write int64 to buf in sortable form: sign bit is inverted, so
+// negative values are ordered before positive values in byte-wise comparison
+func WriteSortableInt64(buf *bytes.Buffer, value int64) {
+	WriteUint64(buf, uint64(value)^sortableInt64SignBit) // nolint G115 two's complement bits are preserved
+}
+
 // Write uint64 to buf
 func WriteUint64(buf *bytes.Buffer, value uint64) {
 	s := []byte{0, 0, 0, 0, 0, 0, 0, 0}
@@ -235,6 +241,15 @@ func ReadInt64(buf *bytes.Buffer) (int64, error) {
 	return BigEndianInt64(buf.Next(size)), nil
 }
 
+// Reads int64 stored by WriteSortableInt64 from buf
+func ReadSortableInt64(buf *bytes.Buffer) (int64, error) {
+	v, err := ReadUInt64(buf)
+	if err != nil {
+		return 0, err
+	}
+	return int64(v ^ sortableInt64SignBit), nil // nolint G115 two's complement bits are preserved
+}
+
 // Reads uint64 from buf
 func ReadUInt64(buf *bytes.Buffer) (uint64, error) {
 	const size = 8
@@ -367,3 +382,5 @@ func BigEndianInt64(b []byte) int64 {
 	return int64(b[7]) | int64(b[6])<<8 | int64(b[5])<<16 | int64(b[4])<<24 |
 		int64(b[3])<<32 | int64(b[2])<<40 | int64(b[1])<<48 | int64(b[0])<<56
 }
+
+const sortableInt64SignBit = uint64(1) << 63
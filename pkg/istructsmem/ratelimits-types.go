@@ -48,6 +48,28 @@ func (frl *functionRateLimits) prepare(buckets irates.IBuckets) {
 	}
 }
 
+func (frl *functionRateLimits) bucketKeys(app appdef.AppQName, funcQName appdef.QName, wsid istructs.WSID) []irates.BucketKey {
+	keys := []irates.BucketKey{}
+	for rlKind := range frl.limits[funcQName] {
+		// app is the part of each key since buckets could be shared among apps, see iratesstg
+		key := irates.BucketKey{
+			QName:         funcQName,
+			RateLimitName: GetFunctionRateLimitName(funcQName, rlKind),
+			App:           app,
+		}
+		// already checked for unsupported kind on appStructs.prepare() stage
+		switch rlKind {
+		case istructs.RateLimitKind_byWorkspace:
+			key.Workspace = wsid
+		case istructs.RateLimitKind_byID:
+			// skip
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 func GetFunctionRateLimitName(funcQName appdef.QName, rateLimitKind istructs.RateLimitKind) (res appdef.QName) {
 	if rateLimitKind >= istructs.RateLimitKind_FakeLast {
 		panic(fmt.Sprintf("unsupported limit kind %v", rateLimitKind))
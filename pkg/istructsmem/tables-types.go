@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -93,6 +94,12 @@ func (row *rowType) fieldValue(f appdef.IField) any {
 		return row.AsRecord(n)
 	case appdef.DataKind_Event:
 		return row.AsEvent(n)
+	case appdef.DataKind_decimal:
+		return row.AsString(n)
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		return row.AsString(n)
+	case appdef.DataKind_uuid, appdef.DataKind_json:
+		return row.AsString(n)
 	}
 	// notest: fullcase switch
 	panic(ErrWrongFieldType("%v", f))
@@ -203,6 +210,12 @@ func nilToZeroValue(kind appdef.DataKind) any {
 		return false
 	case appdef.DataKind_bytes:
 		return []byte{}
+	case appdef.DataKind_decimal:
+		return "0"
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		return coreutils.FormatTemporal(kind, 0)
+	case appdef.DataKind_uuid, appdef.DataKind_json:
+		return ""
 	default:
 		panic(fmt.Sprintf("unsupported nilled field kind: %s", kind))
 	}
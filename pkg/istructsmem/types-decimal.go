@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istructsmem
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+// Returns decimal scale of specified field
+func decimalScale(fld appdef.IField) uint8 {
+	_, scale := appdef.DecimalPrecisionScale(fld.Constraints())
+	return scale
+}
+
+// Returns exact string for unscaled decimal value of specified field
+func formatDecimal(fld appdef.IField, unscaled int64) string {
+	return utils.FormatDecimal(unscaled, decimalScale(fld))
+}
+
+// Returns float64 approximation for unscaled decimal value of specified field
+func decimalToFloat64(fld appdef.IField, unscaled int64) float64 {
+	return utils.DecimalToFloat64(unscaled, decimalScale(fld))
+}
+
+// Parses specified decimal string and puts unscaled value into decimal field
+func (row *rowType) putDecimal(fld appdef.IField, value string) {
+	unscaled, err := utils.ParseDecimal(value, decimalScale(fld))
+	if err != nil {
+		row.collectError(enrichError(err, "can not put value to %v", fld))
+		return
+	}
+	row.putValue(fld.Name(), appdef.DataKind_decimal, unscaled)
+}
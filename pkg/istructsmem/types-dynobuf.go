@@ -54,7 +54,7 @@ func (row *rowType) clarifyJSONValue(value any, kind appdef.DataKind) (res any,
 		case json.Number:
 			return coreutils.ClarifyJSONNumber(v, kind)
 		}
-	case appdef.DataKind_int64:
+	case appdef.DataKind_int64, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
 		switch v := value.(type) {
 		case int64:
 			return v, nil
@@ -103,6 +103,18 @@ func (row *rowType) clarifyJSONValue(value any, kind appdef.DataKind) (res any,
 		case string:
 			return v, nil
 		}
+	case appdef.DataKind_uuid:
+		switch v := value.(type) {
+		case string:
+			return parseUUID(v)
+		case []byte:
+			return v, nil
+		}
+	case appdef.DataKind_json:
+		switch v := value.(type) {
+		case string:
+			return compactJSON(v)
+		}
 	case appdef.DataKind_QName:
 		switch v := value.(type) {
 		case string:
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istructsmem
+
+import (
+	"encoding/json"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+// Validates specified JSON text and returns it in compact form
+func compactJSON(s string) (string, error) {
+	return utils.CompactJSON(s)
+}
+
+// Validates specified JSON text and puts it in compact form into json field.
+//
+// Empty string clears the field
+func (row *rowType) putJSON(fld appdef.IField, value string) {
+	if value != "" {
+		compact, err := compactJSON(value)
+		if err != nil {
+			row.collectError(enrichError(err, "can not put value to %v", fld))
+			return
+		}
+		value = compact
+	}
+	row.putValue(fld.Name(), appdef.DataKind_json, value)
+}
+
+// Puts specified value, decoded from JSON, into json field.
+//
+// String values and json.RawMessage are treated as JSON text, nil clears the field,
+// other values (objects, arrays, numbers and booleans) are embedded JSON values
+func (row *rowType) putJSONValue(fld appdef.IField, value any) {
+	switch v := value.(type) {
+	case nil:
+		row.putJSON(fld, "")
+	case string:
+		row.putJSON(fld, v)
+	case json.RawMessage:
+		row.putJSON(fld, string(v))
+	case []byte:
+		// happens e.g. on IRowWriter.PutJSON() after read from the storage
+		row.putJSON(fld, string(v))
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			row.collectError(enrichError(err, "can not put %T to %v", value, fld))
+			return
+		}
+		row.putJSON(fld, string(b))
+	}
+}
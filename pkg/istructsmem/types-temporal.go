@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istructsmem
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+// Returns RFC 3339 or ISO 8601 duration string for milliseconds value of specified temporal field
+func formatTemporal(fld appdef.IField, ms int64) string {
+	return coreutils.FormatTemporal(fld.DataKind(), ms)
+}
+
+// Parses specified RFC 3339 or ISO 8601 duration string and puts milliseconds into temporal field
+func (row *rowType) putTemporal(fld appdef.IField, value string) {
+	ms, err := coreutils.ParseTemporal(fld.DataKind(), value)
+	if err != nil {
+		row.collectError(enrichError(err, "can not put value to %v", fld))
+		return
+	}
+	row.putValue(fld.Name(), fld.DataKind(), ms)
+}
+
+// Returns milliseconds to be stored into field.
+//
+// For date fields time of day is truncated
+func temporalValue(fld appdef.IField, ms int64) int64 {
+	if fld.DataKind() == appdef.DataKind_date {
+		return utils.TruncateToDate(ms)
+	}
+	return ms
+}
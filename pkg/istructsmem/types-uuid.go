@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istructsmem
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+// UUID field value length in bytes
+const uuidSize = utils.UUIDSize
+
+// Returns canonical lowercase string for 16 bytes of UUID
+func formatUUID(b []byte) string {
+	return utils.FormatUUID(b)
+}
+
+// Parses specified canonical UUID string and returns 16 bytes of UUID
+func parseUUID(s string) ([]byte, error) {
+	return utils.ParseUUID(s)
+}
+
+// Parses specified canonical UUID string and puts 16 bytes into uuid field.
+//
+// Empty string clears the field
+func (row *rowType) putUUID(fld appdef.IField, value string) {
+	if value == "" {
+		row.putValue(fld.Name(), appdef.DataKind_uuid, []byte{})
+		return
+	}
+	b, err := parseUUID(value)
+	if err != nil {
+		row.collectError(enrichError(err, "can not put value to %v", fld))
+		return
+	}
+	row.putValue(fld.Name(), appdef.DataKind_uuid, b)
+}
+
+// Puts specified 16 bytes into uuid field.
+//
+// Empty bytes clears the field
+func (row *rowType) putUUIDBytes(fld appdef.IField, value []byte) {
+	if l := len(value); l != 0 && l != uuidSize {
+		row.collectError(ErrWrongType("can not put %d bytes to %v, expected %d bytes", l, fld, uuidSize))
+		return
+	}
+	row.putValue(fld.Name(), appdef.DataKind_uuid, value)
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/untillpro/dynobuffers"
 
@@ -95,11 +96,11 @@ func (row *rowType) checkPutNil(field appdef.IField, value any) {
 	isNil := false
 
 	switch field.DataKind() {
-	case appdef.DataKind_string:
+	case appdef.DataKind_string, appdef.DataKind_json:
 		if value == nil || len(value.(string)) == 0 {
 			isNil = true
 		}
-	case appdef.DataKind_bytes:
+	case appdef.DataKind_bytes, appdef.DataKind_uuid:
 		if value == nil || len(value.([]byte)) == 0 {
 			isNil = true
 		}
@@ -559,8 +560,13 @@ func (row *rowType) AsInt32(name appdef.FieldName) (value int32) {
 }
 
 // istructs.IRowReader.AsInt64
+//
+// For decimal fields returns unscaled value.
+//
+// For timestamp, date and interval fields returns milliseconds
 func (row *rowType) AsInt64(name appdef.FieldName) (value int64) {
-	fld := row.fieldMustExists(name, appdef.DataKind_int64, appdef.DataKind_RecordID)
+	fld := row.fieldMustExists(name, appdef.DataKind_int64, appdef.DataKind_RecordID, appdef.DataKind_decimal,
+		appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval)
 
 	if fld.DataKind() == appdef.DataKind_RecordID {
 		switch name {
@@ -590,7 +596,8 @@ func (row *rowType) AsFloat32(name appdef.FieldName) (value float32) {
 func (row *rowType) AsFloat64(name appdef.FieldName) (value float64) {
 	fld := row.fieldMustExists(name, appdef.DataKind_float64,
 		appdef.DataKind_int8, appdef.DataKind_int16, // #3435 [~server.vsql.smallints/cmp.istructsmem~impl]
-		appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_RecordID)
+		appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_float32, appdef.DataKind_RecordID,
+		appdef.DataKind_decimal)
 	switch fld.DataKind() {
 	case appdef.DataKind_int8: // #3435 [~server.vsql.smallints/cmp.istructsmem~impl]
 		if value, ok := row.dyB.GetByte(name); ok {
@@ -626,13 +633,19 @@ func (row *rowType) AsFloat64(name appdef.FieldName) (value float64) {
 		if value, ok := row.dyB.GetFloat64(name); ok {
 			return value
 		}
+	case appdef.DataKind_decimal:
+		if value, ok := row.dyB.GetInt64(name); ok {
+			return decimalToFloat64(fld, value)
+		}
 	}
 	return 0
 }
 
 // istructs.IRowReader.AsBytes
+//
+// For uuid fields returns 16 bytes of UUID
 func (row *rowType) AsBytes(name appdef.FieldName) (value []byte) {
-	_ = row.fieldMustExists(name, appdef.DataKind_bytes)
+	_ = row.fieldMustExists(name, appdef.DataKind_bytes, appdef.DataKind_uuid)
 	if bytes := row.dyB.GetByteArray(name); bytes != nil {
 		return bytes.Bytes()
 	}
@@ -641,12 +654,37 @@ func (row *rowType) AsBytes(name appdef.FieldName) (value []byte) {
 }
 
 // istructs.IRowReader.AsString
+//
+// For decimal fields returns exact decimal string, e.g. "123.45".
+//
+// For timestamp and date fields returns RFC 3339 string, e.g. "2025-01-31T10:20:30.123Z" or "2025-01-31",
+// for interval fields returns ISO 8601 duration string, e.g. "PT1H30M".
+//
+// For uuid fields returns canonical lowercase string, e.g. "123e4567-e89b-12d3-a456-426614174000".
+//
+// For json fields returns compact JSON text
 func (row *rowType) AsString(name appdef.FieldName) (value string) {
 	if name == appdef.SystemField_Container {
 		return row.container
 	}
 
-	_ = row.fieldMustExists(name, appdef.DataKind_string)
+	fld := row.fieldMustExists(name, appdef.DataKind_string, appdef.DataKind_decimal,
+		appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval,
+		appdef.DataKind_uuid, appdef.DataKind_json)
+
+	switch fld.DataKind() {
+	case appdef.DataKind_uuid:
+		if bytes := row.dyB.GetByteArray(name); bytes != nil {
+			return formatUUID(bytes.Bytes())
+		}
+		return ""
+	case appdef.DataKind_decimal:
+		unscaled, _ := row.dyB.GetInt64(name)
+		return formatDecimal(fld, unscaled)
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		ms, _ := row.dyB.GetInt64(name)
+		return formatTemporal(fld, ms)
+	}
 
 	if value, ok := row.dyB.GetString(name); ok {
 		return value
@@ -840,7 +878,15 @@ func (row *rowType) PutInt32(name appdef.FieldName, value int32) {
 }
 
 // istructs.IRowWriter.PutInt64
+//
+// For decimal fields value is unscaled, e.g. 12345 is 123.45 for decimal with scale 2.
+//
+// For timestamp, date and interval fields value is milliseconds, time of day is truncated for date fields
 func (row *rowType) PutInt64(name appdef.FieldName, value int64) {
+	if fld := row.fieldDef(name); (fld != nil) && fld.DataKind().IsTemporal() {
+		row.putValue(name, fld.DataKind(), temporalValue(fld, value))
+		return
+	}
 	row.putValue(name, appdef.DataKind_int64, value)
 }
 
@@ -851,6 +897,10 @@ func (row *rowType) PutFloat32(name appdef.FieldName, value float32) {
 
 // istructs.IRowWriter.PutFloat64
 func (row *rowType) PutFloat64(name appdef.FieldName, value float64) {
+	if fld := row.fieldDef(name); (fld != nil) && (fld.DataKind() == appdef.DataKind_decimal) {
+		row.putDecimal(fld, strconv.FormatFloat(value, 'f', -1, 64))
+		return
+	}
 	row.putValue(name, appdef.DataKind_float64, value)
 }
 
@@ -879,6 +929,10 @@ func (row *rowType) PutFromJSON(j map[appdef.FieldName]any) {
 	}
 
 	for n, v := range j {
+		if fld := row.fieldDef(n); (fld != nil) && (fld.DataKind() == appdef.DataKind_json) {
+			row.putJSONValue(fld, v)
+			continue
+		}
 		switch fv := v.(type) {
 		case float64:
 			row.PutFloat64(n, fv)
@@ -921,6 +975,14 @@ func (row *rowType) PutNumber(name appdef.FieldName, value json.Number) {
 		row.collectError(ErrFieldNotFound(name, row))
 		return
 	}
+	switch fld.DataKind() {
+	case appdef.DataKind_decimal:
+		row.putDecimal(fld, value.String())
+		return
+	case appdef.DataKind_json:
+		row.putJSON(fld, value.String())
+		return
+	}
 	clarifiedVal, err := row.clarifyJSONValue(value, fld.DataKind())
 	if err != nil {
 		row.collectError(enrichError(err, "can not put %T to %v", value, fld))
@@ -933,7 +995,7 @@ func (row *rowType) PutNumber(name appdef.FieldName, value json.Number) {
 		row.PutInt16(name, clarifiedVal.(int16))
 	case appdef.DataKind_int32:
 		row.PutInt32(name, clarifiedVal.(int32))
-	case appdef.DataKind_int64:
+	case appdef.DataKind_int64, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
 		row.PutInt64(name, clarifiedVal.(int64))
 	case appdef.DataKind_float32:
 		row.PutFloat32(name, clarifiedVal.(float32))
@@ -948,16 +1010,47 @@ func (row *rowType) PutNumber(name appdef.FieldName, value json.Number) {
 }
 
 // istructs.IRowWriter.PutBytes
+//
+// For uuid fields value should be 16 bytes of UUID
 func (row *rowType) PutBytes(name appdef.FieldName, value []byte) {
+	if fld := row.fieldDef(name); (fld != nil) && (fld.DataKind() == appdef.DataKind_uuid) {
+		row.putUUIDBytes(fld, value)
+		return
+	}
 	row.putValue(name, appdef.DataKind_bytes, value)
 }
 
 // istructs.IRowWriter.PutString
+//
+// For decimal fields value is parsed as exact decimal string, e.g. "123.45".
+//
+// For timestamp and date fields value is parsed as RFC 3339 string,
+// for interval fields value is parsed as ISO 8601 duration string.
+//
+// For uuid fields value is parsed as canonical UUID string.
+//
+// For json fields value is validated and compacted JSON text
 func (row *rowType) PutString(name appdef.FieldName, value string) {
 	if name == appdef.SystemField_Container {
 		row.setContainer(value)
 		return
 	}
+	if fld := row.fieldDef(name); fld != nil {
+		switch k := fld.DataKind(); {
+		case k == appdef.DataKind_decimal:
+			row.putDecimal(fld, value)
+			return
+		case k.IsTemporal():
+			row.putTemporal(fld, value)
+			return
+		case k == appdef.DataKind_uuid:
+			row.putUUID(fld, value)
+			return
+		case k == appdef.DataKind_json:
+			row.putJSON(fld, value)
+			return
+		}
+	}
 	row.putValue(name, appdef.DataKind_string, value)
 }
 
@@ -1000,7 +1093,8 @@ func (row *rowType) PutChars(name appdef.FieldName, value string) {
 			return
 		}
 		row.PutBytes(name, bytes)
-	case appdef.DataKind_string:
+	case appdef.DataKind_string, appdef.DataKind_decimal, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval,
+		appdef.DataKind_uuid, appdef.DataKind_json:
 		row.PutString(name, value)
 	case appdef.DataKind_QName:
 		qName, err := appdef.ParseQName(value)
@@ -8,6 +8,7 @@ package istructsmem
 import (
 	"encoding/binary"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appdef/builder"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 
@@ -106,3 +107,19 @@ func IBucketsFromIAppStructs(as istructs.IAppStructs) irates.IBuckets {
 	// appStructs implementation has method Buckets()
 	return as.(interface{ Buckets() irates.IBuckets }).Buckets()
 }
+
+// takes tokens as IsFunctionRateLimitsExceeded() does, the state of the exceeded bucket is returned
+func FunctionRateLimitsExceeded(as istructs.IAppStructs, funcQName appdef.QName, wsid istructs.WSID) (exceeded bool, state irates.BucketState) {
+	// appStructs implementation has method FunctionRateLimitsExceeded()
+	return as.(interface {
+		FunctionRateLimitsExceeded(appdef.QName, istructs.WSID) (bool, irates.BucketState)
+	}).FunctionRateLimitsExceeded(funcQName, wsid)
+}
+
+// bucket keys of all function rate limits checked on calls in the workspace, ordered by rate limit name
+func FunctionRateLimitsBucketKeys(as istructs.IAppStructs, wsid istructs.WSID) []irates.BucketKey {
+	// appStructs implementation has method FunctionRateLimitsBucketKeys()
+	return as.(interface {
+		FunctionRateLimitsBucketKeys(istructs.WSID) []irates.BucketKey
+	}).FunctionRateLimitsBucketKeys(wsid)
+}
@@ -57,7 +57,7 @@ func (key *keyType) storeViewPartKey(ws istructs.WSID) []byte {
 	utils.WriteUint64(buf, uint64(ws))
 
 	for _, f := range key.partRow.fields.Fields() {
-		utils.SafeWriteBuf(buf, key.partRow.dyB.Get(f.Name()))
+		storeKeyFieldToBuffer(&key.partRow, f, buf)
 	}
 
 	return buf.Bytes()
@@ -68,22 +68,47 @@ func (key *keyType) storeViewClustKey() []byte {
 	buf := new(bytes.Buffer)
 
 	for _, f := range key.ccolsRow.fields.Fields() {
-		utils.SafeWriteBuf(buf, key.ccolsRow.dyB.Get(f.Name()))
+		storeKeyFieldToBuffer(&key.ccolsRow, f, buf)
 	}
 
 	return buf.Bytes()
 }
 
+// Stores key row cell to buffer.
+//
+// Decimal and temporal values are stored in sortable form to keep the order of negative and positive values.
+// UUID values are stored as is, 16 bytes big-endian, so they are sorted in byte order
+func storeKeyFieldToBuffer(row *rowType, field appdef.IField, buf *bytes.Buffer) {
+	v := row.dyB.Get(field.Name())
+	if k := field.DataKind(); k == appdef.DataKind_decimal || k.IsTemporal() {
+		if i, ok := v.(int64); ok {
+			utils.WriteSortableInt64(buf, i)
+			return
+		}
+	}
+	utils.SafeWriteBuf(buf, v)
+}
+
 // Loads clustering columns from buffer
 func loadViewClustKey_00(key *keyType, buf *bytes.Buffer) error {
 	for _, f := range key.ccolsRow.fields.Fields() {
-		if err := loadClustFieldFromBuffer_00(key, f, buf); err != nil {
+		if err := loadKeyFieldFromBuffer_00(key, &key.ccolsRow, f, buf); err != nil {
 			return enrichError(err, key.viewName, "unable to load clustering columns field «%s»", f.Name())
 		}
 	}
 	return key.ccolsRow.build()
 }
 
+// Loads partition key fields from buffer. View QNameID and WSID must be read from buffer before
+func loadViewPartKey_00(key *keyType, buf *bytes.Buffer) error {
+	for _, f := range key.partRow.fields.Fields() {
+		if err := loadKeyFieldFromBuffer_00(key, &key.partRow, f, buf); err != nil {
+			return enrichError(err, key.viewName, "unable to load partition key field «%s»", f.Name())
+		}
+	}
+	return key.partRow.build()
+}
+
 // Loads view value from specified buf using specified codec.
 //
 // This method uses the name of the type set by the caller (val.QName), ignoring that is read from the buffer.
@@ -107,64 +132,74 @@ func loadViewValue(val *valueType, codecVer byte, buf *bytes.Buffer) (err error)
 	return nil
 }
 
-// Loads from buffer row cell
-func loadClustFieldFromBuffer_00(key *keyType, field appdef.IField, buf *bytes.Buffer) (err error) {
+// Loads from buffer key row cell
+func loadKeyFieldFromBuffer_00(key *keyType, row *rowType, field appdef.IField, buf *bytes.Buffer) (err error) {
 	switch field.DataKind() {
 	case appdef.DataKind_int8: // #3435 [~server.vsql.smallints/cmp.istructs~impl]
 		v := int8(0)
 		if v, err = utils.ReadInt8(buf); err == nil {
-			key.ccolsRow.PutInt8(field.Name(), v)
+			row.PutInt8(field.Name(), v)
 		}
 	case appdef.DataKind_int16: // #3435 [~server.vsql.smallints/cmp.istructs~impl]
 		v := int16(0)
 		if v, err = utils.ReadInt16(buf); err == nil {
-			key.ccolsRow.PutInt16(field.Name(), v)
+			row.PutInt16(field.Name(), v)
 		}
 	case appdef.DataKind_int32:
 		v := int32(0)
 		if v, err = utils.ReadInt32(buf); err == nil {
-			key.ccolsRow.PutInt32(field.Name(), v)
+			row.PutInt32(field.Name(), v)
 		}
 	case appdef.DataKind_int64:
 		v := int64(0)
 		if v, err = utils.ReadInt64(buf); err == nil {
-			key.ccolsRow.PutInt64(field.Name(), v)
+			row.PutInt64(field.Name(), v)
 		}
 	case appdef.DataKind_float32:
 		v := float32(0)
 		if v, err = utils.ReadFloat32(buf); err == nil {
-			key.ccolsRow.PutFloat32(field.Name(), v)
+			row.PutFloat32(field.Name(), v)
 		}
 	case appdef.DataKind_float64:
 		v := float64(0)
 		if v, err = utils.ReadFloat64(buf); err == nil {
-			key.ccolsRow.PutFloat64(field.Name(), v)
+			row.PutFloat64(field.Name(), v)
 		}
 	case appdef.DataKind_QName:
 		v := uint16(0)
 		if v, err = utils.ReadUInt16(buf); err == nil {
 			var name appdef.QName
 			if name, err = key.appCfg.qNames.QName(v); err == nil {
-				key.ccolsRow.PutQName(field.Name(), name)
+				row.PutQName(field.Name(), name)
 			}
 		}
 	case appdef.DataKind_bool:
 		v := false
 		if v, err = utils.ReadBool(buf); err == nil {
-			key.ccolsRow.PutBool(field.Name(), v)
+			row.PutBool(field.Name(), v)
 		}
 	case appdef.DataKind_RecordID:
 		v := int64(istructs.NullRecordID)
 		if v, err = utils.ReadInt64(buf); err == nil {
-			key.ccolsRow.PutRecordID(field.Name(), istructs.RecordID(v)) // nolint G115
+			row.PutRecordID(field.Name(), istructs.RecordID(v)) // nolint G115
+		}
+	case appdef.DataKind_decimal, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		v := int64(0)
+		if v, err = utils.ReadSortableInt64(buf); err == nil {
+			row.PutInt64(field.Name(), v)
+		}
+	case appdef.DataKind_uuid:
+		if buf.Len() < uuidSize {
+			return enrichError(io.ErrUnexpectedEOF, "expected %d bytes, but only %d bytes is available", uuidSize, buf.Len())
 		}
+		row.PutBytes(field.Name(), bytes.Clone(buf.Next(uuidSize)))
 	case appdef.DataKind_bytes:
-		key.ccolsRow.PutBytes(field.Name(), buf.Bytes())
+		row.PutBytes(field.Name(), buf.Bytes())
 	case appdef.DataKind_string:
-		key.ccolsRow.PutString(field.Name(), buf.String())
+		row.PutString(field.Name(), buf.String())
 	default:
 		// no test
-		err = ErrWrongFieldType("clustering columns of «%v» unable load data type «%s»", key.viewName, field.DataKind().TrimString())
+		err = ErrWrongFieldType("key of «%v» unable load data type «%s»", key.viewName, field.DataKind().TrimString())
 	}
 	return err
 }
@@ -7,6 +7,7 @@ package istructsmem
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -216,7 +217,40 @@ func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key
 		return err
 	}
 
-	readRecord := func(ccols, value []byte) (err error) {
+	pKey, cKey := k.storeToBytes(workspace)
+	return vr.app.config.storage.Read(ctx, pKey, cKey, utils.IncBytes(cKey), readRecordFunc(k, cb))
+}
+
+// istructs.IViewRecordsRangeReader.ReadRange
+func (vr *appViewRecords) ReadRange(ctx context.Context, workspace istructs.WSID, from, to istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	f, t := from.(*keyType), to.(*keyType)
+	if f.viewName != t.viewName {
+		return ErrWrongType("range keys of different views %v and %v", f.viewName, t.viewName)
+	}
+	for _, k := range []*keyType{f, t} {
+		if err = k.build(); err != nil {
+			return err
+		}
+		if err = validateViewKey(k, true); err != nil {
+			return err
+		}
+	}
+
+	pKey, startCKey := f.storeToBytes(workspace)
+	if !bytes.Equal(pKey, t.storeViewPartKey(workspace)) {
+		return ErrWrongType("range keys %v and %v have different partition keys", f, t)
+	}
+
+	finishCKey := utils.IncBytes(t.storeViewClustKey())
+	if finishCKey != nil && bytes.Compare(startCKey, finishCKey) >= 0 {
+		return nil // empty range, some storages do not accept reversed bounds
+	}
+	return vr.app.config.storage.Read(ctx, pKey, startCKey, finishCKey, readRecordFunc(f, cb))
+}
+
+// Returns storage read callback, which loads view record of the key partition and calls cb
+func readRecordFunc(k *keyType, cb istructs.ValuesCallback) func(ccols, value []byte) error {
+	return func(ccols, value []byte) (err error) {
 		recKey := newKey(k.appCfg, k.viewName)
 		recKey.partRow.copyFrom(&k.partRow)
 		if err := recKey.loadFromBytes(ccols); err != nil {
@@ -229,9 +263,66 @@ func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key
 		}
 		return cb(recKey, valRow)
 	}
+}
 
-	pKey, cKey := k.storeToBytes(workspace)
-	return vr.app.config.storage.Read(ctx, pKey, cKey, utils.IncBytes(cKey), readRecord)
+// istructs.IWorkspaceViewsReader.ReadWorkspaceViews
+func (vr *appViewRecords) ReadWorkspaceViews(ctx context.Context, workspace istructs.WSID, cb istructs.ValuesCallback) (err error) {
+	scanner, ok := vr.app.config.storage.(istorage.IPKeysScanner)
+	if !ok {
+		return istorage.ErrPKeysScanNotSupported
+	}
+
+	// partition keys are collected first, so storage is not read from the scan callback
+	const viewPKeyPrefixLen = 2 + 8 // view QNameID + WSID
+	pKeys := [][]byte{}
+	err = scanner.ScanPKeys(ctx, istorage.FullTokenRange, func(pKey []byte) error {
+		if len(pKey) < viewPKeyPrefixLen {
+			return nil
+		}
+		if istructs.QNameID(binary.BigEndian.Uint16(pKey)) <= istructs.QNameIDSysLast {
+			return nil
+		}
+		if istructs.WSID(binary.BigEndian.Uint64(pKey[2:])) != workspace {
+			return nil
+		}
+		pKeys = append(pKeys, bytes.Clone(pKey))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return nil
+		}
+		viewName, err := vr.app.config.qNames.QName(binary.BigEndian.Uint16(pKey))
+		if err != nil || appdef.View(vr.app.config.AppDef.Type, viewName) == nil {
+			// not a view partition
+			continue
+		}
+		partKey := newKey(vr.app.config, viewName)
+		if err := loadViewPartKey_00(partKey, bytes.NewBuffer(pKey[viewPKeyPrefixLen:])); err != nil {
+			return err
+		}
+		readRecord := func(ccols, value []byte) (err error) {
+			recKey := newKey(vr.app.config, viewName)
+			recKey.partRow.copyFrom(&partKey.partRow)
+			if err := recKey.loadFromBytes(ccols); err != nil {
+				return err
+			}
+
+			valRow := newValue(vr.app.config, viewName)
+			if err := valRow.loadFromBytes(value); err != nil {
+				return err
+			}
+			return cb(recKey, valRow)
+		}
+		if err := vr.app.config.storage.Read(ctx, pKey, nil, nil, readRecord); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // keyType is complex key from two parts (partition key and clustering key)
@@ -312,6 +403,9 @@ func (key *keyType) AsBool(name appdef.FieldName) bool {
 
 // istructs.IRowReader.AsBytes
 func (key *keyType) AsBytes(name appdef.FieldName) []byte {
+	if key.partRow.fieldDef(name) != nil {
+		return key.partRow.AsBytes(name) // uuid partition key field
+	}
 	return key.ccolsRow.AsBytes(name)
 }
 
@@ -389,6 +483,9 @@ func (key *keyType) AsRecordID(name appdef.FieldName) istructs.RecordID {
 
 // istructs.IRowReader.AsString
 func (key *keyType) AsString(name appdef.FieldName) string {
+	if key.partRow.fieldDef(name) != nil {
+		return key.partRow.AsString(name) // decimal, temporal or uuid partition key field
+	}
 	return key.ccolsRow.AsString(name)
 }
 
@@ -468,12 +565,20 @@ func (key *keyType) PutBool(name appdef.FieldName, value bool) {
 
 // istructs.IRowWriter.PutBytes
 func (key *keyType) PutBytes(name appdef.FieldName, value []byte) {
-	key.ccolsRow.PutBytes(name, value)
+	if key.partRow.fieldDef(name) != nil {
+		key.partRow.PutBytes(name, value) // uuid partition key field
+	} else {
+		key.ccolsRow.PutBytes(name, value)
+	}
 }
 
 // istructs.IRowWriter.PutChars
 func (key *keyType) PutChars(name appdef.FieldName, value string) {
-	key.ccolsRow.PutChars(name, value)
+	if key.partRow.fieldDef(name) != nil {
+		key.partRow.PutChars(name, value) // decimal, temporal or uuid partition key field
+	} else {
+		key.ccolsRow.PutChars(name, value)
+	}
 }
 
 // istructs.IRowWriter.PutFloat32
@@ -580,7 +685,11 @@ func (key *keyType) PutRecordID(name appdef.FieldName, value istructs.RecordID)
 
 // istructs.IRowWriter.PutString
 func (key *keyType) PutString(name appdef.FieldName, value string) {
-	key.ccolsRow.PutString(name, value)
+	if key.partRow.fieldDef(name) != nil {
+		key.partRow.PutString(name, value) // decimal, temporal or uuid partition key field
+	} else {
+		key.ccolsRow.PutString(name, value)
+	}
 }
 
 // istructs.IRowReader.RecordIDs
@@ -1012,6 +1012,189 @@ func Test_ViewRecordsPutJSON(t *testing.T) {
 	})
 }
 
+func Test_ReadWorkspaceViews(t *testing.T) {
+	require := require.New(t)
+
+	appName := istructs.AppQName_test1_app1
+	viewName := appdef.NewQName("test", "view")
+
+	storage := teststore.NewStorage(appName)
+	storageProvider := teststore.NewStorageProvider(storage)
+
+	appCfgs := func() AppConfigsType {
+		cfgs := make(AppConfigsType, 1)
+
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+		wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+		wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+		wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+		view := wsb.AddView(viewName)
+		view.Key().PartKey().
+			AddField("pk1", appdef.DataKind_int32).
+			AddField("pk2", appdef.DataKind_QName)
+		view.Key().ClustCols().
+			AddField("cc1", appdef.DataKind_string, constraints.MaxLen(64))
+		view.Value().
+			AddField("v1", appdef.DataKind_int64, true)
+		cfg := cfgs.AddBuiltInAppConfig(appName, adb)
+		cfg.SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+		return cfgs
+	}()
+
+	app, err := Provide(appCfgs, iratesce.TestBucketsFactory, testTokensFactory(), storageProvider, isequencer.SequencesTrustLevel_0).BuiltIn(appName)
+	require.NoError(err)
+
+	put := func(ws istructs.WSID, pk1 int32, cc1 string, v1 int64) {
+		k := app.ViewRecords().KeyBuilder(viewName)
+		k.PutInt32("pk1", pk1)
+		k.PutQName("pk2", viewName)
+		k.PutString("cc1", cc1)
+		v := app.ViewRecords().NewValueBuilder(viewName)
+		v.PutInt64("v1", v1)
+		require.NoError(app.ViewRecords().Put(ws, k, v))
+	}
+	put(1, 1, "a", 11)
+	put(1, 1, "b", 12)
+	put(1, 2, "a", 21)
+	put(2, 1, "a", 100)
+
+	reader, ok := app.ViewRecords().(istructs.IWorkspaceViewsReader)
+	require.True(ok)
+
+	t.Run("should read all view records of the workspace only", func(t *testing.T) {
+		got := map[string]int64{}
+		err := reader.ReadWorkspaceViews(context.Background(), 1, func(key istructs.IKey, value istructs.IValue) error {
+			require.Equal(viewName, key.AsQName("pk2"))
+			got[fmt.Sprintf("%d/%s", key.AsInt32("pk1"), key.AsString("cc1"))] = value.AsInt64("v1")
+			return nil
+		})
+		require.NoError(err)
+		require.Equal(map[string]int64{"1/a": 11, "1/b": 12, "2/a": 21}, got)
+	})
+
+	t.Run("should return callback error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		err := reader.ReadWorkspaceViews(context.Background(), 2, func(istructs.IKey, istructs.IValue) error { return testErr })
+		require.ErrorIs(err, testErr)
+	})
+
+	t.Run("should read nothing for empty workspace", func(t *testing.T) {
+		err := reader.ReadWorkspaceViews(context.Background(), 3, func(istructs.IKey, istructs.IValue) error {
+			require.Fail("unexpected record")
+			return nil
+		})
+		require.NoError(err)
+	})
+}
+
+func Test_ReadRange(t *testing.T) {
+	require := require.New(t)
+
+	appName := istructs.AppQName_test1_app1
+	viewName := appdef.NewQName("test", "view")
+
+	storage := teststore.NewStorage(appName)
+	storageProvider := teststore.NewStorageProvider(storage)
+
+	appCfgs := func() AppConfigsType {
+		cfgs := make(AppConfigsType, 1)
+
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+		wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+		view := wsb.AddView(viewName)
+		view.Key().PartKey().
+			AddField("pk1", appdef.DataKind_int32)
+		view.Key().ClustCols().
+			AddField("cc1", appdef.DataKind_int32).
+			AddField("cc2", appdef.DataKind_string, constraints.MaxLen(64))
+		view.Value().
+			AddField("v1", appdef.DataKind_int64, true)
+		cfg := cfgs.AddBuiltInAppConfig(appName, adb)
+		cfg.SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+		return cfgs
+	}()
+
+	app, err := Provide(appCfgs, iratesce.TestBucketsFactory, testTokensFactory(), storageProvider, isequencer.SequencesTrustLevel_0).BuiltIn(appName)
+	require.NoError(err)
+
+	put := func(pk1, cc1 int32, cc2 string) {
+		k := app.ViewRecords().KeyBuilder(viewName)
+		k.PutInt32("pk1", pk1)
+		k.PutInt32("cc1", cc1)
+		k.PutString("cc2", cc2)
+		v := app.ViewRecords().NewValueBuilder(viewName)
+		v.PutInt64("v1", int64(cc1))
+		require.NoError(app.ViewRecords().Put(1, k, v))
+	}
+	for cc1 := int32(1); cc1 <= 5; cc1++ {
+		put(1, cc1, "a")
+		put(1, cc1, "b")
+	}
+	put(2, 3, "a")
+
+	reader, ok := app.ViewRecords().(istructs.IViewRecordsRangeReader)
+	require.True(ok)
+
+	key := func(pk1 int32, cc ...any) istructs.IKeyBuilder {
+		k := app.ViewRecords().KeyBuilder(viewName)
+		k.PutInt32("pk1", pk1)
+		if len(cc) > 0 {
+			k.PutInt32("cc1", cc[0].(int32))
+		}
+		if len(cc) > 1 {
+			k.PutString("cc2", cc[1].(string))
+		}
+		return k
+	}
+	read := func(from, to istructs.IKeyBuilder) (got []string, err error) {
+		err = reader.ReadRange(context.Background(), 1, from, to, func(key istructs.IKey, value istructs.IValue) error {
+			got = append(got, fmt.Sprintf("%d%s", key.AsInt32("cc1"), key.AsString("cc2")))
+			return nil
+		})
+		return got, err
+	}
+
+	t.Run("should read inclusive range", func(t *testing.T) {
+		got, err := read(key(1, int32(2)), key(1, int32(4)))
+		require.NoError(err)
+		require.Equal([]string{"2a", "2b", "3a", "3b", "4a", "4b"}, got)
+
+		got, err = read(key(1, int32(2), "b"), key(1, int32(3), "a"))
+		require.NoError(err)
+		require.Equal([]string{"2b", "3a"}, got)
+	})
+
+	t.Run("should read open range", func(t *testing.T) {
+		got, err := read(key(1, int32(4)), key(1))
+		require.NoError(err)
+		require.Equal([]string{"4a", "4b", "5a", "5b"}, got)
+
+		got, err = read(key(1), key(1, int32(1)))
+		require.NoError(err)
+		require.Equal([]string{"1a", "1b"}, got)
+	})
+
+	t.Run("should read nothing for empty range", func(t *testing.T) {
+		got, err := read(key(1, int32(4)), key(1, int32(2)))
+		require.NoError(err)
+		require.Empty(got)
+	})
+
+	t.Run("should return error if partition keys are different", func(t *testing.T) {
+		_, err := read(key(1, int32(1)), key(2, int32(5)))
+		require.ErrorIs(err, ErrWrongTypeError)
+	})
+
+	t.Run("should return error if key has a hole", func(t *testing.T) {
+		k := key(1)
+		k.PutString("cc2", "a")
+		_, err := read(key(1), k)
+		require.ErrorIs(err, ErrFieldIsEmptyError)
+	})
+}
+
 func Test_LoadStoreViewRecord_Bytes(t *testing.T) {
 	require := require.New(t)
 
@@ -1235,6 +1418,239 @@ func Test_ViewRecords_ClustColumnsQName(t *testing.T) {
 	})
 }
 
+func Test_ViewRecords_ClustColumnsKinds(t *testing.T) {
+	require := require.New(t)
+
+	// view has partition key field "pk", clustering column "cc" and value field "val" of the test kinds
+	type field struct {
+		kind        appdef.DataKind
+		constraints []appdef.IConstraint
+	}
+	type put func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder)
+
+	tests := []struct {
+		name          string
+		pk, cc, val   field
+		pkValue       any
+		ccValue       string // used to put wrong values and to get the value
+		valValue      string // used to put wrong values
+		puts          []put
+		readPK        func(kb istructs.IKeyBuilder) // nil -> pkValue is put
+		wantPK        string                        // pk as string, empty -> not checked
+		wantCC        []string                      // in sort order
+		wantVal       []string                      // in sort order of cc
+		checkGotValue func(v istructs.IValue)
+		wrongPuts     []put
+	}{
+		{
+			name:     "decimal",
+			pk:       field{kind: appdef.DataKind_int64},
+			cc:       field{kind: appdef.DataKind_decimal, constraints: []appdef.IConstraint{constraints.Precision(10), constraints.Scale(2)}},
+			val:      field{kind: appdef.DataKind_decimal, constraints: []appdef.IConstraint{constraints.Scale(4)}},
+			pkValue:  int64(1),
+			ccValue:  "-10.5",
+			valValue: "1",
+			puts: []put{
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutString("cc", "2.25")
+					vb.PutString("val", "0.0001")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutNumber("cc", gojson.Number("-10.5"))
+					vb.PutString("val", "1.0001")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutInt64("cc", 10000)
+					vb.PutString("val", "2.0001")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutChars("cc", "0")
+					vb.PutString("val", "3.0001")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutFloat64("cc", -0.01)
+					vb.PutString("val", "4.0001")
+				},
+			},
+			wantCC:  []string{"-10.50", "-0.01", "0.00", "2.25", "100.00"},
+			wantVal: []string{"1.0001", "4.0001", "3.0001", "0.0001", "2.0001"},
+			checkGotValue: func(v istructs.IValue) {
+				// unscaled int64 and float64
+				require.EqualValues(10001, v.AsInt64("val"))
+				require.InDelta(1.0001, v.AsFloat64("val"), 1e-9)
+			},
+			wrongPuts: []put{
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutString("cc", "1.001") },
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutString("cc", "abc") },
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutString("cc", "100000000") },
+			},
+		},
+		{
+			name:     "temporal",
+			pk:       field{kind: appdef.DataKind_date},
+			cc:       field{kind: appdef.DataKind_timestamp},
+			val:      field{kind: appdef.DataKind_interval},
+			pkValue:  "1970-01-01",
+			ccValue:  "1970-01-01T01:00:00Z",
+			valValue: "PT1S",
+			puts: []put{
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutString("cc", "1970-01-01T00:00:01Z")
+					vb.PutString("val", "PT1M")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutNumber("cc", gojson.Number("-1000"))
+					vb.PutString("val", "PT2M")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutInt64("cc", 0)
+					vb.PutString("val", "PT3M")
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutChars("cc", "1969-12-31T23:00:00-02:00")
+					vb.PutString("val", "PT4M")
+				},
+			},
+			readPK:  func(kb istructs.IKeyBuilder) { kb.PutInt64("pk", 1000) }, // time of day is truncated
+			wantPK:  "1970-01-01",
+			wantCC:  []string{"1969-12-31T23:59:59.000Z", "1970-01-01T00:00:00.000Z", "1970-01-01T00:00:01.000Z", "1970-01-01T01:00:00.000Z"},
+			wantVal: []string{"PT2M", "PT3M", "PT1M", "PT4M"},
+			checkGotValue: func(v istructs.IValue) {
+				// int64 milliseconds
+				require.EqualValues(4*60*1000, v.AsInt64("val"))
+			},
+			wrongPuts: []put{
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutString("cc", "1970-01-01") },
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutString("pk", "1970-01-01T00:00:00Z") },
+				func(_ istructs.IKeyBuilder, vb istructs.IValueBuilder) { vb.PutString("val", "P1M") },
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutFloat64("cc", 1) },
+			},
+		},
+		{
+			name:     "uuid and json",
+			pk:       field{kind: appdef.DataKind_uuid},
+			cc:       field{kind: appdef.DataKind_uuid},
+			val:      field{kind: appdef.DataKind_json, constraints: []appdef.IConstraint{constraints.MaxLen(32)}},
+			pkValue:  "123e4567-e89b-12d3-a456-426614174000",
+			ccValue:  "123e4567-e89b-12d3-a456-426614174000",
+			valValue: "{}",
+			puts: []put{
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutString("cc", "FFFFFFFF-0000-0000-0000-000000000001")
+					vb.PutString("val", ` { "a" : 1 } `)
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutBytes("cc", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2})
+					vb.PutFromJSON(map[appdef.FieldName]any{"val": []any{"b", true}})
+				},
+				func(kb istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					kb.PutChars("cc", "7fffffff-0000-0000-0000-000000000003")
+					vb.PutNumber("val", gojson.Number("3.14"))
+				},
+			},
+			readPK: func(kb istructs.IKeyBuilder) { kb.PutString("pk", "123E4567-E89B-12D3-A456-426614174000") },
+			wantPK: "123e4567-e89b-12d3-a456-426614174000",
+			wantCC: []string{ // byte order
+				"00000000-0000-0000-0000-000000000002",
+				"7fffffff-0000-0000-0000-000000000003",
+				"ffffffff-0000-0000-0000-000000000001"},
+			wantVal: []string{`["b",true]`, `3.14`, `{"a":1}`},
+			wrongPuts: []put{
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutString("cc", "not-a-uuid") },
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutBytes("cc", []byte{1, 2, 3}) },
+				func(kb istructs.IKeyBuilder, _ istructs.IValueBuilder) { kb.PutInt64("cc", 1) },
+				func(_ istructs.IKeyBuilder, vb istructs.IValueBuilder) { vb.PutString("val", `{"a":`) },
+				func(_ istructs.IKeyBuilder, vb istructs.IValueBuilder) {
+					vb.PutString("val", `{"a": "too long json value for field"}`)
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			appName := istructs.AppQName_test1_app1
+			viewName := appdef.NewQName("test", "view")
+			ws := istructs.WSID(1234)
+
+			adb := builder.New()
+			adb.AddPackage("test", "test.com/test")
+			wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+			wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+			wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+
+			v := wsb.AddView(viewName)
+			v.Key().PartKey().AddField("pk", test.pk.kind, test.pk.constraints...)
+			v.Key().ClustCols().AddField("cc", test.cc.kind, test.cc.constraints...)
+			v.Value().AddField("val", test.val.kind, true, test.val.constraints...)
+
+			cfgs := make(AppConfigsType, 1)
+			cfg := cfgs.AddBuiltInAppConfig(appName, adb)
+			cfg.SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+
+			p := Provide(cfgs, iratesce.TestBucketsFactory, testTokensFactory(), simpleStorageProvider(), isequencer.SequencesTrustLevel_0)
+			as, err := p.BuiltIn(appName)
+			require.NoError(err)
+			viewRecords := as.ViewRecords()
+
+			t.Run("should be ok to put values", func(t *testing.T) {
+				for _, put := range test.puts {
+					kb := viewRecords.KeyBuilder(viewName)
+					kb.PutFromJSON(map[appdef.FieldName]any{"pk": test.pkValue})
+					vb := viewRecords.NewValueBuilder(viewName)
+					put(kb, vb)
+					require.NoError(viewRecords.Put(ws, kb, vb))
+				}
+			})
+
+			t.Run("should be ok to read values in sort order", func(t *testing.T) {
+				kb := viewRecords.KeyBuilder(viewName)
+				if test.readPK != nil {
+					test.readPK(kb)
+				} else {
+					kb.PutFromJSON(map[appdef.FieldName]any{"pk": test.pkValue})
+				}
+
+				cc := []string{}
+				val := []string{}
+				require.NoError(viewRecords.Read(context.Background(), ws, kb, func(key istructs.IKey, value istructs.IValue) error {
+					if test.wantPK != "" {
+						require.Equal(test.wantPK, key.AsString("pk"))
+					}
+					cc = append(cc, key.AsString("cc"))
+					val = append(val, value.AsString("val"))
+					return nil
+				}))
+				require.Equal(test.wantCC, cc)
+				require.Equal(test.wantVal, val)
+			})
+
+			if test.checkGotValue != nil {
+				t.Run("should be ok to get value", func(t *testing.T) {
+					kb := viewRecords.KeyBuilder(viewName)
+					kb.PutFromJSON(map[appdef.FieldName]any{"pk": test.pkValue})
+					kb.PutString("cc", test.ccValue)
+					v, err := viewRecords.Get(ws, kb)
+					require.NoError(err)
+					test.checkGotValue(v)
+				})
+			}
+
+			t.Run("should be error to put wrong values", func(t *testing.T) {
+				for i, put := range test.wrongPuts {
+					kb := viewRecords.KeyBuilder(viewName)
+					kb.PutFromJSON(map[appdef.FieldName]any{"pk": test.pkValue})
+					kb.PutString("cc", test.ccValue)
+					vb := viewRecords.NewValueBuilder(viewName)
+					vb.PutString("val", test.valValue)
+					put(kb, vb)
+					require.Error(viewRecords.Put(ws, kb, vb), i)
+				}
+			})
+		})
+	}
+}
+
 func Test_ViewRecord_GetBatch(t *testing.T) {
 	require := require.New(t)
 
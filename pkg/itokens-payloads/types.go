@@ -22,6 +22,10 @@ type PrincipalPayload struct {
 	Roles       []RoleType
 	GlobalRoles []appdef.QName
 	IsAPIToken  bool
+
+	// token ID: all tokens issued within the login session (refreshed, enriched) share it
+	// empty for tokens issued without session, e.g. system or API tokens
+	SessionID string `json:",omitempty"`
 }
 
 type RoleType struct {
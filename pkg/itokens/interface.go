@@ -31,3 +31,11 @@ type ITokens interface {
 	// Ref. https://pkg.go.dev/crypto/sha256
 	CryptoHash256(data []byte) (hash [32]byte)
 }
+
+// Implemented by ITokens which sign tokens with asymmetric keys
+// Allows to verify tokens without the secret key
+type IJWKS interface {
+	// Public keys which are used to sign tokens, including keys scheduled for activation
+	// Ref. https://datatracker.ietf.org/doc/html/rfc7517
+	JWKS() JWKSet
+}
@@ -12,6 +12,10 @@ const (
 	hashLength          = 32
 	errorVerifyAudience = "error verify token, this token have %s audience and was intended for principal type %s %w"
 	SecretKeyJWTName    = "secretKeyJWT"
+	minRSAKeyBits       = 2048
+	ecP256CoordSize     = 32
+	jwkUseSignature     = "sig"
+	kidHeader           = "kid"
 )
 
 var SecretKeyExample = SecretKeyType{
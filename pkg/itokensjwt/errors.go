@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package itokensjwt
+
+import "errors"
+
+var (
+	ErrUnsupportedSigningKey = errors.New("unsupported signing key")
+	ErrInvalidKID            = errors.New("invalid signing key ID")
+	ErrInvalidKeyPeriod      = errors.New("signing key expires before it is activated")
+	ErrNoSigningKeys         = errors.New("HS256 could not be rejected if there are no signing keys")
+)
@@ -77,13 +77,7 @@ func (j *JWTSigner) ValidateToken(token string, pointerToPayload interface{}) (g
 	)
 	expectedAudience := reflect.TypeOf(pointerToPayload).Elem().String()
 	parser := jwt.NewParser(jwt.WithJSONNumber(), jwt.WithTimeFunc(j.iTime.Now))
-	jwtToken, err = parser.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, itokens.ErrInvalidToken
-		}
-		return j.secretKey, nil
-	})
+	jwtToken, err = parser.Parse(token, j.verificationKey)
 	if jwtToken == nil {
 		if err != nil {
 			err = fmt.Errorf(err.Error()+". %w", itokens.ErrInvalidToken)
@@ -197,6 +191,11 @@ func setErrorDescription(err error) error {
 }
 
 func (j *JWTSigner) sign(claims jwt.Claims) (token string, err error) {
+	if key, ok := j.activeKey(); ok {
+		jwtToken := jwt.NewWithClaims(key.method, claims)
+		jwtToken.Header[kidHeader] = key.KID
+		return jwtToken.SignedString(key.Key)
+	}
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	if onSecretKeyMutate != nil {
@@ -205,6 +204,57 @@ func (j *JWTSigner) sign(claims jwt.Claims) (token string, err error) {
 	return jwtToken.SignedString(j.secretKey)
 }
 
+// the not expired key with the latest ActiveFrom which is not in future
+func (j *JWTSigner) activeKey() (key signingKey, ok bool) {
+	now := j.iTime.Now()
+	for i := len(j.keys) - 1; i >= 0; i-- {
+		if !j.keys[i].ActiveFrom.After(now) && !isExpired(j.keys[i], now) {
+			return j.keys[i], true
+		}
+	}
+	return key, false
+}
+
+// HS256 tokens are verified by the secret key unless rejected, others are verified by the not expired key identified by kid
+func (j *JWTSigner) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if _, active := j.activeKey(); active && j.rejectHS256 {
+			return nil, itokens.ErrInvalidToken
+		}
+		return j.secretKey, nil
+	}
+	kid, _ := token.Header[kidHeader].(string)
+	now := j.iTime.Now()
+	for _, key := range j.keys {
+		if key.KID == kid && !isExpired(key, now) && key.method.Alg() == token.Method.Alg() {
+			return key.Key.Public(), nil
+		}
+	}
+	return nil, itokens.ErrInvalidToken
+}
+
+// all not expired keys, including keys which are not activated yet
+func (j *JWTSigner) JWKS() itokens.JWKSet {
+	res := itokens.JWKSet{Keys: []itokens.JWK{}}
+	now := j.iTime.Now()
+	for _, key := range j.keys {
+		if isExpired(key, now) {
+			continue
+		}
+		jwk, err := key.jwk()
+		if err != nil {
+			// notest: checked on construct
+			panic(err)
+		}
+		res.Keys = append(res.Keys, jwk)
+	}
+	return res
+}
+
+func isExpired(key signingKey, now time.Time) bool {
+	return !key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt)
+}
+
 func getTokenPayload(token []string) string {
 	return token[1]
 }
@@ -214,7 +264,18 @@ func NewJWTSigner(secretKey SecretKeyType, iTime timeu.ITime) *JWTSigner {
 	if len(byteSecretKey) < SecretKeyLength {
 		panic(fmt.Errorf("invalid key length: must be %d chars", SecretKeyLength))
 	}
-	return &JWTSigner{byteSecretKey, iTime}
+	return &JWTSigner{secretKey: byteSecretKey, iTime: iTime}
+}
+
+// NewJWTSignerWithKeys returns the signer which signs tokens by the asymmetric keys, see SigningKey
+// The secret key is still used by CryptoHash256 and to verify HS256 tokens issued before the keys are configured
+func NewJWTSignerWithKeys(secretKey SecretKeyType, keys []SigningKey, iTime timeu.ITime) (*JWTSigner, error) {
+	signer := NewJWTSigner(secretKey, iTime)
+	var err error
+	if signer.keys, err = newSigningKeys(keys); err != nil {
+		return nil, err
+	}
+	return signer, nil
 }
 
 func mergeClaimsMaps(maps ...map[string]interface{}) (result map[string]interface{}) {
@@ -8,11 +8,23 @@
 package itokensjwt
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/voedger/voedger/pkg/goutils/testingu"
@@ -311,6 +323,275 @@ func TestHashFunc(t *testing.T) {
 	require.Equal(hashMsg, b)
 }
 
+func TestAsymmetricKeys(t *testing.T) {
+	require := require.New(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+
+	payload := TestPayload_Principal{TestPayload_Login: TestPayload_Login{Login: "login"}, ProfileWSID: 42}
+
+	for _, key := range []struct {
+		alg string
+		key crypto.Signer
+	}{
+		{"RS256", rsaKey},
+		{"ES256", ecKey},
+		{"EdDSA", edKey},
+	} {
+		t.Run(key.alg, func(t *testing.T) {
+			signer, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key1", Key: key.key}}, false, testingu.NewMockTime())
+			require.NoError(err)
+
+			token, err := signer.IssueToken(istructs.AppQName_test1_app1, time.Minute, &payload)
+			require.NoError(err)
+
+			actualPayload := TestPayload_Principal{}
+			gp, err := signer.ValidateToken(token, &actualPayload)
+			require.NoError(err)
+			require.Equal(payload, actualPayload)
+			require.Equal(istructs.AppQName_test1_app1, gp.AppQName)
+
+			jwks := signer.(itokens.IJWKS).JWKS()
+			require.Len(jwks.Keys, 1)
+			require.Equal("key1", jwks.Keys[0].Kid)
+			require.Equal(key.alg, jwks.Keys[0].Alg)
+
+			// the token is verified by the published public key without the secret key
+			parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+				require.Equal("key1", token.Header["kid"])
+				return key.key.Public(), nil
+			}, jwt.WithValidMethods([]string{key.alg}), jwt.WithoutClaimsValidation())
+			require.NoError(err)
+			require.True(parsed.Valid)
+		})
+	}
+
+	t.Run("JWK", func(t *testing.T) {
+		signer, err := NewJWTSignerWithKeys(SecretKeyExample, []SigningKey{
+			{KID: "rsa", Key: rsaKey},
+			{KID: "ec", Key: ecKey},
+			{KID: "ed", Key: edKey},
+		}, testingu.NewMockTime())
+		require.NoError(err)
+		jwks := signer.JWKS()
+		require.Len(jwks.Keys, 3)
+		enc := base64.RawURLEncoding.EncodeToString
+		require.Equal(itokens.JWK{Kty: "RSA", Kid: "rsa", Use: "sig", Alg: "RS256", N: enc(rsaKey.N.Bytes()), E: "AQAB"}, jwks.Keys[0])
+		require.Equal("EC", jwks.Keys[1].Kty)
+		require.Equal("P-256", jwks.Keys[1].Crv)
+		x, err := base64.RawURLEncoding.DecodeString(jwks.Keys[1].X)
+		require.NoError(err)
+		require.Len(x, 32)
+		require.Equal(itokens.JWK{Kty: "OKP", Kid: "ed", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: enc(edKey.Public().(ed25519.PublicKey))}, jwks.Keys[2])
+
+		b, err := json.Marshal(jwks)
+		require.NoError(err)
+		require.Contains(string(b), `"keys":[{"kty":"RSA","kid":"rsa","use":"sig","alg":"RS256","n":`)
+	})
+
+	t.Run("HS256 tokens issued before keys are configured are still valid", func(t *testing.T) {
+		mockTime := testingu.NewMockTime()
+		hs256Token, err := ProvideITokens(SecretKeyExample, mockTime).IssueToken(istructs.AppQName_test1_app1, time.Minute, &payload)
+		require.NoError(err)
+
+		signer, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key1", Key: edKey}}, false, mockTime)
+		require.NoError(err)
+		actualPayload := TestPayload_Principal{}
+		_, err = signer.ValidateToken(hs256Token, &actualPayload)
+		require.NoError(err)
+		require.Equal(payload, actualPayload)
+	})
+
+	t.Run("HS256 tokens are rejected once the key is active if configured", func(t *testing.T) {
+		mockTime := testingu.NewMockTime()
+		hs256Token, err := ProvideITokens(SecretKeyExample, mockTime).IssueToken(istructs.AppQName_test1_app1, time.Hour, &payload)
+		require.NoError(err)
+
+		signer, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key1", Key: edKey, ActiveFrom: mockTime.Now().Add(time.Minute)}}, true, mockTime)
+		require.NoError(err)
+		actualPayload := TestPayload_Principal{}
+		_, err = signer.ValidateToken(hs256Token, &actualPayload)
+		require.NoError(err)
+
+		mockTime.Add(time.Minute)
+		_, err = signer.ValidateToken(hs256Token, &actualPayload)
+		require.ErrorIs(err, itokens.ErrInvalidToken)
+
+		token, err := signer.IssueToken(istructs.AppQName_test1_app1, time.Minute, &payload)
+		require.NoError(err)
+		_, err = signer.ValidateToken(token, &actualPayload)
+		require.NoError(err)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		mockTime := testingu.NewMockTime()
+		signer1, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key1", Key: edKey}}, false, mockTime)
+		require.NoError(err)
+		signer2, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key2", Key: edKey}}, false, mockTime)
+		require.NoError(err)
+		token, err := signer1.IssueToken(istructs.AppQName_test1_app1, time.Minute, &payload)
+		require.NoError(err)
+		_, err = signer2.ValidateToken(token, &TestPayload_Principal{})
+		require.ErrorIs(err, itokens.ErrInvalidToken)
+	})
+
+	t.Run("algorithm mismatch", func(t *testing.T) {
+		mockTime := testingu.NewMockTime()
+		signer1, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key1", Key: ecKey}}, false, mockTime)
+		require.NoError(err)
+		signer2, err := ProvideITokensWithKeys(SecretKeyExample, []SigningKey{{KID: "key1", Key: edKey}}, false, mockTime)
+		require.NoError(err)
+		token, err := signer1.IssueToken(istructs.AppQName_test1_app1, time.Minute, &payload)
+		require.NoError(err)
+		_, err = signer2.ValidateToken(token, &TestPayload_Principal{})
+		require.ErrorIs(err, itokens.ErrInvalidToken)
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	require := require.New(t)
+
+	_, key1, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	mockTime := testingu.NewMockTime()
+	start := mockTime.Now()
+	signer, err := NewJWTSignerWithKeys(SecretKeyExample, []SigningKey{
+		// order does not matter
+		{KID: "key2", Key: key2, ActiveFrom: start.Add(time.Hour)},
+		{KID: "key1", Key: key1, ExpiresAt: start.Add(2 * time.Hour)},
+	}, mockTime)
+	require.NoError(err)
+
+	payload := TestPayload_Principal{ProfileWSID: 42}
+	issue := func() (token string, kid string) {
+		token, err := signer.IssueToken(istructs.AppQName_test1_app1, 3*time.Hour, &payload)
+		require.NoError(err)
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+		require.NoError(err)
+		return token, parsed.Header["kid"].(string)
+	}
+	kids := func() (res []string) {
+		for _, jwk := range signer.JWKS().Keys {
+			res = append(res, jwk.Kid)
+		}
+		return res
+	}
+
+	// key2 is published before activation
+	token1, kid := issue()
+	require.Equal("key1", kid)
+	require.Equal([]string{"key1", "key2"}, kids())
+
+	// key2 is activated, tokens signed by key1 are still valid
+	mockTime.Add(time.Hour)
+	token2, kid := issue()
+	require.Equal("key2", kid)
+	_, err = signer.ValidateToken(token1, &TestPayload_Principal{})
+	require.NoError(err)
+
+	// key1 is expired -> its tokens are invalid and it is not published anymore
+	mockTime.Add(time.Hour)
+	_, err = signer.ValidateToken(token1, &TestPayload_Principal{})
+	require.ErrorIs(err, itokens.ErrInvalidToken)
+	_, err = signer.ValidateToken(token2, &TestPayload_Principal{})
+	require.NoError(err)
+	require.Equal([]string{"key2"}, kids())
+}
+
+func TestSigningKeyErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(err)
+	now := time.Now()
+
+	cases := map[string]struct {
+		keys        []SigningKey
+		expectedErr error
+	}{
+		"empty kid":            {[]SigningKey{{Key: edKey}}, ErrInvalidKID},
+		"duplicated kid":       {[]SigningKey{{KID: "k", Key: edKey}, {KID: "k", Key: edKey}}, ErrInvalidKID},
+		"small RSA key":        {[]SigningKey{{KID: "k", Key: smallRSAKey}}, ErrUnsupportedSigningKey},
+		"P-384 curve":          {[]SigningKey{{KID: "k", Key: p384Key}}, ErrUnsupportedSigningKey},
+		"expires on activate":  {[]SigningKey{{KID: "k", Key: edKey, ActiveFrom: now, ExpiresAt: now}}, ErrInvalidKeyPeriod},
+		"expires before start": {[]SigningKey{{KID: "k", Key: edKey, ActiveFrom: now, ExpiresAt: now.Add(-time.Hour)}}, ErrInvalidKeyPeriod},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			signer, err := ProvideITokensWithKeys(SecretKeyExample, c.keys, false, testingu.MockTime)
+			require.ErrorIs(err, c.expectedErr)
+			require.Nil(signer)
+		})
+	}
+
+	t.Run("HS256 is rejected without keys", func(t *testing.T) {
+		signer, err := ProvideITokensWithKeys(SecretKeyExample, nil, true, testingu.MockTime)
+		require.ErrorIs(err, ErrNoSigningKeys)
+		require.Nil(signer)
+	})
+}
+
+func TestParseSigningKeyPEM(t *testing.T) {
+	require := require.New(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+
+	pkcs8 := func(key crypto.Signer) []byte {
+		b, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(err)
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b})
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(err)
+
+	for name, c := range map[string]struct {
+		pem []byte
+		key crypto.Signer
+	}{
+		"PKCS #8 RSA":     {pkcs8(rsaKey), rsaKey},
+		"PKCS #8 ECDSA":   {pkcs8(ecKey), ecKey},
+		"PKCS #8 Ed25519": {pkcs8(edKey), edKey},
+		"PKCS #1":         {pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), rsaKey},
+		"SEC 1":           {pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), ecKey},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := ParseSigningKeyPEM(c.pem)
+			require.NoError(err)
+			require.True(c.key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(key.Public()))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ParseSigningKeyPEM([]byte("not a PEM"))
+		require.ErrorIs(err, ErrUnsupportedSigningKey)
+
+		_, err = ParseSigningKeyPEM([]byte(strings.Replace(string(pkcs8(edKey)), "PRIVATE KEY", "RSA PRIVATE KEY", 2)))
+		require.ErrorIs(err, ErrUnsupportedSigningKey)
+
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(err)
+		_, err = ParseSigningKeyPEM(pkcs8(p384Key))
+		require.ErrorIs(err, ErrUnsupportedSigningKey)
+	})
+}
+
 func testEnvOff() {
 	onByteArrayMutate = nil
 	onSecretKeyMutate = nil
@@ -16,3 +16,19 @@ import (
 func ProvideITokens(secretKey SecretKeyType, time timeu.ITime) (tokenImpl itokens.ITokens) {
 	return NewJWTSigner(secretKey, time)
 }
+
+// ProvideITokensWithKeys returns ITokens which signs tokens by the asymmetric keys and implements itokens.IJWKS
+// Empty keys -> tokens are signed by the secret key (HS256) as by ProvideITokens
+// rejectHS256 -> tokens signed by the secret key are not valid once any key is active, see SigningKey.ActiveFrom
+// it should be set when HS256 tokens issued before the key activation are expired
+func ProvideITokensWithKeys(secretKey SecretKeyType, keys []SigningKey, rejectHS256 bool, time timeu.ITime) (itokens.ITokens, error) {
+	if rejectHS256 && len(keys) == 0 {
+		return nil, ErrNoSigningKeys
+	}
+	signer, err := NewJWTSignerWithKeys(secretKey, keys, time)
+	if err != nil {
+		return nil, err
+	}
+	signer.rejectHS256 = rejectHS256
+	return signer, nil
+}
@@ -4,11 +4,47 @@
 
 package itokensjwt
 
-import "github.com/voedger/voedger/pkg/goutils/timeu"
+import (
+	"crypto"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
 
 type SecretKeyType []byte
 
 type JWTSigner struct {
 	secretKey []byte
 	iTime     timeu.ITime
+
+	// sorted by ActiveFrom
+	keys []signingKey
+
+	// HS256 tokens are not verified if there is an active key
+	rejectHS256 bool
+}
+
+// Asymmetric key which is used to sign tokens
+// Tokens are signed by the active key with the latest ActiveFrom, by the secret key (HS256) if there are no active keys
+// Tokens are verified by any key which is not expired, so the next key should be configured and published in JWKS before it is activated
+// ExpiresAt of the previous key should be later than ActiveFrom of the next key plus the max token duration
+type SigningKey struct {
+	// must be unique, goes to the "kid" header of the token
+	KID string
+
+	// *rsa.PrivateKey (RS256, at least 2048 bits), *ecdsa.PrivateKey (ES256, P-256 curve) or ed25519.PrivateKey (EdDSA)
+	Key crypto.Signer
+
+	// zero -> the key is active from the start
+	ActiveFrom time.Time
+
+	// zero -> the key never expires
+	ExpiresAt time.Time
+}
+
+type signingKey struct {
+	SigningKey
+	method jwt.SigningMethod
 }
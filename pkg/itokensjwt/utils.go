@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package itokensjwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+// ParseSigningKeyPEM parses the PEM-encoded private key: PKCS #8, PKCS #1 (RSA) or SEC 1 (EC)
+func ParseSigningKeyPEM(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("%w: PEM block is not found", ErrUnsupportedSigningKey)
+	}
+	var (
+		key interface{}
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedSigningKey, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		// notest: all keys returned by x509 are crypto.Signer
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSigningKey, key)
+	}
+	if _, err := signingMethod(signer); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+func signingMethod(key crypto.Signer) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("%w: RSA key must be at least %d bits", ErrUnsupportedSigningKey, minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%w: only P-256 curve is supported for ECDSA key", ErrUnsupportedSigningKey)
+		}
+		return jwt.SigningMethodES256, nil
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedSigningKey, key)
+}
+
+func newSigningKeys(keys []SigningKey) ([]signingKey, error) {
+	res := make([]signingKey, 0, len(keys))
+	kids := map[string]bool{}
+	for _, key := range keys {
+		if len(key.KID) == 0 || kids[key.KID] {
+			return nil, fmt.Errorf("%w: %q is empty or duplicated", ErrInvalidKID, key.KID)
+		}
+		kids[key.KID] = true
+		if !key.ExpiresAt.IsZero() && !key.ExpiresAt.After(key.ActiveFrom) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKeyPeriod, key.KID)
+		}
+		method, err := signingMethod(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.KID, err)
+		}
+		res = append(res, signingKey{SigningKey: key, method: method})
+	}
+	slices.SortStableFunc(res, func(a, b signingKey) int {
+		return a.ActiveFrom.Compare(b.ActiveFrom)
+	})
+	return res, nil
+}
+
+func (k signingKey) jwk() (jwk itokens.JWK, err error) {
+	jwk = itokens.JWK{
+		Kid: k.KID,
+		Use: jwkUseSignature,
+		Alg: k.method.Alg(),
+	}
+	enc := base64.RawURLEncoding.EncodeToString
+	switch pub := k.Key.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = enc(pub.N.Bytes())
+		jwk.E = enc(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhPub, err := pub.ECDH()
+		if err != nil {
+			// notest
+			return jwk, err
+		}
+		// uncompressed point: 0x04 || X || Y
+		point := ecdhPub.Bytes()
+		jwk.Kty = "EC"
+		jwk.Crv = "P-256"
+		jwk.X = enc(point[1 : 1+ecP256CoordSize])
+		jwk.Y = enc(point[1+ecP256CoordSize:])
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = enc(pub)
+	default:
+		// notest: checked by signingMethod()
+		return jwk, fmt.Errorf("%w: %T", ErrUnsupportedSigningKey, pub)
+	}
+	return jwk, nil
+}
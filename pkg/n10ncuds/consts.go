@@ -0,0 +1,8 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package n10ncuds
+
+// CUDs of the recently notified events are kept to be shared by all subscribers of the workspace
+const eventsCacheSize = 1024
@@ -0,0 +1,12 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package n10ncuds
+
+import "errors"
+
+var (
+	ErrEventNotFound      = errors.New("event not found")
+	ErrWorkspaceNotInited = errors.New("workspace is not initialized")
+)
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package n10ncuds
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+func (c *implIN10nCUDs) Principals(ctx context.Context, app appdef.AppQName, wsid istructs.WSID, token string) (principals []iauthnz.Principal, err error) {
+	err = c.withPartition(ctx, app, wsid, func(appPart appparts.IAppPartition) error {
+		as := appPart.AppStructs()
+		principals, _, err = c.authn.Authenticate(ctx, as, as.AppTokens(), iauthnz.AuthnRequest{
+			RequestWSID: wsid,
+			Token:       token,
+		})
+		return err
+	})
+	return principals, err
+}
+
+func (c *implIN10nCUDs) Summary(ctx context.Context, app appdef.AppQName, wsid istructs.WSID, view appdef.QName, fromOffset, toOffset istructs.Offset,
+	principals []iauthnz.Principal, withFields bool) (cuds []CUD, err error) {
+	roles := []appdef.QName{}
+	for _, prn := range principals {
+		if prn.Kind == iauthnz.PrincipalKind_Role {
+			roles = append(roles, prn.QName)
+		}
+	}
+	err = c.withPartition(ctx, app, wsid, func(appPart appparts.IAppPartition) error {
+		as := appPart.AppStructs()
+		wsDesc, err := as.Records().GetSingleton(wsid, authnz.QNameCDocWorkspaceDescriptor)
+		if err != nil {
+			return err
+		}
+		if wsDesc.QName() == appdef.NullQName {
+			return fmt.Errorf("%w: %d", ErrWorkspaceNotInited, wsid)
+		}
+		ws := as.AppDef().WorkspaceByDescriptor(wsDesc.AsQName(authnz.Field_WSKind))
+		if ws == nil {
+			return fmt.Errorf("%w: workspace %s is not found in AppDef", ErrWorkspaceNotInited, wsDesc.AsQName(authnz.Field_WSKind))
+		}
+
+		events, err := c.readEvents(ctx, as, app, wsid, fromOffset, toOffset)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return fmt.Errorf("%w: wsid %d, offsets %d..%d", ErrEventNotFound, wsid, fromOffset, toOffset)
+		}
+		projectors := c.viewProjectors(as.AppDef(), view)
+		cuds = []CUD{}
+		for _, e := range events {
+			if len(projectors) > 0 && !slices.ContainsFunc(projectors, func(prj appdef.IProjector) bool { return triggers(prj, e) }) {
+				continue
+			}
+			eventCUDs, err := summary(appPart, ws, e, roles, withFields)
+			if err != nil {
+				return err
+			}
+			cuds = append(cuds, eventCUDs...)
+		}
+		return nil
+	})
+	return cuds, err
+}
+
+// cached events are taken, the rest are read from the WLog starting from the first event that is not cached
+func (c *implIN10nCUDs) readEvents(ctx context.Context, as istructs.IAppStructs, app appdef.AppQName, wsid istructs.WSID,
+	fromOffset, toOffset istructs.Offset) (events []*event, err error) {
+	offset := fromOffset
+	for ; offset <= toOffset; offset++ {
+		e, ok := c.events.Get(eventKey{app: app, wsid: wsid, offset: offset})
+		if !ok {
+			break
+		}
+		events = append(events, e)
+	}
+	if offset > toOffset {
+		return events, nil
+	}
+	err = as.Events().ReadWLog(ctx, wsid, offset, int(toOffset-offset)+1, func(wlogOffset istructs.Offset, wlogEvent istructs.IWLogEvent) error { // nolint G115
+		e := newEvent(wlogEvent)
+		c.events.Put(eventKey{app: app, wsid: wsid, offset: wlogOffset}, e)
+		events = append(events, e)
+		return nil
+	})
+	return events, err
+}
+
+// projectors which intents include the view
+// obtained once per AppDef and view
+func (c *implIN10nCUDs) viewProjectors(appDef appdef.IAppDef, view appdef.QName) []appdef.IProjector {
+	key := viewKey{appDef: appDef, view: view}
+	if prjs, ok := c.views.Load(key); ok {
+		return prjs.([]appdef.IProjector)
+	}
+	prjs := []appdef.IProjector{}
+	for prj := range appdef.Projectors(appDef.Types()) {
+		if intent := prj.Intents().Storage(sys.Storage_View); intent != nil && slices.Contains(intent.Names(), view) {
+			prjs = append(prjs, prj)
+		}
+	}
+	c.views.Store(key, prjs)
+	return prjs
+}
+
+// borrows the query processor partition the workspace belongs to
+func (c *implIN10nCUDs) withPartition(ctx context.Context, app appdef.AppQName, wsid istructs.WSID, f func(appparts.IAppPartition) error) error {
+	partitionID, err := c.appParts.AppWorkspacePartitionID(app, wsid)
+	if err != nil {
+		return err
+	}
+	appPart, err := c.appParts.WaitForBorrow(ctx, app, partitionID, appparts.ProcessorKind_Query)
+	if err != nil {
+		return err
+	}
+	defer appPart.Release()
+	return f(appPart)
+}
+
+func newEvent(wlogEvent istructs.IWLogEvent) *event {
+	e := &event{
+		qName:    wlogEvent.QName(),
+		argQName: wlogEvent.ArgumentObject().QName(),
+	}
+	for rec := range wlogEvent.CUDs {
+		cud := eventCUD{
+			id:          rec.ID(),
+			qName:       rec.QName(),
+			isNew:       rec.IsNew(),
+			activated:   rec.IsActivated(),
+			deactivated: rec.IsDeactivated(),
+			fields:      map[appdef.FieldName]interface{}{},
+		}
+		for field, value := range rec.SpecifiedValues {
+			if appdef.IsSysField(field.Name()) {
+				// ID and QName are provided already
+				continue
+			}
+			if b, ok := value.([]byte); ok {
+				// the event is kept after the read
+				value = bytes.Clone(b)
+			}
+			cud.fields[field.Name()] = value
+		}
+		e.cuds = append(e.cuds, cud)
+	}
+	return e
+}
+
+// the same as actualizers.ProjectorEvent() but for the event kept in the cache
+func triggers(prj appdef.IProjector, e *event) bool {
+	switch e.qName {
+	case istructs.QNameForError:
+		return prj.WantErrors()
+	case istructs.QNameForCorruptedData:
+		return false
+	}
+	app := prj.App()
+	if prj.Triggers(appdef.OperationKind_Execute, app.Type(e.qName)) {
+		return true
+	}
+	if e.argQName != appdef.NullQName && prj.Triggers(appdef.OperationKind_ExecuteWithParam, app.Type(e.argQName)) {
+		return true
+	}
+	for _, rec := range e.cuds {
+		t := app.Type(rec.qName)
+		switch {
+		case rec.isNew:
+			if prj.Triggers(appdef.OperationKind_Insert, t) {
+				return true
+			}
+		case prj.Triggers(appdef.OperationKind_Update, t),
+			rec.deactivated && prj.Triggers(appdef.OperationKind_Deactivate, t),
+			rec.activated && prj.Triggers(appdef.OperationKind_Activate, t):
+			return true
+		}
+	}
+	return false
+}
+
+// CUDs of the event the principals are allowed to select
+func summary(appPart appparts.IAppPartition, ws appdef.IWorkspace, e *event, roles []appdef.QName, withFields bool) (cuds []CUD, err error) {
+	cuds = []CUD{}
+	for _, rec := range e.cuds {
+		ok, err := appPart.IsOperationAllowed(ws, appdef.OperationKind_Select, rec.qName, nil, roles)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		cud := CUD{
+			ID:    rec.id,
+			QName: rec.qName.String(),
+			IsNew: rec.isNew,
+		}
+		if withFields {
+			cud.Fields = map[string]interface{}{}
+			for field, value := range rec.fields {
+				if ok, err = appPart.IsOperationAllowed(ws, appdef.OperationKind_Select, rec.qName, []appdef.FieldName{field}, roles); err != nil {
+					return nil, err
+				}
+				if ok {
+					cud.Fields[field] = value
+				}
+			}
+		}
+		cuds = append(cuds, cud)
+	}
+	return cuds, nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package n10ncuds
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// IN10nCUDs provides the summary of the changes the subscriber is notified about,
+// so that the subscriber does not need to query the changed data
+//
+// The view is notified with the WLog offset of the event which updated the view,
+// so the summary describes CUDs of the events stored in the workspace WLog up to that offset
+type IN10nCUDs interface {
+	// Authenticates the subscriber by the token in the workspace
+	// Principals are obtained per workspace and then passed to Summary()
+	Principals(ctx context.Context, app appdef.AppQName, wsid istructs.WSID, token string) ([]iauthnz.Principal, error)
+
+	// Returns CUDs of the events stored in the workspace WLog at offsets from fromOffset to toOffset inclusive
+	// view: CUDs of the events which trigger no projector that updates the view are skipped
+	// no CUDs are skipped if the view is not found in AppDef or there are no projectors that update it
+	// CUDs of the records the principals are not allowed to select are skipped
+	// withFields: the changed fields the principals are allowed to select are provided
+	// Events are read from the WLog once and then shared by all subscribers
+	// Errors: ErrEventNotFound, ErrWorkspaceNotInited
+	Summary(ctx context.Context, app appdef.AppQName, wsid istructs.WSID, view appdef.QName, fromOffset, toOffset istructs.Offset,
+		principals []iauthnz.Principal, withFields bool) ([]CUD, error)
+}
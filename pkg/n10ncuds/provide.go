@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package n10ncuds
+
+import (
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/objcache"
+)
+
+// Provide s.e.
+// The query processor partition is borrowed for a short time on each call
+func Provide(appParts appparts.IAppPartitions, authn iauthnz.IAuthenticator) IN10nCUDs {
+	return &implIN10nCUDs{
+		appParts: appParts,
+		authn:    authn,
+		events:   objcache.New[eventKey, *event](eventsCacheSize),
+	}
+}
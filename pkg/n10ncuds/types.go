@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package n10ncuds
+
+import (
+	"sync"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/objcache"
+)
+
+// CUD describes the record created or updated by the event
+type CUD struct {
+	ID     istructs.RecordID      `json:"id"`
+	QName  string                 `json:"qname"`
+	IsNew  bool                   `json:"isNew"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+type implIN10nCUDs struct {
+	appParts appparts.IAppPartitions
+	authn    iauthnz.IAuthenticator
+	events   objcache.ICache[eventKey, *event]
+	views    sync.Map // viewKey -> []appdef.IProjector
+}
+
+type viewKey struct {
+	appDef appdef.IAppDef
+	view   appdef.QName
+}
+
+type eventKey struct {
+	app    appdef.AppQName
+	wsid   istructs.WSID
+	offset istructs.Offset
+}
+
+// event read from the WLog, kept regardless of the principals and the view
+type event struct {
+	qName    appdef.QName
+	argQName appdef.QName
+	cuds     []eventCUD
+}
+
+type eventCUD struct {
+	id          istructs.RecordID
+	qName       appdef.QName
+	isNew       bool
+	activated   bool
+	deactivated bool
+	fields      map[appdef.FieldName]interface{}
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"regexp"
+	"time"
+)
+
+const (
+	// logins of external subjects start with the prefix
+	// the prefix can not be used by regular logins since ':' is not allowed there
+	LoginPrefix = "oidc:"
+
+	discoveryPath      = "/.well-known/openid-configuration"
+	pkceMethod         = "S256"
+	randomBytesLen     = 32
+	loginHashLen       = 16
+	defaultHTTPTimeout = 10 * time.Second
+	clockSkew          = time.Minute
+)
+
+var (
+	defaultScopes     = []string{"openid", "email", "profile"}
+	validProviderName = regexp.MustCompile(`^[a-z0-9-]+$`)
+	idTokenMethods    = []string{"RS256", "ES256", "EdDSA"}
+)
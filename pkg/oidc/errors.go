@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import "errors"
+
+var (
+	ErrInvalidProviderConfig = errors.New("invalid identity provider config")
+	ErrUnknownProvider       = errors.New("unknown identity provider")
+	ErrIdP                   = errors.New("identity provider error")
+	ErrInvalidIDToken        = errors.New("invalid ID token")
+)
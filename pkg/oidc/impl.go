@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+func (rp *relyingParty) AuthCodeURL(ctx context.Context, providerName string, redirectURI string) (authURL string, state AuthState, err error) {
+	p, err := rp.provider(providerName)
+	if err != nil {
+		return "", state, err
+	}
+	d, err := rp.discover(ctx, p)
+	if err != nil {
+		return "", state, err
+	}
+	state = AuthState{
+		Provider:     providerName,
+		RedirectURI:  redirectURI,
+		State:        randomString(),
+		Nonce:        randomString(),
+		CodeVerifier: randomString(),
+	}
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state.State},
+		"nonce":                 {state.Nonce},
+		"code_challenge":        {codeChallenge(state.CodeVerifier)},
+		"code_challenge_method": {pkceMethod},
+	}
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return d.AuthorizationEndpoint + separator + query.Encode(), state, nil
+}
+
+func (rp *relyingParty) Exchange(ctx context.Context, state AuthState, code string) (identity Identity, err error) {
+	p, err := rp.provider(state.Provider)
+	if err != nil {
+		return identity, err
+	}
+	d, err := rp.discover(ctx, p)
+	if err != nil {
+		return identity, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {state.RedirectURI},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {state.CodeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return identity, fmt.Errorf("%w: %w", ErrIdP, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if len(p.cfg.ClientSecret) > 0 {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	tokenResp := tokenResponse{}
+	if err := rp.do(req, &tokenResp); err != nil {
+		return identity, err
+	}
+	if len(tokenResp.IDToken) == 0 {
+		return identity, fmt.Errorf("%w: token response contains no id_token", ErrIdP)
+	}
+
+	claims := idTokenClaims{}
+	_, err = jwt.ParseWithClaims(tokenResp.IDToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return rp.publicKey(ctx, p, kid)
+	},
+		jwt.WithValidMethods(idTokenMethods),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(rp.iTime.Now),
+	)
+	if err != nil {
+		return identity, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+	if claims.Nonce != state.Nonce {
+		return identity, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if len(claims.Subject) == 0 {
+		return identity, fmt.Errorf("%w: sub claim is empty", ErrInvalidIDToken)
+	}
+	return Identity{
+		Provider:      state.Provider,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+func (rp *relyingParty) provider(name string) (*provider, error) {
+	p, ok := rp.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+func (rp *relyingParty) discover(ctx context.Context, p *provider) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.cfg.Issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIdP, err)
+	}
+	d := &discovery{}
+	if err := rp.do(req, d); err != nil {
+		return nil, err
+	}
+	// Ref. https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("%w: discovered issuer %s does not match the configured one %s", ErrIdP, d.Issuer, p.cfg.Issuer)
+	}
+	if len(d.AuthorizationEndpoint) == 0 || len(d.TokenEndpoint) == 0 || len(d.JWKSURI) == 0 {
+		return nil, fmt.Errorf("%w: authorization, token or jwks endpoint is not discovered", ErrIdP)
+	}
+	p.discovery = d
+	return d, nil
+}
+
+// keys are fetched again if the kid is unknown, the identity provider could rotate them
+func (rp *relyingParty) publicKey(ctx context.Context, p *provider, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.discovery.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIdP, err)
+	}
+	jwks := itokens.JWKSet{}
+	if err := rp.do(req, &jwks); err != nil {
+		return nil, err
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if len(jwk.Use) > 0 && jwk.Use != "sig" {
+			continue
+		}
+		key, err := publicKey(jwk)
+		if err != nil {
+			// keys of unsupported types are just skipped
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("key %q is not found in the identity provider key set", kid)
+}
+
+// unmarshals the JSON response, response of the token endpoint could contain the error description
+func (rp *relyingParty) do(req *http.Request, result interface{}) error {
+	resp, err := rp.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrIdP, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read %s response: %w", ErrIdP, req.URL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		errResp := tokenResponse{}
+		if json.Unmarshal(body, &errResp) == nil && len(errResp.Error) > 0 {
+			return fmt.Errorf("%w: %s: %s %s", ErrIdP, req.URL, errResp.Error, errResp.ErrorDescription)
+		}
+		return fmt.Errorf("%w: %s: unexpected status code %d", ErrIdP, req.URL, resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal %s response: %w", ErrIdP, req.URL, err)
+	}
+	return nil
+}
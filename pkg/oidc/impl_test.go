@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+)
+
+const testRedirectURI = "https://voedger.test/callback"
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	mockTime := testingu.NewMockTime()
+	idp := NewMockIdP(mockTime)
+	defer idp.Close()
+
+	rp, err := Provide([]ProviderConfig{idp.Config("mock")}, mockTime)
+	require.NoError(err)
+
+	// the user agent is redirected to the identity provider
+	authURL, state, err := rp.AuthCodeURL(context.Background(), "mock", testRedirectURI)
+	require.NoError(err)
+	require.Equal("mock", state.Provider)
+	require.Equal(testRedirectURI, state.RedirectURI)
+	parsedAuthURL, err := url.Parse(authURL)
+	require.NoError(err)
+	require.Equal(idp.URL+"/authorize", parsedAuthURL.Scheme+"://"+parsedAuthURL.Host+parsedAuthURL.Path)
+	query := parsedAuthURL.Query()
+	require.Equal(state.State, query.Get("state"))
+	require.Equal(state.Nonce, query.Get("nonce"))
+	require.Equal(codeChallenge(state.CodeVerifier), query.Get("code_challenge"))
+	require.Equal("S256", query.Get("code_challenge_method"))
+	require.Equal("openid email profile", query.Get("scope"))
+
+	// the identity provider redirects the user agent back with the code
+	code := authorize(t, authURL, "user1", state.State)
+
+	identity, err := rp.Exchange(context.Background(), state, code)
+	require.NoError(err)
+	require.Equal(Identity{
+		Provider:      "mock",
+		Subject:       "user1",
+		Email:         "user1@idp.mock",
+		EmailVerified: true,
+		Name:          "user1",
+	}, identity)
+
+	t.Run("code could be used once only", func(t *testing.T) {
+		_, err := rp.Exchange(context.Background(), state, code)
+		require.ErrorIs(err, ErrIdP)
+		require.Contains(err.Error(), "invalid_grant")
+	})
+}
+
+func TestExchangeErrors(t *testing.T) {
+	require := require.New(t)
+	mockTime := testingu.NewMockTime()
+	idp := NewMockIdP(mockTime)
+	defer idp.Close()
+	rp, err := Provide([]ProviderConfig{idp.Config("mock")}, mockTime)
+	require.NoError(err)
+
+	begin := func() (AuthState, string) {
+		authURL, state, err := rp.AuthCodeURL(context.Background(), "mock", testRedirectURI)
+		require.NoError(err)
+		return state, authorize(t, authURL, "user1", state.State)
+	}
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		state, code := begin()
+		state.CodeVerifier = "wrong"
+		_, err := rp.Exchange(context.Background(), state, code)
+		require.ErrorIs(err, ErrIdP)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		state, code := begin()
+		state.Nonce = "wrong"
+		_, err := rp.Exchange(context.Background(), state, code)
+		require.ErrorIs(err, ErrInvalidIDToken)
+	})
+
+	t.Run("expired ID token", func(t *testing.T) {
+		state, code := begin()
+		lateTime := testingu.NewMockTime()
+		lateTime.Add(mockIdPTokenTTL + 2*clockSkew)
+		lateRP, err := Provide([]ProviderConfig{idp.Config("mock")}, lateTime)
+		require.NoError(err)
+		_, err = lateRP.Exchange(context.Background(), state, code)
+		require.ErrorIs(err, ErrInvalidIDToken)
+	})
+
+	t.Run("wrong client", func(t *testing.T) {
+		state, code := begin()
+		cfg := idp.Config("mock")
+		cfg.ClientSecret = "wrong"
+		otherRP, err := Provide([]ProviderConfig{cfg}, mockTime)
+		require.NoError(err)
+		_, err = otherRP.Exchange(context.Background(), state, code)
+		require.ErrorIs(err, ErrIdP)
+		require.Contains(err.Error(), "invalid_client")
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, _, err := rp.AuthCodeURL(context.Background(), "unknown", testRedirectURI)
+		require.ErrorIs(err, ErrUnknownProvider)
+		_, err = rp.Exchange(context.Background(), AuthState{Provider: "unknown"}, "code")
+		require.ErrorIs(err, ErrUnknownProvider)
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		cfg := idp.Config("mock")
+		cfg.Issuer += "/"
+		otherRP, err := Provide([]ProviderConfig{cfg}, mockTime)
+		require.NoError(err)
+		_, _, err = otherRP.AuthCodeURL(context.Background(), "mock", testRedirectURI)
+		require.ErrorIs(err, ErrIdP)
+	})
+}
+
+func TestProvideErrors(t *testing.T) {
+	valid := ProviderConfig{Name: "idp-1", Issuer: "https://idp.test", ClientID: "client"}
+	for name, providers := range map[string][]ProviderConfig{
+		"wrong name":    {{Name: "IdP", Issuer: valid.Issuer, ClientID: valid.ClientID}},
+		"duplicate":     {valid, valid},
+		"no issuer":     {{Name: valid.Name, ClientID: valid.ClientID}},
+		"no client ID":  {{Name: valid.Name, Issuer: valid.Issuer}},
+		"name with ':'": {{Name: "a:b", Issuer: valid.Issuer, ClientID: valid.ClientID}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rp, err := Provide(providers, testingu.MockTime)
+			require.ErrorIs(t, err, ErrInvalidProviderConfig)
+			require.Nil(t, rp)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	require := require.New(t)
+	login := Login("mock", "Some Subject|123")
+	require.True(IsLogin(login))
+	require.Regexp(`^oidc:mock:[0-9a-f]{32}$`, login)
+	require.Equal(login, Login("mock", "Some Subject|123"))
+	require.NotEqual(login, Login("other", "Some Subject|123"))
+	require.False(IsLogin("login"))
+}
+
+// follows the authorization URL as the user agent does and returns the code
+func authorize(t *testing.T, authURL string, subject string, expectedState string) (code string) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL + "&login_hint=" + url.QueryEscape(subject))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, expectedState, location.Query().Get("state"))
+	return location.Query().Get("code")
+}
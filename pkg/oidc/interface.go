@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import "context"
+
+// OpenID Connect relying party, authorization code flow with PKCE
+// Ref. https://openid.net/specs/openid-connect-core-1_0.html#CodeFlowAuth
+type IRelyingParty interface {
+	// returns URL of the identity provider authorization endpoint to redirect the user agent to
+	// state must be kept by the caller until the user agent is redirected back to redirectURI, see AuthState
+	// ErrUnknownProvider, ErrIdP
+	AuthCodeURL(ctx context.Context, provider string, redirectURI string) (authURL string, state AuthState, err error)
+
+	// exchanges the authorization code for the ID token and verifies it
+	// state is the one returned by AuthCodeURL
+	// ErrUnknownProvider, ErrIdP, ErrInvalidIDToken
+	Exchange(ctx context.Context, state AuthState, code string) (Identity, error)
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
+
+func Provide(providers []ProviderConfig, iTime timeu.ITime) (IRelyingParty, error) {
+	rp := &relyingParty{
+		providers:  map[string]*provider{},
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+		iTime:      iTime,
+	}
+	for _, cfg := range providers {
+		if !validProviderName.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("%w: provider name %q must consist of lowercase letters, digits and '-'", ErrInvalidProviderConfig, cfg.Name)
+		}
+		if _, ok := rp.providers[cfg.Name]; ok {
+			return nil, fmt.Errorf("%w: provider %s is duplicated", ErrInvalidProviderConfig, cfg.Name)
+		}
+		if len(cfg.Issuer) == 0 || len(cfg.ClientID) == 0 {
+			return nil, fmt.Errorf("%w: Issuer and ClientID of provider %s must be specified", ErrInvalidProviderConfig, cfg.Name)
+		}
+		rp.providers[cfg.Name] = &provider{cfg: cfg}
+	}
+	return rp, nil
+}
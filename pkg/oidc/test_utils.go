@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+const (
+	mockIdPKID          = "mock-key"
+	mockIdPClientID     = "voedger"
+	mockIdPClientSecret = "secret"
+	mockIdPTokenTTL     = time.Hour
+	mockIdPEmailDomain  = "@idp.mock"
+)
+
+// identity provider for tests
+// the subject to authenticate is taken from login_hint param of the authorization request, the user agent is redirected back immediately
+// e-mail of the subject is <subject>@idp.mock
+type MockIdP struct {
+	*httptest.Server
+	key   *ecdsa.PrivateKey
+	iTime timeu.ITime
+	mu    sync.Mutex
+	codes map[string]mockAuthCode
+}
+
+type mockAuthCode struct {
+	subject     string
+	redirectURI string
+	nonce       string
+	challenge   string
+}
+
+func NewMockIdP(iTime timeu.ITime) *MockIdP {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		// notest
+		panic(err)
+	}
+	idp := &MockIdP{
+		key:   key,
+		iTime: iTime,
+		codes: map[string]mockAuthCode{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, idp.discovery)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+func (idp *MockIdP) Config(name string) ProviderConfig {
+	return ProviderConfig{
+		Name:         name,
+		Issuer:       idp.URL,
+		ClientID:     mockIdPClientID,
+		ClientSecret: mockIdPClientSecret,
+	}
+}
+
+func MockIdPEmail(subject string) string {
+	return subject + mockIdPEmailDomain
+}
+
+func (idp *MockIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	mockIdPReply(w, http.StatusOK, discovery{
+		Issuer:                idp.URL,
+		AuthorizationEndpoint: idp.URL + "/authorize",
+		TokenEndpoint:         idp.URL + "/token",
+		JWKSURI:               idp.URL + "/jwks",
+	})
+}
+
+func (idp *MockIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	subject := q.Get("login_hint")
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if len(subject) == 0 || err != nil || q.Get("client_id") != mockIdPClientID || q.Get("code_challenge_method") != pkceMethod ||
+		q.Get("response_type") != "code" {
+		mockIdPReply(w, http.StatusBadRequest, tokenResponse{Error: "invalid_request"})
+		return
+	}
+	code := randomString()
+	idp.mu.Lock()
+	idp.codes[code] = mockAuthCode{
+		subject:     subject,
+		redirectURI: redirectURI.String(),
+		nonce:       q.Get("nonce"),
+		challenge:   q.Get("code_challenge"),
+	}
+	idp.mu.Unlock()
+	redirectQuery := redirectURI.Query()
+	redirectQuery.Set("code", code)
+	redirectQuery.Set("state", q.Get("state"))
+	redirectURI.RawQuery = redirectQuery.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (idp *MockIdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, _ := r.BasicAuth()
+	if clientID != mockIdPClientID || clientSecret != mockIdPClientSecret {
+		mockIdPReply(w, http.StatusUnauthorized, tokenResponse{Error: "invalid_client"})
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		mockIdPReply(w, http.StatusBadRequest, tokenResponse{Error: "invalid_request"})
+		return
+	}
+	idp.mu.Lock()
+	code, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code")) // the code could be used once only
+	idp.mu.Unlock()
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("redirect_uri") != code.redirectURI ||
+		codeChallenge(r.PostForm.Get("code_verifier")) != code.challenge {
+		mockIdPReply(w, http.StatusBadRequest, tokenResponse{Error: "invalid_grant"})
+		return
+	}
+	now := idp.iTime.Now()
+	claims := idTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idp.URL,
+			Subject:   code.subject,
+			Audience:  jwt.ClaimStrings{mockIdPClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(mockIdPTokenTTL)),
+		},
+		Nonce:         code.nonce,
+		Email:         MockIdPEmail(code.subject),
+		EmailVerified: true,
+		Name:          code.subject,
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	idToken.Header["kid"] = mockIdPKID
+	signed, err := idToken.SignedString(idp.key)
+	if err != nil {
+		// notest
+		mockIdPReply(w, http.StatusInternalServerError, tokenResponse{Error: "server_error", ErrorDescription: err.Error()})
+		return
+	}
+	mockIdPReply(w, http.StatusOK, tokenResponse{IDToken: signed})
+}
+
+func (idp *MockIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	ecdhKey, err := idp.key.PublicKey.ECDH()
+	if err != nil {
+		// notest
+		panic(err)
+	}
+	point := ecdhKey.Bytes() // 0x04 || X || Y
+	coordSize := (len(point) - 1) / 2
+	enc := base64.RawURLEncoding.EncodeToString
+	mockIdPReply(w, http.StatusOK, itokens.JWKSet{Keys: []itokens.JWK{{
+		Kty: "EC",
+		Kid: mockIdPKID,
+		Use: "sig",
+		Alg: "ES256",
+		Crv: "P-256",
+		X:   enc(point[1 : 1+coordSize]),
+		Y:   enc(point[1+coordSize:]),
+	}}})
+}
+
+func mockIdPReply(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		// notest
+		panic(err)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"crypto"
+	"net/http"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
+
+type ProviderConfig struct {
+	// lowercase letters, digits and '-', goes to URLs and logins
+	Name string
+
+	// endpoints are discovered at Issuer + "/.well-known/openid-configuration"
+	Issuer string
+
+	ClientID string
+
+	// empty -> public client, the code is protected by PKCE only
+	ClientSecret string
+
+	// empty -> openid email profile
+	Scopes []string
+}
+
+// authenticated external subject
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// must not be exposed to the user agent as is, e.g. is kept in the signed HttpOnly cookie
+type AuthState struct {
+	Provider     string
+	RedirectURI  string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+type provider struct {
+	cfg ProviderConfig
+
+	// discovered lazily, keys are refreshed on unknown kid
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]crypto.PublicKey
+}
+
+type relyingParty struct {
+	providers  map[string]*provider
+	httpClient *http.Client
+	iTime      timeu.ITime
+}
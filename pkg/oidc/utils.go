@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+// Login returns registry login the external subject is mapped to
+// the subject is hashed since it could be long and could contain characters which are not allowed in logins
+func Login(provider string, subject string) string {
+	hash := sha256.Sum256([]byte(subject))
+	return LoginPrefix + provider + ":" + hex.EncodeToString(hash[:loginHashLen])
+}
+
+func IsLogin(login string) bool {
+	return strings.HasPrefix(login, LoginPrefix)
+}
+
+func randomString() string {
+	b := make([]byte, randomBytesLen)
+	if _, err := rand.Read(b); err != nil {
+		// notest
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Ref. https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func codeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func publicKey(jwk itokens.JWK) (crypto.PublicKey, error) {
+	dec := base64.RawURLEncoding.DecodeString
+	switch {
+	case jwk.Kty == "RSA":
+		n, err := dec(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := dec(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case jwk.Kty == "EC" && jwk.Crv == "P-256":
+		x, err := dec(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := dec(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		// checks the point is on the curve, uncompressed point is 0x04 || X || Y
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+		x, err := dec(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("wrong Ed25519 key size %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s %s", jwk.Kty, jwk.Crv)
+}
@@ -47,6 +47,10 @@ var ErrPositiveValueOnly = errors.New("positive value only allowed")
 var ErrBlobFieldOnlyInTable = errors.New("BLOB field only allowed in table")
 var ErrJobWithoutCronSchedule = errors.New("job without cron schedule is not allowed")
 var ErrQueryMustHaveReturn = errors.New("query must have a return type")
+var ErrDecimalPrecisionOutOfRange = fmt.Errorf("decimal precision must be between 1 and %d", appdef.MaxDecimalPrecision)
+var ErrDecimalScaleExceedsPrecision = errors.New("decimal scale must not exceed precision")
+var ErrIndexOnlyForCWTables = errors.New("INDEX is only allowed for CDoc, CRecord, WDoc or WRecord tables")
+var ErrTooManyIndexFields = fmt.Errorf("maximum number of index fields is %d", appdef.MaxTypeIndexFieldsCount)
 
 func ErrInvalidLocalPackageName(name string) error {
 	return fmt.Errorf("invalid local package name %s", name)
@@ -225,6 +229,10 @@ func ErrViewFieldBytes(name string) error {
 	return fmt.Errorf("bytes field %s not supported in partition key", name)
 }
 
+func ErrViewFieldJSON(name string) error {
+	return fmt.Errorf("json field %s not supported in partition key", name)
+}
+
 func ErrVarcharFieldInCC(name string) error {
 	return fmt.Errorf("varchar field %s can only be the last one in clustering key", name)
 }
@@ -233,6 +241,22 @@ func ErrBytesFieldInCC(name string) error {
 	return fmt.Errorf("bytes field %s can only be the last one in clustering key", name)
 }
 
+func ErrJSONFieldInCC(name string) error {
+	return fmt.Errorf("json field %s not supported in clustering key", name)
+}
+
+func ErrJSONFieldInIndex(name string) error {
+	return fmt.Errorf("json field %s not supported in index", name)
+}
+
+func ErrFieldAlreadyInIndex(name string) error {
+	return fmt.Errorf("field %s already in index", name)
+}
+
+func ErrIndexRedefined(name string) error {
+	return fmt.Errorf("redefinition of index %s", name)
+}
+
 func ErrLimitOperationNotAllowed(name string) error {
 	return fmt.Errorf("operation %s not allowed", name)
 }
@@ -7,6 +7,7 @@ package parser
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/participle/v2/lexer"
@@ -165,6 +166,8 @@ func analyse(c *basicContext, packages []*PackageSchemaAST) {
 				analyseRefFields(v.Items, ictx, appdef.TypeKind_Object)
 			case *ViewStmt:
 				analyseViewRefFields(v.Items, ictx)
+			case *IndexStmt:
+				analyzeIndex(v, ictx)
 			}
 		})
 	}
@@ -509,6 +512,14 @@ func analyzeStorage(u *StorageStmt, c *iterateCtx) {
 
 func analyzeRate(r *RateStmt, c *iterateCtx) {
 
+	if r.Value.Count != nil {
+		if *r.Value.Count <= 0 {
+			c.stmtErr(&r.Pos, ErrPositiveValueOnly)
+			return
+		}
+		r.Value.count = uint32(*r.Value.Count) // nolint G115: checked above
+	}
+
 	if r.Value.Variable != nil {
 		resolve := func(d *DeclareStmt, p *PackageSchemaAST) error {
 
@@ -533,6 +544,39 @@ func analyzeRate(r *RateStmt, c *iterateCtx) {
 	r.workspace = c.mustCurrentWorkspace()
 }
 
+func analyzeIndex(idx *IndexStmt, c *iterateCtx) {
+	if len(idx.Fields) > appdef.MaxTypeIndexFieldsCount {
+		c.stmtErr(&idx.Pos, ErrTooManyIndexFields)
+		return
+	}
+	for i, f := range idx.Fields {
+		if slices.Contains(idx.Fields[:i], f) {
+			c.stmtErr(&idx.Pos, ErrFieldAlreadyInIndex(string(f)))
+			return
+		}
+	}
+	err := resolveInCtx(idx.Table, c, func(t *TableStmt, _ *PackageSchemaAST) error {
+		switch t.tableTypeKind {
+		case appdef.TypeKind_CDoc, appdef.TypeKind_CRecord, appdef.TypeKind_WDoc, appdef.TypeKind_WRecord:
+		default:
+			return ErrIndexOnlyForCWTables
+		}
+		if t.Abstract {
+			return ErrIndexOnlyForCWTables
+		}
+		for _, i := range t.indexes {
+			if i.Name == idx.Name {
+				return ErrIndexRedefined(string(idx.Name))
+			}
+		}
+		t.indexes = append(t.indexes, idx)
+		return nil
+	})
+	if err != nil {
+		c.stmtErr(&idx.Pos, err)
+	}
+}
+
 func analyzeLimit(limit *LimitStmt, c *iterateCtx) {
 	err := resolveInCtx(limit.RateName, c, func(l *RateStmt, schema *PackageSchemaAST) error {
 		limit.RateName.qName = schema.NewQName(l.Name)
@@ -688,6 +732,25 @@ func analyzeDatatype(dt *DataType, c *iterateCtx, isTable bool) {
 			c.stmtErr(&bb.Pos, ErrMaxFieldLengthTooLarge)
 		}
 	}
+	jj := dt.JSON
+	if jj != nil && jj.MaxLen != nil {
+		if *jj.MaxLen > uint64(appdef.MaxFieldLength) {
+			c.stmtErr(&jj.Pos, ErrMaxFieldLengthTooLarge)
+		}
+	}
+	dd := dt.Decimal
+	if dd != nil {
+		p := uint64(appdef.DefaultDecimalPrecision)
+		if dd.Precision != nil {
+			p = *dd.Precision
+			if p == 0 || p > uint64(appdef.MaxDecimalPrecision) {
+				c.stmtErr(&dd.Pos, ErrDecimalPrecisionOutOfRange)
+			}
+		}
+		if dd.Scale != nil && *dd.Scale > p {
+			c.stmtErr(&dd.Pos, ErrDecimalScaleExceedsPrecision)
+		}
+	}
 
 }
 
@@ -748,6 +811,9 @@ func analyzeView(view *ViewStmt, c *iterateCtx) {
 			if fld.Type.Bytes != nil {
 				c.stmtErr(&pkf.Pos, ErrViewFieldBytes(string(pkf.Value)))
 			}
+			if fld.Type.JSON != nil {
+				c.stmtErr(&pkf.Pos, ErrViewFieldJSON(string(pkf.Value)))
+			}
 		}
 	}
 
@@ -768,6 +834,9 @@ func analyzeView(view *ViewStmt, c *iterateCtx) {
 			if fld.Type.Bytes != nil && !last {
 				c.stmtErr(&ccf.Pos, ErrBytesFieldInCC(string(ccf.Value)))
 			}
+			if fld.Type.JSON != nil {
+				c.stmtErr(&ccf.Pos, ErrJSONFieldInCC(string(ccf.Value)))
+			}
 		}
 	}
 
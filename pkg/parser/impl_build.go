@@ -517,6 +517,12 @@ func (c *buildContext) views() error {
 					if (f.Type.Varchar != nil) && (f.Type.Varchar.MaxLen != nil) {
 						cc = append(cc, constraints.MaxLen(uint16(*f.Type.Varchar.MaxLen))) // nolint G115: checked in [analyseFields]
 					}
+				case appdef.DataKind_decimal:
+					cc = append(cc, decimalConstraints(f.Type.Decimal)...)
+				case appdef.DataKind_json:
+					if (f.Type.JSON != nil) && (f.Type.JSON.MaxLen != nil) {
+						cc = append(cc, constraints.MaxLen(uint16(*f.Type.JSON.MaxLen))) // nolint G115: checked in [analyseFields]
+					}
 				}
 				return cc
 			}
@@ -528,7 +534,7 @@ func (c *buildContext) views() error {
 					}
 				}
 				if f.Field != nil {
-					vb().Key().PartKey().AddField(string(f.Field.Name.Value), dataTypeToDataKind(f.Field.Type))
+					vb().Key().PartKey().AddField(string(f.Field.Name.Value), dataTypeToDataKind(f.Field.Type), resolveConstraints(f.Field)...)
 					comment(f.Field.Name.Value, f.Field.Statement)
 					return
 				}
@@ -715,9 +721,32 @@ func (c *buildContext) table(schema *PackageSchemaAST, table *TableStmt) {
 		c.defCtx().defBuilder.(appdef.IWithAbstractBuilder).SetAbstract()
 	}
 	c.applyTags(table.With, c.defCtx().defBuilder.(appdef.ITagger))
+	c.addIndexesToDef(table.indexes)
 	c.popDef()
 }
 
+func (c *buildContext) addIndexesToDef(indexes []*IndexStmt) {
+	tabName := c.defCtx().qname
+	tab := c.adb.AppDef().Type(tabName).(appdef.IWithFields)
+nextIndex:
+	for _, idx := range indexes {
+		fields := make([]appdef.FieldName, len(idx.Fields))
+		for i, f := range idx.Fields {
+			fld := tab.Field(string(f))
+			if fld == nil {
+				c.stmtErr(&idx.Pos, ErrUndefinedField(string(f)))
+				continue nextIndex
+			}
+			if fld.DataKind() == appdef.DataKind_json {
+				c.stmtErr(&idx.Pos, ErrJSONFieldInIndex(string(f)))
+				continue nextIndex
+			}
+			fields[i] = string(f)
+		}
+		c.defCtx().defBuilder.(appdef.IIndexesBuilder).AddIndex(appdef.IndexQName(tabName, string(idx.Name)), fields, idx.Comments...)
+	}
+}
+
 func (c *buildContext) addFieldRefToDef(refField *RefFieldExpr) {
 	if err := c.defCtx().checkName(string(refField.Name)); err != nil {
 		c.stmtErr(&refField.Pos, err)
@@ -757,6 +786,14 @@ func (c *buildContext) addDataTypeField(field *FieldExpr) {
 			cc = append(cc, constraints.Pattern(field.CheckRegexp.Regexp))
 		}
 		bld.AddField(fieldName, appdef.DataKind_string, field.NotNull, cc...)
+	} else if field.Type.DataType.JSON != nil {
+		if field.Type.DataType.JSON.MaxLen != nil {
+			bld.AddField(fieldName, appdef.DataKind_json, field.NotNull, constraints.MaxLen(uint16(*field.Type.DataType.JSON.MaxLen))) // nolint G115: checked in [analyseFields]
+		} else {
+			bld.AddField(fieldName, appdef.DataKind_json, field.NotNull)
+		}
+	} else if field.Type.DataType.Decimal != nil {
+		bld.AddField(fieldName, appdef.DataKind_decimal, field.NotNull, decimalConstraints(field.Type.DataType.Decimal)...)
 	} else if field.Type.DataType.Blob {
 		bld.AddRefField(fieldName, field.NotNull, QNameWDocBLOB)
 	} else {
@@ -880,6 +917,7 @@ func (c *buildContext) addNestedTableToDef(schema *PackageSchemaAST, nested *Nes
 		c.pushDef(contQName, nestedTable.tableTypeKind, nestedTable.workspace)
 		c.addTableItems(schema, nestedTable.Items)
 		c.applyTags(nestedTable.With, c.defCtx().defBuilder.(appdef.ITagger))
+		c.addIndexesToDef(nestedTable.indexes)
 		c.popDef()
 	}
 
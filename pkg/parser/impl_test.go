@@ -1763,6 +1763,305 @@ func Test_1KStringField(t *testing.T) {
 	require.Equal(1, cnt)
 }
 
+func Test_DecimalFields(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.vsql", `APPLICATION test(); WORKSPACE MyWorkspace(
+	TABLE Invoice INHERITS sys.CDoc (
+		Amount decimal(12, 2) NOT NULL,
+		Rate numeric(5),
+		Total decimal
+	);
+	TYPE Payment (
+		Amount decimal(10,4)
+	);
+	VIEW Payments (
+		Account int64,
+		Amount decimal(12,2),
+		Total decimal(18,2),
+		PRIMARY KEY ((Account), Amount)
+	) AS RESULT OF Proj;
+	EXTENSION ENGINE BUILTIN (
+		PROJECTOR Proj AFTER EXECUTE ON (Pay) INTENTS(sys.View(Payments));
+		COMMAND Pay(Payment);
+	);
+)
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{
+		getSysPackageAST(),
+		pkg,
+	})
+	require.NoError(err)
+
+	appBld := builder.New()
+	err = BuildAppDefs(packages, appBld)
+	require.NoError(err)
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	checkDecimal := func(fld appdef.IField, precision, scale uint8) {
+		require.NotNil(fld)
+		require.Equal(appdef.DataKind_decimal, fld.DataKind())
+		p, s := appdef.DecimalPrecisionScale(fld.Constraints())
+		require.Equal(precision, p, fld.Name())
+		require.Equal(scale, s, fld.Name())
+	}
+
+	cdoc := appdef.CDoc(app.Type, appdef.NewQName("test", "Invoice"))
+	require.NotNil(cdoc)
+	checkDecimal(cdoc.Field("Amount"), 12, 2)
+	require.True(cdoc.Field("Amount").Required())
+	checkDecimal(cdoc.Field("Rate"), 5, appdef.DefaultDecimalScale)
+	checkDecimal(cdoc.Field("Total"), appdef.DefaultDecimalPrecision, appdef.DefaultDecimalScale)
+
+	obj := appdef.Object(app.Type, appdef.NewQName("test", "Payment"))
+	require.NotNil(obj)
+	checkDecimal(obj.Field("Amount"), 10, 4)
+
+	view := appdef.View(app.Type, appdef.NewQName("test", "Payments"))
+	require.NotNil(view)
+	checkDecimal(view.Key().ClustCols().Field("Amount"), 12, 2)
+	checkDecimal(view.Value().Field("Total"), 18, 2)
+
+	t.Run("should be errors for wrong precision and scale", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE MyWorkspace(
+	TABLE Invoice INHERITS sys.CDoc (
+		F1 decimal(0),
+		F2 decimal(19, 2),
+		F3 decimal(4, 5)
+	);
+)`, "file.vsql:3:6: decimal precision must be between 1 and 18",
+			"file.vsql:4:6: decimal precision must be between 1 and 18",
+			"file.vsql:5:6: decimal scale must not exceed precision")
+	})
+}
+
+func Test_TemporalFields(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("example.vsql", `APPLICATION test(); WORKSPACE MyWorkspace(
+	TABLE Visit INHERITS sys.CDoc (
+		StartedAt timestamp NOT NULL,
+		Day date,
+		Duration interval
+	);
+	VIEW Visits (
+		Day date,
+		StartedAt timestamp,
+		Duration interval,
+		PRIMARY KEY ((Day), StartedAt)
+	) AS RESULT OF Proj;
+	EXTENSION ENGINE BUILTIN (
+		PROJECTOR Proj AFTER INSERT ON (Visit) INTENTS(sys.View(Visits));
+	);
+)
+	`)
+	require.NoError(err)
+
+	pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	packages, err := BuildAppSchema([]*PackageSchemaAST{
+		getSysPackageAST(),
+		pkg,
+	})
+	require.NoError(err)
+
+	appBld := builder.New()
+	err = BuildAppDefs(packages, appBld)
+	require.NoError(err)
+
+	app, err := appBld.Build()
+	require.NoError(err)
+
+	cdoc := appdef.CDoc(app.Type, appdef.NewQName("test", "Visit"))
+	require.NotNil(cdoc)
+	require.Equal(appdef.DataKind_timestamp, cdoc.Field("StartedAt").DataKind())
+	require.True(cdoc.Field("StartedAt").Required())
+	require.Equal(appdef.DataKind_date, cdoc.Field("Day").DataKind())
+	require.Equal(appdef.DataKind_interval, cdoc.Field("Duration").DataKind())
+
+	view := appdef.View(app.Type, appdef.NewQName("test", "Visits"))
+	require.NotNil(view)
+	require.Equal(appdef.DataKind_date, view.Key().PartKey().Field("Day").DataKind())
+	require.Equal(appdef.DataKind_timestamp, view.Key().ClustCols().Field("StartedAt").DataKind())
+	require.Equal(appdef.DataKind_interval, view.Value().Field("Duration").DataKind())
+}
+
+func Test_UUIDAndJSONFields(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("should be ok to build uuid and json fields", func(t *testing.T) {
+		fs, err := ParseFile("example.vsql", `APPLICATION test(); WORKSPACE MyWorkspace(
+	TABLE Device INHERITS sys.CDoc (
+		ExternalID uuid NOT NULL,
+		Settings json,
+		Labels json(1024)
+	);
+	VIEW Devices (
+		ExternalID uuid,
+		Seq int64,
+		Settings json,
+		PRIMARY KEY ((ExternalID), Seq)
+	) AS RESULT OF Proj;
+	EXTENSION ENGINE BUILTIN (
+		PROJECTOR Proj AFTER INSERT ON (Device) INTENTS(sys.View(Devices));
+	);
+)
+	`)
+		require.NoError(err)
+
+		pkg, err := BuildPackageSchema("test", []*FileSchemaAST{fs})
+		require.NoError(err)
+
+		packages, err := BuildAppSchema([]*PackageSchemaAST{
+			getSysPackageAST(),
+			pkg,
+		})
+		require.NoError(err)
+
+		appBld := builder.New()
+		err = BuildAppDefs(packages, appBld)
+		require.NoError(err)
+
+		app, err := appBld.Build()
+		require.NoError(err)
+
+		cdoc := appdef.CDoc(app.Type, appdef.NewQName("test", "Device"))
+		require.NotNil(cdoc)
+		require.Equal(appdef.DataKind_uuid, cdoc.Field("ExternalID").DataKind())
+		require.True(cdoc.Field("ExternalID").Required())
+		require.Equal(appdef.DataKind_json, cdoc.Field("Settings").DataKind())
+		require.Equal(appdef.DataKind_json, cdoc.Field("Labels").DataKind())
+		require.EqualValues(1024, cdoc.Field("Labels").Constraints()[appdef.ConstraintKind_MaxLen].Value())
+
+		view := appdef.View(app.Type, appdef.NewQName("test", "Devices"))
+		require.NotNil(view)
+		require.Equal(appdef.DataKind_uuid, view.Key().PartKey().Field("ExternalID").DataKind())
+		require.Equal(appdef.DataKind_json, view.Value().Field("Settings").DataKind())
+	})
+
+	t.Run("should be errors", func(t *testing.T) {
+		require := assertions(t)
+
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		TABLE Device INHERITS sys.CDoc (
+			Settings json(65536)
+		);
+	)
+	`, "file.vsql:3:13: maximum field length is 65535")
+
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		VIEW test(
+			field1 json,
+			field2 int,
+			PRIMARY KEY((field1), field2)
+		) AS RESULT OF Proj1;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (sys.View(test));
+			COMMAND Orders()
+		);
+	)
+	`, "file.vsql:5:17: json field field1 not supported in partition key")
+
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		VIEW test(
+			field1 int,
+			field2 json,
+			PRIMARY KEY((field1), field2)
+		) AS RESULT OF Proj1;
+		EXTENSION ENGINE BUILTIN (
+			PROJECTOR Proj1 AFTER EXECUTE ON (Orders) INTENTS (sys.View(test));
+			COMMAND Orders()
+		);
+	)
+	`, "file.vsql:5:26: json field field2 not supported in clustering key")
+	})
+}
+
+func Test_Indexes(t *testing.T) {
+	require := assertions(t)
+
+	t.Run("should be ok to build indexes", func(t *testing.T) {
+		app := require.Build(`APPLICATION test(); WORKSPACE MyWorkspace(
+	TABLE Customer INHERITS sys.CDoc (
+		Name varchar NOT NULL,
+		City varchar,
+		Birthday date,
+		Extra json
+	);
+	TABLE Order INHERITS sys.WDoc (
+		Customer ref(Customer),
+		Total int64
+	);
+	-- customers by city and name
+	INDEX ByCityName ON TABLE Customer (City, Name);
+	INDEX ByBirthday ON TABLE Customer (Birthday);
+	INDEX ByCustomer ON TABLE Order (Customer);
+)
+	`)
+
+		cdoc := appdef.CDoc(app.Type, appdef.NewQName("pkg", "Customer"))
+		require.Equal(2, cdoc.IndexCount())
+		idx := cdoc.Index(appdef.IndexQName(cdoc.QName(), "ByCityName"))
+		require.NotNil(idx)
+		require.Equal("customers by city and name", idx.Comment())
+		require.Len(idx.Fields(), 2)
+		require.Equal("City", idx.Fields()[0].Name())
+		require.Equal("Name", idx.Fields()[1].Name())
+
+		wdoc := appdef.WDoc(app.Type, appdef.NewQName("pkg", "Order"))
+		require.Equal(1, wdoc.IndexCount())
+		require.Equal(appdef.DataKind_RecordID, wdoc.Indexes()[0].Fields()[0].DataKind())
+	})
+
+	t.Run("should be errors", func(t *testing.T) {
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		TABLE Bill INHERITS sys.ODoc (Total int64);
+		INDEX ByTotal ON TABLE Bill (Total);
+	)
+	`, "file.vsql:3:3: INDEX is only allowed for CDoc, CRecord, WDoc or WRecord tables")
+
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		TABLE Customer INHERITS sys.CDoc (Name varchar);
+		INDEX ByName ON TABLE Customer (Name, Name);
+	)
+	`, "file.vsql:3:3: field Name already in index")
+
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		TABLE Customer INHERITS sys.CDoc (Name varchar);
+		INDEX ByName ON TABLE Customer (Name);
+		INDEX ByName ON TABLE Customer (Name);
+	)
+	`, "file.vsql:4:3: redefinition of index ByName")
+
+		require.AppSchemaError(`APPLICATION test(); WORKSPACE Workspace (
+		INDEX ByName ON TABLE Unknown (Name);
+	)
+	`, "file.vsql:2:3: undefined table: Unknown")
+	})
+
+	t.Run("should be build errors", func(t *testing.T) {
+		schema, err := require.AppSchema(`APPLICATION test(); WORKSPACE Workspace (
+		TABLE Customer INHERITS sys.CDoc (Name varchar, Extra json);
+		INDEX ByUnknown ON TABLE Customer (Unknown);
+		INDEX ByExtra ON TABLE Customer (Extra);
+	)
+	`)
+		require.NoError(err)
+		err = BuildAppDefs(schema, builder.New())
+		require.ErrorContains(err, "file.vsql:3:3: undefined field Unknown")
+		require.ErrorContains(err, "file.vsql:4:3: json field Extra not supported in index")
+	})
+}
+
 func Test_ReferenceToNoTable(t *testing.T) {
 	require := require.New(t)
 
@@ -2733,6 +3032,7 @@ func Test_RatesAndLimits(t *testing.T) {
 		r, ok := typ.(appdef.IRate)
 		require.True(ok)
 		require.NotNil(r)
+		require.EqualValues(1, r.Count())
 		require.True(r.Scope(appdef.RateScope_AppPartition))
 		require.False(r.Scope(appdef.RateScope_Workspace))
 		require.False(r.Scope(appdef.RateScope_IP))
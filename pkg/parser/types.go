@@ -134,6 +134,7 @@ type WorkspaceStatement struct {
 	Rate         *RateStmt         `parser:"@@"`
 	View         *ViewStmt         `parser:"| @@"`
 	UseWorkspace *UseWorkspaceStmt `parser:"| @@"`
+	Index        *IndexStmt        `parser:"| @@"`
 
 	// Also allowed in workspace
 	Role      *RoleStmt               `parser:"| @@"`
@@ -313,11 +314,22 @@ type TypeVarchar struct {
 	MaxLen *uint64 `parser:"(('character' 'varying') | 'varchar' | 'text') ( '(' @Int ')' )?"`
 }
 
+type TypeJSON struct {
+	Pos    lexer.Position
+	MaxLen *uint64 `parser:"'json' ( '(' @Int ')' )?"`
+}
+
 type TypeBytes struct {
 	Pos    lexer.Position
 	MaxLen *uint64 `parser:"(('binary' 'varying') | 'varbinary' | 'bytes') ( '(' @Int ')' )?"`
 }
 
+type TypeDecimal struct {
+	Pos       lexer.Position
+	Precision *uint64 `parser:"('decimal' | 'numeric') ( '(' @Int"`
+	Scale     *uint64 `parser:"( ',' @Int )? ')' )?"`
+}
+
 type VoidOrDataType struct {
 	Void     bool           `parser:"( @'void'"`
 	DataType *DataTypeOrDef `parser:"| @@)"`
@@ -332,6 +344,8 @@ type DataType struct {
 	Pos       lexer.Position
 	Varchar   *TypeVarchar `parser:"( @@"`
 	Bytes     *TypeBytes   `parser:"| @@"`
+	Decimal   *TypeDecimal `parser:"| @@"`
+	JSON      *TypeJSON    `parser:"| @@"`
 	Int8      bool         `parser:"| @('tinyint' | 'int8')"`
 	Int16     bool         `parser:"| @('smallint' | 'int16')"`
 	Int32     bool         `parser:"| @('integer' | 'int' | 'int32')"`
@@ -339,6 +353,9 @@ type DataType struct {
 	Float32   bool         `parser:"| @('real' | 'float' | 'float32')"`
 	Float64   bool         `parser:"| @(('double' 'precision') | 'float64')"`
 	Timestamp bool         `parser:"| @'timestamp'"`
+	Date      bool         `parser:"| @'date'"`
+	Interval  bool         `parser:"| @'interval'"`
+	UUID      bool         `parser:"| @'uuid'"`
 	Currency  bool         `parser:"| @('money' | 'currency')"`
 	Bool      bool         `parser:"| @('boolean' | 'bool')"`
 	Blob      bool         `parser:"| @(('binary' 'large' 'object') | 'blob')"`
@@ -376,8 +393,28 @@ func (q DataType) String() (s string) {
 		return "blob"
 	} else if q.Timestamp {
 		return "timestamp"
+	} else if q.Date {
+		return "date"
+	} else if q.Interval {
+		return "interval"
+	} else if q.UUID {
+		return "uuid"
+	} else if q.JSON != nil {
+		if q.JSON.MaxLen != nil {
+			return fmt.Sprintf("json[%d]", *q.JSON.MaxLen)
+		}
+		return fmt.Sprintf("json[%d]", appdef.MaxFieldLength)
 	} else if q.Currency {
 		return "currency"
+	} else if q.Decimal != nil {
+		p, s := appdef.DefaultDecimalPrecision, appdef.DefaultDecimalScale
+		if q.Decimal.Precision != nil {
+			p = uint8(*q.Decimal.Precision) // nolint G115: checked in [analyzeDatatype]
+		}
+		if q.Decimal.Scale != nil {
+			s = uint8(*q.Decimal.Scale) // nolint G115: checked in [analyzeDatatype]
+		}
+		return fmt.Sprintf("decimal(%d,%d)", p, s)
 	}
 
 	return "?"
@@ -651,6 +688,13 @@ type LimitStmt struct {
 
 func (s LimitStmt) GetName() string { return string(s.Name) }
 
+type IndexStmt struct {
+	Statement
+	Name   Ident    `parser:"'INDEX' @Ident"`
+	Table  DefQName `parser:"ONTABLE @@"`
+	Fields []Ident  `parser:"'(' @Ident (',' @Ident)* ')'"`
+}
+
 type GrantColumn struct {
 	Pos     lexer.Position
 	SysName string      `parser:"@(('sys' '.' 'ID') | 'sys' '.' 'ParentID' | 'sys' '.' 'IsActive' | 'sys' '.' 'QName' | 'sys' '.' 'Container')"`
@@ -943,6 +987,7 @@ type TableStmt struct {
 	workspace     workspaceAddr
 	inherits      tableAddr
 	singleton     bool
+	indexes       []*IndexStmt
 }
 
 func (s *TableStmt) GetName() string { return string(s.Name) }
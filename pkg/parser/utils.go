@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/constraints"
 )
 
 func extractStatement(s any) interface{} {
@@ -370,6 +371,9 @@ func dataTypeToDataKind(t DataType) appdef.DataKind {
 	if t.Currency {
 		return appdef.DataKind_int64
 	}
+	if t.Decimal != nil {
+		return appdef.DataKind_decimal
+	}
 	if t.Float32 {
 		return appdef.DataKind_float32
 	}
@@ -396,11 +400,35 @@ func dataTypeToDataKind(t DataType) appdef.DataKind {
 		return appdef.DataKind_string
 	}
 	if t.Timestamp {
-		return appdef.DataKind_int64
+		return appdef.DataKind_timestamp
+	}
+	if t.Date {
+		return appdef.DataKind_date
+	}
+	if t.Interval {
+		return appdef.DataKind_interval
+	}
+	if t.UUID {
+		return appdef.DataKind_uuid
+	}
+	if t.JSON != nil {
+		return appdef.DataKind_json
 	}
 	return appdef.DataKind_null
 }
 
+// Returns precision and scale constraints for decimal data type. Must be called only if valid data type
+func decimalConstraints(t *TypeDecimal) []appdef.IConstraint {
+	cc := []appdef.IConstraint{}
+	if t.Precision != nil {
+		cc = append(cc, constraints.Precision(uint8(*t.Precision))) // nolint G115: checked in [analyzeDatatype]
+	}
+	if t.Scale != nil {
+		cc = append(cc, constraints.Scale(uint8(*t.Scale))) // nolint G115: checked in [analyzeDatatype]
+	}
+	return cc
+}
+
 func buildQname(ctx *iterateCtx, pkg Ident, name Ident) appdef.QName {
 	if pkg == "" {
 		pkg = Ident(ctx.pkg.Name)
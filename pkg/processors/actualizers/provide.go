@@ -35,7 +35,8 @@ func NewSyncActualizerFactoryFactory(actualizerFactory SyncActualizerFactory, se
 	return func(appStructs istructs.IAppStructs, partitionID istructs.PartitionID) pipeline.ISyncOperator {
 		projectors := maps.Clone(appStructs.SyncProjectors())
 		for _, projector := range statelessResources.Projectors {
-			if appdef.Projector(appStructs.AppDef().Type, projector.Name).Sync() {
+			// could be missing in the app built with the previous version of the sys package, e.g. sidecar app
+			if prj := appdef.Projector(appStructs.AppDef().Type, projector.Name); prj != nil && prj.Sync() {
 				projectors[projector.Name] = projector
 			}
 		}
@@ -377,8 +377,12 @@ func checkWSActive(_ context.Context, work pipeline.IWorkpiece) (err error) {
 
 func limitCallRate(_ context.Context, work pipeline.IWorkpiece) (err error) {
 	cmd := work.(*cmdWorkpiece)
-	if cmd.appStructs.IsFunctionRateLimitsExceeded(cmd.cmdQName, cmd.cmdMes.WSID()) {
-		return coreutils.NewHTTPErrorf(http.StatusTooManyRequests)
+	if exceeded, state := istructsmem.FunctionRateLimitsExceeded(cmd.appStructs, cmd.cmdQName, cmd.cmdMes.WSID()); exceeded {
+		return coreutils.NewTooManyRequestsError(state)
+	}
+	// limits declared in VSQL
+	if exceeded, _, state := cmd.appPart.IsLimitExceeded(cmd.cmdQName, appdef.OperationKind_Execute, cmd.cmdMes.WSID(), cmd.cmdMes.Host()); exceeded {
+		return coreutils.NewTooManyRequestsError(state)
 	}
 	return nil
 }
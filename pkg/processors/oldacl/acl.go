@@ -473,6 +473,15 @@ var defaultACL = ACL{
 		},
 		policy: appdef.PolicyKind_Deny,
 	},
+	{
+		desc: "revoke select(Secret) on sys.Webhook",
+		pattern: PatternType{
+			opKindsPattern: []appdef.OperationKind{appdef.OperationKind_Select},
+			fieldsPattern:  [][]string{{"Secret"}},
+			qNamesPattern:  []appdef.QName{qNameCDocWebhook},
+		},
+		policy: appdef.PolicyKind_Deny,
+	},
 
 	// SubscriptionProfile
 }
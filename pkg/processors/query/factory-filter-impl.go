@@ -5,10 +5,16 @@
 package queryprocessor
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"strconv"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
 )
 
 var filterFactories = map[string]func(string, interface{}, coreutils.MapObject) (IFilter, error){
@@ -166,6 +172,55 @@ func nearlyEqual(a, b, epsilon float64) bool {
 	return diff/(absA+absB) < epsilon
 }
 
+// compares exact decimal string field value with filter value, which can be number or decimal string
+func compareDecimal(fieldValue any, filterValue any) (int, error) {
+	a, ok := new(big.Rat).SetString(fieldValue.(string))
+	if !ok {
+		return 0, fmt.Errorf("decimal field value %v: %w", fieldValue, ErrWrongType)
+	}
+	b := new(big.Rat)
+	switch v := filterValue.(type) {
+	case float64:
+		_, ok = b.SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	case json.Number:
+		_, ok = b.SetString(v.String())
+	case string:
+		_, ok = b.SetString(v)
+	default:
+		ok = false
+	}
+	if !ok {
+		return 0, fmt.Errorf("decimal filter value %v: %w", filterValue, ErrWrongType)
+	}
+	return a.Cmp(b), nil
+}
+
+// compares temporal string field value with filter value, which can be RFC 3339 or ISO 8601 duration string or milliseconds
+func compareTemporal(kind appdef.DataKind, fieldValue any, filterValue any) (int, error) {
+	a, err := coreutils.ParseTemporal(kind, fieldValue.(string))
+	if err != nil {
+		return 0, fmt.Errorf("%s field value %v: %w", kind.TrimString(), fieldValue, ErrWrongType)
+	}
+	var b int64
+	switch v := filterValue.(type) {
+	case float64:
+		b = int64(v)
+	case json.Number:
+		b, err = v.Int64()
+	case string:
+		b, err = coreutils.ParseTemporal(kind, v)
+	default:
+		err = ErrWrongType
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s filter value %v: %w", kind.TrimString(), filterValue, ErrWrongType)
+	}
+	if kind == appdef.DataKind_date {
+		b = utils.TruncateToDate(b)
+	}
+	return cmp.Compare(a, b), nil
+}
+
 //TODO (FILTER0002) dynamic prepare and validation?
 //type baseFilter struct {
 //	field       string
@@ -7,6 +7,7 @@ package queryprocessor
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
@@ -47,6 +48,9 @@ func (f EqualsFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, error
 		return nearlyEqual(float64Intf.(float64), outputRow.Value(f.field).(float64), f.epsilon), nil
 	case appdef.DataKind_string:
 		return outputRow.Value(f.field).(string) == f.value.(string), nil
+	case appdef.DataKind_uuid:
+		// canonical lowercase UUID string is compared case-insensitively
+		return strings.EqualFold(outputRow.Value(f.field).(string), f.value.(string)), nil
 	case appdef.DataKind_bool:
 		return outputRow.Value(f.field).(bool) == f.value.(bool), nil
 	case appdef.DataKind_RecordID:
@@ -57,6 +61,18 @@ func (f EqualsFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, error
 		return outputRow.Value(f.field).(istructs.RecordID) == recordIDIntf.(istructs.RecordID), nil
 	case appdef.DataKind_QName:
 		return outputRow.Value(f.field).(string) == f.value.(string), nil
+	case appdef.DataKind_decimal:
+		c, err := compareDecimal(outputRow.Value(f.field), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Eq, f.field, err)
+		}
+		return c == 0, nil
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		c, err := compareTemporal(fk[f.field], outputRow.Value(f.field), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Eq, f.field, err)
+		}
+		return c == 0, nil
 	case appdef.DataKind_null:
 		return false, nil
 	default:
@@ -102,6 +102,57 @@ func TestEqualsFilter_IsMatch(t *testing.T) {
 			require.False(t, match(heightFilter(42.71).IsMatch(fk, row(42.7))))
 		})
 	})
+	t.Run("Compare decimal", func(t *testing.T) {
+		row := func(price string) IOutputRow {
+			r := &testOutputRow{fields: []string{"price"}}
+			r.Set("price", price)
+			return r
+		}
+		fk := FieldsKinds{"price": appdef.DataKind_decimal}
+		priceFilter := func(price string) IFilter {
+			return &EqualsFilter{
+				field: "price",
+				value: json.Number(price),
+			}
+		}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match(priceFilter("42.7").IsMatch(fk, row("42.70"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match(priceFilter("42.71").IsMatch(fk, row("42.70"))))
+		})
+	})
+	t.Run("Compare date and interval", func(t *testing.T) {
+		row := func(day, duration string) IOutputRow {
+			r := &testOutputRow{fields: []string{"day", "duration"}}
+			r.Set("day", day)
+			r.Set("duration", duration)
+			return r
+		}
+		fk := FieldsKinds{"day": appdef.DataKind_date, "duration": appdef.DataKind_interval}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match((&EqualsFilter{field: "day", value: "2025-01-31"}).IsMatch(fk, row("2025-01-31", "PT1H"))))
+			require.True(t, match((&EqualsFilter{field: "duration", value: "PT60M"}).IsMatch(fk, row("2025-01-31", "PT1H"))))
+			require.True(t, match((&EqualsFilter{field: "duration", value: json.Number("3600000")}).IsMatch(fk, row("2025-01-31", "PT1H"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match((&EqualsFilter{field: "day", value: "2025-02-01"}).IsMatch(fk, row("2025-01-31", "PT1H"))))
+		})
+	})
+	t.Run("Compare uuid", func(t *testing.T) {
+		row := func(id string) IOutputRow {
+			r := &testOutputRow{fields: []string{"id"}}
+			r.Set("id", id)
+			return r
+		}
+		fk := FieldsKinds{"id": appdef.DataKind_uuid}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match((&EqualsFilter{field: "id", value: "123E4567-E89B-12D3-A456-426614174000"}).IsMatch(fk, row("123e4567-e89b-12d3-a456-426614174000"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match((&EqualsFilter{field: "id", value: "123e4567-e89b-12d3-a456-426614174001"}).IsMatch(fk, row("123e4567-e89b-12d3-a456-426614174000"))))
+		})
+	})
 	t.Run("Compare string", func(t *testing.T) {
 		row := func(name string) IOutputRow {
 			r := &testOutputRow{fields: []string{"name"}}
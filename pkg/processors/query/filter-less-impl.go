@@ -27,6 +27,18 @@ func (f LessFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, error)
 		return outputRow.Value(f.field).(float64) < f.value.(float64), nil
 	case appdef.DataKind_string:
 		return outputRow.Value(f.field).(string) < f.value.(string), nil
+	case appdef.DataKind_decimal:
+		c, err := compareDecimal(outputRow.Value(f.field), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Lt, f.field, err)
+		}
+		return c < 0, nil
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		c, err := compareTemporal(fk[f.field], outputRow.Value(f.field), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_Lt, f.field, err)
+		}
+		return c < 0, nil
 	case appdef.DataKind_null:
 		return false, nil
 	default:
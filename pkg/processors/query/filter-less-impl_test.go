@@ -96,6 +96,55 @@ func TestLessFilter_IsMatch(t *testing.T) {
 			require.False(t, match(heightFilter(42.69).IsMatch(fk, row(42.7))))
 		})
 	})
+	t.Run("Compare decimal", func(t *testing.T) {
+		row := func(price string) IOutputRow {
+			r := &testOutputRow{fields: []string{"price"}}
+			r.Set("price", price)
+			return r
+		}
+		fk := FieldsKinds{"price": appdef.DataKind_decimal}
+		priceFilter := func(price float64) IFilter {
+			return &LessFilter{
+				field: "price",
+				value: price,
+			}
+		}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match(priceFilter(0.11).IsMatch(fk, row("0.10"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match(priceFilter(0.1).IsMatch(fk, row("0.10"))))
+		})
+		t.Run("Should return error on wrong filter value", func(t *testing.T) {
+			_, err := (&LessFilter{field: "price", value: true}).IsMatch(fk, row("0.10"))
+			require.ErrorIs(t, err, ErrWrongType)
+		})
+	})
+	t.Run("Compare timestamp", func(t *testing.T) {
+		row := func(at string) IOutputRow {
+			r := &testOutputRow{fields: []string{"at"}}
+			r.Set("at", at)
+			return r
+		}
+		fk := FieldsKinds{"at": appdef.DataKind_timestamp}
+		atFilter := func(at interface{}) IFilter {
+			return &LessFilter{
+				field: "at",
+				value: at,
+			}
+		}
+		t.Run("Should match", func(t *testing.T) {
+			require.True(t, match(atFilter("2025-01-01T00:00:00Z").IsMatch(fk, row("2024-12-31T23:59:59.999Z"))))
+			require.True(t, match(atFilter(float64(1000)).IsMatch(fk, row("1970-01-01T00:00:00.999Z"))))
+		})
+		t.Run("Should not match", func(t *testing.T) {
+			require.False(t, match(atFilter("2025-01-01T02:00:00+02:00").IsMatch(fk, row("2025-01-01T00:00:00.000Z"))))
+		})
+		t.Run("Should return error on wrong filter value", func(t *testing.T) {
+			_, err := atFilter("2025-01-01").IsMatch(fk, row("2025-01-01T00:00:00.000Z"))
+			require.ErrorIs(t, err, ErrWrongType)
+		})
+	})
 	t.Run("Compare string", func(t *testing.T) {
 		row := func(name string) IOutputRow {
 			r := &testOutputRow{fields: []string{"name"}}
@@ -6,6 +6,7 @@ package queryprocessor
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
 )
@@ -28,8 +29,23 @@ func (f NotEqualsFilter) IsMatch(fk FieldsKinds, outputRow IOutputRow) (bool, er
 		return !nearlyEqual(f.value.(float64), outputRow.Value(f.field).(float64), f.epsilon), nil
 	case appdef.DataKind_string:
 		return outputRow.Value(f.field).(string) != f.value.(string), nil
+	case appdef.DataKind_uuid:
+		// canonical lowercase UUID string is compared case-insensitively
+		return !strings.EqualFold(outputRow.Value(f.field).(string), f.value.(string)), nil
 	case appdef.DataKind_bool:
 		return outputRow.Value(f.field).(bool) != f.value.(bool), nil
+	case appdef.DataKind_decimal:
+		c, err := compareDecimal(outputRow.Value(f.field), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_NotEq, f.field, err)
+		}
+		return c != 0, nil
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		c, err := compareTemporal(fk[f.field], outputRow.Value(f.field), f.value)
+		if err != nil {
+			return false, fmt.Errorf("'%s' filter: field %s: %w", filterKind_NotEq, f.field, err)
+		}
+		return c != 0, nil
 	case appdef.DataKind_null:
 		return false, nil
 	default:
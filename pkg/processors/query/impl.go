@@ -144,12 +144,14 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 					err = coreutils.WrapSysError(err, http.StatusInternalServerError)
 					var respWriter bus.IResponseWriter
 					statusCode := http.StatusOK
+					var headers []string
 					if err != nil {
 						statusCode = err.(coreutils.SysError).HTTPStatus // nolint:errorlint
+						headers = err.(coreutils.SysError).Headers       // nolint:errorlint
 					}
 					if qwork.responseWriterGetter == nil || qwork.responseWriterGetter() == nil {
 						// have an error before 200ok is sent -> send the status from the actual error
-						respWriter = msg.Responder().InitResponse(statusCode)
+						respWriter = msg.Responder().InitResponse(statusCode, headers...)
 					} else {
 						respWriter = qwork.responseWriterGetter()
 					}
@@ -183,8 +185,12 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 	ops := []*pipeline.WiredOperator{
 		operator("borrowAppPart", borrowAppPart),
 		operator("check function call rate", func(ctx context.Context, qw *queryWork) (err error) {
-			if qw.appStructs.IsFunctionRateLimitsExceeded(qw.msg.QName(), qw.msg.WSID()) {
-				return coreutils.NewSysError(http.StatusTooManyRequests)
+			if exceeded, state := istructsmem.FunctionRateLimitsExceeded(qw.appStructs, qw.msg.QName(), qw.msg.WSID()); exceeded {
+				return coreutils.NewTooManyRequestsError(state)
+			}
+			// limits declared in VSQL
+			if exceeded, _, state := qw.appPart.IsLimitExceeded(qw.msg.QName(), appdef.OperationKind_Execute, qw.msg.WSID(), qw.msg.Host()); exceeded {
+				return coreutils.NewTooManyRequestsError(state)
 			}
 			return nil
 		}),
@@ -387,10 +393,21 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				// TODO: temporary do not check if allowed or not. Deny -> just verbose log, then collect denies,
 				// investigate and implement according grants in vsql
 				// see https://github.com/voedger/voedger/issues/3223
-				_, err := qw.appPart.IsOperationAllowed(ws, appdef.OperationKind_Select, nestedType.QName(), requestedfields, qw.roles)
+				ok, err := qw.appPart.IsOperationAllowed(ws, appdef.OperationKind_Select, nestedType.QName(), requestedfields, qw.roles)
 				if err != nil {
 					return err
 				}
+				if !ok && len(requestedfields) > 0 {
+					// type is allowed but the requested fields are revoked explicitly, e.g. the write-only sys.Webhook.Secret
+					typeOK, err := qw.appPart.IsOperationAllowed(ws, appdef.OperationKind_Select, nestedType.QName(), nil, qw.roles)
+					if err != nil {
+						// notest
+						return err
+					}
+					if typeOK {
+						return coreutils.NewHTTPErrorf(http.StatusForbidden)
+					}
+				}
 			}
 			return nil
 		}),
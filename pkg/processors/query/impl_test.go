@@ -383,7 +383,7 @@ func TestBasicUsage_ServiceFactory(t *testing.T) {
 	appParts, cleanAppParts, appTokens, statelessResources := deployTestAppWithSecretToken(require, nil)
 	defer cleanAppParts()
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, nil)
 	queryProcessor := ProvideServiceFactory()(
 		serviceChannel,
 		appParts,
@@ -1161,7 +1161,7 @@ func TestRateLimiter(t *testing.T) {
 
 	// create aquery processor
 	metrics := imetrics.Provide()
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, nil)
 	queryProcessor := ProvideServiceFactory()(
 		serviceChannel,
 		appParts,
@@ -1207,7 +1207,7 @@ func TestAuthnz(t *testing.T) {
 	appParts, cleanAppParts, appTokens, statelessResources := deployTestAppWithSecretToken(require, nil)
 	defer cleanAppParts()
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, nil)
 	queryProcessor := ProvideServiceFactory()(
 		serviceChannel,
 		appParts,
@@ -24,7 +24,7 @@ func TestWrongTypes(t *testing.T) {
 	require := require.New(t)
 	serviceChannel := make(iprocbus.ServiceChannel)
 	done := make(chan struct{})
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, nil)
 
 	appParts, cleanAppParts, appTokens, statelessResources := deployTestAppWithSecretToken(require, nil)
 
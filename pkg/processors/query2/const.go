@@ -83,6 +83,12 @@ const (
 	schemaFormatByte   = "byte"
 	schemaFormatBinary = "binary"
 
+	schemaFormatDecimal  = "decimal"
+	schemaFormatDateTime = "date-time"
+	schemaFormatDate     = "date"
+	schemaFormatDuration = "duration"
+	schemaFormatUUID     = "uuid"
+
 	schemaKeyType        = "type"
 	schemaKeyFormat      = "format"
 	schemaKeyDescription = "description"
@@ -115,6 +121,10 @@ const (
 const (
 	fieldLogin              = "login"
 	fieldPassword           = "password"
+	fieldDevice             = "device"
+	fieldRemoteAddr         = "remoteAddr"
+	fieldTOTPCode           = "totpCode"
+	fieldEmailCodeToken     = "emailCodeToken"
 	fieldDisplayName        = "displayName"
 	fieldAppName            = "appName"
 	fieldVerifiedEmailToken = "verifiedEmailToken"
@@ -210,7 +220,7 @@ const (
 	descrAppParam   = "Name of an application"
 	descrWSIDParam  = "The ID of workspace"
 
-	descrWhereParam       = "A JSON-encoded string used to filter query results. The value must be URL-encoded"
+	descrWhereParam       = "A JSON-encoded string used to filter query results. Supported operators: $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $exists, $like, $and, $or. The value must be URL-encoded"
 	descrOrderParam       = "Field to order results by"
 	descrLimitParam       = "Maximum number of results to return"
 	descrSkipParam        = "Number of results to skip"
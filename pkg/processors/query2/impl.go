@@ -87,19 +87,21 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 					err = coreutils.WrapSysError(err, http.StatusInternalServerError)
 					var respWriter bus.IResponseWriter
 					statusCode := http.StatusOK
+					var headers []string
 					if err != nil {
 						statusCode = err.(coreutils.SysError).HTTPStatus // nolint:errorlint
+						headers = err.(coreutils.SysError).Headers       // nolint:errorlint
 					}
 					if qwork.apiPathHandler.isArrayResult {
 						if qwork.responseWriterGetter == nil || qwork.responseWriterGetter() == nil {
 							// have an error before 200ok is sent -> send the status from the actual error
-							respWriter = msg.Responder().InitResponse(statusCode)
+							respWriter = msg.Responder().InitResponse(statusCode, headers...)
 						} else {
 							respWriter = qwork.responseWriterGetter()
 						}
 						respWriter.Close(err)
 					} else if err != nil {
-						respondErr := qwork.msg.Responder().Respond(bus.ResponseMeta{ContentType: coreutils.ContentType_ApplicationJSON, StatusCode: statusCode}, err)
+						respondErr := qwork.msg.Responder().Respond(bus.ResponseMeta{ContentType: coreutils.ContentType_ApplicationJSON, StatusCode: statusCode, Headers: headers}, err)
 						if respondErr != nil {
 							logger.Error(fmt.Sprintf("failed to send the error %s: %s", err.Error(), respondErr.Error()))
 						}
@@ -6,6 +6,7 @@ package query2
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -32,11 +33,43 @@ func authLoginHandler() apiPathHandler {
 				return coreutils.NewHTTPError(http.StatusBadRequest, err)
 			}
 
+			// session metadata, provided by the router
+			device, _, err := args.AsString(fieldDevice)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+			remoteAddr, _, err := args.AsString(fieldRemoteAddr)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+
+			// second factor, required if enrolled
+			totpCode, _, err := args.AsString(fieldTOTPCode)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+			emailCodeToken, _, err := args.AsString(fieldEmailCodeToken)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+
 			pseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.CurrentClusterID())
 
+			issueArgs, err := json.Marshal(map[string]any{
+				authnz.Field_Login:      login,
+				"Password":              password,
+				authnz.Field_AppName:    qw.msg.AppQName().String(),
+				authnz.Field_Device:     device,
+				authnz.Field_RemoteAddr: remoteAddr,
+				"TOTPCode":              totpCode,
+				"EmailCodeToken":        emailCodeToken,
+			})
+			if err != nil {
+				// notest
+				return err
+			}
 			url := fmt.Sprintf(`api/v2/apps/%s/%s/workspaces/%d/queries/registry.IssuePrincipalToken?args=%s`,
-				istructs.SysOwner, istructs.AppQName_sys_registry.Name(), pseudoWSID,
-				url.QueryEscape(fmt.Sprintf(`{"Login":"%s", "Password":"%s", "AppName": "%s"}`, login, password, qw.msg.AppQName())))
+				istructs.SysOwner, istructs.AppQName_sys_registry.Name(), pseudoWSID, url.QueryEscape(string(issueArgs)))
 			resp, err := qw.federation.QueryNoRetry(url)
 			if err != nil {
 				return err
@@ -8,6 +8,7 @@ package query2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/voedger/voedger/pkg/pipeline"
 	"github.com/voedger/voedger/pkg/processors/oldacl"
 	"github.com/voedger/voedger/pkg/sys/collection"
+	"github.com/voedger/voedger/pkg/sys/indexes"
 )
 
 // [~server.apiv2.docs/cmp.cdocsHandler~impl]
@@ -78,6 +80,12 @@ func cdocsAuthorizeResult(_ context.Context, qw *queryWork) (err error) {
 }
 func cdocsRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	oo := make([]*pipeline.WiredOperator, 0)
+	if qw.where, err = queryWhere(qw, qw.iDoc.Fields()); err != nil {
+		return err
+	}
+	if qw.where != nil {
+		oo = append(oo, pipeline.WireAsyncOperator("Filter", newFilter(qw.where)))
+	}
 	if qw.queryParams.Constraints != nil && len(qw.queryParams.Constraints.Include) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Include", newInclude(qw, true)))
 	}
@@ -94,6 +102,9 @@ func cdocsRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	return
 }
 func cdocsExec(ctx context.Context, qw *queryWork) (err error) {
+	if index, values := cdocsWhereIndex(qw); index != nil {
+		return cdocsExecByIndex(ctx, qw, index, values)
+	}
 	kb := qw.appStructs.ViewRecords().KeyBuilder(collection.QNameCollectionView)
 	kb.PutInt32(collection.Field_PartKey, collection.PartitionKeyCollection)
 	kb.PutQName(collection.Field_DocQName, qw.msg.QName())
@@ -107,3 +118,37 @@ func cdocsExec(ctx context.Context, qw *queryWork) (err error) {
 		return qw.callbackFunc(obj)
 	})
 }
+
+// Returns index of the document which leading fields are constrained by the where equality values
+func cdocsWhereIndex(qw *queryWork) (appdef.IIndex, []any) {
+	if qw.queryParams.Constraints == nil || len(qw.queryParams.Constraints.Where) == 0 {
+		return nil, nil
+	}
+	return indexes.MatchIndex(qw.iDoc, func(n appdef.FieldName) (any, bool) {
+		switch v := qw.queryParams.Constraints.Where[n].(type) {
+		case nil, map[string]interface{}, []interface{}:
+			return nil, false
+		default:
+			return v, true
+		}
+	})
+}
+
+func cdocsExecByIndex(ctx context.Context, qw *queryWork, index appdef.IIndex, values []any) (err error) {
+	err = indexes.ReadIndex(ctx, qw.appStructs, qw.msg.WSID(), index, values, func(id istructs.RecordID) error {
+		r, err := qw.appStructs.Records().Get(qw.msg.WSID(), true, id)
+		if err != nil {
+			return err
+		}
+		if r.QName() != qw.msg.QName() {
+			return nil
+		}
+		obj := objectBackedByMap{}
+		obj.data = coreutils.FieldsToMap(r, qw.appStructs.AppDef())
+		return qw.callbackFunc(obj)
+	})
+	if errors.Is(err, coreutils.ErrFieldTypeMismatch) {
+		return coreutils.NewHTTPError(http.StatusBadRequest, err)
+	}
+	return err
+}
@@ -90,6 +90,9 @@ func docsAuthorizeResult(ctx context.Context, qw *queryWork) (err error) {
 	return nil
 }
 func docsRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
+	if qw.where, err = queryWhere(qw, qw.resultType.(appdef.IWithFields).Fields()); err != nil {
+		return err
+	}
 	oo := make([]*pipeline.WiredOperator, 0)
 	if qw.queryParams.Constraints != nil && len(qw.queryParams.Constraints.Include) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Include", newInclude(qw, true)))
@@ -136,5 +139,14 @@ func docsExec(_ context.Context, qw *queryWork) (err error) {
 	}
 	obj := objectBackedByMap{}
 	obj.data = coreutils.FieldsToMap(rec, qw.appStructs.AppDef())
+	if qw.where != nil {
+		ok, err := qw.where.match(obj.data)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return coreutils.NewHTTPErrorf(http.StatusNotFound, fmt.Errorf("%s with ID %d does not match where constraint", qw.msg.QName(), qw.msg.DocID()))
+		}
+	}
 	return qw.callbackFunc(obj)
 }
@@ -438,6 +438,13 @@ func (g *schemaGenerator) genAuthLoginPath() {
 								fieldPassword: map[string]interface{}{
 									schemaKeyType: schemaTypeString,
 								},
+								// second factor, one of is required if enrolled
+								fieldTOTPCode: map[string]interface{}{
+									schemaKeyType: schemaTypeString,
+								},
+								fieldEmailCodeToken: map[string]interface{}{
+									schemaKeyType: schemaTypeString,
+								},
 							},
 							schemaKeyRequired: []string{fieldLogin, fieldPassword},
 						},
@@ -146,6 +146,24 @@ func (g *schemaGenerator) generateFieldSchema(field appdef.IField, op appdef.Ope
 	case appdef.DataKind_RecordID:
 		schema[schemaKeyType] = schemaTypeInteger
 		schema[schemaKeyFormat] = schemaFormatInt64
+	case appdef.DataKind_decimal:
+		// exact decimal values are represented as strings, e.g. "123.45"
+		schema[schemaKeyType] = schemaTypeString
+		schema[schemaKeyFormat] = schemaFormatDecimal
+	case appdef.DataKind_timestamp:
+		schema[schemaKeyType] = schemaTypeString
+		schema[schemaKeyFormat] = schemaFormatDateTime
+	case appdef.DataKind_date:
+		schema[schemaKeyType] = schemaTypeString
+		schema[schemaKeyFormat] = schemaFormatDate
+	case appdef.DataKind_interval:
+		schema[schemaKeyType] = schemaTypeString
+		schema[schemaKeyFormat] = schemaFormatDuration
+	case appdef.DataKind_uuid:
+		schema[schemaKeyType] = schemaTypeString
+		schema[schemaKeyFormat] = schemaFormatUUID
+	case appdef.DataKind_json:
+		// embedded JSON document, any JSON value is allowed, so no type is specified
 	default:
 		schema[schemaKeyType] = schemaTypeString
 	}
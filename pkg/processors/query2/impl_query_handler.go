@@ -66,20 +66,21 @@ func queryRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 		return nil
 	}
 	oo := make([]*pipeline.WiredOperator, 0)
+	resultType := qw.appStructs.AppDef().Type(result.QName())
+	if withFields, ok := resultType.(appdef.IWithFields); ok {
+		if qw.where, err = queryWhere(qw, withFields.Fields()); err != nil {
+			return err
+		}
+	}
+	if qw.where != nil {
+		oo = append(oo, pipeline.WireAsyncOperator("Filter", newFilter(qw.where)))
+	}
 	if qw.queryParams.Constraints != nil && len(qw.queryParams.Constraints.Include) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Include", newInclude(qw, false)))
 	}
 	if qw.queryParams.Constraints != nil && (len(qw.queryParams.Constraints.Order) != 0 || qw.queryParams.Constraints.Skip > 0 || qw.queryParams.Constraints.Limit > 0) {
 		oo = append(oo, pipeline.WireAsyncOperator("Aggregator", newAggregator(qw.queryParams)))
 	}
-	resultType := qw.appStructs.AppDef().Type(result.QName())
-	o, err := newFilter(qw, resultType.(appdef.IWithFields).Fields())
-	if err != nil {
-		return err
-	}
-	if o != nil {
-		oo = append(oo, pipeline.WireAsyncOperator("Filter", o))
-	}
 	if qw.queryParams.Constraints != nil && len(qw.queryParams.Constraints.Keys) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Keys", newKeys(qw.queryParams.Constraints.Keys)))
 	}
@@ -7,11 +7,18 @@
 package query2
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/appdef/constraints"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
 )
 
 func Test_getCombinations(t *testing.T) {
@@ -186,3 +193,211 @@ func Test_splitPath(t *testing.T) {
 		})
 	}
 }
+
+func testWhereFields(t *testing.T) map[appdef.FieldName]appdef.IField {
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+	docName := appdef.NewQName("test", "doc")
+	wsb.AddCDoc(docName).
+		AddField("Int", appdef.DataKind_int32, false).
+		AddField("Float", appdef.DataKind_float64, false).
+		AddField("Str", appdef.DataKind_string, false).
+		AddField("Bool", appdef.DataKind_bool, false).
+		AddField("Ref", appdef.DataKind_RecordID, false).
+		AddField("Kind", appdef.DataKind_QName, false).
+		AddField("Bytes", appdef.DataKind_bytes, false).
+		AddField("Amount", appdef.DataKind_decimal, false, constraints.Scale(2)).
+		AddField("At", appdef.DataKind_timestamp, false).
+		AddField("Day", appdef.DataKind_date, false).
+		AddField("Duration", appdef.DataKind_interval, false).
+		AddField("UUID", appdef.DataKind_uuid, false).
+		AddField("Data", appdef.DataKind_json, false)
+	app, err := adb.Build()
+	require.NoError(t, err)
+
+	fields := map[appdef.FieldName]appdef.IField{}
+	for _, f := range appdef.CDoc(app.Type, docName).Fields() {
+		fields[f.Name()] = f
+	}
+	return fields
+}
+
+func Test_where(t *testing.T) {
+	require := require.New(t)
+	fields := testWhereFields(t)
+
+	compile := func(w string) whereAnd {
+		where := Where{}
+		require.NoError(coreutils.JSONUnmarshal([]byte(w), &where), w)
+		expr, err := compileWhere(where, fields)
+		require.NoError(err, w)
+		return expr
+	}
+
+	row := map[string]interface{}{
+		"Int":      int32(42),
+		"Float":    float64(-1.5),
+		"Str":      "Spain",
+		"Bool":     true,
+		"Ref":      istructs.RecordID(100500),
+		"Kind":     appdef.NewQName("test", "doc"),
+		"Bytes":    []byte{1, 2, 3},
+		"Amount":   "123.40",
+		"At":       "2025-01-31T10:20:30.000Z",
+		"Day":      "2025-01-31",
+		"Duration": "PT1H",
+		"UUID":     "123e4567-e89b-12d3-a456-426614174000",
+		"Data":     json.RawMessage(`{"a":1}`),
+	}
+
+	tests := []struct {
+		where string
+		match bool
+	}{
+		{`{"Int": 42}`, true},
+		{`{"Int": {"$eq": 41}}`, false},
+		{`{"Int": {"$ne": 41}}`, true},
+		{`{"Int": {"$gt": 41, "$lt": 43}}`, true},
+		{`{"Int": {"$gte": 42, "$lte": 42}}`, true},
+		{`{"Int": {"$gt": 42}}`, false},
+		{`{"Int": {"$in": [1, 42]}}`, true},
+		{`{"Int": {"$nin": [1, 42]}}`, false},
+		{`{"Int": {"$in": []}}`, false},
+		{`{"Float": {"$lt": 0}}`, true},
+		{`{"Float": -1.5}`, true},
+		{`{"Str": {"$gt": "Italy"}}`, true},
+		{`{"Str": {"$like": "Sp%"}}`, true},
+		{`{"Str": {"$like": "_pain"}}`, true},
+		{`{"Str": {"$like": "sp%"}}`, false},
+		{`{"Str": {"$like": "S.%"}}`, false},
+		{`{"Bool": true}`, true},
+		{`{"Bool": {"$gt": false}}`, true},
+		{`{"Ref": 100500}`, true},
+		{`{"Kind": "test.doc"}`, true},
+		{`{"Kind": {"$like": "test.%"}}`, true},
+		{`{"Bytes": "AQID"}`, true},
+		{`{"Amount": 123.4}`, true},
+		{`{"Amount": "123.40"}`, true},
+		{`{"Amount": {"$gt": 123.39, "$lt": "123.41"}}`, true},
+		{`{"At": {"$gte": "2025-01-31T00:00:00Z", "$lt": 1738368000000}}`, true},
+		{`{"At": {"$lt": "2025-01-31T10:20:30Z"}}`, false},
+		{`{"Day": {"$in": ["2025-01-30", 1738324800000]}}`, true},
+		{`{"Duration": "PT60M"}`, true},
+		{`{"UUID": "123E4567-E89B-12D3-A456-426614174000"}`, true},
+		{`{"UUID": {"$gt": "00000000-0000-0000-0000-000000000000"}}`, true},
+		{`{"Data": {"$exists": true}}`, true},
+		{`{"Data": {"$exists": false}}`, false},
+		{`{"Str": "Spain", "Int": 1}`, false},
+		{`{"$or": [{"Str": "Italy"}, {"Int": {"$gte": 40}}]}`, true},
+		{`{"$or": [{"Str": "Italy"}, {"Int": {"$lt": 40}}]}`, false},
+		{`{"$and": [{"Str": "Spain"}, {"$or": [{"Int": 1}, {"Bool": true}]}]}`, true},
+	}
+	for _, test := range tests {
+		ok, err := compile(test.where).match(row)
+		require.NoError(err, test.where)
+		require.Equal(test.match, ok, test.where)
+	}
+
+	t.Run("should match values as stored in record", func(t *testing.T) {
+		ms, err := coreutils.ParseTemporal(appdef.DataKind_timestamp, "2025-01-31T10:20:30Z")
+		require.NoError(err)
+		row := map[string]interface{}{
+			"Amount": int64(12340),
+			"At":     ms,
+			"UUID":   []byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00},
+		}
+		ok, err := compile(`{"Amount": 123.4, "At": "2025-01-31T10:20:30Z", "UUID": "123e4567-e89b-12d3-a456-426614174000"}`).match(row)
+		require.NoError(err)
+		require.True(ok)
+	})
+
+	t.Run("should match missed values as zero values", func(t *testing.T) {
+		row := map[string]interface{}{}
+		for w, match := range map[string]bool{
+			`{"Int": 0, "Str": "", "Bool": false}`: true,
+			`{"Str": {"$exists": false}}`:          true,
+			`{"Str": {"$exists": true}}`:           false,
+		} {
+			ok, err := compile(w).match(row)
+			require.NoError(err)
+			require.Equal(match, ok, w)
+		}
+	})
+
+	t.Run("should be errors", func(t *testing.T) {
+		for w, expected := range map[string]error{
+			`{"Unknown": 1}`:                errUnexpectedField,
+			`{"$or": [{"Unknown": 1}]}`:     errUnexpectedField,
+			`{"$not": {"Int": 1}}`:          errUnsupportedConstraint,
+			`{"Int": {"$regex": "1"}}`:      errUnsupportedConstraint,
+			`{"Int": {"$like": "1%"}}`:      errUnsupportedConstraint,
+			`{"Data": {"$eq": "{}"}}`:       errUnsupportedConstraint,
+			`{"Int": {}}`:                   errUnexpectedParams,
+			`{"Int": "42"}`:                 errUnexpectedParams,
+			`{"Int": 3000000000}`:           errUnexpectedParams,
+			`{"Int": {"$in": 42}}`:          errUnexpectedParams,
+			`{"Int": {"$exists": 1}}`:       errUnexpectedParams,
+			`{"Str": {"$like": 1}}`:         errUnexpectedParams,
+			`{"Amount": 1.234}`:             errUnexpectedParams,
+			`{"At": "2025-01-01"}`:          errUnexpectedParams,
+			`{"At": {"$gte": "yesterday"}}`: errUnexpectedParams,
+			`{"Kind": "doc"}`:               errUnexpectedParams,
+			`{"UUID": "123e4567"}`:          errUnexpectedParams,
+			`{"Bool": "true"}`:              errUnexpectedParams,
+			`{"$or": []}`:                   errUnexpectedParams,
+			`{"$and": [{"Int": 1}, "Int"]}`: errUnexpectedParams,
+		} {
+			where := Where{}
+			require.NoError(coreutils.JSONUnmarshal([]byte(w), &where))
+			_, err := compileWhere(where, fields)
+			require.ErrorIs(err, expected, w)
+		}
+	})
+}
+
+func Test_whereFieldConstraints(t *testing.T) {
+	require := require.New(t)
+	fields := testWhereFields(t)
+
+	where := Where{}
+	require.NoError(coreutils.JSONUnmarshal([]byte(`{
+		"Int": {"$in": [1, 2]},
+		"Str": {"$gt": "a", "$lte": "z"},
+		"$and": [{"Str": {"$gte": "b"}}, {"Bool": true}],
+		"$or": [{"Float": 1}, {"Float": 2}]
+	}`), &where))
+	expr, err := compileWhere(where, fields)
+	require.NoError(err)
+
+	exact, from, to := expr.fieldConstraints("Int")
+	require.Equal([]interface{}{int64(1), int64(2)}, exact)
+	require.Nil(from)
+	require.Nil(to)
+
+	exact, from, to = expr.fieldConstraints("Str")
+	require.Nil(exact)
+	require.Equal("b", from)
+	require.Equal("z", to)
+
+	exact, _, _ = expr.fieldConstraints("Bool")
+	require.Equal([]interface{}{true}, exact)
+
+	exact, from, to = expr.fieldConstraints("Float")
+	require.Nil(exact)
+	require.Nil(from)
+	require.Nil(to)
+}
+
+func Test_rangeIsContinuous(t *testing.T) {
+	require := require.New(t)
+
+	require.True(rangeIsContinuous(appdef.DataKind_int32, int64(0), int64(math.MaxInt32)))
+	require.True(rangeIsContinuous(appdef.DataKind_int32, int64(math.MinInt32), int64(-1)))
+	require.False(rangeIsContinuous(appdef.DataKind_int32, int64(-1), int64(1)))
+	require.False(rangeIsContinuous(appdef.DataKind_int64, int64(1), nil))
+	require.True(rangeIsContinuous(appdef.DataKind_decimal, int64(-1), nil))
+	require.True(rangeIsContinuous(appdef.DataKind_string, nil, "z"))
+	require.False(rangeIsContinuous(appdef.DataKind_float64, float64(0), float64(1)))
+	require.False(rangeIsContinuous(appdef.DataKind_bool, false, true))
+}
@@ -72,23 +72,17 @@ func viewRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	if err != nil {
 		return
 	}
+	if qw.where, err = queryWhere(qw, qw.iView.Fields()); err != nil {
+		return
+	}
 	oo := make([]*pipeline.WiredOperator, 0)
+	oo = append(oo, pipeline.WireAsyncOperator("Filter", newFilter(qw.where)))
 	if len(qw.queryParams.Constraints.Include) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Include", newInclude(qw, false)))
 	}
 	if len(qw.queryParams.Constraints.Order) != 0 || qw.queryParams.Constraints.Skip > 0 || qw.queryParams.Constraints.Limit > 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Aggregator", newAggregator(qw.queryParams)))
 	}
-	fields := make([]appdef.IField, 0)
-	fields = append(fields, qw.appStructs.AppDef().Type(qw.iView.QName()).(appdef.IView).Key().ClustCols().Fields()...)
-	fields = append(fields, qw.appStructs.AppDef().Type(qw.iView.QName()).(appdef.IView).Value().Fields()...)
-	o, err := newFilter(qw, fields)
-	if err != nil {
-		return
-	}
-	if o != nil {
-		oo = append(oo, pipeline.WireAsyncOperator("Filter", o))
-	}
 	if len(qw.queryParams.Constraints.Keys) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Keys", newKeys(qw.queryParams.Constraints.Keys)))
 	}
@@ -98,102 +92,139 @@ func viewRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	qw.responseWriterGetter = respWriterGetter
 	return
 }
+
+// Reads view records constrained by where.
+//
+// Exact values of partition key and leading clustering columns are read as separate keys,
+// range of the next clustering column is read by IViewRecordsRangeReader if it is implemented.
+// Rows are checked by filter anyway
 func viewExec(ctx context.Context, qw *queryWork) (err error) {
 	kk, err := getKeys(qw)
 	if err != nil {
 		return
 	}
-	for i := range kk {
-		err = qw.appStructs.ViewRecords().Read(ctx, qw.msg.WSID(), kk[i], func(key istructs.IKey, value istructs.IValue) (err error) {
-			obj := objectBackedByMap{}
-			obj.data = coreutils.FieldsToMap(key, qw.appStructs.AppDef())
-			for k, v := range coreutils.FieldsToMap(value, qw.appStructs.AppDef()) {
-				obj.data[k] = v
-			}
-			return qw.callbackFunc(obj)
-		})
+	cb := func(key istructs.IKey, value istructs.IValue) (err error) {
+		obj := objectBackedByMap{}
+		obj.data = coreutils.FieldsToMap(key, qw.appStructs.AppDef())
+		for k, v := range coreutils.FieldsToMap(value, qw.appStructs.AppDef()) {
+			obj.data[k] = v
+		}
+		return qw.callbackFunc(obj)
+	}
+	rangeReader, canReadRange := qw.appStructs.ViewRecords().(istructs.IViewRecordsRangeReader)
+	for _, k := range kk {
+		if k.to != nil && canReadRange {
+			err = rangeReader.ReadRange(ctx, qw.msg.WSID(), k.from, k.to, cb)
+		} else {
+			err = qw.appStructs.ViewRecords().Read(ctx, qw.msg.WSID(), k.prefix, cb)
+		}
 		if err != nil {
 			return
 		}
 	}
 	return
 }
-func getKeys(qw *queryWork) (keys []istructs.IKeyBuilder, err error) {
-	fields := qw.appStructs.AppDef().Type(qw.iView.QName()).(appdef.IView).Key().Fields()
+
+// Key to read view records: prefix of exact values and optional range of the next clustering column
+type viewKey struct {
+	prefix   istructs.IKeyBuilder
+	from, to istructs.IKeyBuilder // nil if there is no range
+}
+
+func getKeys(qw *queryWork) (keys []viewKey, err error) {
+	fields := qw.iView.Key().Fields()
+	partKey := qw.iView.Key().PartKey()
 	values := make([][]interface{}, 0, len(fields))
-	partialKey := false
-	for i, field := range fields {
-		if partialKey {
+	var (
+		rangeField         appdef.IField
+		rangeFrom, rangeTo interface{}
+	)
+	for _, field := range fields {
+		exact, from, to := qw.where.fieldConstraints(field.Name())
+		if exact != nil {
+			values = append(values, exact)
 			continue
 		}
-		switch field.DataKind() {
-		case appdef.DataKind_int32:
-			vv, err := qw.queryParams.Constraints.Where.getAsInt32(field.Name())
-			if err != nil {
-				return nil, err
-			}
-			if vv == nil {
-				partialKey = true
-				continue
-			}
-			values = append(values, make([]interface{}, 0))
-			for _, v := range vv {
-				values[i] = append(values[i], v)
-			}
-		case appdef.DataKind_string:
-			vv, err := qw.queryParams.Constraints.Where.getAsString(field.Name())
-			if err != nil {
-				return nil, err
-			}
-			if vv == nil {
-				continue
+		if partKey.Field(field.Name()) != nil {
+			return nil, coreutils.WrapSysError(fmt.Errorf("%w: field '%s' must be constrained by $eq or $in", errWhereConstraintMustSpecifyThePartitionKey, field.Name()),
+				http.StatusBadRequest)
+		}
+		switch kind := field.DataKind(); kind {
+		case appdef.DataKind_int8, appdef.DataKind_int16, appdef.DataKind_int32, appdef.DataKind_int64:
+			// open range of integers is bounded by kind limits, so the continuity can be checked
+			minValue, maxValue := intBounds(kind)
+			if from == nil && to != nil {
+				from = minValue
 			}
-			values = append(values, make([]interface{}, 0))
-			for _, v := range vv {
-				values[i] = append(values[i], v)
+			if to == nil && from != nil {
+				to = maxValue
 			}
-		default:
-			// do nothing
 		}
+		if (from != nil || to != nil) && rangeIsContinuous(field.DataKind(), from, to) {
+			rangeField, rangeFrom, rangeTo = field, from, to
+		}
+		break
 	}
+
 	cc := getCombinations(values)
-	keys = make([]istructs.IKeyBuilder, len(cc))
+	keys = make([]viewKey, len(cc))
+	newKey := func(c []interface{}) istructs.IKeyBuilder {
+		kb := qw.appStructs.ViewRecords().KeyBuilder(qw.iView.QName())
+		for j, v := range c {
+			putKeyValue(kb, fields[j], v)
+		}
+		return kb
+	}
 	for i, c := range cc {
-		keys[i] = qw.appStructs.ViewRecords().KeyBuilder(qw.iView.QName())
-		for j, intf := range c {
-			switch v := intf.(type) {
-			case int32:
-				keys[i].PutInt32(fields[j].Name(), v)
-			case string:
-				keys[i].PutString(fields[j].Name(), v)
+		keys[i].prefix = newKey(c)
+		if rangeField != nil {
+			keys[i].from, keys[i].to = newKey(c), newKey(c)
+			if rangeFrom != nil {
+				putKeyValue(keys[i].from, rangeField, rangeFrom)
+			}
+			if rangeTo != nil {
+				putKeyValue(keys[i].to, rangeField, rangeTo)
 			}
 		}
 	}
-	return
+	return keys, nil
 }
-func validateFields(qw *queryWork) (err error) {
-	view := qw.appStructs.AppDef().Type(qw.iView.QName()).(appdef.IView)
 
+// Puts canonical where value to the key field
+func putKeyValue(kb istructs.IKeyBuilder, field appdef.IField, v interface{}) {
+	n := field.Name()
+	switch field.DataKind() {
+	case appdef.DataKind_int8:
+		kb.PutInt8(n, int8(v.(int64))) // nolint G115 checked by ClarifyJSONNumber
+	case appdef.DataKind_int16:
+		kb.PutInt16(n, int16(v.(int64))) // nolint G115 checked by ClarifyJSONNumber
+	case appdef.DataKind_int32:
+		kb.PutInt32(n, int32(v.(int64))) // nolint G115 checked by ClarifyJSONNumber
+	case appdef.DataKind_int64, appdef.DataKind_decimal, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		kb.PutInt64(n, v.(int64))
+	case appdef.DataKind_RecordID:
+		kb.PutRecordID(n, istructs.RecordID(v.(int64))) // nolint G115 checked by ClarifyJSONNumber
+	case appdef.DataKind_float32:
+		kb.PutFloat32(n, float32(v.(float64)))
+	case appdef.DataKind_float64:
+		kb.PutFloat64(n, v.(float64))
+	case appdef.DataKind_bool:
+		kb.PutBool(n, v.(bool))
+	case appdef.DataKind_string:
+		kb.PutString(n, v.(string))
+	case appdef.DataKind_QName:
+		kb.PutQName(n, appdef.MustParseQName(v.(string)))
+	case appdef.DataKind_bytes, appdef.DataKind_uuid:
+		kb.PutBytes(n, []byte(v.(string)))
+	}
+}
+
+func validateFields(qw *queryWork) (err error) {
 	if qw.queryParams.Constraints == nil {
 		return errConstraintsAreNull
 	}
 	if len(qw.queryParams.Constraints.Where) == 0 {
 		return errWhereConstraintIsEmpty
 	}
-	for _, field := range view.Key().PartKey().Fields() {
-		if _, ok := qw.queryParams.Constraints.Where[field.Name()]; !ok {
-			return errWhereConstraintMustSpecifyThePartitionKey
-		}
-	}
-
-	ff := make(map[string]bool)
-	for _, field := range view.Fields() {
-		ff[field.Name()] = true
-	}
-	for k := range qw.queryParams.Constraints.Where {
-		if !ff[k] {
-			return fmt.Errorf("%w: '%s'", errUnexpectedField, k)
-		}
-	}
 	return
 }
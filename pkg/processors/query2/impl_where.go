@@ -0,0 +1,495 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package query2
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Compiled where constraint.
+//
+// Where values and row values are compared in canonical form:
+//   - integers and record IDs as int64,
+//   - floats as float64,
+//   - decimals as unscaled int64,
+//   - temporals as milliseconds int64,
+//   - strings and QNames as string,
+//   - bytes and UUIDs as string of raw bytes,
+//   - bools as bool.
+type whereExpr interface {
+	match(row map[string]interface{}) (bool, error)
+}
+
+// Matches if all expressions match
+type whereAnd []whereExpr
+
+// Matches if any expression matches
+type whereOr []whereExpr
+
+// Single field condition, e.g. {"Age": {"$gt": 30}}
+type whereCond struct {
+	field  appdef.IField
+	op     string
+	value  interface{}   // $eq, $ne, $gt, $gte, $lt, $lte
+	values []interface{} // $in, $nin
+	exists bool          // $exists
+	like   *regexp.Regexp
+}
+
+const (
+	opEq     = "$eq"
+	opNe     = "$ne"
+	opGt     = "$gt"
+	opGte    = "$gte"
+	opLt     = "$lt"
+	opLte    = "$lte"
+	opIn     = "$in"
+	opNin    = "$nin"
+	opExists = "$exists"
+	opLike   = "$like"
+	opAnd    = "$and"
+	opOr     = "$or"
+)
+
+// Compiles where constraint of the query params for the specified fields.
+//
+// Returns nil if where constraint is not specified
+func queryWhere(qw *queryWork, fields []appdef.IField) (whereAnd, error) {
+	if qw.queryParams.Constraints == nil || len(qw.queryParams.Constraints.Where) == 0 {
+		return nil, nil
+	}
+	ff := make(map[appdef.FieldName]appdef.IField, len(fields))
+	for _, f := range fields {
+		ff[f.Name()] = f
+	}
+	where, err := compileWhere(qw.queryParams.Constraints.Where, ff)
+	if err != nil {
+		return nil, coreutils.WrapSysError(err, http.StatusBadRequest)
+	}
+	return where, nil
+}
+
+// Compiles where constraint for the specified fields.
+//
+// Top level field constraints are joined by AND, field value without operator means $eq:
+//
+//	{"Country": "Spain", "Age": {"$gt": 30, "$lte": 60}}
+//	{"$or": [{"Country": "Spain"}, {"Country": {"$like": "Ger%"}}], "Name": {"$exists": true}}
+func compileWhere(w map[string]interface{}, fields map[appdef.FieldName]appdef.IField) (whereAnd, error) {
+	expr := whereAnd{}
+	for _, k := range slices.Sorted(maps.Keys(w)) {
+		v := w[k]
+		switch k {
+		case opAnd, opOr:
+			ww, ok := v.([]interface{})
+			if !ok || len(ww) == 0 {
+				return nil, fmt.Errorf("%w: %s must be not empty array of constraints", errUnexpectedParams, k)
+			}
+			sub := make([]whereExpr, 0, len(ww))
+			for _, w := range ww {
+				wm, ok := w.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("%w: %s must be not empty array of constraints", errUnexpectedParams, k)
+				}
+				e, err := compileWhere(wm, fields)
+				if err != nil {
+					return nil, err
+				}
+				sub = append(sub, e)
+			}
+			if k == opAnd {
+				expr = append(expr, whereAnd(sub))
+			} else {
+				expr = append(expr, whereOr(sub))
+			}
+			continue
+		}
+		if strings.HasPrefix(k, "$") {
+			return nil, fmt.Errorf("%w: '%s'", errUnsupportedConstraint, k)
+		}
+		field, ok := fields[k]
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s'", errUnexpectedField, k)
+		}
+		ops, ok := v.(map[string]interface{})
+		if !ok {
+			ops = map[string]interface{}{opEq: v}
+		}
+		if len(ops) == 0 {
+			return nil, fmt.Errorf("%w: '%s' constraint is empty", errUnexpectedParams, k)
+		}
+		for _, op := range slices.Sorted(maps.Keys(ops)) {
+			c, err := compileCond(field, op, ops[op])
+			if err != nil {
+				return nil, err
+			}
+			expr = append(expr, c)
+		}
+	}
+	return expr, nil
+}
+
+func compileCond(field appdef.IField, op string, param interface{}) (c *whereCond, err error) {
+	c = &whereCond{field: field, op: op}
+	kind := field.DataKind()
+	if op != opExists && kind == appdef.DataKind_json {
+		return nil, fmt.Errorf("%w: '%s' for json field '%s', only %s is supported", errUnsupportedConstraint, op, field.Name(), opExists)
+	}
+	switch op {
+	case opEq, opNe, opGt, opGte, opLt, opLte:
+		c.value, err = whereValue(field, param)
+	case opIn, opNin:
+		params, ok := param.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of field '%s' must be array", errUnexpectedParams, op, field.Name())
+		}
+		c.values = make([]interface{}, 0, len(params))
+		for _, p := range params {
+			v, err := whereValue(field, p)
+			if err != nil {
+				return nil, err
+			}
+			c.values = append(c.values, v)
+		}
+	case opExists:
+		exists, ok := param.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of field '%s' must be boolean", errUnexpectedParams, op, field.Name())
+		}
+		c.exists = exists
+	case opLike:
+		pattern, ok := param.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of field '%s' must be string", errUnexpectedParams, op, field.Name())
+		}
+		if kind != appdef.DataKind_string && kind != appdef.DataKind_QName {
+			return nil, fmt.Errorf("%w: '%s' for %s field '%s'", errUnsupportedConstraint, op, kind.TrimString(), field.Name())
+		}
+		c.like = likeRegexp(pattern)
+	default:
+		return nil, fmt.Errorf("%w: '%s' for field '%s'", errUnsupportedConstraint, op, field.Name())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (e whereAnd) match(row map[string]interface{}) (bool, error) {
+	for _, expr := range e {
+		if ok, err := expr.match(row); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (e whereOr) match(row map[string]interface{}) (bool, error) {
+	for _, expr := range e {
+		if ok, err := expr.match(row); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (c *whereCond) match(row map[string]interface{}) (bool, error) {
+	raw, ok := row[c.field.Name()]
+	if c.op == opExists {
+		return (ok && raw != nil) == c.exists, nil
+	}
+	v, err := rowValue(c.field, raw)
+	if err != nil {
+		return false, err
+	}
+	switch c.op {
+	case opEq:
+		return compareValues(v, c.value) == 0, nil
+	case opNe:
+		return compareValues(v, c.value) != 0, nil
+	case opGt:
+		return compareValues(v, c.value) > 0, nil
+	case opGte:
+		return compareValues(v, c.value) >= 0, nil
+	case opLt:
+		return compareValues(v, c.value) < 0, nil
+	case opLte:
+		return compareValues(v, c.value) <= 0, nil
+	case opIn, opNin:
+		in := false
+		for _, value := range c.values {
+			if compareValues(v, value) == 0 {
+				in = true
+				break
+			}
+		}
+		return in == (c.op == opIn), nil
+	case opLike:
+		return c.like.MatchString(v.(string)), nil
+	}
+	return false, nil
+}
+
+// Returns constraints of the field from the top level conjunction of where:
+// exact values from $eq or $in and bounds from $gt, $gte, $lt and $lte.
+//
+// Bounds are inclusive, so strict bounds are rechecked by filter
+func (e whereAnd) fieldConstraints(name appdef.FieldName) (exact []interface{}, from, to interface{}) {
+	for _, expr := range e {
+		switch x := expr.(type) {
+		case whereAnd:
+			xExact, xFrom, xTo := x.fieldConstraints(name)
+			if exact == nil {
+				exact = xExact
+			}
+			if xFrom != nil && (from == nil || compareValues(xFrom, from) > 0) {
+				from = xFrom
+			}
+			if xTo != nil && (to == nil || compareValues(xTo, to) < 0) {
+				to = xTo
+			}
+		case *whereCond:
+			if x.field.Name() != name {
+				continue
+			}
+			switch x.op {
+			case opEq:
+				if exact == nil {
+					exact = []interface{}{x.value}
+				}
+			case opIn:
+				if exact == nil {
+					exact = x.values
+				}
+			case opGt, opGte:
+				if from == nil || compareValues(x.value, from) > 0 {
+					from = x.value
+				}
+			case opLt, opLte:
+				if to == nil || compareValues(x.value, to) < 0 {
+					to = x.value
+				}
+			}
+		}
+	}
+	return exact, from, to
+}
+
+// Returns where value of the field in canonical form
+func whereValue(field appdef.IField, v interface{}) (res interface{}, err error) {
+	kind := field.DataKind()
+	switch kind {
+	case appdef.DataKind_int8, appdef.DataKind_int16, appdef.DataKind_int32, appdef.DataKind_int64, appdef.DataKind_RecordID,
+		appdef.DataKind_float32, appdef.DataKind_float64:
+		n, ok := v.(json.Number)
+		if !ok {
+			break
+		}
+		if res, err = coreutils.ClarifyJSONNumber(n, kind); err == nil {
+			return rowValue(field, res)
+		}
+	case appdef.DataKind_decimal:
+		var s string
+		switch value := v.(type) {
+		case json.Number:
+			s = value.String()
+		case string:
+			s = value
+		default:
+			return nil, errWhereValueType(field, v)
+		}
+		_, scale := appdef.DecimalPrecisionScale(field.Constraints())
+		if res, err = utils.ParseDecimal(s, scale); err == nil {
+			return res, nil
+		}
+	case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		return temporalWhereValue(v, kind)
+	case appdef.DataKind_string:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case appdef.DataKind_bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case appdef.DataKind_QName:
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		if _, err = appdef.ParseQName(s); err == nil {
+			return s, nil
+		}
+	case appdef.DataKind_bytes:
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		// bytes are represented in JSON as base64 strings
+		var b []byte
+		if b, err = base64.StdEncoding.DecodeString(s); err == nil {
+			return string(b), nil
+		}
+	case appdef.DataKind_uuid:
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		var b []byte
+		if b, err = utils.ParseUUID(s); err == nil {
+			return string(b), nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: field '%s': %w", errUnexpectedParams, field.Name(), err)
+	}
+	return nil, errWhereValueType(field, v)
+}
+
+// Returns row value of the field in canonical form.
+//
+// Row values are read by FieldsToMap, so they can be both in form of ReadByKind (e.g. decimal string) and as stored in record (e.g. unscaled int64).
+// Missed value is returned as zero value of the field kind
+func rowValue(field appdef.IField, v interface{}) (interface{}, error) {
+	kind := field.DataKind()
+	switch value := v.(type) {
+	case nil:
+		return zeroWhereValue(kind), nil
+	case int8:
+		return int64(value), nil
+	case int16:
+		return int64(value), nil
+	case int32:
+		return int64(value), nil
+	case int64:
+		return value, nil
+	case istructs.RecordID:
+		return int64(value), nil // nolint G115
+	case float32:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	case bool:
+		return value, nil
+	case appdef.QName:
+		return value.String(), nil
+	case []byte:
+		return string(value), nil
+	case string:
+		if value == "" {
+			return zeroWhereValue(kind), nil
+		}
+		switch kind {
+		case appdef.DataKind_decimal:
+			_, scale := appdef.DecimalPrecisionScale(field.Constraints())
+			return utils.ParseDecimal(value, scale)
+		case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+			return coreutils.ParseTemporal(kind, value)
+		case appdef.DataKind_uuid:
+			b, err := utils.ParseUUID(value)
+			return string(b), err
+		case appdef.DataKind_string, appdef.DataKind_QName:
+			return value, nil
+		}
+	}
+	return nil, errWhereValueType(field, v)
+}
+
+func zeroWhereValue(kind appdef.DataKind) interface{} {
+	switch kind {
+	case appdef.DataKind_float32, appdef.DataKind_float64:
+		return float64(0)
+	case appdef.DataKind_bool:
+		return false
+	case appdef.DataKind_string, appdef.DataKind_QName, appdef.DataKind_bytes, appdef.DataKind_uuid, appdef.DataKind_json:
+		return ""
+	default:
+		return int64(0)
+	}
+}
+
+func errWhereValueType(field appdef.IField, v interface{}) error {
+	return fmt.Errorf("%w: %T value for %s field '%s'", errUnexpectedParams, v, field.DataKind().TrimString(), field.Name())
+}
+
+// Compares canonical values of the same field kind
+func compareValues(v1, v2 interface{}) int {
+	switch v := v1.(type) {
+	case int64:
+		return cmp.Compare(v, v2.(int64))
+	case float64:
+		return cmp.Compare(v, v2.(float64))
+	case string:
+		return strings.Compare(v, v2.(string))
+	case bool:
+		switch b := v2.(bool); {
+		case v == b:
+			return 0
+		case b:
+			return -1
+		default:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Returns regexp for SQL LIKE pattern: % matches any string, _ matches any character
+func likeRegexp(pattern string) *regexp.Regexp {
+	re := strings.Builder{}
+	re.WriteString(`(?s)^`)
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			re.WriteString(`.*`)
+		case '_':
+			re.WriteString(`.`)
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString(`$`)
+	return regexp.MustCompile(re.String())
+}
+
+// Returns true if inclusive range of the key field values is stored in view as continuous bytes range
+func rangeIsContinuous(kind appdef.DataKind, from, to interface{}) bool {
+	switch kind {
+	case appdef.DataKind_int8, appdef.DataKind_int16, appdef.DataKind_int32, appdef.DataKind_int64:
+		// integers are stored as big-endian two's complement, so negative values are stored after positive ones
+		return from != nil && to != nil && (from.(int64) >= 0) == (to.(int64) >= 0)
+	case appdef.DataKind_RecordID, appdef.DataKind_decimal, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval,
+		appdef.DataKind_string, appdef.DataKind_uuid:
+		return true
+	}
+	return false
+}
+
+// Returns minimum and maximum values of the integer kind
+func intBounds(kind appdef.DataKind) (minValue, maxValue int64) {
+	switch kind {
+	case appdef.DataKind_int8:
+		return math.MinInt8, math.MaxInt8
+	case appdef.DataKind_int16:
+		return math.MinInt16, math.MaxInt16
+	case appdef.DataKind_int32:
+		return math.MinInt32, math.MaxInt32
+	}
+	return math.MinInt64, math.MaxInt64
+}
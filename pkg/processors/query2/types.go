@@ -17,6 +17,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/pipeline"
@@ -380,127 +381,47 @@ func (s *sender) OnError(_ context.Context, err error) {
 
 type filter struct {
 	pipeline.AsyncNOOP
-	Int32  map[string]map[int32]bool
-	String map[string]map[string]bool
+	where whereExpr
 }
 
-func newFilter(qw *queryWork, fields []appdef.IField) (o pipeline.IAsyncOperator, err error) {
-	f := &filter{
-		Int32:  make(map[string]map[int32]bool),
-		String: make(map[string]map[string]bool),
-	}
-	if qw.queryParams.Constraints == nil || qw.queryParams.Constraints.Where == nil || len(qw.queryParams.Constraints.Where) == 0 {
-		return nil, nil
-	}
-	for _, field := range fields {
-		switch field.DataKind() {
-		case appdef.DataKind_int32:
-			vv, err := qw.queryParams.Constraints.Where.getAsInt32(field.Name())
-			if err != nil {
-				return nil, err
-			}
-			for _, v := range vv {
-				m, ok := f.Int32[field.Name()]
-				if !ok {
-					m = make(map[int32]bool)
-					f.Int32[field.Name()] = m
-				}
-				m[v] = true
-			}
-		case appdef.DataKind_string:
-			vv, err := qw.queryParams.Constraints.Where.getAsString(field.Name())
-			if err != nil {
-				return nil, err
-			}
-			for _, v := range vv {
-				m, ok := f.String[field.Name()]
-				if !ok {
-					m = make(map[string]bool)
-					f.String[field.Name()] = m
-				}
-				m[v] = true
-			}
-		default:
-			// Do nothing
-		}
-	}
-	if len(f.Int32) == 0 && len(f.String) == 0 {
-		return nil, nil
-	}
-	return f, nil
+func newFilter(where whereExpr) pipeline.IAsyncOperator {
+	return &filter{where: where}
 }
 
 func (f filter) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pipeline.IWorkpiece, err error) {
-	for fieldName, values := range f.Int32 {
-		if !values[work.(istructs.IRowReader).AsInt32(fieldName)] {
-			return nil, nil
-		}
-	}
-	for fieldName, values := range f.String {
-		if !values[work.(istructs.IRowReader).AsString(fieldName)] {
-			return nil, nil
-		}
+	ok, err := f.where.match(work.(objectBackedByMap).data)
+	if !ok || err != nil {
+		return nil, err
 	}
 	return work, nil
 }
 
 type Where map[string]interface{}
 
-func (w Where) getAsInt32(k string) (vv []int32, err error) {
-	switch v := w[k].(type) {
+// Returns temporal where value in milliseconds. Value can be specified as RFC 3339 or ISO 8601 duration string or as milliseconds:
+//
+//	{"CreatedAt": "2025-01-31T10:20:30Z"}
+//	{"CreatedAt": {"$in": ["2025-01-31T10:20:30Z", "2025-02-01T00:00:00Z"]}}
+//	{"CreatedAt": {"$gte": "2025-01-01T00:00:00Z", "$lt": 1738368000000}}
+func temporalWhereValue(v interface{}, kind appdef.DataKind) (ms int64, err error) {
+	switch value := v.(type) {
+	case string:
+		ms, err = coreutils.ParseTemporal(kind, value)
 	case json.Number:
-		int32Val, err := coreutils.ClarifyJSONNumber(v, appdef.DataKind_int32)
-		if err != nil {
-			return nil, err
-		}
-		vv = append(vv, int32Val.(int32))
-		return vv, nil
-	case map[string]interface{}:
-		in, ok := v["$in"]
-		if !ok {
-			return nil, errUnsupportedConstraint
+		var val interface{}
+		if val, err = coreutils.ClarifyJSONNumber(value, kind); err == nil {
+			ms = val.(int64)
 		}
-		params, ok := in.([]interface{})
-		if !ok {
-			return nil, errUnexpectedParams
-		}
-		for _, param := range params {
-			int32Val, err := coreutils.ClarifyJSONNumber(param.(json.Number), appdef.DataKind_int32)
-			if err != nil {
-				return nil, err
-			}
-			vv = append(vv, int32Val.(int32))
-		}
-		return vv, nil
-	case nil:
-		return
 	default:
-		return nil, errUnsupportedType
+		return 0, errUnexpectedParams
 	}
-}
-func (w Where) getAsString(k string) (vv []string, err error) {
-	switch v := w[k].(type) {
-	case string:
-		vv = append(vv, v)
-		return
-	case map[string]interface{}:
-		in, ok := v["$in"]
-		if !ok {
-			return nil, errUnsupportedConstraint
-		}
-		params, ok := in.([]interface{})
-		if !ok {
-			return nil, errUnexpectedParams
-		}
-		for _, param := range params {
-			vv = append(vv, param.(string))
-		}
-		return
-	case nil:
-		return
-	default:
-		return nil, errUnsupportedType
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errUnexpectedParams, err)
+	}
+	if kind == appdef.DataKind_date {
+		ms = utils.TruncateToDate(ms)
 	}
+	return ms, nil
 }
 
 type queryResultWrapper struct {
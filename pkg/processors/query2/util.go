@@ -18,6 +18,7 @@ import (
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
 	"github.com/voedger/voedger/pkg/pipeline"
@@ -27,8 +28,12 @@ import (
 )
 
 func queryRateLimitExceeded(ctx context.Context, qw *queryWork) error {
-	if qw.appStructs.IsFunctionRateLimitsExceeded(qw.msg.QName(), qw.msg.WSID()) {
-		return coreutils.NewSysError(http.StatusTooManyRequests)
+	if exceeded, state := istructsmem.FunctionRateLimitsExceeded(qw.appStructs, qw.msg.QName(), qw.msg.WSID()); exceeded {
+		return coreutils.NewTooManyRequestsError(state)
+	}
+	// limits declared in VSQL
+	if exceeded, _, state := qw.appPart.IsLimitExceeded(qw.msg.QName(), appdef.OperationKind_Execute, qw.msg.WSID(), qw.msg.Host()); exceeded {
+		return coreutils.NewTooManyRequestsError(state)
 	}
 	return nil
 }
@@ -76,6 +81,7 @@ type queryWork struct {
 	iView                appdef.IView
 	iDoc                 appdef.IDoc
 	iRecord              appdef.IContainedRecord
+	where                whereAnd // compiled where constraint, nil if not specified
 	wsDesc               istructs.IRecord
 	callbackFunc         istructs.ExecQueryCallback
 	responseWriterGetter func() bus.IResponseWriter
@@ -7,8 +7,10 @@ package registry
 
 import (
 	"embed"
+	"errors"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
@@ -34,7 +36,43 @@ const (
 	field_Login             = "Login"
 	field_TTLHours          = "TTLHours"
 	field_GlobalRoles       = "GlobalRoles"
+	field_Device            = "Device"
+	field_RemoteAddr        = "RemoteAddr"
+	field_TOTPSecret        = "TOTPSecret"
+	field_RecoveryCodesHash = "RecoveryCodesHash"
+	field_EmailSecondFactor = "EmailSecondFactor"
+	field_TOTPCode          = "TOTPCode"
+	field_NewTOTPCode       = "NewTOTPCode"
+	field_EmailCodeToken    = "EmailCodeToken"
+	field_EnrollmentToken   = "EnrollmentToken"
+	field_RecoveryCodes     = "RecoveryCodes"
+	field_Secret            = "Secret"
+	field_URI               = "URI"
+	field_Enabled           = "Enabled"
 	maxTokenTTLHours        = 168 // 1 week
+	randomPasswordLen       = 32
+	recoveryCodesAmount     = 10
+	recoveryCodesSeparator  = ","
+	totpEnrollmentTokenTTL  = 10 * time.Minute
+
+	// the secret file name of the TOTP secrets encryption key, 32 bytes, see SecondFactorConfig.TOTPSecretKey
+	TOTPSecretKeyName = "totpSecretKey"
+
+	// the login is locked out from the second factor verification for the window after so many failed attempts
+	// the window starts again on each failed attempt
+	maxSecondFactorFailures    = 5
+	secondFactorFailuresTTL    = 15 * time.Minute
+	maxSecondFactorCASAttempts = 10
+
+	// the code of the step is accepted for the allowed clock skew, i.e. during 3 periods
+	lastTOTPStepTTL = 3 * coreutils.TOTPPeriod
+)
+
+// cCols of the second factor state of the login
+var (
+	ccolsLastTOTPStep         = []byte{1}
+	ccolsSecondFactorFailures = []byte{2}
+	ccolsUsedEmailCodePrefix  = []byte{3} // + SHA-256 of the EmailCodeToken
 )
 
 var (
@@ -46,6 +84,16 @@ var (
 	QNameCommandCreateEmailLogin                      = appdef.NewQName(RegistryPackage, "CreateEmailLogin")
 	QNameCommandResetPasswordByEmail                  = appdef.NewQName(RegistryPackage, "ResetPasswordByEmail")
 	QNameCommandUpdateGlobalRoles                     = appdef.NewQName(RegistryPackage, "UpdateGlobalRoles")
+	QNameCommandCreateOIDCLogin                       = appdef.NewQName(RegistryPackage, "CreateOIDCLogin")
+	QNameQueryIssueOIDCPrincipalToken                 = appdef.NewQName(RegistryPackage, "IssueOIDCPrincipalToken")
+	QNameCommandRevokeAllSessions                     = appdef.NewQName(RegistryPackage, "RevokeAllSessions")
+	QNameCommandEnableTOTP                            = appdef.NewQName(RegistryPackage, "EnableTOTP")
+	QNameCommandDisableTOTP                           = appdef.NewQName(RegistryPackage, "DisableTOTP")
+	QNameCommandSetEmailSecondFactor                  = appdef.NewQName(RegistryPackage, "SetEmailSecondFactor")
+	QNameQueryInitiateTOTPEnrollment                  = appdef.NewQName(RegistryPackage, "InitiateTOTPEnrollment")
+	QNameQueryInitiateEmailSecondFactor               = appdef.NewQName(RegistryPackage, "InitiateEmailSecondFactor")
+	QNameQueryIssueEmailSecondFactorToken             = appdef.NewQName(RegistryPackage, "IssueEmailSecondFactorToken")
+	qNameEnableTOTPResult                             = appdef.NewQName(RegistryPackage, "EnableTOTPResult")
 	QNameCommandResetPasswordByEmailUnloggedParams    = appdef.NewQName(RegistryPackage, "ResetPasswordByEmailUnloggedParams")
 	QNameQueryInitiateResetPasswordByEmail            = appdef.NewQName(RegistryPackage, "InitiateResetPasswordByEmail")
 	QNameQueryIssueVerifiedValueTokenForResetPassword = appdef.NewQName(RegistryPackage, "IssueVerifiedValueTokenForResetPassword")
@@ -53,6 +101,15 @@ var (
 	qNameProjectorInvokeCreateWorkspaceID_registry    = appdef.NewQName(RegistryPackage, "InvokeCreateWorkspaceID_registry")
 	errPasswordIsIncorrect                            = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "password is incorrect")
 	errLoginOrPasswordIsIncorrect                     = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "login or password is incorrect")
+	errSecondFactorRequired                           = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "second factor is required")
+	errSecondFactorIsIncorrect                        = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "second factor is incorrect")
+	errSecondFactorEnrollmentRequired                 = coreutils.NewHTTPErrorf(http.StatusForbidden, "second factor must be enrolled first")
+	errTOTPIsNotConfigured                            = coreutils.NewHTTPErrorf(http.StatusServiceUnavailable, "TOTP is not configured")
+	errTOTPEnrolledAlready                            = coreutils.NewHTTPErrorf(http.StatusConflict, "TOTP is enrolled already")
+	errTOTPIsNotEnrolled                              = coreutils.NewHTTPErrorf(http.StatusConflict, "TOTP is not enrolled")
+	errEmailSecondFactorIsNotEnabled                  = coreutils.NewHTTPErrorf(http.StatusConflict, "e-mail second factor is not enabled")
+	errTooManySecondFactorFailures                    = coreutils.NewHTTPErrorf(http.StatusTooManyRequests, "too many failed second factor attempts, try again later")
+	errSecondFactorStateConflict                      = errors.New("second factor state is concurrently modified too many times")
 
 	//go:embed appws.vsql
 	schemasFS embed.FS
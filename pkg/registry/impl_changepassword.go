@@ -8,14 +8,15 @@ package registry
 import (
 	"time"
 
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 )
 
-func provideChangePassword(cfgRegistry *istructsmem.AppConfigType) {
+func provideChangePassword(cfgRegistry *istructsmem.AppConfigType, sessions isessions.ISessions) {
 	cfgRegistry.Resources.Add(istructsmem.NewCommandFunction(
 		qNameCmdChangePassword,
-		cmdChangePasswordExec,
+		provideCmdChangePasswordExec(sessions),
 	))
 
 	cfgRegistry.FunctionRateLimits.AddAppLimit(qNameCmdChangePassword, istructs.RateLimit{
@@ -26,29 +27,35 @@ func provideChangePassword(cfgRegistry *istructsmem.AppConfigType) {
 
 // sys/registry/pseudoWSID
 // null auth
-func cmdChangePasswordExec(args istructs.ExecCommandArgs) (err error) {
-	appName := args.ArgumentObject.AsString(field_AppName)
-	login := args.ArgumentObject.AsString(field_Login)
-	oldPwd := args.ArgumentUnloggedObject.AsString(field_OldPassword)
-	newPwd := args.ArgumentUnloggedObject.AsString(field_NewPassword)
-
-	cdocLogin, loginExists, err := GetCDocLogin(login, args.State, args.WSID, appName)
-	if err != nil {
-		return err
+// all sessions of the login are revoked
+func provideCmdChangePasswordExec(sessions isessions.ISessions) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		appName := args.ArgumentObject.AsString(field_AppName)
+		login := args.ArgumentObject.AsString(field_Login)
+		oldPwd := args.ArgumentUnloggedObject.AsString(field_OldPassword)
+		newPwd := args.ArgumentUnloggedObject.AsString(field_NewPassword)
+
+		cdocLogin, loginExists, err := GetCDocLogin(login, args.State, args.WSID, appName)
+		if err != nil {
+			return err
+		}
+
+		if !loginExists {
+			return errLoginDoesNotExist(login)
+		}
+
+		isPasswordOK, err := CheckPassword(cdocLogin, oldPwd)
+		if err != nil {
+			return err
+		}
+
+		if !isPasswordOK {
+			return errPasswordIsIncorrect
+		}
+
+		if err = ChangePasswordCDocLogin(cdocLogin, newPwd, args.Intents, args.State); err != nil {
+			return err
+		}
+		return revokeAllSessions(sessions, appName, login)
 	}
-
-	if !loginExists {
-		return errLoginDoesNotExist(login)
-	}
-
-	isPasswordOK, err := CheckPassword(cdocLogin, oldPwd)
-	if err != nil {
-		return err
-	}
-
-	if !isPasswordOK {
-		return errPasswordIsIncorrect
-	}
-
-	return ChangePasswordCDocLogin(cdocLogin, newPwd, args.Intents, args.State)
 }
@@ -40,18 +40,7 @@ func createLogin(args istructs.ExecCommandArgs, login string) (err error) {
 		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "SubjectKind must be >0 and <", istructs.SubjectKind_FakeLast)
 	}
 
-	appQName, err := appdef.ParseAppQName(appName)
-	if err != nil {
-		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "failed to parse app qualified name", appQName.String(), ":", err)
-	}
-
-	appParts := args.Workpiece.(interface {
-		AppPartitions() appparts.IAppPartitions
-	}).AppPartitions()
-	if _, err = appParts.AppDef(appQName); err != nil {
-		if errors.Is(err, appparts.ErrNotFound) {
-			return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("target app %s is not found", appQName))
-		}
+	if err = checkTargetApp(args, appName); err != nil {
 		return err
 	}
 
@@ -75,8 +64,30 @@ func createLogin(args istructs.ExecCommandArgs, login string) (err error) {
 		return coreutils.NewHTTPErrorf(http.StatusConflict, "login already exists")
 	}
 
+	return newCDocLogin(args, login, appName, subjectKind, args.ArgumentUnloggedObject.AsString(field_Passwrd))
+}
+
+func checkTargetApp(args istructs.ExecCommandArgs, appName string) error {
+	appQName, err := appdef.ParseAppQName(appName)
+	if err != nil {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "failed to parse app qualified name", appQName.String(), ":", err)
+	}
+
+	appParts := args.Workpiece.(interface {
+		AppPartitions() appparts.IAppPartitions
+	}).AppPartitions()
+	if _, err = appParts.AppDef(appQName); err != nil {
+		if errors.Is(err, appparts.ErrNotFound) {
+			return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("target app %s is not found", appQName))
+		}
+		return err
+	}
+	return nil
+}
+
+func newCDocLogin(args istructs.ExecCommandArgs, login string, appName string, subjectKind int32, pwd string) (err error) {
 	wsKindInitializationData := args.ArgumentObject.AsString(authnz.Field_WSKindInitializationData)
-	pwdSaltedHash, err := GetPasswordSaltedHash(args.ArgumentUnloggedObject.AsString(field_Passwrd))
+	pwdSaltedHash, err := GetPasswordSaltedHash(pwd)
 	if err != nil {
 		return err
 	}
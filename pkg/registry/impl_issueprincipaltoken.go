@@ -13,6 +13,7 @@ import (
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -36,7 +37,8 @@ func (q *iptRR) AsString(name string) string {
 	return q.principalToken
 }
 
-func provideIssuePrincipalTokenExec(itokens itokens.ITokens) istructsmem.ExecQueryClosure {
+// params: TOTPCode or EmailCodeToken if the second factor is enrolled
+func provideIssuePrincipalTokenExec(itokens itokens.ITokens, sessions isessions.ISessions, sf *secondFactor) istructsmem.ExecQueryClosure {
 	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
 		login := args.ArgumentObject.AsString(authnz.Field_Login)
 		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
@@ -68,46 +70,61 @@ func provideIssuePrincipalTokenExec(itokens itokens.ITokens) istructsmem.ExecQue
 			return errLoginOrPasswordIsIncorrect
 		}
 
-		result := &iptRR{
-			profileWSID:          cdocLogin.AsInt64(authnz.Field_WSID),
-			profileCreationError: cdocLogin.AsString(authnz.Field_WSError),
-		}
-		if result.profileWSID == 0 || len(result.profileCreationError) > 0 {
-			return callback(result)
+		if err := sf.checkOnIssue(args.State.AppStructs().AppTokens(), appQName, login, cdocLogin, args.ArgumentObject); err != nil {
+			return err
 		}
 
-		// read global globalRoles
-		globarRolesStr := cdocLogin.AsString(authnz.Field_GlobalRoles)
-		var globalRoles []appdef.QName
-		if len(globarRolesStr) > 0 {
-			globalRolesStr := strings.Split(cdocLogin.AsString(authnz.Field_GlobalRoles), ",")
-			for _, role := range globalRolesStr {
-				roleQName, err := appdef.ParseQName(role)
-				if err != nil {
-					return err
-				}
-				globalRoles = append(globalRoles, roleQName)
+		return issuePrincipalToken(itokens, sessions, appQName, login, cdocLogin, args.ArgumentObject, callback)
+	}
+}
+
+// the login is authenticated already
+// the new session is created, params: TTLHours, Device, RemoteAddr
+func issuePrincipalToken(itokens itokens.ITokens, sessions isessions.ISessions, appQName appdef.AppQName, login string, cdocLogin istructs.IStateValue,
+	params istructs.IObject, callback istructs.ExecQueryCallback) (err error) {
+	result := &iptRR{
+		profileWSID:          cdocLogin.AsInt64(authnz.Field_WSID),
+		profileCreationError: cdocLogin.AsString(authnz.Field_WSError),
+	}
+	if result.profileWSID == 0 || len(result.profileCreationError) > 0 {
+		return callback(result)
+	}
+
+	// read global globalRoles
+	globarRolesStr := cdocLogin.AsString(authnz.Field_GlobalRoles)
+	var globalRoles []appdef.QName
+	if len(globarRolesStr) > 0 {
+		globalRolesStr := strings.Split(cdocLogin.AsString(authnz.Field_GlobalRoles), ",")
+		for _, role := range globalRolesStr {
+			roleQName, err := appdef.ParseQName(role)
+			if err != nil {
+				return err
 			}
+			globalRoles = append(globalRoles, roleQName)
 		}
+	}
 
-		// issue principal token
-		principalPayload := payloads.PrincipalPayload{
-			Login:       args.ArgumentObject.AsString(authnz.Field_Login),
-			SubjectKind: istructs.SubjectKindType(cdocLogin.AsInt32(authnz.Field_SubjectKind)),
-			ProfileWSID: istructs.WSID(result.profileWSID), //nolint G115 since WSID is created by NewWSID()
-			GlobalRoles: globalRoles,                       // [~server.authnz.groles/cmp.c.registry.IssuePrincipalToken~impl]
-		}
-		ttl := time.Duration(args.ArgumentObject.AsInt32(field_TTLHours)) * time.Hour
-		if ttl == 0 {
-			ttl = authnz.DefaultPrincipalTokenExpiration
-		} else if ttl > maxTokenTTLHours*time.Hour {
-			return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Errorf("max token TTL hours is %d hours", maxTokenTTLHours))
-		}
+	// issue principal token
+	principalPayload := payloads.PrincipalPayload{
+		Login:       login,
+		SubjectKind: istructs.SubjectKindType(cdocLogin.AsInt32(authnz.Field_SubjectKind)),
+		ProfileWSID: istructs.WSID(result.profileWSID), //nolint G115 since WSID is created by NewWSID()
+		GlobalRoles: globalRoles,                       // [~server.authnz.groles/cmp.c.registry.IssuePrincipalToken~impl]
+	}
+	ttl := time.Duration(params.AsInt32(field_TTLHours)) * time.Hour
+	if ttl == 0 {
+		ttl = authnz.DefaultPrincipalTokenExpiration
+	} else if ttl > maxTokenTTLHours*time.Hour {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Errorf("max token TTL hours is %d hours", maxTokenTTLHours))
+	}
 
-		if result.principalToken, err = itokens.IssueToken(appQName, ttl, &principalPayload); err != nil {
-			return fmt.Errorf("principal token issue failed: %w", err)
-		}
+	if principalPayload.SessionID, err = sessions.Create(appQName, login, ttl, params.AsString(field_Device), params.AsString(field_RemoteAddr)); err != nil {
+		return fmt.Errorf("session create failed: %w", err)
+	}
 
-		return callback(result)
+	if result.principalToken, err = itokens.IssueToken(appQName, ttl, &principalPayload); err != nil {
+		return fmt.Errorf("principal token issue failed: %w", err)
 	}
+
+	return callback(result)
 }
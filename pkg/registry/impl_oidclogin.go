@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/isessions"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/oidc"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+// sys/registry, pseudoProfileWSID translated to appWSID, called by the router on OIDC callback with the system token
+// the external subject is authenticated by the identity provider already
+// does nothing if the login exists already
+func execCmdCreateOIDCLogin(args istructs.ExecCommandArgs) (err error) {
+	login := args.ArgumentObject.AsString(authnz.Field_Login)
+	if !oidc.IsLogin(login) {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "login of the external subject expected: ", login)
+	}
+	appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+	if err = checkTargetApp(args, appName); err != nil {
+		return err
+	}
+	if err = CheckAppWSID(login, args.WSID, args.State.AppStructs().NumAppWorkspaces()); err != nil {
+		return err
+	}
+	cdocLoginID, err := GetCDocLoginID(args.State, args.WSID, appName, login)
+	if err != nil || cdocLoginID > 0 {
+		return err
+	}
+	// nobody knows the password, so the login could not be used to sign in by IssuePrincipalToken
+	return newCDocLogin(args, login, appName, int32(istructs.SubjectKind_User), randomPassword())
+}
+
+// q.registry.IssueOIDCPrincipalToken, called by the router on OIDC callback with the system token
+// params: TOTPCode or EmailCodeToken if the second factor is enrolled, the same as for IssuePrincipalToken
+func provideIssueOIDCPrincipalTokenExec(itokens itokens.ITokens, sessions isessions.ISessions, sf *secondFactor) istructsmem.ExecQueryClosure {
+	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		login := args.ArgumentObject.AsString(authnz.Field_Login)
+		if !oidc.IsLogin(login) {
+			return coreutils.NewHTTPErrorf(http.StatusBadRequest, "login of the external subject expected: ", login)
+		}
+		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+		appQName, err := appdef.ParseAppQName(appName)
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		cdocLogin, doesLoginExist, err := GetCDocLogin(login, args.State, args.WSID, appName)
+		if err != nil {
+			return err
+		}
+		if !doesLoginExist {
+			return errLoginDoesNotExist(login)
+		}
+		if err := sf.checkOnIssue(args.State.AppStructs().AppTokens(), appQName, login, cdocLogin, args.ArgumentObject); err != nil {
+			return err
+		}
+		return issuePrincipalToken(itokens, sessions, appQName, login, cdocLogin, args.ArgumentObject, callback)
+	}
+}
+
+func randomPassword() string {
+	b := make([]byte, randomPasswordLen)
+	if _, err := rand.Read(b); err != nil {
+		// notest
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -20,7 +21,7 @@ import (
 	"github.com/voedger/voedger/pkg/sys/authnz"
 )
 
-func provideResetPassword(cfgRegistry *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation) {
+func provideResetPassword(cfgRegistry *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation, sessions isessions.ISessions) {
 
 	// sys/registry/pseudoProfileWSID/q.sys.InitiateResetPasswordByEmail
 	// null auth
@@ -33,12 +34,12 @@ func provideResetPassword(cfgRegistry *istructsmem.AppConfigType, itokens itoken
 	// null auth
 	cfgRegistry.Resources.Add(istructsmem.NewQueryFunction(
 		QNameQueryIssueVerifiedValueTokenForResetPassword,
-		provideIssueVerifiedValueTokenForResetPasswordExec(itokens, federation),
+		provideIssueVerifiedValueTokenExec(itokens, federation),
 	))
 
 	cfgRegistry.Resources.Add(istructsmem.NewCommandFunction(
 		QNameCommandResetPasswordByEmail,
-		cmdResetPasswordByEmailExec,
+		provideCmdResetPasswordByEmailExec(sessions),
 	))
 }
 
@@ -79,25 +80,19 @@ func provideQryInitiateResetPasswordByEmailExec(itokens itokens.ITokens, federat
 			return coreutils.NewHTTPErrorf(http.StatusLocked, "login profile is not initialized")
 		}
 
-		sysToken, err := payloads.GetSystemPrincipalToken(itokens, loginAppQName)
+		verificationToken, err := initiateEmailVerification(itokens, federation, loginAppQName, profileWSID,
+			QNameCommandResetPasswordByEmailUnloggedParams, field_Email, email, language)
 		if err != nil {
 			return err
 		}
-		body := fmt.Sprintf(`{"args":{"Entity":"%s","Field":"%s","Email":"%s","TargetWSID":%d,"ForRegistry":true,"Language":"%s"},"elements":[{"fields":["VerificationToken"]}]}`,
-			QNameCommandResetPasswordByEmailUnloggedParams, field_Email, email, profileWSID, language) // targetWSID - is the workspace we're going to use the verified value at
-		resp, err := federation.Func(fmt.Sprintf("api/%s/%d/q.sys.InitiateEmailVerification", loginAppQName, profileWSID), body, coreutils.WithAuthorizeBy(sysToken))
-		if err != nil {
-			return fmt.Errorf("q.sys.InitiateEmailVerification failed: %w", err)
-		}
-
-		verificationToken := resp.SectionRow()[0].(string)
 		return callback(&result{token: verificationToken, profileWSID: profileWSID})
 	}
 }
 
 // sys/registry/pseudoWSID
 // null auth
-func provideIssueVerifiedValueTokenForResetPasswordExec(itokens itokens.ITokens, federation federation.IFederation) istructsmem.ExecQueryClosure {
+// for both q.registry.IssueVerifiedValueTokenForResetPassword and q.registry.IssueEmailSecondFactorToken
+func provideIssueVerifiedValueTokenExec(itokens itokens.ITokens, federation federation.IFederation) istructsmem.ExecQueryClosure {
 	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
 		token := args.ArgumentObject.AsString(field_VerificationToken)
 		code := args.ArgumentObject.AsString(field_VerificationCode)
@@ -126,13 +121,18 @@ func provideIssueVerifiedValueTokenForResetPasswordExec(itokens itokens.ITokens,
 
 // sys/registry/pseudoWSID
 // null auth
-func cmdResetPasswordByEmailExec(args istructs.ExecCommandArgs) (err error) {
-	email := args.ArgumentUnloggedObject.AsString(field_Email)
-	newPwd := args.ArgumentUnloggedObject.AsString(field_NewPwd)
-	appName := args.ArgumentObject.AsString(authnz.Field_AppName)
-	login := email
-
-	return ChangePassword(login, args.State, args.Intents, args.WSID, appName, newPwd)
+func provideCmdResetPasswordByEmailExec(sessions isessions.ISessions) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		email := args.ArgumentUnloggedObject.AsString(field_Email)
+		newPwd := args.ArgumentUnloggedObject.AsString(field_NewPwd)
+		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+		login := email
+
+		if err = ChangePassword(login, args.State, args.Intents, args.WSID, appName, newPwd); err != nil {
+			return err
+		}
+		return revokeAllSessions(sessions, appName, login)
+	}
 }
 
 func (r *result) AsString(string) string {
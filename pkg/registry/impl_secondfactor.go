@@ -0,0 +1,358 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+func newSecondFactor(cfg SecondFactorConfig, time timeu.ITime) *secondFactor {
+	sf := &secondFactor{
+		SecondFactorConfig: cfg,
+		time:               time,
+	}
+	if len(cfg.TOTPSecretKey) > 0 {
+		block, err := aes.NewCipher(cfg.TOTPSecretKey)
+		if err != nil {
+			panic(fmt.Sprintf("wrong TOTP secret key: %s", err))
+		}
+		if sf.aead, err = cipher.NewGCM(block); err != nil {
+			// notest
+			panic(err)
+		}
+	}
+	return sf
+}
+
+func provideSecondFactor(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation, sf *secondFactor) {
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryInitiateTOTPEnrollment,
+		sf.qryInitiateTOTPEnrollmentExec,
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandEnableTOTP,
+		sf.cmdEnableTOTPExec,
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandDisableTOTP,
+		sf.cmdDisableTOTPExec,
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandSetEmailSecondFactor,
+		sf.cmdSetEmailSecondFactorExec,
+	))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryInitiateEmailSecondFactor,
+		provideQryInitiateEmailSecondFactorExec(itokens, federation),
+	))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryIssueEmailSecondFactorToken,
+		provideIssueVerifiedValueTokenExec(itokens, federation),
+	))
+}
+
+// sys/registry/pseudoWSID
+// null auth
+// the TOTP is enrolled by c.registry.EnableTOTP after the user confirms the secret by the code generated by the authenticator app
+func (sf *secondFactor) qryInitiateTOTPEnrollmentExec(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error {
+	if sf.aead == nil {
+		return errTOTPIsNotConfigured
+	}
+	login := args.ArgumentObject.AsString(field_Login)
+	appName := args.ArgumentObject.AsString(field_AppName)
+	cdocLogin, err := getCDocLoginByPassword(args.State, args.WSID, appName, login, args.ArgumentObject.AsString(field_Passwrd))
+	if err != nil {
+		return err
+	}
+	if isTOTPEnrolled(cdocLogin) {
+		return errTOTPEnrolledAlready
+	}
+
+	secret := coreutils.NewTOTPSecret()
+	enrollmentToken, err := args.State.AppStructs().AppTokens().IssueToken(totpEnrollmentTokenTTL, &totpEnrollmentPayload{
+		Login:   login,
+		AppName: appName,
+		Secret:  secret,
+	})
+	if err != nil {
+		return err
+	}
+	return callback(&totpEnrollmentResult{
+		secret:          coreutils.TOTPSecretString(secret),
+		uri:             totpURI(appName, login, secret),
+		enrollmentToken: enrollmentToken,
+	})
+}
+
+// sys/registry/pseudoWSID
+// null auth
+// the current second factor is required if the e-mail second factor is enabled already
+func (sf *secondFactor) cmdEnableTOTPExec(args istructs.ExecCommandArgs) error {
+	if sf.aead == nil {
+		return errTOTPIsNotConfigured
+	}
+	login := args.ArgumentObject.AsString(field_Login)
+	appName := args.ArgumentObject.AsString(field_AppName)
+	cdocLogin, err := getCDocLoginByPassword(args.State, args.WSID, appName, login, args.ArgumentUnloggedObject.AsString(field_Passwrd))
+	if err != nil {
+		return err
+	}
+	if isTOTPEnrolled(cdocLogin) {
+		return errTOTPEnrolledAlready
+	}
+	if err := sf.verifyIfEnrolled(args.State.AppStructs().AppTokens(), appName, login, cdocLogin, args.ArgumentUnloggedObject); err != nil {
+		return err
+	}
+
+	payload := totpEnrollmentPayload{}
+	if _, err := args.State.AppStructs().AppTokens().ValidateToken(args.ArgumentUnloggedObject.AsString(field_EnrollmentToken), &payload); err != nil {
+		return coreutils.NewHTTPError(http.StatusBadRequest, err)
+	}
+	if payload.Login != login || payload.AppName != appName {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "enrollment token is issued for another login")
+	}
+	step, ok := coreutils.MatchTOTPCode(payload.Secret, args.ArgumentUnloggedObject.AsString(field_NewTOTPCode), sf.time.Now())
+	if !ok {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "TOTP code does not match the enrolled secret")
+	}
+	// the confirmation code could not be used to sign in
+	if _, err := sf.acceptTOTPStep(appName, login, step); err != nil {
+		return err
+	}
+
+	encryptedSecret, err := sf.encryptTOTPSecret(appName, login, payload.Secret)
+	if err != nil {
+		return err
+	}
+	recoveryCodes, recoveryCodesHash := newRecoveryCodes()
+
+	loginUpdater, err := updateCDocLogin(cdocLogin, args.State, args.Intents)
+	if err != nil {
+		return err
+	}
+	loginUpdater.PutBytes(field_TOTPSecret, encryptedSecret)
+	loginUpdater.PutBytes(field_RecoveryCodesHash, recoveryCodesHash)
+
+	kb, err := args.State.KeyBuilder(sys.Storage_Result, qNameEnableTOTPResult)
+	if err != nil {
+		return err
+	}
+	result, err := args.Intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	result.PutString(field_RecoveryCodes, strings.Join(recoveryCodes, recoveryCodesSeparator))
+	return nil
+}
+
+// sys/registry/pseudoWSID
+// null auth
+// the recovery code is accepted as the TOTP code, e.g. if the TOTP device is lost
+func (sf *secondFactor) cmdDisableTOTPExec(args istructs.ExecCommandArgs) error {
+	login := args.ArgumentObject.AsString(field_Login)
+	appName := args.ArgumentObject.AsString(field_AppName)
+	cdocLogin, err := getCDocLoginByPassword(args.State, args.WSID, appName, login, args.ArgumentUnloggedObject.AsString(field_Passwrd))
+	if err != nil {
+		return err
+	}
+	if !isTOTPEnrolled(cdocLogin) {
+		return errTOTPIsNotEnrolled
+	}
+	// all recovery codes are dropped together with the TOTP secret, so the used code can not be used again
+	if !isRecoveryCode(cdocLogin, args.ArgumentUnloggedObject.AsString(field_TOTPCode)) {
+		if err := sf.verify(args.State.AppStructs().AppTokens(), appName, login, cdocLogin, args.ArgumentUnloggedObject); err != nil {
+			return err
+		}
+	}
+
+	loginUpdater, err := updateCDocLogin(cdocLogin, args.State, args.Intents)
+	if err != nil {
+		return err
+	}
+	loginUpdater.PutBytes(field_TOTPSecret, []byte{})
+	loginUpdater.PutBytes(field_RecoveryCodesHash, []byte{})
+	return nil
+}
+
+// sys/registry/pseudoWSID
+// null auth
+// the e-mail second factor is available for logins that are e-mails
+func (sf *secondFactor) cmdSetEmailSecondFactorExec(args istructs.ExecCommandArgs) error {
+	login := args.ArgumentObject.AsString(field_Login)
+	appName := args.ArgumentObject.AsString(field_AppName)
+	enabled := args.ArgumentObject.AsBool(field_Enabled)
+	cdocLogin, err := getCDocLoginByPassword(args.State, args.WSID, appName, login, args.ArgumentUnloggedObject.AsString(field_Passwrd))
+	if err != nil {
+		return err
+	}
+	if cdocLogin.AsBool(field_EmailSecondFactor) == enabled {
+		return nil
+	}
+	if enabled {
+		if err := coreutils.ValidateEMail(login); err != nil {
+			return coreutils.NewHTTPErrorf(http.StatusBadRequest, "login is not an e-mail: ", err)
+		}
+	}
+	if err := sf.verifyIfEnrolled(args.State.AppStructs().AppTokens(), appName, login, cdocLogin, args.ArgumentUnloggedObject); err != nil {
+		return err
+	}
+
+	loginUpdater, err := updateCDocLogin(cdocLogin, args.State, args.Intents)
+	if err != nil {
+		return err
+	}
+	loginUpdater.PutBool(field_EmailSecondFactor, enabled)
+	return nil
+}
+
+// sys/registry/pseudoWSID
+// null auth
+// the one-time code is e-mailed to the login, then it is exchanged to the EmailCodeToken by q.registry.IssueEmailSecondFactorToken
+func provideQryInitiateEmailSecondFactorExec(itokens itokens.ITokens, federation federation.IFederation) istructsmem.ExecQueryClosure {
+	return func(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error {
+		login := args.ArgumentObject.AsString(field_Login)
+		appName := args.ArgumentObject.AsString(field_AppName)
+		appQName, err := appdef.ParseAppQName(appName)
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		cdocLogin, err := getCDocLoginByPassword(args.State, args.WSID, appName, login, args.ArgumentObject.AsString(field_Passwrd))
+		if err != nil {
+			return err
+		}
+		if !cdocLogin.AsBool(field_EmailSecondFactor) {
+			return errEmailSecondFactorIsNotEnabled
+		}
+		profileWSID := cdocLogin.AsInt64(authnz.Field_WSID)
+		if profileWSID == 0 {
+			return coreutils.NewHTTPErrorf(http.StatusLocked, "login profile is not initialized")
+		}
+		verificationToken, err := initiateEmailVerification(itokens, federation, appQName, profileWSID,
+			QNameCDocLogin, field_EmailSecondFactor, login, args.ArgumentObject.AsString(field_Language))
+		if err != nil {
+			return err
+		}
+		return callback(&result{token: verificationToken, profileWSID: profileWSID})
+	}
+}
+
+// nil -> the principal token could be issued
+func (sf *secondFactor) checkOnIssue(appTokens istructs.IAppTokens, appQName appdef.AppQName, login string, cdocLogin istructs.IStateValue, params istructs.IObject) error {
+	if !isSecondFactorEnrolled(cdocLogin) {
+		if sf.isRequired(appQName, cdocLogin) {
+			return errSecondFactorEnrollmentRequired
+		}
+		return nil
+	}
+	return sf.verify(appTokens, appQName.String(), login, cdocLogin, params)
+}
+
+// the second factor settings are changed by the one who passes the current second factor, if any
+func (sf *secondFactor) verifyIfEnrolled(appTokens istructs.IAppTokens, appName string, login string, cdocLogin istructs.IStateValue, params istructs.IObject) error {
+	if !isSecondFactorEnrolled(cdocLogin) {
+		return nil
+	}
+	return sf.verify(appTokens, appName, login, cdocLogin, params)
+}
+
+// params: TOTPCode, EmailCodeToken
+// each code is accepted once, the login is locked out for secondFactorFailuresTTL after maxSecondFactorFailures failed attempts
+func (sf *secondFactor) verify(appTokens istructs.IAppTokens, appName string, login string, cdocLogin istructs.IStateValue, params istructs.IObject) error {
+	totpCode := params.AsString(field_TOTPCode)
+	emailCodeToken := params.AsString(field_EmailCodeToken)
+	if len(totpCode) == 0 && len(emailCodeToken) == 0 {
+		return errSecondFactorRequired
+	}
+	failures, err := sf.failures(appName, login)
+	if err != nil {
+		return err
+	}
+	if failures >= maxSecondFactorFailures {
+		return errTooManySecondFactorFailures
+	}
+	ok, err := sf.check(appTokens, appName, login, cdocLogin, totpCode, emailCodeToken)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := sf.addFailure(appName, login); err != nil {
+			return err
+		}
+		return errSecondFactorIsIncorrect
+	}
+	if failures > 0 {
+		return sf.resetFailures(appName, login)
+	}
+	return nil
+}
+
+func (sf *secondFactor) check(appTokens istructs.IAppTokens, appName string, login string, cdocLogin istructs.IStateValue,
+	totpCode string, emailCodeToken string) (ok bool, err error) {
+	if len(totpCode) > 0 && isTOTPEnrolled(cdocLogin) {
+		if sf.aead == nil {
+			return false, errTOTPIsNotConfigured
+		}
+		secret, err := sf.decryptTOTPSecret(appName, login, cdocLogin.AsBytes(field_TOTPSecret))
+		if err != nil {
+			return false, err
+		}
+		if step, ok := coreutils.MatchTOTPCode(secret, totpCode, sf.time.Now()); ok {
+			return sf.acceptTOTPStep(appName, login, step)
+		}
+	}
+	if len(emailCodeToken) > 0 && cdocLogin.AsBool(field_EmailSecondFactor) {
+		vvp := payloads.VerifiedValuePayload{}
+		if gp, err := appTokens.ValidateToken(emailCodeToken, &vvp); err == nil &&
+			vvp.Entity == QNameCDocLogin && vvp.Field == field_EmailSecondFactor && vvp.Value == login {
+			return sf.useEmailCodeToken(appName, login, emailCodeToken, gp.IssuedAt.Add(gp.Duration))
+		}
+	}
+	return false, nil
+}
+
+func (sf *secondFactor) isRequired(appQName appdef.AppQName, cdocLogin istructs.IStateValue) bool {
+	requiredForRoles := sf.RequiredForGlobalRoles[appQName]
+	if len(requiredForRoles) == 0 {
+		return false
+	}
+	globalRolesStr := cdocLogin.AsString(field_GlobalRoles)
+	if len(globalRolesStr) == 0 {
+		return false
+	}
+	for _, role := range strings.Split(globalRolesStr, ",") {
+		for _, requiredForRole := range requiredForRoles {
+			if role == requiredForRole.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *totpEnrollmentResult) AsString(name string) string {
+	switch name {
+	case field_Secret:
+		return r.secret
+	case field_URI:
+		return r.uri
+	}
+	return r.enrollmentToken
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// the code of the step which is not later than the last accepted one is rejected, so that the intercepted code could not be replayed
+func (sf *secondFactor) acceptTOTPStep(appName string, login string, step uint64) (ok bool, err error) {
+	pKey := secondFactorPKey(appName, login)
+	value := binary.BigEndian.AppendUint64(nil, step)
+	for range maxSecondFactorCASAttempts {
+		data := []byte{}
+		exists, err := sf.Storage.TTLGet(pKey, ccolsLastTOTPStep, &data)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			ok, err = sf.Storage.InsertIfNotExists(pKey, ccolsLastTOTPStep, value, ttlSeconds(lastTOTPStepTTL))
+		} else {
+			if len(data) == len(value) && binary.BigEndian.Uint64(data) >= step {
+				return false, nil
+			}
+			ok, err = sf.Storage.CompareAndSwap(pKey, ccolsLastTOTPStep, data, value, ttlSeconds(lastTOTPStepTTL))
+		}
+		if err != nil || ok {
+			return ok, err
+		}
+		// concurrently accepted by other request, retry on the actual step
+	}
+	return false, errSecondFactorStateConflict
+}
+
+// the token is kept as used until it expires
+func (sf *secondFactor) useEmailCodeToken(appName string, login string, token string, expireAt time.Time) (ok bool, err error) {
+	tokenHash := sha256.Sum256([]byte(token))
+	cCols := append(append([]byte{}, ccolsUsedEmailCodePrefix...), tokenHash[:]...)
+	return sf.Storage.InsertIfNotExists(secondFactorPKey(appName, login), cCols, []byte{1}, ttlSeconds(expireAt.Sub(sf.time.Now())))
+}
+
+func (sf *secondFactor) failures(appName string, login string) (failures uint32, err error) {
+	data := []byte{}
+	exists, err := sf.Storage.TTLGet(secondFactorPKey(appName, login), ccolsSecondFactorFailures, &data)
+	if err != nil || !exists || len(data) != 4 { // nolint mnd
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(data), nil
+}
+
+// the window of the failures counter starts again on each failed attempt
+func (sf *secondFactor) addFailure(appName string, login string) error {
+	return sf.updateFailures(appName, login, func(failures uint32) uint32 { return failures + 1 })
+}
+
+func (sf *secondFactor) resetFailures(appName string, login string) error {
+	return sf.updateFailures(appName, login, func(uint32) uint32 { return 0 })
+}
+
+func (sf *secondFactor) updateFailures(appName string, login string, update func(failures uint32) uint32) error {
+	pKey := secondFactorPKey(appName, login)
+	for range maxSecondFactorCASAttempts {
+		data := []byte{}
+		exists, err := sf.Storage.TTLGet(pKey, ccolsSecondFactorFailures, &data)
+		if err != nil {
+			return err
+		}
+		failures := uint32(0)
+		if len(data) == 4 { // nolint mnd
+			failures = binary.BigEndian.Uint32(data)
+		}
+		value := binary.BigEndian.AppendUint32(nil, update(failures))
+		ok := false
+		if exists {
+			ok, err = sf.Storage.CompareAndSwap(pKey, ccolsSecondFactorFailures, data, value, ttlSeconds(secondFactorFailuresTTL))
+		} else {
+			ok, err = sf.Storage.InsertIfNotExists(pKey, ccolsSecondFactorFailures, value, ttlSeconds(secondFactorFailuresTTL))
+		}
+		if err != nil || ok {
+			return err
+		}
+	}
+	return errSecondFactorStateConflict
+}
+
+// pKey: App, Login
+func secondFactorPKey(appName string, login string) []byte {
+	pKey := make([]byte, 0, 2+len(appName)+len(login))
+	pKey = binary.BigEndian.AppendUint16(pKey, uint16(len(appName))) // nolint G115 app name is short
+	pKey = append(pKey, appName...)
+	return append(pKey, login...)
+}
+
+// at least 1 second, so that the record is not kept forever
+func ttlSeconds(d time.Duration) int {
+	return int(max(1, min(math.Ceil(d.Seconds()), math.MaxInt32)))
+}
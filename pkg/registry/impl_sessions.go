@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/isessions"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+// sys/registry/pseudoWSID
+// auth: System
+// e.g. the employee is fired, all principal tokens of the login become invalid
+func provideCmdRevokeAllSessionsExec(sessions isessions.ISessions) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		login := args.ArgumentObject.AsString(field_Login)
+		appName := args.ArgumentObject.AsString(field_AppName)
+		if _, err := appdef.ParseAppQName(appName); err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return revokeAllSessions(sessions, appName, login)
+	}
+}
+
+// appName is validated already
+func revokeAllSessions(sessions isessions.ISessions, appName string, login string) error {
+	appQName, err := appdef.ParseAppQName(appName)
+	if err != nil {
+		// notest
+		return err
+	}
+	if err := sessions.RevokeAll(context.Background(), appQName, login); err != nil {
+		return fmt.Errorf("failed to revoke sessions of login %s: %w", login, err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+// Storage that keeps the second factor state of logins shared among VVMs: the last accepted TOTP step,
+// the used e-mail code tokens and the failed attempts counter
+//
+// Implemented e.g. by the VVM storage adapter which prefixes pKeys to avoid collisions with other data
+type IStorage interface {
+	TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
+	InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error)
+	CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error)
+}
@@ -8,6 +8,8 @@ package registry
 import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -15,7 +17,9 @@ import (
 	_ "github.com/voedger/voedger/pkg/sys"
 )
 
-func Provide(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation) parser.PackageFS {
+func Provide(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation, sessions isessions.ISessions,
+	time timeu.ITime, secondFactorCfg SecondFactorConfig) parser.PackageFS {
+	sf := newSecondFactor(secondFactorCfg, time)
 	cfg.Resources.Add(istructsmem.NewCommandFunction(
 		QNameCommandCreateLogin,
 		execCmdCreateLogin,
@@ -25,12 +29,25 @@ func Provide(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation
 		execCmdCreateEmailLogin,
 	))
 
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandCreateOIDCLogin,
+		execCmdCreateOIDCLogin,
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandRevokeAllSessions,
+		provideCmdRevokeAllSessionsExec(sessions),
+	))
+
 	cfg.Resources.Add(istructsmem.NewQueryFunction(
 		appdef.NewQName(RegistryPackage, "IssuePrincipalToken"),
-		provideIssuePrincipalTokenExec(itokens)))
-	provideChangePassword(cfg)
-	provideResetPassword(cfg, itokens, federation)
+		provideIssuePrincipalTokenExec(itokens, sessions, sf)))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryIssueOIDCPrincipalToken,
+		provideIssueOIDCPrincipalTokenExec(itokens, sessions, sf)))
+	provideChangePassword(cfg, sessions)
+	provideResetPassword(cfg, itokens, federation, sessions)
 	provideUpdateGlobalRoles(cfg)
+	provideSecondFactor(cfg, itokens, federation, sf)
 	cfg.AddAsyncProjectors(
 		provideAsyncProjectorInvokeCreateWorkspaceID(federation, itokens),
 	)
@@ -4,7 +4,13 @@
 
 package registry
 
-import "github.com/voedger/voedger/pkg/istructs"
+import (
+	"crypto/cipher"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+)
 
 // for both Initiate*ResetPassword and Issue*ForResetPassword
 type result struct {
@@ -12,3 +18,38 @@ type result struct {
 	token       string
 	profileWSID int64
 }
+
+type SecondFactorConfig struct {
+	// TOTP secrets are kept encrypted by AES-256-GCM with this key, see TOTPSecretKeyName
+	// empty -> TOTP could not be enrolled
+	TOTPSecretKey []byte
+
+	// keeps used codes and failed attempts, so that the code could not be replayed or brute-forced
+	Storage IStorage
+
+	// the login having any of these global roles in the app must pass the second factor
+	// i.e. the principal token is not issued until the second factor is enrolled
+	RequiredForGlobalRoles map[appdef.AppQName][]appdef.QName
+}
+
+type secondFactor struct {
+	SecondFactorConfig
+	time timeu.ITime
+	aead cipher.AEAD // nil -> TOTP is not configured
+}
+
+// issued by q.registry.InitiateTOTPEnrollment, accepted by c.registry.EnableTOTP
+// the secret is not encrypted since it is returned to the user anyway
+type totpEnrollmentPayload struct {
+	Login   string
+	AppName string
+	Secret  []byte
+}
+
+// q.registry.InitiateTOTPEnrollment
+type totpEnrollmentResult struct {
+	istructs.NullObject
+	secret          string
+	uri             string
+	enrollmentToken string
+}
@@ -6,16 +6,22 @@
 package registry
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/sys"
 )
 
@@ -88,11 +94,7 @@ func UpdateGlobalRoles(login string, st istructs.IState, intents istructs.IInten
 	if !loginExists {
 		return errLoginDoesNotExist(login)
 	}
-	kb, err := st.KeyBuilder(sys.Storage_Record, appdef.NullQName)
-	if err != nil {
-		return err
-	}
-	loginUpdater, err := intents.UpdateValue(kb, cdocLogin)
+	loginUpdater, err := updateCDocLogin(cdocLogin, st, intents)
 	if err != nil {
 		return err
 	}
@@ -105,11 +107,7 @@ func errLoginDoesNotExist(login string) error {
 }
 
 func ChangePasswordCDocLogin(cdocLogin istructs.IStateValue, newPwd string, intents istructs.IIntents, st istructs.IState) error {
-	kb, err := st.KeyBuilder(sys.Storage_Record, appdef.NullQName)
-	if err != nil {
-		return err
-	}
-	loginUpdater, err := intents.UpdateValue(kb, cdocLogin)
+	loginUpdater, err := updateCDocLogin(cdocLogin, st, intents)
 	if err != nil {
 		return err
 	}
@@ -121,6 +119,14 @@ func ChangePasswordCDocLogin(cdocLogin istructs.IStateValue, newPwd string, inte
 	return nil
 }
 
+func updateCDocLogin(cdocLogin istructs.IStateValue, st istructs.IState, intents istructs.IIntents) (istructs.IStateValueBuilder, error) {
+	kb, err := st.KeyBuilder(sys.Storage_Record, appdef.NullQName)
+	if err != nil {
+		return nil, err
+	}
+	return intents.UpdateValue(kb, cdocLogin)
+}
+
 func GetPasswordSaltedHash(pwd string) (pwdSaltedHash []byte, err error) {
 	if pwdSaltedHash, err = bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.MinCost); err != nil {
 		err = fmt.Errorf("password salting & hashing failed: %w", err)
@@ -137,3 +143,104 @@ func CheckPassword(cdocLogin istructs.IStateValue, pwd string) (isPasswordOK boo
 	}
 	return true, nil
 }
+
+// the verification code is e-mailed by q.sys.InitiateEmailVerification called at the login profile
+// the verified value token is issued for sys/registry
+func initiateEmailVerification(itokens itokens.ITokens, federation federation.IFederation, loginAppQName appdef.AppQName, profileWSID int64,
+	entity appdef.QName, field string, email string, language string) (verificationToken string, err error) {
+	sysToken, err := payloads.GetSystemPrincipalToken(itokens, loginAppQName)
+	if err != nil {
+		return "", err
+	}
+	body := fmt.Sprintf(`{"args":{"Entity":"%s","Field":"%s","Email":"%s","TargetWSID":%d,"ForRegistry":true,"Language":"%s"},"elements":[{"fields":["VerificationToken"]}]}`,
+		entity, field, email, profileWSID, language) // targetWSID - is the workspace we're going to use the verified value at
+	resp, err := federation.Func(fmt.Sprintf("api/%s/%d/q.sys.InitiateEmailVerification", loginAppQName, profileWSID), body, coreutils.WithAuthorizeBy(sysToken))
+	if err != nil {
+		return "", fmt.Errorf("q.sys.InitiateEmailVerification failed: %w", err)
+	}
+	return resp.SectionRow()[0].(string), nil
+}
+
+// errLoginOrPasswordIsIncorrect -> the login does not exist or the password is wrong
+func getCDocLoginByPassword(st istructs.IState, appWSID istructs.WSID, appName string, login string, pwd string) (cdocLogin istructs.IStateValue, err error) {
+	cdocLogin, loginExists, err := GetCDocLogin(login, st, appWSID, appName)
+	if err != nil {
+		return nil, err
+	}
+	if !loginExists {
+		return nil, errLoginOrPasswordIsIncorrect
+	}
+	isPasswordOK, err := CheckPassword(cdocLogin, pwd)
+	if err != nil {
+		return nil, err
+	}
+	if !isPasswordOK {
+		return nil, errLoginOrPasswordIsIncorrect
+	}
+	return cdocLogin, nil
+}
+
+func isTOTPEnrolled(cdocLogin istructs.IStateValue) bool {
+	return len(cdocLogin.AsBytes(field_TOTPSecret)) > 0
+}
+
+func isSecondFactorEnrolled(cdocLogin istructs.IStateValue) bool {
+	return isTOTPEnrolled(cdocLogin) || cdocLogin.AsBool(field_EmailSecondFactor)
+}
+
+// the code is shown to the user once, the SHA-256 of each code is kept
+func newRecoveryCodes() (codes []string, codesHash []byte) {
+	for range recoveryCodesAmount {
+		code := coreutils.RecoveryCode()
+		codeHash := sha256.Sum256([]byte(code))
+		codes = append(codes, code)
+		codesHash = append(codesHash, codeHash[:]...)
+	}
+	return codes, codesHash
+}
+
+func isRecoveryCode(cdocLogin istructs.IStateValue, code string) bool {
+	if len(code) == 0 {
+		return false
+	}
+	codeHash := sha256.Sum256([]byte(code))
+	codesHash := cdocLogin.AsBytes(field_RecoveryCodesHash)
+	ok := false
+	for i := 0; i+sha256.Size <= len(codesHash); i += sha256.Size {
+		ok = subtle.ConstantTimeCompare(codesHash[i:i+sha256.Size], codeHash[:]) == 1 || ok
+	}
+	return ok
+}
+
+// the encrypted secret is bound to the login, i.e. could not be copied to another login
+// result: nonce + ciphertext
+func (sf *secondFactor) encryptTOTPSecret(appName string, login string, secret []byte) ([]byte, error) {
+	nonce := make([]byte, sf.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		// notest
+		return nil, err
+	}
+	return sf.aead.Seal(nonce, nonce, secret, totpSecretAdditionalData(appName, login)), nil
+}
+
+func (sf *secondFactor) decryptTOTPSecret(appName string, login string, encryptedSecret []byte) ([]byte, error) {
+	nonceSize := sf.aead.NonceSize()
+	if len(encryptedSecret) < nonceSize {
+		return nil, errors.New("encrypted TOTP secret is too short")
+	}
+	secret, err := sf.aead.Open(nil, encryptedSecret[:nonceSize], encryptedSecret[nonceSize:], totpSecretAdditionalData(appName, login))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt TOTP secret of login %s: %w", login, err)
+	}
+	return secret, nil
+}
+
+func totpSecretAdditionalData(appName string, login string) []byte {
+	return []byte(appName + "/" + login)
+}
+
+// see https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func totpURI(appName string, login string, secret []byte) string {
+	return fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s&algorithm=SHA1&digits=6&period=%d",
+		url.PathEscape(appName), url.PathEscape(login), coreutils.TOTPSecretString(secret), url.QueryEscape(appName), int(coreutils.TOTPPeriod.Seconds()))
+}
@@ -35,18 +35,74 @@ const (
 	URLPlaceholder_role             = "role"
 	URLPlaceholder_channelID        = "channelID"
 	URLPlaceholder_field            = "field"
+	URLPlaceholder_partition        = "partition"
+	URLPlaceholder_consumer         = "consumer"
+	URLPlaceholder_provider         = "provider"
 	hours24                         = 24 * time.Hour
 	DefaultRetryAfterSecondsOn503   = 1
 	defaultN10NExpiresInSeconds     = 60 * 60 * 24 // 24 hours
+	jwksPath                        = "/.well-known/jwks.json"
+
+	// keys scheduled for activation must be published at least this long before, see itokensjwt.SigningKey
+	jwksMaxAgeSeconds = 5 * 60
+
+	// the user must be authenticated by the identity provider within this time
+	oidcStateTTL    = 10 * time.Minute
+	oidcStateCookie = "voedger_oidc_state"
+
+	// the second factor query params of the OIDC login and the args of registry.IssueOIDCPrincipalToken
+	oidcParam_TOTPCode       = "totpCode"
+	oidcParam_EmailCodeToken = "emailCodeToken"
+	field_TOTPCode           = "TOTPCode"
+	field_EmailCodeToken     = "EmailCodeToken"
+
+	// the profile workspace of the new login is awaited on OIDC callback
+	oidcProfileAwaitTimeout  = 10 * time.Second
+	oidcProfileAwaitInterval = 100 * time.Millisecond
+
+	// session device is the User-Agent which is stored as text of the default max length
+	maxSessionDeviceLen = 255
 )
 
 var (
 	onRequestCtxClosed func() = nil // used in tests
 	adminEndpoint             = "127.0.0.1:55555"
+
+	// the server pings the notifications WebSocket client instead of sys.Heartbeat30
+	// the connection is closed if nothing is received from the client within wsPingInterval+wsPongTimeout
+	wsPingInterval = 30 * time.Second
+	wsPongTimeout  = 10 * time.Second
+
+	// principals of the notifications subscriber are re-obtained after that, so the revoked or expired token stops getting CUDs
+	n10nPrincipalsTTL = time.Minute
+)
+
+// payloads of notifications, see N10nArgs
+const (
+	N10nPayload_Offset = "offset"
+	N10nPayload_CUDs   = "cuds"   // offset and IDs and QNames of the changed records
+	N10nPayload_Fields = "fields" // N10nPayload_CUDs and the changed fields
+)
+
+// CUDs of at most that many last events are provided in one notification
+const maxN10nSummaryEvents = 100
+
+// types of notifications WebSocket messages
+const (
+	WSMessage_Subscribe   = "subscribe"
+	WSMessage_Unsubscribe = "unsubscribe"
+	WSMessage_Ping        = "ping"
+	WSMessage_Pong        = "pong"
+	WSMessage_ChannelID   = "channelId"
+	WSMessage_Update      = "update"
+	WSMessage_OK          = "ok"
+	WSMessage_Error       = "error"
 )
 
 const (
 	fieldLogin       = "login"
 	fieldPassword    = "password"
 	fieldDisplayName = "displayName"
+	fieldDevice      = "device"
+	fieldRemoteAddr  = "remoteAddr"
 )
@@ -21,11 +21,13 @@ import (
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/coreutils/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/iblobstorage"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/n10ncuds"
 	"github.com/voedger/voedger/pkg/processors"
 	blobprocessor "github.com/voedger/voedger/pkg/processors/blobber"
 )
@@ -156,7 +158,7 @@ func (s *httpService) registerHandlersV2() {
 	// [~server.n10n/cmp.routerCreateChannelHandler~impl]
 	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/notifications",
 		URLPlaceholder_appOwner, URLPlaceholder_appName),
-		corsHandler(requestHandlerV2_notifications_subscribeAndWatch(s.numsAppsWorkspaces, s.n10n, s.appTokensFactory))).
+		corsHandler(requestHandlerV2_notifications_subscribeAndWatch(s.numsAppsWorkspaces, s.n10n, s.appTokensFactory, s.n10nCUDs, s.time))).
 		Methods(http.MethodPost).Name("notifications subscribe + watch")
 
 	// notifications unsubscribe /api/v2/apps/{owner}/{app}/notifications/{channelId}/workspaces/{wsid}/subscriptions/{entity}
@@ -172,6 +174,12 @@ func (s *httpService) registerHandlersV2() {
 		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_channelID, URLPlaceholder_wsid, URLPlaceholder_view),
 		corsHandler(requestHandlerV2_notifications(s.numsAppsWorkspaces, s.n10n, s.appTokensFactory))).
 		Methods(http.MethodPut).Name("notifications subscribe to an extra view")
+
+	// notifications over WebSocket /api/v2/apps/{owner}/{app}/notifications/websocket
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/notifications/websocket",
+		URLPlaceholder_appOwner, URLPlaceholder_appName),
+		corsHandler(requestHandlerV2_notifications_websocket(s.numsAppsWorkspaces, s.n10n, s.appTokensFactory, s.WSAllowedOrigins))).
+		Methods(http.MethodGet).Name("notifications websocket")
 }
 
 func requestHandlerV2_schemas(reqSender bus.IRequestSender, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces) http.HandlerFunc {
@@ -265,7 +273,7 @@ func authorize(appTokensFactory payloads.IAppTokensFactory, busRequest bus.Reque
 }
 
 func requestHandlerV2_notifications_subscribeAndWatch(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
-	n10n in10n.IN10nBroker, appTokensFactory payloads.IAppTokensFactory) http.HandlerFunc {
+	n10n in10n.IN10nBroker, appTokensFactory payloads.IAppTokensFactory, n10nCUDs n10ncuds.IN10nCUDs, iTime timeu.ITime) http.HandlerFunc {
 	return withRequestValidation(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
 		flusher, ok := rw.(http.Flusher)
 		if !ok {
@@ -282,13 +290,37 @@ func requestHandlerV2_notifications_subscribeAndWatch(numsAppsWorkspaces map[app
 			return
 		}
 
-		subscriptions, expiresIn, err := parseN10nArgs(string(busRequest.Body))
+		subjectLogin := istructs.SubjectLogin(principalPayload.Login)
+		token, _ := bus.GetPrincipalToken(busRequest) // checked by authorize() already
+		if lastEventID := req.Header.Get(coreutils.LastEventID); len(lastEventID) > 0 {
+			// the channel keeps its subscriptions, so only the payload is taken from the body
+			n10nArgs := N10nArgs{}
+			if len(busRequest.Body) > 0 {
+				if err := coreutils.JSONUnmarshalDisallowUnknownFields(busRequest.Body, &n10nArgs); err != nil {
+					ReplyCommonError(rw, "failed to unmarshal request body: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			n10nData, err := n10nPayloadData(req.Context(), n10nCUDs, n10nArgs.Payload, token, iTime)
+			if err != nil {
+				ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			resumeN10NChannel(req.Context(), rw, flusher, lastEventID, n10n, subjectLogin, n10nData)
+			return
+		}
+
+		subscriptions, expiresIn, payload, err := parseN10nArgs(string(busRequest.Body))
+		if err != nil {
+			ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n10nData, err := n10nPayloadData(req.Context(), n10nCUDs, payload, token, iTime)
 		if err != nil {
 			ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
 			return
 		}
 
-		subjectLogin := istructs.SubjectLogin(principalPayload.Login)
 		channel, err := n10n.NewChannel(subjectLogin, expiresIn)
 		if err != nil {
 			ReplyCommonError(rw, "create new channel failed: "+err.Error(), http.StatusInternalServerError)
@@ -299,7 +331,7 @@ func requestHandlerV2_notifications_subscribeAndWatch(numsAppsWorkspaces map[app
 		rw.Header().Set("Cache-Control", "no-cache")
 		rw.Header().Set("Connection", "keep-alive")
 
-		if _, err = fmt.Fprintf(rw, "event: channelId\ndata: %s\n\n", channel); err != nil {
+		if _, err = fmt.Fprintf(rw, "id: %s\nevent: channelId\ndata: %s\n\n", n10nLastEventID(channel, 0), channel); err != nil {
 			// notest
 			logger.Error("failed to write created channel id to client:", err)
 			return
@@ -327,10 +359,41 @@ func requestHandlerV2_notifications_subscribeAndWatch(numsAppsWorkspaces map[app
 			subscribedProjectionKeys = append(subscribedProjectionKeys, projectionKey)
 		}
 
-		serveN10NChannel(req.Context(), rw, flusher, channel, n10n, subjectLogin)
+		serveN10NChannel(req.Context(), rw, flusher, channel, n10n, subjectLogin, true, n10nData)
 	})
 }
 
+// [Last-Event-ID] is `{channelID}:{eventID}`
+func resumeN10NChannel(ctx context.Context, rw http.ResponseWriter, flusher http.Flusher, lastEventIDHeader string, n10n in10n.IN10nBroker,
+	subjectLogin istructs.SubjectLogin, n10nData n10nDataFunc) {
+	channel, lastEventID, err := parseN10nLastEventID(lastEventIDHeader)
+	if err != nil {
+		ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := n10n.ResumeChannel(channel, subjectLogin, lastEventID); err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, in10n.ErrChannelDoesNotExist):
+			code = http.StatusNotFound
+		case errors.Is(err, in10n.ErrChannelIsWatched):
+			code = http.StatusConflict
+		}
+		ReplyCommonError(rw, "resume channel failed: "+err.Error(), code)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+
+	// WatchChannelEx must be called after ResumeChannel anyway, so write error is not checked here
+	if _, err = fmt.Fprintf(rw, "id: %s\nevent: channelId\ndata: %s\n\n", lastEventIDHeader, channel); err == nil {
+		flusher.Flush()
+	}
+	serveN10NChannel(ctx, rw, flusher, channel, n10n, subjectLogin, true, n10nData)
+}
+
 // handles both unsubscribe and subscribe to an extra view
 func requestHandlerV2_notifications(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
 	n10n in10n.IN10nBroker, appTokensFactory payloads.IAppTokensFactory) http.HandlerFunc {
@@ -388,38 +451,43 @@ func requestHandlerV2_notifications(numsAppsWorkspaces map[appdef.AppQName]istru
 	})
 }
 
-func parseN10nArgs(body string) (subscriptions []subscription, expiresIn time.Duration, err error) {
+func parseN10nArgs(body string) (subscriptions []subscription, expiresIn time.Duration, payload string, err error) {
 	n10nArgs := N10nArgs{}
 	if err := coreutils.JSONUnmarshalDisallowUnknownFields([]byte(body), &n10nArgs); err != nil {
-		return nil, 0, fmt.Errorf("failed to unmarshal request body: %w", err)
+		return nil, 0, "", fmt.Errorf("failed to unmarshal request body: %w", err)
 	}
 	if n10nArgs.ExpiresInSeconds == 0 {
 		n10nArgs.ExpiresInSeconds = defaultN10NExpiresInSeconds
 	} else if n10nArgs.ExpiresInSeconds < 0 {
-		return nil, 0, fmt.Errorf("invalid expiresIn value %d", n10nArgs.ExpiresInSeconds)
+		return nil, 0, "", fmt.Errorf("invalid expiresIn value %d", n10nArgs.ExpiresInSeconds)
 	}
 	expiresIn = time.Duration(n10nArgs.ExpiresInSeconds) * time.Second
-	if len(n10nArgs.Subscriptions) == 0 {
-		return nil, 0, errors.New("no subscriptions provided")
+	subscriptions, err = parseSubscriptions(n10nArgs.Subscriptions)
+	return subscriptions, expiresIn, n10nArgs.Payload, err
+}
+
+func parseSubscriptions(subscriptionsJSON []SubscriptionJSON) (subscriptions []subscription, err error) {
+	if len(subscriptionsJSON) == 0 {
+		return nil, errors.New("no subscriptions provided")
 	}
-	for i, subscr := range n10nArgs.Subscriptions {
+	for i, subscr := range subscriptionsJSON {
 		if len(subscr.Entity) == 0 || len(subscr.WSIDNumber.String()) == 0 {
-			return nil, 0, fmt.Errorf("subscriptions[%d]: entity and\\or wsid is not provided", i)
+			return nil, fmt.Errorf("subscriptions[%d]: entity and\\or wsid is not provided", i)
 		}
 		wsid, err := coreutils.ClarifyJSONWSID(subscr.WSIDNumber)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		entity, err := appdef.ParseQName(subscr.Entity)
 		if err != nil {
-			return nil, 0, fmt.Errorf("subscriptions[%d]: failed to parse entity %s as a QName: %w", i, subscr.Entity, err)
+			return nil, fmt.Errorf("subscriptions[%d]: failed to parse entity %s as a QName: %w", i, subscr.Entity, err)
 		}
 		subscriptions = append(subscriptions, subscription{
 			entity: entity,
 			wsid:   wsid,
 		})
 	}
-	return subscriptions, expiresIn, err
+	return subscriptions, nil
 }
 
 // [~server.devices/cmp.routerDevicesCreatePathHandler~impl]
@@ -464,12 +532,28 @@ func requestHandlerV2_auth_login(reqSender bus.IRequestSender, numsAppsWorkspace
 		busRequest.APIPath = int(processors.APIPath_Auth_Login)
 		busRequest.Method = http.MethodGet
 		queryParams := map[string]string{}
-		queryParams["args"] = string(busRequest.Body)
+		queryParams["args"] = loginArgs(req, busRequest.Body)
 		busRequest.Query = queryParams
 		sendRequestAndReadResponse(req, busRequest, reqSender, rw)
 	})
 }
 
+// the session metadata provided by the client is overwritten
+func loginArgs(req *http.Request, body []byte) string {
+	args := map[string]any{}
+	if err := json.Unmarshal(body, &args); err != nil {
+		// will be reported by the query processor
+		return string(body)
+	}
+	args[fieldDevice], args[fieldRemoteAddr] = sessionMeta(req)
+	res, err := json.Marshal(args)
+	if err != nil {
+		// notest
+		return string(body)
+	}
+	return string(res)
+}
+
 func requestHandlerV2_blobs_read(blobRequestHandler blobprocessor.IRequestHandler,
 	requestSender bus.IRequestSender) http.HandlerFunc {
 	return func(rw http.ResponseWriter, req *http.Request) {
@@ -621,7 +705,7 @@ func sendRequestAndReadResponse(req *http.Request, busRequest bus.Request, reqSe
 		return
 	}
 
-	initResponse(rw, respMeta.ContentType, respMeta.StatusCode)
+	initResponse(rw, respMeta.ContentType, respMeta.StatusCode, respMeta.Headers...)
 	reply_v2(requestCtx, rw, respCh, respErr, cancel, respMeta.Mode())
 }
 
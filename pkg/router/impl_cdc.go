@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/cdc"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+)
+
+func (s *httpService) registerHandlersCDC() {
+	if s.cdc == nil {
+		return
+	}
+
+	// change feed: /api/v2/apps/{owner}/{app}/cdc/partitions/{partition}/events
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/cdc/partitions/{%s:[0-9]+}/events",
+		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_partition),
+		corsHandler(requestHandlerV2_cdc_events(s.numsAppsWorkspaces, s.cdc, s.appTokensFactory))).
+		Methods(http.MethodGet).Name("cdc events")
+
+	// consumer offset: /api/v2/apps/{owner}/{app}/cdc/partitions/{partition}/consumers/{consumer}/offset
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/cdc/partitions/{%s:[0-9]+}/consumers/{%s}/offset",
+		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_partition, URLPlaceholder_consumer),
+		corsHandler(requestHandlerV2_cdc_offset(s.numsAppsWorkspaces, s.cdc, s.appTokensFactory))).
+		Methods(http.MethodGet, http.MethodPut).Name("cdc consumer offset")
+}
+
+// streams events as NDJSON or as SSE if `Accept: text/event-stream`
+// query params: consumer, from, follow, limit
+// SSE: Last-Event-ID header has the priority over `from`
+func requestHandlerV2_cdc_events(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, feed cdc.ICDC,
+	appTokensFactory payloads.IAppTokensFactory) http.HandlerFunc {
+	return withRequestValidation(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		if !authorizeCDC(rw, req, data, appTokensFactory) {
+			return
+		}
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			// notest
+			WriteTextResponse(rw, "streaming unsupported!", http.StatusInternalServerError)
+			return
+		}
+
+		params, err := parseCDCStreamParams(req, data)
+		if err != nil {
+			ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		isSSE := strings.Contains(req.Header.Get(coreutils.Accept), coreutils.ContentType_TextEventStream)
+		if isSSE {
+			if lastEventID := req.Header.Get(coreutils.LastEventID); len(lastEventID) > 0 {
+				lastOffset, err := strconv.ParseUint(lastEventID, utils.DecimalBase, utils.BitSize64)
+				if err != nil {
+					ReplyCommonError(rw, fmt.Sprintf("wrong %s header: %s", coreutils.LastEventID, err), http.StatusBadRequest)
+					return
+				}
+				params.From = istructs.Offset(lastOffset) + 1
+			}
+		}
+
+		headerWritten := false
+		writeHeader := func() {
+			if headerWritten {
+				return
+			}
+			headerWritten = true
+			if isSSE {
+				rw.Header().Set(coreutils.ContentType, coreutils.ContentType_TextEventStream)
+				rw.Header().Set("Cache-Control", "no-cache")
+				rw.Header().Set("Connection", "keep-alive")
+			} else {
+				rw.Header().Set(coreutils.ContentType, coreutils.ContentType_ApplicationNDJSON)
+			}
+			rw.WriteHeader(http.StatusOK)
+			flusher.Flush()
+		}
+		if params.Follow {
+			// client should know that the stream is established before the first event
+			writeHeader()
+		}
+
+		err = feed.Stream(req.Context(), params, func(event cdc.Event) error {
+			writeHeader()
+			eventJSON, err := json.Marshal(&event)
+			if err != nil {
+				// notest
+				return err
+			}
+			if isSSE {
+				_, err = fmt.Fprintf(rw, "id: %d\nevent: event\ndata: %s\n\n", event.PLogOffset, eventJSON)
+			} else {
+				_, err = fmt.Fprintf(rw, "%s\n", eventJSON)
+			}
+			if err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		})
+		if err != nil {
+			if !headerWritten {
+				replyCDCErr(rw, err)
+				return
+			}
+			// the stream is broken, the client will resume it from the last received event
+			logger.Error(fmt.Sprintf("cdc stream %s partition %d failed: %s", params.App, params.Partition, err))
+			return
+		}
+		writeHeader()
+	})
+}
+
+// GET -> {"offset":N}, PUT {"offset":N} -> commit
+func requestHandlerV2_cdc_offset(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, feed cdc.ICDC,
+	appTokensFactory payloads.IAppTokensFactory) http.HandlerFunc {
+	return withRequestValidation(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		if !authorizeCDC(rw, req, data, appTokensFactory) {
+			return
+		}
+		partition, err := parseCDCPartition(data)
+		if err != nil {
+			ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		consumer := data.vars[URLPlaceholder_consumer]
+
+		offset := struct {
+			Offset istructs.Offset `json:"offset"`
+		}{}
+		if req.Method == http.MethodGet {
+			if offset.Offset, err = feed.CommittedOffset(data.appQName, partition, consumer); err != nil {
+				replyCDCErr(rw, err)
+				return
+			}
+			offsetJSON, err := json.Marshal(&offset)
+			if err != nil {
+				// notest
+				ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			ReplyJSON(rw, string(offsetJSON), http.StatusOK)
+			return
+		}
+
+		if err := json.Unmarshal(data.body, &offset); err != nil {
+			ReplyCommonError(rw, "failed to unmarshal offset: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := feed.Commit(data.appQName, partition, consumer, offset.Offset); err != nil {
+			replyCDCErr(rw, err)
+			return
+		}
+		ReplyJSON(rw, "{}", http.StatusOK)
+	})
+}
+
+// the feed contains data of all workspaces, so the system principal is required
+func authorizeCDC(rw http.ResponseWriter, req *http.Request, data validatedData, appTokensFactory payloads.IAppTokensFactory) bool {
+	principalPayload, err := authorize(appTokensFactory, createBusRequest(req.Method, data, req))
+	if err != nil {
+		ReplyCommonError(rw, err.Error(), http.StatusUnauthorized)
+		return false
+	}
+	if principalPayload.ProfileWSID != istructs.NullWSID {
+		ReplyCommonError(rw, "system principal token is required", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func parseCDCPartition(data validatedData) (istructs.PartitionID, error) {
+	partition, err := strconv.ParseUint(data.vars[URLPlaceholder_partition], utils.DecimalBase, utils.BitSize16)
+	if err != nil {
+		return 0, fmt.Errorf("wrong partition: %w", err)
+	}
+	return istructs.PartitionID(partition), nil
+}
+
+func parseCDCStreamParams(req *http.Request, data validatedData) (params cdc.StreamParams, err error) {
+	params.App = data.appQName
+	if params.Partition, err = parseCDCPartition(data); err != nil {
+		return params, err
+	}
+	query := req.URL.Query()
+	params.Consumer = query.Get("consumer")
+	if from := query.Get("from"); len(from) > 0 {
+		offset, err := strconv.ParseUint(from, utils.DecimalBase, utils.BitSize64)
+		if err != nil {
+			return params, fmt.Errorf("wrong from: %w", err)
+		}
+		params.From = istructs.Offset(offset)
+	}
+	if follow := query.Get("follow"); len(follow) > 0 {
+		if params.Follow, err = strconv.ParseBool(follow); err != nil {
+			return params, fmt.Errorf("wrong follow: %w", err)
+		}
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		if params.Limit, err = strconv.Atoi(limit); err != nil {
+			return params, fmt.Errorf("wrong limit: %w", err)
+		}
+	}
+	return params, nil
+}
+
+func replyCDCErr(rw http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, cdc.ErrInvalidConsumer), errors.Is(err, cdc.ErrInvalidOffset):
+		ReplyCommonError(rw, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, cdc.ErrOffsetBehind):
+		ReplyCommonError(rw, err.Error(), http.StatusConflict)
+	case errors.Is(err, istructs.ErrAppNotFound):
+		ReplyCommonError(rw, err.Error(), http.StatusNotFound)
+	default:
+		ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
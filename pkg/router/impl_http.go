@@ -52,10 +52,16 @@ func (s *httpService) Prepare(work interface{}) (err error) {
 
 	s.registerRouterCheckerHandler()
 
+	s.registerJWKSHandler()
+
 	s.registerHandlersV1()
 
 	s.registerHandlersV2()
 
+	s.registerHandlersOIDC()
+
+	s.registerHandlersCDC()
+
 	s.registerDebugHandlers()
 
 	// must be the last
@@ -112,7 +118,7 @@ func (s *httpService) Stop() {
 		s.server.Close()
 	}
 	if s.n10n != nil {
-		for s.n10n.MetricNumSubcriptions() > 0 {
+		for s.n10n.MetricNumWatchedChannels() > 0 {
 			time.Sleep(subscriptionsCloseCheckInterval)
 		}
 	}
@@ -204,7 +210,7 @@ func RequestHandler_V1(requestSender bus.IRequestSender, numsAppsWorkspaces map[
 			return
 		}
 
-		initResponse(rw, responseMeta.ContentType, responseMeta.StatusCode)
+		initResponse(rw, responseMeta.ContentType, responseMeta.StatusCode, responseMeta.Headers...)
 		reply_v1(requestCtx, rw, responseCh, responseErr, responseMeta.ContentType, cancel, request, responseMeta.Mode())
 	})
 }
@@ -231,8 +237,11 @@ func checkHandler() http.HandlerFunc {
 	}
 }
 
-func initResponse(w http.ResponseWriter, contentType string, statusCode int) {
+func initResponse(w http.ResponseWriter, contentType string, statusCode int, headersKV ...string) {
 	w.Header().Set(coreutils.ContentType, contentType)
 	w.Header().Set("X-Content-Type-Options", "nosniff")
+	for i := 0; i < len(headersKV); i += 2 {
+		w.Header().Set(headersKV[i], headersKV[i+1])
+	}
 	w.WriteHeader(statusCode)
 }
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+// public keys to verify tokens by external services
+// the key set is empty if tokens are signed by the secret key only
+func (s *httpService) registerJWKSHandler() {
+	jwks, ok := s.iTokens.(itokens.IJWKS)
+	if !ok {
+		return
+	}
+	s.router.Handle(jwksPath, corsHandler(jwksHandler(jwks))).Methods("GET", "OPTIONS").Name("jwks")
+}
+
+func jwksHandler(jwks itokens.IJWKS) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		body, err := json.Marshal(jwks.JWKS())
+		if err != nil {
+			// notest
+			ReplyCommonError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		initResponse(w, coreutils.ContentType_ApplicationJSON, http.StatusOK, "Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAgeSeconds))
+		if _, err := w.Write(body); err != nil {
+			logger.Error("failed to write jwks response:", err)
+		}
+	}
+}
@@ -75,23 +75,34 @@ func (s *httpService) subscribeAndWatchHandler() http.HandlerFunc {
 			}
 		}
 		flusher.Flush()
-		serveN10NChannel(req.Context(), rw, flusher, channel, s.n10n, urlParams.SubjectLogin)
+		serveN10NChannel(req.Context(), rw, flusher, channel, s.n10n, urlParams.SubjectLogin, false, n10nOffsetData)
 	}
 }
 
 // finishes when ctx is closed or on SSE message sending failure
+// resumable: SSE messages have IDs to be used as Last-Event-ID, the channel is detached instead of terminated on finish
+// data: builds the data of SSE messages
 func serveN10NChannel(ctx context.Context, rw http.ResponseWriter, flusher http.Flusher, channel in10n.ChannelID, n10n in10n.IN10nBroker,
-	subjectLogin istructs.SubjectLogin) {
-	ch := make(chan in10nmem.UpdateUnit)
+	subjectLogin istructs.SubjectLogin, resumable bool, data n10nDataFunc) {
+	ch := make(chan n10nEvent)
 	watchChannelCtx, watchChannelCtxCancel := context.WithCancel(ctx)
 	go func() {
 		defer close(ch)
-		n10n.WatchChannel(watchChannelCtx, channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {
-			var unit = in10nmem.UpdateUnit{
-				Projection: projection,
-				Offset:     offset,
+		notify := func(eventID in10n.EventID, projection in10n.ProjectionKey, offset istructs.Offset) {
+			ch <- n10nEvent{
+				UpdateUnit: in10nmem.UpdateUnit{
+					Projection: projection,
+					Offset:     offset,
+				},
+				id: eventID,
 			}
-			ch <- unit
+		}
+		if resumable {
+			n10n.WatchChannelEx(watchChannelCtx, channel, notify)
+			return
+		}
+		n10n.WatchChannel(watchChannelCtx, channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {
+			notify(0, projection, offset)
 		})
 	}()
 	defer logger.Info("serving n10n channel", channel,"finished")
@@ -100,7 +111,10 @@ func serveN10NChannel(ctx context.Context, rw http.ResponseWriter, flusher http.
 		if !ok {
 			break
 		}
-		sseMessage := fmt.Sprintf("event: %s\ndata: %s\n\n", result.Projection.ToJSON(), utils.UintToString(result.Offset))
+		sseMessage := fmt.Sprintf("event: %s\ndata: %s\n\n", result.Projection.ToJSON(), data(result.Projection, result.Offset))
+		if resumable {
+			sseMessage = fmt.Sprintf("id: %s\n", n10nLastEventID(channel, result.id)) + sseMessage
+		}
 		if _, err := fmt.Fprint(rw, sseMessage); err != nil {
 			logger.Error("failed to write sse message for subjectLogin", subjectLogin, "to client:", sseMessage, ":", err.Error())
 			break // WatchChannel will be finished on cancel()
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/n10ncuds"
+)
+
+func n10nOffsetData(_ in10n.ProjectionKey, offset istructs.Offset) string {
+	return utils.UintToString(offset)
+}
+
+// payload: N10nPayload_*, empty -> N10nPayload_Offset
+func n10nPayloadData(ctx context.Context, n10nCUDs n10ncuds.IN10nCUDs, payload string, token string, iTime timeu.ITime) (n10nDataFunc, error) {
+	switch payload {
+	case "", N10nPayload_Offset:
+		return n10nOffsetData, nil
+	case N10nPayload_CUDs, N10nPayload_Fields:
+	default:
+		return nil, fmt.Errorf("unknown payload %q, expected one of %s, %s, %s", payload, N10nPayload_Offset, N10nPayload_CUDs, N10nPayload_Fields)
+	}
+	if n10nCUDs == nil {
+		return nil, fmt.Errorf("payload %s is not supported", payload)
+	}
+	return n10nCUDsData(ctx, n10nCUDs, token, payload == N10nPayload_Fields, iTime), nil
+}
+
+// principals of the subscriber are obtained per workspace and re-obtained each n10nPrincipalsTTL
+// CUDs of the events since the previous notification of the projection are provided
+// CUDs of the events that do not update the subscribed view are skipped
+// the first notification of the projection provides CUDs of the event at the notified offset only
+// the result must be called from one goroutine only
+func n10nCUDsData(ctx context.Context, n10nCUDs n10ncuds.IN10nCUDs, token string, withFields bool, iTime timeu.ITime) n10nDataFunc {
+	principalsByWSID := map[istructs.WSID]n10nPrincipals{}
+	lastOffsets := map[in10n.ProjectionKey]istructs.Offset{}
+	return func(projection in10n.ProjectionKey, offset istructs.Offset) string {
+		data := N10nData{Offset: offset}
+		if projection.Projection != in10n.QNameHeartbeat30 {
+			fromOffset := offset
+			if lastOffset, ok := lastOffsets[projection]; ok && lastOffset < offset {
+				fromOffset = lastOffset + 1
+			}
+			lastOffsets[projection] = offset
+			if offset-fromOffset >= maxN10nSummaryEvents {
+				fromOffset = offset - maxN10nSummaryEvents + 1
+				data.Truncated = true
+			}
+			cuds, err := func() ([]n10ncuds.CUD, error) {
+				prns, ok := principalsByWSID[projection.WS]
+				if !ok || iTime.Now().Sub(prns.obtainedAt) >= n10nPrincipalsTTL {
+					principals, err := n10nCUDs.Principals(ctx, projection.App, projection.WS, token)
+					if err != nil {
+						delete(principalsByWSID, projection.WS)
+						return nil, err
+					}
+					prns = n10nPrincipals{principals: principals, obtainedAt: iTime.Now()}
+					principalsByWSID[projection.WS] = prns
+				}
+				return n10nCUDs.Summary(ctx, projection.App, projection.WS, projection.Projection, fromOffset, offset, prns.principals, withFields)
+			}()
+			if err != nil {
+				// the subscriber is notified anyway, it could query the changes
+				logger.Error(fmt.Sprintf("failed to get CUDs of %s offsets %d..%d: %s", projection.ToJSON(), fromOffset, offset, err))
+			}
+			data.CUDs = cuds
+		}
+		dataJSON, err := json.Marshal(&data)
+		if err != nil {
+			// notest
+			logger.Error("failed to marshal n10n data:", err)
+			return utils.UintToString(offset)
+		}
+		return string(dataJSON)
+	}
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+)
+
+// GET /api/v2/apps/{owner}/{app}/notifications/websocket
+// The channel is created on connect and lives while the connection is alive, channelId message is sent first.
+// Client messages: subscribe, unsubscribe (reply is ok or error with the same id), ping, pong.
+// Server messages: channelId, update, ping (client must reply with pong or any other message), pong, ok, error.
+func requestHandlerV2_notifications_websocket(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
+	n10n in10n.IN10nBroker, appTokensFactory payloads.IAppTokensFactory, allowedOrigins []string) http.HandlerFunc {
+	return withRequestValidation(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		busRequest := createBusRequest(req.Method, data, req)
+		principalPayload, err := authorize(appTokensFactory, busRequest)
+		if err != nil {
+			ReplyCommonError(rw, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		conn := &n10nWSConn{
+			n10n:    n10n,
+			app:     busRequest.AppQName,
+			subject: istructs.SubjectLogin(principalPayload.Login),
+		}
+		server := websocket.Server{
+			// browsers do not apply CORS to websockets, so the origin is checked here, 403 otherwise
+			Handshake: func(_ *websocket.Config, req *http.Request) error {
+				if !isWSOriginAllowed(req, allowedOrigins) {
+					return fmt.Errorf("origin %s is not allowed", req.Header.Get("Origin"))
+				}
+				return nil
+			},
+			Handler: func(ws *websocket.Conn) {
+				conn.serve(req.Context(), ws)
+			},
+		}
+		server.ServeHTTP(rw, req)
+	})
+}
+
+type n10nWSConn struct {
+	n10n    in10n.IN10nBroker
+	app     appdef.AppQName
+	subject istructs.SubjectLogin
+	channel in10n.ChannelID
+	out     chan WSServerMessage
+}
+
+// finishes when ctx is closed, the channel is expired or the connection is lost
+func (c *n10nWSConn) serve(ctx context.Context, ws *websocket.Conn) {
+	// hijacked connection could have deadlines set by the http server
+	if err := ws.SetDeadline(time.Time{}); err != nil {
+		// notest
+		logger.Error("failed to reset websocket deadlines:", err)
+		return
+	}
+	defer ws.Close()
+
+	channel, err := c.n10n.NewChannel(c.subject, hours24)
+	if err != nil {
+		c.send(ws, WSServerMessage{Type: WSMessage_Error, Status: n10nErrorToStatusCode(err), Message: "create new channel failed: " + err.Error()})
+		return
+	}
+	c.channel = channel
+	c.out = make(chan WSServerMessage)
+	if !c.send(ws, WSServerMessage{Type: WSMessage_ChannelID, ChannelID: channel}) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer cancel() // channel is expired
+		c.n10n.WatchChannel(ctx, channel, func(projection in10n.ProjectionKey, offset istructs.Offset) {
+			c.reply(ctx, WSServerMessage{
+				Type:   WSMessage_Update,
+				Entity: projection.Projection.String(),
+				WSID:   projection.WS,
+				Offset: offset,
+			})
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		defer cancel() // connection is lost or closed by the client
+		c.receive(ctx, ws)
+	}()
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		select {
+		case msg := <-c.out:
+			if !c.send(ws, msg) {
+				cancel()
+			}
+		case <-ticker.C:
+			if !c.send(ws, WSServerMessage{Type: WSMessage_Ping}) {
+				cancel()
+			}
+		case <-ctx.Done():
+		}
+	}
+	ws.Close() // unblocks receive()
+	wg.Wait()
+	logger.Info("serving n10n websocket channel", channel, "finished")
+}
+
+func (c *n10nWSConn) receive(ctx context.Context, ws *websocket.Conn) {
+	for ctx.Err() == nil {
+		if err := ws.SetReadDeadline(time.Now().Add(wsPingInterval + wsPongTimeout)); err != nil {
+			// notest
+			return
+		}
+		var data []byte
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			if ctx.Err() == nil {
+				logger.Verbose(fmt.Sprintf("n10n websocket channel %s of subjectLogin %s: receive failed: %s", c.channel, c.subject, err))
+			}
+			return
+		}
+		msg := WSClientMessage{}
+		if err := coreutils.JSONUnmarshalDisallowUnknownFields(data, &msg); err != nil {
+			c.reply(ctx, WSServerMessage{Type: WSMessage_Error, Status: http.StatusBadRequest, Message: "failed to unmarshal message: " + err.Error()})
+			continue
+		}
+		c.handle(ctx, msg)
+	}
+}
+
+func (c *n10nWSConn) handle(ctx context.Context, msg WSClientMessage) {
+	switch msg.Type {
+	case WSMessage_Ping:
+		c.reply(ctx, WSServerMessage{Type: WSMessage_Pong, ID: msg.ID})
+		return
+	case WSMessage_Pong:
+		// read deadline is prolonged already
+		return
+	case WSMessage_Subscribe, WSMessage_Unsubscribe:
+	default:
+		c.reply(ctx, WSServerMessage{Type: WSMessage_Error, ID: msg.ID, Status: http.StatusBadRequest, Message: "unknown message type: " + msg.Type})
+		return
+	}
+
+	subscriptions, err := parseSubscriptions(msg.Subscriptions)
+	if err != nil {
+		c.reply(ctx, WSServerMessage{Type: WSMessage_Error, ID: msg.ID, Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	subscribedProjectionKeys := []in10n.ProjectionKey{}
+	for i, sub := range subscriptions {
+		if sub.entity == in10n.QNameHeartbeat30 {
+			c.reply(ctx, WSServerMessage{Type: WSMessage_Error, ID: msg.ID, Status: http.StatusBadRequest,
+				Message: fmt.Sprintf("subscriptions[%d]: %s is not available over websocket, ping messages are sent instead", i, in10n.QNameHeartbeat30)})
+			return
+		}
+		projectionKey := in10n.ProjectionKey{
+			App:        c.app,
+			Projection: sub.entity,
+			WS:         sub.wsid,
+		}
+		if msg.Type == WSMessage_Unsubscribe {
+			err = c.n10n.Unsubscribe(c.channel, projectionKey)
+		} else if err = c.n10n.Subscribe(c.channel, projectionKey); err == nil {
+			subscribedProjectionKeys = append(subscribedProjectionKeys, projectionKey)
+		}
+		if err != nil {
+			for _, subscribedKey := range subscribedProjectionKeys {
+				if err := c.n10n.Unsubscribe(c.channel, subscribedKey); err != nil {
+					logger.Error(fmt.Sprintf("failed to unsubscribe key %#v: %s", subscribedKey, err))
+				}
+			}
+			c.reply(ctx, WSServerMessage{Type: WSMessage_Error, ID: msg.ID, Status: n10nErrorToStatusCode(err),
+				Message: fmt.Sprintf("subscriptions[%d]: %s failed: %s", i, msg.Type, err)})
+			return
+		}
+	}
+	c.reply(ctx, WSServerMessage{Type: WSMessage_OK, ID: msg.ID})
+}
+
+// passes the message to the writing routine
+func (c *n10nWSConn) reply(ctx context.Context, msg WSServerMessage) {
+	select {
+	case c.out <- msg:
+	case <-ctx.Done():
+	}
+}
+
+// must be called from the writing routine only
+func (c *n10nWSConn) send(ws *websocket.Conn, msg WSServerMessage) bool {
+	if err := ws.SetWriteDeadline(time.Now().Add(wsPongTimeout)); err != nil {
+		// notest
+		return false
+	}
+	if err := websocket.JSON.Send(ws, msg); err != nil {
+		logger.Error(fmt.Sprintf("failed to send %s websocket message for subjectLogin %s: %s", msg.Type, c.subject, err))
+		return false
+	}
+	if logger.IsVerbose() {
+		logger.Verbose(fmt.Sprintf("websocket message sent for subjectLogin %s: %#v", c.subject, msg))
+	}
+	return true
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/oidc"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+func (s *httpService) registerHandlersOIDC() {
+	if s.oidc == nil {
+		return
+	}
+
+	// redirect to the identity provider: /api/v2/apps/{owner}/{app}/auth/oidc/{provider}
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/auth/oidc/{%s}",
+		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_provider),
+		corsHandler(requestHandlerV2_oidc_login(s.numsAppsWorkspaces, s.oidc, s.iTokens))).
+		Methods(http.MethodGet).Name("auth oidc login")
+
+	// redirect back from the identity provider: /api/v2/apps/{owner}/{app}/auth/oidc/{provider}/callback
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/auth/oidc/{%s}/callback",
+		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_provider),
+		corsHandler(requestHandlerV2_oidc_callback(s.numsAppsWorkspaces, s.oidc, s.iTokens, s.federation))).
+		Methods(http.MethodGet).Name("auth oidc callback")
+}
+
+// authorization code flow with PKCE is started, the state is kept in the signed HttpOnly cookie
+// the second factor, if enrolled, is provided here by totpCode or emailCodeToken query params since the callback is made by the identity provider
+func requestHandlerV2_oidc_login(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, relyingParty oidc.IRelyingParty,
+	iTokens itokens.ITokens) http.HandlerFunc {
+	return withRequestValidation(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		provider := data.vars[URLPlaceholder_provider]
+		authURL, state, err := relyingParty.AuthCodeURL(req.Context(), provider, oidcRedirectURI(req))
+		if err != nil {
+			replyOIDCError(rw, err)
+			return
+		}
+		statePayload := oidcStatePayload{
+			AuthState:      state,
+			App:            data.appQName,
+			TOTPCode:       req.URL.Query().Get(oidcParam_TOTPCode),
+			EmailCodeToken: req.URL.Query().Get(oidcParam_EmailCodeToken),
+		}
+		stateToken, err := iTokens.IssueToken(data.appQName, oidcStateTTL, &statePayload)
+		if err != nil {
+			// notest
+			ReplyCommonError(rw, "failed to issue OIDC state token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.SetCookie(rw, oidcStateCookieFor(req, stateToken, int(oidcStateTTL.Seconds())))
+		http.Redirect(rw, req, authURL, http.StatusFound)
+	})
+}
+
+// the external subject is mapped to the registry login which is created on the first login
+// replies the same as auth/login
+func requestHandlerV2_oidc_callback(numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, relyingParty oidc.IRelyingParty,
+	iTokens itokens.ITokens, federation federation.IFederation) http.HandlerFunc {
+	return withRequestValidation(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		provider := data.vars[URLPlaceholder_provider]
+		query := req.URL.Query()
+		if idpErr := query.Get("error"); len(idpErr) > 0 {
+			ReplyCommonError(rw, fmt.Sprintf("identity provider error: %s %s", idpErr, query.Get("error_description")), http.StatusUnauthorized)
+			return
+		}
+
+		// the state cookie could be used once only
+		http.SetCookie(rw, oidcStateCookieFor(req, "", -1))
+		cookie, err := req.Cookie(oidcStateCookie)
+		if err != nil {
+			ReplyCommonError(rw, "OIDC state cookie is missing, start the login again", http.StatusUnauthorized)
+			return
+		}
+		statePayload := oidcStatePayload{}
+		if _, err := iTokens.ValidateToken(cookie.Value, &statePayload); err != nil {
+			ReplyCommonError(rw, "OIDC state cookie is invalid: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if statePayload.App != data.appQName || statePayload.Provider != provider || statePayload.State != query.Get("state") {
+			ReplyCommonError(rw, "OIDC state mismatch", http.StatusUnauthorized)
+			return
+		}
+
+		identity, err := relyingParty.Exchange(req.Context(), statePayload.AuthState, query.Get("code"))
+		if err != nil {
+			replyOIDCError(rw, err)
+			return
+		}
+
+		device, remoteAddr := sessionMeta(req)
+		result, err := oidcLogin(req.Context(), iTokens, federation, data.appQName, identity, device, remoteAddr, statePayload)
+		if err != nil {
+			replyErr(rw, err)
+			return
+		}
+		resultJSON, err := json.Marshal(&result)
+		if err != nil {
+			// notest
+			ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		ReplyJSON(rw, string(resultJSON), http.StatusOK)
+	})
+}
+
+// creates the login if it does not exist and issues the principal token when the profile workspace is ready
+func oidcLogin(ctx context.Context, iTokens itokens.ITokens, federation federation.IFederation, app appdef.AppQName,
+	identity oidc.Identity, device, remoteAddr string, state oidcStatePayload) (result oidcLoginResult, err error) {
+	sysToken, err := payloads.GetSystemPrincipalToken(iTokens, istructs.AppQName_sys_registry)
+	if err != nil {
+		// notest
+		return result, err
+	}
+	login := oidc.Login(identity.Provider, identity.Subject)
+	pseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.CurrentClusterID())
+	displayName := identity.Name
+	if len(displayName) == 0 {
+		displayName = identity.Email
+	}
+	wsKindInitData := fmt.Sprintf(`{"DisplayName":%q}`, displayName)
+	body := fmt.Sprintf(`{"args":{"Login":%q,"AppName":"%s","WSKindInitializationData":%q,"ProfileCluster":%d}}`,
+		login, app, wsKindInitData, istructs.CurrentClusterID())
+	if _, err = federation.Func(fmt.Sprintf("api/v2/apps/%s/%s/workspaces/%d/commands/registry.CreateOIDCLogin",
+		istructs.SysOwner, istructs.AppQName_sys_registry.Name(), pseudoWSID), body,
+		coreutils.WithAuthorizeBy(sysToken),
+		coreutils.WithMethod(http.MethodPost),
+	); err != nil {
+		return result, err
+	}
+
+	argsJSON, err := json.Marshal(map[string]any{
+		authnz.Field_Login:      login,
+		authnz.Field_AppName:    app.String(),
+		authnz.Field_Device:     device,
+		authnz.Field_RemoteAddr: remoteAddr,
+		field_TOTPCode:          state.TOTPCode,
+		field_EmailCodeToken:    state.EmailCodeToken,
+	})
+	if err != nil {
+		// notest
+		return result, err
+	}
+	args := url.QueryEscape(string(argsJSON))
+	deadline := time.Now().Add(oidcProfileAwaitTimeout)
+	for {
+		resp, err := federation.Query(fmt.Sprintf("api/v2/apps/%s/%s/workspaces/%d/queries/registry.IssueOIDCPrincipalToken?args=%s",
+			istructs.SysOwner, istructs.AppQName_sys_registry.Name(), pseudoWSID, args),
+			coreutils.WithAuthorizeBy(sysToken),
+		)
+		if err != nil {
+			return result, err
+		}
+		if resp.IsEmpty() {
+			// notest
+			return result, errors.New("registry.IssueOIDCPrincipalToken response is empty")
+		}
+		res := resp.QPv2Response.Result()
+		if wsError := res[authnz.Field_WSError].(string); len(wsError) > 0 {
+			return result, errors.New("the login profile is created with an error: " + wsError)
+		}
+		if wsid := istructs.WSID(res[authnz.Field_WSID].(float64)); wsid > 0 {
+			return oidcLoginResult{
+				PrincipalToken:   res["PrincipalToken"].(string),
+				ExpiresInSeconds: int(authnz.DefaultPrincipalTokenExpiration.Seconds()),
+				ProfileWSID:      wsid,
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return result, coreutils.NewHTTPErrorf(http.StatusConflict, "profile workspace is not yet ready, try again later")
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(oidcProfileAwaitInterval):
+		}
+	}
+}
+
+// the callback URL on the same host the login is started on
+func oidcRedirectURI(req *http.Request) string {
+	scheme := "http"
+	if isHTTPS(req) {
+		scheme = "https"
+	}
+	vars := mux.Vars(req)
+	return fmt.Sprintf("%s://%s/api/v2/apps/%s/%s/auth/oidc/%s/callback", scheme, req.Host,
+		vars[URLPlaceholder_appOwner], vars[URLPlaceholder_appName], vars[URLPlaceholder_provider])
+}
+
+// SameSite=Lax: the cookie is sent on the top-level redirect from the identity provider
+func oidcStateCookieFor(req *http.Request, value string, maxAge int) *http.Cookie {
+	vars := mux.Vars(req)
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     fmt.Sprintf("/api/v2/apps/%s/%s/auth/oidc/%s", vars[URLPlaceholder_appOwner], vars[URLPlaceholder_appName], vars[URLPlaceholder_provider]),
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   isHTTPS(req),
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// the router could be behind the TLS terminating proxy
+func isHTTPS(req *http.Request) bool {
+	return req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https"
+}
+
+func replyOIDCError(rw http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, oidc.ErrUnknownProvider):
+		ReplyCommonError(rw, err.Error(), http.StatusNotFound)
+	case errors.Is(err, oidc.ErrInvalidIDToken):
+		ReplyCommonError(rw, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, oidc.ErrIdP):
+		ReplyCommonError(rw, err.Error(), http.StatusBadGateway)
+	default:
+		ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
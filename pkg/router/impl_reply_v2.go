@@ -86,6 +86,7 @@ func createBusRequest(reqMethod string, data validatedData, req *http.Request) b
 		AppQName: data.appQName,
 		Resource: data.vars[URLPlaceholder_resourceName],
 		Body:     data.body,
+		Host:     remoteHost(req), // per IP limits are applied by the client address
 	}
 
 	if docIDStr, hasDocID := data.vars[URLPlaceholder_id]; hasDocID {
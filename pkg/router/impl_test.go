@@ -8,6 +8,7 @@ package router
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -25,7 +26,10 @@ import (
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/n10ncuds"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
 
@@ -420,7 +424,7 @@ func startRouter(t *testing.T, router *testRouter, rp RouterParams, sendTimeout
 	ctx, cancel := context.WithCancel(context.Background())
 	requestSender := bus.NewIRequestSender(testingu.MockTime, sendTimeout, requestHandler)
 	httpSrv, acmeSrv, adminService := Provide(rp, nil, nil, nil, requestSender,
-		map[appdef.AppQName]istructs.NumAppWorkspaces{istructs.AppQName_test1_app1: 10}, nil, nil, nil)
+		map[appdef.AppQName]istructs.NumAppWorkspaces{istructs.AppQName_test1_app1: 10}, nil, nil, nil, nil, nil, nil, testingu.MockTime)
 	require.Nil(t, acmeSrv)
 	require.NoError(t, httpSrv.Prepare(nil))
 	require.NoError(t, adminService.Prepare(nil))
@@ -486,6 +490,99 @@ func (t testRouter) expectClientDisconnection(tst *testing.T) {
 	}
 }
 
+func TestWSOrigin(t *testing.T) {
+	allowed := []string{"https://app.example.com"}
+	cases := []struct {
+		origin         string
+		allowedOrigins []string
+		expected       bool
+	}{
+		{"", nil, true},
+		{"http://router.example.com", nil, true},
+		{"https://app.example.com", nil, false},
+		{"https://app.example.com", allowed, true},
+		{"HTTPS://APP.EXAMPLE.COM", allowed, true},
+		{"https://other.example.com", allowed, false},
+		{"https://other.example.com", []string{"*"}, true},
+	}
+	for _, c := range cases {
+		req, err := http.NewRequest(http.MethodGet, "http://router.example.com/api/v2/apps/test1/app1/notifications/websocket", http.NoBody)
+		require.NoError(t, err)
+		if len(c.origin) > 0 {
+			req.Header.Set("Origin", c.origin)
+		}
+		require.Equal(t, c.expected, isWSOriginAllowed(req, c.allowedOrigins), "%s %v", c.origin, c.allowedOrigins)
+	}
+}
+
+func TestN10nCUDsData(t *testing.T) {
+	require := require.New(t)
+	n10nCUDs := &testN10nCUDs{}
+	mockTime := testingu.NewMockTime()
+	data := n10nCUDsData(context.Background(), n10nCUDs, "token", false, mockTime)
+	projection := in10n.ProjectionKey{App: istructs.AppQName_test1_app1, Projection: appdef.NewQName("app1pkg", "view"), WS: testWSID}
+
+	notify := func(offset istructs.Offset) N10nData {
+		res := N10nData{}
+		require.NoError(json.Unmarshal([]byte(data(projection, offset)), &res))
+		return res
+	}
+
+	t.Run("the first notification provides the event at the offset", func(t *testing.T) {
+		notify(5)
+		require.Equal([][2]istructs.Offset{{5, 5}}, n10nCUDs.ranges)
+		require.Equal(projection.Projection, n10nCUDs.view)
+	})
+
+	t.Run("events since the previous notification", func(t *testing.T) {
+		res := notify(8)
+		require.Equal([][2]istructs.Offset{{5, 5}, {6, 8}}, n10nCUDs.ranges)
+		require.Len(res.CUDs, 3)
+		require.False(res.Truncated)
+	})
+
+	t.Run("too many events", func(t *testing.T) {
+		res := notify(8 + maxN10nSummaryEvents + 1)
+		require.Equal([2]istructs.Offset{10, 8 + maxN10nSummaryEvents + 1}, n10nCUDs.ranges[2])
+		require.True(res.Truncated)
+	})
+
+	require.Equal(1, n10nCUDs.principalsCalls)
+
+	t.Run("principals are re-obtained", func(t *testing.T) {
+		mockTime.Add(n10nPrincipalsTTL)
+		n10nCUDs.principalsErr = errors.New("token expired")
+		res := notify(200)
+		require.Empty(res.CUDs)
+		n10nCUDs.principalsErr = nil
+		notify(201)
+		require.Equal(3, n10nCUDs.principalsCalls)
+	})
+}
+
+// one CUD per event
+type testN10nCUDs struct {
+	ranges          [][2]istructs.Offset
+	view            appdef.QName
+	principalsCalls int
+	principalsErr   error
+}
+
+func (c *testN10nCUDs) Principals(context.Context, appdef.AppQName, istructs.WSID, string) ([]iauthnz.Principal, error) {
+	c.principalsCalls++
+	return nil, c.principalsErr
+}
+
+func (c *testN10nCUDs) Summary(_ context.Context, _ appdef.AppQName, _ istructs.WSID, view appdef.QName, fromOffset, toOffset istructs.Offset,
+	_ []iauthnz.Principal, _ bool) (res []n10ncuds.CUD, err error) {
+	c.view = view
+	c.ranges = append(c.ranges, [2]istructs.Offset{fromOffset, toOffset})
+	for offset := fromOffset; offset <= toOffset; offset++ {
+		res = append(res, n10ncuds.CUD{ID: istructs.RecordID(offset)})
+	}
+	return res, nil
+}
+
 func expectJSONResp(t *testing.T, expectedJSON string, expectedString string, resp *http.Response) {
 	b, err := io.ReadAll(resp.Body)
 	require.NoError(t, err)
@@ -11,10 +11,14 @@ import (
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cdc"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/n10ncuds"
+	"github.com/voedger/voedger/pkg/oidc"
 	blobprocessor "github.com/voedger/voedger/pkg/processors/blobber"
 	"golang.org/x/crypto/acme/autocert"
 
@@ -26,9 +30,10 @@ import (
 // where is VVM RequestHandler? bus.RequestHandler
 func Provide(rp RouterParams, broker in10n.IN10nBroker, blobRequestHandler blobprocessor.IRequestHandler, autocertCache autocert.Cache,
 	requestSender bus.IRequestSender, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens,
-	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory) (httpSrv IHTTPService, acmeSrv IACMEService, adminSrv IAdminService) {
+	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory, feed cdc.ICDC,
+	n10nCUDs n10ncuds.IN10nCUDs, relyingParty oidc.IRelyingParty, iTime timeu.ITime) (httpSrv IHTTPService, acmeSrv IACMEService, adminSrv IAdminService) {
 	httpServ := getHTTPService("HTTP server", coreutils.ServerAddress(rp.Port), rp, broker, blobRequestHandler,
-		requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory)
+		requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory, feed, n10nCUDs, relyingParty, iTime)
 
 	if coreutils.IsTest() {
 		adminEndpoint = "127.0.0.1:0"
@@ -37,7 +42,7 @@ func Provide(rp RouterParams, broker in10n.IN10nBroker, blobRequestHandler blobp
 		WriteTimeout:     rp.WriteTimeout,
 		ReadTimeout:      rp.ReadTimeout,
 		ConnectionsLimit: rp.ConnectionsLimit,
-	}, broker, nil, requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory)
+	}, broker, nil, requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory, feed, n10nCUDs, relyingParty, iTime)
 
 	if rp.Port != HTTPSPort {
 		return httpServ, nil, adminSrv
@@ -82,7 +87,8 @@ func Provide(rp RouterParams, broker in10n.IN10nBroker, blobRequestHandler blobp
 func getHTTPService(name string, listenAddress string, rp RouterParams, broker in10n.IN10nBroker,
 	blobRequestHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens,
-	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory) *httpService {
+	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory, feed cdc.ICDC,
+	n10nCUDs n10ncuds.IN10nCUDs, relyingParty oidc.IRelyingParty, iTime timeu.ITime) *httpService {
 	httpServ := &httpService{
 		RouterParams:       rp,
 		n10n:               broker,
@@ -94,6 +100,10 @@ func getHTTPService(name string, listenAddress string, rp RouterParams, broker i
 		iTokens:            iTokens,
 		federation:         federation,
 		appTokensFactory:   appTokensFactory,
+		cdc:                feed,
+		n10nCUDs:           n10nCUDs,
+		oidc:               relyingParty,
+		time:               iTime,
 	}
 
 	return httpServ
@@ -12,17 +12,24 @@ import (
 	"net/url"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/mux"
 	"golang.org/x/crypto/acme/autocert"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cdc"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10nmem"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/n10ncuds"
+	"github.com/voedger/voedger/pkg/oidc"
 	blobprocessor "github.com/voedger/voedger/pkg/processors/blobber"
 )
 
@@ -37,6 +44,10 @@ type RouterParams struct {
 	Routes               map[string]string // /grafana=http://10.0.0.3:3000 : https://alpha.dev.untill.ru/grafana/foo -> http://10.0.0.3:3000/grafana/foo
 	RoutesRewrite        map[string]string // /grafana-rewrite=http://10.0.0.3:3000/rewritten : https://alpha.dev.untill.ru/grafana-rewrite/foo -> http://10.0.0.3:3000/rewritten/foo
 	RouteDomains         map[string]string // resellerportal.dev.untill.ru=http://resellerportal : https://resellerportal.dev.untill.ru/foo -> http://resellerportal/foo
+
+	// websocket connections are accepted from the same origin, from these origins (e.g. https://app.example.com) and from non-browser clients which send no Origin
+	// "*" -> any origin
+	WSAllowedOrigins []string
 }
 
 type httpService struct {
@@ -55,6 +66,10 @@ type httpService struct {
 	iTokens            itokens.ITokens
 	federation         federation.IFederation
 	appTokensFactory   payloads.IAppTokensFactory
+	cdc                cdc.ICDC
+	n10nCUDs           n10ncuds.IN10nCUDs
+	oidc               oidc.IRelyingParty
+	time               timeu.ITime
 }
 
 type httpsService struct {
@@ -90,4 +105,64 @@ type subscription struct {
 type N10nArgs struct {
 	Subscriptions    []SubscriptionJSON `json:"subscriptions"`
 	ExpiresInSeconds int64              `json:"expiresIn"`
+
+	// N10nPayload_*, empty -> N10nPayload_Offset
+	Payload string `json:"payload"`
+}
+
+// data of the notification message if the payload is not N10nPayload_Offset
+// CUDs are changes made since the previous notification of the projection
+// CUDs are omitted if there are no changes the subscriber is allowed to read or the summary could not be built
+// Truncated: the changes are too many, CUDs cover the last ones only
+type N10nData struct {
+	Offset    istructs.Offset `json:"offset"`
+	CUDs      []n10ncuds.CUD  `json:"cuds,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// principals of the notifications subscriber in the workspace
+type n10nPrincipals struct {
+	principals []iauthnz.Principal
+	obtainedAt time.Time
+}
+
+// builds the data of the notification message
+type n10nDataFunc func(projection in10n.ProjectionKey, offset istructs.Offset) string
+
+type n10nEvent struct {
+	in10nmem.UpdateUnit
+	id in10n.EventID
+}
+
+// message sent by the client over the notifications WebSocket
+type WSClientMessage struct {
+	Type          string             `json:"type"`
+	ID            string             `json:"id,omitempty"`
+	Subscriptions []SubscriptionJSON `json:"subscriptions,omitempty"`
+}
+
+// message sent by the server over the notifications WebSocket
+type WSServerMessage struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id,omitempty"`
+	ChannelID in10n.ChannelID `json:"channelId,omitempty"`
+	Entity    string          `json:"entity,omitempty"`
+	WSID      istructs.WSID   `json:"wsid,omitempty"`
+	Offset    istructs.Offset `json:"offset,omitempty"`
+	Status    int             `json:"status,omitempty"`
+	Message   string          `json:"message,omitempty"`
+}
+
+// kept in the signed cookie between the redirect to the identity provider and the callback
+type oidcStatePayload struct {
+	oidc.AuthState
+	App            appdef.AppQName
+	TOTPCode       string `json:",omitempty"`
+	EmailCodeToken string `json:",omitempty"`
+}
+
+type oidcLoginResult struct {
+	PrincipalToken   string        `json:"principalToken"`
+	ExpiresInSeconds int           `json:"expiresInSeconds"`
+	ProfileWSID      istructs.WSID `json:"profileWSID"`
 }
@@ -10,12 +10,16 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"runtime/debug"
 	"strconv"
 	"strings"
 
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/in10n"
 )
 
 var onBeforeWriteResponse func(w http.ResponseWriter) // not nil in tests only
@@ -79,3 +83,53 @@ func replyErr(rw http.ResponseWriter, err error) {
 		ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+func n10nLastEventID(channel in10n.ChannelID, eventID in10n.EventID) string {
+	return string(channel) + ":" + utils.UintToString(eventID)
+}
+
+func parseN10nLastEventID(lastEventID string) (channel in10n.ChannelID, eventID in10n.EventID, err error) {
+	channelStr, eventIDStr, ok := strings.Cut(lastEventID, ":")
+	if !ok || len(channelStr) == 0 {
+		return "", 0, fmt.Errorf("malformed %s %q: {channelID}:{eventID} expected", coreutils.LastEventID, lastEventID)
+	}
+	id, err := strconv.ParseUint(eventIDStr, utils.DecimalBase, utils.BitSize64)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed %s %q: %w", coreutils.LastEventID, lastEventID, err)
+	}
+	return in10n.ChannelID(channelStr), in10n.EventID(id), nil
+}
+
+// metadata of the session created on login, see isessions.Session
+func sessionMeta(req *http.Request) (device, remoteAddr string) {
+	device = req.UserAgent()
+	if len(device) > maxSessionDeviceLen {
+		device = strings.ToValidUTF8(device[:maxSessionDeviceLen], "")
+	}
+	return device, remoteHost(req)
+}
+
+// address of the client without the port
+// no Origin -> not a browser, e.g. a server-side client
+func isWSOriginAllowed(req *http.Request, allowedOrigins []string) bool {
+	origin := req.Header.Get("Origin")
+	if len(origin) == 0 {
+		return true
+	}
+	if originURL, err := url.Parse(origin); err == nil && strings.EqualFold(originURL.Host, req.Host) {
+		return true
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteHost(req *http.Request) string {
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
+	return req.RemoteAddr
+}
@@ -49,7 +49,7 @@ func implProvideAsyncActualizerState(ctx context.Context, appStructsFunc state.A
 	}
 
 	state.addStorage(sys.Storage_View, storages.NewViewRecordsStorage(ctx, appStructsFunc, wsidFunc, n10nFunc), S_GET|S_GET_BATCH|S_READ|S_INSERT|S_UPDATE)
-	state.addStorage(sys.Storage_Record, storages.NewRecordsStorage(appStructsFunc, wsidFunc, nil), S_GET|S_GET_BATCH)
+	state.addStorage(sys.Storage_Record, storages.NewRecordsStorage(ctx, appStructsFunc, wsidFunc, nil), S_GET|S_GET_BATCH|S_READ)
 	state.addStorage(sys.Storage_Event, storages.NewEventStorage(eventFunc), S_GET)
 	state.addStorage(sys.Storage_WLog, storages.NewWLogStorage(ctx, ieventsFunc, wsidFunc), S_GET|S_READ)
 	state.addStorage(sys.Storage_SendMail, storages.NewSendMailStorage(opts.MessagesSenderOverride), S_INSERT)
@@ -28,9 +28,14 @@ const (
 	Field_AppName                   = "AppName"
 	Field_Email                     = "Email" // c.registry.CreateEmailLogin.Email
 	DefaultPrincipalTokenExpiration = time.Hour
+	Field_Device                    = "Device"
+	Field_RemoteAddr                = "RemoteAddr"
+	field_SessionID                 = "SessionID"
 )
 
 var (
+	qNameQuerySessions                    = appdef.NewQName(appdef.SysPackage, "Sessions")
+	qNameCommandRevokeSession             = appdef.NewQName(appdef.SysPackage, "RevokeSession")
 	QNameCDoc_WorkspaceKind_UserProfile   = appdef.NewQName(appdef.SysPackage, "UserProfile")
 	QNameCDoc_WorkspaceKind_DeviceProfile = appdef.NewQName(appdef.SysPackage, "DeviceProfile")
 	QNameCDoc_WorkspaceKind_AppWorkspace  = appdef.NewQName(appdef.SysPackage, "AppWorkspace")
@@ -6,7 +6,12 @@ package authnz
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/isessions"
 
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
@@ -15,7 +20,7 @@ import (
 	"github.com/voedger/voedger/pkg/sys/storages"
 )
 
-func provideRefreshPrincipalTokenExec(itokens itokens.ITokens) istructsmem.ExecQueryClosure {
+func provideRefreshPrincipalTokenExec(itokens itokens.ITokens, sessions isessions.ISessions) istructsmem.ExecQueryClosure {
 	return func(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
 		existingPrincipalToken, err := storages.GetPrincipalTokenFromState(args.State)
 		if err != nil {
@@ -33,6 +38,18 @@ func provideRefreshPrincipalTokenExec(itokens itokens.ITokens) istructsmem.ExecQ
 			return fmt.Errorf("token issue failed: %w", err)
 		}
 
+		// the session lasts as long as its latest token
+		// session not found -> the token outlived the session record, e.g. enriched one, then it is still checked for revocation
+		if len(principalPayload.SessionID) > 0 {
+			err = sessions.Prolong(gp.AppQName, principalPayload.Login, principalPayload.SessionID, gp.Duration)
+			if errors.Is(err, isessions.ErrSessionRevoked) {
+				return coreutils.NewHTTPError(http.StatusUnauthorized, err)
+			}
+			if err != nil && !errors.Is(err, isessions.ErrSessionNotFound) {
+				return err
+			}
+		}
+
 		issuePrincipalTokenRR := &issuePrincipalTokenRR{
 			principalToken: newPrincipalToken,
 		}
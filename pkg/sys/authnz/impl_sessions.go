@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package authnz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/isessions"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/sys/storages"
+)
+
+// targetApp/profileWS/q.sys.Sessions
+// sessions of the current login, one row per session
+func provideQrySessionsExec(atf payloads.IAppTokensFactory, sessions isessions.ISessions) istructsmem.ExecQueryClosure {
+	return func(ctx context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		principalPayload, err := currentPrincipalPayload(args.State, atf)
+		if err != nil {
+			return err
+		}
+		list, err := sessions.List(ctx, args.State.App(), principalPayload.Login)
+		if err != nil {
+			return err
+		}
+		for _, session := range list {
+			if err := callback(&sessionRR{session: session, isCurrent: session.ID == principalPayload.SessionID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// targetApp/profileWS/c.sys.RevokeSession
+// the current session could be revoked as well, i.e. sign out
+func provideCmdRevokeSessionExec(atf payloads.IAppTokensFactory, sessions isessions.ISessions) istructsmem.ExecCommandClosure {
+	return func(args istructs.ExecCommandArgs) error {
+		principalPayload, err := currentPrincipalPayload(args.State, atf)
+		if err != nil {
+			return err
+		}
+		err = sessions.Revoke(args.State.App(), principalPayload.Login, args.ArgumentObject.AsString(field_SessionID))
+		if errors.Is(err, isessions.ErrSessionNotFound) {
+			return coreutils.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+}
+
+func currentPrincipalPayload(st istructs.IState, atf payloads.IAppTokensFactory) (principalPayload payloads.PrincipalPayload, err error) {
+	principalToken, err := storages.GetPrincipalTokenFromState(st)
+	if err != nil {
+		return principalPayload, err
+	}
+	return payloads.GetPrincipalPayload(atf.New(st.App()), principalToken)
+}
+
+type sessionRR struct {
+	istructs.NullObject
+	session   isessions.Session
+	isCurrent bool
+}
+
+func (s *sessionRR) AsString(name string) string {
+	switch name {
+	case field_SessionID:
+		return s.session.ID
+	case Field_Device:
+		return s.session.Device
+	default:
+		return s.session.RemoteAddr
+	}
+}
+
+func (s *sessionRR) AsInt64(name string) int64 {
+	if name == Field_CreatedAtMs {
+		return s.session.CreatedAt.UnixMilli()
+	}
+	return s.session.ExpiresAt.UnixMilli()
+}
+
+func (s *sessionRR) AsBool(string) bool {
+	return s.isCurrent
+}
@@ -6,21 +6,32 @@ package authnz
 
 import (
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/isessions"
 	istructsmem "github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 )
 
-func Provide(sr istructsmem.IStatelessResources, itokens itokens.ITokens, atf payloads.IAppTokensFactory) {
+func Provide(sr istructsmem.IStatelessResources, itokens itokens.ITokens, atf payloads.IAppTokensFactory, sessions isessions.ISessions) {
 	sr.AddQueries(appdef.SysPackagePath,
 		istructsmem.NewQueryFunction(
 			appdef.NewQName(appdef.SysPackage, "RefreshPrincipalToken"),
-			provideRefreshPrincipalTokenExec(itokens),
+			provideRefreshPrincipalTokenExec(itokens, sessions),
 		),
 		istructsmem.NewQueryFunction(
 			appdef.NewQName(appdef.SysPackage, "EnrichPrincipalToken"),
 			provideExecQryEnrichPrincipalToken(atf),
 		),
+		istructsmem.NewQueryFunction(
+			qNameQuerySessions,
+			provideQrySessionsExec(atf, sessions),
+		),
+	)
+	sr.AddCommands(appdef.SysPackagePath,
+		istructsmem.NewCommandFunction(
+			qNameCommandRevokeSession,
+			provideCmdRevokeSessionExec(atf, sessions),
+		),
 	)
 
 }
@@ -15,9 +15,17 @@ var (
 	QNameCommandInit = appdef.NewQName(appdef.SysPackage, "Init")
 )
 
+var QNameQueryRateLimits = appdef.NewQName(appdef.SysPackage, "RateLimits")
+
 const (
 	field_ExistingQName = "ExistingQName"
 	field_NewQName      = "NewQName"
+	field_RateLimit     = "RateLimit"
+	field_Function      = "Function"
+	field_Period        = "Period"
+	field_MaxTokens     = "MaxTokens"
+	field_Remaining     = "Remaining"
+	field_ResetAfter    = "ResetAfter"
 	MaxCUDs             = 100
 )
 
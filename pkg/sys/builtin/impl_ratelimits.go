@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package builtin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+func (r *rateLimitsRR) AsQName(name string) appdef.QName {
+	switch name {
+	case field_RateLimit:
+		return r.key.RateLimitName
+	case field_Function:
+		return r.key.QName
+	}
+	return r.NullObject.AsQName(name)
+}
+
+func (r *rateLimitsRR) AsInt32(name string) int32 {
+	switch name {
+	case field_MaxTokens:
+		return int32(r.state.MaxTokensPerPeriod) // nolint G115
+	case field_Remaining:
+		return int32(r.state.Remaining()) // nolint G115
+	}
+	return r.NullObject.AsInt32(name)
+}
+
+func (r *rateLimitsRR) AsInt64(name string) int64 {
+	switch name {
+	case field_Period:
+		return int64(r.state.Period.Seconds())
+	case field_ResetAfter:
+		return int64(r.state.ResetAfter().Seconds())
+	}
+	return r.NullObject.AsInt64(name)
+}
+
+// states of the function rate limits buckets of the workspace, the bucket of the limit per app is shared among all workspaces
+func provideQryRateLimits(sr istructsmem.IStatelessResources) {
+	sr.AddQueries(appdef.SysPackagePath, istructsmem.NewQueryFunction(
+		QNameQueryRateLimits,
+		func(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+			as := args.State.AppStructs()
+			buckets := istructsmem.IBucketsFromIAppStructs(as)
+			for _, key := range istructsmem.FunctionRateLimitsBucketKeys(as, args.WSID) {
+				state, err := buckets.GetBucketState(key)
+				if errors.Is(err, irates.ErrorRateLimitNotFound) {
+					continue
+				}
+				if err != nil {
+					// notest
+					return err
+				}
+				if err := callback(&rateLimitsRR{key: key, state: state}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	))
+}
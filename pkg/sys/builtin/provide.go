@@ -27,6 +27,7 @@ func Provide(sr istructsmem.IStatelessResources, buildInfo *debug.BuildInfo, asp
 
 	provideQryEcho(sr)
 	provideQryGRCount(sr)
+	provideQryRateLimits(sr)
 	proivideRenameQName(sr, asp)
 }
 
@@ -4,9 +4,18 @@
 
 package builtin
 
-import "github.com/voedger/voedger/pkg/istructs"
+import (
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istructs"
+)
 
 type echoRR struct {
 	istructs.NullObject
 	text string
 }
+
+type rateLimitsRR struct {
+	istructs.NullObject
+	key   irates.BucketKey
+	state irates.BucketState
+}
@@ -45,6 +45,7 @@ const (
 	Storage_Record_Field_WSID        = "WSID"
 	Storage_Record_Field_Singleton   = "Singleton" // Deprecated: use Storage_Record_Field_IsSingleton instead
 	Storage_Record_Field_IsSingleton = "IsSingleton"
+	Storage_Record_Field_Index       = "Index" // name of the table index to read records by
 
 	Storage_View_Field_WSID = "WSID"
 
@@ -55,6 +56,7 @@ const (
 	Storage_HTTP_Field_HTTPClientTimeoutMilliseconds = "HTTPClientTimeoutMilliseconds"
 	Storage_HTTP_Field_StatusCode                    = "StatusCode"
 	Storage_HTTP_Field_HandleErrors                  = "HandleErrors"
+	Storage_HTTP_Field_PublicAddressesOnly           = "PublicAddressesOnly"
 	Storage_HTTP_Field_Error                         = "Error"
 
 	Storage_WLog_Field_Offset         = "Offset"
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+var (
+	QNameViewIndexes        = appdef.NewQName(appdef.SysPackage, "Indexes")
+	QNameViewIndexedRecords = appdef.NewQName(appdef.SysPackage, "IndexedRecords")
+	QNameViewIndexBackfills = appdef.NewQName(appdef.SysPackage, "IndexBackfills")
+	QNameCmdBackfillIndexes = appdef.NewQName(appdef.SysPackage, "BackfillIndexes")
+	qNameApplyIndexes       = appdef.NewQName(appdef.SysPackage, "ApplyIndexes")
+	qNameAPScheduleBackfill = appdef.NewQName(appdef.SysPackage, "ScheduleIndexesBackfill")
+)
+
+const (
+	// view.sys.Indexes, view.sys.IndexedRecords
+	Field_Index  = "Index"
+	Field_Values = "Values"
+	Field_ID     = "ID"
+
+	// view.sys.IndexBackfills
+	field_Dummy      = "Dummy"
+	Field_WLogOffset = "WLogOffset"
+	Field_Done       = "Done"
+)
+
+const (
+	dummyPartKey = int32(1)
+
+	// WLog events scanned for one index by one BackfillIndexes call
+	backfillEventsLimit = 1000
+
+	// records indexed by one BackfillIndexes call, each one produces two view intents
+	backfillRecordsLimit = 400
+
+	// strings and bytes are escaped and terminated to keep the encoding sortable and prefix-free
+	escapeByte     = byte(0x00)
+	escapedZero    = byte(0xFF)
+	terminatorByte = byte(0x01)
+)
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import "errors"
+
+var ErrIndexValueTooLong = errors.New("index value is too long")
+
+var ErrIndexNotExist = errors.New("index does not exist")
+
+var ErrIndexFieldsMismatch = errors.New("provided fields are not the leading fields of the index")
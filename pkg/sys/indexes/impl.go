@@ -0,0 +1,363 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+// raised to stop WLog reading when backfill records limit is reached
+var errBackfillLimitReached = errors.New("backfill records limit reached")
+
+func applyIndexes(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) (err error) {
+	if event.QName() == QNameCmdBackfillIndexes {
+		return backfill(event, st, intents)
+	}
+	appDef := st.AppStructs().AppDef()
+	if event.WLogOffset() == istructs.FirstOffset {
+		// all records of the new workspace are indexed by its events, so there is nothing to backfill
+		if err := markBackfilled(appDef, st, intents, event.WLogOffset()+1); err != nil {
+			return err
+		}
+	}
+	for cud := range event.CUDs {
+		rec, ok := appDef.Type(cud.QName()).(appdef.IRecord)
+		if !ok || rec.IndexCount() == 0 {
+			continue
+		}
+		var row istructs.IRowReader = cud
+		if !cud.IsNew() {
+			if !indexFieldsChanged(rec, cud) {
+				continue
+			}
+			// records are applied before sync projectors, so the stored record contains the result of the update
+			kb, err := st.KeyBuilder(sys.Storage_Record, cud.QName())
+			if err != nil {
+				// notest
+				return err
+			}
+			kb.PutRecordID(sys.Storage_Record_Field_ID, cud.ID())
+			if row, err = st.MustExist(kb); err != nil {
+				return err
+			}
+		}
+		for _, index := range rec.Indexes() {
+			if err := indexRecord(st, intents, index, row, cud.ID()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns true if some index field of the record is changed by the CUD
+func indexFieldsChanged(rec appdef.IRecord, cud istructs.ICUDRow) bool {
+	for f := range cud.SpecifiedValues {
+		for _, index := range rec.Indexes() {
+			for _, indexField := range index.Fields() {
+				if indexField.Name() == f.Name() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Puts the record values to the index.
+//
+// Entry of the previous values is kept in the index with null ID, because view records can not be deleted.
+// The entry is reused if the record gets the same values again, entries with null ID are skipped by [ReadIndex].
+// Deactivation does not change the index fields, so inactive records stay in the index
+func indexRecord(st istructs.IState, intents istructs.IIntents, index appdef.IIndex, row istructs.IRowReader, id istructs.RecordID) error {
+	newValues, err := indexKeyValues(row, index, id)
+	if err != nil {
+		return err
+	}
+
+	indexedKB, indexedRecord, indexed, err := getIndexedRecord(st, index, id)
+	if err != nil {
+		return err
+	}
+	var oldValues []byte
+	if indexed {
+		if oldValues = indexedRecord.AsBytes(Field_Values); bytes.Equal(oldValues, newValues) {
+			return nil
+		}
+	}
+
+	if len(oldValues) > 0 {
+		oldKB, oldEntry, ok, err := getIndexEntry(st, index, oldValues)
+		if err != nil {
+			return err
+		}
+		if ok {
+			entryUpdater, err := intents.UpdateValue(oldKB, oldEntry)
+			if err != nil {
+				return err
+			}
+			entryUpdater.PutRecordID(Field_ID, istructs.NullRecordID)
+		}
+	}
+
+	newKB, newEntry, ok, err := getIndexEntry(st, index, newValues)
+	if err != nil {
+		return err
+	}
+	var entryBuilder istructs.IStateValueBuilder
+	if ok {
+		entryBuilder, err = intents.UpdateValue(newKB, newEntry)
+	} else {
+		entryBuilder, err = intents.NewValue(newKB)
+	}
+	if err != nil {
+		return err
+	}
+	entryBuilder.PutRecordID(Field_ID, id)
+
+	var indexedBuilder istructs.IStateValueBuilder
+	if indexed {
+		indexedBuilder, err = intents.UpdateValue(indexedKB, indexedRecord)
+	} else {
+		indexedBuilder, err = intents.NewValue(indexedKB)
+	}
+	if err != nil {
+		return err
+	}
+	indexedBuilder.PutBytes(Field_Values, newValues)
+	return nil
+}
+
+func getIndexedRecord(st istructs.IState, index appdef.IIndex, id istructs.RecordID) (istructs.IStateKeyBuilder, istructs.IStateValue, bool, error) {
+	kb, err := st.KeyBuilder(sys.Storage_View, QNameViewIndexedRecords)
+	if err != nil {
+		// notest
+		return nil, nil, false, err
+	}
+	kb.PutQName(Field_Index, index.Name())
+	kb.PutRecordID(Field_ID, id)
+	sv, ok, err := st.CanExist(kb)
+	return kb, sv, ok, err
+}
+
+func getIndexEntry(st istructs.IState, index appdef.IIndex, values []byte) (istructs.IStateKeyBuilder, istructs.IStateValue, bool, error) {
+	kb, err := st.KeyBuilder(sys.Storage_View, QNameViewIndexes)
+	if err != nil {
+		// notest
+		return nil, nil, false, err
+	}
+	kb.PutQName(Field_Index, index.Name())
+	kb.PutBytes(Field_Values, values)
+	sv, ok, err := st.CanExist(kb)
+	return kb, sv, ok, err
+}
+
+// Indexes the records created before the index was declared.
+//
+// Each call continues the WLog scan of the every unfinished index from the stored offset.
+// Records already indexed are skipped, the current state of the record is indexed.
+// The scan is limited by events and records count, the command is repeated by [scheduleBackfill] until all indexes are done
+func backfill(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) error {
+	recordsLimit := backfillRecordsLimit
+	for rec := range appdef.Records(st.AppStructs().AppDef().Types()) {
+		for _, index := range rec.Indexes() {
+			if recordsLimit == 0 {
+				return nil
+			}
+			if err := backfillIndex(event, st, intents, rec.QName(), index, &recordsLimit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func backfillIndex(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents, recQName appdef.QName, index appdef.IIndex, recordsLimit *int) error {
+	kb, err := st.KeyBuilder(sys.Storage_View, QNameViewIndexBackfills)
+	if err != nil {
+		// notest
+		return err
+	}
+	kb.PutInt32(field_Dummy, dummyPartKey)
+	kb.PutQName(Field_Index, index.Name())
+	backfillState, ok, err := st.CanExist(kb)
+	if err != nil {
+		return err
+	}
+	from := istructs.FirstOffset
+	if ok {
+		if backfillState.AsBool(Field_Done) {
+			return nil
+		}
+		from = istructs.Offset(backfillState.AsInt64(Field_WLogOffset)) // nolint G115
+	}
+
+	as := st.AppStructs()
+	wsid := event.Workspace()
+	next := from
+	if till := event.WLogOffset(); from < till {
+		count := min(backfillEventsLimit, int(till-from)) // nolint G115
+		seen := map[istructs.RecordID]bool{}
+		err = as.Events().ReadWLog(context.Background(), wsid, from, count, func(wlogOffset istructs.Offset, e istructs.IWLogEvent) error {
+			for cud := range e.CUDs {
+				if cud.QName() != recQName || seen[cud.ID()] {
+					continue
+				}
+				seen[cud.ID()] = true
+				if *recordsLimit == 0 {
+					// the event will be scanned again by the next call
+					return errBackfillLimitReached
+				}
+				_, _, indexed, err := getIndexedRecord(st, index, cud.ID())
+				if err != nil {
+					return err
+				}
+				if indexed {
+					continue
+				}
+				rec, err := as.Records().Get(wsid, true, cud.ID())
+				if err != nil {
+					return err
+				}
+				if rec.QName() == appdef.NullQName {
+					continue
+				}
+				if err := indexRecord(st, intents, index, rec, cud.ID()); err != nil {
+					return err
+				}
+				*recordsLimit--
+			}
+			next = wlogOffset + 1
+			return nil
+		})
+		if err == nil {
+			next = from + istructs.Offset(count) // nolint G115
+		} else if !errors.Is(err, errBackfillLimitReached) {
+			return err
+		}
+	}
+
+	var backfillBuilder istructs.IStateValueBuilder
+	if ok {
+		backfillBuilder, err = intents.UpdateValue(kb, backfillState)
+	} else {
+		backfillBuilder, err = intents.NewValue(kb)
+	}
+	if err != nil {
+		return err
+	}
+	backfillBuilder.PutInt64(Field_WLogOffset, int64(next)) // nolint G115
+	backfillBuilder.PutBool(Field_Done, next >= event.WLogOffset())
+	return nil
+}
+
+func markBackfilled(appDef appdef.IAppDef, st istructs.IState, intents istructs.IIntents, next istructs.Offset) error {
+	for rec := range appdef.Records(appDef.Types()) {
+		for _, index := range rec.Indexes() {
+			kb, err := st.KeyBuilder(sys.Storage_View, QNameViewIndexBackfills)
+			if err != nil {
+				// notest
+				return err
+			}
+			kb.PutInt32(field_Dummy, dummyPartKey)
+			kb.PutQName(Field_Index, index.Name())
+			backfillBuilder, err := intents.NewValue(kb)
+			if err != nil {
+				// notest
+				return err
+			}
+			backfillBuilder.PutInt64(Field_WLogOffset, int64(next)) // nolint G115
+			backfillBuilder.PutBool(Field_Done, true)
+		}
+	}
+	return nil
+}
+
+// Returns projector which executes BackfillIndexes in the workspace of the event until all indexes of the workspace are done.
+//
+// Workspaces created before the index was declared are backfilled on their next event, [ReadIndex] scans the records meanwhile
+func scheduleBackfill(federation federation.IFederation, tokens itokens.ITokens) func(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) error {
+	return func(event istructs.IPLogEvent, st istructs.IState, _ istructs.IIntents) error {
+		as := st.AppStructs()
+		for rec := range appdef.Records(as.AppDef().Types()) {
+			for _, index := range rec.Indexes() {
+				done, err := isBackfilled(as, event.Workspace(), index)
+				if err != nil {
+					return err
+				}
+				if done {
+					continue
+				}
+				token, err := payloads.GetSystemPrincipalToken(tokens, st.App())
+				if err != nil {
+					// notest
+					return err
+				}
+				// the partition of the workspace is served by this VVM, admin endpoint works before the public one is started
+				_, err = federation.AdminFunc(fmt.Sprintf("api/%s/%d/c.%s", st.App(), event.Workspace(), QNameCmdBackfillIndexes),
+					"{}", coreutils.WithAuthorizeBy(token), coreutils.WithDiscardResponse())
+				if err != nil {
+					// e.g. the workspace is inactive. Backfill is continued on the next event of the workspace
+					logger.Error(fmt.Sprintf("failed to backfill indexes of workspace %d: %s", event.Workspace(), err))
+				}
+				// one call continues all unfinished indexes, next one is made on the BackfillIndexes event
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+// Denies the events which record values can not be put into the index
+func eventIndexValidator(ctx context.Context, rawEvent istructs.IRawEvent, appStructs istructs.IAppStructs, wsid istructs.WSID) error {
+	for cud := range rawEvent.CUDs {
+		rec, ok := appStructs.AppDef().Type(cud.QName()).(appdef.IRecord)
+		if !ok || rec.IndexCount() == 0 {
+			continue
+		}
+		var row istructs.IRowReader = cud
+		specified := map[appdef.FieldName]any{}
+		if !cud.IsNew() {
+			if !indexFieldsChanged(rec, cud) {
+				continue
+			}
+			// update -> stored values are overridden by the specified ones
+			stored, err := appStructs.Records().Get(wsid, true, cud.ID())
+			if err != nil {
+				// notest
+				return err
+			}
+			row = stored
+			for f, v := range cud.SpecifiedValues {
+				specified[f.Name()] = v
+			}
+		}
+		for _, index := range rec.Indexes() {
+			_, err := indexKeyValuesFunc(index, cud.ID(), func(f appdef.IField) any {
+				if v, ok := specified[f.Name()]; ok {
+					return v
+				}
+				return coreutils.ReadByKind(f.Name(), f.DataKind(), row)
+			})
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Reads the records of the index type which encoded leading index values start with the prefix and calls cb in the index order.
+//
+// Records are found by the CUDs of the workspace WLog, the current state of the record is matched
+func scanIndex(ctx context.Context, as istructs.IAppStructs, wsid istructs.WSID, index appdef.IIndex, prefix []byte, cb func(istructs.RecordID) error) error {
+	rec := indexedType(as.AppDef(), index)
+	if rec == nil {
+		// notest
+		return ErrIndexNotExist
+	}
+	ids := []istructs.RecordID{}
+	seen := map[istructs.RecordID]bool{}
+	err := as.Events().ReadWLog(ctx, wsid, istructs.FirstOffset, istructs.ReadToTheEnd, func(_ istructs.Offset, event istructs.IWLogEvent) error {
+		for cud := range event.CUDs {
+			if cud.QName() == rec.QName() && !seen[cud.ID()] {
+				seen[cud.ID()] = true
+				ids = append(ids, cud.ID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	scan := &indexScan{index: index, prefix: prefix}
+	for _, id := range ids {
+		r, err := as.Records().Get(wsid, true, id)
+		if err != nil {
+			return err
+		}
+		if r.QName() == appdef.NullQName {
+			// notest
+			continue
+		}
+		if err := scan.add(r, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range scan.ids() {
+		if err := cb(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the record type which declares the index
+func indexedType(appDef appdef.IAppDef, index appdef.IIndex) appdef.IRecord {
+	for rec := range appdef.Records(appDef.Types()) {
+		if rec.Index(index.Name()) != nil {
+			return rec
+		}
+	}
+	return nil
+}
+
+// Collects the scanned records matched by the leading index values
+type indexScan struct {
+	index   appdef.IIndex
+	prefix  []byte
+	matched []indexScanEntry
+}
+
+type indexScanEntry struct {
+	values []byte
+	id     istructs.RecordID
+}
+
+func (s *indexScan) add(row istructs.IRowReader, id istructs.RecordID) error {
+	values, err := indexKeyValues(row, s.index, id)
+	if errors.Is(err, ErrIndexValueTooLong) {
+		// the record could not be put into the index
+		return nil
+	}
+	if err != nil {
+		// notest
+		return err
+	}
+	if bytes.HasPrefix(values, s.prefix) {
+		s.matched = append(s.matched, indexScanEntry{values: values, id: id})
+	}
+	return nil
+}
+
+// Returns IDs of the matched records in the index order
+func (s *indexScan) ids() []istructs.RecordID {
+	slices.SortFunc(s.matched, func(a, b indexScanEntry) int {
+		return bytes.Compare(a.values, b.values)
+	})
+	res := make([]istructs.RecordID, 0, len(s.matched))
+	for _, e := range s.matched {
+		res = append(res, e.id)
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestIndexScan(t *testing.T) {
+	require := require.New(t)
+	doc := testDoc(t)
+	index := doc.Index(testIdxByStrInt)
+
+	scan := &indexScan{index: index, prefix: encode(t, doc.Field("Str"), "a")}
+	for id, data := range map[istructs.RecordID]map[string]any{
+		1: {"Str": "a", "Int": int64(2)},
+		2: {"Str": "b", "Int": int64(1)},
+		3: {"Str": "a", "Int": int64(1)},
+		4: {"Str": "ab", "Int": int64(0)},
+		5: {"Str": "a", "Int": int64(1)},
+		6: {"Str": string(bytes.Repeat([]byte("a"), 70000)), "Int": int64(1)}, // could not be indexed
+	} {
+		require.NoError(scan.add(&coreutils.TestObject{Name: testDocQName, ID_: id, Data: data}, id))
+	}
+
+	// index order, equal values are ordered by ID
+	require.Equal([]istructs.RecordID{3, 5, 1}, scan.ids())
+}
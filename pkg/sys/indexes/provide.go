@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+func Provide(sr istructsmem.IStatelessResources, federation federation.IFederation, tokens itokens.ITokens) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		QNameCmdBackfillIndexes,
+		istructsmem.NullCommandExec, // the backfill itself is made by ApplyIndexes projector
+	))
+	sr.AddProjectors(appdef.SysPackagePath, istructs.Projector{
+		Name: qNameApplyIndexes,
+		Func: applyIndexes,
+	}, istructs.Projector{
+		Name: qNameAPScheduleBackfill,
+		Func: scheduleBackfill(federation, tokens),
+	})
+}
+
+func ProvideEventValidator(cfg *istructsmem.AppConfigType) {
+	cfg.AddEventValidators(eventIndexValidator)
+}
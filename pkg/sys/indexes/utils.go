@@ -0,0 +1,338 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Returns index of the record type which leading fields are specified most by the value func and the values of these fields.
+//
+// Returns nil if the type has no index which first field is specified
+func MatchIndex(rec appdef.IWithIndexes, value func(appdef.FieldName) (any, bool)) (index appdef.IIndex, values []any) {
+	for _, idx := range rec.Indexes() {
+		vv := make([]any, 0, len(idx.Fields()))
+		for _, f := range idx.Fields() {
+			v, ok := value(f.Name())
+			if !ok {
+				break
+			}
+			vv = append(vv, v)
+		}
+		if len(vv) > len(values) {
+			index, values = idx, vv
+		}
+	}
+	return index, values
+}
+
+// Calls cb for ID of each record which leading index fields are equal to the provided values.
+//
+// IDs are passed in the index order, records with equal values are passed in ID order.
+// Records are not checked for activity, inactive records are indexed too.
+// Until the backfill of the index is done in the workspace, the records are scanned instead of the index reading
+func ReadIndex(ctx context.Context, as istructs.IAppStructs, wsid istructs.WSID, index appdef.IIndex, values []any, cb func(istructs.RecordID) error) error {
+	if len(values) > len(index.Fields()) {
+		return fmt.Errorf("%v has %d fields, %d values provided: %w", index, len(index.Fields()), len(values), ErrIndexFieldsMismatch)
+	}
+	buf := bytes.NewBuffer(nil)
+	for i, v := range values {
+		if err := writeIndexValue(buf, index.Fields()[i], v); err != nil {
+			return err
+		}
+	}
+	backfilled, err := isBackfilled(as, wsid, index)
+	if err != nil {
+		return err
+	}
+	if !backfilled {
+		return scanIndex(ctx, as, wsid, index, buf.Bytes(), cb)
+	}
+	kb := as.ViewRecords().KeyBuilder(QNameViewIndexes)
+	kb.PutQName(Field_Index, index.Name())
+	if buf.Len() > 0 {
+		kb.PutBytes(Field_Values, buf.Bytes())
+	}
+	return as.ViewRecords().Read(ctx, wsid, kb, func(_ istructs.IKey, value istructs.IValue) error {
+		if id := value.AsRecordID(Field_ID); id != istructs.NullRecordID {
+			return cb(id)
+		}
+		return nil // stale entry
+	})
+}
+
+// Returns IDs of the records which fields are equal to the provided values.
+//
+// Provided fields must be the leading fields of some index of the table, the index with the most leading fields matched is used
+func GetRecordIDsByIndex(ctx context.Context, wsid istructs.WSID, tableQName appdef.QName, as istructs.IAppStructs, values map[string]any) (ids []istructs.RecordID, err error) {
+	table, ok := as.AppDef().Type(tableQName).(appdef.IRecord)
+	if !ok {
+		return nil, appdef.ErrNotFound("record type %q", tableQName)
+	}
+	index, vv := MatchIndex(table, func(n appdef.FieldName) (any, bool) {
+		v, ok := values[n]
+		return v, ok
+	})
+	if index == nil || len(vv) != len(values) {
+		return nil, fmt.Errorf("provided set of fields does not match any known index of %s: %w", tableQName, ErrIndexNotExist)
+	}
+	err = ReadIndex(ctx, as, wsid, index, vv, func(id istructs.RecordID) error {
+		ids = append(ids, id)
+		return nil
+	})
+	return ids, err
+}
+
+// Returns true if the records created before the index was declared are indexed in the workspace
+func isBackfilled(as istructs.IAppStructs, wsid istructs.WSID, index appdef.IIndex) (bool, error) {
+	kb := as.ViewRecords().KeyBuilder(QNameViewIndexBackfills)
+	kb.PutInt32(field_Dummy, dummyPartKey)
+	kb.PutQName(Field_Index, index.Name())
+	value, err := as.ViewRecords().Get(wsid, kb)
+	if errors.Is(err, istructs.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		// notest
+		return false, err
+	}
+	return value.AsBool(Field_Done), nil
+}
+
+// Returns encoded values of the index fields of the record followed by the record ID
+func indexKeyValues(rec istructs.IRowReader, index appdef.IIndex, id istructs.RecordID) ([]byte, error) {
+	return indexKeyValuesFunc(index, id, func(f appdef.IField) any {
+		return coreutils.ReadByKind(f.Name(), f.DataKind(), rec)
+	})
+}
+
+// Returns encoded values of the index fields provided by the value func followed by the record ID
+func indexKeyValuesFunc(index appdef.IIndex, id istructs.RecordID, value func(appdef.IField) any) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	for _, f := range index.Fields() {
+		if err := writeIndexValue(buf, f, value(f)); err != nil {
+			return nil, err
+		}
+	}
+	binary.Write(buf, binary.BigEndian, uint64(id)) // nolint
+	if buf.Len() > int(appdef.MaxFieldLength) {
+		return nil, fmt.Errorf(`%w: resulting len of the values of %v is %d, max %d is allowed. Decrease len of values of index fields`,
+			ErrIndexValueTooLong, index, buf.Len(), appdef.MaxFieldLength)
+	}
+	return buf.Bytes(), nil
+}
+
+// Writes value of the index field to the buffer.
+//
+// Encoding keeps the order of values of the same kind and is prefix-free, so that encoded values
+// of leading fields are the prefix of encoded values of all fields.
+// Value can be specified as read by [coreutils.ReadByKind], as stored by record or as decoded from JSON
+func writeIndexValue(buf *bytes.Buffer, field appdef.IField, value any) error {
+	switch k := field.DataKind(); k {
+	case appdef.DataKind_int8, appdef.DataKind_int16, appdef.DataKind_int32, appdef.DataKind_int64:
+		v, err := indexInt64Value(field, value)
+		if err != nil {
+			return err
+		}
+		writeSortableInt64(buf, v)
+	case appdef.DataKind_decimal, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		v, err := indexInt64Value(field, value)
+		if err != nil {
+			return err
+		}
+		if k == appdef.DataKind_date {
+			v = utils.TruncateToDate(v)
+		}
+		writeSortableInt64(buf, v)
+	case appdef.DataKind_float32:
+		v, err := indexFloat64Value(field, value)
+		if err != nil {
+			return err
+		}
+		bits := math.Float32bits(float32(v))
+		if bits&(1<<31) == 0 {
+			bits ^= 1 << 31
+		} else {
+			bits = ^bits
+		}
+		binary.Write(buf, binary.BigEndian, bits) // nolint
+	case appdef.DataKind_float64:
+		v, err := indexFloat64Value(field, value)
+		if err != nil {
+			return err
+		}
+		bits := math.Float64bits(v)
+		if bits&(1<<63) == 0 {
+			bits ^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		binary.Write(buf, binary.BigEndian, bits) // nolint
+	case appdef.DataKind_bool:
+		v, ok := value.(bool)
+		if !ok {
+			return errIndexValueType(field, value)
+		}
+		if v {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	case appdef.DataKind_RecordID:
+		var id istructs.RecordID
+		switch v := value.(type) {
+		case istructs.RecordID:
+			id = v
+		case json.Number:
+			val, err := coreutils.ClarifyJSONNumber(v, k)
+			if err != nil {
+				return err
+			}
+			id = val.(istructs.RecordID)
+		default:
+			i, err := indexInt64Value(field, value)
+			if err != nil {
+				return err
+			}
+			id = istructs.RecordID(i) // nolint G115
+		}
+		binary.Write(buf, binary.BigEndian, uint64(id)) // nolint
+	case appdef.DataKind_QName:
+		switch v := value.(type) {
+		case appdef.QName:
+			writeEscaped(buf, []byte(v.String()))
+		case string:
+			writeEscaped(buf, []byte(v))
+		default:
+			return errIndexValueType(field, value)
+		}
+	case appdef.DataKind_string:
+		v, ok := value.(string)
+		if !ok {
+			return errIndexValueType(field, value)
+		}
+		writeEscaped(buf, []byte(v))
+	case appdef.DataKind_bytes:
+		switch v := value.(type) {
+		case []byte:
+			writeEscaped(buf, v)
+		case string:
+			// bytes are represented in JSON as base64 strings
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return err
+			}
+			writeEscaped(buf, b)
+		default:
+			return errIndexValueType(field, value)
+		}
+	case appdef.DataKind_uuid:
+		b := make([]byte, utils.UUIDSize) // empty value is indexed as nil uuid
+		switch v := value.(type) {
+		case []byte:
+			copy(b, v)
+		case string:
+			if v != "" {
+				u, err := utils.ParseUUID(v)
+				if err != nil {
+					return err
+				}
+				copy(b, u)
+			}
+		default:
+			return errIndexValueType(field, value)
+		}
+		buf.Write(b)
+	default:
+		return fmt.Errorf("%s-field %s: %w", k.TrimString(), field.Name(), appdef.ErrUnsupportedError)
+	}
+	return nil
+}
+
+// Returns integer, decimal or temporal value as it is stored in record
+func indexInt64Value(field appdef.IField, value any) (int64, error) {
+	k := field.DataKind()
+	switch v := value.(type) {
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case istructs.RecordID:
+		return int64(v), nil // nolint G115
+	case float64:
+		// numbers decoded from JSON
+		if k == appdef.DataKind_decimal {
+			_, scale := appdef.DecimalPrecisionScale(field.Constraints())
+			return utils.ParseDecimal(fmt.Sprint(v), scale)
+		}
+		return int64(v), nil
+	case json.Number:
+		if k == appdef.DataKind_decimal {
+			_, scale := appdef.DecimalPrecisionScale(field.Constraints())
+			return utils.ParseDecimal(v.String(), scale)
+		}
+		return v.Int64()
+	case string:
+		switch {
+		case v == "":
+			return 0, nil // empty value read from record
+		case k == appdef.DataKind_decimal:
+			_, scale := appdef.DecimalPrecisionScale(field.Constraints())
+			return utils.ParseDecimal(v, scale)
+		case k.IsTemporal():
+			return coreutils.ParseTemporal(k, v)
+		}
+	}
+	return 0, errIndexValueType(field, value)
+}
+
+func indexFloat64Value(field appdef.IField, value any) (float64, error) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	}
+	return 0, errIndexValueType(field, value)
+}
+
+func errIndexValueType(field appdef.IField, value any) error {
+	return fmt.Errorf("%w: %T for %s index field %s", coreutils.ErrFieldTypeMismatch, value, field.DataKind().TrimString(), field.Name())
+}
+
+func writeSortableInt64(buf *bytes.Buffer, v int64) {
+	binary.Write(buf, binary.BigEndian, uint64(v)^(1<<63)) // nolint G115 two's complement bits are preserved
+}
+
+func writeEscaped(buf *bytes.Buffer, b []byte) {
+	for _, c := range b {
+		buf.WriteByte(c)
+		if c == escapeByte {
+			buf.WriteByte(escapedZero)
+		}
+	}
+	buf.WriteByte(escapeByte)
+	buf.WriteByte(terminatorByte)
+}
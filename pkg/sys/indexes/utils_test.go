@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package indexes
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/coreutils"
+)
+
+var (
+	testDocQName      = appdef.NewQName("test", "doc")
+	testIdxByStrInt   = appdef.IndexQName(testDocQName, "ByStrInt")
+	testIdxByStrFloat = appdef.IndexQName(testDocQName, "ByStrFloat")
+)
+
+func testDoc(t *testing.T) appdef.ICDoc {
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+	doc := wsb.AddCDoc(testDocQName)
+	doc.
+		AddField("Int", appdef.DataKind_int64, false).
+		AddField("Float", appdef.DataKind_float64, false).
+		AddField("Str", appdef.DataKind_string, false).
+		AddField("Bool", appdef.DataKind_bool, false)
+	doc.
+		AddIndex(testIdxByStrInt, []appdef.FieldName{"Str", "Int"}).
+		AddIndex(testIdxByStrFloat, []appdef.FieldName{"Str", "Bool", "Float"})
+	app, err := adb.Build()
+	require.NoError(t, err)
+	return appdef.CDoc(app.Type, testDocQName)
+}
+
+func encode(t *testing.T, field appdef.IField, value any) []byte {
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, writeIndexValue(buf, field, value))
+	return buf.Bytes()
+}
+
+func TestIndexValueOrder(t *testing.T) {
+	doc := testDoc(t)
+
+	tests := []struct {
+		field  string
+		values []any
+	}{
+		{"Int", []any{int64(-100), int64(-1), int64(0), int64(5), int64(100)}},
+		{"Float", []any{-2.5, -0.1, 0.0, 1.5, 10.0}},
+		{"Str", []any{"", "a", "a\x00", "ab", "b"}},
+		{"Bool", []any{false, true}},
+	}
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			field := doc.Field(test.field)
+			for i := 1; i < len(test.values); i++ {
+				prev, next := encode(t, field, test.values[i-1]), encode(t, field, test.values[i])
+				require.Negative(t, bytes.Compare(prev, next), "%v must be less than %v", test.values[i-1], test.values[i])
+			}
+		})
+	}
+}
+
+func TestIndexValueJSON(t *testing.T) {
+	require := require.New(t)
+	doc := testDoc(t)
+
+	require.Equal(encode(t, doc.Field("Int"), int64(42)), encode(t, doc.Field("Int"), json.Number("42")))
+	require.Equal(encode(t, doc.Field("Float"), 4.2), encode(t, doc.Field("Float"), json.Number("4.2")))
+
+	buf := bytes.NewBuffer(nil)
+	require.ErrorIs(writeIndexValue(buf, doc.Field("Int"), "42"), coreutils.ErrFieldTypeMismatch)
+}
+
+func TestIndexValuePrefix(t *testing.T) {
+	require := require.New(t)
+	doc := testDoc(t)
+
+	leading := encode(t, doc.Field("Str"), "a")
+	require.True(bytes.HasPrefix(append(encode(t, doc.Field("Str"), "a"), encode(t, doc.Field("Int"), int64(1))...), leading))
+	require.False(bytes.HasPrefix(append(encode(t, doc.Field("Str"), "ab"), encode(t, doc.Field("Int"), int64(1))...), leading))
+	require.False(bytes.HasPrefix(append(encode(t, doc.Field("Str"), "a\x00"), encode(t, doc.Field("Int"), int64(1))...), leading))
+}
+
+func TestMatchIndex(t *testing.T) {
+	require := require.New(t)
+	doc := testDoc(t)
+
+	match := func(values map[string]any) (appdef.QName, []any) {
+		idx, vv := MatchIndex(doc, func(n appdef.FieldName) (any, bool) {
+			v, ok := values[n]
+			return v, ok
+		})
+		if idx == nil {
+			return appdef.NullQName, vv
+		}
+		return idx.Name(), vv
+	}
+
+	name, vv := match(map[string]any{"Str": "a", "Int": int64(1)})
+	require.Equal(testIdxByStrInt, name)
+	require.Equal([]any{"a", int64(1)}, vv)
+
+	name, vv = match(map[string]any{"Str": "a", "Bool": true, "Float": 1.5})
+	require.Equal(testIdxByStrFloat, name)
+	require.Equal([]any{"a", true, 1.5}, vv)
+
+	name, _ = match(map[string]any{"Int": int64(1)})
+	require.Equal(appdef.NullQName, name)
+}
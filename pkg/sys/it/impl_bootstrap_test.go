@@ -11,6 +11,7 @@ import (
 	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/btstrp"
 	"github.com/voedger/voedger/pkg/cluster"
@@ -61,12 +62,29 @@ func TestBoostrap_BasicUsage(t *testing.T) {
 		blobStorage := iblobstoragestg.BlobAppStoragePtr(new(istorage.IAppStorage))
 		routerStorage := dbcertcache.RouterAppStoragePtr(new(istorage.IAppStorage))
 		err = btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
-			nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage)
+			nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage, nil)
 		require.NoError(err)
 		require.NotNil(*blobStorage)
 		require.NotNil(*routerStorage)
 	})
 
+	t.Run("maintenance apps are deployed without partitions", func(t *testing.T) {
+		appParts, cleanup, err := appparts.New(vit.IAppStructsProvider)
+		require.NoError(err)
+		defer cleanup()
+		blobStorage := iblobstoragestg.BlobAppStoragePtr(new(istorage.IAppStorage))
+		routerStorage := dbcertcache.RouterAppStoragePtr(new(istorage.IAppStorage))
+		err = btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
+			nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage, []appdef.AppQName{otherApps[0].Name})
+		require.NoError(err)
+
+		partsCount, err := appParts.AppPartsCount(otherApps[0].Name)
+		require.NoError(err)
+		require.Equal(otherApps[0].NumParts, partsCount)
+		_, err = appParts.Borrow(otherApps[0].Name, 0, appparts.ProcessorKind_Command)
+		require.ErrorIs(err, appparts.ErrNotFound)
+	})
+
 	t.Run("panic on NumPartitions change", func(t *testing.T) {
 		appParts, cleanup, err := appparts.New(vit.IAppStructsProvider)
 		require.NoError(err)
@@ -80,7 +98,7 @@ func TestBoostrap_BasicUsage(t *testing.T) {
 		require.PanicsWithValue(fmt.Sprintf("failed to deploy app %[1]s: status 409, expected [200 201]: num partitions changed: app %[1]s declaring NumPartitions=%d but was previously deployed with NumPartitions=%d",
 			otherApps[0].Name, otherApps[0].AppDeploymentDescriptor.NumParts, otherApps[0].AppDeploymentDescriptor.NumParts-1), func() {
 			btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
-				nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage)
+				nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage, nil)
 		})
 	})
 
@@ -98,7 +116,7 @@ func TestBoostrap_BasicUsage(t *testing.T) {
 			blobStorage := iblobstoragestg.BlobAppStoragePtr(new(istorage.IAppStorage))
 			routerStorage := dbcertcache.RouterAppStoragePtr(new(istorage.IAppStorage))
 			btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
-				nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage)
+				nil, vit.ITokens, vit.IAppStorageProvider, blobStorage, routerStorage, nil)
 		})
 	})
 }
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/cdc"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestBasicUsage_CDC(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	sysPrn := vit.GetSystemPrincipal(istructs.AppQName_test1_app1)
+
+	body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"CDC food"}}]}`
+	wlogOffset := vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+
+	partition, err := vit.IAppPartitions.AppWorkspacePartitionID(istructs.AppQName_test1_app1, ws.WSID)
+	require.NoError(err)
+	eventsURL := fmt.Sprintf("api/v2/apps/test1/app1/cdc/partitions/%d/events", partition)
+
+	var plogOffset istructs.Offset
+	t.Run("read the partition feed as NDJSON", func(t *testing.T) {
+		resp := vit.POST(eventsURL+"?from=1", "",
+			coreutils.WithMethod(http.MethodGet),
+			coreutils.WithAuthorizeBy(sysPrn.Token),
+		)
+		require.Contains(resp.HTTPResp.Header.Get(coreutils.ContentType), coreutils.ContentType_ApplicationNDJSON)
+		scanner := bufio.NewScanner(strings.NewReader(resp.Body))
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			event := cdc.Event{}
+			require.NoError(json.Unmarshal(scanner.Bytes(), &event))
+			if event.WSID == ws.WSID && event.WLogOffset == wlogOffset {
+				require.Equal("sys.CUD", event.QName)
+				require.Len(event.CUDs, 1)
+				plogOffset = event.PLogOffset
+			}
+		}
+		require.NoError(scanner.Err())
+		require.NotZero(plogOffset)
+	})
+
+	consumerURL := fmt.Sprintf("api/v2/apps/test1/app1/cdc/partitions/%d/consumers/it-consumer/offset", partition)
+
+	t.Run("commit and read back the consumer offset", func(t *testing.T) {
+		vit.POST(consumerURL, fmt.Sprintf(`{"offset":%d}`, plogOffset),
+			coreutils.WithMethod(http.MethodPut),
+			coreutils.WithAuthorizeBy(sysPrn.Token),
+		)
+		resp := vit.POST(consumerURL, "",
+			coreutils.WithMethod(http.MethodGet),
+			coreutils.WithAuthorizeBy(sysPrn.Token),
+		)
+		require.JSONEq(fmt.Sprintf(`{"offset":%d}`, plogOffset), resp.Body)
+
+		// the committed offset does not move backwards
+		vit.POST(consumerURL, fmt.Sprintf(`{"offset":%d}`, plogOffset-1),
+			coreutils.WithMethod(http.MethodPut),
+			coreutils.WithAuthorizeBy(sysPrn.Token),
+			coreutils.Expect409(),
+		)
+	})
+
+	t.Run("consumer continues after the committed offset", func(t *testing.T) {
+		resp := vit.POST(eventsURL+"?consumer=it-consumer", "",
+			coreutils.WithMethod(http.MethodGet),
+			coreutils.WithAuthorizeBy(sysPrn.Token),
+		)
+		scanner := bufio.NewScanner(strings.NewReader(resp.Body))
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			event := cdc.Event{}
+			require.NoError(json.Unmarshal(scanner.Bytes(), &event))
+			require.Greater(event.PLogOffset, plogOffset)
+		}
+		require.NoError(scanner.Err())
+	})
+
+	t.Run("system principal only", func(t *testing.T) {
+		vit.POST(eventsURL, "", coreutils.WithMethod(http.MethodGet), coreutils.Expect401())
+		vit.POST(eventsURL, "",
+			coreutils.WithMethod(http.MethodGet),
+			coreutils.WithAuthorizeBy(ws.Owner.Token),
+			coreutils.Expect403(),
+		)
+	})
+}
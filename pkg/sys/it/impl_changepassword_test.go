@@ -29,7 +29,7 @@ func TestBasicUsage_ChangePassword(t *testing.T) {
 	body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"OldPassword":"1","NewPassword":"%s"}}`, loginName, istructs.AppQName_test1_app1, newPwd)
 	vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ChangePassword", body)
 
-	// note: previous tokens are still valid after password change
+	// note: previous tokens are revoked on password change, see TestSessions_RevokeAll
 
 	// expect no errors on login with new password
 	login.Pwd = newPwd
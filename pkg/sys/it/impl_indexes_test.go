@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys/indexes"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestBasicUsage_Indexes(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	codeA := fmt.Sprintf("a%d", vit.NextNumber())
+	codeB := fmt.Sprintf("b%d", vit.NextNumber())
+
+	body := fmt.Sprintf(`{"cuds":[
+		{"fields":{"sys.ID":1,"sys.QName":"app1pkg.DocIndexed","Code":"%[1]s","Num":2}},
+		{"fields":{"sys.ID":2,"sys.QName":"app1pkg.DocIndexed","Code":"%[1]s","Num":1}},
+		{"fields":{"sys.ID":3,"sys.QName":"app1pkg.DocIndexed","Code":"%[2]s","Num":1}}
+	]}`, codeA, codeB)
+	resp := vit.PostWS(ws, "c.sys.CUD", body)
+	id1, id2, id3 := resp.NewIDs["1"], resp.NewIDs["2"], resp.NewIDs["3"]
+
+	as, err := vit.BuiltIn(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	getIDs := func(values map[string]any) []istructs.RecordID {
+		ids, err := indexes.GetRecordIDsByIndex(context.Background(), ws.WSID, it.QNameApp1_DocIndexed, as, values)
+		require.NoError(err)
+		return ids
+	}
+
+	t.Run("read by leading fields", func(t *testing.T) {
+		require.Equal([]istructs.RecordID{id2, id1}, getIDs(map[string]any{"Code": codeA}))
+		require.Equal([]istructs.RecordID{id1}, getIDs(map[string]any{"Code": codeA, "Num": int32(2)}))
+		require.Empty(getIDs(map[string]any{"Code": codeA, "Num": int32(3)}))
+	})
+
+	t.Run("not leading fields", func(t *testing.T) {
+		_, err := indexes.GetRecordIDsByIndex(context.Background(), ws.WSID, it.QNameApp1_DocIndexed, as, map[string]any{"Num": int32(1)})
+		require.ErrorIs(err, indexes.ErrIndexNotExist)
+	})
+
+	t.Run("index is updated on record update", func(t *testing.T) {
+		body := fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"Code":"%s","Num":2}}]}`, id2, codeB)
+		vit.PostWS(ws, "c.sys.CUD", body)
+
+		require.Equal([]istructs.RecordID{id1}, getIDs(map[string]any{"Code": codeA}))
+		require.Equal([]istructs.RecordID{id3, id2}, getIDs(map[string]any{"Code": codeB}))
+
+		// update of not indexed fields keeps the index
+		body = fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"Descr":"descr"}}]}`, id2)
+		vit.PostWS(ws, "c.sys.CUD", body)
+		require.Equal([]istructs.RecordID{id3, id2}, getIDs(map[string]any{"Code": codeB}))
+	})
+
+	t.Run("query cdocs by index", func(t *testing.T) {
+		where := url.QueryEscape(fmt.Sprintf(`{"Code":"%s"}`, codeB))
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/cdocs/%s?keys="sys.ID",Num&where=%s`, ws.WSID, it.QNameApp1_DocIndexed, where),
+			coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(fmt.Sprintf(`{"results":[{"Num":1,"sys.ID":%d},{"Num":2,"sys.ID":%d}]}`, id3, id2), resp.Body)
+	})
+
+	t.Run("backfill", func(t *testing.T) {
+		kb := as.ViewRecords().KeyBuilder(indexes.QNameViewIndexBackfills)
+		kb.PutInt32("Dummy", 1)
+		kb.PutQName(indexes.Field_Index, appdef.IndexQName(it.QNameApp1_DocIndexed, "ByCodeNum"))
+		isDone := func() bool {
+			value, err := as.ViewRecords().Get(ws.WSID, kb)
+			require.NoError(err)
+			return value.AsBool(indexes.Field_Done)
+		}
+
+		// records of the new workspace are indexed by its events, so nothing is scheduled to backfill
+		require.True(isDone())
+
+		// the workspace WLog is scanned again by the explicit call
+		vit.PostWS(ws, "c.sys.BackfillIndexes", "{}")
+		require.True(isDone())
+
+		// records are indexed already, so the index is not changed
+		require.Equal([]istructs.RecordID{id3, id2}, getIDs(map[string]any{"Code": codeB}))
+	})
+
+	t.Run("entries of previous values", func(t *testing.T) {
+		entries := func() (total int, byID2 int) {
+			kb := as.ViewRecords().KeyBuilder(indexes.QNameViewIndexes)
+			kb.PutQName(indexes.Field_Index, appdef.IndexQName(it.QNameApp1_DocIndexed, "ByCodeNum"))
+			err := as.ViewRecords().Read(context.Background(), ws.WSID, kb, func(_ istructs.IKey, value istructs.IValue) error {
+				total++
+				if value.AsRecordID(indexes.Field_ID) == id2 {
+					byID2++
+				}
+				return nil
+			})
+			require.NoError(err)
+			return total, byID2
+		}
+		totalBefore, byID2 := entries()
+		require.Equal(1, byID2)
+
+		// id2 gets the initial values back -> the entry left with null ID on the first update is reused
+		body := fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"Code":"%s","Num":1}}]}`, id2, codeA)
+		vit.PostWS(ws, "c.sys.CUD", body)
+		require.Equal([]istructs.RecordID{id2, id1}, getIDs(map[string]any{"Code": codeA}))
+		require.Equal([]istructs.RecordID{id3}, getIDs(map[string]any{"Code": codeB}))
+
+		totalAfter, byID2 := entries()
+		require.Equal(totalBefore, totalAfter)
+		require.Equal(1, byID2)
+	})
+
+	t.Run("inactive records stay in the index", func(t *testing.T) {
+		body := fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"sys.IsActive":false}}]}`, id3)
+		vit.PostWS(ws, "c.sys.CUD", body)
+
+		// the caller checks sys.IsActive of the read records
+		require.Equal([]istructs.RecordID{id3}, getIDs(map[string]any{"Code": codeB}))
+		rec, err := as.Records().Get(ws.WSID, true, id3)
+		require.NoError(err)
+		require.False(rec.AsBool(appdef.SystemField_IsActive))
+	})
+}
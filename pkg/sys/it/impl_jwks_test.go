@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	it "github.com/voedger/voedger/pkg/vit"
+	sys_test_template "github.com/voedger/voedger/pkg/vit/testdata"
+	"github.com/voedger/voedger/pkg/vvm"
+)
+
+// tokens are signed by the asymmetric key and could be verified by the public key published at /.well-known/jwks.json
+func TestJWKS_BasicUsage(t *testing.T) {
+	require := require.New(t)
+	_, currentKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	_, nextKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1,
+			it.WithWorkspaceTemplate(it.QNameApp1_TestWSKind, "test_template", sys_test_template.TestTemplateFS),
+			it.WithUserLogin("login", "pwd"),
+			it.WithChildWorkspace(it.QNameApp1_TestWSKind, "test_ws", "test_template", "", "login", map[string]interface{}{"IntFld": 42}),
+		),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			cfg.JWTSigningKeys = []itokensjwt.SigningKey{
+				{KID: "current", Key: currentKey},
+				{KID: "next", Key: nextKey, ActiveFrom: cfg.Time.Now().Add(365 * 24 * time.Hour)},
+			}
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	resp := vit.POST(".well-known/jwks.json", "", coreutils.WithMethod(http.MethodGet))
+	require.Contains(resp.HTTPResp.Header.Get(coreutils.ContentType), coreutils.ContentType_ApplicationJSON)
+	jwks := itokens.JWKSet{}
+	require.NoError(json.Unmarshal([]byte(resp.Body), &jwks))
+	require.Len(jwks.Keys, 2)
+	publicKeys := map[string]ed25519.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		require.Equal("OKP", jwk.Kty)
+		require.Equal("EdDSA", jwk.Alg)
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		require.NoError(err)
+		publicKeys[jwk.Kid] = x
+	}
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	// verify the token as an external service does
+	token, err := jwt.Parse(ws.Owner.Token, func(token *jwt.Token) (interface{}, error) {
+		require.Equal("current", token.Header["kid"])
+		return publicKeys[token.Header["kid"].(string)], nil
+	}, jwt.WithValidMethods([]string{"EdDSA"}), jwt.WithTimeFunc(vit.Now))
+	require.NoError(err)
+	require.True(token.Valid)
+
+	// the token is accepted by the VVM
+	vit.PostWS(ws, "q.sys.Echo", `{"args":{"Text":"hello"}}`)
+}
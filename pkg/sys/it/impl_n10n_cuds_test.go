@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/n10ncuds"
+	"github.com/voedger/voedger/pkg/router"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestBasicUsage_n10n_CUDs(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	subscribe := func(token string, payload string) (data chan router.N10nData, closeConn func()) {
+		body := fmt.Sprintf(`{"subscriptions": [{"entity":"app1pkg.CategoryIdx","wsid": %d}], "payload": "%s"}`, ws.WSID, payload)
+		resp := vit.POST("api/v2/apps/test1/app1/notifications", body,
+			coreutils.WithAuthorizeBy(token),
+			coreutils.WithLongPolling(),
+		)
+		data = make(chan router.N10nData)
+		go readN10nData(resp.HTTPResp.Body, data)
+		return data, func() {
+			resp.HTTPResp.Body.Close()
+			for range data {
+			}
+		}
+	}
+
+	// the owner is allowed to select category and TestCDocWithDeniedFields.Fld1 only
+	ownerData, closeOwner := subscribe(ws.Owner.Token, router.N10nPayload_Fields)
+	defer closeOwner()
+
+	// the foreign login has no roles in the workspace
+	otherLogin := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+	otherPrn := vit.SignIn(otherLogin)
+	otherData, closeOther := subscribe(otherPrn.Token, router.N10nPayload_CUDs)
+	defer closeOther()
+
+	// the previous notification of the subscribers
+	primeOffset := vit.PostWS(ws, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Prime food"}}]}`).CurrentWLogOffset
+	waitForN10nData(t, primeOffset, ownerData)
+	waitForN10nData(t, primeOffset, otherData)
+
+	// CategoryIdx is not updated by the event, so its CUDs are skipped
+	vit.PostWS(ws, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.TestCDocWithDeniedFields","Fld1":41,"DeniedFld2":43}}]}`)
+	resp := vit.PostWS(ws, "c.sys.CUD", `{"cuds":[
+		{"fields":{"sys.ID":1,"sys.QName":"app1pkg.TestCDocWithDeniedFields","Fld1":42,"DeniedFld2":43}},
+		{"fields":{"sys.ID":2,"sys.QName":"app1pkg.category","name":"Awesome food"}}
+	]}`)
+	offset := resp.CurrentWLogOffset
+
+	t.Run("changed fields since the previous notification are filtered by the view and ACL", func(t *testing.T) {
+		data := waitForN10nData(t, offset, ownerData)
+		require.Len(data.CUDs, 2)
+		require.False(data.Truncated)
+
+		cuds := map[string]n10ncuds.CUD{}
+		for _, cud := range data.CUDs {
+			cuds[cud.QName] = cud
+		}
+		require.Equal(resp.NewIDs["1"], cuds["app1pkg.TestCDocWithDeniedFields"].ID)
+		require.Equal(map[string]interface{}{"Fld1": float64(42)}, cuds["app1pkg.TestCDocWithDeniedFields"].Fields)
+
+		require.Equal(resp.NewIDs["2"], cuds["app1pkg.category"].ID)
+		require.True(cuds["app1pkg.category"].IsNew)
+		require.Equal("Awesome food", cuds["app1pkg.category"].Fields["name"])
+	})
+
+	t.Run("records are filtered by ACL", func(t *testing.T) {
+		data := waitForN10nData(t, offset, otherData)
+		require.Empty(data.CUDs)
+	})
+
+	t.Run("CUDs without fields", func(t *testing.T) {
+		cudsData, closeCUDs := subscribe(ws.Owner.Token, router.N10nPayload_CUDs)
+		defer closeCUDs()
+
+		// CategoryIdx is updated on category insert only
+		body := fmt.Sprintf(`{"cuds":[
+			{"sys.ID":%d,"fields":{"name":"Even more awesome food"}},
+			{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Another food"}}
+		]}`, resp.NewIDs["2"])
+		cudResp := vit.PostWS(ws, "c.sys.CUD", body)
+		data := waitForN10nData(t, cudResp.CurrentWLogOffset, cudsData)
+		require.Len(data.CUDs, 2)
+		for _, cud := range data.CUDs {
+			require.Equal(cud.ID == cudResp.NewIDs["1"], cud.IsNew)
+			require.Nil(cud.Fields)
+		}
+	})
+
+	t.Run("400 on unknown payload", func(t *testing.T) {
+		body := fmt.Sprintf(`{"subscriptions": [{"entity":"app1pkg.CategoryIdx","wsid": %d}], "payload": "unknown"}`, ws.WSID)
+		vit.POST("api/v2/apps/test1/app1/notifications", body,
+			coreutils.WithAuthorizeBy(ws.Owner.Token),
+			coreutils.Expect400(),
+		)
+	})
+}
+
+// reads data of notifications until the stream is closed
+func readN10nData(body io.Reader, data chan router.N10nData) {
+	defer close(data)
+	scanner := bufio.NewScanner(body)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: ") && event != "channelId":
+			d := router.N10nData{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &d); err != nil {
+				panic(err)
+			}
+			data <- d
+		}
+	}
+}
+
+func waitForN10nData(t *testing.T, offset istructs.Offset, data chan router.N10nData) router.N10nData {
+	for d := range data {
+		if d.Offset == offset {
+			return d
+		}
+	}
+	t.Fatal("notification is not received for offset", offset)
+	return router.N10nData{}
+}
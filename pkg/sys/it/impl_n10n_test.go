@@ -456,3 +456,92 @@ func TestN10NSubscribeToExtraView(t *testing.T) {
 	}
 	waitForDone()
 }
+
+func TestN10NResumeByLastEventID(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	// owning does not matter for notifications, need just a valid token
+	token := ws.Owner.Token
+
+	body := fmt.Sprintf(`{"subscriptions": [{"entity":"app1pkg.CategoryIdx","wsid": %d}]}`, ws.WSID)
+	resp := vit.POST("api/v2/apps/test1/app1/notifications", body,
+		coreutils.WithAuthorizeBy(token),
+		coreutils.WithLongPolling(),
+	)
+	offsetsChan, channelID, waitForDone := federation.ListenSSEEvents(resp.HTTPResp.Request.Context(), resp.HTTPResp.Body)
+
+	cudBody := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Awesome food"}}]}`
+	waitForOffset(t, vit.PostWS(ws, "c.sys.CUD", cudBody).CurrentWLogOffset, offsetsChan)
+
+	// disconnect
+	resp.HTTPResp.Body.Close()
+	for range offsetsChan {
+	}
+	waitForDone()
+
+	// update during the disconnect
+	offsetDuringDisconnect := vit.PostWS(ws, "c.sys.CUD", cudBody).CurrentWLogOffset
+
+	// resume: the channel keeps its subscriptions, the latest offset is delivered
+	lastEventID := fmt.Sprintf("%s:0", channelID)
+	for {
+		resp = vit.POST("api/v2/apps/test1/app1/notifications", "",
+			coreutils.WithAuthorizeBy(token),
+			coreutils.WithHeaders(coreutils.LastEventID, lastEventID),
+			coreutils.WithLongPolling(),
+			coreutils.WithExpectedCode(http.StatusOK),
+			coreutils.WithExpectedCode(http.StatusConflict),
+		)
+		if resp.HTTPResp.StatusCode == http.StatusOK {
+			break
+		}
+		// the server has not noticed the disconnect yet
+		time.Sleep(10 * time.Millisecond)
+	}
+	offsetsChan, resumedChannelID, waitForDone := federation.ListenSSEEvents(resp.HTTPResp.Request.Context(), resp.HTTPResp.Body)
+	require.Equal(channelID, resumedChannelID)
+	waitForOffset(t, offsetDuringDisconnect, offsetsChan)
+
+	t.Run("409 on the channel is watched", func(t *testing.T) {
+		vit.POST("api/v2/apps/test1/app1/notifications", "",
+			coreutils.WithAuthorizeBy(token),
+			coreutils.WithHeaders(coreutils.LastEventID, lastEventID),
+			coreutils.Expect409(),
+		)
+	})
+
+	resp.HTTPResp.Body.Close()
+	for range offsetsChan {
+	}
+	waitForDone()
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("400 on malformed Last-Event-ID", func(t *testing.T) {
+			vit.POST("api/v2/apps/test1/app1/notifications", "",
+				coreutils.WithAuthorizeBy(token),
+				coreutils.WithHeaders(coreutils.LastEventID, "malformed"),
+				coreutils.Expect400(),
+			)
+		})
+		t.Run("404 on unknown channel", func(t *testing.T) {
+			vit.POST("api/v2/apps/test1/app1/notifications", "",
+				coreutils.WithAuthorizeBy(token),
+				coreutils.WithHeaders(coreutils.LastEventID, "unknown:1"),
+				coreutils.Expect404(),
+			)
+		})
+		t.Run("404 on the channel of another subject", func(t *testing.T) {
+			otherLogin := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+			otherPrn := vit.SignIn(otherLogin)
+			vit.POST("api/v2/apps/test1/app1/notifications", "",
+				coreutils.WithAuthorizeBy(otherPrn.Token),
+				coreutils.WithHeaders(coreutils.LastEventID, lastEventID),
+				coreutils.Expect404(),
+			)
+		})
+	})
+}
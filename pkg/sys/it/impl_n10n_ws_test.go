@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/router"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestBasicUsage_n10n_WebSocket(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	// owning does not matter for notifications, need just a valid token
+	conn := dialN10nWebSocket(t, vit, ws.Owner.Token)
+	defer conn.Close()
+
+	msg := receiveWSMessage(t, conn)
+	require.Equal(router.WSMessage_ChannelID, msg.Type)
+	require.NotEmpty(msg.ChannelID)
+
+	// subscribe
+	sendWSMessage(t, conn, router.WSClientMessage{
+		Type: router.WSMessage_Subscribe,
+		ID:   "1",
+		Subscriptions: []router.SubscriptionJSON{
+			{Entity: "app1pkg.CategoryIdx", WSIDNumber: jsonNumber(ws.WSID)},
+			{Entity: "app1pkg.DailyIdx", WSIDNumber: jsonNumber(ws.WSID)},
+		},
+	})
+	require.Equal(router.WSServerMessage{Type: router.WSMessage_OK, ID: "1"}, receiveWSMessage(t, conn))
+
+	// force projections update
+	body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Awesome food"}}]}`
+	resultOffsetOfCategoryCUD := vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+	waitForWSUpdate(t, conn, "app1pkg.CategoryIdx", ws.WSID, resultOffsetOfCategoryCUD)
+
+	t.Run("ping", func(t *testing.T) {
+		sendWSMessage(t, conn, router.WSClientMessage{Type: router.WSMessage_Ping, ID: "ping"})
+		require.Equal(router.WSServerMessage{Type: router.WSMessage_Pong, ID: "ping"}, receiveWSMessage(t, conn))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("heartbeats are replaced by pings", func(t *testing.T) {
+			sendWSMessage(t, conn, router.WSClientMessage{
+				Type:          router.WSMessage_Subscribe,
+				ID:            "hb",
+				Subscriptions: []router.SubscriptionJSON{{Entity: "sys.Heartbeat30", WSIDNumber: "0"}},
+			})
+			msg := receiveWSMessage(t, conn)
+			require.Equal(router.WSMessage_Error, msg.Type)
+			require.Equal("hb", msg.ID)
+			require.Equal(http.StatusBadRequest, msg.Status)
+		})
+		t.Run("no subscriptions", func(t *testing.T) {
+			sendWSMessage(t, conn, router.WSClientMessage{Type: router.WSMessage_Subscribe, ID: "2"})
+			msg := receiveWSMessage(t, conn)
+			require.Equal(router.WSMessage_Error, msg.Type)
+			require.Equal(http.StatusBadRequest, msg.Status)
+			require.Contains(msg.Message, "no subscriptions provided")
+		})
+		t.Run("malformed entity", func(t *testing.T) {
+			sendWSMessage(t, conn, router.WSClientMessage{
+				Type:          router.WSMessage_Unsubscribe,
+				ID:            "3",
+				Subscriptions: []router.SubscriptionJSON{{Entity: "malformed", WSIDNumber: jsonNumber(ws.WSID)}},
+			})
+			msg := receiveWSMessage(t, conn)
+			require.Equal(router.WSMessage_Error, msg.Type)
+			require.Equal(http.StatusBadRequest, msg.Status)
+		})
+		t.Run("unknown message type", func(t *testing.T) {
+			sendWSMessage(t, conn, router.WSClientMessage{Type: "unknown", ID: "4"})
+			msg := receiveWSMessage(t, conn)
+			require.Equal(router.WSMessage_Error, msg.Type)
+			require.Equal("4", msg.ID)
+			require.Equal(http.StatusBadRequest, msg.Status)
+		})
+		t.Run("malformed message", func(t *testing.T) {
+			require.NoError(websocket.Message.Send(conn, `{"unknownField":1}`))
+			msg := receiveWSMessage(t, conn)
+			require.Equal(router.WSMessage_Error, msg.Type)
+			require.Equal(http.StatusBadRequest, msg.Status)
+		})
+		t.Run("403 on the foreign origin", func(t *testing.T) {
+			cfg := n10nWebSocketConfig(t, vit, ws.Owner.Token)
+			origin, err := url.Parse("https://foreign.example.com")
+			require.NoError(err)
+			cfg.Origin = origin
+			_, err = websocket.DialConfig(cfg)
+			require.ErrorContains(err, websocket.ErrBadStatus.Error())
+		})
+		t.Run("401 on no token", func(t *testing.T) {
+			_, err := websocket.DialConfig(n10nWebSocketConfig(t, vit, ""))
+			require.ErrorContains(err, websocket.ErrBadStatus.Error())
+			vit.POST("api/v2/apps/test1/app1/notifications/websocket", "",
+				coreutils.WithMethod(http.MethodGet),
+				coreutils.Expect401(),
+			)
+		})
+	})
+
+	// unsubscribe
+	sendWSMessage(t, conn, router.WSClientMessage{
+		Type:          router.WSMessage_Unsubscribe,
+		ID:            "5",
+		Subscriptions: []router.SubscriptionJSON{{Entity: "app1pkg.CategoryIdx", WSIDNumber: jsonNumber(ws.WSID)}},
+	})
+	require.Equal(router.WSServerMessage{Type: router.WSMessage_OK, ID: "5"}, receiveWSMessage(t, conn))
+
+	// only the remaining subscription is notified
+	vit.PostWS(ws, "c.sys.CUD", body)
+	body = `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.Daily","Year":42}}]}`
+	resultOffsetOfDailyCUD := vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+	for {
+		msg := receiveWSMessage(t, conn)
+		require.Equal(router.WSMessage_Update, msg.Type)
+		require.Equal("app1pkg.DailyIdx", msg.Entity)
+		if msg.Offset == resultOffsetOfDailyCUD {
+			break
+		}
+	}
+}
+
+func n10nWebSocketConfig(t *testing.T, vit *it.VIT, token string) *websocket.Config {
+	baseURL := vit.IFederation.URLStr()
+	wsURL := "ws" + strings.TrimPrefix(baseURL, "http") + "/api/v2/apps/test1/app1/notifications/websocket"
+	cfg, err := websocket.NewConfig(wsURL, baseURL)
+	require.NoError(t, err)
+	if len(token) > 0 {
+		cfg.Header.Set("Authorization", "Bearer "+token)
+	}
+	return cfg
+}
+
+func dialN10nWebSocket(t *testing.T, vit *it.VIT, token string) *websocket.Conn {
+	conn, err := websocket.DialConfig(n10nWebSocketConfig(t, vit, token))
+	require.NoError(t, err)
+	return conn
+}
+
+func sendWSMessage(t *testing.T, conn *websocket.Conn, msg router.WSClientMessage) {
+	require.NoError(t, websocket.JSON.Send(conn, msg))
+}
+
+func receiveWSMessage(t *testing.T, conn *websocket.Conn) (msg router.WSServerMessage) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, websocket.JSON.Receive(conn, &msg))
+	return msg
+}
+
+func waitForWSUpdate(t *testing.T, conn *websocket.Conn, entity string, wsid istructs.WSID, offset istructs.Offset) {
+	for {
+		msg := receiveWSMessage(t, conn)
+		require.Equal(t, router.WSMessage_Update, msg.Type, msg.Message)
+		if msg.Entity == entity && msg.WSID == wsid && msg.Offset == offset {
+			return
+		}
+	}
+}
+
+func jsonNumber(wsid istructs.WSID) json.Number {
+	return json.Number(fmt.Sprint(wsid))
+}
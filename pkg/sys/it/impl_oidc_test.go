@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/oidc"
+	it "github.com/voedger/voedger/pkg/vit"
+	"github.com/voedger/voedger/pkg/vvm"
+)
+
+// the user is authenticated by the identity provider, the login and the profile are created on the first sign in
+func TestOIDC_BasicUsage(t *testing.T) {
+	require := require.New(t)
+	idp := oidc.NewMockIdP(testingu.MockTime)
+	defer idp.Close()
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			cfg.OIDCProviders = []oidc.ProviderConfig{idp.Config("mock")}
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	loginURL := vit.URLStr() + "/api/v2/apps/test1/app1/auth/oidc/mock"
+	subject := vit.NextName()
+
+	var profileWSID istructs.WSID
+	t.Run("first sign in creates the login and the profile", func(t *testing.T) {
+		resp := oidcSignIn(t, loginURL, subject)
+		defer resp.Body.Close()
+		require.Equal(http.StatusOK, resp.StatusCode)
+		result := map[string]interface{}{}
+		require.NoError(json.NewDecoder(resp.Body).Decode(&result))
+		profileWSID = istructs.WSID(result["profileWSID"].(float64))
+		require.NotZero(profileWSID)
+		require.NotZero(result["expiresInSeconds"])
+
+		// the token is the standard principal token
+		token := result["principalToken"].(string)
+		require.NotEmpty(token)
+		vit.PostApp(istructs.AppQName_test1_app1, profileWSID, "q.sys.Echo", `{"args":{"Text":"hello"}}`, coreutils.WithAuthorizeBy(token))
+	})
+
+	t.Run("next sign in is mapped to the same login", func(t *testing.T) {
+		resp := oidcSignIn(t, loginURL, subject)
+		defer resp.Body.Close()
+		require.Equal(http.StatusOK, resp.StatusCode)
+		result := map[string]interface{}{}
+		require.NoError(json.NewDecoder(resp.Body).Decode(&result))
+		require.Equal(profileWSID, istructs.WSID(result["profileWSID"].(float64)))
+	})
+
+	t.Run("login of the external subject could not sign in by password", func(t *testing.T) {
+		body := fmt.Sprintf(`{"login":%q,"password":"pwd"}`, oidc.Login("mock", subject))
+		vit.POST("api/v2/apps/test1/app1/auth/login", body, coreutils.Expect401())
+	})
+
+	t.Run("registry functions are not available for anonymous", func(t *testing.T) {
+		login := oidc.Login("mock", vit.NextName())
+		pseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.CurrentClusterID())
+		body := fmt.Sprintf(`{"args":{"Login":%q,"AppName":"test1/app1","WSKindInitializationData":"{}","ProfileCluster":1}}`, login)
+		vit.Func(fmt.Sprintf("api/v2/apps/sys/registry/workspaces/%d/commands/registry.CreateOIDCLogin", pseudoWSID), body,
+			coreutils.WithMethod(http.MethodPost), coreutils.Expect403())
+	})
+}
+
+func TestOIDC_Errors(t *testing.T) {
+	require := require.New(t)
+	idp := oidc.NewMockIdP(testingu.MockTime)
+	defer idp.Close()
+
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			cfg.OIDCProviders = []oidc.ProviderConfig{idp.Config("mock")}
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	client := noRedirectHTTPClient()
+	loginURL := vit.URLStr() + "/api/v2/apps/test1/app1/auth/oidc/mock"
+
+	t.Run("unknown provider", func(t *testing.T) {
+		resp, err := client.Get(vit.URLStr() + "/api/v2/apps/test1/app1/auth/oidc/unknown")
+		require.NoError(err)
+		defer resp.Body.Close()
+		require.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	// code and state are obtained, the state cookie is returned as well
+	begin := func() (callbackURL string, cookie *http.Cookie) {
+		resp, err := client.Get(loginURL)
+		require.NoError(err)
+		resp.Body.Close()
+		require.Equal(http.StatusFound, resp.StatusCode)
+		require.Len(resp.Cookies(), 1)
+		cookie = resp.Cookies()[0]
+		require.True(cookie.HttpOnly)
+
+		resp, err = client.Get(resp.Header.Get("Location") + "&login_hint=" + vit.NextName())
+		require.NoError(err)
+		resp.Body.Close()
+		require.Equal(http.StatusFound, resp.StatusCode)
+		return resp.Header.Get("Location"), cookie
+	}
+	callback := func(callbackURL string, cookie *http.Cookie) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, callbackURL, nil)
+		require.NoError(err)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		resp, err := client.Do(req)
+		require.NoError(err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return resp
+	}
+
+	t.Run("no state cookie", func(t *testing.T) {
+		callbackURL, _ := begin()
+		require.Equal(http.StatusUnauthorized, callback(callbackURL, nil).StatusCode)
+	})
+
+	t.Run("state mismatch", func(t *testing.T) {
+		callbackURL, cookie := begin()
+		u, err := url.Parse(callbackURL)
+		require.NoError(err)
+		q := u.Query()
+		q.Set("state", "wrong")
+		u.RawQuery = q.Encode()
+		require.Equal(http.StatusUnauthorized, callback(u.String(), cookie).StatusCode)
+	})
+
+	t.Run("state cookie of the other flow", func(t *testing.T) {
+		callbackURL, _ := begin()
+		_, otherCookie := begin()
+		require.Equal(http.StatusUnauthorized, callback(callbackURL, otherCookie).StatusCode)
+	})
+
+	t.Run("identity provider error", func(t *testing.T) {
+		_, cookie := begin()
+		resp := callback(loginURL+"/callback?error=access_denied", cookie)
+		require.Equal(http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("state cookie is secure behind the TLS terminating proxy", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, loginURL, nil)
+		require.NoError(err)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		resp, err := client.Do(req)
+		require.NoError(err)
+		resp.Body.Close()
+		require.Len(resp.Cookies(), 1)
+		require.True(resp.Cookies()[0].Secure)
+		location, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(err)
+		require.True(strings.HasPrefix(location.Query().Get("redirect_uri"), "https://"))
+	})
+
+	t.Run("code could be used once only", func(t *testing.T) {
+		callbackURL, cookie := begin()
+		require.Equal(http.StatusOK, callback(callbackURL, cookie).StatusCode)
+		require.Equal(http.StatusBadGateway, callback(callbackURL, cookie).StatusCode)
+	})
+}
+
+func TestOIDC_SecondFactor(t *testing.T) {
+	require := require.New(t)
+	idp := oidc.NewMockIdP(testingu.MockTime)
+	defer idp.Close()
+
+	adminRole := appdef.NewQName("app1pkg", "LimitedAccessRole")
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			cfg.OIDCProviders = []oidc.ProviderConfig{idp.Config("mock")}
+			cfg.SecondFactorRequiredForGlobalRoles = map[appdef.AppQName][]appdef.QName{
+				istructs.AppQName_test1_app1: {adminRole},
+			}
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	loginURL := vit.URLStr() + "/api/v2/apps/test1/app1/auth/oidc/mock"
+	subject := vit.NextName()
+	resp := oidcSignIn(t, loginURL, subject)
+	resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	login := oidc.Login("mock", subject)
+	pseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.CurrentClusterID())
+	sysRegistryToken := vit.GetSystemPrincipal(istructs.AppQName_sys_registry).Token
+	body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","GlobalRoles":"%s"}}`, login, istructs.AppQName_test1_app1, adminRole)
+	vit.PostApp(istructs.AppQName_sys_registry, pseudoWSID, "c.registry.UpdateGlobalRoles", body, coreutils.WithAuthorizeBy(sysRegistryToken))
+
+	// the second factor is checked the same as on sign in by password
+	resp = oidcSignIn(t, loginURL+"?totpCode=000000", subject)
+	resp.Body.Close()
+	require.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+// follows the redirects as the user agent does
+func oidcSignIn(t *testing.T, loginURL string, subject string) *http.Response {
+	client := noRedirectHTTPClient()
+	resp, err := client.Get(loginURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	cookies := resp.Cookies()
+
+	// the mock identity provider authenticates the subject from login_hint
+	resp, err = client.Get(resp.Header.Get("Location") + "&login_hint=" + url.QueryEscape(subject))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, resp.Header.Get("Location"), nil)
+	require.NoError(t, err)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func noRedirectHTTPClient() *http.Client {
+	return &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+}
@@ -217,6 +217,60 @@ func TestQueryProcessor2_Views(t *testing.T) {
 			{"Day":2,"Month":1,"Year":2023}
 		]}`, resp.Body)
 	})
+	t.Run("Read by PK and CC range", func(t *testing.T) {
+		where := url.QueryEscape(`{"Year":2023,"Month":{"$gte":3},"Day":{"$gt":3}}`)
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where=%s&keys=Year,Month,Day`, ws.WSID, it.QNameApp1_ViewDailyIdx, where), coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(`{"results":[
+			{"Day":4,"Month":3,"Year":2023},
+			{"Day":5,"Month":3,"Year":2023},
+			{"Day":4,"Month":4,"Year":2023},
+			{"Day":5,"Month":4,"Year":2023}
+		]}`, resp.Body)
+	})
+	t.Run("Read by PK, CC eq and next CC range", func(t *testing.T) {
+		where := url.QueryEscape(`{"Year":{"$in":[2021,2022]},"Month":2,"Day":{"$gte":3,"$lt":5}}`)
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where=%s&keys=Year,Month,Day`, ws.WSID, it.QNameApp1_ViewDailyIdx, where), coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(`{"results":[
+			{"Day":3,"Month":2,"Year":2021},
+			{"Day":4,"Month":2,"Year":2021},
+			{"Day":3,"Month":2,"Year":2022},
+			{"Day":4,"Month":2,"Year":2022}
+		]}`, resp.Body)
+	})
+	t.Run("Read with logical operators", func(t *testing.T) {
+		where := url.QueryEscape(`{"Year":2022,"$or":[{"Month":1},{"StringValue":{"$like":"%-04-05"}}],"Day":{"$nin":[2,3]},"StringValue":{"$exists":true}}`)
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where=%s&keys=StringValue`, ws.WSID, it.QNameApp1_ViewDailyIdx, where), coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(`{"results":[
+			{"StringValue":"2022-01-04"},
+			{"StringValue":"2022-01-05"},
+			{"StringValue":"2022-04-05"}
+		]}`, resp.Body)
+	})
+	t.Run("Filter is applied before skip and limit", func(t *testing.T) {
+		where := url.QueryEscape(`{"Year":2023,"Month":{"$ne":1}}`)
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where=%s&keys=StringValue&skip=1&limit=2`, ws.WSID, it.QNameApp1_ViewDailyIdx, where), coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(`{"results":[
+			{"StringValue":"2023-02-03"},
+			{"StringValue":"2023-02-04"}
+		]}`, resp.Body)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		for where, expected := range map[string]string{
+			`{"Year":{"$gt":2022}}`:           "where constraint must specify the partition key: field 'Year' must be constrained by $eq or $in",
+			`{"Year":2022,"Month":{"$re":1}}`: "unsupported constraint: '$re' for field 'Month'",
+			`{"Year":2022,"Unknown":1}`:       "unexpected field: 'Unknown'",
+			`{"Year":"2022"}`:                 "unexpected params: string value for int32 field 'Year'",
+		} {
+			resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where=%s`, ws.WSID, it.QNameApp1_ViewDailyIdx, url.QueryEscape(where)),
+				coreutils.WithAuthorizeBy(ws.Owner.Token), coreutils.Expect400())
+			require.NoError(err)
+			require.JSONEq(fmt.Sprintf(`{"results":[],"error":{"status":400,"message":%q}}`, expected), resp.Body, where)
+		}
+	})
 	t.Run("ACL test", func(t *testing.T) {
 		newLoginName := vit.NextName()
 		newLogin := vit.SignUp(newLoginName, "1", istructs.AppQName_test1_app1)
@@ -286,6 +340,19 @@ func TestQueryProcessor2_Queries(t *testing.T) {
 				{"Day":5,"Month":4,"StringValue":"2023-04-05","Year":2023,"sys.Container":"","sys.QName":"app1pkg.QryDailyIdxResult"}
 			]}`, resp.Body)
 	})
+	t.Run("QryDailyIdx with arg and logical operators filter", func(t *testing.T) {
+		where := url.QueryEscape(`{"$or":[{"Month":{"$lt":2}},{"Day":{"$gte":5}}],"Month":{"$lte":3}}`)
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/queries/app1pkg.QryDailyIdx?args={"Year":2023}&where=%s&keys=StringValue`, ws.WSID, where), coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(`{"results":[
+				{"StringValue":"2023-01-02"},
+				{"StringValue":"2023-01-03"},
+				{"StringValue":"2023-01-04"},
+				{"StringValue":"2023-01-05"},
+				{"StringValue":"2023-02-05"},
+				{"StringValue":"2023-03-05"}
+			]}`, resp.Body)
+	})
 	t.Run("QryDailyIdx with order desc", func(t *testing.T) {
 		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/queries/app1pkg.QryDailyIdx?args={"Year":2023}&order=-Month`, ws.WSID), coreutils.WithAuthorizeBy(ws.Owner.Token))
 		require.NoError(err)
@@ -2423,6 +2490,17 @@ func TestQueryProcessor2_Docs(t *testing.T) {
 		require.JSONEq(fmt.Sprintf(`{"name":"Awesome food", "sys.ID":%d, "sys.IsActive":true, "sys.QName":"app1pkg.category"}`, ids["1"]), resp.Body)
 	})
 
+	t.Run("read document with where", func(t *testing.T) {
+		path := fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/docs/%s/%d?where=%s`, ws.WSID, it.QNameApp1_CDocCategory, ids["1"], url.QueryEscape(`{"name":{"$like":"Awesome%"}}`))
+		resp, err := vit.IFederation.Query(path, coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(fmt.Sprintf(`{"name":"Awesome food", "sys.ID":%d, "sys.IsActive":true, "sys.QName":"app1pkg.category"}`, ids["1"]), resp.Body)
+
+		path = fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/docs/%s/%d?where=%s`, ws.WSID, it.QNameApp1_CDocCategory, ids["1"], url.QueryEscape(`{"sys.IsActive":false}`))
+		resp, _ = vit.IFederation.Query(path, coreutils.WithAuthorizeBy(ws.Owner.Token), coreutils.Expect404())
+		require.JSONEq(fmt.Sprintf(`{"status":404,"message":"app1pkg.category with ID %d does not match where constraint"}`, ids["1"]), resp.Body)
+	})
+
 	t.Run("odocs", func(t *testing.T) {
 		body := `{"args":{"sys.ID": 1,"odocIntFld":42, "orecord1":[{"sys.ID":2,"sys.ParentID":1,"orecord1IntFld":43}]}}`
 		resp := vit.PostWS(ws, "c.app1pkg.CmdODocOne", body)
@@ -2534,6 +2612,15 @@ func TestQueryProcessor2_CDocs(t *testing.T) {
 				{"Day":3,"Month":3,"Year":2022,"sys.ID":%[10]d}
 		]}`, ids["35"], ids["34"], ids["33"], ids["20"], ids["19"], ids["18"], ids["38"], ids["39"], ids["16"], ids["14"]), resp.Body)
 	})
+	t.Run("Read documents and use where constraint", func(t *testing.T) {
+		where := url.QueryEscape(`{"Year":{"$gte":2024},"Month":{"$ne":2}}`)
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/cdocs/%s?keys="sys.ID",Year,Month,Day&where=%s&order=Year`, ws.WSID, it.QNameApp1_CDocDaily, where), coreutils.WithAuthorizeBy(ws.Owner.Token))
+		require.NoError(err)
+		require.JSONEq(fmt.Sprintf(`{"results":[
+				{"Day":1,"Month":1,"Year":2024,"sys.ID":%[1]d},
+				{"Day":1,"Month":1,"Year":2025,"sys.ID":%[2]d}
+		]}`, ids["4"], ids["2"]), resp.Body)
+	})
 }
 
 // [~server.authnz/it.TestLogin~impl]
@@ -5,12 +5,25 @@
 package sys_it
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/iratesstg"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
 	it "github.com/voedger/voedger/pkg/vit"
+	sys_test_template "github.com/voedger/voedger/pkg/vit/testdata"
+	"github.com/voedger/voedger/pkg/vvm"
+	"github.com/voedger/voedger/pkg/vvm/storage"
 )
 
 func TestRates_BasicUsage(t *testing.T) {
@@ -60,3 +73,143 @@ func TestRates_BasicUsage(t *testing.T) {
 		vit.PostWS(ws, "c.app1pkg.RatedCmd", bodyCmd)
 	}
 }
+
+func TestRates_Headers(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	// restore rates spent by other tests
+	vit.TimeAdd(time.Hour)
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	bodyCmd := `{"args":{}}`
+	for i := 0; i < 2; i++ {
+		vit.PostWS(ws, "c.app1pkg.RatedCmd", bodyCmd)
+	}
+
+	t.Run("rate limit states", func(t *testing.T) {
+		body := `{"args":{},"elements":[{"fields":["RateLimit","Function","Period","MaxTokens","Remaining","ResetAfter"]}]}`
+		resp := vit.PostWS(ws, "q.sys.RateLimits", body)
+		states := map[string][]interface{}{}
+		for _, row := range resp.Sections[0].Elements {
+			states[row[0][0][0].(string)] = row[0][0]
+		}
+		// 2 per minute, 2 calls are made
+		require.Equal([]interface{}{"app1pkg.func_RatedCmd_byApp", "app1pkg.RatedCmd", float64(60), float64(2), float64(0), float64(60)},
+			states["app1pkg.func_RatedCmd_byApp"])
+		// 4 per hour, 2 calls are made
+		require.Equal([]interface{}{"app1pkg.func_RatedCmd_byWS", "app1pkg.RatedCmd", float64(3600), float64(4), float64(2), float64(1800)},
+			states["app1pkg.func_RatedCmd_byWS"])
+		// the query is not called yet
+		require.Equal(float64(2), states["app1pkg.func_RatedQry_byApp"][4])
+	})
+
+	t.Run("headers on exceeded rate limit", func(t *testing.T) {
+		resp := vit.PostWS(ws, "c.app1pkg.RatedCmd", bodyCmd, coreutils.Expect429())
+		require.Equal("2", resp.HTTPResp.Header.Get(coreutils.RateLimitLimit))
+		require.Equal("0", resp.HTTPResp.Header.Get(coreutils.RateLimitRemaining))
+		require.Equal("60", resp.HTTPResp.Header.Get(coreutils.RateLimitReset))
+		require.Equal("30", resp.HTTPResp.Header.Get(coreutils.RetryAfter))
+	})
+
+	t.Run("headers on exceeded rate limit in API v2", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/queries/app1pkg.RatedQry`, ws.WSID),
+				coreutils.WithAuthorizeBy(ws.Owner.Token))
+			require.NoError(err)
+		}
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/queries/app1pkg.RatedQry`, ws.WSID),
+			coreutils.WithAuthorizeBy(ws.Owner.Token), coreutils.Expect429())
+		require.NoError(err)
+		require.Equal("30", resp.HTTPResp.Header.Get(coreutils.RetryAfter))
+	})
+}
+
+func TestRates_VSQLLimits(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	// 2 per minute per workspace per IP
+	t.Run("command", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			vit.PostWS(ws, "c.app1pkg.IPRatedCmd", `{}`)
+		}
+		resp := vit.PostWS(ws, "c.app1pkg.IPRatedCmd", `{}`, coreutils.Expect429())
+		require.Equal("2", resp.HTTPResp.Header.Get(coreutils.RateLimitLimit))
+		require.Equal("0", resp.HTTPResp.Header.Get(coreutils.RateLimitRemaining))
+		require.Equal("60", resp.HTTPResp.Header.Get(coreutils.RateLimitReset))
+		require.Equal("30", resp.HTTPResp.Header.Get(coreutils.RetryAfter))
+	})
+
+	t.Run("query", func(t *testing.T) {
+		body := `{"args":{},"elements":[{"fields":["Fld"]}]}`
+		for i := 0; i < 2; i++ {
+			vit.PostWS(ws, "q.app1pkg.IPRatedQry", body)
+		}
+		resp := vit.PostWS(ws, "q.app1pkg.IPRatedQry", body, coreutils.Expect429())
+		require.Equal("30", resp.HTTPResp.Header.Get(coreutils.RetryAfter))
+
+		// API v2 requests are from the same IP
+		resp, err := vit.IFederation.Query(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/queries/app1pkg.IPRatedQry`, ws.WSID),
+			coreutils.WithAuthorizeBy(ws.Owner.Token), coreutils.Expect429())
+		require.NoError(err)
+		require.Equal("30", resp.HTTPResp.Header.Get(coreutils.RetryAfter))
+	})
+
+	t.Run("limits are restored", func(t *testing.T) {
+		vit.TimeAdd(time.Minute)
+		vit.PostWS(ws, "c.app1pkg.IPRatedCmd", `{}`)
+	})
+}
+
+// buckets are kept in the storage, so tokens taken on one VVM are taken on other VVMs of the cluster
+func TestRates_SharedBuckets(t *testing.T) {
+	require := require.New(t)
+	keyspaceSuffix := uuid.NewString()
+	var sharedStorageFactory istorage.IAppStorageFactory
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1,
+			it.WithWorkspaceTemplate(it.QNameApp1_TestWSKind, "test_template", sys_test_template.TestTemplateFS),
+			it.WithUserLogin("login", "pwd"),
+			it.WithChildWorkspace(it.QNameApp1_TestWSKind, "test_ws", "test_template", "", "login", map[string]interface{}{"IntFld": 42}),
+		),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			var err error
+			sharedStorageFactory, err = cfg.StorageFactory()
+			require.NoError(err)
+			cfg.StorageFactory = func() (provider istorage.IAppStorageFactory, err error) {
+				return sharedStorageFactory, nil
+			}
+			cfg.KeyspaceNameSuffix = keyspaceSuffix
+			cfg.SharedRateBuckets = true
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	bodyCmd := `{"args":{}}`
+
+	// buckets of the other VVM over the same storage
+	sysVVMStorage, err := provider.Provide(sharedStorageFactory, keyspaceSuffix).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+	otherVVMBuckets := iratesstg.Provide(storage.NewRateBucketsStorage(sysVVMStorage), testingu.MockTime)
+	perAppKey := irates.BucketKey{
+		RateLimitName: istructsmem.GetFunctionRateLimitName(it.QNameCmdRated, istructs.RateLimitKind_byApp),
+		App:           istructs.AppQName_test1_app1,
+		QName:         it.QNameCmdRated,
+	}
+	otherVVMBuckets.SetDefaultBucketState(perAppKey.RateLimitName, irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 2})
+
+	// 2 per minute: 1 call is made on the VVM, 1 on the other VVM
+	vit.PostWS(ws, "c.app1pkg.RatedCmd", bodyCmd)
+	ok, _ := otherVVMBuckets.TakeTokens([]irates.BucketKey{perAppKey}, 1)
+	require.True(ok)
+
+	vit.PostWS(ws, "c.app1pkg.RatedCmd", bodyCmd, coreutils.Expect429())
+	ok, _ = otherVVMBuckets.TakeTokens([]irates.BucketKey{perAppKey}, 1)
+	require.False(ok)
+}
@@ -22,7 +22,7 @@ func TestBasicUsage_ResetPassword(t *testing.T) {
 	defer vit.TearDown()
 	loginName := vit.NextName() + "@123.com"
 	login := vit.SignUp(loginName, "1", istructs.AppQName_test1_app1)
-	vit.SignIn(login)
+	prn := vit.SignIn(login)
 
 	profileWSID := istructs.WSID(0)
 	token, code := InitiateEmailVerificationFunc(vit, func() *coreutils.FuncResponse {
@@ -45,6 +45,9 @@ func TestBasicUsage_ResetPassword(t *testing.T) {
 	body = fmt.Sprintf(`{"args":{"AppName":"%s"},"unloggedArgs":{"Email":"%s","NewPwd":"%s"}}`, istructs.AppQName_test1_app1, verifiedValueToken, newPwd)
 	vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ResetPasswordByEmail", body) // null auth policy
 
+	// previous sessions are revoked
+	vit.PostProfile(prn, "q.sys.RefreshPrincipalToken", `{}`, coreutils.Expect401())
+
 	// expect no errors on login with new password
 	login.Pwd = newPwd
 	vit.SignIn(login)
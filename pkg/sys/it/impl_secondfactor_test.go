@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	it "github.com/voedger/voedger/pkg/vit"
+	"github.com/voedger/voedger/pkg/vvm"
+)
+
+func TestSecondFactor_TOTP(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	login := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+	vit.SignIn(login)
+
+	secret, enrollmentToken := initiateTOTPEnrollment(vit, login)
+
+	t.Run("enrollment is not confirmed by the wrong code", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"Password":"1","EnrollmentToken":"%s","NewTOTPCode":"000000"}}`,
+			login.Name, login.AppQName, enrollmentToken)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.EnableTOTP", body, coreutils.Expect400())
+	})
+
+	var recoveryCodes []string
+	t.Run("enroll", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"Password":"1","EnrollmentToken":"%s","NewTOTPCode":"%s"}}`,
+			login.Name, login.AppQName, enrollmentToken, coreutils.TOTPCode(secret, vit.Now()))
+		resp := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.EnableTOTP", body)
+		recoveryCodes = strings.Split(resp.CmdResult["RecoveryCodes"].(string), ",")
+		require.Len(recoveryCodes, 10)
+
+		// could not be enrolled twice
+		body = fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Password":"1"},"elements":[{"fields":["Secret"]}]}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.InitiateTOTPEnrollment", body, coreutils.Expect409())
+	})
+
+	t.Run("sign in requires the TOTP code", func(t *testing.T) {
+		// the code used to confirm the enrollment could not be used to sign in
+		signIn(vit, login, fmt.Sprintf(`"totpCode":"%s"`, coreutils.TOTPCode(secret, vit.Now())), coreutils.Expect401()).Println()
+
+		vit.TimeAdd(coreutils.TOTPPeriod)
+		signIn(vit, login, `"totpCode":""`, coreutils.Expect401()).Println()
+		signIn(vit, login, `"totpCode":"000000"`, coreutils.Expect401()).Println()
+		resp := signIn(vit, login, fmt.Sprintf(`"totpCode":"%s"`, coreutils.TOTPCode(secret, vit.Now())))
+		require.Contains(resp.Body, "principalToken")
+
+		// the code could not be replayed
+		signIn(vit, login, fmt.Sprintf(`"totpCode":"%s"`, coreutils.TOTPCode(secret, vit.Now())), coreutils.Expect401()).Println()
+
+		// the same for q.registry.IssuePrincipalToken
+		vit.TimeAdd(coreutils.TOTPPeriod)
+		body := fmt.Sprintf(`{"args":{"Login":"%s","Password":"1","AppName":"%s","TOTPCode":"%s"},"elements":[{"fields":["PrincipalToken"]}]}`,
+			login.Name, login.AppQName, coreutils.TOTPCode(secret, vit.Now()))
+		resp2 := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.IssuePrincipalToken", body)
+		require.NotEmpty(resp2.SectionRow()[0])
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.IssuePrincipalToken", body, coreutils.Expect401())
+	})
+
+	t.Run("disable by the recovery code", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"Password":"1","TOTPCode":"unknown"}}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.DisableTOTP", body, coreutils.Expect401())
+
+		body = fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"Password":"1","TOTPCode":"%s"}}`, login.Name, login.AppQName, recoveryCodes[3])
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.DisableTOTP", body)
+
+		// the second factor is not required anymore
+		vit.SignIn(login)
+
+		// the recovery code is not valid anymore
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.DisableTOTP", body, coreutils.Expect409())
+	})
+
+	t.Run("401 on wrong password", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Password":"wrong"},"elements":[{"fields":["Secret"]}]}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.InitiateTOTPEnrollment", body, coreutils.Expect401())
+	})
+}
+
+func TestSecondFactor_Email(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	login := vit.SignUp(vit.NextName()+"@123.com", "1", istructs.AppQName_test1_app1)
+	vit.SignIn(login)
+
+	body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Enabled":true},"unloggedArgs":{"Password":"1"}}`, login.Name, login.AppQName)
+	vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.SetEmailSecondFactor", body)
+
+	signIn(vit, login, `"totpCode":""`, coreutils.Expect401()).Println()
+
+	emailCodeToken := issueEmailCodeToken(vit, login)
+
+	t.Run("sign in by the e-mailed code", func(t *testing.T) {
+		resp := signIn(vit, login, fmt.Sprintf(`"emailCodeToken":"%s"`, emailCodeToken))
+		require.Contains(resp.Body, "principalToken")
+
+		// the token is one-time
+		signIn(vit, login, fmt.Sprintf(`"emailCodeToken":"%s"`, emailCodeToken), coreutils.Expect401()).Println()
+	})
+
+	t.Run("disable requires the second factor", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Enabled":false},"unloggedArgs":{"Password":"1"}}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.SetEmailSecondFactor", body, coreutils.Expect401())
+
+		// the token issued at the same second is the same one
+		vit.TimeAdd(time.Second)
+		body = fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Enabled":false},"unloggedArgs":{"Password":"1","EmailCodeToken":"%s"}}`,
+			login.Name, login.AppQName, issueEmailCodeToken(vit, login))
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.SetEmailSecondFactor", body)
+		vit.SignIn(login)
+	})
+
+	t.Run("not available if the login is not an e-mail", func(t *testing.T) {
+		login := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Enabled":true},"unloggedArgs":{"Password":"1"}}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.SetEmailSecondFactor", body, coreutils.Expect400())
+	})
+
+	t.Run("code is not sent if the e-mail second factor is not enabled", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Password":"1"},"elements":[{"fields":["VerificationToken"]}]}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.InitiateEmailSecondFactor", body, coreutils.Expect409())
+	})
+}
+
+func TestSecondFactor_RequiredForGlobalRoles(t *testing.T) {
+	require := require.New(t)
+	adminRole := appdef.NewQName("app1pkg", "LimitedAccessRole")
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			cfg.SecondFactorRequiredForGlobalRoles = map[appdef.AppQName][]appdef.QName{
+				istructs.AppQName_test1_app1: {adminRole},
+			}
+		}),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+
+	login := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+	vit.SignIn(login)
+
+	sysRegistryToken := vit.GetSystemPrincipal(istructs.AppQName_sys_registry).Token
+	body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","GlobalRoles":"%s"}}`, login.Name, login.AppQName, adminRole)
+	vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.UpdateGlobalRoles", body, coreutils.WithAuthorizeBy(sysRegistryToken))
+
+	// the second factor must be enrolled first
+	signIn(vit, login, `"totpCode":""`, coreutils.Expect403()).Println()
+
+	secret, enrollmentToken := initiateTOTPEnrollment(vit, login)
+	body = fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"Password":"1","EnrollmentToken":"%s","NewTOTPCode":"%s"}}`,
+		login.Name, login.AppQName, enrollmentToken, coreutils.TOTPCode(secret, vit.Now()))
+	vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.EnableTOTP", body)
+
+	vit.TimeAdd(coreutils.TOTPPeriod)
+	signIn(vit, login, `"totpCode":""`, coreutils.Expect401()).Println()
+	resp := signIn(vit, login, fmt.Sprintf(`"totpCode":"%s"`, coreutils.TOTPCode(secret, vit.Now())))
+	require.Contains(resp.Body, "principalToken")
+
+	t.Run("429 after too many failed attempts", func(t *testing.T) {
+		for range 5 {
+			signIn(vit, login, `"totpCode":"000000"`, coreutils.Expect401()).Println()
+		}
+		vit.TimeAdd(coreutils.TOTPPeriod)
+		signIn(vit, login, fmt.Sprintf(`"totpCode":"%s"`, coreutils.TOTPCode(secret, vit.Now())), coreutils.Expect429()).Println()
+	})
+}
+
+func initiateTOTPEnrollment(vit *it.VIT, login it.Login) (secret []byte, enrollmentToken string) {
+	vit.T.Helper()
+	body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Password":"%s"},"elements":[{"fields":["Secret","URI","EnrollmentToken"]}]}`,
+		login.Name, login.AppQName, login.Pwd)
+	resp := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.InitiateTOTPEnrollment", body)
+	secretStr := resp.SectionRow()[0].(string)
+	require.True(vit.T, strings.HasPrefix(resp.SectionRow()[1].(string), "otpauth://totp/test1%2Fapp1:"+login.Name+"?secret="+secretStr))
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secretStr)
+	require.NoError(vit.T, err)
+	return secret, resp.SectionRow()[2].(string)
+}
+
+func issueEmailCodeToken(vit *it.VIT, login it.Login) string {
+	vit.T.Helper()
+	profileWSID := istructs.WSID(0)
+	token, code := InitiateEmailVerificationFunc(vit, func() *coreutils.FuncResponse {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s","Password":"%s"},"elements":[{"fields":["VerificationToken","ProfileWSID"]}]}`,
+			login.Name, login.AppQName, login.Pwd)
+		resp := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.InitiateEmailSecondFactor", body)
+		profileWSID = istructs.WSID(resp.SectionRow()[1].(float64))
+		return resp
+	})
+	body := fmt.Sprintf(`{"args":{"VerificationToken":"%s","VerificationCode":"%s","ProfileWSID":%d,"AppName":"%s"},"elements":[{"fields":["EmailCodeToken"]}]}`,
+		token, code, profileWSID, login.AppQName)
+	resp := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.IssueEmailSecondFactorToken", body)
+	return resp.SectionRow()[0].(string)
+}
+
+// API v2 auth/login, secondFactor is the JSON fragment, e.g. `"totpCode":"123456"`
+func signIn(vit *it.VIT, login it.Login, secondFactor string, opts ...coreutils.ReqOptFunc) *coreutils.HTTPResponse {
+	vit.T.Helper()
+	body := fmt.Sprintf(`{"login":"%s","password":"%s",%s}`, login.Name, login.Pwd, secondFactor)
+	return vit.POST(fmt.Sprintf("api/v2/apps/%s/%s/auth/login", login.AppQName.Owner(), login.AppQName.Name()), body, opts...)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+const sessionsBody = `{"elements":[{"fields":["SessionID","Device","RemoteAddr","IsCurrent","CreatedAtMs","ExpiresAtMs"]}]}`
+
+func TestSessions_BasicUsage(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	login := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+	laptop := vit.SignIn(login)
+	phone := vit.SignIn(login)
+	laptopSessionID, phoneSessionID := sessionID(vit, laptop), sessionID(vit, phone)
+	require.NotEmpty(laptopSessionID)
+	require.NotEqual(laptopSessionID, phoneSessionID)
+
+	t.Run("list sessions of the current login", func(t *testing.T) {
+		resp := vit.PostProfile(laptop, "q.sys.Sessions", sessionsBody)
+		require.Equal(2, resp.NumRows())
+		for i := range resp.NumRows() {
+			row := resp.SectionRow(i)
+			require.Equal("127.0.0.1", row[2])
+			require.NotEmpty(row[1]) // User-Agent
+			require.Equal(row[0] == laptopSessionID, row[3])
+			require.Equal(float64(vit.Now().UnixMilli()), row[4])
+			require.Equal(float64(vit.Now().Add(time.Hour).UnixMilli()), row[5])
+		}
+	})
+
+	t.Run("revoke other session", func(t *testing.T) {
+		vit.PostProfile(laptop, "c.sys.RevokeSession", fmt.Sprintf(`{"args":{"SessionID":"%s"}}`, phoneSessionID))
+		vit.PostProfile(phone, "q.sys.Sessions", sessionsBody, coreutils.Expect401())
+
+		resp := vit.PostProfile(laptop, "q.sys.Sessions", sessionsBody)
+		require.Equal(1, resp.NumRows())
+		require.Equal(laptopSessionID, resp.SectionRow()[0])
+	})
+
+	t.Run("refreshed token belongs to the same session", func(t *testing.T) {
+		vit.TimeAdd(time.Minute)
+		resp := vit.PostProfile(laptop, "q.sys.RefreshPrincipalToken", `{"elements":[{"fields":["NewPrincipalToken"]}]}`)
+		refreshed := *laptop
+		refreshed.Token = resp.SectionRow()[0].(string)
+		require.Equal(laptopSessionID, sessionID(vit, &refreshed))
+
+		// the session is prolonged
+		resp = vit.PostProfile(&refreshed, "q.sys.Sessions", sessionsBody)
+		require.Equal(float64(vit.Now().Add(time.Hour).UnixMilli()), resp.SectionRow()[5])
+
+		// revoke the current session, i.e. sign out
+		vit.PostProfile(&refreshed, "c.sys.RevokeSession", fmt.Sprintf(`{"args":{"SessionID":"%s"}}`, laptopSessionID))
+		vit.PostProfile(&refreshed, "q.sys.Sessions", sessionsBody, coreutils.Expect401())
+		vit.PostProfile(laptop, "q.sys.Sessions", sessionsBody, coreutils.Expect401())
+	})
+
+	t.Run("404 on unknown session", func(t *testing.T) {
+		prn := vit.SignIn(login)
+		vit.PostProfile(prn, "c.sys.RevokeSession", `{"args":{"SessionID":"unknown"}}`, coreutils.Expect404())
+	})
+
+	t.Run("sessions of other login are not affected", func(t *testing.T) {
+		prn := vit.GetPrincipal(istructs.AppQName_test1_app1, it.TestEmail)
+		vit.PostProfile(prn, "q.sys.Sessions", sessionsBody)
+	})
+}
+
+func TestSessions_RevokeAll(t *testing.T) {
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	t.Run("on password change", func(t *testing.T) {
+		login := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+		prn1 := vit.SignIn(login)
+		prn2 := vit.SignIn(login)
+
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":{"OldPassword":"1","NewPassword":"2"}}`, login.Name, istructs.AppQName_test1_app1)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ChangePassword", body)
+
+		vit.PostProfile(prn1, "q.sys.Sessions", sessionsBody, coreutils.Expect401())
+		vit.PostProfile(prn2, "q.sys.Sessions", sessionsBody, coreutils.Expect401())
+
+		// new session with the new password
+		login.Pwd = "2"
+		prn := vit.SignIn(login)
+		resp := vit.PostProfile(prn, "q.sys.Sessions", sessionsBody)
+		require.Equal(t, 1, resp.NumRows())
+	})
+
+	t.Run("by the system", func(t *testing.T) {
+		login := vit.SignUp(vit.NextName(), "1", istructs.AppQName_test1_app1)
+		prn := vit.SignIn(login)
+
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"}}`, login.Name, istructs.AppQName_test1_app1)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.RevokeAllSessions", body, coreutils.Expect403())
+
+		sysToken, err := payloads.GetSystemPrincipalToken(vit.ITokens, istructs.AppQName_sys_registry)
+		require.NoError(t, err)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.RevokeAllSessions", body, coreutils.WithAuthorizeBy(sysToken))
+
+		vit.PostProfile(prn, "q.sys.Sessions", sessionsBody, coreutils.Expect401())
+	})
+}
+
+func sessionID(vit *it.VIT, prn *it.Principal) string {
+	pp := payloads.PrincipalPayload{}
+	_, err := vit.ITokens.ValidateToken(prn.Token, &pp)
+	require.NoError(vit.T, err)
+	return pp.SessionID
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys/webhooks"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+type webhookDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+func TestBasicUsage_Webhooks(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	const secret = "top secret"
+	failing := atomic.Bool{}
+	deliveries := make(chan webhookDelivery, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		deliveries <- webhookDelivery{header: r.Header, body: body}
+	}))
+	defer receiver.Close()
+
+	prn := vit.GetPrincipal(istructs.AppQName_test1_app1, it.TestEmail)
+	ws := vit.CreateWorkspace(it.SimpleWSParams(vit.NextName()), prn)
+
+	body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"sys.Webhook","URL":%q,"Secret":%q,"EventQNames":"app1pkg.category","MaxAttempts":2}}]}`,
+		receiver.URL, secret)
+	webhookID := vit.PostWS(ws, "c.sys.CUD", body).NewID()
+
+	// deactivate the webhook to not to affect other tests
+	defer vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"sys.IsActive":false}}]}`, webhookID))
+
+	t.Run("secret is write-only", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Schema":"sys.Webhook","ID":%d},"elements":[{"fields":["URL"]}]}`, webhookID)
+		require.Equal(receiver.URL, vit.PostWS(ws, "q.sys.Collection", body).SectionRow()[0])
+
+		body = fmt.Sprintf(`{"args":{"Schema":"sys.Webhook","ID":%d},"elements":[{"fields":["URL","Secret"]}]}`, webhookID)
+		vit.PostWS(ws, "q.sys.Collection", body, coreutils.Expect403())
+
+		body = fmt.Sprintf(`{"args":{"Query":"select Secret from sys.Webhook.%d"},"elements":[{"fields":["Result"]}]}`, webhookID)
+		vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.Expect403())
+
+		path := fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/docs/sys.Webhook/%d", ws.WSID, webhookID)
+		vit.POST(path, "", coreutils.WithAuthorizeBy(ws.Owner.Token), coreutils.WithMethod(http.MethodGet), coreutils.Expect403())
+	})
+
+	var deliveredOffset istructs.Offset
+	t.Run("signed delivery", func(t *testing.T) {
+		// not matched by the filter
+		vit.PostWS(ws, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.computers"}}]}`)
+
+		body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Awesome food"}}]}`
+		deliveredOffset = vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+
+		delivery := waitForWebhookDelivery(t, deliveries)
+		timestamp := delivery.header.Get(webhooks.Header_Timestamp)
+		require.Equal(webhooks.Signature(secret, timestamp, delivery.body), delivery.header.Get(webhooks.Header_Signature))
+		require.Equal("sys.CUD", delivery.header.Get(webhooks.Header_Event))
+		require.Equal(fmt.Sprint(webhookID), delivery.header.Get(webhooks.Header_WebhookID))
+
+		payload := webhooks.Payload{}
+		require.NoError(json.Unmarshal(delivery.body, &payload))
+		require.Equal(delivery.header.Get(webhooks.Header_Delivery), payload.DeliveryID)
+		require.Equal(ws.WSID, payload.WSID)
+		require.Equal(deliveredOffset, payload.WLogOffset)
+		require.Len(payload.CUDs, 1)
+		require.Equal("Awesome food", payload.CUDs[0]["fields"].(map[string]interface{})["name"])
+	})
+
+	t.Run("delivery attempts history", func(t *testing.T) {
+		attempts := waitForWebhookView(t, vit, ws, "sys.WebhookDeliveryAttempts", webhookID, 1)
+		require.Len(attempts, 1)
+		require.EqualValues(deliveredOffset, attempts[0]["WLogOffset"])
+		require.EqualValues(1, attempts[0]["Attempt"])
+		require.EqualValues(http.StatusOK, attempts[0]["StatusCode"])
+		require.Equal(true, attempts[0]["Delivered"])
+	})
+
+	var failedOffset istructs.Offset
+	t.Run("dead letter after all attempts", func(t *testing.T) {
+		failing.Store(true)
+		body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Failed food"}}]}`
+		failedOffset = vit.PostWS(ws, "c.sys.CUD", body).CurrentWLogOffset
+
+		deadLetters := waitForWebhookView(t, vit, ws, "sys.WebhookDeadLetters", webhookID, 1)
+		require.EqualValues(failedOffset, deadLetters[0]["WLogOffset"])
+		require.EqualValues(2, deadLetters[0]["Attempts"])
+		require.Equal(false, deadLetters[0]["Replayed"])
+		require.Contains(deadLetters[0]["LastError"], "503")
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		failing.Store(false)
+		vit.PostWS(ws, "c.sys.ReplayWebhookDelivery", fmt.Sprintf(`{"args":{"WebhookID":%d,"WLogOffset":%d}}`, webhookID, failedOffset))
+
+		delivery := waitForWebhookDelivery(t, deliveries)
+		payload := webhooks.Payload{}
+		require.NoError(json.Unmarshal(delivery.body, &payload))
+		require.Equal(failedOffset, payload.WLogOffset)
+
+		attempts := waitForWebhookView(t, vit, ws, "sys.WebhookDeliveryAttempts", webhookID, 4)
+		require.EqualValues(3, attempts[3]["Attempt"])
+		require.Equal(true, attempts[3]["Delivered"])
+
+		// replayed already
+		vit.PostWS(ws, "c.sys.ReplayWebhookDelivery", fmt.Sprintf(`{"args":{"WebhookID":%d,"WLogOffset":%d}}`, webhookID, failedOffset),
+			coreutils.Expect400(webhooks.ErrDeadLetterReplayed.Error()))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("invalid URL", func(t *testing.T) {
+			body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"sys.Webhook","URL":"ftp://example.com","Secret":"s"}}]}`
+			vit.PostWS(ws, "c.sys.CUD", body, coreutils.Expect400(webhooks.ErrInvalidWebhookURL.Error()))
+		})
+		t.Run("invalid MaxAttempts", func(t *testing.T) {
+			body := fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"MaxAttempts":%d}}]}`, webhookID, webhooks.MaxMaxAttempts+1)
+			vit.PostWS(ws, "c.sys.CUD", body, coreutils.Expect400(webhooks.ErrInvalidMaxAttempts.Error()))
+		})
+		t.Run("invalid EventQNames", func(t *testing.T) {
+			body := fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"EventQNames":"app1pkg.category,wrong"}}]}`, webhookID)
+			vit.PostWS(ws, "c.sys.CUD", body, coreutils.Expect400(webhooks.ErrInvalidEventQNames.Error()))
+		})
+		t.Run("unknown dead letter", func(t *testing.T) {
+			vit.PostWS(ws, "c.sys.ReplayWebhookDelivery", fmt.Sprintf(`{"args":{"WebhookID":%d,"WLogOffset":%d}}`, webhookID, deliveredOffset),
+				coreutils.Expect404(webhooks.ErrDeadLetterNotFound.Error()))
+		})
+	})
+}
+
+func waitForWebhookDelivery(t *testing.T, deliveries chan webhookDelivery) webhookDelivery {
+	select {
+	case delivery := <-deliveries:
+		return delivery
+	case <-time.After(time.Until(it.TestDeadline())):
+		t.Fatal("webhook is not delivered")
+	}
+	return webhookDelivery{}
+}
+
+func readWebhookView(vit *it.VIT, ws *it.AppWorkspace, view string, webhookID istructs.RecordID) []map[string]interface{} {
+	return vit.SqlQueryRows(ws, "select * from %s where WebhookID = %d", view, webhookID)
+}
+
+// the projector is async
+// the mocked time is moved forward since the next delivery attempts are made by job.sys.RetryWebhookDeliveries which runs each minute
+func waitForWebhookView(t *testing.T, vit *it.VIT, ws *it.AppWorkspace, view string, webhookID istructs.RecordID, expectedLen int) []map[string]interface{} {
+	once := sync.Once{}
+	for deadline := it.TestDeadline(); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		if res := readWebhookView(vit, ws, view, webhookID); len(res) >= expectedLen {
+			return res
+		}
+		once.Do(func() { t.Log("waiting for", view) })
+		vit.TimeAdd(time.Minute)
+	}
+	t.Fatal("no expected records in", view)
+	return nil
+}
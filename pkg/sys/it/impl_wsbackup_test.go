@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	it "github.com/voedger/voedger/pkg/vit"
+	sys_test_template "github.com/voedger/voedger/pkg/vit/testdata"
+	"github.com/voedger/voedger/pkg/vvm"
+	"github.com/voedger/voedger/pkg/vvm/builtin/clusterapp"
+)
+
+func TestWorkspaceBackup(t *testing.T) {
+	require := require.New(t)
+	backupPath := t.TempDir()
+	keyspaceSuffix := uuid.NewString()
+	var sharedStorageFactory istorage.IAppStorageFactory
+	useSharedStorage := func(cfg *vvm.VVMConfig) {
+		if sharedStorageFactory == nil {
+			var err error
+			sharedStorageFactory, err = cfg.StorageFactory()
+			require.NoError(err)
+		}
+		cfg.KeyspaceNameSuffix = keyspaceSuffix
+		cfg.StorageFactory = func() (provider istorage.IAppStorageFactory, err error) {
+			return sharedStorageFactory, nil
+		}
+		cfg.WorkspaceBackupPath = cluster.WorkspaceBackupPath(backupPath)
+	}
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1,
+			it.WithWorkspaceTemplate(it.QNameApp1_TestWSKind, "test_template", sys_test_template.TestTemplateFS),
+			it.WithUserLogin("login", "pwd"),
+			it.WithChildWorkspace(it.QNameApp1_TestWSKind, "test_ws", "test_template", "", "login", map[string]interface{}{"IntFld": 42}),
+		),
+		it.WithVVMConfig(useSharedStorage),
+	)
+	vit := it.NewVIT(t, &cfg)
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	sysPrn := vit.GetSystemPrincipal(istructs.AppQName_sys_cluster)
+	targetWSID := istructs.NewWSID(ws.WSID.ClusterID(), ws.WSID.BaseWSID()+1_000_000)
+	cmd := func(name string, wsid istructs.WSID, filePath string, opts ...coreutils.ReqOptFunc) *coreutils.FuncResponse {
+		body := fmt.Sprintf(`{"args":{"AppQName":"%s","WSID":%d,"FilePath":"%s"}}`, istructs.AppQName_test1_app1, wsid, filePath)
+		return vit.PostApp(istructs.AppQName_sys_cluster, clusterapp.ClusterAppWSID, "c.cluster."+name, body,
+			append(opts, coreutils.WithAuthorizeBy(sysPrn.Token))...)
+	}
+
+	createCategory := func(name string) istructs.RecordID {
+		body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":%q}}]}`, name)
+		return vit.PostWS(ws, "c.sys.CUD", body).NewID()
+	}
+	cat1ID := createCategory("cat1")
+	cat2ID := createCategory("cat2")
+
+	backupResp := cmd("BackupWorkspace", ws.WSID, "ws.zip")
+	require.FileExists(filepath.Join(backupPath, "ws.zip"))
+
+	t.Run("archive path must not leave the backup path", func(t *testing.T) {
+		outside := t.TempDir()
+		require.NoError(os.Symlink(outside, filepath.Join(backupPath, "link")))
+		for _, filePath := range []string{filepath.Join(outside, "ws.zip"), "../ws.zip", "link/ws.zip"} {
+			cmd("BackupWorkspace", ws.WSID, filePath, coreutils.Expect400())
+			cmd("RestoreWorkspace", targetWSID, filePath, coreutils.Expect400())
+		}
+		entries, err := os.ReadDir(outside)
+		require.NoError(err)
+		require.Empty(entries)
+	})
+
+	t.Run("409 on import to the deployed partition", func(t *testing.T) {
+		cmd("RestoreWorkspace", targetWSID, "ws.zip", coreutils.Expect409())
+	})
+
+	t.Run("restore in place", func(t *testing.T) {
+		as, err := vit.BuiltIn(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		lastWLogOffset := lastWLogOffsetOf(t, as, ws.WSID)
+
+		// changes made after the backup
+		vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"name":"changed"}}]}`, cat1ID))
+		vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"sys.IsActive":false}}]}`, cat2ID))
+		cat3ID := createCategory("cat3")
+
+		// the partition keeps working, the command returns the changes to be made by the async projector
+		resp := cmd("RestoreWorkspace", ws.WSID, "ws.zip")
+		require.EqualValues(3, resp.CmdResult["Events"])
+		require.EqualValues(3, resp.CmdResult["Records"])
+
+		// 3 changes are followed by the restoring deactivation, activation and update
+		restoredWLogOffset := lastWLogOffset + 6
+		for deadline := it.TestDeadline(); lastWLogOffsetOf(t, as, ws.WSID) < restoredWLogOffset; time.Sleep(100 * time.Millisecond) {
+			require.True(time.Now().Before(deadline), "workspace is not restored")
+		}
+
+		t.Run("records and the collection view", func(t *testing.T) {
+			for id, expected := range map[istructs.RecordID][]any{
+				cat1ID: {"cat1", true},
+				cat2ID: {"cat2", true},
+				cat3ID: {"cat3", false},
+			} {
+				body := fmt.Sprintf(`{"args":{"Schema":"app1pkg.category","ID":%d},"elements":[{"fields":["name","sys.IsActive"]}]}`, id)
+				require.Equal(expected, vit.PostWS(ws, "q.sys.Collection", body).SectionRow())
+			}
+		})
+
+		t.Run("WLog", func(t *testing.T) {
+			cuds := []map[string]any{}
+			err := as.Events().ReadWLog(context.Background(), ws.WSID, lastWLogOffset+4, istructs.ReadToTheEnd,
+				func(_ istructs.Offset, event istructs.IWLogEvent) error {
+					require.Equal(istructs.QNameCommandCUD, event.QName())
+					for cud := range event.CUDs {
+						cuds = append(cuds, coreutils.FieldsToMap(cud, as.AppDef()))
+					}
+					return nil
+				})
+			require.NoError(err)
+			require.Len(cuds, 3)
+			require.Equal(cat3ID, cuds[0][appdef.SystemField_ID])
+			require.Equal(false, cuds[0][appdef.SystemField_IsActive])
+			require.Equal(cat2ID, cuds[1][appdef.SystemField_ID])
+			require.Equal(true, cuds[1][appdef.SystemField_IsActive])
+			require.Equal(cat1ID, cuds[2][appdef.SystemField_ID])
+			require.Equal("cat1", cuds[2]["name"])
+		})
+	})
+
+	vit.TearDown()
+
+	// 2nd launch - the app partitions are stopped, so the workspace could be imported into the empty workspace
+	cfg = it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			useSharedStorage(cfg)
+			cfg.MaintenanceApps = []appdef.AppQName{istructs.AppQName_test1_app1}
+		}),
+	)
+	vit = it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+	sysPrn = vit.GetSystemPrincipal(istructs.AppQName_sys_cluster)
+
+	importResp := cmd("RestoreWorkspace", targetWSID, "ws.zip")
+	require.Equal(backupResp.CmdResult, importResp.CmdResult)
+	require.NotZero(importResp.CmdResult["Events"])
+
+	// the imported workspace is exported the same as the source one
+	cmd("BackupWorkspace", targetWSID, "target.zip")
+	src := readArchive(t, filepath.Join(backupPath, "ws.zip"))
+	target := readArchive(t, filepath.Join(backupPath, "target.zip"))
+	require.Equal(src["records.jsonl"], target["records.jsonl"])
+	require.Equal(src["views.jsonl"], target["views.jsonl"])
+	require.Contains(target["records.jsonl"], fmt.Sprintf(`{"name":"cat1","sys.ID":%d,"sys.IsActive":true,"sys.QName":"app1pkg.category"}`, cat1ID))
+
+	// events are imported as synced ones
+	require.Len(target["wlog.jsonl"], len(src["wlog.jsonl"]))
+	for i := range src["wlog.jsonl"] {
+		srcEvent, targetEvent := map[string]any{}, map[string]any{}
+		require.NoError(json.Unmarshal([]byte(src["wlog.jsonl"][i]), &srcEvent))
+		require.NoError(json.Unmarshal([]byte(target["wlog.jsonl"][i]), &targetEvent))
+		for _, event := range []map[string]any{srcEvent, targetEvent} {
+			delete(event, "Synced")
+			delete(event, "DeviceID")
+			delete(event, "SyncedAt")
+		}
+		require.Equal(srcEvent, targetEvent)
+	}
+}
+
+func lastWLogOffsetOf(t *testing.T, as istructs.IAppStructs, wsid istructs.WSID) (last istructs.Offset) {
+	err := as.Events().ReadWLog(context.Background(), wsid, istructs.FirstOffset, istructs.ReadToTheEnd,
+		func(offset istructs.Offset, _ istructs.IWLogEvent) error {
+			last = offset
+			return nil
+		})
+	require.NoError(t, err)
+	return last
+}
+
+// entry name -> lines, views are read in the storage order, so lines are sorted
+func readArchive(t *testing.T, path string) map[string][]string {
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer zr.Close()
+	res := map[string][]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if f.Name == "views.jsonl" {
+			slices.Sort(lines)
+		}
+		res[f.Name] = lines
+	}
+	return res
+}
@@ -52,9 +52,11 @@ func readViewRecords(ctx context.Context, wsid istructs.WSID, viewRecordQName ap
 				name = cn.Name.String()
 			}
 
+			val := r.Right.(*sqlparser.SQLVal)
 			kk = append(kk, keyPart{
-				name:  name,
-				value: r.Right.(*sqlparser.SQLVal).Val,
+				name:    name,
+				value:   val.Val,
+				valType: val.Type,
 			})
 		case *sqlparser.AndExpr:
 			e := keyParts(r.Left)
@@ -92,13 +94,20 @@ func readViewRecords(ctx context.Context, wsid istructs.WSID, viewRecordQName ap
 			fallthrough
 		case appdef.DataKind_float64:
 			fallthrough
-		case appdef.DataKind_RecordID:
+		case appdef.DataKind_RecordID, appdef.DataKind_decimal:
 			n := json.Number(string(k.value))
 			kb.PutNumber(k.name, n)
-		case appdef.DataKind_bytes, appdef.DataKind_string:
+		case appdef.DataKind_bytes, appdef.DataKind_string, appdef.DataKind_uuid:
 			fallthrough
 		case appdef.DataKind_QName:
 			kb.PutChars(k.name, string(k.value))
+		case appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+			// RFC 3339 or ISO 8601 duration string, or milliseconds
+			if k.valType == sqlparser.IntVal {
+				kb.PutNumber(k.name, json.Number(string(k.value)))
+			} else {
+				kb.PutChars(k.name, string(k.value))
+			}
 		default:
 			return errUnsupportedDataKind
 		}
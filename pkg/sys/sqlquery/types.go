@@ -5,6 +5,8 @@
 package sqlquery
 
 import (
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
@@ -22,8 +24,9 @@ func (f *filter) filter(field string) bool {
 }
 
 type keyPart struct {
-	name  string
-	value []byte
+	name    string
+	value   []byte
+	valType sqlparser.ValType
 }
 
 type result struct {
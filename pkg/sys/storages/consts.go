@@ -5,6 +5,7 @@
 package storages
 
 import (
+	"net/netip"
 	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -15,8 +16,17 @@ const (
 	httpStorageKeyBuilderStringerSliceCap = 3
 	field_WSKind                          = "WSKind"
 	wsidTypeValidatorCacheSize            = 100
+
+	publicAddressesHTTPClientMaxIdleConns    = 100
+	publicAddressesHTTPClientIdleConnTimeout = 90 * time.Second
 )
 
 var (
 	qNameCDocWorkspaceDescriptor = appdef.NewQName(appdef.SysPackage, "WorkspaceDescriptor")
+
+	// not public addresses that are not reported by netip.Addr methods
+	notPublicPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+		netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	}
 )
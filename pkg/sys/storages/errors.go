@@ -15,6 +15,7 @@ var (
 	ErrNotFoundKey                      = errors.New("not found key")
 	ErrNotFound                         = errors.New("not found")
 	ErrNotSupported                     = errors.New("not supported")
+	ErrNotPublicAddress                 = errors.New("connection to not public address is refused")
 	errNotImplemented                   = errors.New("not implemented")
 	errCurrentValueIsNotAnArray         = errors.New("current value is not an array")
 	errFieldByIndexIsNotAnObjectOrArray = errors.New("field by index is not an object or array")
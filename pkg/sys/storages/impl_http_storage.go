@@ -9,9 +9,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/netip"
+	"slices"
 	"strings"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -35,12 +39,13 @@ func NewHTTPStorage(customClient state.IHTTPClient) state.IStateStorage {
 
 type httpStorageKeyBuilder struct {
 	baseKeyBuilder
-	timeout      time.Duration
-	method       string
-	url          string
-	body         []byte
-	headers      map[string]string
-	handleErrors bool
+	timeout             time.Duration
+	method              string
+	url                 string
+	body                []byte
+	headers             map[string]string
+	handleErrors        bool
+	publicAddressesOnly bool
 }
 
 func (b *httpStorageKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
@@ -58,6 +63,9 @@ func (b *httpStorageKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
 	if b.handleErrors != kb.handleErrors {
 		return false
 	}
+	if b.publicAddressesOnly != kb.publicAddressesOnly {
+		return false
+	}
 	if b.url != kb.url {
 		return false
 	}
@@ -102,6 +110,8 @@ func (b *httpStorageKeyBuilder) PutBool(name string, value bool) {
 	switch name {
 	case sys.Storage_HTTP_Field_HandleErrors:
 		b.handleErrors = value
+	case sys.Storage_HTTP_Field_PublicAddressesOnly:
+		b.publicAddressesOnly = value
 	default:
 		b.baseKeyBuilder.PutBool(name, value)
 	}
@@ -188,7 +198,11 @@ func (s *httpStorage) Read(key istructs.IStateKeyBuilder, callback istructs.Valu
 		reqNumber = atomic.AddInt64(&requestNumber, 1)
 		logger.Verbose("req ", reqNumber, ": ", method, " ", kb.url, " body: ", string(kb.body))
 	}
-	res, err := http.DefaultClient.Do(req)
+	client := http.DefaultClient
+	if kb.publicAddressesOnly {
+		client = publicAddressesHTTPClient
+	}
+	res, err := client.Do(req)
 	if err != nil {
 		return errorResult(err)
 	}
@@ -210,6 +224,38 @@ func (s *httpStorage) Read(key istructs.IStateKeyBuilder, callback istructs.Valu
 	})
 }
 
+// the address is checked after the host is resolved, so the hostname resolving to a private address and redirects are rejected too
+// the proxy is not used, otherwise the address of the proxy is checked instead of the target one
+var publicAddressesHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   defaultHTTPClientTimeout,
+			KeepAlive: defaultHTTPClientTimeout,
+			Control:   dialPublicAddressesOnly,
+		}).DialContext,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        publicAddressesHTTPClientMaxIdleConns,
+		IdleConnTimeout:     publicAddressesHTTPClientIdleConnTimeout,
+		TLSHandshakeTimeout: defaultHTTPClientTimeout,
+	},
+}
+
+func dialPublicAddressesOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || slices.ContainsFunc(notPublicPrefixes, func(p netip.Prefix) bool { return p.Contains(ip) }) {
+		return fmt.Errorf("%w: %s", ErrNotPublicAddress, ip)
+	}
+	return nil
+}
+
 type httpValue struct {
 	istructs.IStateValue
 	body       []byte
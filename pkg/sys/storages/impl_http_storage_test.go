@@ -113,3 +113,43 @@ func TestHTTPStorageKeyBuilder_headers(t *testing.T) {
 	headers := k.headers
 	require.Equal("hello:world", headers["key"])
 }
+
+func TestHTTPStorage_PublicAddressesOnly(t *testing.T) {
+	require := require.New(t)
+	storage := NewHTTPStorage(nil)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	t.Run("loopback is refused", func(t *testing.T) {
+		k := storage.NewKeyBuilder(appdef.NullQName, nil)
+		k.PutString(sys.Storage_HTTP_Field_URL, ts.URL)
+		k.PutBool(sys.Storage_HTTP_Field_PublicAddressesOnly, true)
+		err := storage.(state.IWithRead).Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil })
+		require.ErrorIs(err, ErrNotPublicAddress)
+	})
+
+	t.Run("addresses", func(t *testing.T) {
+		for address, public := range map[string]bool{
+			"127.0.0.1:80":          false,
+			"[::1]:80":              false,
+			"10.1.2.3:80":           false,
+			"172.16.0.1:80":         false,
+			"192.168.1.1:80":        false,
+			"169.254.169.254:80":    false,
+			"[fe80::1]:80":          false,
+			"[fd00::1]:80":          false,
+			"[::ffff:10.0.0.1]:80":  false,
+			"0.0.0.0:80":            false,
+			"100.64.0.1:80":         false,
+			"8.8.8.8:443":           true,
+			"[2001:4860::8888]:443": true,
+		} {
+			err := dialPublicAddressesOnly("tcp", address, nil)
+			if public {
+				require.NoError(err, address)
+			} else {
+				require.ErrorIs(err, ErrNotPublicAddress, address)
+			}
+		}
+	})
+}
@@ -6,6 +6,7 @@ package storages
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -15,9 +16,12 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/indexes"
 )
 
 type recordsStorage struct {
+	ctx              context.Context
+	appStructsFunc   state.AppStructsFunc
 	recordsFunc      state.RecordsFunc
 	cudFunc          state.CUDFunc
 	wsidFunc         state.WSIDFunc
@@ -34,6 +38,8 @@ type recordsKeyBuilder struct {
 	singleton   appdef.QName
 	isSingleton bool
 	wsid        istructs.WSID
+	index       string
+	values      map[appdef.FieldName]any // values of the leading index fields
 }
 
 func (b *recordsKeyBuilder) String() string {
@@ -49,6 +55,9 @@ func (b *recordsKeyBuilder) String() string {
 		fmt.Fprint(bb, ", isSingleton")
 	}
 	fmt.Fprintf(bb, ", wsid:%d", b.wsid)
+	if b.index != "" {
+		fmt.Fprintf(bb, ", index:%s, values:%v", b.index, b.values)
+	}
 	return bb.String()
 }
 func (b *recordsKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
@@ -68,8 +77,70 @@ func (b *recordsKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
 	if b.wsid != kb.wsid {
 		return false
 	}
+	if b.index != kb.index {
+		return false
+	}
+	return reflect.DeepEqual(b.values, kb.values)
+}
+
+// Puts value of the index field. Returns false if the index is not specified yet
+func (b *recordsKeyBuilder) putIndexValue(name appdef.FieldName, value any) bool {
+	if b.index == "" {
+		return false
+	}
+	b.values[name] = value
 	return true
 }
+func (b *recordsKeyBuilder) PutInt8(name string, value int8) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutInt8(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutInt16(name string, value int16) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutInt16(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutInt32(name string, value int32) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutInt32(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutFloat32(name string, value float32) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutFloat32(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutFloat64(name string, value float64) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutFloat64(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutBytes(name string, value []byte) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutBytes(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutString(name string, value string) {
+	if name == sys.Storage_Record_Field_Index {
+		b.index = value
+		b.values = map[appdef.FieldName]any{}
+		return
+	}
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutString(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutChars(name string, value string) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutChars(name, value)
+	}
+}
+func (b *recordsKeyBuilder) PutNumber(name string, value json.Number) {
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutNumber(name, value)
+	}
+}
 func (b *recordsKeyBuilder) PutInt64(name string, value int64) {
 	if name == sys.Storage_Record_Field_WSID {
 		wsid, err := coreutils.Int64ToWSID(value)
@@ -87,14 +158,18 @@ func (b *recordsKeyBuilder) PutInt64(name string, value int64) {
 		b.id = recID
 		return
 	}
-	b.baseKeyBuilder.PutInt64(name, value)
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutInt64(name, value)
+	}
 }
 func (b *recordsKeyBuilder) PutRecordID(name string, value istructs.RecordID) {
 	if name == sys.Storage_Record_Field_ID {
 		b.id = value
 		return
 	}
-	b.baseKeyBuilder.PutRecordID(name, value)
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutRecordID(name, value)
+	}
 }
 func (b *recordsKeyBuilder) PutBool(name string, value bool) {
 	if name == sys.Storage_Record_Field_IsSingleton {
@@ -104,18 +179,24 @@ func (b *recordsKeyBuilder) PutBool(name string, value bool) {
 		b.isSingleton = value
 		return
 	}
-	b.baseKeyBuilder.PutBool(name, value)
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutBool(name, value)
+	}
 }
 func (b *recordsKeyBuilder) PutQName(name string, value appdef.QName) {
 	if name == sys.Storage_Record_Field_Singleton {
 		b.singleton = value
 		return
 	}
-	b.baseKeyBuilder.PutQName(name, value)
+	if !b.putIndexValue(name, value) {
+		b.baseKeyBuilder.PutQName(name, value)
+	}
 }
 
-func NewRecordsStorage(appStructsFunc state.AppStructsFunc, wsidFunc state.WSIDFunc, cudFunc state.CUDFunc) state.IStateStorage {
+func NewRecordsStorage(ctx context.Context, appStructsFunc state.AppStructsFunc, wsidFunc state.WSIDFunc, cudFunc state.CUDFunc) state.IStateStorage {
 	return &recordsStorage{
+		ctx:              ctx,
+		appStructsFunc:   appStructsFunc,
 		recordsFunc:      func() istructs.IRecords { return appStructsFunc().Records() },
 		wsidFunc:         wsidFunc,
 		cudFunc:          cudFunc,
@@ -227,6 +308,49 @@ func (s *recordsStorage) GetBatch(items []state.GetBatchItem) (err error) {
 	}
 	return err
 }
+
+// Reads records of the entity by the index specified by the Index field.
+//
+// Values of the leading index fields must be specified, records are read in the index order
+func (s *recordsStorage) Read(key istructs.IStateKeyBuilder, callback istructs.ValueCallback) (err error) {
+	k := key.(*recordsKeyBuilder)
+	if k.index == "" {
+		return fmt.Errorf("%s field must be specified to read records: %w", sys.Storage_Record_Field_Index, ErrNotSupported)
+	}
+	if err = s.wsTypeVailidator.validate(k.wsid, k.entity); err != nil {
+		return err
+	}
+	as := s.appStructsFunc()
+	rec, ok := as.AppDef().Type(k.entity).(appdef.IRecord)
+	if !ok {
+		return appdef.ErrNotFound("record type %q", k.entity)
+	}
+	index := rec.Index(appdef.IndexQName(k.entity, k.index))
+	if index == nil {
+		return fmt.Errorf("index %s of %s: %w", k.index, k.entity, indexes.ErrIndexNotExist)
+	}
+	values := make([]any, 0, len(k.values))
+	for _, f := range index.Fields() {
+		v, ok := k.values[f.Name()]
+		if !ok {
+			break
+		}
+		values = append(values, v)
+	}
+	if len(values) != len(k.values) {
+		return fmt.Errorf("%v: %w", index, indexes.ErrIndexFieldsMismatch)
+	}
+	return indexes.ReadIndex(s.ctx, as, k.wsid, index, values, func(id istructs.RecordID) error {
+		record, err := s.recordsFunc().Get(k.wsid, true, id)
+		if err != nil {
+			return err
+		}
+		if record.QName() == appdef.NullQName {
+			return nil
+		}
+		return callback(&recordsKey{id: id}, &recordsValue{record: record})
+	})
+}
 func (s *recordsStorage) Validate([]state.ApplyBatchItem) (err error)   { return }
 func (s *recordsStorage) ApplyBatch([]state.ApplyBatchItem) (err error) { return }
 func (s *recordsStorage) ProvideValueBuilder(key istructs.IStateKeyBuilder, _ istructs.IStateValueBuilder) (istructs.IStateValueBuilder, error) {
@@ -305,6 +429,14 @@ func (b *recordsValueBuilder) PutRecordID(name string, value istructs.RecordID)
 	b.rw.PutRecordID(name, value)
 }
 
+type recordsKey struct {
+	istructs.IKey
+	id istructs.RecordID
+}
+
+func (k *recordsKey) AsInt64(string) int64                { return int64(k.id) } // nolint G115
+func (k *recordsKey) AsRecordID(string) istructs.RecordID { return k.id }
+
 type recordsValue struct {
 	baseStateValue
 	istructs.IStateRecordValue
@@ -5,6 +5,7 @@
 package storages
 
 import (
+	"context"
 	"testing"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -25,7 +26,7 @@ func BenchmarkRecordsGet(b *testing.B) {
 	appStructsFunc := func() istructs.IAppStructs {
 		return appStructs
 	}
-	storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+	storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 	k1 := storage.NewKeyBuilder(appdef.NullQName, nil)
 	k1.PutRecordID(sys.Storage_Record_Field_ID, 2)
 	k1.PutInt64(sys.Storage_Record_Field_WSID, 1)
@@ -5,6 +5,7 @@
 package storages
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -86,7 +87,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 		appStructsFunc := func() istructs.IAppStructs {
 			return appStructs
 		}
-		storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+		storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 		k1 := storage.NewKeyBuilder(appdef.NullQName, nil)
 		k1.PutRecordID(sys.Storage_Record_Field_ID, 1)
 		k2 := storage.NewKeyBuilder(appdef.NullQName, nil)
@@ -152,7 +153,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 		appStructsFunc := func() istructs.IAppStructs {
 			return appStructs
 		}
-		storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+		storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 		k1 := storage.NewKeyBuilder(appdef.NullQName, nil)
 		k1.PutQName(sys.Storage_Record_Field_Singleton, testRecordQName1)
 		k2 := storage.NewKeyBuilder(appdef.NullQName, nil)
@@ -180,7 +181,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 		appStructsFunc := func() istructs.IAppStructs {
 			return appStructs
 		}
-		storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+		storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 		k := storage.NewKeyBuilder(appdef.NullQName, nil)
 		_, err := storage.(state.IWithGet).Get(k)
 		require.ErrorIs(err, ErrNotFound)
@@ -199,7 +200,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 		appStructsFunc := func() istructs.IAppStructs {
 			return appStructs
 		}
-		storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+		storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 		k := storage.NewKeyBuilder(appdef.NullQName, nil)
 		k.PutRecordID(sys.Storage_Record_Field_ID, istructs.RecordID(1))
 		_, err := storage.(state.IWithGet).Get(k)
@@ -225,7 +226,7 @@ func TestRecordsStorage_GetBatch(t *testing.T) {
 		appStructsFunc := func() istructs.IAppStructs {
 			return appStructs
 		}
-		storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+		storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 		k := storage.NewKeyBuilder(appdef.NullQName, nil)
 		k.PutQName(sys.Storage_Record_Field_Singleton, testRecordQName1)
 		_, err := storage.(state.IWithGet).Get(k)
@@ -257,7 +258,7 @@ func TestRecordsStorage_Insert(t *testing.T) {
 	cudFunc := func() istructs.ICUD {
 		return cud
 	}
-	storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.NullWSID), cudFunc)
+	storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.NullWSID), cudFunc)
 	kb := storage.NewKeyBuilder(testRecordQName1, nil)
 	vb, err := storage.(state.IWithInsert).ProvideValueBuilder(kb, nil)
 	require.NoError(err)
@@ -294,7 +295,7 @@ func TestRecordsStorage_InsertRecordIDField(t *testing.T) {
 	cudFunc := func() istructs.ICUD {
 		return cud
 	}
-	storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.NullWSID), cudFunc)
+	storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.NullWSID), cudFunc)
 	kb := storage.NewKeyBuilder(testRecordQName2, nil)
 	vb, err := storage.(state.IWithInsert).ProvideValueBuilder(kb, nil)
 	require.NoError(err)
@@ -320,7 +321,7 @@ func TestRecordsStorage_Update(t *testing.T) {
 	appStructsFunc := func() istructs.IAppStructs {
 		return appStructs
 	}
-	storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.NullWSID), cudFunc)
+	storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.NullWSID), cudFunc)
 	kb := storage.NewKeyBuilder(testRecordQName1, nil)
 	vb, err := storage.(state.IWithUpdate).ProvideValueBuilderForUpdate(kb, sv, nil)
 	require.NoError(err)
@@ -341,7 +342,7 @@ func TestRecordsStorage_ValidateInWorkspaces_Reads(t *testing.T) {
 	appStructsFunc := func() istructs.IAppStructs {
 		return mockedStructs
 	}
-	storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+	storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 
 	wrongSingleton := appdef.NewQName("test", "RecordX")
 	wrongKb := storage.NewKeyBuilder(appdef.NullQName, nil)
@@ -374,7 +375,7 @@ func TestRecordsStorage_ValidateInWorkspaces_Writes(t *testing.T) {
 	appStructsFunc := func() istructs.IAppStructs {
 		return mockedStructs
 	}
-	storage := NewRecordsStorage(appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+	storage := NewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
 
 	wrongSingleton := appdef.NewQName("test", "RecordX")
 	wrongKb := storage.NewKeyBuilder(wrongSingleton, nil)
@@ -387,3 +388,29 @@ func TestRecordsStorage_ValidateInWorkspaces_Writes(t *testing.T) {
 	})
 
 }
+
+func TestRecordsStorage_Read(t *testing.T) {
+	require := require.New(t)
+	storage := NewRecordsStorage(context.Background(), func() istructs.IAppStructs { return &mockAppStructs{} }, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+
+	t.Run("Should require index", func(t *testing.T) {
+		kb := storage.NewKeyBuilder(testRecordQName1, nil)
+		err := storage.(state.IWithRead).Read(kb, func(istructs.IKey, istructs.IStateValue) error { return nil })
+		require.ErrorIs(err, ErrNotSupported)
+	})
+
+	t.Run("Should put index values after index", func(t *testing.T) {
+		kb := storage.NewKeyBuilder(testRecordQName1, nil)
+		require.Panics(func() { kb.PutInt64("number", 1) }, "index must be specified first")
+
+		kb.PutString(sys.Storage_Record_Field_Index, "ByNumber")
+		kb.PutInt64("number", 1)
+
+		other := storage.NewKeyBuilder(testRecordQName1, nil)
+		other.PutString(sys.Storage_Record_Field_Index, "ByNumber")
+		require.False(kb.Equals(other))
+		other.PutInt64("number", 1)
+		require.True(kb.Equals(other))
+		require.Contains(kb.String(), "index:ByNumber")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/extensionpoints"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
@@ -23,18 +24,21 @@ import (
 	"github.com/voedger/voedger/pkg/sys/builtin"
 	"github.com/voedger/voedger/pkg/sys/collection"
 	"github.com/voedger/voedger/pkg/sys/describe"
+	"github.com/voedger/voedger/pkg/sys/indexes"
 	"github.com/voedger/voedger/pkg/sys/invite"
 	"github.com/voedger/voedger/pkg/sys/journal"
 	"github.com/voedger/voedger/pkg/sys/smtp"
 	"github.com/voedger/voedger/pkg/sys/sqlquery"
 	"github.com/voedger/voedger/pkg/sys/uniques"
 	"github.com/voedger/voedger/pkg/sys/verifier"
+	"github.com/voedger/voedger/pkg/sys/webhooks"
 	"github.com/voedger/voedger/pkg/sys/workspace"
 )
 
 func ProvideStateless(sr istructsmem.IStatelessResources, smtpCfg smtp.Cfg, eps map[appdef.AppQName]extensionpoints.IExtensionPoint, buildInfo *debug.BuildInfo,
 	storageProvider istorage.IAppStorageProvider, wsPostInitFunc workspace.WSPostInitFunc, time timeu.ITime,
-	itokens itokens.ITokens, federation federation.IFederation, asp istructs.IAppStructsProvider, atf payloads.IAppTokensFactory) {
+	itokens itokens.ITokens, federation federation.IFederation, asp istructs.IAppStructsProvider, atf payloads.IAppTokensFactory, sessions isessions.ISessions,
+	webhooksAllowPrivateAddresses bool) {
 	blobber.ProvideBlobberCmds(sr)
 	collection.Provide(sr)
 	journal.Provide(sr, eps)
@@ -42,10 +46,12 @@ func ProvideStateless(sr istructsmem.IStatelessResources, smtpCfg smtp.Cfg, eps
 	workspace.Provide(sr, time, itokens, federation, itokens, wsPostInitFunc, eps)
 	sqlquery.Provide(sr, federation, itokens)
 	verifier.Provide(sr, itokens, federation, asp, smtpCfg)
-	authnz.Provide(sr, itokens, atf)
+	authnz.Provide(sr, itokens, atf, sessions)
 	invite.Provide(sr, time, federation, itokens, smtpCfg)
 	uniques.Provide(sr)
+	indexes.Provide(sr, federation, itokens)
 	describe.Provide(sr)
+	webhooks.Provide(sr, time, webhooksAllowPrivateAddresses)
 }
 
 func Provide(cfg *istructsmem.AppConfigType) parser.PackageFS {
@@ -53,7 +59,9 @@ func Provide(cfg *istructsmem.AppConfigType) parser.PackageFS {
 	builtin.ProvideCUDValidators(cfg)
 	builtin.ProvideSysIsActiveValidation(cfg)
 	uniques.ProvideEventValidator(cfg)
+	indexes.ProvideEventValidator(cfg)
 	blobber.ProvideBlobberCUDValidators(cfg)
+	webhooks.ProvideCUDValidator(cfg)
 	return parser.PackageFS{
 		Path: appdef.SysPackage,
 		FS:   sys.SysFS,
@@ -19,6 +19,7 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
 )
 
 func applyUniques(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) (err error) {
@@ -157,13 +158,15 @@ func getUniqueKeyValuesFromMap(values map[string]interface{}, uniqueFields []app
 		if err := coreutils.CheckValueByKind(val, uniqueField.DataKind()); err != nil {
 			return nil, err
 		}
-		writeUniqueKeyValue(uniqueField, val, buf, uniqueFields)
+		if err := writeUniqueKeyValue(uniqueField, val, buf, uniqueFields); err != nil {
+			return nil, err
+		}
 	}
 	return buf.Bytes(), checkUniqueKeyLen(buf, uniqueQName)
 }
 
 // uniqueFields is provided just to determine if should handle backward compatibility
-func writeUniqueKeyValue(uniqueField appdef.IField, value interface{}, buf *bytes.Buffer, uniqueFields []appdef.IField) {
+func writeUniqueKeyValue(uniqueField appdef.IField, value interface{}, buf *bytes.Buffer, uniqueFields []appdef.IField) error {
 	switch uniqueField.DataKind() {
 	case appdef.DataKind_string:
 		if len(uniqueFields) > 1 {
@@ -186,9 +189,44 @@ func writeUniqueKeyValue(uniqueField appdef.IField, value interface{}, buf *byte
 			qNameStr = value.(string)
 		}
 		buf.WriteString(qNameStr)
+	case appdef.DataKind_decimal, appdef.DataKind_timestamp, appdef.DataKind_date, appdef.DataKind_interval:
+		v, err := int64UniqueKeyValue(uniqueField, value)
+		if err != nil {
+			return err
+		}
+		binary.Write(buf, binary.BigEndian, v) // nolint
+	case appdef.DataKind_uuid:
+		// uuid values are read from records as canonical strings, but are written to the unique key as stored 16 bytes
+		b, ok := value.([]byte)
+		if !ok {
+			var err error
+			if b, err = utils.ParseUUID(value.(string)); err != nil {
+				return err
+			}
+		}
+		buf.Write(b)
 	default:
 		binary.Write(buf, binary.BigEndian, value) // nolint
 	}
+	return nil
+}
+
+// decimal and temporal values are read from records as strings, but are written to the unique key as stored int64
+func int64UniqueKeyValue(uniqueField appdef.IField, value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		if uniqueField.DataKind() == appdef.DataKind_date {
+			return utils.TruncateToDate(v), nil
+		}
+		return v, nil
+	case string:
+		if uniqueField.DataKind() == appdef.DataKind_decimal {
+			_, scale := appdef.DecimalPrecisionScale(uniqueField.Constraints())
+			return utils.ParseDecimal(v, scale)
+		}
+		return coreutils.ParseTemporal(uniqueField.DataKind(), v)
+	}
+	return 0, fmt.Errorf("%w: %T for %s unique field %s", coreutils.ErrFieldTypeMismatch, value, uniqueField.DataKind().TrimString(), uniqueField.Name())
 }
 
 func checkUniqueKeyLen(buf *bytes.Buffer, uniqueQName appdef.QName) error {
@@ -203,7 +241,9 @@ func getUniqueKeyValuesFromRec(rec istructs.IRowReader, uniqueFields []appdef.IF
 	buf := bytes.NewBuffer(nil)
 	for _, uniqueField := range uniqueFields {
 		val := coreutils.ReadByKind(uniqueField.Name(), uniqueField.DataKind(), rec)
-		writeUniqueKeyValue(uniqueField, val, buf, uniqueFields)
+		if err := writeUniqueKeyValue(uniqueField, val, buf, uniqueFields); err != nil {
+			return nil, err
+		}
 	}
 	return buf.Bytes(), checkUniqueKeyLen(buf, uniqueQName)
 }
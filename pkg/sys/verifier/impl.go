@@ -165,13 +165,22 @@ func provideIVVTExec(itokens itokens.ITokens, asp istructs.IAppStructsProvider)
 		}
 
 		// code ok -> reset per-profile rate limit
+		// only the bucket of the current profile is reset, buckets of other profiles are not affected
 		appBuckets := istructsmem.IBucketsFromIAppStructs(as)
 		rateLimitName := istructsmem.GetFunctionRateLimitName(QNameQueryIssueVerifiedValueToken, istructs.RateLimitKind_byWorkspace)
-		appBuckets.ResetRateBuckets(rateLimitName, irates.BucketState{
-			Period:             RateLimit_IssueVerifiedValueToken.Period,
-			MaxTokensPerPeriod: RateLimit_IssueVerifiedValueToken.MaxAllowedPerDuration,
-			TakenTokens:        0,
-		})
+		for _, bucketKey := range istructsmem.FunctionRateLimitsBucketKeys(as, args.WSID) {
+			if bucketKey.RateLimitName != rateLimitName {
+				continue
+			}
+			if err := appBuckets.SetBucketState(bucketKey, irates.BucketState{
+				Period:             RateLimit_IssueVerifiedValueToken.Period,
+				MaxTokensPerPeriod: RateLimit_IssueVerifiedValueToken.MaxAllowedPerDuration,
+				TakenTokens:        0,
+			}); err != nil {
+				// notest
+				return err
+			}
+		}
 
 		return callback(&ivvtResult{verifiedValueToken: verifiedValueToken})
 	}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+var (
+	QNameCDocWebhook                 = appdef.NewQName(appdef.SysPackage, "Webhook")
+	QNameViewWebhookDeliveryAttempts = appdef.NewQName(appdef.SysPackage, "WebhookDeliveryAttempts")
+	QNameViewWebhookDeadLetters      = appdef.NewQName(appdef.SysPackage, "WebhookDeadLetters")
+	QNameCmdReplayWebhookDelivery    = appdef.NewQName(appdef.SysPackage, "ReplayWebhookDelivery")
+	qNameAPDeliverWebhooks           = appdef.NewQName(appdef.SysPackage, "DeliverWebhooks")
+	qNameViewWebhookRetries          = appdef.NewQName(appdef.SysPackage, "WebhookRetries")
+	qNameViewWebhookRetriesCursor    = appdef.NewQName(appdef.SysPackage, "WebhookRetriesCursor")
+	qNameJobRetryWebhookDeliveries   = appdef.NewQName(appdef.SysPackage, "RetryWebhookDeliveries")
+)
+
+const (
+	// cdoc.sys.Webhook
+	Field_URL         = "URL"
+	Field_Secret      = "Secret"
+	Field_EventQNames = "EventQNames"
+	Field_MaxAttempts = "MaxAttempts"
+
+	// view.sys.WebhookDeliveryAttempts, view.sys.WebhookDeadLetters
+	Field_WebhookID   = "WebhookID"
+	Field_WLogOffset  = "WLogOffset"
+	Field_Attempt     = "Attempt"
+	Field_EventQName  = "EventQName"
+	Field_AttemptedAt = "AttemptedAt"
+	Field_StatusCode  = "StatusCode"
+	Field_Error       = "Error"
+	Field_Delivered   = "Delivered"
+	Field_Attempts    = "Attempts"
+	Field_FailedAt    = "FailedAt"
+	Field_LastError   = "LastError"
+	Field_Replayed    = "Replayed"
+
+	// view.sys.WebhookRetries, view.sys.WebhookRetriesCursor
+	field_DueAt       = "DueAt"
+	field_WebhookWSID = "WebhookWSID"
+	field_LastAttempt = "LastAttempt"
+	field_Replay      = "Replay"
+	field_Dummy       = "Dummy"
+	field_Dummy2      = "Dummy2"
+)
+
+const (
+	DefaultMaxAttempts = 5
+	MaxMaxAttempts     = 10
+
+	// delay before the 2nd attempt, doubled for each next one
+	// attempts are made by job.sys.RetryWebhookDeliveries, so delays are not less than its period
+	InitialBackoff = retryPeriod
+	MaxBackoff     = time.Hour
+
+	deliveryTimeout = 10 * time.Second
+
+	// period of job.sys.RetryWebhookDeliveries, must match its cron schedule
+	retryPeriod = time.Minute
+
+	// retries due earlier than the first run of job.sys.RetryWebhookDeliveries are skipped
+	retriesLookback = time.Hour
+
+	// each retry takes up to 2 intents and up to deliveryTimeout
+	maxRetriesPerRun = 32
+
+	maxErrorLen        = 1024
+	eventQNamesSep     = ","
+	signatureAlgPrefix = "sha256="
+)
+
+// headers of the delivery request
+const (
+	Header_WebhookID = "X-Voedger-Webhook-ID"
+	Header_Delivery  = "X-Voedger-Delivery"
+	Header_Event     = "X-Voedger-Event"
+	Header_Timestamp = "X-Voedger-Timestamp"
+
+	// sha256=hex(HMAC-SHA256(Secret, Timestamp + "." + body))
+	Header_Signature = "X-Voedger-Signature"
+)
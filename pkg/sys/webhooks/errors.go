@@ -0,0 +1,16 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import "errors"
+
+var (
+	ErrInvalidWebhookURL  = errors.New("webhook URL must be an absolute http or https URL")
+	ErrInvalidMaxAttempts = errors.New("webhook MaxAttempts is out of range")
+	ErrInvalidEventQNames = errors.New("webhook EventQNames must be comma-separated QNames")
+	ErrDeadLetterNotFound = errors.New("webhook dead letter not found")
+	ErrDeadLetterReplayed = errors.New("webhook dead letter is replayed already")
+	errUnexpectedStatus   = errors.New("unexpected status code")
+)
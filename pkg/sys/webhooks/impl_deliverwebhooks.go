@@ -0,0 +1,300 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/collection"
+)
+
+func asyncProjectorDeliverWebhooks(time timeu.ITime, allowPrivateAddresses bool) istructs.Projector {
+	return istructs.Projector{
+		Name: qNameAPDeliverWebhooks,
+		Func: deliverWebhooksProjector(time, allowPrivateAddresses),
+	}
+}
+
+// AFTER INSERT OR UPDATE OR ACTIVATE OR DEACTIVATE ON (CRecord, WRecord) OR
+// AFTER EXECUTE WITH PARAM ON ODoc OR
+// AFTER EXECUTE ON (ReplayWebhookDelivery)
+//
+// makes the first attempt only, the next ones are queued to view.sys.WebhookRetries and made by job.sys.RetryWebhookDeliveries
+// so a failing receiver does not delay the next events of the partition
+func deliverWebhooksProjector(time timeu.ITime, allowPrivateAddresses bool) func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+	return func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+		d := &deliverer{time: time, state: s, intents: intents, wsid: event.Workspace(), allowPrivateAddresses: allowPrivateAddresses}
+		if event.QName() == QNameCmdReplayWebhookDelivery {
+			return d.replay(event.ArgumentObject().AsRecordID(Field_WebhookID), istructs.Offset(event.ArgumentObject().AsInt64(Field_WLogOffset))) // nolint G115
+		}
+		if affectsWebhooks(event) {
+			return nil
+		}
+		subs, err := activeSubscriptions(s)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			if !sub.matches(event) {
+				continue
+			}
+			// the event could be re-projected after restart
+			delivered, err := d.attemptExists(sub.id, event.WLogOffset(), 1)
+			if err != nil {
+				return err
+			}
+			if delivered {
+				continue
+			}
+			if err := d.attempt(sub, event.WLogOffset(), event, 1, sub.maxAttempts, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// wsid is the workspace of the webhook, differs from the state workspace for job.sys.RetryWebhookDeliveries
+type deliverer struct {
+	time                  timeu.ITime
+	state                 istructs.IState
+	intents               istructs.IIntents
+	wsid                  istructs.WSID
+	allowPrivateAddresses bool
+}
+
+// active cdoc.sys.Webhook records of the workspace
+func activeSubscriptions(s istructs.IState) (res []subscription, err error) {
+	kb, err := s.KeyBuilder(sys.Storage_View, collection.QNameCollectionView)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt32(collection.Field_PartKey, collection.PartitionKeyCollection)
+	kb.PutQName(collection.Field_DocQName, QNameCDocWebhook)
+	err = s.Read(kb, func(_ istructs.IKey, value istructs.IStateValue) error {
+		rec := value.(istructs.IStateViewValue).AsRecord(collection.Field_Record)
+		if rec.AsBool(appdef.SystemField_IsActive) {
+			res = append(res, newSubscription(rec))
+		}
+		return nil
+	})
+	return res, err
+}
+
+// ok == false -> the webhook is not found or deactivated
+func (d *deliverer) activeSubscription(webhookID istructs.RecordID) (sub subscription, ok bool, err error) {
+	kb, err := d.state.KeyBuilder(sys.Storage_Record, QNameCDocWebhook)
+	if err != nil {
+		return sub, false, err
+	}
+	kb.PutInt64(sys.Storage_Record_Field_WSID, int64(d.wsid)) // nolint G115
+	kb.PutRecordID(sys.Storage_Record_Field_ID, webhookID)
+	sv, ok, err := d.state.CanExist(kb)
+	if err != nil || !ok || !sv.AsBool(appdef.SystemField_IsActive) {
+		return sub, false, err
+	}
+	return newSubscription(sv), true, nil
+}
+
+// ok == false -> the event is not found in WLog
+func (d *deliverer) wlogEvent(wlogOffset istructs.Offset) (event istructs.IDbEvent, ok bool, err error) {
+	kb, err := d.state.KeyBuilder(sys.Storage_WLog, appdef.NullQName)
+	if err != nil {
+		return nil, false, err
+	}
+	kb.PutInt64(sys.Storage_WLog_Field_WSID, int64(d.wsid))       // nolint G115
+	kb.PutInt64(sys.Storage_WLog_Field_Offset, int64(wlogOffset)) // nolint G115
+	sv, ok, err := d.state.CanExist(kb)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return sv.(istructs.IStateWLogValue).AsEvent(), true, nil
+}
+
+// makes and records the attempt
+// failed -> the next attempt is queued or, if the attempt is the lastAttempt, the dead letter is put
+// replay is true -> the attempt continues the replay of the dead letter
+func (d *deliverer) attempt(sub subscription, wlogOffset istructs.Offset, event istructs.IDbEvent, attempt, lastAttempt int, replay bool) error {
+	payload := Payload{
+		DeliveryID:   deliveryID(d.wsid, sub.id, wlogOffset),
+		WebhookID:    sub.id,
+		WSID:         d.wsid,
+		WLogOffset:   wlogOffset,
+		QName:        event.QName().String(),
+		RegisteredAt: event.RegisteredAt(),
+	}
+	appDef := d.state.AppStructs().AppDef()
+	if args := coreutils.ObjectToMap(event.ArgumentObject(), appDef); len(args) > 0 {
+		payload.Args = args
+	}
+	if cuds := coreutils.CUDsToMap(event, appDef); len(cuds) > 0 {
+		payload.CUDs = cuds
+	}
+	body, err := json.Marshal(&payload)
+	if err != nil {
+		// notest
+		return err
+	}
+
+	res := deliveryResult{}
+	attemptedAt := d.time.Now().UnixMilli()
+	if res.statusCode, res.error, err = d.post(sub, payload, body, attemptedAt); err != nil {
+		return err
+	}
+	res.delivered = len(res.error) == 0
+	if err := d.putAttempt(sub.id, wlogOffset, attempt, event.QName(), attemptedAt, res); err != nil {
+		return err
+	}
+
+	switch {
+	case res.delivered && replay:
+		failedAt, err := d.deadLetterFailedAt(sub.id, wlogOffset)
+		if err != nil {
+			return err
+		}
+		return d.putDeadLetter(sub.id, wlogOffset, event.QName(), attempt, failedAt, res.error, true)
+	case res.delivered:
+		return nil
+	case attempt < lastAttempt:
+		nextAttemptAt := attemptedAt + backoff(attempt).Milliseconds()
+		return d.putRetry(retry{
+			dueAt:       retryBucket(nextAttemptAt),
+			webhookWSID: d.wsid,
+			webhookID:   sub.id,
+			wlogOffset:  wlogOffset,
+			attempt:     attempt + 1,
+			lastAttempt: lastAttempt,
+			replay:      replay,
+		})
+	}
+	logger.Warning(fmt.Sprintf("webhook %d: delivery of event %s wlogOffset %d to %s failed after %d attempts: %s",
+		sub.id, event.QName(), wlogOffset, sub.url, attempt, res.error))
+	return d.putDeadLetter(sub.id, wlogOffset, event.QName(), attempt, attemptedAt, res.error, false)
+}
+
+// returns the error text if the delivery is failed
+func (d *deliverer) post(sub subscription, payload Payload, body []byte, timestamp int64) (statusCode int32, errStr string, err error) {
+	kb, err := d.state.KeyBuilder(sys.Storage_HTTP, appdef.NullQName)
+	if err != nil {
+		return 0, "", err
+	}
+	ts := strconv.FormatInt(timestamp, utils.DecimalBase)
+	kb.PutString(sys.Storage_HTTP_Field_Method, http.MethodPost)
+	kb.PutString(sys.Storage_HTTP_Field_URL, sub.url)
+	kb.PutBytes(sys.Storage_HTTP_Field_Body, body)
+	kb.PutInt64(sys.Storage_HTTP_Field_HTTPClientTimeoutMilliseconds, deliveryTimeout.Milliseconds())
+	kb.PutBool(sys.Storage_HTTP_Field_HandleErrors, true)
+	// the URL is set by the workspace owner, so the VVM internal network must not be reachable by it
+	kb.PutBool(sys.Storage_HTTP_Field_PublicAddressesOnly, !d.allowPrivateAddresses)
+	kb.PutString(sys.Storage_HTTP_Field_Header, coreutils.ContentType+": "+coreutils.ContentType_ApplicationJSON)
+	kb.PutString(sys.Storage_HTTP_Field_Header, Header_WebhookID+": "+utils.UintToString(sub.id))
+	kb.PutString(sys.Storage_HTTP_Field_Header, Header_Delivery+": "+payload.DeliveryID)
+	kb.PutString(sys.Storage_HTTP_Field_Header, Header_Event+": "+payload.QName)
+	kb.PutString(sys.Storage_HTTP_Field_Header, Header_Timestamp+": "+ts)
+	kb.PutString(sys.Storage_HTTP_Field_Header, Header_Signature+": "+Signature(sub.secret, ts, body))
+	err = d.state.Read(kb, func(_ istructs.IKey, value istructs.IStateValue) error {
+		if errStr = value.AsString(sys.Storage_HTTP_Field_Error); len(errStr) > 0 {
+			return nil
+		}
+		statusCode = value.AsInt32(sys.Storage_HTTP_Field_StatusCode)
+		if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+			errStr = fmt.Sprintf("%s %d: %s", errUnexpectedStatus, statusCode, value.AsString(sys.Storage_HTTP_Field_Body))
+		}
+		return nil
+	})
+	return statusCode, truncate(errStr), err
+}
+
+func (d *deliverer) attemptKey(webhookID istructs.RecordID, wlogOffset istructs.Offset, attempt int) (istructs.IStateKeyBuilder, error) {
+	kb, err := d.state.KeyBuilder(sys.Storage_View, QNameViewWebhookDeliveryAttempts)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt64(sys.Storage_View_Field_WSID, int64(d.wsid)) // nolint G115
+	kb.PutRecordID(Field_WebhookID, webhookID)
+	kb.PutInt64(Field_WLogOffset, int64(wlogOffset)) // nolint G115
+	kb.PutInt32(Field_Attempt, int32(attempt))       // nolint G115
+	return kb, nil
+}
+
+func (d *deliverer) attemptExists(webhookID istructs.RecordID, wlogOffset istructs.Offset, attempt int) (bool, error) {
+	kb, err := d.attemptKey(webhookID, wlogOffset, attempt)
+	if err != nil {
+		return false, err
+	}
+	_, ok, err := d.state.CanExist(kb)
+	return ok, err
+}
+
+func (d *deliverer) putAttempt(webhookID istructs.RecordID, wlogOffset istructs.Offset, attempt int, eventQName appdef.QName,
+	attemptedAt int64, res deliveryResult) error {
+	kb, err := d.attemptKey(webhookID, wlogOffset, attempt)
+	if err != nil {
+		return err
+	}
+	vb, err := d.intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	vb.PutQName(Field_EventQName, eventQName)
+	vb.PutInt64(Field_AttemptedAt, attemptedAt)
+	vb.PutInt32(Field_StatusCode, res.statusCode)
+	vb.PutString(Field_Error, res.error)
+	vb.PutBool(Field_Delivered, res.delivered)
+	return nil
+}
+
+func (d *deliverer) deadLetterKey(webhookID istructs.RecordID, wlogOffset istructs.Offset) (istructs.IStateKeyBuilder, error) {
+	kb, err := d.state.KeyBuilder(sys.Storage_View, QNameViewWebhookDeadLetters)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt64(sys.Storage_View_Field_WSID, int64(d.wsid)) // nolint G115
+	kb.PutRecordID(Field_WebhookID, webhookID)
+	kb.PutInt64(Field_WLogOffset, int64(wlogOffset)) // nolint G115
+	return kb, nil
+}
+
+// the failure time of the dead letter being replayed
+func (d *deliverer) deadLetterFailedAt(webhookID istructs.RecordID, wlogOffset istructs.Offset) (int64, error) {
+	kb, err := d.deadLetterKey(webhookID, wlogOffset)
+	if err != nil {
+		return 0, err
+	}
+	sv, ok, err := d.state.CanExist(kb)
+	if err != nil || !ok {
+		// notest
+		return 0, err
+	}
+	return sv.AsInt64(Field_FailedAt), nil
+}
+
+func (d *deliverer) putDeadLetter(webhookID istructs.RecordID, wlogOffset istructs.Offset, eventQName appdef.QName, attempts int,
+	failedAt int64, lastError string, replayed bool) error {
+	kb, err := d.deadLetterKey(webhookID, wlogOffset)
+	if err != nil {
+		return err
+	}
+	vb, err := d.intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	vb.PutQName(Field_EventQName, eventQName)
+	vb.PutInt32(Field_Attempts, int32(attempts)) // nolint G115
+	vb.PutInt64(Field_FailedAt, failedAt)
+	vb.PutString(Field_LastError, lastError)
+	vb.PutBool(Field_Replayed, replayed)
+	return nil
+}
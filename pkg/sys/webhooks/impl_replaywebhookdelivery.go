@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideCmdReplayWebhookDelivery(sr istructsmem.IStatelessResources) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		QNameCmdReplayWebhookDelivery,
+		execCmdReplayWebhookDelivery,
+	))
+}
+
+// the delivery itself is made by ap.sys.DeliverWebhooks
+func execCmdReplayWebhookDelivery(args istructs.ExecCommandArgs) (err error) {
+	webhookID := args.ArgumentObject.AsRecordID(Field_WebhookID)
+	kbWebhook, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocWebhook)
+	if err != nil {
+		return err
+	}
+	kbWebhook.PutRecordID(sys.Storage_Record_Field_ID, webhookID)
+	svWebhook, ok, err := args.State.CanExist(kbWebhook)
+	if err != nil {
+		return err
+	}
+	if !ok || svWebhook.AsQName(appdef.SystemField_QName) != QNameCDocWebhook {
+		return coreutils.NewHTTPErrorf(http.StatusNotFound, fmt.Sprintf("webhook %d not found", webhookID))
+	}
+
+	wlogOffset := args.ArgumentObject.AsInt64(Field_WLogOffset)
+	if wlogOffset == 0 {
+		return nil
+	}
+	kbDeadLetter, err := args.State.KeyBuilder(sys.Storage_View, QNameViewWebhookDeadLetters)
+	if err != nil {
+		return err
+	}
+	kbDeadLetter.PutRecordID(Field_WebhookID, webhookID)
+	kbDeadLetter.PutInt64(Field_WLogOffset, wlogOffset)
+	svDeadLetter, ok, err := args.State.CanExist(kbDeadLetter)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return coreutils.NewHTTPError(http.StatusNotFound, ErrDeadLetterNotFound)
+	}
+	if svDeadLetter.AsBool(Field_Replayed) {
+		return coreutils.NewHTTPError(http.StatusBadRequest, ErrDeadLetterReplayed)
+	}
+	return nil
+}
+
+type deadLetter struct {
+	wlogOffset istructs.Offset
+	attempts   int
+}
+
+// wlogOffset 0 -> all not replayed dead letters of the webhook
+// the first attempt of each dead letter is made here, the next ones are queued as for the regular delivery
+func (d *deliverer) replay(webhookID istructs.RecordID, wlogOffset istructs.Offset) error {
+	sub, ok, err := d.activeSubscription(webhookID)
+	if err != nil || !ok {
+		// deactivated after the replay is requested
+		return err
+	}
+
+	deadLetters, err := d.deadLetters(webhookID, wlogOffset)
+	if err != nil {
+		return err
+	}
+	for _, dl := range deadLetters {
+		// the event could be re-projected after restart
+		replayed, err := d.attemptExists(webhookID, dl.wlogOffset, dl.attempts+1)
+		if err != nil {
+			return err
+		}
+		if replayed {
+			continue
+		}
+		event, ok, err := d.wlogEvent(dl.wlogOffset)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// notest
+			logger.Error(fmt.Sprintf("webhook %d: event wlogOffset %d to replay is not found in WLog", webhookID, dl.wlogOffset))
+			continue
+		}
+		if err := d.attempt(sub, dl.wlogOffset, event, dl.attempts+1, dl.attempts+sub.maxAttempts, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *deliverer) deadLetters(webhookID istructs.RecordID, wlogOffset istructs.Offset) (res []deadLetter, err error) {
+	add := func(wlogOffset istructs.Offset, value istructs.IStateValue) {
+		if !value.AsBool(Field_Replayed) {
+			res = append(res, deadLetter{
+				wlogOffset: wlogOffset,
+				attempts:   int(value.AsInt32(Field_Attempts)),
+			})
+		}
+	}
+	if wlogOffset != 0 {
+		kb, err := d.deadLetterKey(webhookID, wlogOffset)
+		if err != nil {
+			return nil, err
+		}
+		value, ok, err := d.state.CanExist(kb)
+		if err != nil || !ok {
+			return nil, err
+		}
+		add(wlogOffset, value)
+		return res, nil
+	}
+	kb, err := d.state.KeyBuilder(sys.Storage_View, QNameViewWebhookDeadLetters)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt64(sys.Storage_View_Field_WSID, int64(d.wsid)) // nolint G115
+	kb.PutRecordID(Field_WebhookID, webhookID)
+	err = d.state.Read(kb, func(key istructs.IKey, value istructs.IStateValue) error {
+		add(istructs.Offset(key.AsInt64(Field_WLogOffset)), value) // nolint G115
+		return nil
+	})
+	return res, err
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func jobRetryWebhookDeliveries(time timeu.ITime, allowPrivateAddresses bool) istructsmem.BuiltinJob {
+	return istructsmem.BuiltinJob{
+		Name: qNameJobRetryWebhookDeliveries,
+		Func: retryWebhookDeliveries(time, allowPrivateAddresses),
+	}
+}
+
+// runs in each app workspace and makes the attempts queued to its view.sys.WebhookRetries by ap.sys.DeliverWebhooks
+// view.sys.WebhookRetriesCursor holds the first DueAt bucket to be processed, processed retries are not deleted
+// the recent buckets are processed on the next runs since they could be written yet by projectors of other partitions
+func retryWebhookDeliveries(time timeu.ITime, allowPrivateAddresses bool) func(s istructs.IState, intents istructs.IIntents) error {
+	return func(s istructs.IState, intents istructs.IIntents) error {
+		last := retryBucket(time.Now().UnixMilli()) - 2*retryPeriod.Milliseconds()
+		dueAt, ok, err := retriesCursor(s)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			dueAt = last - retriesLookback.Milliseconds()
+		}
+		attempts := 0
+	buckets:
+		for ; dueAt <= last; dueAt += retryPeriod.Milliseconds() {
+			retries, err := dueRetries(s, dueAt)
+			if err != nil {
+				return err
+			}
+			for _, r := range retries {
+				if attempts == maxRetriesPerRun {
+					// the rest of the bucket is processed on the next run, the made attempts are skipped then
+					break buckets
+				}
+				d := &deliverer{time: time, state: s, intents: intents, wsid: r.webhookWSID, allowPrivateAddresses: allowPrivateAddresses}
+				attempted, err := d.retry(r)
+				if err != nil {
+					return err
+				}
+				if attempted {
+					attempts++
+				}
+			}
+		}
+		return putRetriesCursor(s, intents, dueAt)
+	}
+}
+
+// attempted == false -> the attempt is made already or is not needed anymore
+func (d *deliverer) retry(r retry) (attempted bool, err error) {
+	exists, err := d.attemptExists(r.webhookID, r.wlogOffset, r.attempt)
+	if err != nil || exists {
+		return false, err
+	}
+	sub, ok, err := d.activeSubscription(r.webhookID)
+	if err != nil || !ok {
+		// deactivated -> the retry is dropped
+		return false, err
+	}
+	event, ok, err := d.wlogEvent(r.wlogOffset)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		// notest
+		logger.Error(fmt.Sprintf("webhook %d: event wlogOffset %d to retry is not found in WLog of workspace %d", r.webhookID, r.wlogOffset, r.webhookWSID))
+		return false, nil
+	}
+	return true, d.attempt(sub, r.wlogOffset, event, r.attempt, r.lastAttempt, r.replay)
+}
+
+// the retry is put to the app workspace of the webhook workspace
+func (d *deliverer) putRetry(r retry) error {
+	kb, err := d.state.KeyBuilder(sys.Storage_View, qNameViewWebhookRetries)
+	if err != nil {
+		return err
+	}
+	appWSID := coreutils.GetAppWSID(d.wsid, d.state.AppStructs().NumAppWorkspaces())
+	kb.PutInt64(sys.Storage_View_Field_WSID, int64(appWSID)) // nolint G115
+	kb.PutInt64(field_DueAt, r.dueAt)
+	kb.PutInt64(field_WebhookWSID, int64(r.webhookWSID)) // nolint G115
+	kb.PutRecordID(Field_WebhookID, r.webhookID)
+	kb.PutInt64(Field_WLogOffset, int64(r.wlogOffset)) // nolint G115
+	vb, err := d.intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	vb.PutInt32(Field_Attempt, int32(r.attempt))         // nolint G115
+	vb.PutInt32(field_LastAttempt, int32(r.lastAttempt)) // nolint G115
+	vb.PutBool(field_Replay, r.replay)
+	return nil
+}
+
+// retries of the bucket of the current app workspace
+func dueRetries(s istructs.IState, dueAt int64) (res []retry, err error) {
+	kb, err := s.KeyBuilder(sys.Storage_View, qNameViewWebhookRetries)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt64(field_DueAt, dueAt)
+	err = s.Read(kb, func(key istructs.IKey, value istructs.IStateValue) error {
+		res = append(res, retry{
+			dueAt:       dueAt,
+			webhookWSID: istructs.WSID(key.AsInt64(field_WebhookWSID)), // nolint G115
+			webhookID:   key.AsRecordID(Field_WebhookID),
+			wlogOffset:  istructs.Offset(key.AsInt64(Field_WLogOffset)), // nolint G115
+			attempt:     int(value.AsInt32(Field_Attempt)),
+			lastAttempt: int(value.AsInt32(field_LastAttempt)),
+			replay:      value.AsBool(field_Replay),
+		})
+		return nil
+	})
+	return res, err
+}
+
+func retriesCursorKey(s istructs.IState) (istructs.IStateKeyBuilder, error) {
+	kb, err := s.KeyBuilder(sys.Storage_View, qNameViewWebhookRetriesCursor)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt32(field_Dummy, 1)
+	kb.PutInt32(field_Dummy2, 1)
+	return kb, nil
+}
+
+// ok == false -> the job is run the first time in the app workspace
+func retriesCursor(s istructs.IState) (dueAt int64, ok bool, err error) {
+	kb, err := retriesCursorKey(s)
+	if err != nil {
+		return 0, false, err
+	}
+	sv, ok, err := s.CanExist(kb)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	return sv.AsInt64(field_DueAt), true, nil
+}
+
+func putRetriesCursor(s istructs.IState, intents istructs.IIntents, dueAt int64) error {
+	kb, err := retriesCursorKey(s)
+	if err != nil {
+		return err
+	}
+	vb, err := intents.NewValue(kb)
+	if err != nil {
+		return err
+	}
+	vb.PutInt64(field_DueAt, dueAt)
+	return nil
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// validates fields of cdoc.sys.Webhook that are specified on insert or update
+func provideWebhookValidator() istructs.CUDValidator {
+	return istructs.CUDValidator{
+		Match: func(cud istructs.ICUDRow, _ istructs.WSID, _ appdef.QName) bool {
+			return cud.QName() == QNameCDocWebhook
+		},
+		Validate: func(_ context.Context, _ istructs.IAppStructs, cudRow istructs.ICUDRow, _ istructs.WSID, _ appdef.QName) (err error) {
+			cudRow.SpecifiedValues(func(field appdef.IField, value any) bool {
+				switch field.Name() {
+				case Field_URL:
+					err = validateURL(value.(string))
+				case Field_EventQNames:
+					_, err = parseEventQNames(value.(string))
+				case Field_MaxAttempts:
+					err = validateMaxAttempts(value.(int32))
+				}
+				return err == nil
+			})
+			if err != nil {
+				return coreutils.WrapSysError(err, http.StatusBadRequest)
+			}
+			return nil
+		},
+	}
+}
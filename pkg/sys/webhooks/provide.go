@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+// allowPrivateAddresses is false -> webhooks are not delivered to loopback, private and link-local addresses
+func Provide(sr istructsmem.IStatelessResources, time timeu.ITime, allowPrivateAddresses bool) {
+	provideCmdReplayWebhookDelivery(sr)
+	sr.AddProjectors(appdef.SysPackagePath, asyncProjectorDeliverWebhooks(time, allowPrivateAddresses))
+	sr.AddJobs(appdef.SysPackagePath, jobRetryWebhookDeliveries(time, allowPrivateAddresses))
+}
+
+func ProvideCUDValidator(cfg *istructsmem.AppConfigType) {
+	cfg.AddCUDValidators(provideWebhookValidator())
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// body of the delivery request
+type Payload struct {
+	DeliveryID   string                   `json:"deliveryID"`
+	WebhookID    istructs.RecordID        `json:"webhookID"`
+	WSID         istructs.WSID            `json:"wsid"`
+	WLogOffset   istructs.Offset          `json:"wlogOffset"`
+	QName        string                   `json:"qname"`
+	RegisteredAt istructs.UnixMilli       `json:"registeredAt"`
+	Args         map[string]interface{}   `json:"args,omitempty"`
+	CUDs         []map[string]interface{} `json:"cuds,omitempty"`
+}
+
+// active cdoc.sys.Webhook
+type subscription struct {
+	id          istructs.RecordID
+	url         string
+	secret      string
+	eventQNames appdef.QNames // empty -> all events
+	maxAttempts int
+}
+
+type deliveryResult struct {
+	delivered  bool
+	statusCode int32
+	error      string
+}
+
+// view.sys.WebhookRetries record
+type retry struct {
+	dueAt       int64
+	webhookWSID istructs.WSID
+	webhookID   istructs.RecordID
+	wlogOffset  istructs.Offset
+	attempt     int
+	lastAttempt int
+	replay      bool
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Signature returns the value of the X-Voedger-Signature header
+// receivers should calc the same over the received X-Voedger-Timestamp and body and compare using hmac.Equal
+func Signature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureAlgPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// delay after the failed attempt before the next one
+// attempt is 1-based
+func backoff(attempt int) time.Duration {
+	delay := InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= MaxBackoff {
+			return MaxBackoff
+		}
+	}
+	return delay
+}
+
+func deliveryID(wsid istructs.WSID, webhookID istructs.RecordID, wlogOffset istructs.Offset) string {
+	return fmt.Sprintf("%d-%d-%d", wsid, webhookID, wlogOffset)
+}
+
+func parseEventQNames(str string) (appdef.QNames, error) {
+	if len(str) == 0 {
+		return nil, nil
+	}
+	strs := strings.Split(str, eventQNamesSep)
+	for i := range strs {
+		strs[i] = strings.TrimSpace(strs[i])
+	}
+	res, err := appdef.ParseQNames(strs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEventQNames, err)
+	}
+	return res, nil
+}
+
+func validateURL(str string) error {
+	u, err := url.Parse(str)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidWebhookURL, str)
+	}
+	return nil
+}
+
+func validateMaxAttempts(maxAttempts int32) error {
+	if maxAttempts < 0 || maxAttempts > MaxMaxAttempts {
+		return fmt.Errorf("%w: %d, must be from 0 (default %d) to %d", ErrInvalidMaxAttempts, maxAttempts, DefaultMaxAttempts, MaxMaxAttempts)
+	}
+	return nil
+}
+
+func newSubscription(rec istructs.IRowReader) subscription {
+	res := subscription{
+		id:          rec.AsRecordID(appdef.SystemField_ID),
+		url:         rec.AsString(Field_URL),
+		secret:      rec.AsString(Field_Secret),
+		maxAttempts: int(rec.AsInt32(Field_MaxAttempts)),
+	}
+	// validated on insert or update
+	res.eventQNames, _ = parseEventQNames(rec.AsString(Field_EventQNames))
+	if res.maxAttempts == 0 {
+		res.maxAttempts = DefaultMaxAttempts
+	}
+	return res
+}
+
+// the event matches if its QName or QName of any its CUD is in the filter
+func (s subscription) matches(event istructs.IDbEvent) bool {
+	if len(s.eventQNames) == 0 || s.eventQNames.Contains(event.QName()) {
+		return true
+	}
+	matched := false
+	event.CUDs(func(cud istructs.ICUDRow) bool {
+		matched = s.eventQNames.Contains(cud.QName())
+		return !matched
+	})
+	return matched
+}
+
+// events that affect webhooks themselves are not delivered to avoid leaking secrets
+func affectsWebhooks(event istructs.IDbEvent) bool {
+	res := false
+	event.CUDs(func(cud istructs.ICUDRow) bool {
+		res = cud.QName() == QNameCDocWebhook
+		return !res
+	})
+	return res
+}
+
+func truncate(str string) string {
+	if len(str) > maxErrorLen {
+		return str[:maxErrorLen]
+	}
+	return str
+}
+
+// DueAt of view.sys.WebhookRetries: unix ms rounded up to retryPeriod
+func retryBucket(unixMilli int64) int64 {
+	period := retryPeriod.Milliseconds()
+	return (unixMilli + period - 1) / period * period
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+func TestSignature(t *testing.T) {
+	require := require.New(t)
+	body := []byte(`{"qname":"sys.CUD"}`)
+	sig := Signature("secret", "1700000000000", body)
+	// echo -n '1700000000000.{"qname":"sys.CUD"}' | openssl dgst -sha256 -hmac secret
+	require.Equal("sha256=6d60e4f93c5781525c48e4ddcf45392755311712556712f73f4c2efbdf10063d", sig)
+	require.NotEqual(sig, Signature("other", "1700000000000", body))
+	require.NotEqual(sig, Signature("secret", "1700000000001", body))
+	require.NotEqual(sig, Signature("secret", "1700000000000", []byte(`{}`)))
+}
+
+func TestBackoff(t *testing.T) {
+	require := require.New(t)
+	require.Equal(InitialBackoff, backoff(1))
+	require.Equal(2*InitialBackoff, backoff(2))
+	require.Equal(4*InitialBackoff, backoff(3))
+	require.Equal(MaxBackoff, backoff(MaxMaxAttempts))
+	require.Equal(MaxBackoff, backoff(100))
+	require.Equal(32*InitialBackoff, backoff(6))
+	require.Equal(MaxBackoff, backoff(7))
+
+	// each next attempt is made by the next job.sys.RetryWebhookDeliveries run at least
+	for attempt := 1; attempt <= MaxMaxAttempts; attempt++ {
+		delay := backoff(attempt).Milliseconds()
+		require.GreaterOrEqual(delay, retryPeriod.Milliseconds())
+		require.Equal(delay, retryBucket(delay))
+	}
+}
+
+func TestRetryBucket(t *testing.T) {
+	require := require.New(t)
+	period := retryPeriod.Milliseconds()
+	require.Zero(retryBucket(0))
+	require.Equal(period, retryBucket(1))
+	require.Equal(period, retryBucket(period))
+	require.Equal(2*period, retryBucket(period+1))
+}
+
+func TestValidation(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("URL", func(t *testing.T) {
+		require.NoError(validateURL("http://localhost:8080/hook"))
+		require.NoError(validateURL("https://example.com/hook?x=1"))
+		for _, url := range []string{"", "example.com", "ftp://example.com", "https://", "://wrong"} {
+			require.ErrorIs(validateURL(url), ErrInvalidWebhookURL, url)
+		}
+	})
+
+	t.Run("MaxAttempts", func(t *testing.T) {
+		require.NoError(validateMaxAttempts(0))
+		require.NoError(validateMaxAttempts(MaxMaxAttempts))
+		require.ErrorIs(validateMaxAttempts(-1), ErrInvalidMaxAttempts)
+		require.ErrorIs(validateMaxAttempts(MaxMaxAttempts+1), ErrInvalidMaxAttempts)
+	})
+
+	t.Run("EventQNames", func(t *testing.T) {
+		qNames, err := parseEventQNames("")
+		require.NoError(err)
+		require.Empty(qNames)
+
+		qNames, err = parseEventQNames("app1pkg.category, sys.CUD")
+		require.NoError(err)
+		require.Equal(appdef.QNamesFrom(appdef.NewQName("app1pkg", "category"), appdef.NewQName("sys", "CUD")), qNames)
+
+		_, err = parseEventQNames("app1pkg.category,wrong")
+		require.ErrorIs(err, ErrInvalidEventQNames)
+	})
+}
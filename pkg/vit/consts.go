@@ -36,6 +36,7 @@ var (
 	SchemaTestApp2WithJobFS embed.FS
 
 	DefaultTestAppEnginesPool = appparts.PoolSize(10, 10, 20, 10)
+	testTOTPSecretKey         = []byte("01234567890123456789012345678901")
 	maxRateLimit2PerMinute    = istructs.RateLimit{
 		Period:                time.Minute,
 		MaxAllowedPerDuration: 2,
@@ -30,9 +30,11 @@ import (
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/cas"
+	"github.com/voedger/voedger/pkg/istorage/pg"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/registry"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/state/smtptest"
 	"github.com/voedger/voedger/pkg/sys/authnz"
@@ -85,20 +87,29 @@ func newVit(t testing.TB, vitCfg *VITConfig, useCas bool, vvmLaunchOnly bool) *V
 
 	cfg.Time = testingu.MockTime
 
+	// webhook receivers of the tests are httptest servers on the loopback
+	cfg.WebhooksAllowPrivateAddresses = true
+
 	emailMessagesChan := make(chan smtptest.Message, 1) // must be buffered
 	cfg.ActualizerStateOpts = append(cfg.ActualizerStateOpts, state.WithEmailSenderOverride(emailMessagesChan))
 
 	vitPreConfig := &vitPreConfig{
 		vvmCfg:  &cfg,
 		vitApps: vitApps{},
-		secrets: map[string][]byte{},
+		secrets: map[string][]byte{registry.TOTPSecretKeyName: testTOTPSecretKey},
 	}
 
-	if useCas {
+	switch {
+	case useCas:
 		cfg.StorageFactory = func() (provider istorage.IAppStorageFactory, err error) {
 			logger.Info("using istoragecas ", fmt.Sprint(cas.DefaultCasParams))
 			return cas.Provide(cas.DefaultCasParams)
 		}
+	case coreutils.IsPostgreSQLStorage():
+		cfg.StorageFactory = func() (provider istorage.IAppStorageFactory, err error) {
+			logger.Info("using istoragepg")
+			return pg.Provide(pg.ParamsFromEnv(), cfg.Time)
+		}
 	}
 
 	for _, opt := range vitCfg.opts {
@@ -405,6 +416,9 @@ func (vit *VIT) SqlQueryRows(ws *AppWorkspace, sqlQuery string, fmtArgs ...any)
 	body := fmt.Sprintf(`{"args":{"Query":"%s"},"elements":[{"fields":["Result"]}]}`, fmt.Sprintf(sqlQuery, fmtArgs...))
 	resp := vit.PostWS(ws, "q.sys.SqlQuery", body, coreutils.WithAuthorizeBy(ws.Owner.Token))
 	res := []map[string]interface{}{}
+	if resp.IsEmpty() {
+		return res
+	}
 	for _, elem := range resp.Sections[0].Elements {
 		m := map[string]interface{}{}
 		require.NoError(vit.T, json.Unmarshal([]byte(elem[0][0][0].(string)), &m))
@@ -560,10 +574,10 @@ func (vit *VIT) MockBuckets(appQName appdef.AppQName, rateLimitName appdef.QName
 	initialState, err := appBuckets.GetDefaultBucketsState(rateLimitName)
 	require.NoError(vit.T, err)
 	appBuckets.SetDefaultBucketState(rateLimitName, bs)
-	appBuckets.ResetRateBuckets(rateLimitName, bs)
+	require.NoError(vit.T, appBuckets.ResetRateBuckets(rateLimitName, bs))
 	vit.cleanups = append(vit.cleanups, func(vit *VIT) {
 		appBuckets.SetDefaultBucketState(rateLimitName, initialState)
-		appBuckets.ResetRateBuckets(rateLimitName, initialState)
+		require.NoError(vit.T, appBuckets.ResetRateBuckets(rateLimitName, initialState))
 	})
 }
 
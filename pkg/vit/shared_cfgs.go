@@ -76,6 +76,7 @@ var (
 	QNameApp1_DocConstraintsString           = appdef.NewQName(app1PkgName, "DocConstraintsString")
 	QNameApp1_DocConstraintsFewUniques       = appdef.NewQName(app1PkgName, "DocConstraintsFewUniques")
 	QNameApp1_DocConstraintsOldAndNewUniques = appdef.NewQName(app1PkgName, "DocConstraintsOldAndNewUniques")
+	QNameApp1_DocIndexed                     = appdef.NewQName(app1PkgName, "DocIndexed")
 	QNameApp1_CDocCategory                   = appdef.NewQName(app1PkgName, "category")
 	QNameApp1_CDocDaily                      = appdef.NewQName(app1PkgName, "Daily")
 	QNameApp1_CDocCurrency                   = appdef.NewQName(app1PkgName, "Currency")
@@ -88,6 +89,8 @@ var (
 	QNameApp1_WDocCapabilities               = appdef.NewQName(app1PkgName, "Capabilities")
 	QNameCmdRated                            = appdef.NewQName(app1PkgName, "RatedCmd")
 	QNameQryRated                            = appdef.NewQName(app1PkgName, "RatedQry")
+	QNameCmdIPRated                          = appdef.NewQName(app1PkgName, "IPRatedCmd")
+	QNameQryIPRated                          = appdef.NewQName(app1PkgName, "IPRatedQry")
 	QNameODoc1                               = appdef.NewQName(app1PkgName, "odoc1")
 	QNameODoc2                               = appdef.NewQName(app1PkgName, "odoc2")
 	TestSMTPCfg                              = smtp.Cfg{
@@ -204,6 +207,10 @@ func ProvideApp1(apis builtinapps.APIs, cfg *istructsmem.AppConfigType, ep exten
 		istructsmem.NullCommandExec,
 	))
 
+	// limited in VSQL
+	cfg.Resources.Add(istructsmem.NewCommandFunction(QNameCmdIPRated, istructsmem.NullCommandExec))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(QNameQryIPRated, istructsmem.NullQueryExec))
+
 	// per-app limits
 	cfg.FunctionRateLimits.AddAppLimit(QNameCmdRated, maxRateLimit2PerMinute)
 	cfg.FunctionRateLimits.AddAppLimit(QNameQryRated, maxRateLimit2PerMinute)
@@ -27,7 +27,7 @@ func Provide(smtpCfg smtp.Cfg, numCP istructs.NumCommandProcessors) builtinapps.
 		sysPackageFS := sysprovide.Provide(cfg)
 
 		// sys/registry resources
-		registryPackageFS := registry.Provide(cfg, apis.ITokens, apis.IFederation)
+		registryPackageFS := registry.Provide(cfg, apis.ITokens, apis.IFederation, apis.ISessions, apis.ITime, apis.SecondFactor)
 		cfg.AddSyncProjectors(registry.ProvideSyncProjectorLoginIdx())
 		registryAppPackageFS := parser.PackageFS{
 			Path: RegistryAppFQN,
@@ -8,15 +8,18 @@ package builtinapps
 import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/extensionpoints"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/parser"
+	"github.com/voedger/voedger/pkg/registry"
 )
 
 type Builder func(apis APIs, cfg *istructsmem.AppConfigType, ep extensionpoints.IExtensionPoint) Def
@@ -40,7 +43,10 @@ type APIs struct {
 	payloads.IAppTokensFactory
 	federation.IFederation
 	timeu.ITime
-	SidecarApps []appparts.SidecarApp
+	isessions.ISessions
+	SecondFactor        registry.SecondFactorConfig
+	SidecarApps         []appparts.SidecarApp
+	WorkspaceBackupPath cluster.WorkspaceBackupPath
 	// IAppPartitions - wrong, wire cycle: `appparts.NewWithActualizerWithExtEnginesFactories(asp, actualizer, eef) IAppPartitions`` accepts engines.ProvideExtEngineFactories()
 	//                                     that requires filled AppConfigsType, but AppConfigsType requires apps.APIs with IAppPartitions
 }
@@ -21,6 +21,7 @@ const (
 	DefaultQuotasChannelsPerSubject                                    = 50
 	DefaultQuotasSubscriptionsFactor                                   = 1000 // Quotas.Subscriptions will be NumCommandProcessors * DefaultQuotasSubscriptionsFactor
 	DefaultQuotasSubscriptionsPerSubject                               = 100
+	DefaultN10nChannelGracePeriod                                      = 30 * time.Second
 	DefaultMetricsServicePort                                          = 8000
 	DefaultCacheSize                                                   = 1024 * 1024 * 1024 // 1Gb
 	ShortestPossibleFunctionNameLen                                    = len("q.a.a")
@@ -30,6 +31,8 @@ const (
 	actualizerFlushInterval                                            = time.Millisecond * 500
 	DefaultLeadershipDurationSeconds                                   = ielections.LeadershipDurationSeconds(20)
 	DefaultLeadershipAcquisitionDuration                               = LeadershipAcquisitionDuration(120 * time.Second)
+	totpSecretKeyLen                                                   = 32 // AES-256
+	sessionsCheckCacheTTL                                              = 5 * time.Second
 )
 
 const (
@@ -49,8 +49,13 @@ func provideStatelessFuncs(statelessResources istructsmem.IStatelessResources) i
 
 	for path, projector := range statelessResources.Projectors {
 		fullQName := appdef.NewFullQName(path, projector.Name.Entity())
+		funcs[fullQName] = projectorFunc(projector)
+	}
+
+	for path, job := range statelessResources.Jobs {
+		fullQName := appdef.NewFullQName(path, job.Name.Entity())
 		funcs[fullQName] = func(_ context.Context, io iextengine.IExtensionIO) error {
-			return projector.Func(io.PLogEvent(), io, io)
+			return job.Func(io, io)
 		}
 	}
 
@@ -81,22 +86,14 @@ func provideAppsBuiltInExtFuncs(cfgs istructsmem.AppConfigsType) iextengine.Buil
 
 		// sync projectors
 		for _, syncProjector := range cfg.SyncProjectors() {
-			sp := syncProjector
-			fn := func(_ context.Context, io iextengine.IExtensionIO) error {
-				return sp.Func(io.PLogEvent(), io, io)
-			}
 			extName := extName(syncProjector.Name, cfg)
-			appFuncs[extName] = fn
+			appFuncs[extName] = projectorFunc(syncProjector)
 		}
 
 		// async projectors
 		for _, asyncProjector := range cfg.AsyncProjectors() {
-			asp := asyncProjector
-			fn := func(_ context.Context, io iextengine.IExtensionIO) error {
-				return asp.Func(io.PLogEvent(), io, io)
-			}
 			extName := extName(asyncProjector.Name, cfg)
-			appFuncs[extName] = fn
+			appFuncs[extName] = projectorFunc(asyncProjector)
 		}
 
 		funcs[app] = appFuncs
@@ -118,18 +115,26 @@ func writeJobs(cfg *istructsmem.AppConfigType, appFuncs iextengine.BuiltInExtFun
 func writeProjectors(cfg *istructsmem.AppConfigType, appFuncs iextengine.BuiltInExtFuncs) {
 	write := func(projectors istructs.Projectors) {
 		for _, projector := range projectors {
-			p := projector
-			fn := func(_ context.Context, io iextengine.IExtensionIO) error {
-				return p.Func(io.PLogEvent(), io, io)
-			}
-			extName := extName(p.Name, cfg)
-			appFuncs[extName] = fn
+			extName := extName(projector.Name, cfg)
+			appFuncs[extName] = projectorFunc(projector)
 		}
 	}
 	write(cfg.SyncProjectors())
 	write(cfg.AsyncProjectors())
 }
 
+// ctx is passed to the projectors which accept it
+func projectorFunc(projector istructs.Projector) iextengine.BuiltInExtFunc {
+	if projector.CtxFunc != nil {
+		return func(ctx context.Context, io iextengine.IExtensionIO) error {
+			return projector.CtxFunc(ctx, io.PLogEvent(), io, io)
+		}
+	}
+	return func(_ context.Context, io iextengine.IExtensionIO) error {
+		return projector.Func(io.PLogEvent(), io, io)
+	}
+}
+
 func writeFuncs(cfg *istructsmem.AppConfigType, appFuncs iextengine.BuiltInExtFuncs) {
 	for qName := range cfg.Resources.Resources {
 		ires := cfg.Resources.QueryResource(qName)
@@ -50,6 +50,7 @@ func NewVVMDefaultConfig() VVMConfig {
 		MaxPrepareQueries:      DefaultMaxPrepareQueries,
 		VVMPort:                DefaultVVMPort,
 		MetricsServicePort:     DefaultMetricsServicePort,
+		N10nChannelGracePeriod: DefaultN10nChannelGracePeriod,
 		StorageFactory: func() (provider istorage.IAppStorageFactory, err error) {
 			logger.Info("using istoragemem")
 			return mem.Provide(testingu.MockTime), nil
@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -35,33 +36,41 @@ import (
 	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/n10ncuds"
+	"github.com/voedger/voedger/pkg/oidc"
 	"github.com/voedger/voedger/pkg/parser"
 	"github.com/voedger/voedger/pkg/processors"
 	"github.com/voedger/voedger/pkg/processors/actualizers"
 	blobprocessor "github.com/voedger/voedger/pkg/processors/blobber"
 	"github.com/voedger/voedger/pkg/processors/query2"
 	"github.com/voedger/voedger/pkg/processors/schedulers"
+	"github.com/voedger/voedger/pkg/registry"
 	"github.com/voedger/voedger/pkg/router"
 	builtinapps "github.com/voedger/voedger/pkg/vvm/builtin"
 	"github.com/voedger/voedger/pkg/vvm/engines"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cdc"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/iblobstoragestg"
 	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10ncluster"
 	"github.com/voedger/voedger/pkg/in10nmem"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/iprocbusmem"
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/iratesstg"
 	"github.com/voedger/voedger/pkg/isecrets"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istoragecache"
+	"github.com/voedger/voedger/pkg/istoragecompress"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -177,12 +186,13 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideRouterParams,
 		provideRouterAppStoragePtr,
 		provideIFederation,
+		provideOIDCRelyingParty,
 		provideCachingAppStorageProvider,  // IAppStorageProvider
-		itokensjwt.ProvideITokens,         // ITokens
+		provideITokens,                    // ITokens
 		provideIAppStructsProvider,        // IAppStructsProvider
 		payloads.ProvideIAppTokensFactory, // IAppTokensFactory
 		provideAppPartitions,
-		in10nmem.ProvideEx2,
+		provideN10nBroker,
 		queryprocessor.ProvideServiceFactory,
 		query2.ProvideServiceFactory,
 		commandprocessor.ProvideServiceFactory,
@@ -200,6 +210,8 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideNumsAppsWorkspaces,
 		provideSecretKeyJWT,
 		provideBucketsFactory,
+		provideSessions,
+		provideSecondFactorConfig,
 		provideSubjectGetterFunc,
 		provideStorageFactory,
 		provideIAppStorageUncachingProviderFactory,
@@ -223,6 +235,8 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideWLimiterFactory,
 		bus.NewIRequestSender,
 		blobprocessor.NewIRequestHandler,
+		cdc.Provide,
+		n10ncuds.Provide,
 		provideIVVMAppTTLStorage,
 		storage.NewElectionsTTLStorage,
 		federation.NewForQP,
@@ -242,10 +256,33 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 			"ActualizerStateOpts",
 			"SecretsReader",
 			"SequencesTrustLevel",
+			"WorkspaceBackupPath",
 		),
 	))
 }
 
+func provideN10nBroker(quotas in10n.Quotas, iTime timeu.ITime, vvmCfg *VVMConfig, prov istorage.IAppStorageProvider) (in10n.IN10nBroker, func(), error) {
+	local, localCleanup := in10nmem.ProvideEx3(quotas, iTime, vvmCfg.N10nChannelGracePeriod)
+	if vvmCfg.N10nTransport == nil {
+		return local, localCleanup, nil
+	}
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		localCleanup()
+		return nil, nil, err
+	}
+	transport, err := vvmCfg.N10nTransport(storage.NewN10nClusterStorage(sysVVMStorage), iTime)
+	if err != nil {
+		localCleanup()
+		return nil, nil, err
+	}
+	broker, brokerCleanup := in10ncluster.Provide(local, transport, iTime)
+	return broker, func() {
+		brokerCleanup()
+		localCleanup()
+	}, nil
+}
+
 func provideIVVMAppTTLStorage(prov istorage.IAppStorageProvider) (storage.ISysVvmStorage, error) {
 	return prov.AppStorage(istructs.AppQName_sys_vvm)
 }
@@ -271,7 +308,7 @@ func provideSchedulerRunner(cfg schedulers.BasicSchedulerConfig) appparts.ISched
 
 func provideBootstrapOperator(federation federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, apppar appparts.IAppPartitions,
 	builtinApps []appparts.BuiltInApp, sidecarApps []appparts.SidecarApp, itokens itokens.ITokens, storageProvider istorage.IAppStorageProvider, blobberAppStoragePtr iblobstoragestg.BlobAppStoragePtr,
-	routerAppStoragePtr dbcertcache.RouterAppStoragePtr) (BootstrapOperator, error) {
+	routerAppStoragePtr dbcertcache.RouterAppStoragePtr, vvmConfig *VVMConfig) (BootstrapOperator, error) {
 	var clusterBuiltinApp btstrp.ClusterBuiltInApp
 	otherApps := make([]appparts.BuiltInApp, 0, len(builtinApps))
 	for _, app := range builtinApps {
@@ -290,7 +327,8 @@ func provideBootstrapOperator(federation federation.IFederation, asp istructs.IA
 		return nil, fmt.Errorf("%s app should be added to VVM builtin apps", istructs.AppQName_sys_cluster)
 	}
 	return pipeline.NewSyncOp(func(ctx context.Context, work pipeline.IWorkpiece) (err error) {
-		return btstrp.Bootstrap(federation, asp, time, apppar, clusterBuiltinApp, otherApps, sidecarApps, itokens, storageProvider, blobberAppStoragePtr, routerAppStoragePtr)
+		return btstrp.Bootstrap(federation, asp, time, apppar, clusterBuiltinApp, otherApps, sidecarApps, itokens, storageProvider, blobberAppStoragePtr, routerAppStoragePtr,
+			vvmConfig.MaintenanceApps)
 	}), nil
 }
 
@@ -351,10 +389,10 @@ func provideAppsExtensionPoints(vvmConfig *VVMConfig) map[appdef.AppQName]extens
 
 func provideStatelessResources(cfgs AppConfigsTypeEmpty, vvmCfg *VVMConfig, appEPs map[appdef.AppQName]extensionpoints.IExtensionPoint,
 	buildInfo *debug.BuildInfo, sp istorage.IAppStorageProvider, itokens itokens.ITokens, federation federation.IFederation,
-	asp istructs.IAppStructsProvider, atf payloads.IAppTokensFactory) istructsmem.IStatelessResources {
+	asp istructs.IAppStructsProvider, atf payloads.IAppTokensFactory, sessions isessions.ISessions) istructsmem.IStatelessResources {
 	ssr := istructsmem.NewStatelessResources()
 	sysprovide.ProvideStateless(ssr, vvmCfg.SMTPConfig, appEPs, buildInfo, sp, vvmCfg.WSPostInitFunc, vvmCfg.Time, itokens, federation,
-		asp, atf)
+		asp, atf, sessions, vvmCfg.WebhooksAllowPrivateAddresses)
 	return ssr
 }
 
@@ -467,16 +505,69 @@ func provideSubjectGetterFunc() iauthnzimpl.SubjectGetterFunc {
 	}
 }
 
-func provideBucketsFactory(time timeu.ITime) irates.BucketsFactoryType {
+func provideBucketsFactory(time timeu.ITime, vvmCfg *VVMConfig, prov istorage.IAppStorageProvider) (irates.BucketsFactoryType, error) {
+	if !vvmCfg.SharedRateBuckets {
+		return func() irates.IBuckets {
+			return iratesce.Provide(time)
+		}, nil
+	}
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		return nil, err
+	}
+	rateBucketsStorage := storage.NewRateBucketsStorage(sysVVMStorage)
 	return func() irates.IBuckets {
-		return iratesce.Provide(time)
+		return iratesstg.Provide(rateBucketsStorage, time)
+	}, nil
+}
+
+func provideSessions(time timeu.ITime, prov istorage.IAppStorageProvider) (isessions.ISessions, error) {
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		return nil, err
 	}
+	return isessions.Provide(storage.NewSessionsStorage(sysVVMStorage), time, sessionsCheckCacheTTL), nil
 }
 
 func provideSecretKeyJWT(sr isecrets.ISecretReader) (itokensjwt.SecretKeyType, error) {
 	return sr.ReadSecret(itokensjwt.SecretKeyJWTName)
 }
 
+// TOTP secrets encryption key is kept in the separate secret, so that it is not changed together with secretKeyJWT
+// no secret -> TOTP is not available
+func provideSecondFactorConfig(vvmCfg *VVMConfig, sr isecrets.ISecretReader, prov istorage.IAppStorageProvider) (registry.SecondFactorConfig, error) {
+	totpSecretKey, err := sr.ReadSecret(registry.TOTPSecretKeyName)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		totpSecretKey = nil
+	case err != nil:
+		return registry.SecondFactorConfig{}, err
+	case len(totpSecretKey) != totpSecretKeyLen:
+		return registry.SecondFactorConfig{}, fmt.Errorf("%s secret must be %d bytes long, got %d", registry.TOTPSecretKeyName, totpSecretKeyLen, len(totpSecretKey))
+	}
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		return registry.SecondFactorConfig{}, err
+	}
+	return registry.SecondFactorConfig{
+		TOTPSecretKey:          totpSecretKey,
+		Storage:                storage.NewSecondFactorStorage(sysVVMStorage),
+		RequiredForGlobalRoles: vvmCfg.SecondFactorRequiredForGlobalRoles,
+	}, nil
+}
+
+func provideITokens(secretKey itokensjwt.SecretKeyType, vvmCfg *VVMConfig, time timeu.ITime) (itokens.ITokens, error) {
+	return itokensjwt.ProvideITokensWithKeys(secretKey, vvmCfg.JWTSigningKeys, vvmCfg.JWTRejectHS256, time)
+}
+
+// nil -> OIDC login is not available
+func provideOIDCRelyingParty(vvmCfg *VVMConfig, time timeu.ITime) (oidc.IRelyingParty, error) {
+	if len(vvmCfg.OIDCProviders) == 0 {
+		return nil, nil
+	}
+	return oidc.Provide(vvmCfg.OIDCProviders, time)
+}
+
 func provideNumsAppsWorkspaces(vvmApps VVMApps, asp istructs.IAppStructsProvider, sidecarApps []appparts.SidecarApp) (map[appdef.AppQName]istructs.NumAppWorkspaces, error) {
 	res := map[appdef.AppQName]istructs.NumAppWorkspaces{}
 	for _, appQName := range vvmApps {
@@ -545,6 +636,7 @@ func provideRouterParams(cfg *VVMConfig, port VVMPortType) router.RouterParams {
 		Routes:               cfg.Routes,
 		RoutesRewrite:        cfg.RoutesRewrite,
 		RouteDomains:         cfg.RouteDomains,
+		WSAllowedOrigins:     cfg.RouterWSAllowedOrigins,
 		Port:                 int(port),
 	}
 	return res
@@ -720,9 +812,14 @@ func provideChannelGroups(cfg *VVMConfig) (res []iprocbusmem.ChannelGroup) {
 }
 
 func provideCachingAppStorageProvider(storageCacheSize StorageCacheSizeType, metrics imetrics.IMetrics,
-	vvmName processors.VVMName, uncachingProvider IAppStorageUncachingProviderFactory, iTime timeu.ITime) istorage.IAppStorageProvider {
-	aspNonCaching := uncachingProvider()
-	return istoragecache.Provide(int(storageCacheSize), aspNonCaching, metrics, string(vvmName), iTime)
+	vvmName processors.VVMName, uncachingProvider IAppStorageUncachingProviderFactory, iTime timeu.ITime, vvmCfg *VVMConfig) istorage.IAppStorageProvider {
+	// values are compressed under the cache, so the cache keeps not compressed values
+	aspNonCaching := istoragecompress.Provide(uncachingProvider(), vvmCfg.StorageCompression, metrics, string(vvmName))
+	tiers := []istoragecache.ICacheTierFactory{istoragecache.NewLocalTier(int(storageCacheSize))}
+	if vvmCfg.StorageCacheSharedTier != nil {
+		tiers = append(tiers, vvmCfg.StorageCacheSharedTier)
+	}
+	return istoragecache.ProvideTiered(tiers, aspNonCaching, metrics, string(vvmName), iTime)
 }
 
 func provideBlobAppStoragePtr(astp istorage.IAppStorageProvider) iblobstoragestg.BlobAppStoragePtr {
@@ -742,9 +839,10 @@ func provideRouterServices(rp router.RouterParams, sendTimeout bus.SendTimeout,
 	wLimiterFactory blobprocessor.WLimiterFactory, blobStorage BlobStorage,
 	autocertCache autocert.Cache, requestSender bus.IRequestSender, vvmPortSource *VVMPortSource,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens,
-	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory) RouterServices {
+	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory, feed cdc.ICDC, n10nCUDs n10ncuds.IN10nCUDs,
+	relyingParty oidc.IRelyingParty, iTime timeu.ITime) RouterServices {
 	httpSrv, acmeSrv, adminSrv := router.Provide(rp, broker, blobRequestHandler, autocertCache, requestSender, numsAppsWorkspaces,
-		iTokens, federation, appTokensFactory)
+		iTokens, federation, appTokensFactory, feed, n10nCUDs, relyingParty, iTime)
 	vvmPortSource.getter = func() VVMPortType {
 		return VVMPortType(httpSrv.GetPort())
 	}
@@ -17,6 +17,18 @@ const (
 
 	// [~server.design.sequences/cmp.VVMSeqStorageAdapter.KeyPrefixSeqStorageWS~impl]
 	pKeyPrefix_SeqStorage_WS
+
+	// batches and nodes registry of the clustered n10n broker
+	pKeyPrefix_N10nCluster
+
+	// rate limits buckets shared among VVMs
+	pKeyPrefix_RateBuckets
+
+	// login sessions shared among VVMs
+	pKeyPrefix_Sessions
+
+	// used codes and failed attempts of the logins second factor
+	pKeyPrefix_SecondFactor
 )
 
 const (
@@ -23,6 +23,18 @@ const (
 	// [~server.design.sequences/cmp.VVMSeqStorageAdapter.KeyPrefixSeqStorageWS.test~impl]
 	_ = uint32(pKeyPrefix_SeqStorage_WS - 3)
 	_ = uint32(3 - pKeyPrefix_SeqStorage_WS)
+
+	_ = uint32(pKeyPrefix_N10nCluster - 4)
+	_ = uint32(4 - pKeyPrefix_N10nCluster)
+
+	_ = uint32(pKeyPrefix_RateBuckets - 5)
+	_ = uint32(5 - pKeyPrefix_RateBuckets)
+
+	_ = uint32(pKeyPrefix_Sessions - 6)
+	_ = uint32(6 - pKeyPrefix_Sessions)
+
+	_ = uint32(pKeyPrefix_SecondFactor - 7)
+	_ = uint32(7 - pKeyPrefix_SecondFactor)
 )
 
 func TestConsts(t *testing.T) {
@@ -35,6 +47,14 @@ func TestConsts(t *testing.T) {
 	// [~server.design.sequences/cmp.VVMSeqStorageAdapter.KeyPrefixSeqStorageWS.test~impl]
 	require.Equal(uint32(3), pKeyPrefix_SeqStorage_WS)
 
+	require.Equal(uint32(4), pKeyPrefix_N10nCluster)
+
+	require.Equal(uint32(5), pKeyPrefix_RateBuckets)
+
+	require.Equal(uint32(6), pKeyPrefix_Sessions)
+
+	require.Equal(uint32(7), pKeyPrefix_SecondFactor)
+
 	// [~server.design.sequences/cmp.VVMSeqStorageAdapter.PLogOffsetCC.test~impl]
 	require.Equal(uint32(0), PLogOffsetCC)
 }
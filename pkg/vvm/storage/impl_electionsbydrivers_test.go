@@ -19,6 +19,7 @@ import (
 	"github.com/voedger/voedger/pkg/istorage/bbolt"
 	"github.com/voedger/voedger/pkg/istorage/cas"
 	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/pg"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -49,6 +50,15 @@ func TestTTLStorageBbolt(t *testing.T) {
 	testElectionsByDriver(t, storagePactory)
 }
 
+func TestTTLStoragePg(t *testing.T) {
+	if !coreutils.IsPostgreSQLStorage() {
+		t.Skip()
+	}
+	storagePactory, err := pg.Provide(pg.ParamsFromEnv(), testingu.MockTime)
+	require.NoError(t, err)
+	testElectionsByDriver(t, storagePactory)
+}
+
 // [~server.design.orch/VVM.test.TTLStorageDyn~impl]
 func TestTTLStorageDynamoDB(t *testing.T) {
 	if !coreutils.IsDynamoDBStorage() {
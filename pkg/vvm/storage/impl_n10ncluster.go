@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// in10ncluster.IStorage over the sys/vvm storage, pKeys are prefixed with pKeyPrefix_N10nCluster
+type implN10nClusterStorage struct {
+	sysVVMStorage istorage.IAppStorage
+}
+
+func (s *implN10nClusterStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.InsertIfNotExists(n10nClusterPKey(pKey), cCols, value, ttlSeconds)
+}
+
+func (s *implN10nClusterStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.CompareAndSwap(n10nClusterPKey(pKey), cCols, oldValue, newValue, ttlSeconds)
+}
+
+func (s *implN10nClusterStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) (err error) {
+	return s.sysVVMStorage.TTLRead(ctx, n10nClusterPKey(pKey), startCCols, finishCCols, cb)
+}
+
+func n10nClusterPKey(pKey []byte) []byte {
+	res := make([]byte, 0, 4+len(pKey))
+	res = binary.BigEndian.AppendUint32(res, pKeyPrefix_N10nCluster)
+	return append(res, pKey...)
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// iratesstg.IStorage over the sys/vvm storage, pKeys are prefixed with pKeyPrefix_RateBuckets
+type implRateBucketsStorage struct {
+	sysVVMStorage istorage.IAppStorage
+}
+
+func (s *implRateBucketsStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.sysVVMStorage.TTLGet(rateBucketsPKey(pKey), cCols, data)
+}
+
+func (s *implRateBucketsStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.InsertIfNotExists(rateBucketsPKey(pKey), cCols, value, ttlSeconds)
+}
+
+func (s *implRateBucketsStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.CompareAndSwap(rateBucketsPKey(pKey), cCols, oldValue, newValue, ttlSeconds)
+}
+
+func (s *implRateBucketsStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) (err error) {
+	return s.sysVVMStorage.TTLRead(ctx, rateBucketsPKey(pKey), startCCols, finishCCols, cb)
+}
+
+func rateBucketsPKey(pKey []byte) []byte {
+	res := make([]byte, 0, 4+len(pKey))
+	res = binary.BigEndian.AppendUint32(res, pKeyPrefix_RateBuckets)
+	return append(res, pKey...)
+}
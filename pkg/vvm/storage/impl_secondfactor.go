@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storage
+
+import (
+	"encoding/binary"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// registry.IStorage over the sys/vvm storage, pKeys are prefixed with pKeyPrefix_SecondFactor
+type implSecondFactorStorage struct {
+	sysVVMStorage istorage.IAppStorage
+}
+
+func (s *implSecondFactorStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.sysVVMStorage.TTLGet(secondFactorPKey(pKey), cCols, data)
+}
+
+func (s *implSecondFactorStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.InsertIfNotExists(secondFactorPKey(pKey), cCols, value, ttlSeconds)
+}
+
+func (s *implSecondFactorStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.CompareAndSwap(secondFactorPKey(pKey), cCols, oldValue, newValue, ttlSeconds)
+}
+
+func secondFactorPKey(pKey []byte) []byte {
+	res := make([]byte, 0, 4+len(pKey))
+	res = binary.BigEndian.AppendUint32(res, pKeyPrefix_SecondFactor)
+	return append(res, pKey...)
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// isessions.IStorage over the sys/vvm storage, pKeys are prefixed with pKeyPrefix_Sessions
+type implSessionsStorage struct {
+	sysVVMStorage istorage.IAppStorage
+}
+
+func (s *implSessionsStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.sysVVMStorage.TTLGet(sessionsPKey(pKey), cCols, data)
+}
+
+func (s *implSessionsStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.InsertIfNotExists(sessionsPKey(pKey), cCols, value, ttlSeconds)
+}
+
+func (s *implSessionsStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	return s.sysVVMStorage.CompareAndSwap(sessionsPKey(pKey), cCols, oldValue, newValue, ttlSeconds)
+}
+
+func (s *implSessionsStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb func(cCols []byte, value []byte) error) (err error) {
+	return s.sysVVMStorage.TTLRead(ctx, sessionsPKey(pKey), startCCols, finishCCols, cb)
+}
+
+func sessionsPKey(pKey []byte) []byte {
+	res := make([]byte, 0, 4+len(pKey))
+	res = binary.BigEndian.AppendUint32(res, pKeyPrefix_Sessions)
+	return append(res, pKey...)
+}
@@ -6,7 +6,12 @@ package storage
 
 import (
 	"github.com/voedger/voedger/pkg/ielections"
+	"github.com/voedger/voedger/pkg/in10ncluster"
+	"github.com/voedger/voedger/pkg/iratesstg"
 	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/isessions"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/registry"
 )
 
 // [~server.design.orch/NewElectionsTTLStorage~impl]
@@ -21,3 +26,27 @@ func NewVVMSeqStorageAdapter(sysVVMStorage ISysVvmStorage) isequencer.IVVMSeqSto
 		sysVVMStorage: sysVVMStorage,
 	}
 }
+
+func NewN10nClusterStorage(sysVVMStorage istorage.IAppStorage) in10ncluster.IStorage {
+	return &implN10nClusterStorage{
+		sysVVMStorage: sysVVMStorage,
+	}
+}
+
+func NewRateBucketsStorage(sysVVMStorage istorage.IAppStorage) iratesstg.IStorage {
+	return &implRateBucketsStorage{
+		sysVVMStorage: sysVVMStorage,
+	}
+}
+
+func NewSecondFactorStorage(sysVVMStorage istorage.IAppStorage) registry.IStorage {
+	return &implSecondFactorStorage{
+		sysVVMStorage: sysVVMStorage,
+	}
+}
+
+func NewSessionsStorage(sysVVMStorage istorage.IAppStorage) isessions.IStorage {
+	return &implSessionsStorage{
+		sysVVMStorage: sysVVMStorage,
+	}
+}
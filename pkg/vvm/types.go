@@ -15,19 +15,25 @@ import (
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/apppartsctl"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/extensionpoints"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/iblobstorage"
 	"github.com/voedger/voedger/pkg/ielections"
+	"github.com/voedger/voedger/pkg/in10ncluster"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/iprocbusmem"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istoragecache"
+	"github.com/voedger/voedger/pkg/istoragecompress"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/oidc"
 	"github.com/voedger/voedger/pkg/parser"
 	"github.com/voedger/voedger/pkg/pipeline"
 	"github.com/voedger/voedger/pkg/processors"
@@ -145,6 +151,7 @@ type VVMConfig struct {
 	Routes                     map[string]string
 	RoutesRewrite              map[string]string
 	RouteDomains               map[string]string
+	RouterWSAllowedOrigins     []string // see router.RouterParams.WSAllowedOrigins
 	SendTimeout                bus.SendTimeout
 	StorageFactory             func() (provider istorage.IAppStorageFactory, err error)
 	BLOBMaxSize                iblobstorage.BLOBMaxSizeType
@@ -154,6 +161,7 @@ type VVMConfig struct {
 	NumBLOBProcessors          istructs.NumBLOBProcessors
 	MaxPrepareQueries          MaxPrepareQueriesType
 	StorageCacheSize           StorageCacheSizeType
+	StorageCompression         istoragecompress.Params
 	processorsChannels         []ProcesorChannel
 	ActualizerStateOpts        []state.StateOptFunc
 	SecretsReader              isecrets.ISecretReader
@@ -162,6 +170,50 @@ type VVMConfig struct {
 	DataPath                   string
 	MetricsServicePort         metrics.MetricsServicePort
 
+	// nil -> cache consists of the local tier only
+	// e.g. istoragecache.NewRedisTier() to share the cache between VVMs and keep it warm on VVM restart
+	StorageCacheSharedTier istoragecache.ICacheTierFactory
+
+	// how long the disconnected n10n channel is kept to be resumed by Last-Event-ID, 0 -> channels are not resumable
+	N10nChannelGracePeriod time.Duration
+
+	// nil -> n10n broker notifies about updates made on this VVM only
+	// e.g. in10ncluster.NewStorageTransport to notify about updates made on any VVM of the cluster
+	N10nTransport in10ncluster.TransportFactory
+
+	// false -> rate limits are enforced by each VVM on its own, i.e. are multiplied by the number of VVMs
+	// true -> buckets are kept in the sys/vvm storage, so rate limits are enforced cluster-wide
+	SharedRateBuckets bool
+
+	// empty -> tokens are signed by the secretKeyJWT secret (HS256) and can not be verified without it
+	// otherwise tokens are signed by asymmetric keys published at /.well-known/jwks.json, see itokensjwt.SigningKey
+	// e.g. itokensjwt.ParseSigningKeyPEM() to load the key from the secret
+	JWTSigningKeys []itokensjwt.SigningKey
+
+	// true -> tokens signed by the secretKeyJWT secret (HS256) are rejected once any of JWTSigningKeys is active
+	// should be set when HS256 tokens issued before the key activation are expired
+	JWTRejectHS256 bool
+
+	// OpenID Connect identity providers the users could sign in by, see router /api/v2/apps/{owner}/{app}/auth/oidc/{provider}
+	// the external subject is mapped to the registry login which is created on the first sign in
+	OIDCProviders []oidc.ProviderConfig
+
+	// the registry login having any of these global roles in the app must pass the second factor (TOTP or e-mail code) to get the principal token
+	// TOTP secrets are encrypted by the key kept in the registry.TOTPSecretKeyName secret, so the secret must not be changed once TOTP is enrolled
+	SecondFactorRequiredForGlobalRoles map[appdef.AppQName][]appdef.QName
+
+	// host folder the archives of c.cluster.BackupWorkspace and c.cluster.RestoreWorkspace are kept in, the archive path is relative to it
+	// empty -> the commands are not available
+	WorkspaceBackupPath cluster.WorkspaceBackupPath
+
+	// the apps are deployed without partitions, i.e. no requests are processed and no actualizers and jobs work
+	// e.g. to restore the workspace by c.cluster.RestoreWorkspace which requires the partition of the workspace to be stopped
+	MaintenanceApps []appdef.AppQName
+
+	// false -> webhooks are not delivered to loopback, private and link-local addresses, the address is checked on dial
+	// true -> e.g. the webhook receivers are in the same private network
+	WebhooksAllowPrivateAddresses bool
+
 	// 0 -> dynamic port will be used, new on each vvmIdx
 	// >0 -> vVMPort+vvmIdx will be actually used
 	VVMPort VVMPortType
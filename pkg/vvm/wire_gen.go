@@ -17,6 +17,7 @@ import (
 	"github.com/voedger/voedger/pkg/apppartsctl"
 	"github.com/voedger/voedger/pkg/btstrp"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cdc"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/extensionpoints"
@@ -28,22 +29,28 @@ import (
 	"github.com/voedger/voedger/pkg/iblobstoragestg"
 	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/in10ncluster"
 	"github.com/voedger/voedger/pkg/in10nmem"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/iprocbusmem"
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/iratesstg"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/isessions"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istoragecache"
+	"github.com/voedger/voedger/pkg/istoragecompress"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
 	"github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/itokensjwt"
 	"github.com/voedger/voedger/pkg/metrics"
+	"github.com/voedger/voedger/pkg/n10ncuds"
+	"github.com/voedger/voedger/pkg/oidc"
 	"github.com/voedger/voedger/pkg/parser"
 	"github.com/voedger/voedger/pkg/pipeline"
 	"github.com/voedger/voedger/pkg/processors"
@@ -53,6 +60,7 @@ import (
 	"github.com/voedger/voedger/pkg/processors/query"
 	"github.com/voedger/voedger/pkg/processors/query2"
 	"github.com/voedger/voedger/pkg/processors/schedulers"
+	"github.com/voedger/voedger/pkg/registry"
 	"github.com/voedger/voedger/pkg/router"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys/invite"
@@ -63,6 +71,7 @@ import (
 	"github.com/voedger/voedger/pkg/vvm/metrics"
 	"github.com/voedger/voedger/pkg/vvm/storage"
 	"golang.org/x/crypto/acme/autocert"
+	"io/fs"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -83,13 +92,15 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	commandChannelFactory := provideCommandChannelFactory(serviceChannelFactory)
 	appConfigsTypeEmpty := provideAppConfigsTypeEmpty()
 	iTime := vvmConfig.Time
-	bucketsFactoryType := provideBucketsFactory(iTime)
 	iSecretReader := vvmConfig.SecretsReader
 	secretKeyType, err := provideSecretKeyJWT(iSecretReader)
 	if err != nil {
 		return nil, nil, err
 	}
-	iTokens := itokensjwt.ProvideITokens(secretKeyType, iTime)
+	iTokens, err := provideITokens(secretKeyType, vvmConfig, iTime)
+	if err != nil {
+		return nil, nil, err
+	}
 	iAppTokensFactory := payloads.ProvideIAppTokensFactory(iTokens)
 	storageCacheSizeType := vvmConfig.StorageCacheSize
 	iMetrics := imetrics.Provide()
@@ -99,12 +110,23 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		return nil, nil, err
 	}
 	iAppStorageUncachingProviderFactory := provideIAppStorageUncachingProviderFactory(iAppStorageFactory, vvmConfig)
-	iAppStorageProvider := provideCachingAppStorageProvider(storageCacheSizeType, iMetrics, vvmName, iAppStorageUncachingProviderFactory, iTime)
+	iAppStorageProvider := provideCachingAppStorageProvider(storageCacheSizeType, iMetrics, vvmName, iAppStorageUncachingProviderFactory, iTime, vvmConfig)
+	bucketsFactoryType, err := provideBucketsFactory(iTime, vvmConfig, iAppStorageProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	iSessions, err := provideSessions(iTime, iAppStorageProvider)
+	if err != nil {
+		return nil, nil, err
+	}
 	sequencesTrustLevel := vvmConfig.SequencesTrustLevel
 	iAppStructsProvider := provideIAppStructsProvider(appConfigsTypeEmpty, bucketsFactoryType, iAppTokensFactory, iAppStorageProvider, sequencesTrustLevel)
 	syncActualizerFactory := actualizers.ProvideSyncActualizerFactory()
 	quotas := provideN10NQuotas(vvmConfig)
-	in10nBroker, cleanup := in10nmem.ProvideEx2(quotas, iTime)
+	in10nBroker, cleanup, err := provideN10nBroker(quotas, iTime, vvmConfig, iAppStorageProvider)
+	if err != nil {
+		return nil, nil, err
+	}
 	v2 := provideAppsExtensionPoints(vvmConfig)
 	buildInfo, err := provideBuildInfo()
 	if err != nil {
@@ -113,7 +135,7 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	}
 	vvmPortSource := provideVVMPortSource()
 	iFederation, cleanup2 := provideIFederation(vvmConfig, vvmPortSource)
-	iStatelessResources := provideStatelessResources(appConfigsTypeEmpty, vvmConfig, v2, buildInfo, iAppStorageProvider, iTokens, iFederation, iAppStructsProvider, iAppTokensFactory)
+	iStatelessResources := provideStatelessResources(appConfigsTypeEmpty, vvmConfig, v2, buildInfo, iAppStorageProvider, iTokens, iFederation, iAppStructsProvider, iAppTokensFactory, iSessions)
 	v3 := actualizers.NewSyncActualizerFactoryFactory(syncActualizerFactory, iSecretReader, in10nBroker, iStatelessResources)
 	v4 := vvmConfig.ActualizerStateOpts
 	basicAsyncActualizerConfig := provideBasicAsyncActualizerConfig(vvmName, iSecretReader, iTokens, iMetrics, in10nBroker, iFederation, v4...)
@@ -134,6 +156,13 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		cleanup()
 		return nil, nil, err
 	}
+	secondFactorConfig, err := provideSecondFactorConfig(vvmConfig, iSecretReader, iAppStorageProvider)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	workspaceBackupPath := vvmConfig.WorkspaceBackupPath
 	apIs := builtinapps.APIs{
 		ITokens:             iTokens,
 		IAppStructsProvider: iAppStructsProvider,
@@ -141,7 +170,10 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		IAppTokensFactory:   iAppTokensFactory,
 		IFederation:         iFederation,
 		ITime:               iTime,
+		ISessions:           iSessions,
+		SecondFactor:        secondFactorConfig,
 		SidecarApps:         v5,
+		WorkspaceBackupPath: workspaceBackupPath,
 	}
 	builtInAppsArtefacts, err := provideBuiltInAppsArtefacts(vvmConfig, apIs, appConfigsTypeEmpty, v2)
 	if err != nil {
@@ -157,7 +189,7 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	}
 	v6 := provideSubjectGetterFunc()
 	isDeviceAllowedFuncs := provideIsDeviceAllowedFunc(v2)
-	iAuthenticator := iauthnzimpl.NewDefaultAuthenticator(v6, isDeviceAllowedFuncs)
+	iAuthenticator := iauthnzimpl.NewDefaultAuthenticator(v6, isDeviceAllowedFuncs, iSessions)
 	serviceFactory := commandprocessor.ProvideServiceFactory(iAppPartitions, iTime, in10nBroker, iMetrics, vvmName, iAuthenticator, iSecretReader)
 	operatorCommandProcessors := provideCommandProcessors(numCommandProcessors, commandChannelFactory, serviceFactory)
 	numQueryProcessors := vvmConfig.NumQueryProcessors
@@ -186,7 +218,7 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	iAppPartsCtlPipelineService := provideAppPartsCtlPipelineService(iAppPartitionsController)
 	v7 := provideBuiltInApps(builtInAppsArtefacts, v5)
 	routerAppStoragePtr := provideRouterAppStoragePtr(iAppStorageProvider)
-	bootstrapOperator, err := provideBootstrapOperator(iFederation, iAppStructsProvider, iTime, iAppPartitions, v7, v5, iTokens, iAppStorageProvider, blobAppStoragePtr, routerAppStoragePtr)
+	bootstrapOperator, err := provideBootstrapOperator(iFederation, iAppStructsProvider, iTime, iAppPartitions, v7, v5, iTokens, iAppStorageProvider, blobAppStoragePtr, routerAppStoragePtr, vvmConfig)
 	if err != nil {
 		cleanup4()
 		cleanup3()
@@ -214,7 +246,13 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		cleanup()
 		return nil, nil, err
 	}
-	routerServices := provideRouterServices(routerParams, sendTimeout, in10nBroker, iRequestHandler, quotas, wLimiterFactory, blobStorage, cache, iRequestSender, vvmPortSource, v8, iTokens, iFederation, iAppTokensFactory)
+	icdc := cdc.Provide(iAppStructsProvider, in10nBroker)
+	in10nCUDs := n10ncuds.Provide(iAppPartitions, iAuthenticator)
+	iRelyingParty, err := provideOIDCRelyingParty(vvmConfig, iTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	routerServices := provideRouterServices(routerParams, sendTimeout, in10nBroker, iRequestHandler, quotas, wLimiterFactory, blobStorage, cache, iRequestSender, vvmPortSource, v8, iTokens, iFederation, iAppTokensFactory, icdc, in10nCUDs, iRelyingParty, iTime)
 	adminEndpointServiceOperator := provideAdminEndpointServiceOperator(routerServices)
 	metricsServicePort := vvmConfig.MetricsServicePort
 	metricsService := metrics.ProvideMetricsService(vvmCtx, metricsServicePort, iMetrics)
@@ -307,6 +345,28 @@ func Provide(vvmCfg *VVMConfig) (voedgerVM *VoedgerVM, err error) {
 	return voedgerVM, nil
 }
 
+func provideN10nBroker(quotas in10n.Quotas, iTime timeu.ITime, vvmCfg *VVMConfig, prov istorage.IAppStorageProvider) (in10n.IN10nBroker, func(), error) {
+	local, localCleanup := in10nmem.ProvideEx3(quotas, iTime, vvmCfg.N10nChannelGracePeriod)
+	if vvmCfg.N10nTransport == nil {
+		return local, localCleanup, nil
+	}
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		localCleanup()
+		return nil, nil, err
+	}
+	transport, err := vvmCfg.N10nTransport(storage.NewN10nClusterStorage(sysVVMStorage), iTime)
+	if err != nil {
+		localCleanup()
+		return nil, nil, err
+	}
+	broker, brokerCleanup := in10ncluster.Provide(local, transport, iTime)
+	return broker, func() {
+		brokerCleanup()
+		localCleanup()
+	}, nil
+}
+
 func provideIVVMAppTTLStorage(prov istorage.IAppStorageProvider) (storage.ISysVvmStorage, error) {
 	return prov.AppStorage(istructs.AppQName_sys_vvm)
 }
@@ -332,7 +392,7 @@ func provideSchedulerRunner(cfg schedulers.BasicSchedulerConfig) appparts.ISched
 
 func provideBootstrapOperator(federation2 federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, apppar appparts.IAppPartitions,
 	builtinApps []appparts.BuiltInApp, sidecarApps []appparts.SidecarApp, itokens2 itokens.ITokens, storageProvider istorage.IAppStorageProvider, blobberAppStoragePtr iblobstoragestg.BlobAppStoragePtr,
-	routerAppStoragePtr dbcertcache.RouterAppStoragePtr) (BootstrapOperator, error) {
+	routerAppStoragePtr dbcertcache.RouterAppStoragePtr, vvmConfig *VVMConfig) (BootstrapOperator, error) {
 	var clusterBuiltinApp btstrp.ClusterBuiltInApp
 	otherApps := make([]appparts.BuiltInApp, 0, len(builtinApps))
 	for _, app := range builtinApps {
@@ -351,7 +411,8 @@ func provideBootstrapOperator(federation2 federation.IFederation, asp istructs.I
 		return nil, fmt.Errorf("%s app should be added to VVM builtin apps", istructs.AppQName_sys_cluster)
 	}
 	return pipeline.NewSyncOp(func(ctx context.Context, work pipeline.IWorkpiece) (err error) {
-		return btstrp.Bootstrap(federation2, asp, time, apppar, clusterBuiltinApp, otherApps, sidecarApps, itokens2, storageProvider, blobberAppStoragePtr, routerAppStoragePtr)
+		return btstrp.Bootstrap(federation2, asp, time, apppar, clusterBuiltinApp, otherApps, sidecarApps, itokens2, storageProvider, blobberAppStoragePtr, routerAppStoragePtr,
+			vvmConfig.MaintenanceApps)
 	}), nil
 }
 
@@ -412,9 +473,9 @@ func provideAppsExtensionPoints(vvmConfig *VVMConfig) map[appdef.AppQName]extens
 
 func provideStatelessResources(cfgs AppConfigsTypeEmpty, vvmCfg *VVMConfig, appEPs map[appdef.AppQName]extensionpoints.IExtensionPoint,
 	buildInfo *debug.BuildInfo, sp istorage.IAppStorageProvider, itokens2 itokens.ITokens, federation2 federation.IFederation,
-	asp istructs.IAppStructsProvider, atf payloads.IAppTokensFactory) istructsmem.IStatelessResources {
+	asp istructs.IAppStructsProvider, atf payloads.IAppTokensFactory, sessions isessions.ISessions) istructsmem.IStatelessResources {
 	ssr := istructsmem.NewStatelessResources()
-	sysprovide.ProvideStateless(ssr, vvmCfg.SMTPConfig, appEPs, buildInfo, sp, vvmCfg.WSPostInitFunc, vvmCfg.Time, itokens2, federation2, asp, atf)
+	sysprovide.ProvideStateless(ssr, vvmCfg.SMTPConfig, appEPs, buildInfo, sp, vvmCfg.WSPostInitFunc, vvmCfg.Time, itokens2, federation2, asp, atf, sessions, vvmCfg.WebhooksAllowPrivateAddresses)
 	return ssr
 }
 
@@ -526,16 +587,69 @@ func provideSubjectGetterFunc() iauthnzimpl.SubjectGetterFunc {
 	}
 }
 
-func provideBucketsFactory(time timeu.ITime) irates.BucketsFactoryType {
+func provideBucketsFactory(time timeu.ITime, vvmCfg *VVMConfig, prov istorage.IAppStorageProvider) (irates.BucketsFactoryType, error) {
+	if !vvmCfg.SharedRateBuckets {
+		return func() irates.IBuckets {
+			return iratesce.Provide(time)
+		}, nil
+	}
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		return nil, err
+	}
+	rateBucketsStorage := storage.NewRateBucketsStorage(sysVVMStorage)
 	return func() irates.IBuckets {
-		return iratesce.Provide(time)
+		return iratesstg.Provide(rateBucketsStorage, time)
+	}, nil
+}
+
+func provideSessions(time timeu.ITime, prov istorage.IAppStorageProvider) (isessions.ISessions, error) {
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		return nil, err
 	}
+	return isessions.Provide(storage.NewSessionsStorage(sysVVMStorage), time, sessionsCheckCacheTTL), nil
 }
 
 func provideSecretKeyJWT(sr isecrets.ISecretReader) (itokensjwt.SecretKeyType, error) {
 	return sr.ReadSecret(itokensjwt.SecretKeyJWTName)
 }
 
+// TOTP secrets encryption key is kept in the separate secret, so that it is not changed together with secretKeyJWT
+// no secret -> TOTP is not available
+func provideSecondFactorConfig(vvmCfg *VVMConfig, sr isecrets.ISecretReader, prov istorage.IAppStorageProvider) (registry.SecondFactorConfig, error) {
+	totpSecretKey, err := sr.ReadSecret(registry.TOTPSecretKeyName)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		totpSecretKey = nil
+	case err != nil:
+		return registry.SecondFactorConfig{}, err
+	case len(totpSecretKey) != totpSecretKeyLen:
+		return registry.SecondFactorConfig{}, fmt.Errorf("%s secret must be %d bytes long, got %d", registry.TOTPSecretKeyName, totpSecretKeyLen, len(totpSecretKey))
+	}
+	sysVVMStorage, err := prov.AppStorage(istructs.AppQName_sys_vvm)
+	if err != nil {
+		return registry.SecondFactorConfig{}, err
+	}
+	return registry.SecondFactorConfig{
+		TOTPSecretKey:          totpSecretKey,
+		Storage:                storage.NewSecondFactorStorage(sysVVMStorage),
+		RequiredForGlobalRoles: vvmCfg.SecondFactorRequiredForGlobalRoles,
+	}, nil
+}
+
+func provideITokens(secretKey itokensjwt.SecretKeyType, vvmCfg *VVMConfig, time timeu.ITime) (itokens.ITokens, error) {
+	return itokensjwt.ProvideITokensWithKeys(secretKey, vvmCfg.JWTSigningKeys, vvmCfg.JWTRejectHS256, time)
+}
+
+// nil -> OIDC login is not available
+func provideOIDCRelyingParty(vvmCfg *VVMConfig, time timeu.ITime) (oidc.IRelyingParty, error) {
+	if len(vvmCfg.OIDCProviders) == 0 {
+		return nil, nil
+	}
+	return oidc.Provide(vvmCfg.OIDCProviders, time)
+}
+
 func provideNumsAppsWorkspaces(vvmApps VVMApps, asp istructs.IAppStructsProvider, sidecarApps []appparts.SidecarApp) (map[appdef.AppQName]istructs.NumAppWorkspaces, error) {
 	res := map[appdef.AppQName]istructs.NumAppWorkspaces{}
 	for _, appQName := range vvmApps {
@@ -604,6 +718,7 @@ func provideRouterParams(cfg *VVMConfig, port VVMPortType) router.RouterParams {
 		Routes:               cfg.Routes,
 		RoutesRewrite:        cfg.RoutesRewrite,
 		RouteDomains:         cfg.RouteDomains,
+		WSAllowedOrigins:     cfg.RouterWSAllowedOrigins,
 		Port:                 int(port),
 	}
 	return res
@@ -778,9 +893,14 @@ func provideChannelGroups(cfg *VVMConfig) (res []iprocbusmem.ChannelGroup) {
 }
 
 func provideCachingAppStorageProvider(storageCacheSize StorageCacheSizeType, metrics2 imetrics.IMetrics,
-	vvmName processors.VVMName, uncachingProvider IAppStorageUncachingProviderFactory, iTime timeu.ITime) istorage.IAppStorageProvider {
-	aspNonCaching := uncachingProvider()
-	return istoragecache.Provide(int(storageCacheSize), aspNonCaching, metrics2, string(vvmName), iTime)
+	vvmName processors.VVMName, uncachingProvider IAppStorageUncachingProviderFactory, iTime timeu.ITime, vvmCfg *VVMConfig) istorage.IAppStorageProvider {
+	// values are compressed under the cache, so the cache keeps not compressed values
+	aspNonCaching := istoragecompress.Provide(uncachingProvider(), vvmCfg.StorageCompression, metrics2, string(vvmName))
+	tiers := []istoragecache.ICacheTierFactory{istoragecache.NewLocalTier(int(storageCacheSize))}
+	if vvmCfg.StorageCacheSharedTier != nil {
+		tiers = append(tiers, vvmCfg.StorageCacheSharedTier)
+	}
+	return istoragecache.ProvideTiered(tiers, aspNonCaching, metrics2, string(vvmName), iTime)
 }
 
 func provideBlobAppStoragePtr(astp istorage.IAppStorageProvider) iblobstoragestg.BlobAppStoragePtr {
@@ -800,9 +920,10 @@ func provideRouterServices(rp router.RouterParams, sendTimeout bus.SendTimeout,
 	wLimiterFactory blobprocessor.WLimiterFactory, blobStorage BlobStorage,
 	autocertCache autocert.Cache, requestSender bus.IRequestSender, vvmPortSource *VVMPortSource,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens, federation2 federation.IFederation,
-	appTokensFactory payloads.IAppTokensFactory) RouterServices {
+	appTokensFactory payloads.IAppTokensFactory, feed cdc.ICDC, n10nCUDs n10ncuds.IN10nCUDs,
+	relyingParty oidc.IRelyingParty, iTime timeu.ITime) RouterServices {
 	httpSrv, acmeSrv, adminSrv := router.Provide(rp, broker, blobRequestHandler, autocertCache, requestSender, numsAppsWorkspaces,
-		iTokens, federation2, appTokensFactory)
+		iTokens, federation2, appTokensFactory, feed, n10nCUDs, relyingParty, iTime)
 	vvmPortSource.getter = func() VVMPortType {
 		return VVMPortType(httpSrv.GetPort())
 	}
@@ -897,6 +1018,5 @@ func provideServicePipeline(
 	publicEndpoint PublicEndpointServiceOperator,
 	appStorageProvider istorage.IAppStorageProvider,
 ) ServicePipeline {
-	return pipeline.NewSyncPipeline(vvmCtx, "ServicePipeline", pipeline.WireSyncOperator("internal services", pipeline.ForkOperator(pipeline.ForkSame, pipeline.ForkBranch(opQueryProcessors_v1), pipeline.ForkBranch(opQueryProcessors_v2), pipeline.ForkBranch(opCommandProcessors), pipeline.ForkBranch(opBLOBProcessors), pipeline.ForkBranch(pipeline.ServiceOperator(opAsyncActualizers)), pipeline.ForkBranch(pipeline.ServiceOperator(appPartsCtl)), pipeline.ForkBranch(pipeline.ServiceOperator(appStorageProvider)))), pipeline.WireSyncOperator("admin endpoint", adminEndpoint), pipeline.WireSyncOperator("bootstrap", bootstrapSyncOp), pipeline.WireSyncOperator("public endpoint", publicEndpoint),
-	)
+	return pipeline.NewSyncPipeline(vvmCtx, "ServicePipeline", pipeline.WireSyncOperator("internal services", pipeline.ForkOperator(pipeline.ForkSame, pipeline.ForkBranch(opQueryProcessors_v1), pipeline.ForkBranch(opQueryProcessors_v2), pipeline.ForkBranch(opCommandProcessors), pipeline.ForkBranch(opBLOBProcessors), pipeline.ForkBranch(pipeline.ServiceOperator(opAsyncActualizers)), pipeline.ForkBranch(pipeline.ServiceOperator(appPartsCtl)), pipeline.ForkBranch(pipeline.ServiceOperator(appStorageProvider)))), pipeline.WireSyncOperator("admin endpoint", adminEndpoint), pipeline.WireSyncOperator("bootstrap", bootstrapSyncOp), pipeline.WireSyncOperator("public endpoint", publicEndpoint))
 }
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import "time"
+
+// archive format version, stored in the manifest
+const ArchiveVersion = 1
+
+// archive entries
+const (
+	entry_Manifest = "manifest.json"
+	entry_WLog     = "wlog.jsonl"
+	entry_Records  = "records.jsonl"
+	entry_Views    = "views.jsonl"
+	entry_BLOBs    = "blobs.jsonl"
+	entry_BLOBData = "blobs/%d"
+)
+
+const (
+	// records are read from the source workspace by batches of this size
+	recordsBatchSize = 256
+
+	// how many times the next PLog offset is tried if the partition is being written concurrently
+	maxPLogOffsetAttempts = 100
+
+	// lifetime of the verified value tokens issued on import to put values into verifiable fields
+	verifiedValueTokenDuration = time.Minute
+)
@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import "errors"
+
+var (
+	ErrWorkspaceNotEmpty          = errors.New("target workspace is not empty")
+	ErrAppMismatch                = errors.New("archive is exported from another application")
+	ErrUnsupportedArchiveVersion  = errors.New("unsupported archive version")
+	ErrArchiveEntryNotFound       = errors.New("archive entry not found")
+	ErrPLogOffsetAttemptsExceeded = errors.New("failed to find free PLog offset")
+	ErrWorkspaceMismatch          = errors.New("archive is exported from another workspace")
+	ErrRecordNotFound             = errors.New("archived record is not found in the workspace")
+)
@@ -0,0 +1,290 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import (
+	"archive/zip"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/blobber"
+)
+
+// Export writes the logical backup of the workspace to w as zip archive
+// The workspace is expected to be not changed during the export
+func Export(ctx context.Context, params ExportParams, w io.Writer) (m Manifest, err error) {
+	e := &exporter{
+		params:     params,
+		appDef:     params.AppStructs.AppDef(),
+		zw:         zip.NewWriter(w),
+		createdIDs: []istructs.RecordID{},
+		blobIDs:    []istructs.RecordID{},
+	}
+	e.m = Manifest{
+		Version:  ArchiveVersion,
+		AppQName: params.AppStructs.AppQName(),
+		WSID:     params.WSID,
+	}
+
+	steps := []func(context.Context) error{e.exportWLog, e.exportRecords, e.exportViews, e.exportBLOBs, e.exportManifest}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return e.m, err
+		}
+		if ctx.Err() != nil {
+			return e.m, ctx.Err()
+		}
+	}
+	return e.m, e.zw.Close()
+}
+
+type exporter struct {
+	params ExportParams
+	appDef appdef.IAppDef
+	zw     *zip.Writer
+	m      Manifest
+
+	// IDs of records created by the workspace events
+	createdIDs []istructs.RecordID
+	blobIDs    []istructs.RecordID
+}
+
+func (e *exporter) exportWLog(ctx context.Context) error {
+	enc, err := e.createEntry(entry_WLog)
+	if err != nil {
+		return err
+	}
+	return e.params.AppStructs.Events().ReadWLog(ctx, e.params.WSID, istructs.FirstOffset, istructs.ReadToTheEnd,
+		func(wlogOffset istructs.Offset, event istructs.IWLogEvent) error {
+			defer event.Release()
+			row := e.eventToRow(wlogOffset, event)
+			if err := enc.Encode(&row); err != nil {
+				return fmt.Errorf("failed to export event %d: %w", wlogOffset, err)
+			}
+			e.m.Events++
+			e.m.LastWLogOffset = wlogOffset
+			return nil
+		})
+}
+
+func (e *exporter) eventToRow(wlogOffset istructs.Offset, event istructs.IWLogEvent) eventRow {
+	row := eventRow{
+		WLogOffset:   wlogOffset,
+		QName:        event.QName(),
+		RegisteredAt: event.RegisteredAt(),
+		Synced:       event.Synced(),
+	}
+	if row.Synced {
+		row.DeviceID = event.DeviceID()
+		row.SyncedAt = event.SyncedAt()
+	}
+	if !event.Error().ValidEvent() {
+		row.QName = event.Error().QNameFromParams()
+		row.Error = event.Error().ErrStr()
+		return row
+	}
+
+	if arg := event.ArgumentObject(); arg.QName() != appdef.NullQName {
+		row.Argument = coreutils.ObjectToMap(arg, e.appDef)
+		if appdef.ODoc(e.appDef.Type, arg.QName()) != nil {
+			e.collectObjectIDs(arg)
+		}
+	}
+
+	updates := []istructs.ICUDRow{}
+	for cud := range event.CUDs {
+		if !cud.IsNew() {
+			updates = append(updates, cud)
+			continue
+		}
+		row.CUDs = append(row.CUDs, cudRow{
+			IsNew:  true,
+			Fields: coreutils.FieldsToMap(cud, e.appDef),
+		})
+		e.createdIDs = append(e.createdIDs, cud.ID())
+		e.m.LastRecordID = max(e.m.LastRecordID, cud.ID())
+		if cud.QName() == blobber.QNameWDocBLOB {
+			e.blobIDs = append(e.blobIDs, cud.ID())
+		}
+	}
+	// updates of the read event are enumerated in random order, so they are sorted to get the same archive each time
+	slices.SortFunc(updates, func(a, b istructs.ICUDRow) int { return cmp.Compare(a.ID(), b.ID()) })
+	for _, cud := range updates {
+		row.CUDs = append(row.CUDs, cudRow{Fields: coreutils.FieldsToMap(cud, e.appDef)})
+	}
+	return row
+}
+
+// ODoc records IDs are taken from the same sequence as the other records
+func (e *exporter) collectObjectIDs(obj istructs.IObject) {
+	e.m.LastRecordID = max(e.m.LastRecordID, obj.AsRecordID(appdef.SystemField_ID))
+	for child := range obj.Children() {
+		e.collectObjectIDs(child)
+	}
+}
+
+// current state of the records is exported, so changes made without events (e.g. unlogged updates) are kept
+func (e *exporter) exportRecords(ctx context.Context) error {
+	enc, err := e.createEntry(entry_Records)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(e.createdIDs) && ctx.Err() == nil; start += recordsBatchSize {
+		ids := e.createdIDs[start:min(start+recordsBatchSize, len(e.createdIDs))]
+		batch := make([]istructs.RecordGetBatchItem, len(ids))
+		for i, id := range ids {
+			batch[i].ID = id
+		}
+		if err := e.params.AppStructs.Records().GetBatch(e.params.WSID, true, batch); err != nil {
+			return err
+		}
+		for _, item := range batch {
+			if item.Record.QName() == appdef.NullQName {
+				// e.g. ORecord
+				continue
+			}
+			if err := enc.Encode(coreutils.FieldsToMap(item.Record, e.appDef)); err != nil {
+				return fmt.Errorf("failed to export record %d: %w", item.ID, err)
+			}
+			e.m.Records++
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportViews(ctx context.Context) error {
+	reader, ok := e.params.AppStructs.ViewRecords().(istructs.IWorkspaceViewsReader)
+	if !ok {
+		return istorage.ErrPKeysScanNotSupported
+	}
+	enc, err := e.createEntry(entry_Views)
+	if err != nil {
+		return err
+	}
+	skipped := asyncProjectorsViews(e.appDef)
+	for view := range skipped {
+		e.m.SkippedViews = append(e.m.SkippedViews, view)
+	}
+	slices.SortFunc(e.m.SkippedViews, appdef.CompareQName)
+	return reader.ReadWorkspaceViews(ctx, e.params.WSID, func(key istructs.IKey, value istructs.IValue) error {
+		viewName := value.AsQName(appdef.SystemField_QName)
+		if skipped[viewName] {
+			return nil
+		}
+		row := coreutils.FieldsToMap(key, e.appDef)
+		for name, v := range coreutils.FieldsToMap(value, e.appDef) {
+			row[name] = v
+		}
+		row[appdef.SystemField_QName] = viewName
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("failed to export %s view record: %w", viewName, err)
+		}
+		e.m.ViewRecords++
+		return nil
+	})
+}
+
+func (e *exporter) exportBLOBs(ctx context.Context) error {
+	if e.params.BLOBStorage == nil {
+		return nil
+	}
+	rows := []blobRow{}
+	for _, blobID := range e.blobIDs {
+		key := iblobstorage.PersistentBLOBKeyType{
+			ClusterAppID: istructs.ClusterAppID_sys_blobber,
+			WSID:         e.params.WSID,
+			BlobID:       blobID,
+		}
+		data := &entryWriter{}
+		stateCallback := func(state iblobstorage.BLOBState) (err error) {
+			rows = append(rows, blobRow{BlobID: blobID, Descr: state.Descr})
+			data.w, err = e.zw.Create(fmt.Sprintf(entry_BLOBData, blobID))
+			return err
+		}
+		err := e.params.BLOBStorage.ReadBLOB(ctx, &key, stateCallback, data, noLimit)
+		if errors.Is(err, iblobstorage.ErrBLOBNotFound) {
+			// upload is failed or not finished yet
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export BLOB %d: %w", blobID, err)
+		}
+		e.m.BLOBs++
+	}
+
+	// the previous entry is closed on the next entry creation, so descriptions are written after all BLOBs data
+	enc, err := e.createEntry(entry_BLOBs)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := enc.Encode(&row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportManifest(context.Context) error {
+	w, err := e.zw.Create(entry_Manifest)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&e.m)
+}
+
+func (e *exporter) createEntry(name string) (*json.Encoder, error) {
+	w, err := e.zw.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return json.NewEncoder(w), nil
+}
+
+// views that are written by async projectors only are rebuilt by the actualizers from the imported events
+func asyncProjectorsViews(appDef appdef.IAppDef) map[appdef.QName]bool {
+	async, other := map[appdef.QName]bool{}, map[appdef.QName]bool{}
+	for ext := range appdef.Extensions(appDef.Types()) {
+		storage := ext.Intents().Storage(sys.Storage_View)
+		if storage == nil {
+			continue
+		}
+		written := other
+		if prj, ok := ext.(appdef.IProjector); ok && !prj.Sync() {
+			written = async
+		}
+		for _, view := range storage.Names() {
+			written[view] = true
+		}
+	}
+	for view := range other {
+		delete(async, view)
+	}
+	return async
+}
+
+// BLOB data entry is created by the BLOB storage state callback, so the entry is not created if the BLOB is not found
+type entryWriter struct {
+	w io.Writer
+}
+
+func (ew *entryWriter) Write(p []byte) (int, error) {
+	return ew.w.Write(p)
+}
+
+func noLimit(uint64) error { return nil }
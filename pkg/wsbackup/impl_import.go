@@ -0,0 +1,475 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+)
+
+// Import replays the archive written by Export into the empty workspace, e.g. to inspect the data of the workspace as it was
+// Events are written as synced events with the source record IDs and WLog offsets starting from the next free PLog offset of the partition
+// Async projectors handle the imported events as the usual ones, so views built by them are not imported
+// Events, records and sequences are written to the storage directly, bypassing the command processor and the sequencer,
+// so the partition of the workspace must be stopped during the import, e.g. by VVMConfig.MaintenanceApps
+// The imported workspace is not registered (no cdoc.sys.WorkspaceID and no parent link), use Restore to restore the workspace into its own WSID
+func Import(ctx context.Context, params ImportParams, r io.ReaderAt, size int64) (m Manifest, err error) {
+	zr, m, err := openArchive(r, size, params.AppStructs)
+	if err != nil {
+		return m, err
+	}
+	i := &importer{
+		params: params,
+		appDef: params.AppStructs.AppDef(),
+		zr:     zr,
+	}
+
+	if err := i.checkWorkspaceEmpty(ctx); err != nil {
+		return m, err
+	}
+	if i.plogOffset = params.PLogOffset; i.plogOffset == istructs.NullOffset {
+		if i.plogOffset, err = i.findPLogEnd(ctx); err != nil {
+			return m, err
+		}
+	}
+
+	steps := []func(context.Context) error{i.importWLog, i.importRecords, i.importViews, i.importBLOBs}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return m, err
+		}
+		if ctx.Err() != nil {
+			return m, ctx.Err()
+		}
+	}
+	return m, i.putSequences(m)
+}
+
+type importer struct {
+	params     ImportParams
+	appDef     appdef.IAppDef
+	zr         *zip.Reader
+	plogOffset istructs.Offset
+}
+
+func (i *importer) checkWorkspaceEmpty(ctx context.Context) error {
+	return i.params.AppStructs.Events().ReadWLog(ctx, i.params.WSID, istructs.FirstOffset, istructs.ReadToTheEnd,
+		func(istructs.Offset, istructs.IWLogEvent) error {
+			return fmt.Errorf("%w: workspace %d", ErrWorkspaceNotEmpty, i.params.WSID)
+		})
+}
+
+// PLog offsets are sequential, so the first free one is found by the exponential and then binary search
+func (i *importer) findPLogEnd(ctx context.Context) (istructs.Offset, error) {
+	exists := func(offset istructs.Offset) (ok bool, err error) {
+		err = i.params.AppStructs.Events().ReadPLog(ctx, i.params.Partition, offset, 1, func(_ istructs.Offset, event istructs.IPLogEvent) error {
+			ok = true
+			return nil
+		})
+		return ok, err
+	}
+	lo, hi := istructs.NullOffset, istructs.FirstOffset // lo exists, hi is checked
+	for {
+		ok, err := exists(hi)
+		if err != nil || !ok {
+			if err != nil {
+				return istructs.NullOffset, err
+			}
+			break
+		}
+		lo, hi = hi, hi*2
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, err := exists(mid)
+		if err != nil {
+			return istructs.NullOffset, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
+
+func (i *importer) importWLog(ctx context.Context) error {
+	return i.readEntry(entry_WLog, func(dec *json.Decoder) error {
+		for dec.More() && ctx.Err() == nil {
+			row := eventRow{}
+			if err := dec.Decode(&row); err != nil {
+				return err
+			}
+			if err := i.importEvent(row); err != nil {
+				return fmt.Errorf("failed to import event %d: %w", row.WLogOffset, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (i *importer) importEvent(row eventRow) error {
+	for attempt := 0; attempt < maxPLogOffsetAttempts; attempt++ {
+		rawEvent, buildErr, err := i.buildEvent(row)
+		if err != nil {
+			return err
+		}
+		// ok to use local IDGenerator here, the source IDs are kept for the synced events
+		plogEvent, err := i.params.AppStructs.Events().PutPlog(rawEvent, buildErr, istructsmem.NewIDGenerator())
+		if errors.Is(err, istructsmem.ErrSequencesViolation) {
+			// the offset is taken by the concurrent command
+			i.plogOffset++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		defer plogEvent.Release()
+		i.plogOffset++
+		if plogEvent.Error().ValidEvent() {
+			if err := i.params.AppStructs.Records().Apply(plogEvent); err != nil {
+				return err
+			}
+		}
+		return i.params.AppStructs.Events().PutWlog(plogEvent)
+	}
+	return ErrPLogOffsetAttemptsExceeded
+}
+
+func (i *importer) buildEvent(row eventRow) (rawEvent istructs.IRawEvent, buildErr error, err error) {
+	reb := i.params.AppStructs.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+		GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+			HandlingPartition: i.params.Partition,
+			PLogOffset:        i.plogOffset,
+			Workspace:         i.params.WSID,
+			WLogOffset:        row.WLogOffset,
+			QName:             row.QName,
+			RegisteredAt:      row.RegisteredAt,
+		},
+		Device:   row.DeviceID,
+		SyncedAt: row.SyncedAt,
+	})
+
+	if len(row.Error) > 0 {
+		// the event is stored with the source error whatever the build result is
+		rawEvent, _ = reb.BuildRawEvent()
+		return rawEvent, errors.New(row.Error), nil
+	}
+
+	if len(row.Argument) > 0 {
+		if err := i.verifyObjectFields(row.Argument); err != nil {
+			return nil, nil, err
+		}
+		reb.ArgumentObjectBuilder().FillFromJSON(row.Argument)
+	}
+
+	for _, cud := range row.CUDs {
+		qName, err := fieldsQName(cud.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := i.verifyFields(qName, cud.Fields); err != nil {
+			return nil, nil, err
+		}
+		if cud.IsNew {
+			reb.CUDBuilder().Create(qName).PutFromJSON(cud.Fields)
+			continue
+		}
+		id, err := fieldsID(cud.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing, err := i.params.AppStructs.Records().Get(i.params.WSID, true, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		reb.CUDBuilder().Update(existing).PutFromJSON(cud.Fields)
+	}
+
+	rawEvent, err = reb.BuildRawEvent()
+	if err != nil {
+		return nil, nil, err
+	}
+	return rawEvent, nil, nil
+}
+
+func (i *importer) importRecords(ctx context.Context) error {
+	return i.readEntry(entry_Records, func(dec *json.Decoder) error {
+		for dec.More() && ctx.Err() == nil {
+			fields := map[string]any{}
+			if err := dec.Decode(&fields); err != nil {
+				return err
+			}
+			qName, err := fieldsQName(fields)
+			if err != nil {
+				return err
+			}
+			if err := i.verifyFields(qName, fields); err != nil {
+				return err
+			}
+			if err := i.params.AppStructs.Records().PutJSON(i.params.WSID, fields); err != nil {
+				return fmt.Errorf("failed to import record %v: %w", fields[appdef.SystemField_ID], err)
+			}
+		}
+		return nil
+	})
+}
+
+func (i *importer) importViews(ctx context.Context) error {
+	return i.readEntry(entry_Views, func(dec *json.Decoder) error {
+		for dec.More() && ctx.Err() == nil {
+			fields := map[string]any{}
+			if err := dec.Decode(&fields); err != nil {
+				return err
+			}
+			if err := i.putViewRecord(fields); err != nil {
+				return fmt.Errorf("failed to import %v view record: %w", fields[appdef.SystemField_QName], err)
+			}
+		}
+		return nil
+	})
+}
+
+// record fields of the view value (e.g. sys.CollectionView) are exported as objects, so they are put as the imported records
+func (i *importer) putViewRecord(fields map[string]any) error {
+	viewRecords := i.params.AppStructs.ViewRecords()
+	viewName, err := fieldsQName(fields)
+	view := appdef.View(i.appDef.Type, viewName)
+	if err != nil || view == nil {
+		// PutJSON reports the error
+		return viewRecords.PutJSON(i.params.WSID, fields)
+	}
+	kb := viewRecords.KeyBuilder(viewName)
+	vb := viewRecords.NewValueBuilder(viewName)
+	keyFields := map[string]any{}
+	valueFields := map[string]any{}
+	for name, value := range fields {
+		switch {
+		case name == appdef.SystemField_QName:
+		case view.Key().Field(name) != nil:
+			keyFields[name] = value
+		default:
+			recordFields, ok := value.(map[string]any)
+			if f := view.Value().Field(name); !ok || f == nil || f.DataKind() != appdef.DataKind_Record {
+				valueFields[name] = value
+				continue
+			}
+			id, err := fieldsID(recordFields)
+			if err != nil {
+				return err
+			}
+			record, err := i.params.AppStructs.Records().Get(i.params.WSID, true, id)
+			if err != nil {
+				return err
+			}
+			if record.QName() == appdef.NullQName {
+				return fmt.Errorf("record %d of field %s is not imported", id, name)
+			}
+			vb.PutRecord(name, record)
+		}
+	}
+	kb.PutFromJSON(keyFields)
+	vb.PutFromJSON(valueFields)
+	return viewRecords.Put(i.params.WSID, kb, vb)
+}
+
+func (i *importer) importBLOBs(ctx context.Context) error {
+	if i.params.BLOBStorage == nil {
+		return nil
+	}
+	rows, err := i.readBLOBRows()
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := i.writeBLOB(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// no rows if the archive is exported without BLOBs
+func (i *importer) readBLOBRows() (rows []blobRow, err error) {
+	err = i.readEntry(entry_BLOBs, func(dec *json.Decoder) error {
+		for dec.More() {
+			row := blobRow{}
+			if err := dec.Decode(&row); err != nil {
+				return err
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+	if errors.Is(err, ErrArchiveEntryNotFound) {
+		return nil, nil
+	}
+	return rows, err
+}
+
+func (i *importer) blobKey(blobID istructs.RecordID) iblobstorage.PersistentBLOBKeyType {
+	return iblobstorage.PersistentBLOBKeyType{
+		ClusterAppID: istructs.ClusterAppID_sys_blobber,
+		WSID:         i.params.WSID,
+		BlobID:       blobID,
+	}
+}
+
+func (i *importer) writeBLOB(ctx context.Context, row blobRow) error {
+	name := fmt.Sprintf(entry_BLOBData, row.BlobID)
+	data, err := i.zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrArchiveEntryNotFound, name)
+	}
+	defer data.Close()
+	if _, err = i.params.BLOBStorage.WriteBLOB(ctx, i.blobKey(row.BlobID), row.Descr, data, noLimit); err != nil {
+		return fmt.Errorf("failed to import BLOB %d: %w", row.BlobID, err)
+	}
+	return nil
+}
+
+func (i *importer) putSequences(m Manifest) error {
+	if i.params.SeqStorage == nil {
+		return nil
+	}
+	wsid := isequencer.WSID(i.params.WSID)
+	return i.params.SeqStorage.PutNumbers(isequencer.ClusterAppID(i.params.ClusterAppID), []isequencer.SeqValue{
+		{
+			Key:   isequencer.NumberKey{WSID: wsid, SeqID: isequencer.SeqID(istructs.QNameIDWLogOffsetSequence)},
+			Value: isequencer.Number(m.LastWLogOffset),
+		},
+		{
+			Key:   isequencer.NumberKey{WSID: wsid, SeqID: isequencer.SeqID(istructs.QNameIDRecordIDSequence)},
+			Value: isequencer.Number(m.LastRecordID),
+		},
+	})
+}
+
+// replaces values of the verifiable fields of the object and its children with verified value tokens
+func (i *importer) verifyObjectFields(obj map[string]any) error {
+	qName, err := fieldsQName(obj)
+	if err != nil {
+		return err
+	}
+	if err := i.verifyFields(qName, obj); err != nil {
+		return err
+	}
+	for _, v := range obj {
+		children, ok := v.([]any)
+		if !ok {
+			continue
+		}
+		for _, c := range children {
+			if child, ok := c.(map[string]any); ok {
+				if err := i.verifyObjectFields(child); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// verifiable fields accept verified value tokens only
+func (i *importer) verifyFields(qName appdef.QName, fields map[string]any) error {
+	typ, ok := i.appDef.Type(qName).(appdef.IWithFields)
+	if !ok {
+		return nil
+	}
+	for name, value := range fields {
+		fld := typ.Field(name)
+		if fld == nil || !fld.Verifiable() {
+			continue
+		}
+		kind := appdef.VerificationKind_EMail
+		for _, k := range appdef.VerificationKind_Any {
+			if fld.VerificationKind(k) {
+				kind = k
+				break
+			}
+		}
+		token, err := i.params.AppStructs.AppTokens().IssueToken(verifiedValueTokenDuration, &payloads.VerifiedValuePayload{
+			VerificationKind: kind,
+			WSID:             i.params.WSID,
+			Entity:           qName,
+			Field:            name,
+			Value:            value,
+		})
+		if err != nil {
+			return err
+		}
+		fields[name] = token
+	}
+	return nil
+}
+
+func (i *importer) readEntry(name string, cb func(*json.Decoder) error) error {
+	return readEntry(i.zr, name, cb)
+}
+
+// ReadManifest reads the manifest of the archive written by Export
+func ReadManifest(r io.ReaderAt, size int64) (m Manifest, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return m, fmt.Errorf("failed to open archive: %w", err)
+	}
+	err = readEntry(zr, entry_Manifest, func(dec *json.Decoder) error { return dec.Decode(&m) })
+	return m, err
+}
+
+// checks the archive is exported from the application
+func openArchive(r io.ReaderAt, size int64, appStructs istructs.IAppStructs) (zr *zip.Reader, m Manifest, err error) {
+	if zr, err = zip.NewReader(r, size); err != nil {
+		return nil, m, fmt.Errorf("failed to open archive: %w", err)
+	}
+	if err := readEntry(zr, entry_Manifest, func(dec *json.Decoder) error { return dec.Decode(&m) }); err != nil {
+		return nil, m, err
+	}
+	if m.Version != ArchiveVersion {
+		return nil, m, fmt.Errorf("%w: %d", ErrUnsupportedArchiveVersion, m.Version)
+	}
+	if m.AppQName != appStructs.AppQName() {
+		return nil, m, fmt.Errorf("%w: %s, expected %s", ErrAppMismatch, m.AppQName, appStructs.AppQName())
+	}
+	return zr, m, nil
+}
+
+func readEntry(zr *zip.Reader, name string, cb func(*json.Decoder) error) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrArchiveEntryNotFound, name)
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	dec.UseNumber()
+	if err := cb(dec); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return nil
+}
+
+func fieldsQName(fields map[string]any) (appdef.QName, error) {
+	s, _ := fields[appdef.SystemField_QName].(string)
+	return appdef.ParseQName(s)
+}
+
+func fieldsID(fields map[string]any) (istructs.RecordID, error) {
+	n, _ := fields[appdef.SystemField_ID].(json.Number)
+	id, err := strconv.ParseUint(n.String(), 10, 64)
+	return istructs.RecordID(id), err
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+	"github.com/voedger/voedger/pkg/sys/builtin"
+)
+
+// Restore brings the records of the workspace back to the state of the archive exported from the same workspace,
+// e.g. after an accidental mass delete
+// Records are changed by CUDs through the command processor, so the partition keeps working and the workspace stays registered:
+// - records changed after the export get the archived field values and sys.IsActive
+// - records created after the export are deactivated
+// - missing BLOBs are written
+// Views are updated by the projectors on the restoring events, WLog keeps the events made after the export followed by the restoring ones
+func Restore(ctx context.Context, params RestoreParams, r io.ReaderAt, size int64) (res RestoreResult, err error) {
+	zr, m, err := openArchive(r, size, params.AppStructs)
+	if err != nil {
+		return res, err
+	}
+	if m.WSID != params.WSID {
+		return res, fmt.Errorf("%w: %d, expected %d", ErrWorkspaceMismatch, m.WSID, params.WSID)
+	}
+	rs := &restorer{
+		i: &importer{
+			params: ImportParams{
+				AppStructs:  params.AppStructs,
+				WSID:        params.WSID,
+				BLOBStorage: params.BLOBStorage,
+			},
+			appDef: params.AppStructs.AppDef(),
+			zr:     zr,
+		},
+		params: params,
+	}
+
+	// deactivations go first, so that unique values of the records created after the export are released
+	if err := rs.deactivateCreated(ctx, m.LastWLogOffset); err != nil {
+		return rs.res, err
+	}
+	if err := rs.restoreRecords(ctx); err != nil {
+		return rs.res, err
+	}
+	for _, cuds := range [][]CUD{rs.deactivations, rs.activations, rs.updates} {
+		if err := rs.send(ctx, cuds); err != nil {
+			return rs.res, err
+		}
+	}
+	return rs.res, rs.restoreBLOBs(ctx)
+}
+
+type restorer struct {
+	i      *importer
+	params RestoreParams
+	res    RestoreResult
+
+	// sys.IsActive could not be updated together with other fields
+	deactivations []CUD
+	activations   []CUD
+	updates       []CUD
+}
+
+// records created after the export are found by WLog events which are made after the export
+func (rs *restorer) deactivateCreated(ctx context.Context, lastWLogOffset istructs.Offset) error {
+	created := []istructs.RecordID{}
+	err := rs.params.AppStructs.Events().ReadWLog(ctx, rs.params.WSID, lastWLogOffset+1, istructs.ReadToTheEnd,
+		func(_ istructs.Offset, event istructs.IWLogEvent) error {
+			defer event.Release()
+			for cud := range event.CUDs {
+				if cud.IsNew() {
+					created = append(created, cud.ID())
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	for _, id := range created {
+		record, err := rs.params.AppStructs.Records().Get(rs.params.WSID, true, id)
+		if err != nil {
+			return err
+		}
+		if canBeDeactivated(rs.i.appDef, record) && record.AsBool(appdef.SystemField_IsActive) {
+			rs.deactivations = append(rs.deactivations, CUD{ID: id, Fields: map[string]any{appdef.SystemField_IsActive: false}})
+			rs.res.Records++
+		}
+	}
+	return nil
+}
+
+func (rs *restorer) restoreRecords(ctx context.Context) error {
+	return rs.i.readEntry(entry_Records, func(dec *json.Decoder) error {
+		for dec.More() && ctx.Err() == nil {
+			archived := map[string]any{}
+			if err := dec.Decode(&archived); err != nil {
+				return err
+			}
+			if err := rs.restoreRecord(archived); err != nil {
+				return fmt.Errorf("failed to restore record %v: %w", archived[appdef.SystemField_ID], err)
+			}
+		}
+		return ctx.Err()
+	})
+}
+
+func (rs *restorer) restoreRecord(archived map[string]any) error {
+	id, err := fieldsID(archived)
+	if err != nil {
+		return err
+	}
+	record, err := rs.params.AppStructs.Records().Get(rs.params.WSID, true, id)
+	if err != nil {
+		return err
+	}
+	if record.QName() == appdef.NullQName {
+		return fmt.Errorf("%w: %d", ErrRecordNotFound, id)
+	}
+	if record.QName() == authnz.QNameCDocWorkspaceDescriptor {
+		// the workspace state is managed by the workspace lifecycle
+		return nil
+	}
+	current, err := normalize(coreutils.FieldsToMap(record, rs.i.appDef))
+	if err != nil {
+		// notest
+		return err
+	}
+
+	changed := map[string]any{}
+	for name, value := range archived {
+		if !appdef.IsSysField(name) && !reflect.DeepEqual(value, current[name]) {
+			changed[name] = value
+		}
+	}
+	// fields that are set after the export are cleared
+	fields := rs.i.appDef.Type(record.QName()).(appdef.IWithFields)
+	for name, value := range current {
+		if _, ok := archived[name]; ok || appdef.IsSysField(name) {
+			continue
+		}
+		if zero, ok := zeroValue(fields.Field(name)); ok && !reflect.DeepEqual(value, zero) {
+			changed[name] = zero
+		}
+	}
+
+	if canBeDeactivated(rs.i.appDef, record) {
+		archivedActive, ok := archived[appdef.SystemField_IsActive].(bool)
+		archivedActive = archivedActive || !ok
+		currentActive := record.AsBool(appdef.SystemField_IsActive)
+		switch {
+		case archivedActive && !currentActive:
+			rs.activations = append(rs.activations, CUD{ID: id, Fields: map[string]any{appdef.SystemField_IsActive: true}})
+		case !archivedActive && currentActive:
+			rs.deactivations = append(rs.deactivations, CUD{ID: id, Fields: map[string]any{appdef.SystemField_IsActive: false}})
+		}
+		if archivedActive != currentActive {
+			rs.res.Records++
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := rs.i.verifyFields(record.QName(), changed); err != nil {
+		return err
+	}
+	rs.updates = append(rs.updates, CUD{ID: id, Fields: changed})
+	rs.res.Records++
+	return nil
+}
+
+func (rs *restorer) send(ctx context.Context, cuds []CUD) error {
+	for start := 0; start < len(cuds); start += builtin.MaxCUDs {
+		if !rs.params.DryRun {
+			if err := rs.params.SendCUDs(ctx, cuds[start:min(start+builtin.MaxCUDs, len(cuds))]); err != nil {
+				return err
+			}
+		}
+		rs.res.Events++
+	}
+	return nil
+}
+
+// BLOBs are not deleted, so only BLOBs which upload is failed or not finished yet are written
+func (rs *restorer) restoreBLOBs(ctx context.Context) error {
+	if rs.params.BLOBStorage == nil {
+		return nil
+	}
+	rows, err := rs.i.readBLOBRows()
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		key := rs.i.blobKey(row.BlobID)
+		_, err := rs.params.BLOBStorage.QueryBLOBState(ctx, &key)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, iblobstorage.ErrBLOBNotFound) {
+			return err
+		}
+		if !rs.params.DryRun {
+			if err := rs.i.writeBLOB(ctx, row); err != nil {
+				return err
+			}
+		}
+		rs.res.BLOBs++
+	}
+	return nil
+}
+
+// singletons and ORecords could not be deactivated
+func canBeDeactivated(appDef appdef.IAppDef, record istructs.IRecord) bool {
+	typ := appDef.Type(record.QName())
+	if singleton, ok := typ.(appdef.ISingleton); ok && singleton.Singleton() {
+		return false
+	}
+	fields, ok := typ.(appdef.IWithFields)
+	return ok && fields.Field(appdef.SystemField_IsActive) != nil
+}
+
+// the value to put into the field to clear it, false if the field could not be cleared by CUD
+func zeroValue(fld appdef.IField) (value any, ok bool) {
+	if fld == nil {
+		// notest
+		return nil, false
+	}
+	switch fld.DataKind() {
+	case appdef.DataKind_int8, appdef.DataKind_int16, appdef.DataKind_int32, appdef.DataKind_int64,
+		appdef.DataKind_float32, appdef.DataKind_float64, appdef.DataKind_RecordID:
+		return json.Number("0"), true
+	case appdef.DataKind_string, appdef.DataKind_bytes:
+		return "", true
+	case appdef.DataKind_bool:
+		return false, true
+	case appdef.DataKind_QName:
+		return appdef.NullQName.String(), true
+	}
+	return nil, false
+}
+
+// values are made the same as the archived ones, i.e. decoded from JSON with numbers as json.Number
+func normalize(fields map[string]any) (res map[string]any, err error) {
+	err = roundTrip(fields, &res)
+	return res, err
+}
+
+func roundTrip(value any, res any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(res)
+}
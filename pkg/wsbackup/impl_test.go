@@ -0,0 +1,504 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/appdef/filter"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/iblobstoragestg"
+	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/blobber"
+)
+
+var (
+	qNameDoc       = appdef.NewQName("test", "doc")
+	qNameRec       = appdef.NewQName("test", "rec")
+	qNameWDoc      = appdef.NewQName("test", "wdoc")
+	qNameOrder     = appdef.NewQName("test", "order")
+	qNameOrderItem = appdef.NewQName("test", "orderItem")
+	qNameCmdOrder  = appdef.NewQName("test", "cmdOrder")
+	qNameView      = appdef.NewQName("test", "view")
+	qNameAsyncView = appdef.NewQName("test", "asyncView")
+)
+
+const (
+	srcWSID      = istructs.WSID(1001)
+	dstWSID      = istructs.WSID(2002)
+	srcPartition = istructs.PartitionID(0)
+	dstPartition = istructs.PartitionID(1)
+)
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+	as, blobStorage := newTestApp(t)
+
+	docID := istructs.FirstUserRecordID
+	recID := docID + 1
+	wdocID := docID + 2
+	blobID := docID + 3
+	orderID := docID + 4
+	orderItemID := docID + 5
+	blobData := []byte("BLOB data")
+
+	t.Run("fill the source workspace", func(t *testing.T) {
+		plog := istructs.FirstOffset
+		wlog := istructs.FirstOffset
+		put := func(qName appdef.QName, buildErr error, fill func(reb istructs.IRawEventBuilder)) {
+			reb := as.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+				GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+					HandlingPartition: srcPartition,
+					PLogOffset:        plog,
+					Workspace:         srcWSID,
+					WLogOffset:        wlog,
+					QName:             qName,
+					RegisteredAt:      istructs.UnixMilli(wlog),
+				},
+				SyncedAt: istructs.UnixMilli(wlog),
+			})
+			fill(reb)
+			rawEvent, err := reb.BuildRawEvent()
+			if buildErr == nil {
+				require.NoError(err)
+			}
+			plogEvent, err := as.Events().PutPlog(rawEvent, buildErr, istructsmem.NewIDGenerator())
+			require.NoError(err)
+			if buildErr == nil {
+				require.NoError(as.Records().Apply(plogEvent))
+			}
+			require.NoError(as.Events().PutWlog(plogEvent))
+			plogEvent.Release()
+			plog++
+			wlog++
+		}
+
+		put(istructs.QNameCommandCUD, nil, func(reb istructs.IRawEventBuilder) {
+			doc := reb.CUDBuilder().Create(qNameDoc)
+			doc.PutRecordID(appdef.SystemField_ID, docID)
+			doc.PutString("name", "doc")
+			doc.PutString("email", verifiedEmailToken(t, as, qNameDoc, "test@test.com"))
+			rec := reb.CUDBuilder().Create(qNameRec)
+			rec.PutRecordID(appdef.SystemField_ID, recID)
+			rec.PutRecordID(appdef.SystemField_ParentID, docID)
+			rec.PutString(appdef.SystemField_Container, "recs")
+			rec.PutInt32("number", 42)
+			wdoc := reb.CUDBuilder().Create(qNameWDoc)
+			wdoc.PutRecordID(appdef.SystemField_ID, wdocID)
+			wdoc.PutString("value", "logged")
+			blob := reb.CUDBuilder().Create(blobber.QNameWDocBLOB)
+			blob.PutRecordID(appdef.SystemField_ID, blobID)
+			blob.PutInt32("status", 1)
+		})
+		put(istructs.QNameCommandCUD, nil, func(reb istructs.IRawEventBuilder) {
+			existing, err := as.Records().Get(srcWSID, true, docID)
+			require.NoError(err)
+			reb.CUDBuilder().Update(existing).PutString("name", "doc2")
+		})
+		put(qNameCmdOrder, nil, func(reb istructs.IRawEventBuilder) {
+			order := reb.ArgumentObjectBuilder()
+			order.PutRecordID(appdef.SystemField_ID, orderID)
+			order.PutInt32("number", 7)
+			item := order.ChildBuilder("items")
+			item.PutRecordID(appdef.SystemField_ID, orderItemID)
+			item.PutRecordID(appdef.SystemField_ParentID, orderID)
+			item.PutString("name", "item")
+		})
+		put(qNameCmdOrder, errors.New("test error"), func(istructs.IRawEventBuilder) {})
+
+		// unlogged update
+		require.NoError(as.Records().PutJSON(srcWSID, map[appdef.FieldName]any{
+			appdef.SystemField_QName: qNameWDoc.String(),
+			appdef.SystemField_ID:    wdocID,
+			"value":                  "unlogged",
+		}))
+
+		require.NoError(as.ViewRecords().PutJSON(srcWSID, map[appdef.FieldName]any{
+			appdef.SystemField_QName: qNameView.String(),
+			"pk":                     int32(1),
+			"cc":                     "a",
+			"value":                  int64(100),
+		}))
+		require.NoError(as.ViewRecords().PutJSON(srcWSID, map[appdef.FieldName]any{
+			appdef.SystemField_QName: qNameAsyncView.String(),
+			"pk":                     int32(1),
+			"cc":                     int32(2),
+			"value":                  int64(200),
+		}))
+
+		_, err := blobStorage.WriteBLOB(context.Background(), iblobstorage.PersistentBLOBKeyType{
+			ClusterAppID: istructs.ClusterAppID_sys_blobber,
+			WSID:         srcWSID,
+			BlobID:       blobID,
+		}, iblobstorage.DescrType{Name: "test.txt", ContentType: "text/plain"}, bytes.NewReader(blobData), noLimit)
+		require.NoError(err)
+	})
+
+	archive := bytes.Buffer{}
+	t.Run("export", func(t *testing.T) {
+		m, err := Export(context.Background(), ExportParams{AppStructs: as, WSID: srcWSID, BLOBStorage: blobStorage}, &archive)
+		require.NoError(err)
+		require.Equal(Manifest{
+			Version:        ArchiveVersion,
+			AppQName:       istructs.AppQName_test1_app1,
+			WSID:           srcWSID,
+			Events:         4,
+			Records:        4,
+			ViewRecords:    1,
+			BLOBs:          1,
+			LastWLogOffset: 4,
+			LastRecordID:   orderItemID,
+			SkippedViews:   []appdef.QName{qNameAsyncView},
+		}, m)
+	})
+
+	// another workspace event takes the first offset of the target partition
+	otherEvent := as.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+		GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+			HandlingPartition: dstPartition,
+			PLogOffset:        istructs.FirstOffset,
+			Workspace:         dstWSID + 1,
+			WLogOffset:        istructs.FirstOffset,
+			QName:             istructs.QNameCommandCUD,
+		},
+	})
+	otherWDoc := otherEvent.CUDBuilder().Create(qNameWDoc)
+	otherWDoc.PutRecordID(appdef.SystemField_ID, istructs.FirstUserRecordID)
+	rawEvent, err := otherEvent.BuildRawEvent()
+	require.NoError(err)
+	_, err = as.Events().PutPlog(rawEvent, nil, istructsmem.NewIDGenerator())
+	require.NoError(err)
+
+	seqStorage := &testSeqStorage{}
+	importParams := ImportParams{
+		AppStructs:   as,
+		WSID:         dstWSID,
+		Partition:    dstPartition,
+		BLOBStorage:  blobStorage,
+		SeqStorage:   seqStorage,
+		ClusterAppID: istructs.ClusterAppID_test1_app1,
+	}
+
+	t.Run("import", func(t *testing.T) {
+		m, err := Import(context.Background(), importParams, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.NoError(err)
+		require.Equal(4, m.Events)
+
+		require.Equal([]isequencer.SeqValue{
+			{Key: isequencer.NumberKey{WSID: isequencer.WSID(dstWSID), SeqID: isequencer.SeqID(istructs.QNameIDWLogOffsetSequence)}, Value: 4},
+			{Key: isequencer.NumberKey{WSID: isequencer.WSID(dstWSID), SeqID: isequencer.SeqID(istructs.QNameIDRecordIDSequence)}, Value: isequencer.Number(orderItemID)},
+		}, seqStorage.numbers)
+	})
+
+	t.Run("check the target workspace", func(t *testing.T) {
+		t.Run("events", func(t *testing.T) {
+			qNames := []appdef.QName{}
+			err := as.Events().ReadWLog(context.Background(), dstWSID, istructs.FirstOffset, istructs.ReadToTheEnd, func(offset istructs.Offset, event istructs.IWLogEvent) error {
+				require.Equal(istructs.UnixMilli(offset), event.RegisteredAt())
+				qNames = append(qNames, event.QName())
+				return nil
+			})
+			require.NoError(err)
+			require.Equal([]appdef.QName{istructs.QNameCommandCUD, istructs.QNameCommandCUD, qNameCmdOrder, istructs.QNameForError}, qNames)
+
+			plogOffsets := []istructs.Offset{}
+			err = as.Events().ReadPLog(context.Background(), dstPartition, istructs.FirstOffset, istructs.ReadToTheEnd, func(offset istructs.Offset, event istructs.IPLogEvent) error {
+				if event.Workspace() == dstWSID {
+					plogOffsets = append(plogOffsets, offset)
+				}
+				return nil
+			})
+			require.NoError(err)
+			require.Equal([]istructs.Offset{2, 3, 4, 5}, plogOffsets)
+		})
+
+		t.Run("records", func(t *testing.T) {
+			doc, err := as.Records().Get(dstWSID, true, docID)
+			require.NoError(err)
+			require.Equal("doc2", doc.AsString("name"))
+			require.Equal("test@test.com", doc.AsString("email"))
+
+			rec, err := as.Records().Get(dstWSID, true, recID)
+			require.NoError(err)
+			require.Equal(docID, rec.Parent())
+			require.EqualValues(42, rec.AsInt32("number"))
+
+			wdoc, err := as.Records().Get(dstWSID, true, wdocID)
+			require.NoError(err)
+			require.Equal("unlogged", wdoc.AsString("value"))
+
+			err = as.Events().ReadWLog(context.Background(), dstWSID, 3, 1, func(_ istructs.Offset, event istructs.IWLogEvent) error {
+				order := event.ArgumentObject()
+				require.Equal(orderID, order.AsRecordID(appdef.SystemField_ID))
+				for item := range order.Children("items") {
+					require.Equal(orderItemID, item.AsRecordID(appdef.SystemField_ID))
+					require.Equal("item", item.AsString("name"))
+				}
+				return nil
+			})
+			require.NoError(err)
+		})
+
+		t.Run("views", func(t *testing.T) {
+			kb := as.ViewRecords().KeyBuilder(qNameView)
+			kb.PutInt32("pk", 1)
+			kb.PutString("cc", "a")
+			v, err := as.ViewRecords().Get(dstWSID, kb)
+			require.NoError(err)
+			require.EqualValues(100, v.AsInt64("value"))
+
+			kb = as.ViewRecords().KeyBuilder(qNameAsyncView)
+			kb.PutInt32("pk", 1)
+			kb.PutInt32("cc", 2)
+			_, err = as.ViewRecords().Get(dstWSID, kb)
+			require.ErrorIs(err, istructs.ErrRecordNotFound)
+		})
+
+		t.Run("BLOBs", func(t *testing.T) {
+			data := bytes.Buffer{}
+			descr := iblobstorage.DescrType{}
+			err := blobStorage.ReadBLOB(context.Background(), &iblobstorage.PersistentBLOBKeyType{
+				ClusterAppID: istructs.ClusterAppID_sys_blobber,
+				WSID:         dstWSID,
+				BlobID:       blobID,
+			}, func(state iblobstorage.BLOBState) error {
+				descr = state.Descr
+				return nil
+			}, &data, noLimit)
+			require.NoError(err)
+			require.Equal(blobData, data.Bytes())
+			require.Equal("test.txt", descr.Name)
+		})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Run("target workspace is not empty", func(t *testing.T) {
+			_, err := Import(context.Background(), importParams, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+			require.ErrorIs(err, ErrWorkspaceNotEmpty)
+		})
+
+		t.Run("not an archive", func(t *testing.T) {
+			_, err := Import(context.Background(), importParams, bytes.NewReader([]byte("test")), 4)
+			require.Error(err)
+		})
+	})
+}
+
+func TestRestore(t *testing.T) {
+	require := require.New(t)
+	as, blobStorage := newTestApp(t)
+
+	docID := istructs.FirstUserRecordID
+	recID := docID + 1
+	wdocID := docID + 2
+	newWDocID := docID + 3
+
+	plog := istructs.FirstOffset
+	put := func(fill func(cud istructs.ICUD)) {
+		reb := as.Events().GetSyncRawEventBuilder(istructs.SyncRawEventBuilderParams{
+			GenericRawEventBuilderParams: istructs.GenericRawEventBuilderParams{
+				HandlingPartition: srcPartition,
+				PLogOffset:        plog,
+				Workspace:         srcWSID,
+				WLogOffset:        plog,
+				QName:             istructs.QNameCommandCUD,
+			},
+		})
+		fill(reb.CUDBuilder())
+		rawEvent, err := reb.BuildRawEvent()
+		require.NoError(err)
+		plogEvent, err := as.Events().PutPlog(rawEvent, nil, istructsmem.NewIDGenerator())
+		require.NoError(err)
+		require.NoError(as.Records().Apply(plogEvent))
+		require.NoError(as.Events().PutWlog(plogEvent))
+		plogEvent.Release()
+		plog++
+	}
+	get := func(id istructs.RecordID) istructs.IRecord {
+		rec, err := as.Records().Get(srcWSID, true, id)
+		require.NoError(err)
+		return rec
+	}
+
+	put(func(cud istructs.ICUD) {
+		doc := cud.Create(qNameDoc)
+		doc.PutRecordID(appdef.SystemField_ID, docID)
+		doc.PutString("name", "doc")
+		doc.PutString("email", verifiedEmailToken(t, as, qNameDoc, "test@test.com"))
+		rec := cud.Create(qNameRec)
+		rec.PutRecordID(appdef.SystemField_ID, recID)
+		rec.PutRecordID(appdef.SystemField_ParentID, docID)
+		rec.PutString(appdef.SystemField_Container, "recs")
+		wdoc := cud.Create(qNameWDoc)
+		wdoc.PutRecordID(appdef.SystemField_ID, wdocID)
+		wdoc.PutString("value", "value")
+	})
+
+	archive := bytes.Buffer{}
+	_, err := Export(context.Background(), ExportParams{AppStructs: as, WSID: srcWSID, BLOBStorage: blobStorage}, &archive)
+	require.NoError(err)
+
+	// changes made after the export
+	put(func(cud istructs.ICUD) {
+		cud.Update(get(docID)).PutString("name", "changed")
+		cud.Update(get(recID)).PutInt32("number", 42)
+		cud.Update(get(wdocID)).PutBool(appdef.SystemField_IsActive, false)
+		newWDoc := cud.Create(qNameWDoc)
+		newWDoc.PutRecordID(appdef.SystemField_ID, newWDocID)
+	})
+
+	sent := [][]CUD{}
+	params := RestoreParams{
+		AppStructs:  as,
+		WSID:        srcWSID,
+		BLOBStorage: blobStorage,
+		SendCUDs: func(_ context.Context, cuds []CUD) error {
+			sent = append(sent, cuds)
+			return nil
+		},
+	}
+	t.Run("dry run", func(t *testing.T) {
+		dryRun := params
+		dryRun.DryRun = true
+		res, err := Restore(context.Background(), dryRun, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.NoError(err)
+		require.Equal(RestoreResult{Events: 3, Records: 4}, res)
+		require.Empty(sent)
+	})
+
+	res, err := Restore(context.Background(), params, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(err)
+	require.Equal(RestoreResult{Events: 3, Records: 4}, res)
+	require.Equal([][]CUD{
+		{{ID: newWDocID, Fields: map[string]any{appdef.SystemField_IsActive: false}}},
+		{{ID: wdocID, Fields: map[string]any{appdef.SystemField_IsActive: true}}},
+		{{ID: docID, Fields: map[string]any{"name": "doc"}}, {ID: recID, Fields: map[string]any{"number": json.Number("0")}}},
+	}, sent)
+
+	t.Run("nothing to restore after the restore", func(t *testing.T) {
+		params.SendCUDs = func(_ context.Context, cuds []CUD) error {
+			put(func(cud istructs.ICUD) {
+				for _, c := range cuds {
+					upd := cud.Update(get(c.ID))
+					for name, value := range c.Fields {
+						switch v := value.(type) {
+						case bool:
+							upd.PutBool(name, v)
+						case string:
+							upd.PutString(name, v)
+						case json.Number:
+							upd.PutNumber(name, v)
+						}
+					}
+				}
+			})
+			return nil
+		}
+		_, err := Restore(context.Background(), params, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.NoError(err)
+		require.Equal("doc", get(docID).AsString("name"))
+		require.False(get(newWDocID).AsBool(appdef.SystemField_IsActive))
+
+		res, err := Restore(context.Background(), params, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.NoError(err)
+		require.Zero(res)
+	})
+
+	t.Run("ErrWorkspaceMismatch", func(t *testing.T) {
+		params.WSID = dstWSID
+		_, err := Restore(context.Background(), params, bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.ErrorIs(err, ErrWorkspaceMismatch)
+	})
+}
+
+func newTestApp(t *testing.T) (istructs.IAppStructs, iblobstorage.IBLOBStorage) {
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+	wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+	wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+
+	doc := wsb.AddCDoc(qNameDoc)
+	doc.AddField("name", appdef.DataKind_string, true).
+		AddField("email", appdef.DataKind_string, false).
+		SetFieldVerify("email", appdef.VerificationKind_EMail)
+	doc.AddContainer("recs", qNameRec, 0, appdef.Occurs_Unbounded)
+	wsb.AddCRecord(qNameRec).AddField("number", appdef.DataKind_int32, false)
+	wsb.AddWDoc(qNameWDoc).AddField("value", appdef.DataKind_string, false)
+	wsb.AddWDoc(blobber.QNameWDocBLOB).AddField("status", appdef.DataKind_int32, true)
+
+	order := wsb.AddODoc(qNameOrder)
+	order.AddField("number", appdef.DataKind_int32, true)
+	order.AddContainer("items", qNameOrderItem, 0, appdef.Occurs_Unbounded)
+	wsb.AddORecord(qNameOrderItem).AddField("name", appdef.DataKind_string, true)
+	wsb.AddCommand(qNameCmdOrder).SetParam(qNameOrder)
+
+	view := wsb.AddView(qNameView)
+	view.Key().PartKey().AddField("pk", appdef.DataKind_int32)
+	view.Key().ClustCols().AddField("cc", appdef.DataKind_string)
+	view.Value().AddField("value", appdef.DataKind_int64, true)
+
+	asyncView := wsb.AddView(qNameAsyncView)
+	asyncView.Key().PartKey().AddField("pk", appdef.DataKind_int32)
+	asyncView.Key().ClustCols().AddField("cc", appdef.DataKind_int32)
+	asyncView.Value().AddField("value", appdef.DataKind_int64, true)
+
+	prj := wsb.AddProjector(appdef.NewQName("test", "asyncProjector"))
+	prj.Events().Add([]appdef.OperationKind{appdef.OperationKind_Execute}, filter.QNames(qNameCmdOrder))
+	prj.Intents().Add(sys.Storage_View, qNameAsyncView)
+
+	cfgs := make(istructsmem.AppConfigsType, 1)
+	cfg := cfgs.AddBuiltInAppConfig(istructs.AppQName_test1_app1, adb)
+	cfg.SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+
+	storageProvider := provider.Provide(mem.Provide(testingu.MockTime))
+	asp := istructsmem.Provide(cfgs, iratesce.TestBucketsFactory,
+		payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()), storageProvider, isequencer.SequencesTrustLevel_0)
+	as, err := asp.BuiltIn(istructs.AppQName_test1_app1)
+	require.NoError(t, err)
+
+	blobAppStorage, err := storageProvider.AppStorage(istructs.AppQName_sys_blobber)
+	require.NoError(t, err)
+	blobStorage := iblobstoragestg.Provide(&blobAppStorage, testingu.MockTime)
+	return as, blobStorage
+}
+
+func verifiedEmailToken(t *testing.T, as istructs.IAppStructs, entity appdef.QName, email string) string {
+	token, err := as.AppTokens().IssueToken(verifiedValueTokenDuration, &payloads.VerifiedValuePayload{
+		VerificationKind: appdef.VerificationKind_EMail,
+		Entity:           entity,
+		Field:            "email",
+		Value:            email,
+	})
+	require.NoError(t, err)
+	return token
+}
+
+type testSeqStorage struct {
+	isequencer.IVVMSeqStorageAdapter
+	numbers []isequencer.SeqValue
+}
+
+func (s *testSeqStorage) PutNumbers(_ isequencer.ClusterAppID, batch []isequencer.SeqValue) error {
+	s.numbers = append(s.numbers, batch...)
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package wsbackup
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type ExportParams struct {
+	AppStructs istructs.IAppStructs
+	WSID       istructs.WSID
+
+	// BLOBs are not exported if nil
+	BLOBStorage iblobstorage.IBLOBStorage
+}
+
+type ImportParams struct {
+	AppStructs istructs.IAppStructs
+
+	// target workspace, must have no events
+	WSID istructs.WSID
+
+	// partition the target workspace belongs to
+	Partition istructs.PartitionID
+
+	// the first PLog offset to write the events to
+	// the end of the partition PLog is found if NullOffset
+	PLogOffset istructs.Offset
+
+	// BLOBs are not imported if nil
+	BLOBStorage iblobstorage.IBLOBStorage
+
+	// sequences of the target workspace are not written if nil
+	// the sequencer then actualizes them from the PLog on the partition recovery
+	SeqStorage   isequencer.IVVMSeqStorageAdapter
+	ClusterAppID istructs.ClusterAppID
+}
+
+type RestoreParams struct {
+	AppStructs istructs.IAppStructs
+
+	// workspace the archive is exported from
+	WSID istructs.WSID
+
+	// missing BLOBs are not restored if nil
+	BLOBStorage iblobstorage.IBLOBStorage
+
+	// sends CUDs to the workspace through the command processor, e.g. by c.sys.CUD
+	// at most builtin.MaxCUDs CUDs are sent at once
+	SendCUDs func(ctx context.Context, cuds []CUD) error
+
+	// changes are counted but not made, e.g. to validate the archive before the restore
+	DryRun bool
+}
+
+// update of the existing record, marshaled as the c.sys.CUD argument
+type CUD struct {
+	ID     istructs.RecordID `json:"sys.ID"`
+	Fields map[string]any    `json:"fields"`
+}
+
+type RestoreResult struct {
+	// CUDs batches sent, or to be sent on the dry run
+	Events int
+
+	// records updated, activated or deactivated
+	Records int
+
+	// missing BLOBs written
+	BLOBs int
+}
+
+// stored as manifest.json
+type Manifest struct {
+	Version  int
+	AppQName appdef.AppQName
+
+	// source workspace
+	WSID istructs.WSID
+
+	Events      int
+	Records     int
+	ViewRecords int
+	BLOBs       int
+
+	// sequences of the source workspace
+	LastWLogOffset istructs.Offset
+	LastRecordID   istructs.RecordID
+
+	// views that are not exported because they are built by async projectors from the events
+	SkippedViews []appdef.QName `json:",omitempty"`
+}
+
+// line of wlog.jsonl
+type eventRow struct {
+	WLogOffset   istructs.Offset
+	QName        appdef.QName
+	RegisteredAt istructs.UnixMilli
+	Synced       bool                       `json:",omitempty"`
+	DeviceID     istructs.ConnectedDeviceID `json:",omitempty"`
+	SyncedAt     istructs.UnixMilli         `json:",omitempty"`
+
+	// not empty if the event is stored with error
+	Error string `json:",omitempty"`
+
+	Argument map[string]any `json:",omitempty"`
+	CUDs     []cudRow       `json:",omitempty"`
+}
+
+type cudRow struct {
+	IsNew  bool `json:",omitempty"`
+	Fields map[string]any
+}
+
+// line of blobs.jsonl, data is stored in blobs/<BlobID> entry
+type blobRow struct {
+	BlobID istructs.RecordID
+	Descr  iblobstorage.DescrType
+}